@@ -0,0 +1,396 @@
+// Package auth restricts handlers to admins.
+//
+// A Guard decides the role of the user behind an update: bot admins are
+// listed by ID, and chat admins can be read from getChatAdministrators
+// (cached per chat). Handlers wrapped with AdminOnly only run for admins;
+// everyone else gets a "not authorized" reply:
+//
+//	guard := auth.NewGuard(
+//	    auth.WithAdmins(42, 1337),
+//	    auth.WithChatAdmins(bot, 5*time.Minute),
+//	    auth.WithDeniedReply(bot.Sender(), "Only admins can do that."),
+//	)
+//	ban := guard.AdminOnly(func(ctx context.Context, update tg.Update) error {
+//	    ...
+//	})
+//	for update := range bot.Updates() {
+//	    ctx := bot.UpdateContext(update)
+//	    if cmd, ok := tg.ParseCommand(update.Message); ok && cmd.Name == "ban" {
+//	        _ = ban(ctx, update)
+//	    }
+//	}
+//
+// Guard.Decorator makes the role available from the update context, so
+// handlers can also branch on it with RoleFrom. It is resolved on first
+// use, so updates no handler checks cost no lookups.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ErrNotAuthorized is returned by Guard.Check for callers who are not
+// admins.
+var ErrNotAuthorized = errors.New("galigo: not authorized")
+
+// DefaultCacheTTL is how long chat admin lists are cached when
+// WithChatAdmins is given ttl <= 0.
+const DefaultCacheTTL = 5 * time.Minute
+
+// DefaultDeniedText is the reply sent to callers who are not admins.
+const DefaultDeniedText = "You are not authorized to use this command."
+
+// Role is the caller's standing, from least to most privileged.
+type Role int
+
+const (
+	RoleUser      Role = iota // not an admin
+	RoleChatAdmin             // administrator or owner of the update's chat
+	RoleAdmin                 // bot admin, listed in WithAdmins
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleChatAdmin:
+		return "chat_admin"
+	case RoleAdmin:
+		return "admin"
+	}
+	return "user"
+}
+
+// IsAdmin reports whether r is RoleChatAdmin or RoleAdmin.
+func (r Role) IsAdmin() bool { return r >= RoleChatAdmin }
+
+// Handler processes one update, e.g. a command.
+type Handler func(ctx context.Context, update tg.Update) error
+
+// AdminLister lists a chat's administrators. *sender.Client and
+// *galigo.Bot implement it.
+type AdminLister interface {
+	GetChatAdministrators(ctx context.Context, chatID tg.ChatID) ([]tg.ChatMember, error)
+}
+
+// Replier sends the "not authorized" reply. *sender.Client implements it.
+type Replier interface {
+	SendMessage(ctx context.Context, req sender.SendMessageRequest) (*tg.Message, error)
+	AnswerCallbackQuery(ctx context.Context, req sender.AnswerCallbackQueryRequest) error
+}
+
+type config struct {
+	admins     map[int64]bool
+	lister     AdminLister
+	ttl        time.Duration
+	replier    Replier
+	deniedText string
+}
+
+// Option configures a Guard.
+type Option func(*config)
+
+// WithAdmins adds bot admins by user ID. They are admins in every chat.
+func WithAdmins(userIDs ...int64) Option {
+	return func(c *config) {
+		for _, id := range userIDs {
+			c.admins[id] = true
+		}
+	}
+}
+
+// WithChatAdmins makes the administrators of group and channel chats
+// RoleChatAdmin, read with api and cached per chat for ttl (<= 0 =
+// DefaultCacheTTL). Promotions and demotions seen in chat_member updates
+// invalidate the chat's entry.
+func WithChatAdmins(api AdminLister, ttl time.Duration) Option {
+	return func(c *config) {
+		c.lister = api
+		if ttl <= 0 {
+			ttl = DefaultCacheTTL
+		}
+		c.ttl = ttl
+	}
+}
+
+// WithDeniedReply sends text (DefaultDeniedText if empty) to callers who
+// are not admins: as a reply to their message, or as an alert for
+// callback queries. Without it, denied updates are dropped silently.
+func WithDeniedReply(api Replier, text string) Option {
+	return func(c *config) {
+		c.replier = api
+		if text != "" {
+			c.deniedText = text
+		}
+	}
+}
+
+// Guard resolves callers' roles and gates handlers. It is safe for
+// concurrent use.
+type Guard struct {
+	cfg config
+
+	mu       sync.Mutex
+	cache    map[int64]adminEntry  // by chat ID
+	inflight map[int64]*adminFetch // by chat ID
+}
+
+type adminEntry struct {
+	ids     map[int64]bool
+	expires time.Time
+}
+
+// adminFetch is a getChatAdministrators call shared by every caller
+// asking for the chat while it runs.
+type adminFetch struct {
+	done chan struct{}
+	ids  map[int64]bool
+	err  error
+}
+
+// NewGuard creates a Guard. Without WithAdmins or WithChatAdmins nobody
+// is an admin.
+func NewGuard(opts ...Option) *Guard {
+	cfg := config{
+		admins:     make(map[int64]bool),
+		deniedText: DefaultDeniedText,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Guard{cfg: cfg, cache: make(map[int64]adminEntry), inflight: make(map[int64]*adminFetch)}
+}
+
+// Role returns the role of the user behind update. Chat admin lists are
+// only fetched for group and channel chats, and only if the user is not a
+// bot admin already. Updates without a user are RoleUser.
+func (g *Guard) Role(ctx context.Context, update tg.Update) (Role, error) {
+	g.observe(update)
+	return g.role(ctx, update)
+}
+
+func (g *Guard) role(ctx context.Context, update tg.Update) (Role, error) {
+	user := update.EffectiveUser()
+	if user == nil {
+		return RoleUser, nil
+	}
+	if g.cfg.admins[user.ID] {
+		return RoleAdmin, nil
+	}
+	chat := update.EffectiveChat()
+	if g.cfg.lister == nil || chat == nil || chat.Type == "private" {
+		return RoleUser, nil
+	}
+	ids, err := g.chatAdmins(ctx, chat.ID)
+	if err != nil {
+		return RoleUser, fmt.Errorf("auth: chat %d admins: %w", chat.ID, err)
+	}
+	if ids[user.ID] {
+		return RoleChatAdmin, nil
+	}
+	return RoleUser, nil
+}
+
+// Invalidate drops the cached admin list of chatID.
+func (g *Guard) Invalidate(chatID int64) {
+	g.mu.Lock()
+	delete(g.cache, chatID)
+	g.mu.Unlock()
+}
+
+// observe invalidates the cached admins of a chat when update promotes or
+// demotes one of its members.
+func (g *Guard) observe(update tg.Update) {
+	if m := update.ChatMember; m != nil && m.Chat != nil && tg.IsAdmin(m.OldChatMember) != tg.IsAdmin(m.NewChatMember) {
+		g.Invalidate(m.Chat.ID)
+	}
+}
+
+// chatAdmins returns the admin IDs of chatID from the cache, or fetches
+// them. Concurrent callers for the same chat share one fetch; if it ends
+// with the fetching caller's context error, the others fetch again.
+func (g *Guard) chatAdmins(ctx context.Context, chatID int64) (map[int64]bool, error) {
+	for {
+		now := time.Now()
+		g.mu.Lock()
+		if entry, ok := g.cache[chatID]; ok && now.Before(entry.expires) {
+			g.mu.Unlock()
+			return entry.ids, nil
+		}
+		f, busy := g.inflight[chatID]
+		if !busy {
+			f = &adminFetch{done: make(chan struct{})}
+			g.inflight[chatID] = f
+			g.mu.Unlock()
+			g.fetch(ctx, chatID, f)
+			return f.ids, f.err
+		}
+		g.mu.Unlock()
+
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if f.err == nil || !isContextErr(f.err) {
+			return f.ids, f.err
+		}
+	}
+}
+
+func (g *Guard) fetch(ctx context.Context, chatID int64, f *adminFetch) {
+	members, err := g.cfg.lister.GetChatAdministrators(ctx, tg.ChatID(chatID))
+	if err == nil {
+		f.ids = make(map[int64]bool, len(members))
+		for _, m := range members {
+			if u := m.GetUser(); u != nil {
+				f.ids[u.ID] = true
+			}
+		}
+	}
+	f.err = err
+
+	g.mu.Lock()
+	if err == nil {
+		g.cache[chatID] = adminEntry{ids: f.ids, expires: time.Now().Add(g.cfg.ttl)}
+	}
+	delete(g.inflight, chatID)
+	g.mu.Unlock()
+	close(f.done)
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// Decorator returns a receiver.ContextDecorator that makes the caller's
+// role available in the update context, for use with
+// galigo.WithContextDecorator. Retrieve it with RoleFrom. The role is
+// resolved on first use and then kept; a failed lookup is retried on the
+// next use.
+func (g *Guard) Decorator() receiver.ContextDecorator {
+	return func(ctx context.Context, update tg.Update) context.Context {
+		g.observe(update)
+		return context.WithValue(ctx, contextKey{}, &lazyRole{guard: g, update: update})
+	}
+}
+
+// lazyRole is the role stored by Decorator, resolved on first use.
+type lazyRole struct {
+	guard  *Guard
+	update tg.Update
+
+	mu       sync.Mutex
+	resolved bool
+	role     Role
+}
+
+func (l *lazyRole) get(ctx context.Context) (Role, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.resolved {
+		return l.role, nil
+	}
+	role, err := l.guard.role(ctx, l.update)
+	if err != nil {
+		return RoleUser, err
+	}
+	l.role, l.resolved = role, true
+	return role, nil
+}
+
+// Check returns nil if the caller is an admin. Otherwise it sends the
+// denied reply (see WithDeniedReply) and returns ErrNotAuthorized, joined
+// with the reply's error if sending failed. The role is taken from ctx if
+// Decorator or NewContext stored one.
+func (g *Guard) Check(ctx context.Context, update tg.Update) error {
+	var role Role
+	var err error
+	switch v := ctx.Value(contextKey{}).(type) {
+	case Role:
+		role = v
+	case *lazyRole:
+		role, err = v.get(ctx)
+	default:
+		role, err = g.Role(ctx, update)
+	}
+	if err != nil {
+		return err
+	}
+	if role.IsAdmin() {
+		return nil
+	}
+	if err := g.deny(ctx, update); err != nil {
+		return errors.Join(ErrNotAuthorized, err)
+	}
+	return ErrNotAuthorized
+}
+
+// AdminOnly wraps next so it only runs for admins. For denied callers the
+// wrapped handler sends the denied reply, skips next and returns nil, or
+// the reply's error joined with ErrNotAuthorized. Role lookup errors are
+// returned as they are.
+func (g *Guard) AdminOnly(next Handler) Handler {
+	return func(ctx context.Context, update tg.Update) error {
+		err := g.Check(ctx, update)
+		switch {
+		case err == nil:
+			return next(ctx, update)
+		case err == ErrNotAuthorized:
+			return nil
+		}
+		return err
+	}
+}
+
+func (g *Guard) deny(ctx context.Context, update tg.Update) error {
+	if g.cfg.replier == nil {
+		return nil
+	}
+	if cb := update.CallbackQuery; cb != nil {
+		return g.cfg.replier.AnswerCallbackQuery(ctx, sender.AnswerCallbackQueryRequest{
+			CallbackQueryID: cb.ID,
+			Text:            g.cfg.deniedText,
+			ShowAlert:       true,
+		})
+	}
+	msg := update.EffectiveMessage()
+	if msg == nil || msg.Chat == nil {
+		return nil
+	}
+	_, err := g.cfg.replier.SendMessage(ctx, sender.SendMessageRequest{
+		BusinessConnectionID: msg.BusinessConnectionID,
+		ChatID:               tg.ChatID(msg.Chat.ID),
+		MessageThreadID:      msg.MessageThreadID,
+		Text:                 g.cfg.deniedText,
+		ReplyParameters:      &tg.ReplyParameters{MessageID: msg.MessageID, AllowSendingWithoutReply: true},
+	})
+	return err
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying role.
+func NewContext(ctx context.Context, role Role) context.Context {
+	return context.WithValue(ctx, contextKey{}, role)
+}
+
+// RoleFrom returns the role stored in ctx by Decorator or NewContext. A
+// role from Decorator is resolved here on first use, with ctx; if that
+// fails, RoleFrom reports false.
+func RoleFrom(ctx context.Context) (Role, bool) {
+	switch v := ctx.Value(contextKey{}).(type) {
+	case Role:
+		return v, true
+	case *lazyRole:
+		role, err := v.get(ctx)
+		return role, err == nil
+	}
+	return RoleUser, false
+}
@@ -0,0 +1,254 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/auth"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+type fakeAPI struct {
+	mu       sync.Mutex
+	admins   map[int64][]int64 // chat ID -> admin user IDs
+	listErr  error
+	lists    int
+	sent     []sender.SendMessageRequest
+	answered []sender.AnswerCallbackQueryRequest
+}
+
+func (f *fakeAPI) GetChatAdministrators(_ context.Context, chatID tg.ChatID) ([]tg.ChatMember, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lists++
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	var members []tg.ChatMember
+	for _, id := range f.admins[chatID.(int64)] {
+		members = append(members, member("administrator", id))
+	}
+	return members, nil
+}
+
+func (f *fakeAPI) SendMessage(_ context.Context, req sender.SendMessageRequest) (*tg.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, req)
+	return &tg.Message{MessageID: 1}, nil
+}
+
+func (f *fakeAPI) AnswerCallbackQuery(_ context.Context, req sender.AnswerCallbackQueryRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.answered = append(f.answered, req)
+	return nil
+}
+
+func member(status string, userID int64) tg.ChatMember {
+	m, err := tg.UnmarshalChatMember([]byte(fmt.Sprintf(`{"status":%q,"user":{"id":%d}}`, status, userID)))
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func groupMessage(chatID, userID int64) tg.Update {
+	return tg.Update{Message: &tg.Message{
+		MessageID: 7,
+		Chat:      &tg.Chat{ID: chatID, Type: "supergroup"},
+		From:      &tg.User{ID: userID},
+		Text:      "/ban 42",
+	}}
+}
+
+func TestGuard_Roles(t *testing.T) {
+	api := &fakeAPI{admins: map[int64][]int64{-100: {5}}}
+	guard := auth.NewGuard(auth.WithAdmins(1), auth.WithChatAdmins(api, time.Minute))
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		update tg.Update
+		want   auth.Role
+	}{
+		{"bot admin", groupMessage(-100, 1), auth.RoleAdmin},
+		{"chat admin", groupMessage(-100, 5), auth.RoleChatAdmin},
+		{"member", groupMessage(-100, 9), auth.RoleUser},
+		{"chat admin elsewhere", groupMessage(-200, 5), auth.RoleUser},
+		{"private chat", tg.Update{Message: &tg.Message{Chat: &tg.Chat{ID: 5, Type: "private"}, From: &tg.User{ID: 5}}}, auth.RoleUser},
+		{"no user", tg.Update{Poll: &tg.Poll{ID: "p"}}, auth.RoleUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, err := guard.Role(ctx, tt.update)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, role)
+		})
+	}
+	assert.Equal(t, "chat_admin", auth.RoleChatAdmin.String())
+	assert.True(t, auth.RoleAdmin.IsAdmin())
+	assert.False(t, auth.RoleUser.IsAdmin())
+}
+
+func TestGuard_CachesChatAdmins(t *testing.T) {
+	api := &fakeAPI{admins: map[int64][]int64{-100: {5}}}
+	guard := auth.NewGuard(auth.WithChatAdmins(api, time.Minute))
+	ctx := context.Background()
+
+	for range 3 {
+		_, err := guard.Role(ctx, groupMessage(-100, 9))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, api.lists)
+
+	guard.Invalidate(-100)
+	_, _ = guard.Role(ctx, groupMessage(-100, 9))
+	assert.Equal(t, 2, api.lists)
+}
+
+func TestGuard_CacheExpires(t *testing.T) {
+	api := &fakeAPI{admins: map[int64][]int64{-100: {5}}}
+	guard := auth.NewGuard(auth.WithChatAdmins(api, time.Millisecond))
+	ctx := context.Background()
+
+	_, _ = guard.Role(ctx, groupMessage(-100, 9))
+	time.Sleep(5 * time.Millisecond)
+	_, _ = guard.Role(ctx, groupMessage(-100, 9))
+	assert.Equal(t, 2, api.lists)
+}
+
+func TestGuard_PromotionInvalidatesCache(t *testing.T) {
+	api := &fakeAPI{admins: map[int64][]int64{-100: {}}}
+	guard := auth.NewGuard(auth.WithChatAdmins(api, time.Hour))
+	ctx := context.Background()
+
+	role, _ := guard.Role(ctx, groupMessage(-100, 9))
+	assert.Equal(t, auth.RoleUser, role)
+
+	api.admins[-100] = []int64{9}
+	_, _ = guard.Role(ctx, tg.Update{ChatMember: &tg.ChatMemberUpdated{
+		Chat:          &tg.Chat{ID: -100, Type: "supergroup"},
+		From:          &tg.User{ID: 1},
+		OldChatMember: member("member", 9),
+		NewChatMember: member("administrator", 9),
+	}})
+
+	role, _ = guard.Role(ctx, groupMessage(-100, 9))
+	assert.Equal(t, auth.RoleChatAdmin, role)
+}
+
+func TestGuard_AdminOnly(t *testing.T) {
+	api := &fakeAPI{}
+	guard := auth.NewGuard(auth.WithAdmins(1), auth.WithDeniedReply(api, "Admins only."))
+	ctx := context.Background()
+
+	var ran []int64
+	handler := guard.AdminOnly(func(_ context.Context, update tg.Update) error {
+		ran = append(ran, update.Message.From.ID)
+		return nil
+	})
+
+	require.NoError(t, handler(ctx, groupMessage(-100, 1)))
+	require.NoError(t, handler(ctx, groupMessage(-100, 9)))
+
+	assert.Equal(t, []int64{1}, ran)
+	require.Len(t, api.sent, 1)
+	assert.Equal(t, "Admins only.", api.sent[0].Text)
+	assert.Equal(t, int64(-100), api.sent[0].ChatID)
+	assert.Equal(t, 7, api.sent[0].ReplyParameters.MessageID)
+}
+
+func TestGuard_DeniedCallbackGetsAlert(t *testing.T) {
+	api := &fakeAPI{}
+	guard := auth.NewGuard(auth.WithDeniedReply(api, ""))
+
+	err := guard.Check(context.Background(), tg.Update{CallbackQuery: &tg.CallbackQuery{
+		ID:   "cb1",
+		From: &tg.User{ID: 9},
+	}})
+
+	assert.ErrorIs(t, err, auth.ErrNotAuthorized)
+	require.Len(t, api.answered, 1)
+	assert.Equal(t, "cb1", api.answered[0].CallbackQueryID)
+	assert.Equal(t, auth.DefaultDeniedText, api.answered[0].Text)
+	assert.True(t, api.answered[0].ShowAlert)
+	assert.Empty(t, api.sent)
+}
+
+func TestGuard_DecoratorStoresRole(t *testing.T) {
+	api := &fakeAPI{admins: map[int64][]int64{-100: {5}}}
+	guard := auth.NewGuard(auth.WithChatAdmins(api, time.Minute))
+
+	ctx := guard.Decorator()(context.Background(), groupMessage(-100, 5))
+	assert.Equal(t, 0, api.lists, "the role is resolved lazily")
+	role, ok := auth.RoleFrom(ctx)
+	require.True(t, ok)
+	assert.Equal(t, auth.RoleChatAdmin, role)
+
+	// Check uses the stored role without another lookup.
+	assert.NoError(t, guard.Check(ctx, groupMessage(-100, 5)))
+	assert.Equal(t, 1, api.lists)
+}
+
+func TestGuard_LookupError(t *testing.T) {
+	boom := errors.New("boom")
+	api := &fakeAPI{listErr: boom}
+	guard := auth.NewGuard(auth.WithChatAdmins(api, time.Minute), auth.WithDeniedReply(api, ""))
+
+	ctx := guard.Decorator()(context.Background(), groupMessage(-100, 5))
+	_, ok := auth.RoleFrom(ctx)
+	assert.False(t, ok, "no role when the lookup fails")
+
+	called := false
+	err := guard.AdminOnly(func(context.Context, tg.Update) error {
+		called = true
+		return nil
+	})(ctx, groupMessage(-100, 5))
+
+	assert.ErrorIs(t, err, boom)
+	assert.False(t, called)
+	assert.Empty(t, api.sent, "no denied reply when the role is unknown")
+}
+
+// blockingAPI is a fakeAPI whose GetChatAdministrators waits for release.
+type blockingAPI struct {
+	*fakeAPI
+	release chan struct{}
+}
+
+func (b blockingAPI) GetChatAdministrators(ctx context.Context, chatID tg.ChatID) ([]tg.ChatMember, error) {
+	<-b.release
+	return b.fakeAPI.GetChatAdministrators(ctx, chatID)
+}
+
+func TestGuard_ConcurrentLookupsShareOneFetch(t *testing.T) {
+	api := blockingAPI{&fakeAPI{admins: map[int64][]int64{-100: {5}}}, make(chan struct{})}
+	guard := auth.NewGuard(auth.WithChatAdmins(api, time.Minute))
+
+	var wg sync.WaitGroup
+	roles := make([]auth.Role, 8)
+	for i := range roles {
+		wg.Go(func() {
+			role, err := guard.Role(context.Background(), groupMessage(-100, 5))
+			assert.NoError(t, err)
+			roles[i] = role
+		})
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(api.release)
+	wg.Wait()
+
+	assert.Equal(t, 1, api.lists)
+	for _, role := range roles {
+		assert.Equal(t, auth.RoleChatAdmin, role)
+	}
+}
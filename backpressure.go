@@ -0,0 +1,168 @@
+package galigo
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prilive-com/galigo/clock"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// BackpressureConfig tunes WithCoupledBackpressure. Zero fields use defaults.
+type BackpressureConfig struct {
+	// HighWater is the fill ratio of the updates buffer considered full (default 0.9).
+	HighWater float64
+	// LowWater is the fill ratio at which pressure is released (default 0.5).
+	LowWater float64
+	// Sustain is how long the buffer must stay above HighWater before
+	// bulk traffic is slowed (default 1s).
+	Sustain time.Duration
+	// MaxDelay bounds how long a single bulk request is held (default 5s).
+	MaxDelay time.Duration
+	// Interval is how often the buffer is sampled (default 100ms).
+	Interval time.Duration
+}
+
+func (c BackpressureConfig) withDefaults() BackpressureConfig {
+	if c.HighWater <= 0 || c.HighWater > 1 {
+		c.HighWater = 0.9
+	}
+	if c.LowWater <= 0 || c.LowWater >= c.HighWater {
+		c.LowWater = c.HighWater / 2
+	}
+	if c.Sustain <= 0 {
+		c.Sustain = time.Second
+	}
+	if c.MaxDelay <= 0 {
+		c.MaxDelay = 5 * time.Second
+	}
+	if c.Interval <= 0 {
+		c.Interval = 100 * time.Millisecond
+	}
+	return c
+}
+
+// WithCoupledBackpressure couples outgoing bulk traffic to receiver load.
+// When the updates buffer stays above HighWater for Sustain, requests made
+// with a sender.WithBulkPriority context are held (up to MaxDelay each)
+// until the buffer drains below LowWater, so interactive handling wins
+// under load. Requests without the bulk marker are never delayed.
+func WithCoupledBackpressure(cfg BackpressureConfig) Option {
+	return func(c *botConfig) {
+		cfg = cfg.withDefaults()
+		c.backpressure = &cfg
+	}
+}
+
+// backpressure samples the updates buffer and implements sender.BulkThrottle.
+type backpressure struct {
+	updates chan tg.Update
+	cfg     BackpressureConfig
+	clock   clock.Clock
+
+	pressured atomic.Bool
+	fullSince time.Time // monitor goroutine only
+
+	mu      sync.Mutex
+	release chan struct{} // closed when pressure clears; guarded by mu
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+func newBackpressure(updates chan tg.Update, cfg BackpressureConfig, clk clock.Clock) *backpressure {
+	return &backpressure{
+		updates: updates,
+		cfg:     cfg,
+		clock:   clk,
+		release: make(chan struct{}),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+func (p *backpressure) start() {
+	go func() {
+		defer close(p.done)
+		ticker := p.clock.NewTicker(p.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C():
+				p.sample(now)
+			case <-p.stop:
+				p.set(false)
+				return
+			}
+		}
+	}()
+}
+
+func (p *backpressure) close() {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+		<-p.done
+	})
+}
+
+// sample updates the pressure state from the current buffer fill ratio.
+func (p *backpressure) sample(now time.Time) {
+	capacity := cap(p.updates)
+	if capacity == 0 {
+		return
+	}
+	fill := float64(len(p.updates)) / float64(capacity)
+
+	switch {
+	case fill >= p.cfg.HighWater:
+		if p.fullSince.IsZero() {
+			p.fullSince = now
+		}
+		if now.Sub(p.fullSince) >= p.cfg.Sustain {
+			p.set(true)
+		}
+	case fill <= p.cfg.LowWater:
+		p.fullSince = time.Time{}
+		p.set(false)
+	default:
+		// Between the watermarks: keep the current state (hysteresis).
+		p.fullSince = time.Time{}
+	}
+}
+
+func (p *backpressure) set(pressured bool) {
+	if p.pressured.Load() == pressured {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pressured {
+		p.release = make(chan struct{})
+	} else {
+		close(p.release)
+	}
+	p.pressured.Store(pressured)
+}
+
+// Wait implements sender.BulkThrottle.
+func (p *backpressure) Wait(ctx context.Context) error {
+	if !p.pressured.Load() {
+		return nil
+	}
+	p.mu.Lock()
+	release := p.release
+	p.mu.Unlock()
+
+	timer := p.clock.NewTimer(p.cfg.MaxDelay)
+	defer timer.Stop()
+	select {
+	case <-release:
+	case <-timer.C():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package galigo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/clock"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func fill(ch chan tg.Update, n int) {
+	for range n {
+		ch <- tg.Update{}
+	}
+}
+
+func drain(ch chan tg.Update) {
+	for len(ch) > 0 {
+		<-ch
+	}
+}
+
+func TestBackpressure_EngagesAfterSustainAndReleasesAtLowWater(t *testing.T) {
+	updates := make(chan tg.Update, 10)
+	p := newBackpressure(updates, BackpressureConfig{Sustain: time.Second}.withDefaults(), clock.Real)
+	now := time.Now()
+
+	fill(updates, 9)
+	p.sample(now)
+	assert.False(t, p.pressured.Load(), "must not engage before Sustain")
+	p.sample(now.Add(time.Second))
+	assert.True(t, p.pressured.Load())
+
+	// Between watermarks: state is kept.
+	<-updates
+	<-updates
+	p.sample(now.Add(2 * time.Second))
+	assert.True(t, p.pressured.Load())
+
+	drain(updates)
+	p.sample(now.Add(3 * time.Second))
+	assert.False(t, p.pressured.Load())
+}
+
+func TestBackpressure_ShortSpikeIgnored(t *testing.T) {
+	updates := make(chan tg.Update, 10)
+	p := newBackpressure(updates, BackpressureConfig{Sustain: time.Second}.withDefaults(), clock.Real)
+	now := time.Now()
+
+	fill(updates, 10)
+	p.sample(now)
+	<-updates
+	<-updates
+	p.sample(now.Add(500 * time.Millisecond))
+	fill(updates, 2)
+	p.sample(now.Add(1200 * time.Millisecond))
+	assert.False(t, p.pressured.Load(), "spike timer must restart after dipping below HighWater")
+}
+
+func TestBackpressure_WaitReleasedWhenPressureClears(t *testing.T) {
+	updates := make(chan tg.Update, 10)
+	p := newBackpressure(updates, BackpressureConfig{MaxDelay: time.Minute}.withDefaults(), clock.Real)
+	require.NoError(t, p.Wait(context.Background()), "no pressure, no wait")
+
+	p.set(true)
+	done := make(chan error, 1)
+	go func() { done <- p.Wait(context.Background()) }()
+
+	select {
+	case <-done:
+		t.Fatal("Wait returned while pressured")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	p.set(false)
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Wait not released")
+	}
+}
+
+func TestBackpressure_WaitBoundedByMaxDelayAndContext(t *testing.T) {
+	updates := make(chan tg.Update, 10)
+	p := newBackpressure(updates, BackpressureConfig{MaxDelay: 10 * time.Millisecond}.withDefaults(), clock.Real)
+	p.set(true)
+
+	start := time.Now()
+	require.NoError(t, p.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p.cfg.MaxDelay = time.Minute
+	assert.ErrorIs(t, p.Wait(ctx), context.Canceled)
+}
+
+func TestBot_WithCoupledBackpressure(t *testing.T) {
+	bot, err := New("123456789:ABCdefGHIjklMNOpqrSTUvwxYZ",
+		WithUpdateBufferSize(4),
+		WithCoupledBackpressure(BackpressureConfig{
+			Interval: 5 * time.Millisecond,
+			Sustain:  10 * time.Millisecond,
+		}),
+	)
+	require.NoError(t, err)
+	defer bot.Close()
+
+	assert.False(t, bot.UnderBackpressure())
+	fill(bot.updates, 4)
+	assert.Eventually(t, bot.UnderBackpressure, time.Second, 5*time.Millisecond)
+
+	drain(bot.updates)
+	assert.Eventually(t, func() bool { return !bot.UnderBackpressure() }, time.Second, 5*time.Millisecond)
+}
+
+func TestBot_WithoutBackpressure(t *testing.T) {
+	bot, err := New("123456789:ABCdefGHIjklMNOpqrSTUvwxYZ")
+	require.NoError(t, err)
+	defer bot.Close()
+
+	assert.False(t, bot.UnderBackpressure())
+}
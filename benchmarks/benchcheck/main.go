@@ -0,0 +1,181 @@
+// Command benchcheck compares go test -bench results with a baseline and
+// exits 1 when a benchmark got slower or allocates more than allowed.
+//
+// Both files hold go test -bench -benchmem output; with -count > 1 the
+// median of each metric is compared, which keeps one noisy run from
+// failing the check. Benchmarks only in one file are listed but never
+// fail it.
+//
+// Usage:
+//
+//	go test -run '^$' -bench . -benchmem -count 5 ./benchmarks > new.txt
+//	go run ./benchmarks/benchcheck -baseline benchmarks/baseline.txt new.txt
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+var (
+	baseline  = flag.String("baseline", "benchmarks/baseline.txt", "baseline results")
+	timeLimit = flag.Float64("time", 0.25, "allowed ns/op increase (0.25 = 25%)")
+	memLimit  = flag.Float64("mem", 0.10, "allowed B/op and allocs/op increase")
+)
+
+// metrics of one benchmark, one value per run.
+type metrics struct {
+	ns, bytes, allocs []float64
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("benchcheck: ")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Fatal("usage: benchcheck [-baseline file] [-time f] [-mem f] new.txt")
+	}
+
+	old, err := parseFile(*baseline)
+	if err != nil {
+		log.Fatal(err)
+	}
+	cur, err := parseFile(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(cur) == 0 {
+		log.Fatalf("no benchmark results in %s", flag.Arg(0))
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "benchmark\told ns/op\tnew ns/op\tdelta\told allocs\tnew allocs\tdelta\tB/op delta\t\t")
+	regressions := 0
+	for _, name := range sortedKeys(cur) {
+		n := cur[name]
+		o, ok := old[name]
+		if !ok {
+			fmt.Fprintf(w, "%s\t-\t%.0f\tnew\t-\t%.0f\t\t\t\t\n", name, median(n.ns), median(n.allocs))
+			continue
+		}
+		dNs := delta(median(o.ns), median(n.ns))
+		dAllocs := delta(median(o.allocs), median(n.allocs))
+		dBytes := delta(median(o.bytes), median(n.bytes))
+		mark := ""
+		if dNs > *timeLimit || dAllocs > *memLimit || dBytes > *memLimit {
+			mark = "REGRESSION"
+			regressions++
+		}
+		fmt.Fprintf(w, "%s\t%.0f\t%.0f\t%s\t%.0f\t%.0f\t%s\t%s\t%s\t\n", name,
+			median(o.ns), median(n.ns), percent(dNs),
+			median(o.allocs), median(n.allocs), percent(dAllocs), percent(dBytes), mark)
+	}
+	for _, name := range sortedKeys(old) {
+		if _, ok := cur[name]; !ok {
+			fmt.Fprintf(w, "%s\t%.0f\t-\tmissing\t\t\t\t\t\t\n", name, median(old[name].ns))
+		}
+	}
+	_ = w.Flush()
+
+	if regressions > 0 {
+		fmt.Printf("\n%d regression(s) beyond +%.0f%% time / +%.0f%% memory\n", regressions, *timeLimit*100, *memLimit*100)
+		os.Exit(1)
+	}
+}
+
+func parseFile(path string) (map[string]*metrics, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parse(f)
+}
+
+// parse reads benchmark lines such as
+//
+//	BenchmarkX-8   1000   1234 ns/op   56.7 MB/s   890 B/op   12 allocs/op
+//
+// dropping the -GOMAXPROCS suffix so results from different machines line
+// up.
+func parse(r io.Reader) (map[string]*metrics, error) {
+	results := make(map[string]*metrics)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		name := fields[0]
+		if i := strings.LastIndexByte(name, '-'); i > 0 {
+			if _, err := strconv.Atoi(name[i+1:]); err == nil {
+				name = name[:i]
+			}
+		}
+		m := results[name]
+		if m == nil {
+			m = &metrics{}
+			results[name] = m
+		}
+		// fields[1] is the iteration count; the rest are value/unit pairs.
+		for i := 2; i+1 < len(fields); i += 2 {
+			v, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				continue
+			}
+			switch fields[i+1] {
+			case "ns/op":
+				m.ns = append(m.ns, v)
+			case "B/op":
+				m.bytes = append(m.bytes, v)
+			case "allocs/op":
+				m.allocs = append(m.allocs, v)
+			}
+		}
+	}
+	return results, sc.Err()
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	s := slices.Sorted(slices.Values(values))
+	if len(s)%2 == 1 {
+		return s[len(s)/2]
+	}
+	return (s[len(s)/2-1] + s[len(s)/2]) / 2
+}
+
+// delta is the relative change from old to cur; growing from zero counts
+// as +100%.
+func delta(old, cur float64) float64 {
+	switch {
+	case old == cur:
+		return 0
+	case old == 0:
+		return 1
+	}
+	return (cur - old) / old
+}
+
+func percent(d float64) string {
+	return fmt.Sprintf("%+.1f%%", d*100)
+}
+
+func sortedKeys(m map[string]*metrics) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
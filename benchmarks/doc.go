@@ -0,0 +1,13 @@
+// Package benchmarks holds galigo's performance regression suite: Go
+// benchmarks of the hot paths that are not tied to one package's
+// internals, run against a stored baseline.
+//
+//	make bench-check     # compare with benchmarks/baseline.txt
+//	make bench-baseline  # re-record the baseline after an intended change
+//
+// Timings depend on the machine, so the baseline is only meaningful where
+// it was recorded: re-record it on the CI runner or workstation that runs
+// the check. Allocation counts are stable everywhere and are the tighter
+// limit. The package has no non-test code; benchcheck compares two sets
+// of go test -bench results.
+package benchmarks
@@ -0,0 +1,94 @@
+package benchmarks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// largeMessage is a forwarded group photo message with a long caption,
+// entities, a reply with its own photo, and an inline keyboard: most of
+// the nested types a bot decodes on a busy chat.
+const largeMessage = `{
+  "message_id": 48213,
+  "message_thread_id": 77,
+  "from": {"id": 5551234567, "is_bot": false, "first_name": "Alice", "last_name": "Example", "username": "alice_example", "language_code": "en", "is_premium": true},
+  "chat": {"id": -1001234567890, "type": "supergroup", "title": "galigo benchmarks", "username": "galigo_bench", "is_forum": true},
+  "date": 1760000000,
+  "edit_date": 1760000060,
+  "forward_from_chat": {"id": -1009876543210, "type": "channel", "title": "Release notes", "username": "galigo_releases"},
+  "forward_date": 1759990000,
+  "is_topic_message": true,
+  "media_group_id": "13812345678901234",
+  "author_signature": "Release bot",
+  "caption": "galigo v1.4 is out: faster polling, zero-copy webhooks, sharded processing, leader election, distributed rate limiting and shared circuit breakers. Read the changelog at https://example.com/galigo/changelog and tell @alice_example what you think #release #golang",
+  "caption_entities": [
+    {"type": "bold", "offset": 0, "length": 11},
+    {"type": "url", "offset": 171, "length": 36},
+    {"type": "mention", "offset": 216, "length": 14},
+    {"type": "hashtag", "offset": 249, "length": 8},
+    {"type": "hashtag", "offset": 258, "length": 7},
+    {"type": "text_link", "offset": 12, "length": 3, "url": "https://example.com/galigo"}
+  ],
+  "photo": [
+    {"file_id": "AgACAgIAAxkBAAIBZ2aX_small_file_id_0123456789", "file_unique_id": "AQADsmall", "width": 90, "height": 51, "file_size": 1372},
+    {"file_id": "AgACAgIAAxkBAAIBZ2aX_medium_file_id_0123456789", "file_unique_id": "AQADmedium", "width": 320, "height": 180, "file_size": 17614},
+    {"file_id": "AgACAgIAAxkBAAIBZ2aX_large_file_id_0123456789", "file_unique_id": "AQADlarge", "width": 800, "height": 450, "file_size": 71250},
+    {"file_id": "AgACAgIAAxkBAAIBZ2aX_xlarge_file_id_0123456789", "file_unique_id": "AQADxlarge", "width": 1280, "height": 720, "file_size": 142933}
+  ],
+  "reply_to_message": {
+    "message_id": 48190,
+    "from": {"id": 5559876543, "is_bot": false, "first_name": "Bob", "username": "bob_example", "language_code": "de"},
+    "chat": {"id": -1001234567890, "type": "supergroup", "title": "galigo benchmarks", "username": "galigo_bench", "is_forum": true},
+    "date": 1759999000,
+    "text": "When is the next release? The polling path still allocates a lot on large batches.",
+    "entities": [{"type": "italic", "offset": 0, "length": 26}],
+    "photo": [
+      {"file_id": "AgACAgIAAxkBAAIBZ2aX_reply_small_0123456789", "file_unique_id": "AQADrsmall", "width": 90, "height": 90, "file_size": 2048},
+      {"file_id": "AgACAgIAAxkBAAIBZ2aX_reply_large_0123456789", "file_unique_id": "AQADrlarge", "width": 640, "height": 640, "file_size": 51200}
+    ]
+  },
+  "reply_markup": {"inline_keyboard": [
+    [{"text": "Changelog", "url": "https://example.com/galigo/changelog"}, {"text": "Docs", "url": "https://example.com/galigo/docs"}],
+    [{"text": "👍 42", "callback_data": "react:up:48213"}, {"text": "👎 1", "callback_data": "react:down:48213"}],
+    [{"text": "Share", "switch_inline_query": "galigo v1.4"}]
+  ]}
+}`
+
+func BenchmarkMessage_Unmarshal(b *testing.B) {
+	data := []byte(largeMessage)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for b.Loop() {
+		var msg tg.Message
+		if err := json.Unmarshal(data, &msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMessage_Marshal(b *testing.B) {
+	var msg tg.Message
+	if err := json.Unmarshal([]byte(largeMessage), &msg); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := json.Marshal(&msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMessage_DecodeUpdate(b *testing.B) {
+	data := []byte(`{"update_id": 900001, "message": ` + largeMessage + `}`)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for b.Loop() {
+		var update tg.Update
+		if err := (tg.Decoder{}).Decode(data, &update); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package benchmarks
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// encodeMultipart builds and encodes req as Telegram would receive it.
+func encodeMultipart(b *testing.B, req any) {
+	b.Helper()
+	mr, err := sender.BuildMultipartRequest(req)
+	if err != nil {
+		b.Fatal(err)
+	}
+	enc := sender.NewMultipartEncoder(io.Discard)
+	if err := enc.Encode(mr); err != nil {
+		b.Fatal(err)
+	}
+	if err := enc.Close(); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func BenchmarkMultipart_Photo(b *testing.B) {
+	photo := bytes.Repeat([]byte{0xff, 0xd8, 0x42}, 256<<10/3) // ~256 KB
+	b.SetBytes(int64(len(photo)))
+	b.ReportAllocs()
+	for b.Loop() {
+		encodeMultipart(b, sender.SendPhotoRequest{
+			ChatID:    tg.ChatID(123456789),
+			Photo:     sender.FromBytes(photo, "photo.jpg"),
+			Caption:   "<b>Benchmark</b> photo",
+			ParseMode: tg.ParseModeHTML,
+			ReplyMarkup: tg.InlineKeyboardMarkup{InlineKeyboard: [][]tg.InlineKeyboardButton{
+				{{Text: "Like", CallbackData: "like"}, {Text: "Share", CallbackData: "share"}},
+			}},
+		})
+	}
+}
+
+func BenchmarkMultipart_MediaGroup(b *testing.B) {
+	photo := bytes.Repeat([]byte{0x89, 0x50}, 32<<10) // 64 KB each
+	b.SetBytes(int64(len(photo)) * 10)
+	b.ReportAllocs()
+	for b.Loop() {
+		media := make([]sender.InputFile, 10)
+		for i := range media {
+			media[i] = sender.FromBytes(photo, "photo.png").WithMediaType("photo").WithCaption("album item")
+		}
+		encodeMultipart(b, sender.SendMediaGroupRequest{
+			ChatID: tg.ChatID(-1001234567890),
+			Media:  media,
+		})
+	}
+}
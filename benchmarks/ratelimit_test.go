@@ -0,0 +1,68 @@
+package benchmarks
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/time/rate"
+
+	"github.com/prilive-com/galigo/sender"
+)
+
+// chatIDs returns n chat IDs, half of them groups.
+func chatIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		id := int64(100000 + i)
+		if i%2 == 1 {
+			id = -1001000000000 - int64(i)
+		}
+		ids[i] = strconv.FormatInt(id, 10)
+	}
+	return ids
+}
+
+// benchmarkRateLimiter calls Wait from GOMAXPROCS goroutines spread over
+// 10k chats. Limits are infinite, so only the bookkeeping is measured.
+func benchmarkRateLimiter(b *testing.B, maxChats int) {
+	limiter := sender.NewMemoryRateLimiter(sender.Config{
+		GlobalRPS:       float64(rate.Inf),
+		GlobalBurst:     1,
+		PerChatRPS:      float64(rate.Inf),
+		PerChatBurst:    1,
+		GroupRPS:        float64(rate.Inf),
+		GroupBurst:      1,
+		MaxChatLimiters: maxChats,
+	})
+	ids := chatIDs(10000)
+	for _, id := range ids {
+		_ = limiter.Wait(context.Background(), id)
+	}
+
+	var next atomic.Uint64
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		ctx := context.Background()
+		i := next.Add(7919) // spread the goroutines over the chats
+		for pb.Next() {
+			i++
+			if err := limiter.Wait(ctx, ids[i%uint64(len(ids))]); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+func BenchmarkRateLimiter_10kChats(b *testing.B) {
+	benchmarkRateLimiter(b, 10000)
+}
+
+// BenchmarkRateLimiter_10kChatsEvicting caps the limiter below the number
+// of chats, so most calls create a bucket and evict another.
+func BenchmarkRateLimiter_10kChatsEvicting(b *testing.B) {
+	benchmarkRateLimiter(b, 1000)
+}
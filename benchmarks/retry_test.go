@@ -0,0 +1,89 @@
+package benchmarks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sony/gobreaker/v2"
+
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+const (
+	sentMessage = `{"ok":true,"result":{"message_id":1,"date":1760000000,"chat":{"id":42,"type":"private"},"text":"hi"}}`
+	serverError = `{"ok":false,"error_code":502,"description":"Bad Gateway"}`
+)
+
+// stubTransport answers every request in memory, failing the first
+// failures out of every failures+1 calls with a 502.
+type stubTransport struct {
+	failures int64
+	calls    atomic.Int64
+}
+
+func (t *stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+		_ = req.Body.Close()
+	}
+	status, body := http.StatusOK, sentMessage
+	if t.failures > 0 && t.calls.Add(1)%(t.failures+1) != 0 {
+		status, body = http.StatusBadGateway, serverError
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+type noSleep struct{}
+
+func (noSleep) Sleep(ctx context.Context, _ time.Duration) error { return ctx.Err() }
+
+type noLimit struct{}
+
+func (noLimit) Wait(context.Context, string) error { return nil }
+
+// benchmarkSend sends messages through a Client with retries and
+// failures per success, isolating the retry loop from the network and
+// from rate limiting.
+func benchmarkSend(b *testing.B, retries int, failures int64) {
+	client, err := sender.New("123456789:ABCdefGHIjklMNOpqrsTUVwxyz0123456789",
+		sender.WithHTTPClient(&http.Client{Transport: &stubTransport{failures: failures}}),
+		sender.WithRetries(retries),
+		sender.WithSleeper(noSleep{}),
+		sender.WithRateLimiter(noLimit{}),
+		sender.WithCircuitBreakerSettings(sender.CircuitBreakerSettings{
+			ReadyToTrip: func(gobreaker.Counts) bool { return false },
+		}),
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := client.SendMessage(ctx, sender.SendMessageRequest{ChatID: tg.ChatID(42), Text: "hi"}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSend_NoRetries is the baseline for the withRetry overhead.
+func BenchmarkSend_NoRetries(b *testing.B) { benchmarkSend(b, 0, 0) }
+
+// BenchmarkSend_RetriesUnused has retries enabled but never needs them.
+func BenchmarkSend_RetriesUnused(b *testing.B) { benchmarkSend(b, 3, 0) }
+
+// BenchmarkSend_RetryOnce fails every first attempt with a 502.
+func BenchmarkSend_RetryOnce(b *testing.B) { benchmarkSend(b, 3, 1) }
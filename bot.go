@@ -1,15 +1,29 @@
 package galigo
 
+//go:generate go run ./internal/gen/facade
+
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
+	"net/http"
+	"strings"
 	"sync"
+	"time"
+
+	"github.com/sony/gobreaker/v2"
+	"golang.org/x/time/rate"
 
+	"github.com/prilive-com/galigo/clock"
 	"github.com/prilive-com/galigo/internal/validate"
+	"github.com/prilive-com/galigo/leader"
 	"github.com/prilive-com/galigo/receiver"
 	"github.com/prilive-com/galigo/sender"
 	"github.com/prilive-com/galigo/tg"
+	"github.com/prilive-com/galigo/tg/sendopt"
 )
 
 // Bot is the unified Telegram bot client combining receiver and sender.
@@ -22,6 +36,14 @@ type Bot struct {
 	updates  chan tg.Update
 	config   botConfig
 
+	// Stages between the receiver and Updates, foreign command filtering
+	// then album aggregation (nil = none); staged is what Updates returns
+	staged     <-chan tg.Update
+	stopStages context.CancelFunc
+
+	// Coupled back-pressure (nil = disabled)
+	backpressure *backpressure
+
 	// P1 FIX: Ensure Close() is idempotent
 	closeOnce sync.Once
 }
@@ -34,6 +56,7 @@ type botConfig struct {
 	pollingTimeout   int
 	pollingLimit     int
 	pollingMaxErrors int
+	pollingOffset    int64 // set by Manager.ReloadToken
 	deleteWebhook    bool
 	allowedUpdates   []string
 
@@ -52,6 +75,90 @@ type botConfig struct {
 
 	// Logger
 	logger *slog.Logger
+
+	// Outgoing request headers
+	userAgent    string
+	extraHeaders map[string]string
+
+	// Decodes API results and updates (zero value = lenient)
+	decoder tg.Decoder
+
+	// Client-side limit checks (see WithLimitValidation)
+	skipLimitValidation bool
+
+	// Thumbnail hook for video/animation uploads
+	thumbnailer sender.ThumbnailerFunc
+	cache       sender.Cache
+	cacheTTL    time.Duration
+
+	// Persistent store for scheduled messages (nil = in memory)
+	scheduleStore sender.ScheduleStore
+
+	// Named message templates (nil = none)
+	templates *sender.Templates
+
+	// Shared HTTP transport (nil = one per component)
+	transport http.RoundTripper
+
+	// Limiter shared with other bots (nil = none)
+	sharedLimiter *rate.Limiter
+
+	// Replaces the built-in send limits (nil = in-memory)
+	rateLimiter sender.RateLimiter
+
+	// Rate limiter tokens per method (nil = defaults)
+	methodCosts map[string]int
+
+	// Resend policy for "can't parse entities" errors
+	parseFallback sender.ParseFallback
+
+	// Proxy URLs for sending and polling, in failover order
+	proxyURLs []string
+
+	// Circuit breaker transition hook
+	onBreakerChange func(name string, from, to gobreaker.State)
+
+	// Per-class circuit breakers
+	breakerPerClass      bool
+	classBreakerSettings map[sender.MethodClass]sender.CircuitBreakerSettings
+
+	// Shares breaker transitions between replicas (nil = local only)
+	breakerCoord sender.BreakerCoordinator
+
+	// Hedged requests and retry budget (nil = off)
+	hedge       *sender.HedgeConfig
+	retryBudget *sender.RetryBudget
+
+	// Idempotent sends (nil = off)
+	idempotency *sender.IdempotencyConfig
+
+	// getMe on New (0 = off)
+	startupTimeout time.Duration
+
+	// Per-update context hook
+	contextDecorator receiver.ContextDecorator
+
+	// Coupled back-pressure (nil = disabled)
+	backpressure *BackpressureConfig
+
+	// Album aggregation window (0 = disabled)
+	albumWindow time.Duration
+
+	// Deliver commands addressed to other bots (see WithForeignCommands)
+	foreignCommands bool
+
+	// Recording of received updates (nil = off)
+	recordTo io.Writer
+
+	// Handler failure reporting for Bot.Recover
+	recover RecoverConfig
+
+	// Time source for retries, backoff and the scheduler (nil = real time)
+	clock clock.Clock
+
+	// Polling leader election among replicas (nil = always poll)
+	leaderLock leader.Lock
+	leaderTTL  time.Duration
 }
 
 // Option configures the Bot.
@@ -97,6 +204,22 @@ func WithRateLimit(globalRPS float64, burst int) Option {
 	}
 }
 
+// WithSendTimeout sets the per-attempt timeout of API calls without file
+// uploads. See sender.WithRequestTimeout for per-call overrides.
+func WithSendTimeout(d time.Duration) Option {
+	return func(c *botConfig) {
+		c.senderConfig.SendTimeout = d
+	}
+}
+
+// WithUploadTimeout sets the per-attempt timeout of API calls that upload
+// files (default 5 minutes).
+func WithUploadTimeout(d time.Duration) Option {
+	return func(c *botConfig) {
+		c.senderConfig.UploadTimeout = d
+	}
+}
+
 // WithPollingMaxErrors sets max consecutive errors.
 func WithPollingMaxErrors(max int) Option {
 	return func(c *botConfig) {
@@ -118,6 +241,265 @@ func WithDeleteWebhook(delete bool) Option {
 	}
 }
 
+// WithBaseURL sets the Telegram API base URL for both sending and receiving,
+// e.g. a local Bot API server or a galigotest mock server.
+// The URL must not include the "/bot<token>" suffix.
+func WithBaseURL(url string) Option {
+	return func(c *botConfig) {
+		c.senderConfig.BaseURL = url
+		c.receiverConfig.BaseURL = strings.TrimSuffix(url, "/") + "/bot"
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every Bot API request,
+// for both sending and polling. The default identifies galigo and its version.
+func WithUserAgent(ua string) Option {
+	return func(c *botConfig) {
+		c.userAgent = ua
+	}
+}
+
+// WithExtraHeaders adds headers to every Bot API request, for both sending
+// and polling. Repeated calls merge.
+func WithExtraHeaders(headers map[string]string) Option {
+	return func(c *botConfig) {
+		if c.extraHeaders == nil {
+			c.extraHeaders = make(map[string]string, len(headers))
+		}
+		maps.Copy(c.extraHeaders, headers)
+	}
+}
+
+// WithDecoder sets how API results and updates are decoded, for sending,
+// polling and webhooks. Use tg.StrictDecoder in tests to fail on fields
+// galigo does not model.
+func WithDecoder(d tg.Decoder) Option {
+	return func(c *botConfig) {
+		c.decoder = d
+	}
+}
+
+// WithLimitValidation enables or disables client-side checks of Telegram's
+// limits before sending. See sender.WithLimitValidation.
+func WithLimitValidation(enabled bool) Option {
+	return func(c *botConfig) {
+		c.skipLimitValidation = !enabled
+	}
+}
+
+// WithThumbnailer sets a hook that generates thumbnails for uploaded videos
+// and animations. See sender.WithThumbnailer.
+func WithThumbnailer(fn sender.ThumbnailerFunc) Option {
+	return func(c *botConfig) {
+		c.thumbnailer = fn
+	}
+}
+
+// WithCache caches idempotent getters (GetMe, GetChat, GetMyCommands, ...)
+// for ttl. See sender.WithCache.
+func WithCache(cache sender.Cache, ttl time.Duration) Option {
+	return func(c *botConfig) {
+		c.cache = cache
+		c.cacheTTL = ttl
+	}
+}
+
+// WithScheduleStore keeps scheduled messages in store.
+// See sender.WithScheduleStore.
+func WithScheduleStore(store sender.ScheduleStore) Option {
+	return func(c *botConfig) {
+		c.scheduleStore = store
+	}
+}
+
+// WithTemplates sets the message templates used by SendTemplate.
+// See sender.Templates.
+func WithTemplates(t *sender.Templates) Option {
+	return func(c *botConfig) {
+		c.templates = t
+	}
+}
+
+// WithTransport sends all Bot API requests, including polling, through rt
+// instead of per-component transports, so connections are pooled across
+// the sender, the receiver and other bots using the same rt. Use
+// NewTransport for a tuned pool with connection metrics.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *botConfig) {
+		c.transport = rt
+	}
+}
+
+// WithProxy sends and polls through the first reachable of the given
+// proxy URLs (http://, https:// or socks5://, with optional user:pass@),
+// failing over to the next when one cannot be reached. It cannot be
+// combined with WithTransport; set TransportConfig.Proxy instead.
+func WithProxy(urls ...string) Option {
+	return func(c *botConfig) {
+		c.proxyURLs = urls
+	}
+}
+
+// WithSharedRateLimiter caps the combined send rate of all bots given the
+// same limiter. See sender.WithSharedRateLimiter.
+func WithSharedRateLimiter(l *rate.Limiter) Option {
+	return func(c *botConfig) {
+		c.sharedLimiter = l
+	}
+}
+
+// WithRateLimiter replaces the sender's built-in global and per-chat
+// limits, e.g. with a sender.RedisRateLimiter shared by all replicas. See
+// sender.WithRateLimiter.
+func WithRateLimiter(l sender.RateLimiter) Option {
+	return func(c *botConfig) {
+		c.rateLimiter = l
+	}
+}
+
+// WithMethodCosts sets how many rate limiter tokens each request to a
+// method takes. See sender.WithMethodCosts.
+func WithMethodCosts(costs map[string]int) Option {
+	return func(c *botConfig) {
+		c.methodCosts = costs
+	}
+}
+
+// WithParseFallback resends a message Telegram could not parse once,
+// without parse mode or with its text escaped. See sender.WithParseFallback.
+func WithParseFallback(policy sender.ParseFallback) Option {
+	return func(c *botConfig) {
+		c.parseFallback = policy
+	}
+}
+
+// WithBreakerStateChange calls fn on every circuit breaker transition.
+// See sender.WithBreakerStateChange.
+func WithBreakerStateChange(fn func(name string, from, to gobreaker.State)) Option {
+	return func(c *botConfig) {
+		c.onBreakerChange = fn
+	}
+}
+
+// WithBreakerPerClass gives each API method class its own circuit breaker.
+// See sender.WithBreakerPerClass.
+func WithBreakerPerClass(settings map[sender.MethodClass]sender.CircuitBreakerSettings) Option {
+	return func(c *botConfig) {
+		c.breakerPerClass = true
+		c.classBreakerSettings = settings
+	}
+}
+
+// WithBreakerCoordinator opens the circuit breakers of all replicas
+// together. See sender.WithBreakerCoordinator.
+func WithBreakerCoordinator(coord sender.BreakerCoordinator) Option {
+	return func(c *botConfig) {
+		c.breakerCoord = coord
+	}
+}
+
+// WithHedging sends a second attempt for slow requests. See
+// sender.HedgeConfig.
+func WithHedging(cfg sender.HedgeConfig) Option {
+	return func(c *botConfig) {
+		c.hedge = &cfg
+	}
+}
+
+// WithRetryBudget caps retries at a share of recent requests.
+// See sender.RetryBudget.
+func WithRetryBudget(budget sender.RetryBudget) Option {
+	return func(c *botConfig) {
+		c.retryBudget = &budget
+	}
+}
+
+// WithIdempotency records successful sends so that repeating one with the
+// same sender.WithIdempotencyKey returns the first message.
+// See sender.IdempotencyConfig.
+func WithIdempotency(cfg sender.IdempotencyConfig) Option {
+	return func(c *botConfig) {
+		c.idempotency = &cfg
+	}
+}
+
+// WithStartupValidation makes New check the token with getMe within
+// timeout, failing with tg.ErrUnauthorized instead of on the first send.
+// See sender.WithStartupValidation.
+func WithStartupValidation(timeout time.Duration) Option {
+	return func(c *botConfig) {
+		if timeout <= 0 {
+			timeout = sender.DefaultStartupTimeout
+		}
+		c.startupTimeout = timeout
+	}
+}
+
+// WithContextDecorator sets a hook that enriches the per-update contexts
+// returned by Bot.UpdateContext.
+func WithContextDecorator(fn receiver.ContextDecorator) Option {
+	return func(c *botConfig) {
+		c.contextDecorator = fn
+	}
+}
+
+// WithClock sets the clock used for send retries, hedging, circuit
+// breakers, polling backoff, album collection, backpressure and the
+// message scheduler. Tests pass galigotest.FakeClock to run them without
+// waiting.
+func WithClock(clk clock.Clock) Option {
+	return func(c *botConfig) {
+		c.clock = clk
+	}
+}
+
+// WithPollingPOST sends getUpdates as a POST with a JSON body instead of a
+// GET with a query string. See receiver.WithPollingPOST.
+func WithPollingPOST() Option {
+	return func(c *botConfig) {
+		c.receiverConfig.PollingPOST = true
+	}
+}
+
+// WithPollingLeaderLock polls only while holding lock, so that of several
+// replicas of the bot exactly one polls. See receiver.WithPollingLeaderLock.
+func WithPollingLeaderLock(lock leader.Lock, ttl time.Duration) Option {
+	return func(c *botConfig) {
+		c.leaderLock = lock
+		c.leaderTTL = ttl
+	}
+}
+
+// WithRawUpdates keeps the JSON of every received update, available through
+// tg.Update.Raw and tg.Message.Raw, so fields galigo does not model yet can
+// still be read.
+func WithRawUpdates() Option {
+	return func(c *botConfig) {
+		c.receiverConfig.KeepRawUpdates = true
+	}
+}
+
+// WithAlbums merges the parts of incoming media groups into one update with
+// tg.Update.Album set, waiting up to window for further parts (0 uses
+// receiver.DefaultAlbumWindow). See receiver.CollectAlbums.
+func WithAlbums(window time.Duration) Option {
+	return func(c *botConfig) {
+		if window <= 0 {
+			window = receiver.DefaultAlbumWindow
+		}
+		c.albumWindow = window
+	}
+}
+
+// WithForeignCommands delivers commands addressed to other bots, such as
+// "/start@otherbot" in a group. By default they are dropped once the bot
+// knows its username, i.e. with WithStartupValidation.
+func WithForeignCommands() Option {
+	return func(c *botConfig) {
+		c.foreignCommands = true
+	}
+}
+
 // WithUpdateBufferSize sets the updates channel buffer size.
 func WithUpdateBufferSize(size int) Option {
 	return func(c *botConfig) {
@@ -164,47 +546,167 @@ func New(token string, opts ...Option) (*Bot, error) {
 		logger = slog.Default()
 	}
 
+	// Create updates channel
+	updates := make(chan tg.Update, cfg.updateBufferSize)
+
+	senderOpts := []sender.Option{
+		sender.WithLogger(logger),
+		sender.WithUserAgent(cfg.userAgent),
+		sender.WithExtraHeaders(cfg.extraHeaders),
+		sender.WithLimitValidation(!cfg.skipLimitValidation),
+		sender.WithDecoder(cfg.decoder),
+	}
+	if cfg.thumbnailer != nil {
+		senderOpts = append(senderOpts, sender.WithThumbnailer(cfg.thumbnailer))
+	}
+	if cfg.cache != nil {
+		senderOpts = append(senderOpts, sender.WithCache(cfg.cache, cfg.cacheTTL))
+	}
+	if cfg.scheduleStore != nil {
+		senderOpts = append(senderOpts, sender.WithScheduleStore(cfg.scheduleStore))
+	}
+	if cfg.templates != nil {
+		senderOpts = append(senderOpts, sender.WithTemplates(cfg.templates))
+	}
+	if cfg.transport != nil {
+		if len(cfg.proxyURLs) > 0 {
+			return nil, errors.New("galigo: WithProxy cannot be combined with WithTransport")
+		}
+		senderOpts = append(senderOpts, sender.WithHTTPClient(&http.Client{Transport: cfg.transport}))
+	}
+	if len(cfg.proxyURLs) > 0 {
+		senderOpts = append(senderOpts, sender.WithProxy(cfg.proxyURLs...))
+	}
+	if cfg.sharedLimiter != nil {
+		senderOpts = append(senderOpts, sender.WithSharedRateLimiter(cfg.sharedLimiter))
+	}
+	if cfg.rateLimiter != nil {
+		senderOpts = append(senderOpts, sender.WithRateLimiter(cfg.rateLimiter))
+	}
+	if cfg.methodCosts != nil {
+		senderOpts = append(senderOpts, sender.WithMethodCosts(cfg.methodCosts))
+	}
+	if cfg.parseFallback != sender.ParseFallbackNone {
+		senderOpts = append(senderOpts, sender.WithParseFallback(cfg.parseFallback))
+	}
+	if cfg.onBreakerChange != nil {
+		senderOpts = append(senderOpts, sender.WithBreakerStateChange(cfg.onBreakerChange))
+	}
+	if cfg.breakerPerClass {
+		senderOpts = append(senderOpts, sender.WithBreakerPerClass(cfg.classBreakerSettings))
+	}
+	if cfg.breakerCoord != nil {
+		senderOpts = append(senderOpts, sender.WithBreakerCoordinator(cfg.breakerCoord))
+	}
+	if cfg.hedge != nil {
+		senderOpts = append(senderOpts, sender.WithHedging(*cfg.hedge))
+	}
+	if cfg.retryBudget != nil {
+		senderOpts = append(senderOpts, sender.WithRetryBudget(*cfg.retryBudget))
+	}
+	if cfg.idempotency != nil {
+		senderOpts = append(senderOpts, sender.WithIdempotency(*cfg.idempotency))
+	}
+	if cfg.clock != nil {
+		senderOpts = append(senderOpts, sender.WithClock(cfg.clock))
+	}
+	if cfg.startupTimeout > 0 {
+		senderOpts = append(senderOpts, sender.WithStartupValidation(cfg.startupTimeout))
+	}
+	var bp *backpressure
+	if cfg.backpressure != nil {
+		clk := cfg.clock
+		if clk == nil {
+			clk = clock.Real
+		}
+		bp = newBackpressure(updates, *cfg.backpressure, clk)
+		senderOpts = append(senderOpts, sender.WithBulkThrottle(bp))
+	}
+
 	// Create sender
-	senderClient, err := sender.NewFromConfig(cfg.senderConfig, sender.WithLogger(logger))
+	senderClient, err := sender.NewFromConfig(cfg.senderConfig, senderOpts...)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create updates channel
-	updates := make(chan tg.Update, cfg.updateBufferSize)
-
 	bot := &Bot{
-		token:   secretToken,
-		logger:  logger,
-		sender:  senderClient,
-		updates: updates,
-		config:  cfg,
+		token:        secretToken,
+		logger:       logger,
+		sender:       senderClient,
+		updates:      updates,
+		config:       cfg,
+		backpressure: bp,
 	}
+	if bp != nil {
+		bp.start()
+	}
+	bot.startStages()
 
 	// Create receiver based on mode
 	if cfg.mode == receiver.ModeLongPolling {
+		pollingOpts := []receiver.PollingOption{
+			receiver.WithPollingMaxErrors(cfg.pollingMaxErrors),
+			receiver.WithPollingAllowedUpdates(cfg.allowedUpdates),
+			receiver.WithPollingDeleteWebhook(cfg.deleteWebhook),
+			receiver.WithContextDecorator(cfg.contextDecorator),
+			receiver.WithPollingUserAgent(cfg.userAgent),
+			receiver.WithPollingExtraHeaders(cfg.extraHeaders),
+			receiver.WithPollingDecoder(cfg.decoder),
+		}
+		if len(cfg.proxyURLs) > 0 {
+			pollingOpts = append(pollingOpts, receiver.WithPollingProxy(cfg.proxyURLs...))
+		}
+		if cfg.recordTo != nil {
+			pollingOpts = append(pollingOpts, receiver.WithRecordUpdates(cfg.recordTo))
+		}
+		if cfg.clock != nil {
+			pollingOpts = append(pollingOpts, receiver.WithPollingClock(cfg.clock))
+		}
+		if cfg.leaderLock != nil {
+			pollingOpts = append(pollingOpts, receiver.WithPollingLeaderLock(cfg.leaderLock, cfg.leaderTTL))
+		}
+		if cfg.pollingOffset > 0 {
+			pollingOpts = append(pollingOpts, receiver.WithPollingOffset(cfg.pollingOffset))
+		}
+		if cfg.transport != nil {
+			pollingOpts = append(pollingOpts, receiver.WithPollingHTTPClient(&http.Client{
+				Transport: cfg.transport,
+				Timeout:   time.Duration(cfg.pollingTimeout+10) * time.Second,
+			}))
+		}
 		bot.receiver = receiver.NewPollingClient(
 			secretToken,
 			updates,
 			logger,
 			cfg.receiverConfig,
-			receiver.WithPollingMaxErrors(cfg.pollingMaxErrors),
-			receiver.WithPollingAllowedUpdates(cfg.allowedUpdates),
-			receiver.WithPollingDeleteWebhook(cfg.deleteWebhook),
+			pollingOpts...,
 		)
 	} else {
-		bot.webhook = receiver.NewWebhookHandler(logger, updates, cfg.receiverConfig)
+		webhookOpts := []receiver.WebhookOption{
+			receiver.WithWebhookContextDecorator(cfg.contextDecorator),
+			receiver.WithWebhookDecoder(cfg.decoder),
+		}
+		if cfg.recordTo != nil {
+			webhookOpts = append(webhookOpts, receiver.WithWebhookRecordUpdates(cfg.recordTo))
+		}
+		if cfg.clock != nil {
+			webhookOpts = append(webhookOpts, receiver.WithWebhookClock(cfg.clock))
+		}
+		bot.webhook = receiver.NewWebhookHandler(logger, updates, cfg.receiverConfig, webhookOpts...)
 	}
 
 	return bot, nil
 }
 
-// Start begins receiving updates.
+// Start begins receiving updates. ctx becomes the base of every
+// per-update context (see UpdateContext): in polling mode it also bounds
+// the poll loop; in webhook mode, where updates arrive through
+// WebhookHandler(), Start only records it as the handler's base context.
 func (b *Bot) Start(ctx context.Context) error {
 	if b.receiver != nil {
 		return b.receiver.Start(ctx)
 	}
-	// Webhook mode: handler is used via WebhookHandler()
+	b.webhook.SetBaseContext(ctx)
 	return nil
 }
 
@@ -215,7 +717,7 @@ func (b *Bot) Stop() {
 	}
 }
 
-// Close releases all resources.
+// Close releases all resources and drops the bot token.
 // Close is idempotent; subsequent calls are no-ops.
 //
 // In polling mode, Close() waits for the poll loop to exit (via Stop()),
@@ -230,26 +732,170 @@ func (b *Bot) Close() error {
 	var err error
 	b.closeOnce.Do(func() {
 		b.Stop()
+		if b.backpressure != nil {
+			b.backpressure.close()
+		}
 		// Only close updates channel in polling mode.
 		// In webhook mode, concurrent HTTP handlers may still send updates.
 		if b.receiver != nil {
 			close(b.updates)
+		} else if b.stopStages != nil {
+			b.stopStages()
 		}
 		err = b.sender.Close()
+
+		// Polling has stopped; drop the token it shared with the bot
+		b.token.Zero()
 	})
 	return err
 }
 
 // Updates returns the updates channel.
 func (b *Bot) Updates() <-chan tg.Update {
+	if b.staged != nil {
+		return b.staged
+	}
 	return b.updates
 }
 
+// startStages chains the enabled update stages onto the updates channel.
+func (b *Bot) startStages() {
+	var username string
+	if me := b.sender.Me(); me != nil && !b.config.foreignCommands {
+		username = me.Username
+	}
+	if username == "" && b.config.albumWindow <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var staged <-chan tg.Update = b.updates
+	if username != "" {
+		staged = receiver.DropForeignCommands(ctx, staged, username)
+	}
+	if b.config.albumWindow > 0 {
+		var albumOpts []receiver.AlbumOption
+		if b.config.clock != nil {
+			albumOpts = append(albumOpts, receiver.WithAlbumClock(b.config.clock))
+		}
+		staged = receiver.CollectAlbums(ctx, staged, b.config.albumWindow, albumOpts...)
+	}
+	b.staged = staged
+	b.stopStages = cancel
+}
+
+// Self returns the bot's own user, known after WithStartupValidation or a
+// successful GetMe, or nil before. Handlers can use it to recognise
+// mentions of the bot or its own messages.
+func (b *Bot) Self() *tg.User {
+	return b.sender.Me()
+}
+
+// UpdateContext returns the context a handler should use for update.
+// In both polling and webhook mode it inherits values and cancellation from
+// the context passed to Start, then runs WithContextDecorator's hook on it.
+// Before Start is called the base is context.Background(). Webhook request
+// contexts are not used: they end once Telegram's request is answered.
+// See receiver.ContextDecorator for the derivation chain.
+func (b *Bot) UpdateContext(update tg.Update) context.Context {
+	if b.receiver != nil {
+		return b.receiver.UpdateContext(update)
+	}
+	return b.webhook.UpdateContext(update)
+}
+
 // WebhookHandler returns the HTTP handler for webhook mode.
 func (b *Bot) WebhookHandler() *receiver.WebhookHandler {
 	return b.webhook
 }
 
+// UnderBackpressure reports whether bulk traffic is currently being slowed
+// because the updates buffer is persistently full. It is always false
+// without WithCoupledBackpressure.
+func (b *Bot) UnderBackpressure() bool {
+	return b.backpressure != nil && b.backpressure.pressured.Load()
+}
+
+// SetTokenOption configures Bot.SetToken.
+type SetTokenOption func(*setTokenConfig)
+
+type setTokenConfig struct {
+	webhookURL string
+}
+
+// ReregisterWebhook makes SetToken call setWebhook with url, the webhook
+// secret and the allowed updates once the new token is in place.
+func ReregisterWebhook(url string) SetTokenOption {
+	return func(c *setTokenConfig) {
+		c.webhookURL = url
+	}
+}
+
+// SetToken rotates the bot token without restarting: sending and, in
+// polling mode, polling switch to token for subsequent requests while
+// requests in flight finish with the old one. The token is checked with
+// getMe and must belong to the same bot (tg.ErrTokenBotMismatch);
+// otherwise the bot keeps its current token.
+func (b *Bot) SetToken(ctx context.Context, token tg.SecretToken, opts ...SetTokenOption) error {
+	var cfg setTokenConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if err := b.sender.SetToken(ctx, token); err != nil {
+		return err
+	}
+	if b.receiver != nil {
+		if err := b.receiver.SetToken(ctx, token); err != nil {
+			return fmt.Errorf("galigo: sender rotated, polling kept the old token: %w", err)
+		}
+	}
+	if cfg.webhookURL != "" {
+		req := map[string]any{"url": cfg.webhookURL}
+		if b.config.webhookSecret != "" {
+			req["secret_token"] = b.config.webhookSecret
+		}
+		if len(b.config.allowedUpdates) > 0 {
+			req["allowed_updates"] = b.config.allowedUpdates
+		}
+		if _, err := b.sender.Invoke(ctx, "setWebhook", req); err != nil {
+			return fmt.Errorf("galigo: re-register webhook: %w", err)
+		}
+	}
+	return nil
+}
+
+// CheckProxy connects to the proxies of WithProxy, starting with the
+// current one, and switches both sending and polling to the first
+// reachable one. It returns an error if none can be reached, and nil
+// without WithProxy.
+func (b *Bot) CheckProxy(ctx context.Context) error {
+	err := b.sender.CheckProxy(ctx)
+	if b.receiver != nil {
+		err = errors.Join(err, b.receiver.CheckProxy(ctx))
+	}
+	return err
+}
+
+// PollingStats returns the polling counters: time since the last
+// successful getUpdates, updates per poll, update age and updates buffer
+// occupancy. It reports false in webhook mode.
+func (b *Bot) PollingStats() (receiver.PollingStats, bool) {
+	if b.receiver == nil {
+		return receiver.PollingStats{}, false
+	}
+	return b.receiver.Stats(), true
+}
+
+// IsLeader reports whether this replica polls for updates; see
+// WithPollingLeaderLock. It is true in webhook mode.
+func (b *Bot) IsLeader() bool {
+	if b.receiver != nil {
+		return b.receiver.IsLeader()
+	}
+	return true
+}
+
 // IsHealthy returns health status for K8s probes.
 func (b *Bot) IsHealthy() bool {
 	if b.receiver != nil {
@@ -264,12 +910,16 @@ func (b *Bot) SendMessage(ctx context.Context, chatID tg.ChatID, text string, op
 		ChatID: chatID,
 		Text:   text,
 	}
-	for _, opt := range opts {
-		opt(&req)
-	}
+	req.ApplySendOptions(opts...)
 	return b.sender.SendMessage(ctx, req)
 }
 
+// SendTemplate renders template name with data and sends it to chatID.
+// See WithTemplates.
+func (b *Bot) SendTemplate(ctx context.Context, chatID tg.ChatID, name string, data any, opts ...SendOption) (*tg.Message, error) {
+	return b.sender.SendTemplate(ctx, chatID, name, data, opts...)
+}
+
 // SendPhoto sends a photo from URL or file_id.
 // For uploading files, use SendPhotoFile.
 func (b *Bot) SendPhoto(ctx context.Context, chatID tg.ChatID, photo string, opts ...PhotoOption) (*tg.Message, error) {
@@ -281,9 +931,7 @@ func (b *Bot) SendPhoto(ctx context.Context, chatID tg.ChatID, photo string, opt
 	if !isURL(photo) {
 		req.Photo = sender.FromFileID(photo)
 	}
-	for _, opt := range opts {
-		opt(&req)
-	}
+	req.ApplySendOptions(opts...)
 	return b.sender.SendPhoto(ctx, req)
 }
 
@@ -293,9 +941,7 @@ func (b *Bot) SendPhotoFile(ctx context.Context, chatID tg.ChatID, photo sender.
 		ChatID: chatID,
 		Photo:  photo,
 	}
-	for _, opt := range opts {
-		opt(&req)
-	}
+	req.ApplySendOptions(opts...)
 	return b.sender.SendPhoto(ctx, req)
 }
 
@@ -339,64 +985,35 @@ func (b *Bot) Sender() *sender.Client {
 	return b.sender
 }
 
-// SendOption configures send message requests.
-type SendOption func(*sender.SendMessageRequest)
+// SendOption and PhotoOption are sendopt.Option, so the same options work
+// here and on every sender.Client helper (Edit, Reply, Forward, Copy).
+type (
+	// SendOption configures send message requests.
+	SendOption = sendopt.Option
+	// PhotoOption configures send photo requests.
+	PhotoOption = sendopt.Option
+)
 
 // WithParseMode sets the parse mode.
-func WithParseMode(mode tg.ParseMode) SendOption {
-	return func(r *sender.SendMessageRequest) {
-		r.ParseMode = mode
-	}
-}
+func WithParseMode(mode tg.ParseMode) SendOption { return sendopt.ParseMode(mode) }
 
 // WithKeyboard sets the reply keyboard.
-func WithKeyboard(kb *tg.InlineKeyboardMarkup) SendOption {
-	return func(r *sender.SendMessageRequest) {
-		r.ReplyMarkup = kb
-	}
-}
+func WithKeyboard(kb *tg.InlineKeyboardMarkup) SendOption { return sendopt.Keyboard(kb) }
 
 // WithReplyTo sets the reply-to message ID.
-func WithReplyTo(messageID int) SendOption {
-	return func(r *sender.SendMessageRequest) {
-		r.ReplyToMessageID = messageID
-	}
-}
+func WithReplyTo(messageID int) SendOption { return sendopt.ReplyTo(messageID) }
 
 // Silent disables notification.
-func Silent() SendOption {
-	return func(r *sender.SendMessageRequest) {
-		r.DisableNotification = true
-	}
-}
-
-// PhotoOption configures send photo requests.
-type PhotoOption func(*sender.SendPhotoRequest)
+func Silent() SendOption { return sendopt.Silent() }
 
 // WithPhotoCaption sets the photo caption.
-func WithPhotoCaption(caption string) PhotoOption {
-	return func(r *sender.SendPhotoRequest) {
-		r.Caption = caption
-	}
-}
+func WithPhotoCaption(caption string) PhotoOption { return sendopt.Caption(caption) }
 
 // WithPhotoParseMode sets parse mode for caption.
-func WithPhotoParseMode(mode tg.ParseMode) PhotoOption {
-	return func(r *sender.SendPhotoRequest) {
-		r.ParseMode = mode
-	}
-}
+func WithPhotoParseMode(mode tg.ParseMode) PhotoOption { return sendopt.ParseMode(mode) }
 
 // WithPhotoKeyboard sets the reply keyboard.
-func WithPhotoKeyboard(kb *tg.InlineKeyboardMarkup) PhotoOption {
-	return func(r *sender.SendPhotoRequest) {
-		r.ReplyMarkup = kb
-	}
-}
+func WithPhotoKeyboard(kb *tg.InlineKeyboardMarkup) PhotoOption { return sendopt.Keyboard(kb) }
 
 // PhotoSilent disables notification for photo.
-func PhotoSilent() PhotoOption {
-	return func(r *sender.SendPhotoRequest) {
-		r.DisableNotification = true
-	}
-}
+func PhotoSilent() PhotoOption { return sendopt.Silent() }
@@ -0,0 +1,1041 @@
+// Code generated by internal/gen/facade; DO NOT EDIT.
+
+package galigo
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"iter"
+	"time"
+
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// AddStickerToSet adds a new sticker to an existing sticker set.
+func (b *Bot) AddStickerToSet(ctx context.Context, req sender.AddStickerToSetRequest) error {
+	return b.sender.AddStickerToSet(ctx, req)
+}
+
+// AnswerCallbackQuery answers a callback query.
+func (b *Bot) AnswerCallbackQuery(ctx context.Context, req sender.AnswerCallbackQueryRequest) error {
+	return b.sender.AnswerCallbackQuery(ctx, req)
+}
+
+// AnswerInlineQuery sends answers to an inline query.
+func (b *Bot) AnswerInlineQuery(ctx context.Context, req sender.AnswerInlineQueryRequest) error {
+	return b.sender.AnswerInlineQuery(ctx, req)
+}
+
+// AnswerPreCheckoutQuery responds to a pre-checkout query.
+// Must be called within 10 seconds. NO RETRY — value operation.
+func (b *Bot) AnswerPreCheckoutQuery(ctx context.Context, req sender.AnswerPreCheckoutQueryRequest) error {
+	return b.sender.AnswerPreCheckoutQuery(ctx, req)
+}
+
+// AnswerShippingQuery responds to a shipping query.
+func (b *Bot) AnswerShippingQuery(ctx context.Context, req sender.AnswerShippingQueryRequest) error {
+	return b.sender.AnswerShippingQuery(ctx, req)
+}
+
+// AnswerWebAppQuery sets the result of an interaction with a Web App.
+func (b *Bot) AnswerWebAppQuery(ctx context.Context, req sender.AnswerWebAppQueryRequest) (*tg.SentWebAppMessage, error) {
+	return b.sender.AnswerWebAppQuery(ctx, req)
+}
+
+// ApproveSuggestedPost approves a suggested post in a channel direct
+// messages chat. sendDate overrides the publication time, and must be set
+// if the post has none; pass 0 otherwise. The bot must have the
+// can_post_messages right in the channel.
+func (b *Bot) ApproveSuggestedPost(ctx context.Context, chatID int64, messageID int, sendDate int64) error {
+	return b.sender.ApproveSuggestedPost(ctx, chatID, messageID, sendDate)
+}
+
+// AuditStickerSets verifies every sticker set recorded in store via
+// GetStickerSet and classifies it as live or missing. With
+// WithOrphanPredicate and WithDeleteOrphans it bulk-deletes orphans.
+// Per-set failures are collected in StickerSetAudit.Errors; the returned
+// error is only set when the store itself fails or ctx is done.
+func (b *Bot) AuditStickerSets(ctx context.Context, store sender.StickerSetStore, opts ...sender.AuditStickerSetsOption) (*sender.StickerSetAudit, error) {
+	return b.sender.AuditStickerSets(ctx, store, opts...)
+}
+
+// BanChatMember bans a user in a group, supergroup, or channel.
+// The user will not be able to return to the chat on their own using invite links.
+func (b *Bot) BanChatMember(ctx context.Context, chatID tg.ChatID, userID int64, opts ...sender.BanOption) error {
+	return b.sender.BanChatMember(ctx, chatID, userID, opts...)
+}
+
+// BanChatSenderChat bans a channel chat in a supergroup or channel.
+func (b *Bot) BanChatSenderChat(ctx context.Context, chatID tg.ChatID, senderChatID int64) error {
+	return b.sender.BanChatSenderChat(ctx, chatID, senderChatID)
+}
+
+// Batch starts a batch of message calls that use ctx. By default it runs 8
+// calls at a time and collects all errors.
+func (b *Bot) Batch(ctx context.Context) *sender.MessageBatch {
+	return b.sender.Batch(ctx)
+}
+
+// BreakerHealth returns the state of every circuit breaker.
+func (b *Bot) BreakerHealth() sender.BreakerHealth {
+	return b.sender.BreakerHealth()
+}
+
+// BreakerState returns the state of the circuit breaker. With per-class
+// breakers it returns the most degraded one (open, then half-open); see
+// BreakerHealth for all of them.
+func (b *Bot) BreakerState() sender.BreakerState {
+	return b.sender.BreakerState()
+}
+
+// CancelScheduled removes a scheduled message.
+func (b *Bot) CancelScheduled(ctx context.Context, id string) error {
+	return b.sender.CancelScheduled(ctx, id)
+}
+
+// CloseBot closes the bot instance on Telegram servers.
+// Used before moving to a local Bot API server.
+// Note: This is different from Client.Close() which releases local resources.
+func (b *Bot) CloseBot(ctx context.Context) error {
+	return b.sender.CloseBot(ctx)
+}
+
+// CloseForumTopic closes an open topic.
+func (b *Bot) CloseForumTopic(ctx context.Context, chatID tg.ChatID, messageThreadID int) error {
+	return b.sender.CloseForumTopic(ctx, chatID, messageThreadID)
+}
+
+// CloseGeneralForumTopic closes the General topic.
+func (b *Bot) CloseGeneralForumTopic(ctx context.Context, chatID tg.ChatID) error {
+	return b.sender.CloseGeneralForumTopic(ctx, chatID)
+}
+
+// ConvertGiftToStars converts an owned gift to Telegram Stars.
+// NO RETRY — value operation to prevent double-conversion.
+func (b *Bot) ConvertGiftToStars(ctx context.Context, req sender.ConvertGiftToStarsRequest) error {
+	return b.sender.ConvertGiftToStars(ctx, req)
+}
+
+// CopyMessage copies a message.
+func (b *Bot) CopyMessage(ctx context.Context, req sender.CopyMessageRequest) (*tg.MessageID, error) {
+	return b.sender.CopyMessage(ctx, req)
+}
+
+// CopyMessages copies multiple messages at once.
+func (b *Bot) CopyMessages(ctx context.Context, req sender.CopyMessagesRequest) ([]tg.MessageID, error) {
+	return b.sender.CopyMessages(ctx, req)
+}
+
+// CopyMessagesChunked copies req.MessageIDs, of any length and order, in
+// chunks. See ForwardMessagesChunked.
+func (b *Bot) CopyMessagesChunked(ctx context.Context, req sender.CopyMessagesRequest) (*sender.ChunkedMessagesResult, error) {
+	return b.sender.CopyMessagesChunked(ctx, req)
+}
+
+// CreateChatSubscriptionInviteLink creates a subscription invite link for a channel chat.
+func (b *Bot) CreateChatSubscriptionInviteLink(ctx context.Context, req sender.CreateChatSubscriptionInviteLinkRequest) (*tg.ChatInviteLink, error) {
+	return b.sender.CreateChatSubscriptionInviteLink(ctx, req)
+}
+
+// CreateForumTopic creates a topic in a forum supergroup or private chat.
+// The bot must be an administrator with can_manage_topics rights.
+func (b *Bot) CreateForumTopic(ctx context.Context, chatID tg.ChatID, name string, opts ...sender.CreateTopicOption) (*tg.ForumTopic, error) {
+	return b.sender.CreateForumTopic(ctx, chatID, name, opts...)
+}
+
+// CreateInvoiceLink creates a link for an invoice.
+func (b *Bot) CreateInvoiceLink(ctx context.Context, req sender.CreateInvoiceLinkRequest) (string, error) {
+	return b.sender.CreateInvoiceLink(ctx, req)
+}
+
+// CreateNewStickerSet creates a new sticker set owned by a user.
+func (b *Bot) CreateNewStickerSet(ctx context.Context, req sender.CreateNewStickerSetRequest) error {
+	return b.sender.CreateNewStickerSet(ctx, req)
+}
+
+// DeclineSuggestedPost declines a suggested post in a channel direct
+// messages chat, with an optional comment for its author of at most
+// MaxDeclineCommentLength characters. The bot must have the
+// can_manage_direct_messages right in the channel.
+func (b *Bot) DeclineSuggestedPost(ctx context.Context, chatID int64, messageID int, comment string) error {
+	return b.sender.DeclineSuggestedPost(ctx, chatID, messageID, comment)
+}
+
+// DeleteChatPhoto deletes the chat photo.
+// The bot must be an administrator with can_change_info rights.
+func (b *Bot) DeleteChatPhoto(ctx context.Context, chatID tg.ChatID) error {
+	return b.sender.DeleteChatPhoto(ctx, chatID)
+}
+
+// DeleteForumTopic deletes a topic along with all its messages.
+func (b *Bot) DeleteForumTopic(ctx context.Context, chatID tg.ChatID, messageThreadID int) error {
+	return b.sender.DeleteForumTopic(ctx, chatID, messageThreadID)
+}
+
+// DeleteMessage deletes a message.
+func (b *Bot) DeleteMessage(ctx context.Context, req sender.DeleteMessageRequest) error {
+	return b.sender.DeleteMessage(ctx, req)
+}
+
+// DeleteMessages deletes multiple messages at once.
+func (b *Bot) DeleteMessages(ctx context.Context, chatID tg.ChatID, messageIDs []int) error {
+	return b.sender.DeleteMessages(ctx, chatID, messageIDs)
+}
+
+// DeleteMyCommands removes the bot's command list for the specified scope and language.
+func (b *Bot) DeleteMyCommands(ctx context.Context, opts ...sender.BotCommandOption) error {
+	return b.sender.DeleteMyCommands(ctx, opts...)
+}
+
+// DeleteStickerFromSet deletes a sticker from a set.
+func (b *Bot) DeleteStickerFromSet(ctx context.Context, sticker string) error {
+	return b.sender.DeleteStickerFromSet(ctx, sticker)
+}
+
+// DeleteStickerSet deletes a sticker set.
+func (b *Bot) DeleteStickerSet(ctx context.Context, name string) error {
+	return b.sender.DeleteStickerSet(ctx, name)
+}
+
+// DeleteStory deletes a story posted by a business account.
+func (b *Bot) DeleteStory(ctx context.Context, req sender.DeleteStoryRequest) error {
+	return b.sender.DeleteStory(ctx, req)
+}
+
+// DemoteChatMember removes all admin privileges from a user.
+func (b *Bot) DemoteChatMember(ctx context.Context, chatID tg.ChatID, userID int64) error {
+	return b.sender.DemoteChatMember(ctx, chatID, userID)
+}
+
+// Download fetches the file with fileID into w and returns its info. See
+// DownloadFile.
+func (b *Bot) Download(ctx context.Context, fileID string, w io.Writer) (*tg.File, error) {
+	return b.sender.Download(ctx, fileID, w)
+}
+
+// DownloadFile copies file, as returned by GetFile, into w. Failed
+// attempts are retried with the client's retry settings as long as
+// nothing was written, or w can be rewound (like *os.File) to where it
+// stood before the first attempt; attempts cut by DownloadTimeout are
+// retried too. The length is checked against file.FileSize when known. Absolute paths from a local
+// Bot API server are read from disk.
+func (b *Bot) DownloadFile(ctx context.Context, file *tg.File, w io.Writer) error {
+	return b.sender.DownloadFile(ctx, file, w)
+}
+
+// EditChatSubscriptionInviteLink edits a subscription invite link created by the bot.
+func (b *Bot) EditChatSubscriptionInviteLink(ctx context.Context, req sender.EditChatSubscriptionInviteLinkRequest) (*tg.ChatInviteLink, error) {
+	return b.sender.EditChatSubscriptionInviteLink(ctx, req)
+}
+
+// EditForumTopic edits name and icon of a topic.
+func (b *Bot) EditForumTopic(ctx context.Context, chatID tg.ChatID, messageThreadID int, opts ...sender.EditTopicOption) error {
+	return b.sender.EditForumTopic(ctx, chatID, messageThreadID, opts...)
+}
+
+// EditGeneralForumTopic edits the name of the General topic.
+func (b *Bot) EditGeneralForumTopic(ctx context.Context, chatID tg.ChatID, name string) error {
+	return b.sender.EditGeneralForumTopic(ctx, chatID, name)
+}
+
+// EditMessageCaption edits message caption.
+func (b *Bot) EditMessageCaption(ctx context.Context, req sender.EditMessageCaptionRequest) (*tg.Message, error) {
+	return b.sender.EditMessageCaption(ctx, req)
+}
+
+// EditMessageChecklist edits a checklist message.
+func (b *Bot) EditMessageChecklist(ctx context.Context, req sender.EditMessageChecklistRequest) (*tg.Message, error) {
+	return b.sender.EditMessageChecklist(ctx, req)
+}
+
+// EditMessageMedia edits the media content of a message.
+func (b *Bot) EditMessageMedia(ctx context.Context, req sender.EditMessageMediaRequest) (*tg.Message, error) {
+	return b.sender.EditMessageMedia(ctx, req)
+}
+
+// EditMessageReplyMarkup edits message reply markup.
+func (b *Bot) EditMessageReplyMarkup(ctx context.Context, req sender.EditMessageReplyMarkupRequest) (*tg.Message, error) {
+	return b.sender.EditMessageReplyMarkup(ctx, req)
+}
+
+// EditMessageText edits message text.
+func (b *Bot) EditMessageText(ctx context.Context, req sender.EditMessageTextRequest) (*tg.Message, error) {
+	return b.sender.EditMessageText(ctx, req)
+}
+
+// EditStory edits a story posted by a business account.
+func (b *Bot) EditStory(ctx context.Context, req sender.EditStoryRequest) (*tg.Story, error) {
+	return b.sender.EditStory(ctx, req)
+}
+
+// FileURL returns the download URL of file, as returned by GetFile. The
+// URL contains the bot token; do not log or share it. Absolute paths from
+// a local Bot API server have no URL and yield an error.
+func (b *Bot) FileURL(file *tg.File) (string, error) {
+	return b.sender.FileURL(file)
+}
+
+// ForwardMessage forwards a message.
+func (b *Bot) ForwardMessage(ctx context.Context, req sender.ForwardMessageRequest) (*tg.Message, error) {
+	return b.sender.ForwardMessage(ctx, req)
+}
+
+// ForwardMessages forwards multiple messages at once.
+func (b *Bot) ForwardMessages(ctx context.Context, req sender.ForwardMessagesRequest) ([]tg.MessageID, error) {
+	return b.sender.ForwardMessages(ctx, req)
+}
+
+// ForwardMessagesChunked forwards req.MessageIDs, of any length and order,
+// in chunks (see ChunkMessageIDs). Each chunk is retried like SendMessage.
+// It stops at the first chunk that fails, so later messages are never
+// delivered ahead of earlier ones; the returned error names that chunk and
+// the result tells what was sent.
+func (b *Bot) ForwardMessagesChunked(ctx context.Context, req sender.ForwardMessagesRequest) (*sender.ChunkedMessagesResult, error) {
+	return b.sender.ForwardMessagesChunked(ctx, req)
+}
+
+// GetAvailableGifts returns the list of gifts that can be sent by the bot.
+func (b *Bot) GetAvailableGifts(ctx context.Context) (*tg.Gifts, error) {
+	return b.sender.GetAvailableGifts(ctx)
+}
+
+// GetBusinessAccountStarBalance returns the Star balance of a business account.
+func (b *Bot) GetBusinessAccountStarBalance(ctx context.Context, businessConnectionID string) (*tg.StarAmount, error) {
+	return b.sender.GetBusinessAccountStarBalance(ctx, businessConnectionID)
+}
+
+// GetBusinessConnection returns information about a business connection.
+func (b *Bot) GetBusinessConnection(ctx context.Context, businessConnectionID string) (*tg.BusinessConnection, error) {
+	return b.sender.GetBusinessConnection(ctx, businessConnectionID)
+}
+
+// GetChat returns full information about a chat.
+func (b *Bot) GetChat(ctx context.Context, chatID tg.ChatID) (*tg.ChatFullInfo, error) {
+	return b.sender.GetChat(ctx, chatID)
+}
+
+// GetChatAdministrators returns a list of administrators in a chat.
+func (b *Bot) GetChatAdministrators(ctx context.Context, chatID tg.ChatID) ([]tg.ChatMember, error) {
+	return b.sender.GetChatAdministrators(ctx, chatID)
+}
+
+// GetChatMember returns information about a member of a chat.
+func (b *Bot) GetChatMember(ctx context.Context, chatID tg.ChatID, userID int64) (tg.ChatMember, error) {
+	return b.sender.GetChatMember(ctx, chatID, userID)
+}
+
+// GetChatMemberCount returns the number of members in a chat.
+func (b *Bot) GetChatMemberCount(ctx context.Context, chatID tg.ChatID) (int, error) {
+	return b.sender.GetChatMemberCount(ctx, chatID)
+}
+
+// GetChatMenuButton returns the bot's default menu button, or with
+// MenuButtonForChat the one of a private chat.
+func (b *Bot) GetChatMenuButton(ctx context.Context, opts ...sender.MenuButtonOption) (*tg.MenuButton, error) {
+	return b.sender.GetChatMenuButton(ctx, opts...)
+}
+
+// GetCustomEmojiStickers returns information about custom emoji stickers by their identifiers.
+func (b *Bot) GetCustomEmojiStickers(ctx context.Context, customEmojiIDs []string) ([]tg.Sticker, error) {
+	return b.sender.GetCustomEmojiStickers(ctx, customEmojiIDs)
+}
+
+// GetFile returns basic info about a file and prepares it for downloading.
+func (b *Bot) GetFile(ctx context.Context, fileID string) (*tg.File, error) {
+	return b.sender.GetFile(ctx, fileID)
+}
+
+// GetForumTopicIconStickers returns custom emoji stickers usable as topic icons.
+func (b *Bot) GetForumTopicIconStickers(ctx context.Context) ([]*tg.Sticker, error) {
+	return b.sender.GetForumTopicIconStickers(ctx)
+}
+
+// GetGameHighScores returns data for high score tables.
+func (b *Bot) GetGameHighScores(ctx context.Context, req sender.GetGameHighScoresRequest) ([]tg.GameHighScore, error) {
+	return b.sender.GetGameHighScores(ctx, req)
+}
+
+// GetMe returns basic information about the bot and keeps it for Me.
+func (b *Bot) GetMe(ctx context.Context) (*tg.User, error) {
+	return b.sender.GetMe(ctx)
+}
+
+// GetMyCommands returns the bot's command list for the specified scope and language.
+func (b *Bot) GetMyCommands(ctx context.Context, opts ...sender.BotCommandOption) ([]tg.BotCommand, error) {
+	return b.sender.GetMyCommands(ctx, opts...)
+}
+
+// GetMyDefaultAdministratorRights returns the bot's default admin rights.
+func (b *Bot) GetMyDefaultAdministratorRights(ctx context.Context, forChannels bool) (*tg.ChatAdministratorRights, error) {
+	return b.sender.GetMyDefaultAdministratorRights(ctx, forChannels)
+}
+
+// GetMyDescription returns the bot's description.
+func (b *Bot) GetMyDescription(ctx context.Context, opts ...sender.LanguageOption) (*tg.BotDescription, error) {
+	return b.sender.GetMyDescription(ctx, opts...)
+}
+
+// GetMyName returns the bot's name for the specified language.
+func (b *Bot) GetMyName(ctx context.Context, opts ...sender.LanguageOption) (*tg.BotName, error) {
+	return b.sender.GetMyName(ctx, opts...)
+}
+
+// GetMyShortDescription returns the bot's short description.
+func (b *Bot) GetMyShortDescription(ctx context.Context, opts ...sender.LanguageOption) (*tg.BotShortDescription, error) {
+	return b.sender.GetMyShortDescription(ctx, opts...)
+}
+
+// GetMyStarBalance returns the bot's current Star balance.
+func (b *Bot) GetMyStarBalance(ctx context.Context) (*tg.StarAmount, error) {
+	return b.sender.GetMyStarBalance(ctx)
+}
+
+// GetOwnedGifts returns the gifts owned by the specified user.
+func (b *Bot) GetOwnedGifts(ctx context.Context, req sender.GetOwnedGiftsRequest) (*tg.OwnedGifts, error) {
+	return b.sender.GetOwnedGifts(ctx, req)
+}
+
+// GetStarTransactions returns the bot's Star transactions.
+func (b *Bot) GetStarTransactions(ctx context.Context, req sender.GetStarTransactionsRequest) (*tg.StarTransactions, error) {
+	return b.sender.GetStarTransactions(ctx, req)
+}
+
+// GetStickerSet returns a sticker set by name.
+func (b *Bot) GetStickerSet(ctx context.Context, name string) (*tg.StickerSet, error) {
+	return b.sender.GetStickerSet(ctx, name)
+}
+
+// GetUserChatBoosts returns the list of boosts added to a chat by a user.
+func (b *Bot) GetUserChatBoosts(ctx context.Context, req sender.GetUserChatBoostsRequest) (*tg.UserChatBoosts, error) {
+	return b.sender.GetUserChatBoosts(ctx, req)
+}
+
+// GetUserProfileAudios returns a user's profile audios.
+// Added in Bot API 9.4.
+func (b *Bot) GetUserProfileAudios(ctx context.Context, userID int64, opts ...sender.GetUserProfileAudiosOption) (*tg.UserProfileAudios, error) {
+	return b.sender.GetUserProfileAudios(ctx, userID, opts...)
+}
+
+// GetUserProfilePhotos returns a user's profile pictures.
+func (b *Bot) GetUserProfilePhotos(ctx context.Context, userID int64, opts ...sender.GetUserProfilePhotosOption) (*tg.UserProfilePhotos, error) {
+	return b.sender.GetUserProfilePhotos(ctx, userID, opts...)
+}
+
+// GiftPremiumSubscription gifts a Telegram Premium subscription to a user,
+// paid in Stars from the bot's balance.
+// NO RETRY — value operation to prevent double-payment.
+func (b *Bot) GiftPremiumSubscription(ctx context.Context, req sender.GiftPremiumSubscriptionRequest) error {
+	return b.sender.GiftPremiumSubscription(ctx, req)
+}
+
+// HideGeneralForumTopic hides the General topic.
+func (b *Bot) HideGeneralForumTopic(ctx context.Context, chatID tg.ChatID) error {
+	return b.sender.HideGeneralForumTopic(ctx, chatID)
+}
+
+// InvalidateCache drops every cached answer.
+func (b *Bot) InvalidateCache() {
+	b.sender.InvalidateCache()
+}
+
+// InvalidateChat drops cached answers about chatID (GetChat,
+// GetChatAdministrators, GetChatMemberCount). Call it from update handlers
+// when a chat changes outside the bot, e.g. on chat_member updates.
+func (b *Bot) InvalidateChat(chatID tg.ChatID) {
+	b.sender.InvalidateChat(chatID)
+}
+
+// Invoke calls an arbitrary Bot API method and returns the raw result.
+// It is an escape hatch for methods galigo does not wrap yet; the request
+// goes through the same rate limiting, circuit breaker and error mapping
+// as the typed methods. The chat_id field of payload, if present, selects
+// the per-chat rate limiter.
+func (b *Bot) Invoke(ctx context.Context, method string, payload any) (json.RawMessage, error) {
+	return b.sender.Invoke(ctx, method, payload)
+}
+
+// LeaveChat makes the bot leave a group, supergroup, or channel.
+func (b *Bot) LeaveChat(ctx context.Context, chatID tg.ChatID) error {
+	return b.sender.LeaveChat(ctx, chatID)
+}
+
+// LogOut logs out from the cloud Bot API server.
+// After a successful call, you can use the local Bot API server.
+func (b *Bot) LogOut(ctx context.Context) error {
+	return b.sender.LogOut(ctx)
+}
+
+// Me returns the bot's user from the last successful GetMe, including the
+// one of WithStartupValidation, or nil if there was none.
+func (b *Bot) Me() *tg.User {
+	return b.sender.Me()
+}
+
+// Pin pins a message using Editable.
+func (b *Bot) Pin(ctx context.Context, e tg.Editable, opts ...sender.PinOption) error {
+	return b.sender.Pin(ctx, e, opts...)
+}
+
+// PinChatMessage pins a message in a chat.
+// The bot must be an administrator with can_pin_messages rights.
+func (b *Bot) PinChatMessage(ctx context.Context, chatID tg.ChatID, messageID int, opts ...sender.PinOption) error {
+	return b.sender.PinChatMessage(ctx, chatID, messageID, opts...)
+}
+
+// PostStory posts a story on behalf of a business account.
+func (b *Bot) PostStory(ctx context.Context, req sender.PostStoryRequest) (*tg.Story, error) {
+	return b.sender.PostStory(ctx, req)
+}
+
+// PromoteChatMember promotes or demotes a user in a supergroup or channel.
+// Pass all boolean parameters as false to demote a user.
+func (b *Bot) PromoteChatMember(ctx context.Context, chatID tg.ChatID, userID int64, opts ...sender.PromoteOption) error {
+	return b.sender.PromoteChatMember(ctx, chatID, userID, opts...)
+}
+
+// PromoteChatMemberWithRights promotes a user with the given rights.
+// This is a convenience method that applies ChatAdministratorRights.
+func (b *Bot) PromoteChatMemberWithRights(ctx context.Context, chatID tg.ChatID, userID int64, rights tg.ChatAdministratorRights) error {
+	return b.sender.PromoteChatMemberWithRights(ctx, chatID, userID, rights)
+}
+
+// Quote replies to msg quoting part of it. quote must be an exact substring
+// of the message's text or caption; otherwise Quote fails with
+// tg.ErrQuoteNotFound without calling Telegram. See tg.QuoteReply.
+func (b *Bot) Quote(ctx context.Context, msg *tg.Message, quote, text string, opts ...sender.ReplyOption) (*tg.Message, error) {
+	return b.sender.Quote(ctx, msg, quote, text, opts...)
+}
+
+// React sets the bot's emoji reactions on a message using Editable, e.g.
+// client.React(ctx, msg, tg.ReactionThumbsUp). Each emoji must be one of
+// tg.ReactionEmojis. Calling it without emojis removes the bot's reactions.
+func (b *Bot) React(ctx context.Context, e tg.Editable, emojis ...string) error {
+	return b.sender.React(ctx, e, emojis...)
+}
+
+// RefundStarPayment refunds a Telegram Stars payment.
+// NO RETRY — value operation to prevent double-refund.
+func (b *Bot) RefundStarPayment(ctx context.Context, req sender.RefundStarPaymentRequest) error {
+	return b.sender.RefundStarPayment(ctx, req)
+}
+
+// RemoveBusinessAccountProfilePhoto removes the profile photo of a business account.
+func (b *Bot) RemoveBusinessAccountProfilePhoto(ctx context.Context, req sender.RemoveBusinessAccountProfilePhotoRequest) error {
+	return b.sender.RemoveBusinessAccountProfilePhoto(ctx, req)
+}
+
+// RemoveChatVerification removes verification from a previously verified chat.
+func (b *Bot) RemoveChatVerification(ctx context.Context, chatID tg.ChatID) error {
+	return b.sender.RemoveChatVerification(ctx, chatID)
+}
+
+// RemoveMyProfilePhoto removes the bot's profile photo.
+// Added in Bot API 9.4.
+func (b *Bot) RemoveMyProfilePhoto(ctx context.Context, opts ...sender.ProfilePhotoOption) error {
+	return b.sender.RemoveMyProfilePhoto(ctx, opts...)
+}
+
+// RemoveUserVerification removes verification from a previously verified user.
+func (b *Bot) RemoveUserVerification(ctx context.Context, userID int64) error {
+	return b.sender.RemoveUserVerification(ctx, userID)
+}
+
+// ReopenForumTopic reopens a closed topic.
+func (b *Bot) ReopenForumTopic(ctx context.Context, chatID tg.ChatID, messageThreadID int) error {
+	return b.sender.ReopenForumTopic(ctx, chatID, messageThreadID)
+}
+
+// ReopenGeneralForumTopic reopens the General topic.
+func (b *Bot) ReopenGeneralForumTopic(ctx context.Context, chatID tg.ChatID) error {
+	return b.sender.ReopenGeneralForumTopic(ctx, chatID)
+}
+
+// ReplaceStickerInSet replaces an existing sticker in a sticker set with a new one.
+func (b *Bot) ReplaceStickerInSet(ctx context.Context, req sender.ReplaceStickerInSetRequest) error {
+	return b.sender.ReplaceStickerInSet(ctx, req)
+}
+
+// Reply sends text as a reply to msg, in the same chat, forum topic and
+// business connection. The reply is still sent if msg has been deleted.
+func (b *Bot) Reply(ctx context.Context, msg *tg.Message, text string, opts ...sender.ReplyOption) (*tg.Message, error) {
+	return b.sender.Reply(ctx, msg, text, opts...)
+}
+
+// ResetBreaker closes the circuit breakers and clears their counts, also
+// releasing a TripBreaker.
+func (b *Bot) ResetBreaker() {
+	b.sender.ResetBreaker()
+}
+
+// RestrictChatMember restricts a user in a supergroup.
+// The bot must be an administrator with can_restrict_members rights.
+func (b *Bot) RestrictChatMember(ctx context.Context, chatID tg.ChatID, userID int64, permissions tg.ChatPermissions, opts ...sender.RestrictOption) error {
+	return b.sender.RestrictChatMember(ctx, chatID, userID, permissions, opts...)
+}
+
+// RunScheduler sends scheduled messages as they fall due, until ctx is
+// done. Messages that fell due while no scheduler ran are sent on start.
+// Run at most one scheduler per store.
+//
+// When the store fails to take a job's new state after a send, the
+// scheduler keeps that state in memory and writes it again on every
+// pass, so the job is not sent twice.
+func (b *Bot) RunScheduler(ctx context.Context) error {
+	return b.sender.RunScheduler(ctx)
+}
+
+// SavePreparedInlineMessage stores a message that can be sent by a user of a Mini App.
+func (b *Bot) SavePreparedInlineMessage(ctx context.Context, req sender.SavePreparedInlineMessageRequest) (*tg.PreparedInlineMessage, error) {
+	return b.sender.SavePreparedInlineMessage(ctx, req)
+}
+
+// Schedule stores req to be sent at at and returns the job ID. Messages
+// are sent by RunScheduler, which must be running (in this or, with a
+// shared store, another process) when they fall due.
+func (b *Bot) Schedule(ctx context.Context, at time.Time, req sender.SendMessageRequest, opts ...sender.ScheduleOption) (string, error) {
+	return b.sender.Schedule(ctx, at, req, opts...)
+}
+
+// ScheduledMessages returns all pending scheduled messages.
+func (b *Bot) ScheduledMessages(ctx context.Context) ([]sender.ScheduledMessage, error) {
+	return b.sender.ScheduledMessages(ctx)
+}
+
+// SendAnimation sends an animation (GIF or H.264/MPEG-4 AVC video without sound).
+func (b *Bot) SendAnimation(ctx context.Context, req sender.SendAnimationRequest) (*tg.Message, error) {
+	return b.sender.SendAnimation(ctx, req)
+}
+
+// SendAudio sends an audio file.
+func (b *Bot) SendAudio(ctx context.Context, req sender.SendAudioRequest) (*tg.Message, error) {
+	return b.sender.SendAudio(ctx, req)
+}
+
+// SendChatAction sends a chat action (typing, upload_photo, etc.).
+// Telegram ignores unknown actions, so they are rejected with a
+// *tg.ValidationError before the request is sent.
+func (b *Bot) SendChatAction(ctx context.Context, chatID tg.ChatID, action tg.ChatAction) error {
+	return b.sender.SendChatAction(ctx, chatID, action)
+}
+
+// SendChecklist sends a checklist message.
+func (b *Bot) SendChecklist(ctx context.Context, req sender.SendChecklistRequest) (*tg.Message, error) {
+	return b.sender.SendChecklist(ctx, req)
+}
+
+// SendContact sends a phone contact.
+func (b *Bot) SendContact(ctx context.Context, req sender.SendContactRequest) (*tg.Message, error) {
+	return b.sender.SendContact(ctx, req)
+}
+
+// SendDice sends an animated emoji that displays a random value.
+func (b *Bot) SendDice(ctx context.Context, chatID tg.ChatID, opts ...sender.SendDiceOption) (*tg.Message, error) {
+	return b.sender.SendDice(ctx, chatID, opts...)
+}
+
+// SendDocument sends a document.
+func (b *Bot) SendDocument(ctx context.Context, req sender.SendDocumentRequest) (*tg.Message, error) {
+	return b.sender.SendDocument(ctx, req)
+}
+
+// SendGame sends a game.
+func (b *Bot) SendGame(ctx context.Context, req sender.SendGameRequest) (*tg.Message, error) {
+	return b.sender.SendGame(ctx, req)
+}
+
+// SendGift sends a gift to a user.
+// NO RETRY — value operation to prevent double-send.
+func (b *Bot) SendGift(ctx context.Context, req sender.SendGiftRequest) error {
+	return b.sender.SendGift(ctx, req)
+}
+
+// SendInvoice sends an invoice.
+func (b *Bot) SendInvoice(ctx context.Context, req sender.SendInvoiceRequest) (*tg.Message, error) {
+	return b.sender.SendInvoice(ctx, req)
+}
+
+// SendLocation sends a location.
+func (b *Bot) SendLocation(ctx context.Context, req sender.SendLocationRequest) (*tg.Message, error) {
+	return b.sender.SendLocation(ctx, req)
+}
+
+// SendMediaGroup sends a group of photos, videos, documents or audios as an album.
+func (b *Bot) SendMediaGroup(ctx context.Context, req sender.SendMediaGroupRequest) ([]*tg.Message, error) {
+	return b.sender.SendMediaGroup(ctx, req)
+}
+
+// SendMessageDraft sends a draft message for streaming.
+// Call repeatedly with the same DraftID and growing text for a streaming effect.
+// ChatID must be an integer (private chats only).
+// Available to all bots since Bot API 9.5.
+func (b *Bot) SendMessageDraft(ctx context.Context, req sender.SendMessageDraftRequest) error {
+	return b.sender.SendMessageDraft(ctx, req)
+}
+
+// SendPoll sends a native poll.
+func (b *Bot) SendPoll(ctx context.Context, req sender.SendPollRequest) (*tg.Message, error) {
+	return b.sender.SendPoll(ctx, req)
+}
+
+// SendPollSimple sends a simple regular poll.
+func (b *Bot) SendPollSimple(ctx context.Context, chatID tg.ChatID, question string, options []string, opts ...sender.PollOption) (*tg.Message, error) {
+	return b.sender.SendPollSimple(ctx, chatID, question, options, opts...)
+}
+
+// SendQuiz sends a quiz poll with a correct answer.
+func (b *Bot) SendQuiz(ctx context.Context, chatID tg.ChatID, question string, options []string, correctOptionIndex int, opts ...sender.PollOption) (*tg.Message, error) {
+	return b.sender.SendQuiz(ctx, chatID, question, options, correctOptionIndex, opts...)
+}
+
+// SendSticker sends a sticker.
+func (b *Bot) SendSticker(ctx context.Context, req sender.SendStickerRequest) (*tg.Message, error) {
+	return b.sender.SendSticker(ctx, req)
+}
+
+// SendVenue sends a venue.
+func (b *Bot) SendVenue(ctx context.Context, req sender.SendVenueRequest) (*tg.Message, error) {
+	return b.sender.SendVenue(ctx, req)
+}
+
+// SendVideo sends a video.
+func (b *Bot) SendVideo(ctx context.Context, req sender.SendVideoRequest) (*tg.Message, error) {
+	return b.sender.SendVideo(ctx, req)
+}
+
+// SendVideoNote sends a video note (round video up to 1 minute).
+func (b *Bot) SendVideoNote(ctx context.Context, req sender.SendVideoNoteRequest) (*tg.Message, error) {
+	return b.sender.SendVideoNote(ctx, req)
+}
+
+// SendVoice sends a voice message.
+func (b *Bot) SendVoice(ctx context.Context, req sender.SendVoiceRequest) (*tg.Message, error) {
+	return b.sender.SendVoice(ctx, req)
+}
+
+// SetBusinessAccountBio sets the bio of a business account.
+func (b *Bot) SetBusinessAccountBio(ctx context.Context, req sender.SetBusinessAccountBioRequest) error {
+	return b.sender.SetBusinessAccountBio(ctx, req)
+}
+
+// SetBusinessAccountGiftSettings sets the gift settings of a business account.
+func (b *Bot) SetBusinessAccountGiftSettings(ctx context.Context, req sender.SetBusinessAccountGiftSettingsRequest) error {
+	return b.sender.SetBusinessAccountGiftSettings(ctx, req)
+}
+
+// SetBusinessAccountName sets the name of a business account.
+func (b *Bot) SetBusinessAccountName(ctx context.Context, req sender.SetBusinessAccountNameRequest) error {
+	return b.sender.SetBusinessAccountName(ctx, req)
+}
+
+// SetBusinessAccountProfilePhoto sets the profile photo of a business account.
+func (b *Bot) SetBusinessAccountProfilePhoto(ctx context.Context, req sender.SetBusinessAccountProfilePhotoRequest) error {
+	return b.sender.SetBusinessAccountProfilePhoto(ctx, req)
+}
+
+// SetBusinessAccountUsername sets the username of a business account.
+func (b *Bot) SetBusinessAccountUsername(ctx context.Context, req sender.SetBusinessAccountUsernameRequest) error {
+	return b.sender.SetBusinessAccountUsername(ctx, req)
+}
+
+// SetChatAdministratorCustomTitle sets a custom title for an administrator.
+// Max length: 16 characters, emoji are not allowed.
+func (b *Bot) SetChatAdministratorCustomTitle(ctx context.Context, chatID tg.ChatID, userID int64, customTitle string) error {
+	return b.sender.SetChatAdministratorCustomTitle(ctx, chatID, userID, customTitle)
+}
+
+// SetChatDescription changes the description of a chat.
+// Description length: 0-255 characters.
+func (b *Bot) SetChatDescription(ctx context.Context, chatID tg.ChatID, description string) error {
+	return b.sender.SetChatDescription(ctx, chatID, description)
+}
+
+// SetChatMemberTag sets or removes a custom tag for a regular member.
+// Pass an empty string as tag to remove the tag.
+// Tag must be 0-16 characters, emoji are not allowed.
+// The bot must be an administrator with can_manage_tags right.
+func (b *Bot) SetChatMemberTag(ctx context.Context, chatID tg.ChatID, userID int64, tag string) error {
+	return b.sender.SetChatMemberTag(ctx, chatID, userID, tag)
+}
+
+// SetChatMenuButton sets the bot's menu button, by default for all private
+// chats; MenuButtonForChat limits it to one chat. Pass
+// tg.MenuButtonDefault() to reset.
+func (b *Bot) SetChatMenuButton(ctx context.Context, button tg.MenuButton, opts ...sender.MenuButtonOption) error {
+	return b.sender.SetChatMenuButton(ctx, button, opts...)
+}
+
+// SetChatPermissions sets default chat permissions for all members.
+// The bot must be an administrator with can_restrict_members rights.
+func (b *Bot) SetChatPermissions(ctx context.Context, chatID tg.ChatID, permissions tg.ChatPermissions, opts ...sender.SetPermissionsOption) error {
+	return b.sender.SetChatPermissions(ctx, chatID, permissions, opts...)
+}
+
+// SetChatPhoto sets a new chat photo.
+// The bot must be an administrator with can_change_info rights.
+func (b *Bot) SetChatPhoto(ctx context.Context, chatID tg.ChatID, photo sender.InputFile) error {
+	return b.sender.SetChatPhoto(ctx, chatID, photo)
+}
+
+// SetChatTitle changes the title of a chat.
+// Title length: 1-128 characters.
+func (b *Bot) SetChatTitle(ctx context.Context, chatID tg.ChatID, title string) error {
+	return b.sender.SetChatTitle(ctx, chatID, title)
+}
+
+// SetCustomEmojiStickerSetThumbnail sets the thumbnail of a custom emoji sticker set.
+func (b *Bot) SetCustomEmojiStickerSetThumbnail(ctx context.Context, req sender.SetCustomEmojiStickerSetThumbnailRequest) error {
+	return b.sender.SetCustomEmojiStickerSetThumbnail(ctx, req)
+}
+
+// SetGameScore sets the score of the specified user in a game message.
+// Returns the edited message when chat_id + message_id are used.
+// Returns nil when inline_message_id is used (Telegram returns true).
+func (b *Bot) SetGameScore(ctx context.Context, req sender.SetGameScoreRequest) (*tg.Message, error) {
+	return b.sender.SetGameScore(ctx, req)
+}
+
+// SetMessageReaction sets a reaction on a message. An empty Reaction list
+// removes the bot's reactions. Build entries with tg.EmojiReaction or
+// tg.CustomEmojiReaction; bots cannot set the paid reaction.
+func (b *Bot) SetMessageReaction(ctx context.Context, req sender.SetMessageReactionRequest) error {
+	return b.sender.SetMessageReaction(ctx, req)
+}
+
+// SetMyCommands sets the bot's command list for the specified scope and language.
+// Commands appear in the menu button when users type "/".
+func (b *Bot) SetMyCommands(ctx context.Context, commands []tg.BotCommand, opts ...sender.BotCommandOption) error {
+	return b.sender.SetMyCommands(ctx, commands, opts...)
+}
+
+// SetMyDefaultAdministratorRights sets the default admin rights requested when bot is added to groups/channels.
+func (b *Bot) SetMyDefaultAdministratorRights(ctx context.Context, opts ...sender.AdminRightsOption) error {
+	return b.sender.SetMyDefaultAdministratorRights(ctx, opts...)
+}
+
+// SetMyDescription sets the bot's description (shown in empty chat).
+func (b *Bot) SetMyDescription(ctx context.Context, description string, opts ...sender.LanguageOption) error {
+	return b.sender.SetMyDescription(ctx, description, opts...)
+}
+
+// SetMyName sets the bot's name for the specified language.
+// Pass empty string to remove the dedicated name for that language.
+func (b *Bot) SetMyName(ctx context.Context, name string, opts ...sender.LanguageOption) error {
+	return b.sender.SetMyName(ctx, name, opts...)
+}
+
+// SetMyProfilePhoto sets the bot's profile photo.
+// Added in Bot API 9.4.
+func (b *Bot) SetMyProfilePhoto(ctx context.Context, photo sender.InputFile, opts ...sender.ProfilePhotoOption) error {
+	return b.sender.SetMyProfilePhoto(ctx, photo, opts...)
+}
+
+// SetMyShortDescription sets the bot's short description (shown in profile/search).
+func (b *Bot) SetMyShortDescription(ctx context.Context, shortDescription string, opts ...sender.LanguageOption) error {
+	return b.sender.SetMyShortDescription(ctx, shortDescription, opts...)
+}
+
+// SetPassportDataErrors informs a user that some of the Telegram Passport elements they provided contain errors.
+func (b *Bot) SetPassportDataErrors(ctx context.Context, req sender.SetPassportDataErrorsRequest) error {
+	return b.sender.SetPassportDataErrors(ctx, req)
+}
+
+// SetStickerEmojiList changes the list of emojis assigned to a sticker.
+func (b *Bot) SetStickerEmojiList(ctx context.Context, req sender.SetStickerEmojiListRequest) error {
+	return b.sender.SetStickerEmojiList(ctx, req)
+}
+
+// SetStickerKeywords changes the search keywords for a sticker.
+func (b *Bot) SetStickerKeywords(ctx context.Context, req sender.SetStickerKeywordsRequest) error {
+	return b.sender.SetStickerKeywords(ctx, req)
+}
+
+// SetStickerMaskPosition changes the mask position of a mask sticker.
+func (b *Bot) SetStickerMaskPosition(ctx context.Context, req sender.SetStickerMaskPositionRequest) error {
+	return b.sender.SetStickerMaskPosition(ctx, req)
+}
+
+// SetStickerPositionInSet moves a sticker in a set to a specific position.
+func (b *Bot) SetStickerPositionInSet(ctx context.Context, req sender.SetStickerPositionInSetRequest) error {
+	return b.sender.SetStickerPositionInSet(ctx, req)
+}
+
+// SetStickerSetThumbnail sets the thumbnail of a sticker set.
+func (b *Bot) SetStickerSetThumbnail(ctx context.Context, req sender.SetStickerSetThumbnailRequest) error {
+	return b.sender.SetStickerSetThumbnail(ctx, req)
+}
+
+// SetStickerSetTitle sets the title of a sticker set.
+func (b *Bot) SetStickerSetTitle(ctx context.Context, name, title string) error {
+	return b.sender.SetStickerSetTitle(ctx, name, title)
+}
+
+// SetUserEmojiStatus changes the emoji status of a user who allowed the bot
+// to do so through a Mini App (requestEmojiStatusAccess).
+func (b *Bot) SetUserEmojiStatus(ctx context.Context, req sender.SetUserEmojiStatusRequest) error {
+	return b.sender.SetUserEmojiStatus(ctx, req)
+}
+
+// StarTransactions returns an iterator over all of the bot's Star
+// transactions, newest first, fetched with GetStarTransactions in pages of
+// pageSize (1-100, default 100) with the offset advanced automatically.
+// Only transactions matching every filter are yielded. An error is yielded
+// once and ends the iteration.
+func (b *Bot) StarTransactions(ctx context.Context, pageSize int, filters ...sender.StarTransactionFilter) iter.Seq2[tg.StarTransaction, error] {
+	return b.sender.StarTransactions(ctx, pageSize, filters...)
+}
+
+// StickerSetPages returns an iterator over the sticker sets recorded in
+// store, fetched with GetStickerSet in pages of up to pageSize sets.
+// Names that no longer exist on Telegram are skipped. Any other error is
+// yielded once and ends the iteration.
+func (b *Bot) StickerSetPages(ctx context.Context, store sender.StickerSetStore, pageSize int) iter.Seq2[[]*tg.StickerSet, error] {
+	return b.sender.StickerSetPages(ctx, store, pageSize)
+}
+
+// StopPoll stops a poll and returns the final results.
+func (b *Bot) StopPoll(ctx context.Context, chatID tg.ChatID, messageID int, opts ...sender.StopPollOption) (*tg.Poll, error) {
+	return b.sender.StopPoll(ctx, chatID, messageID, opts...)
+}
+
+// SyncCommands makes Telegram's command lists match set. Each roster is
+// compared with GetMyCommands and only rosters that differ are sent; then
+// lists set does not declare (see CommandSet.Drop) are deleted if
+// Telegram still has them. It makes no changes when everything matches, so
+// calling it on every start is cheap. It stops at the first error.
+func (b *Bot) SyncCommands(ctx context.Context, set *sender.CommandSet) error {
+	return b.sender.SyncCommands(ctx, set)
+}
+
+// SyncProfiles makes the bot's profiles match profiles, keyed by language
+// code with "" for the default. Languages not in the map are left alone.
+//
+// All profiles are validated and the current values read with the Get
+// methods before anything is set, so a bad profile or a failed read
+// changes nothing. Changes are then applied in language order; if one
+// fails, the fields already set are restored on a best-effort basis and
+// the error is returned together with the changes that could not be
+// rolled back. On success it returns the applied changes.
+func (b *Bot) SyncProfiles(ctx context.Context, profiles map[string]sender.BotProfile) ([]sender.ProfileChange, error) {
+	return b.sender.SyncProfiles(ctx, profiles)
+}
+
+// SyncStickerSet makes the sticker set spec.Name match spec, creating it
+// if it does not exist. Steps are applied in the order of
+// StickerSetSyncResult.Ops: title, deletions, replacements, emoji
+// updates, additions, then moves. It stops at the first failed step.
+func (b *Bot) SyncStickerSet(ctx context.Context, spec sender.StickerSetSpec, opts ...sender.SyncStickerSetOption) (*sender.StickerSetSyncResult, error) {
+	return b.sender.SyncStickerSet(ctx, spec, opts...)
+}
+
+// TransferBusinessAccountStars transfers Stars from the bot to a business account.
+// NO RETRY — value operation to prevent double-transfer.
+func (b *Bot) TransferBusinessAccountStars(ctx context.Context, req sender.TransferBusinessAccountStarsRequest) error {
+	return b.sender.TransferBusinessAccountStars(ctx, req)
+}
+
+// TransferGift transfers an owned gift to another user.
+// NO RETRY — value operation to prevent double-transfer.
+func (b *Bot) TransferGift(ctx context.Context, req sender.TransferGiftRequest) error {
+	return b.sender.TransferGift(ctx, req)
+}
+
+// TripBreaker forces the circuit breakers open: requests fail with
+// ErrCircuitOpen until ResetBreaker is called. Use it to shed traffic
+// during an incident.
+func (b *Bot) TripBreaker() {
+	b.sender.TripBreaker()
+}
+
+// UnbanChatMember unbans a previously banned user in a supergroup or channel.
+func (b *Bot) UnbanChatMember(ctx context.Context, chatID tg.ChatID, userID int64, opts ...sender.UnbanOption) error {
+	return b.sender.UnbanChatMember(ctx, chatID, userID, opts...)
+}
+
+// UnbanChatSenderChat unbans a previously banned channel chat.
+func (b *Bot) UnbanChatSenderChat(ctx context.Context, chatID tg.ChatID, senderChatID int64) error {
+	return b.sender.UnbanChatSenderChat(ctx, chatID, senderChatID)
+}
+
+// UnhideGeneralForumTopic unhides the General topic.
+func (b *Bot) UnhideGeneralForumTopic(ctx context.Context, chatID tg.ChatID) error {
+	return b.sender.UnhideGeneralForumTopic(ctx, chatID)
+}
+
+// Unpin unpins a message using Editable.
+func (b *Bot) Unpin(ctx context.Context, e tg.Editable) error {
+	return b.sender.Unpin(ctx, e)
+}
+
+// UnpinAllChatMessages unpins all pinned messages in a chat.
+func (b *Bot) UnpinAllChatMessages(ctx context.Context, chatID tg.ChatID) error {
+	return b.sender.UnpinAllChatMessages(ctx, chatID)
+}
+
+// UnpinAllForumTopicMessages unpins all messages in a topic.
+func (b *Bot) UnpinAllForumTopicMessages(ctx context.Context, chatID tg.ChatID, messageThreadID int) error {
+	return b.sender.UnpinAllForumTopicMessages(ctx, chatID, messageThreadID)
+}
+
+// UnpinAllGeneralForumTopicMessages unpins all messages in the General topic.
+func (b *Bot) UnpinAllGeneralForumTopicMessages(ctx context.Context, chatID tg.ChatID) error {
+	return b.sender.UnpinAllGeneralForumTopicMessages(ctx, chatID)
+}
+
+// UnpinChatMessage unpins a message in a chat.
+// If messageID is 0, unpins the most recent pinned message.
+func (b *Bot) UnpinChatMessage(ctx context.Context, chatID tg.ChatID, messageID int) error {
+	return b.sender.UnpinChatMessage(ctx, chatID, messageID)
+}
+
+// UpgradeGift upgrades an owned gift.
+// NO RETRY — value operation to prevent double-upgrade.
+func (b *Bot) UpgradeGift(ctx context.Context, req sender.UpgradeGiftRequest) error {
+	return b.sender.UpgradeGift(ctx, req)
+}
+
+// UploadStickerFile uploads a sticker file for later use in sticker sets.
+func (b *Bot) UploadStickerFile(ctx context.Context, req sender.UploadStickerFileRequest) (*tg.File, error) {
+	return b.sender.UploadStickerFile(ctx, req)
+}
+
+// VerifyChat verifies a chat on behalf of the organization.
+func (b *Bot) VerifyChat(ctx context.Context, req sender.VerifyChatRequest) error {
+	return b.sender.VerifyChat(ctx, req)
+}
+
+// VerifyUser verifies a user on behalf of the organization.
+func (b *Bot) VerifyUser(ctx context.Context, req sender.VerifyUserRequest) error {
+	return b.sender.VerifyUser(ctx, req)
+}
+
+// WatchStarBalance polls GetMyStarBalance every interval (default one
+// minute) and emits a change whenever the balance differs from the
+// previous poll. The first successful poll sets the baseline and emits
+// nothing. Poll errors are logged and skipped. The returned channel is
+// closed when ctx is done.
+func (b *Bot) WatchStarBalance(ctx context.Context, interval time.Duration) <-chan sender.StarBalanceChange {
+	return b.sender.WatchStarBalance(ctx, interval)
+}
+
+// WithChatAction sends action to chatID, then runs fn and repeats the
+// action until fn returns. The first action is sent before fn starts, and
+// none is sent once fn has returned: the refresh is cancelled and waited
+// for before WithChatAction returns. fn's error is returned; failures to
+// send the action are logged, not returned, since the indicator is
+// cosmetic; an unknown action is returned without running fn.
+//
+//	err := client.WithChatAction(ctx, chatID, tg.ChatActionTyping, func(ctx context.Context) error {
+//	    answer := llm.Complete(ctx, prompt) // slow
+//	    _, err := client.SendMessage(ctx, sender.SendMessageRequest{ChatID: chatID, Text: answer})
+//	    return err
+//	})
+func (b *Bot) WithChatAction(ctx context.Context, chatID tg.ChatID, action tg.ChatAction, fn func(ctx context.Context) error) error {
+	return b.sender.WithChatAction(ctx, chatID, action, fn)
+}
+
+// WithTyping is WithChatAction with the "typing" action.
+func (b *Bot) WithTyping(ctx context.Context, chatID tg.ChatID, fn func(ctx context.Context) error) error {
+	return b.sender.WithTyping(ctx, chatID, fn)
+}
@@ -0,0 +1,81 @@
+// Package clock abstracts time for the parts of galigo that wait: polling
+// backoff, the sender's retry sleeps, hedging and circuit breakers,
+// limiter cleanup, album collection, webhook failover and the message
+// scheduler. Production code uses Real; tests pass a fake clock (see
+// galigotest.FakeClock) to sender.WithClock, receiver.WithPollingClock or
+// galigo.WithClock and advance it instead of sleeping.
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the time and waits. It is a superset of sender.Sleeper.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Sleep waits for d or until ctx is done, returning ctx.Err() then.
+	Sleep(ctx context.Context, d time.Duration) error
+
+	// After returns a channel that receives the time once d has passed,
+	// like time.After.
+	After(d time.Duration) <-chan time.Time
+
+	// NewTimer returns a Timer that fires once d has passed, like
+	// time.NewTimer. Unlike After, a stopped Timer releases its resources,
+	// so prefer it for waits that are often abandoned.
+	NewTimer(d time.Duration) Timer
+
+	// NewTicker returns a Ticker that ticks every d, like time.NewTicker.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer delivers one tick on C unless stopped first.
+type Timer interface {
+	C() <-chan time.Time
+	// Stop prevents the Timer from firing. It reports false if the Timer
+	// had already fired or been stopped.
+	Stop() bool
+}
+
+// Ticker delivers ticks on C until stopped.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Real is the Clock backed by package time.
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func (realClock) NewTimer(d time.Duration) Timer { return realTimer{time.NewTimer(d)} }
+
+func (realClock) NewTicker(d time.Duration) Ticker { return realTicker{time.NewTicker(d)} }
+
+type realTimer struct{ t *time.Timer }
+
+func (t realTimer) C() <-chan time.Time { return t.t.C }
+func (t realTimer) Stop() bool          { return t.t.Stop() }
+
+type realTicker struct{ t *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time { return t.t.C }
+func (t realTicker) Stop()               { t.t.Stop() }
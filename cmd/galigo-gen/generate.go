@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+const header = "// Code generated by cmd/galigo-gen from Bot API %s; review before committing.\n\n"
+
+// genTypes renders objects as package tg declarations.
+func genTypes(s *Schema, objects []Object) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, header, s.VersionString())
+	b.WriteString("package tg\n\n")
+	for _, o := range objects {
+		comment(&b, o.Name, o.Description)
+		switch o.Type {
+		case "properties":
+			fmt.Fprintf(&b, "type %s struct {\n", o.Name)
+			for _, p := range o.Properties {
+				writeField(&b, p, "")
+			}
+			b.WriteString("}\n\n")
+		case "any_of":
+			// Unions are decoded by hand in galigo (see ChatMember,
+			// MessageOrigin); list the variants for the implementer.
+			variants := make([]string, 0, len(o.AnyOf))
+			for _, v := range o.AnyOf {
+				variants = append(variants, v.Reference)
+			}
+			fmt.Fprintf(&b, "// TODO: union of %s; needs custom JSON decoding.\ntype %s any\n\n", strings.Join(variants, ", "), o.Name)
+		default:
+			fmt.Fprintf(&b, "type %s struct{}\n\n", o.Name)
+		}
+	}
+	return format.Source(b.Bytes())
+}
+
+// genMethods renders request structs and Client methods for package
+// sender, following the layout of its hand-written files: a request type
+// per method, validation of required identifiers, then call/callJSON.
+func genMethods(s *Schema, methods []Method) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, header, s.VersionString())
+	b.WriteString("package sender\n\nimport (\n\t\"context\"\n\n\t\"github.com/prilive-com/galigo/tg\"\n)\n\n")
+
+	b.WriteString("// ================== Request Types ==================\n\n")
+	for _, m := range methods {
+		if len(m.Arguments) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "// %sRequest represents a %s request.\n", exported(m.Name), m.Name)
+		fmt.Fprintf(&b, "type %sRequest struct {\n", exported(m.Name))
+		for _, a := range m.Arguments {
+			writeField(&b, a, "tg.")
+		}
+		b.WriteString("}\n\n")
+	}
+
+	b.WriteString("// ================== Methods ==================\n\n")
+	for _, m := range methods {
+		writeMethod(&b, m)
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return b.Bytes(), err
+	}
+	if bytes.Contains(src, []byte("json.RawMessage")) {
+		src = bytes.Replace(src, []byte("\t\"context\"\n"), []byte("\t\"context\"\n\t\"encoding/json\"\n"), 1)
+	}
+	if !bytes.Contains(src, []byte("tg.")) {
+		src = bytes.Replace(src, []byte("\n\t\"github.com/prilive-com/galigo/tg\"\n"), nil, 1)
+		return format.Source(src)
+	}
+	return src, nil
+}
+
+func writeField(b *bytes.Buffer, a Argument, qual string) {
+	tag := a.Name
+	if !a.Required {
+		tag += ",omitempty"
+	}
+	typ := goType(a.Name, a.TypeInfo, a.Required, qual)
+	fmt.Fprintf(b, "\t%s %s `json:%q`", goName(a.Name), typ, tag)
+	if a.Required && qual != "" {
+		b.WriteString(" // required")
+	}
+	b.WriteString("\n")
+}
+
+func writeMethod(b *bytes.Buffer, m Method) {
+	name := exported(m.Name)
+	result, zero, call := returnType(m)
+
+	comment(b, name, m.Description)
+	params := "ctx context.Context"
+	payload := "struct{}{}"
+	if len(m.Arguments) > 0 {
+		params += ", req " + name + "Request"
+		payload = "req"
+	}
+	if result == "" {
+		fmt.Fprintf(b, "func (c *Client) %s(%s) error {\n", name, params)
+	} else {
+		fmt.Fprintf(b, "func (c *Client) %s(%s) (%s, error) {\n", name, params, result)
+	}
+
+	chatIDs := ""
+	for _, a := range m.Arguments {
+		if !a.Required {
+			continue
+		}
+		field := "req." + goName(a.Name)
+		fail := "return " + zero + "err"
+		failValidation := func(msg string) string {
+			return fmt.Sprintf("return %stg.NewValidationError(%q, %q)", zero, a.Name, msg)
+		}
+		switch typ := goType(a.Name, a.TypeInfo, true, "tg."); {
+		case typ == "tg.ChatID":
+			fmt.Fprintf(b, "\tif err := validateChatID(%s); err != nil {\n\t\t%s\n\t}\n", field, fail)
+			if a.Name == "chat_id" {
+				chatIDs = ", extractChatID(req.ChatID)"
+			}
+		case a.Name == "user_id":
+			fmt.Fprintf(b, "\tif %s <= 0 {\n\t\t%s\n\t}\n", field, failValidation("must be positive"))
+		case typ == "int" && strings.HasSuffix(a.Name, "message_id"):
+			fmt.Fprintf(b, "\tif err := validateMessageID(%s); err != nil {\n\t\t%s\n\t}\n", field, fail)
+		case typ == "string":
+			fmt.Fprintf(b, "\tif %s == \"\" {\n\t\t%s\n\t}\n", field, failValidation("is required"))
+		case strings.HasPrefix(typ, "[]"):
+			fmt.Fprintf(b, "\tif len(%s) == 0 {\n\t\t%s\n\t}\n", field, failValidation("is required"))
+		}
+	}
+
+	if result == "" {
+		fmt.Fprintf(b, "\treturn c.callJSON(ctx, %q, %s, nil%s)\n}\n\n", m.Name, payload, chatIDs)
+		return
+	}
+	if call == "" {
+		fmt.Fprintf(b, "\treturn call[%s](c, ctx, %q, %s%s)\n}\n\n", strings.TrimPrefix(result, "*"), m.Name, payload, chatIDs)
+		return
+	}
+	fmt.Fprintf(b, "\tres, err := call[%s](c, ctx, %q, %s%s)\n\tif err != nil {\n\t\treturn %serr\n\t}\n\treturn *res, nil\n}\n\n",
+		call, m.Name, payload, chatIDs, zero)
+}
+
+// returnType returns the method's Go result type ("" for bool results,
+// which galigo maps to a plain error), its zero value prefix for return
+// statements, and, for non-pointer results, the type to decode.
+func returnType(m Method) (result, zero, decode string) {
+	t := m.ReturnType
+	switch t.Type {
+	case "bool", "":
+		return "", "", ""
+	case "reference":
+		return "*tg." + t.Reference, "nil, ", ""
+	case "array":
+		typ := goType("", t, true, "tg.")
+		return typ, "nil, ", typ
+	case "any_of":
+		// e.g. "Message or True" for edits of inline messages
+		for _, alt := range t.AnyOf {
+			if alt.Type == "reference" {
+				return "*tg." + alt.Reference, "nil, ", ""
+			}
+		}
+	case "string":
+		return "string", `"", `, "string"
+	case "integer":
+		return "int", "0, ", "int"
+	}
+	return "json.RawMessage", "nil, ", "json.RawMessage"
+}
+
+// comment writes a doc comment for name from a Bot API description.
+func comment(b *bytes.Buffer, name, desc string) {
+	s := firstSentence(desc)
+	switch {
+	case strings.HasPrefix(s, "This object represents "):
+		s = name + " represents " + strings.TrimPrefix(s, "This object represents ")
+	case strings.HasPrefix(s, "This object "):
+		s = name + " " + strings.TrimPrefix(s, "This object ")
+	case strings.HasPrefix(s, "Use this method to "):
+		s = name + " is used to " + strings.TrimPrefix(s, "Use this method to ")
+	case s == "":
+		s = name + " is generated from the Bot API schema."
+	default:
+		// "Changes the ...", "Returns the ..."
+		s = name + " " + strings.ToLower(s[:1]) + s[1:]
+	}
+	fmt.Fprintf(b, "// %s\n", s)
+}
@@ -0,0 +1,244 @@
+// Command galigo-gen generates Bot API bindings from a machine-readable
+// schema and reports what galigo does not implement yet.
+//
+// It reads the custom.json schema published by the community tg-bot-api
+// project for every Bot API release. Generated code follows the layout of
+// packages tg and sender: tg types, and sender request structs plus Client
+// methods that validate required identifiers and go through call/callJSON.
+// Only methods and types missing from the tree are generated by default,
+// so the output compiles next to the hand-written code.
+//
+// Usage:
+//
+//	go run ./cmd/galigo-gen -schema custom.json -diff
+//	go run ./cmd/galigo-gen -schema custom.json \
+//	    -sender sender/generated.go -tg tg/generated.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	schemaPath = flag.String("schema", "", "Bot API schema (tg-bot-api custom.json)")
+	root       = flag.String("root", ".", "galigo repository root")
+	diffOnly   = flag.Bool("diff", false, "report unimplemented methods and types, generate nothing")
+	senderOut  = flag.String("sender", "", "write request types and Client methods to this file")
+	tgOut      = flag.String("tg", "", "write Bot API types to this file")
+	all        = flag.Bool("all", false, "generate every method and type, not only missing ones")
+	only       = flag.String("only", "", "comma-separated method or type names to generate")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("galigo-gen: ")
+	flag.Parse()
+	if *schemaPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	schema, err := loadSchema(*schemaPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	implemented, err := implementedMethods(filepath.Join(*root, "sender"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	types, err := declaredTypes(filepath.Join(*root, "tg"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var methods []Method
+	for _, m := range schema.Methods {
+		if selected(m.Name, !implemented[m.Name]) {
+			methods = append(methods, m)
+		}
+	}
+	var objects []Object
+	for _, o := range schema.Objects {
+		if selected(o.Name, !types[o.Name]) {
+			objects = append(objects, o)
+		}
+	}
+
+	if *diffOnly || (*senderOut == "" && *tgOut == "") {
+		report(schema, implemented, types)
+		return
+	}
+	if *senderOut != "" {
+		src, err := genMethods(schema, methods)
+		write(*senderOut, src, err)
+	}
+	if *tgOut != "" {
+		src, err := genTypes(schema, objects)
+		write(*tgOut, src, err)
+	}
+}
+
+// selected reports whether name is generated: listed in -only, or
+// missing (or -all) when -only is empty.
+func selected(name string, missing bool) bool {
+	if *only != "" {
+		return slices.Contains(strings.Split(*only, ","), name)
+	}
+	return missing || *all
+}
+
+func write(path string, src []byte, err error) {
+	if err != nil {
+		log.Fatalf("%s: %v\n%s", path, err, src)
+	}
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("wrote %s\n", path)
+}
+
+// report prints the methods and types of the schema that galigo lacks.
+func report(s *Schema, implemented, types map[string]bool) {
+	var methods, objects []string
+	for _, m := range s.Methods {
+		if !implemented[m.Name] {
+			methods = append(methods, m.Name)
+		}
+	}
+	for _, o := range s.Objects {
+		if !types[o.Name] && o.Name != "InputFile" {
+			objects = append(objects, o.Name)
+		}
+	}
+	fmt.Printf("Bot API %s: %d methods, %d types\n", s.VersionString(), len(s.Methods), len(s.Objects))
+	fmt.Printf("\nUnimplemented methods (%d):\n", len(methods))
+	for _, m := range methods {
+		fmt.Printf("  %s\n", m)
+	}
+	fmt.Printf("\nMissing types (%d):\n", len(objects))
+	for _, o := range objects {
+		fmt.Printf("  %s\n", o)
+	}
+}
+
+// methodArgs maps the sender helpers that send a Bot API request to the
+// position of their method-name argument.
+var methodArgs = map[string]int{
+	"call":           2, // call[T](c, ctx, method, ...)
+	"callJSON":       1, // c.callJSON(ctx, method, ...)
+	"executeRequest": 1,
+	"doRequest":      1,
+}
+
+// implementedMethods returns the Bot API methods the sender package
+// calls: the string literals passed as the method name to the helpers in
+// methodArgs.
+func implementedMethods(dir string) (map[string]bool, error) {
+	files, err := parseDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	methods := make(map[string]bool)
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			pos, ok := methodArgs[calleeName(call.Fun)]
+			if !ok || pos >= len(call.Args) {
+				return true
+			}
+			if lit, ok := call.Args[pos].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				if s, err := strconv.Unquote(lit.Value); err == nil {
+					methods[s] = true
+				}
+			}
+			return true
+		})
+	}
+	return methods, nil
+}
+
+// calleeName returns the name of the called function or method, without
+// receiver or type arguments.
+func calleeName(fun ast.Expr) string {
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f.Name
+	case *ast.SelectorExpr:
+		return f.Sel.Name
+	case *ast.IndexExpr:
+		return calleeName(f.X)
+	case *ast.IndexListExpr:
+		return calleeName(f.X)
+	}
+	return ""
+}
+
+// declaredTypes returns the type names declared in package tg.
+func declaredTypes(dir string) (map[string]bool, error) {
+	files, err := parseDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				names[spec.(*ast.TypeSpec).Name.Name] = true
+			}
+		}
+	}
+	return names, nil
+}
+
+// parseDir parses the non-test Go files of dir, skipping generated
+// output of this tool so that it can be regenerated.
+func parseDir(dir string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if isOutput(path) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+func isOutput(path string) bool {
+	for _, out := range []string{*senderOut, *tgOut} {
+		if out != "" && filepath.Clean(out) == filepath.Clean(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImplementedMethods(t *testing.T) {
+	dir := t.TempDir()
+	src := `package sender
+
+func (c *Client) SendDice(ctx context.Context, req SendDiceRequest) (*tg.Message, error) {
+	if req.Emoji == "" {
+		return nil, tg.NewValidationError("sendPoll", "emoji")
+	}
+	return call[tg.Message](c, ctx, "sendDice", req, "chat_id")
+}
+
+func (c *Client) SetMyName(ctx context.Context, name string) error {
+	return c.callJSON(ctx, "setMyName", SetMyNameRequest{Name: name}, nil)
+}
+
+func (c *Client) LogOut(ctx context.Context) error {
+	_, err := c.executeRequest(ctx, "logOut", struct{}{})
+	c.logger.Debug("getUpdates", "method", "getMe")
+	return err
+}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "methods.go"), []byte(src), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "methods_test.go"), []byte(`package sender
+
+func x() { call[int](nil, nil, "sendPoll", nil) }
+`), 0o644))
+
+	methods, err := implementedMethods(dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"sendDice": true, "setMyName": true, "logOut": true}, methods)
+}
+
+func TestImplementedMethods_SenderPackage(t *testing.T) {
+	methods, err := implementedMethods(filepath.Join("..", "..", "sender"))
+	require.NoError(t, err)
+	for _, name := range []string{"sendMessage", "getMe", "setMyCommands", "forwardMessages", "getChatAdministrators"} {
+		assert.True(t, methods[name], name)
+	}
+	assert.False(t, methods["chat_id"])
+}
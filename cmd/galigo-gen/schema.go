@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Schema is a machine-readable Bot API description in the format of the
+// community tg-bot-api project (https://github.com/ark0f/tg-bot-api),
+// which publishes a custom.json for every Bot API release.
+type Schema struct {
+	Version struct {
+		Major int `json:"major"`
+		Minor int `json:"minor"`
+		Patch int `json:"patch"`
+	} `json:"version"`
+	Methods []Method `json:"methods"`
+	Objects []Object `json:"objects"`
+}
+
+// Method is a Bot API method.
+type Method struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Arguments   []Argument `json:"arguments"`
+	ReturnType  TypeInfo   `json:"return_type"`
+}
+
+// Object is a Bot API type.
+type Object struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Type        string     `json:"type"` // "properties", "any_of" or "unknown"
+	Properties  []Argument `json:"properties"`
+	AnyOf       []TypeInfo `json:"any_of"`
+}
+
+// Argument is a method argument or an object property.
+type Argument struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+	TypeInfo
+}
+
+// TypeInfo describes a value type: "integer", "float", "string", "bool",
+// "reference" (to an object), "array" or "any_of".
+type TypeInfo struct {
+	Type      string     `json:"type"`
+	Reference string     `json:"reference,omitempty"`
+	Array     *TypeInfo  `json:"array,omitempty"`
+	AnyOf     []TypeInfo `json:"any_of,omitempty"`
+	Default   any        `json:"default,omitempty"`
+}
+
+// loadSchema reads a schema file.
+func loadSchema(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(s.Methods) == 0 && len(s.Objects) == 0 {
+		return nil, fmt.Errorf("%s: no methods or objects; not a tg-bot-api schema?", path)
+	}
+	return &s, nil
+}
+
+// VersionString returns the Bot API version, e.g. "9.1".
+func (s *Schema) VersionString() string {
+	v := fmt.Sprintf("%d.%d", s.Version.Major, s.Version.Minor)
+	if s.Version.Patch > 0 {
+		v += fmt.Sprintf(".%d", s.Version.Patch)
+	}
+	return v
+}
+
+// initialisms are written in upper case in Go names.
+var initialisms = map[string]string{
+	"id":   "ID",
+	"ids":  "IDs",
+	"url":  "URL",
+	"urls": "URLs",
+	"html": "HTML",
+	"ip":   "IP",
+	"api":  "API",
+	"json": "JSON",
+}
+
+// goName converts a snake_case Bot API name to a Go identifier.
+func goName(snake string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(snake, "_") {
+		if part == "" {
+			continue
+		}
+		if up, ok := initialisms[part]; ok {
+			b.WriteString(up)
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// exported returns a method name with its first letter in upper case.
+func exported(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// goType returns the Go type of a field or argument named name. qual is
+// the package qualifier for Bot API types ("tg." outside package tg).
+func goType(name string, t TypeInfo, required bool, qual string) string {
+	switch t.Type {
+	case "integer":
+		return intType(name)
+	case "float":
+		return "float64"
+	case "string":
+		return "string"
+	case "bool":
+		return "bool"
+	case "array":
+		if t.Array == nil {
+			return "[]any"
+		}
+		return "[]" + goType(name, *t.Array, true, qual)
+	case "reference":
+		switch {
+		case t.Reference == "InputFile":
+			return inputFileType(qual)
+		case strings.HasSuffix(name, "reply_markup"):
+			return "any"
+		case required:
+			return qual + t.Reference
+		default:
+			return "*" + qual + t.Reference
+		}
+	case "any_of":
+		if isChatID(t) {
+			return qual + "ChatID"
+		}
+		for _, alt := range t.AnyOf {
+			if alt.Type == "reference" && alt.Reference == "InputFile" {
+				return inputFileType(qual)
+			}
+		}
+		return "any"
+	}
+	return "any"
+}
+
+// intType follows galigo's conventions: message IDs are int, other IDs
+// and dates int64, counts and sizes int.
+func intType(name string) string {
+	switch {
+	case name == "message_id" || name == "message_thread_id" || strings.HasSuffix(name, "_message_id"):
+		return "int"
+	case strings.HasSuffix(name, "_id") || name == "id" || name == "date" || strings.HasSuffix(name, "_date") || strings.HasSuffix(name, "_size"):
+		return "int64"
+	}
+	return "int"
+}
+
+// isChatID reports whether t is "Integer or String", the chat_id type.
+func isChatID(t TypeInfo) bool {
+	if len(t.AnyOf) != 2 {
+		return false
+	}
+	kinds := t.AnyOf[0].Type + "," + t.AnyOf[1].Type
+	return kinds == "integer,string" || kinds == "string,integer"
+}
+
+// inputFileType is the uploadable file type, defined in package sender.
+func inputFileType(qual string) string {
+	if qual == "" {
+		return "any" // package tg cannot refer to sender.InputFile
+	}
+	return "InputFile"
+}
+
+// firstSentence returns the first sentence of a description for a doc
+// comment.
+func firstSentence(desc string) string {
+	desc = strings.Join(strings.Fields(desc), " ")
+	if i := strings.Index(desc, ". "); i >= 0 {
+		desc = desc[:i+1]
+	}
+	return desc
+}
@@ -2,11 +2,19 @@ package cleanup
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/prilive-com/galigo/cmd/galigo-testbot/engine"
 )
 
+// maxDeleteBatch is the most message IDs deleteMessages accepts.
+const maxDeleteBatch = 100
+
 // Cleaner handles message cleanup.
 type Cleaner struct {
 	sender engine.SenderClient
@@ -32,6 +40,9 @@ func (c *Cleaner) CleanupMessages(ctx context.Context, rt *engine.Runtime) (dele
 			errors++
 		} else {
 			deleted++
+			if rt.Tracker != nil {
+				rt.Tracker.Forget(cm.ChatID, cm.MessageID)
+			}
 		}
 	}
 
@@ -53,3 +64,132 @@ func (c *Cleaner) CleanupMessages(ctx context.Context, rt *engine.Runtime) (dele
 
 	return deleted, errors
 }
+
+// Options select the tracked messages CleanupTracked deletes.
+type Options struct {
+	ChatID    int64         // 0 = every tracked chat
+	OlderThan time.Duration // only messages sent at least this long ago
+	NewerThan time.Duration // only messages sent less than this long ago
+	DryRun    bool          // report the messages without deleting them
+}
+
+// ParseOptions parses /cleanup arguments: "dry-run", "older=<duration>",
+// "newer=<duration>" and "chat=<id>", e.g. "older=1h dry-run".
+func ParseOptions(args string) (Options, error) {
+	var opts Options
+	for _, arg := range strings.Fields(args) {
+		key, value, _ := strings.Cut(arg, "=")
+		var err error
+		switch key {
+		case "dry-run", "dry":
+			opts.DryRun = true
+		case "older":
+			opts.OlderThan, err = time.ParseDuration(value)
+		case "newer":
+			opts.NewerThan, err = time.ParseDuration(value)
+		case "chat":
+			opts.ChatID, err = strconv.ParseInt(value, 10, 64)
+		default:
+			return opts, fmt.Errorf("unknown cleanup option %q (want dry-run, older=, newer=, chat=)", arg)
+		}
+		if err != nil {
+			return opts, fmt.Errorf("cleanup option %q: %w", arg, err)
+		}
+	}
+	return opts, nil
+}
+
+// match reports whether a message sent at sentAt passes the age filters.
+func (o Options) match(sentAt, now time.Time) bool {
+	age := now.Sub(sentAt)
+	if o.OlderThan > 0 && age < o.OlderThan {
+		return false
+	}
+	if o.NewerThan > 0 && age >= o.NewerThan {
+		return false
+	}
+	return true
+}
+
+// Result reports the outcome of CleanupTracked.
+type Result struct {
+	Matched []engine.CreatedMessage // messages selected by the options, oldest first
+	Deleted int
+	Failed  int // still tracked, for a later cleanup
+	DryRun  bool
+}
+
+// maxListed bounds the messages listed by a dry-run summary.
+const maxListed = 20
+
+// Summary formats the result for a chat reply.
+func (r *Result) Summary(now time.Time) string {
+	var sb strings.Builder
+	if !r.DryRun {
+		fmt.Fprintf(&sb, "Cleanup: deleted %d of %d tracked message(s)", r.Deleted, len(r.Matched))
+		if r.Failed > 0 {
+			fmt.Fprintf(&sb, ", %d failed (kept for the next /cleanup)", r.Failed)
+		}
+		return sb.String()
+	}
+
+	fmt.Fprintf(&sb, "Cleanup dry run: would delete %d message(s)", len(r.Matched))
+	for i, m := range r.Matched {
+		if i == maxListed {
+			fmt.Fprintf(&sb, "\n  ... and %d more", len(r.Matched)-maxListed)
+			break
+		}
+		fmt.Fprintf(&sb, "\n  chat %d, message %d, sent %s ago", m.ChatID, m.MessageID, now.Sub(m.SentAt).Round(time.Second))
+	}
+	return sb.String()
+}
+
+// CleanupTracked deletes the messages recorded in store by earlier runs
+// that match opts, in batches per chat. Deleted messages, and messages
+// Telegram no longer has, are removed from the store; messages of a batch
+// that failed stay tracked.
+func (c *Cleaner) CleanupTracked(ctx context.Context, store *Store, opts Options) (*Result, error) {
+	chats := []int64{opts.ChatID}
+	if opts.ChatID == 0 {
+		var err error
+		if chats, err = store.Chats(); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	result := &Result{DryRun: opts.DryRun}
+	for _, chatID := range chats {
+		msgs, err := store.Messages(chatID)
+		if err != nil {
+			return result, err
+		}
+		msgs = slices.DeleteFunc(msgs, func(m engine.CreatedMessage) bool { return !opts.match(m.SentAt, now) })
+		result.Matched = append(result.Matched, msgs...)
+		if opts.DryRun {
+			continue
+		}
+
+		for batch := range slices.Chunk(msgs, maxDeleteBatch) {
+			ids := make([]int, len(batch))
+			for i, m := range batch {
+				ids[i] = m.MessageID
+			}
+			// deleteMessages skips messages that are already gone.
+			if err := c.sender.DeleteMessages(ctx, chatID, ids); err != nil {
+				c.logger.Warn("failed to delete tracked messages", "chat_id", chatID, "count", len(ids), "error", err)
+				result.Failed += len(ids)
+				continue
+			}
+			result.Deleted += len(ids)
+			if err := store.Remove(chatID, ids); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	slices.SortStableFunc(result.Matched, func(a, b engine.CreatedMessage) int { return a.SentAt.Compare(b.SentAt) })
+	c.logger.Info("tracked cleanup completed",
+		"matched", len(result.Matched), "deleted", result.Deleted, "failed", result.Failed, "dry_run", opts.DryRun)
+	return result, nil
+}
@@ -0,0 +1,151 @@
+package cleanup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prilive-com/galigo/cmd/galigo-testbot/engine"
+)
+
+// Store persists created messages in a directory, one JSON file per chat,
+// so that messages left behind by a failed or interrupted run can be
+// deleted by a later /cleanup. It implements engine.MessageTracker and is
+// safe for concurrent use within one process.
+type Store struct {
+	dir    string
+	logger *slog.Logger
+	mu     sync.Mutex
+}
+
+// NewStore creates a store in dir, typically <StorageDir>/tracked. The
+// directory is created on the first write.
+func NewStore(dir string, logger *slog.Logger) *Store {
+	return &Store{dir: dir, logger: logger}
+}
+
+var _ engine.MessageTracker = (*Store)(nil)
+
+// Track records a message. Errors are logged, since a failed write must
+// not fail the scenario that sent the message.
+func (s *Store) Track(m engine.CreatedMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msgs, err := s.load(m.ChatID)
+	if err == nil {
+		err = s.save(m.ChatID, append(msgs, m))
+	}
+	if err != nil {
+		s.logger.Warn("failed to track message", "chat_id", m.ChatID, "message_id", m.MessageID, "error", err)
+	}
+}
+
+// Forget removes a message, e.g. after it was deleted.
+func (s *Store) Forget(chatID int64, messageID int) {
+	if err := s.Remove(chatID, []int{messageID}); err != nil {
+		s.logger.Warn("failed to untrack message", "chat_id", chatID, "message_id", messageID, "error", err)
+	}
+}
+
+// Remove removes messages of a chat.
+func (s *Store) Remove(chatID int64, messageIDs []int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msgs, err := s.load(chatID)
+	if err != nil {
+		return err
+	}
+	n := len(msgs)
+	msgs = slices.DeleteFunc(msgs, func(m engine.CreatedMessage) bool {
+		return slices.Contains(messageIDs, m.MessageID)
+	})
+	if len(msgs) == n {
+		return nil
+	}
+	return s.save(chatID, msgs)
+}
+
+// Messages returns the tracked messages of a chat, oldest first.
+func (s *Store) Messages(chatID int64) ([]engine.CreatedMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load(chatID)
+}
+
+// Chats returns the IDs of the chats with tracked messages.
+func (s *Store) Chats() ([]int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var chats []int64
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || e.IsDir() {
+			continue
+		}
+		if id, err := strconv.ParseInt(name, 10, 64); err == nil {
+			chats = append(chats, id)
+		}
+	}
+	slices.Sort(chats)
+	return chats, nil
+}
+
+func (s *Store) path(chatID int64) string {
+	return filepath.Join(s.dir, strconv.FormatInt(chatID, 10)+".json")
+}
+
+// load reads the messages of a chat. s.mu must be held.
+func (s *Store) load(chatID int64) ([]engine.CreatedMessage, error) {
+	data, err := os.ReadFile(s.path(chatID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var msgs []engine.CreatedMessage
+	if err := json.Unmarshal(data, &msgs); err != nil {
+		return nil, fmt.Errorf("read %s: %w", s.path(chatID), err)
+	}
+	return msgs, nil
+}
+
+// save replaces the messages of a chat, removing the file when none are
+// left. s.mu must be held.
+func (s *Store) save(chatID int64, msgs []engine.CreatedMessage) error {
+	path := s.path(chatID)
+	if len(msgs) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(msgs, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write and rename so an interrupted run never leaves a torn file.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -28,6 +28,9 @@ type Config struct {
 	Max429Retries     int
 	AllowStress       bool
 
+	// Parallel runs (--parallel): one test chat per worker for isolation
+	ParallelChatIDs []int64
+
 	// Webhook (for future use)
 	WebhookPublicURL   string
 	WebhookSecretToken string
@@ -85,6 +88,17 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("at least one admin required in TESTBOT_ADMINS")
 	}
 
+	// Parallel chat pool (optional)
+	if poolStr := os.Getenv("TESTBOT_PARALLEL_CHAT_IDS"); poolStr != "" {
+		for _, s := range strings.Split(poolStr, ",") {
+			id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid TESTBOT_PARALLEL_CHAT_IDS entry %q: %w", s, err)
+			}
+			cfg.ParallelChatIDs = append(cfg.ParallelChatIDs, id)
+		}
+	}
+
 	// Webhook config (optional)
 	cfg.WebhookPublicURL = os.Getenv("TESTBOT_WEBHOOK_PUBLIC_URL")
 	cfg.WebhookSecretToken = os.Getenv("TESTBOT_WEBHOOK_SECRET_TOKEN")
@@ -92,16 +106,6 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
-// IsAdmin checks if a user ID is in the admin list.
-func (c *Config) IsAdmin(userID int64) bool {
-	for _, id := range c.Admins {
-		if id == userID {
-			return true
-		}
-	}
-	return false
-}
-
 func getEnvDefault(key, defaultVal string) string {
 	if val := os.Getenv(key); val != "" {
 		return val
@@ -0,0 +1,145 @@
+// Package conversation loads scripted conversations for black-box
+// acceptance tests of another bot. A script is a YAML file listing turns;
+// each turn sends a message to the test chat and/or waits for a reply
+// matching a regular expression:
+//
+//	name: echo-bot
+//	bot: "@my_echo_bot"
+//	timeout: 15s
+//	turns:
+//	  - send: /start
+//	    expect: (?i)welcome
+//	  - send: /echo hello
+//	    expect: ^hello$
+//	    timeout: 5s
+//
+// The script becomes a single engine scenario, so it runs on the usual
+// runner and lands in the usual reports.
+package conversation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/prilive-com/galigo/cmd/galigo-testbot/engine"
+)
+
+// DefaultTimeout is how long a turn waits for its reply when neither the
+// turn nor the script sets a timeout.
+const DefaultTimeout = 30 * time.Second
+
+// Script is a parsed conversation file.
+type Script struct {
+	Name        string        `yaml:"name"`
+	Description string        `yaml:"description"`
+	Bot         string        `yaml:"bot"`     // Username of the bot under test; "" accepts any sender
+	Timeout     time.Duration `yaml:"timeout"` // Default reply timeout for all turns
+	Turns       []Turn        `yaml:"turns"`
+}
+
+// Turn is one exchange: a message to send, a reply to wait for, or both.
+type Turn struct {
+	Send    string        `yaml:"send"`
+	Expect  string        `yaml:"expect"`  // Regular expression matched against the reply text or caption
+	Timeout time.Duration `yaml:"timeout"` // Overrides Script.Timeout
+
+	pattern *regexp.Regexp
+}
+
+// Load reads and parses a conversation file. A script without a name is
+// named after the file.
+func Load(path string) (*Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if s.Name == "" {
+		s.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return s, nil
+}
+
+// Parse parses a conversation script. Unknown keys are rejected so that a
+// misspelt key does not silently turn into a turn without a check.
+func Parse(data []byte) (*Script, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var s Script
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("parse conversation: %w", err)
+	}
+	if len(s.Turns) == 0 {
+		return nil, errors.New("conversation has no turns")
+	}
+	for i := range s.Turns {
+		t := &s.Turns[i]
+		if t.Send == "" && t.Expect == "" {
+			return nil, fmt.Errorf("turn %d: send or expect required", i+1)
+		}
+		if t.Timeout < 0 {
+			return nil, fmt.Errorf("turn %d: negative timeout", i+1)
+		}
+		if t.Expect != "" {
+			re, err := regexp.Compile(t.Expect)
+			if err != nil {
+				return nil, fmt.Errorf("turn %d: expect: %w", i+1, err)
+			}
+			t.pattern = re
+		}
+	}
+	return &s, nil
+}
+
+// Scenario returns the script as a scenario: a sendMessage step per send,
+// an expectReply step per expect, and a final cleanup of the sent messages.
+// The scenario timeout leaves room for every turn to wait its full timeout.
+func (s *Script) Scenario() engine.Scenario {
+	var steps []engine.Step
+	budget := time.Minute
+	for _, t := range s.Turns {
+		if t.Send != "" {
+			steps = append(steps, &engine.SendMessageStep{Text: t.Send})
+		}
+		if t.pattern != nil {
+			timeout := s.turnTimeout(t)
+			steps = append(steps, &engine.ExpectReplyStep{Pattern: t.pattern, From: s.Bot, Timeout: timeout})
+			budget += timeout
+		}
+	}
+	steps = append(steps, &engine.CleanupStep{})
+
+	desc := s.Description
+	if desc == "" {
+		desc = fmt.Sprintf("Scripted conversation (%d turns)", len(s.Turns))
+	}
+	return &engine.BaseScenario{
+		ScenarioName:        "Conversation_" + s.Name,
+		ScenarioDescription: desc,
+		CoveredMethods:      []string{"sendMessage"},
+		ScenarioSteps:       steps,
+		ScenarioTimeout:     budget,
+	}
+}
+
+func (s *Script) turnTimeout(t Turn) time.Duration {
+	switch {
+	case t.Timeout > 0:
+		return t.Timeout
+	case s.Timeout > 0:
+		return s.Timeout
+	default:
+		return DefaultTimeout
+	}
+}
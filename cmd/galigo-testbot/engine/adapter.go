@@ -45,22 +45,11 @@ func (a *SenderAdapter) GetMe(ctx context.Context) (*tg.User, error) {
 
 // SendMessage sends a text message.
 func (a *SenderAdapter) SendMessage(ctx context.Context, chatID int64, text string, opts ...SendOption) (*tg.Message, error) {
-	options := &SendOptions{}
-	for _, opt := range opts {
-		opt(options)
-	}
-
 	req := sender.SendMessageRequest{
-		ChatID:             chatID,
-		Text:               text,
-		ParseMode:          tg.ParseMode(options.ParseMode),
-		LinkPreviewOptions: options.LinkPreviewOptions,
-	}
-
-	if options.ReplyMarkup != nil {
-		req.ReplyMarkup = options.ReplyMarkup
+		ChatID: chatID,
+		Text:   text,
 	}
-
+	req.ApplySendOptions(opts...)
 	return a.client.SendMessage(ctx, req)
 }
 
@@ -100,7 +89,7 @@ func (a *SenderAdapter) CopyMessage(ctx context.Context, chatID, fromChatID int6
 }
 
 // SendChatAction sends a chat action.
-func (a *SenderAdapter) SendChatAction(ctx context.Context, chatID int64, action string) error {
+func (a *SenderAdapter) SendChatAction(ctx context.Context, chatID int64, action tg.ChatAction) error {
 	return a.client.SendChatAction(ctx, chatID, action)
 }
 
@@ -120,17 +109,12 @@ func mediaInputToInputFile(m MediaInput) sender.InputFile {
 
 // SendPhoto sends a photo with optional caption and parse mode.
 func (a *SenderAdapter) SendPhoto(ctx context.Context, chatID int64, photo MediaInput, opts ...SendOption) (*tg.Message, error) {
-	options := &SendOptions{}
-	for _, opt := range opts {
-		opt(options)
+	req := sender.SendPhotoRequest{
+		ChatID: chatID,
+		Photo:  mediaInputToInputFile(photo),
 	}
-
-	return a.client.SendPhoto(ctx, sender.SendPhotoRequest{
-		ChatID:    chatID,
-		Photo:     mediaInputToInputFile(photo),
-		Caption:   options.Caption,
-		ParseMode: tg.ParseMode(options.ParseMode),
-	})
+	req.ApplySendOptions(opts...)
+	return a.client.SendPhoto(ctx, req)
 }
 
 // SendDocument sends a document.
@@ -451,6 +435,28 @@ func (a *SenderAdapter) GetAvailableGifts(ctx context.Context) (*tg.Gifts, error
 	return a.client.GetAvailableGifts(ctx)
 }
 
+// ================= Extended: Verification =================
+
+// VerifyChat verifies a chat on behalf of the bot's organization.
+func (a *SenderAdapter) VerifyChat(ctx context.Context, chatID int64, description string) error {
+	return a.client.VerifyChat(ctx, sender.VerifyChatRequest{ChatID: chatID, CustomDescription: description})
+}
+
+// RemoveChatVerification removes a chat's verification.
+func (a *SenderAdapter) RemoveChatVerification(ctx context.Context, chatID int64) error {
+	return a.client.RemoveChatVerification(ctx, chatID)
+}
+
+// VerifyUser verifies a user on behalf of the bot's organization.
+func (a *SenderAdapter) VerifyUser(ctx context.Context, userID int64, description string) error {
+	return a.client.VerifyUser(ctx, sender.VerifyUserRequest{UserID: userID, CustomDescription: description})
+}
+
+// RemoveUserVerification removes a user's verification.
+func (a *SenderAdapter) RemoveUserVerification(ctx context.Context, userID int64) error {
+	return a.client.RemoveUserVerification(ctx, userID)
+}
+
 // ================= Extended: Checklists =================
 
 // SendChecklist sends a checklist message.
@@ -536,7 +542,7 @@ func (a *SenderAdapter) SetMessageReaction(ctx context.Context, chatID int64, me
 		ChatID:    chatID,
 		MessageID: messageID,
 		Reaction: []sender.ReactionType{
-			{Type: "emoji", Emoji: emoji},
+			tg.EmojiReaction(emoji),
 		},
 		IsBig: isBig,
 	})
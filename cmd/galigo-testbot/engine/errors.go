@@ -1,23 +1,16 @@
 package engine
 
-import "errors"
+import "github.com/prilive-com/galigo/e2e"
 
 // SkipError indicates a scenario was skipped due to missing prerequisites.
-type SkipError struct {
-	Reason string
-}
-
-func (e SkipError) Error() string {
-	return "skipped: " + e.Reason
-}
+type SkipError = e2e.SkipError
 
 // Skip returns a SkipError with the given reason.
 func Skip(reason string) error {
-	return SkipError{Reason: reason}
+	return e2e.Skip(reason)
 }
 
 // IsSkip returns true if err is a SkipError.
 func IsSkip(err error) bool {
-	var skipErr SkipError
-	return errors.As(err, &skipErr)
+	return e2e.IsSkip(err)
 }
@@ -0,0 +1,17 @@
+package engine
+
+import (
+	"io"
+
+	"github.com/prilive-com/galigo/e2e"
+)
+
+// ReportWriter renders a finalized run report in one output format.
+type ReportWriter interface {
+	// Format is the name used with --report-format, e.g. "junit".
+	Format() string
+	// Extension is the file extension including the dot, e.g. ".xml".
+	Extension() string
+	// WriteReport renders report to w.
+	WriteReport(w io.Writer, report *e2e.Report) error
+}
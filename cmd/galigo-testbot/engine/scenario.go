@@ -5,70 +5,35 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/prilive-com/galigo/e2e"
 	"github.com/prilive-com/galigo/sender"
 	"github.com/prilive-com/galigo/tg"
+	"github.com/prilive-com/galigo/tg/sendopt"
 )
 
-// Scenario is a named sequence of steps that declares method coverage.
-type Scenario interface {
-	Name() string
-	Description() string
-	Covers() []string // Methods this scenario exercises
-	Steps() []Step
-	Timeout() time.Duration
-}
-
-// BaseScenario provides common implementation.
-type BaseScenario struct {
-	ScenarioName        string
-	ScenarioDescription string
-	CoveredMethods      []string
-	ScenarioSteps       []Step
-	ScenarioTimeout     time.Duration
-}
-
-func (s *BaseScenario) Name() string           { return s.ScenarioName }
-func (s *BaseScenario) Description() string    { return s.ScenarioDescription }
-func (s *BaseScenario) Covers() []string       { return s.CoveredMethods }
-func (s *BaseScenario) Steps() []Step          { return s.ScenarioSteps }
-func (s *BaseScenario) Timeout() time.Duration { return s.ScenarioTimeout }
-
-// Step represents a single test step.
-type Step interface {
-	Name() string
-	Execute(ctx context.Context, rt *Runtime) (*StepResult, error)
-}
-
-// StepResult captures evidence from step execution.
-type StepResult struct {
-	StepName   string        `json:"step_name"`
-	Method     string        `json:"method,omitempty"`
-	Duration   time.Duration `json:"duration"`
-	Success    bool          `json:"success"`
-	Error      string        `json:"error,omitempty"`
-	MessageIDs []int         `json:"message_ids,omitempty"`
-	FileIDs    []string      `json:"file_ids,omitempty"`
-	Evidence   any           `json:"evidence,omitempty"`
-}
-
-// ScenarioResult captures the result of running a scenario.
-type ScenarioResult struct {
-	ScenarioName string        `json:"scenario_name"`
-	Covers       []string      `json:"covers"`
-	StartTime    time.Time     `json:"start_time"`
-	EndTime      time.Time     `json:"end_time"`
-	Duration     time.Duration `json:"duration"`
-	Success      bool          `json:"success"`
-	Skipped      bool          `json:"skipped,omitempty"`
-	SkipReason   string        `json:"skip_reason,omitempty"`
-	Error        string        `json:"error,omitempty"`
-	Steps        []StepResult  `json:"steps"`
-}
+// Scenario, Step and result types come from the public e2e package,
+// instantiated with the testbot Runtime.
+type (
+	Scenario       = e2e.Scenario[*Runtime]
+	BaseScenario   = e2e.BaseScenario[*Runtime]
+	Step           = e2e.Step[*Runtime]
+	StepResult     = e2e.StepResult
+	ScenarioResult = e2e.ScenarioResult
+)
 
 // CreatedMessage tracks messages for cleanup.
 type CreatedMessage struct {
-	ChatID    int64
-	MessageID int
+	ChatID    int64     `json:"chat_id"`
+	MessageID int       `json:"message_id"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+// MessageTracker records created messages beyond the lifetime of a Runtime,
+// so that messages left behind by a failed run can be deleted later (see
+// cleanup.Store). Implementations must be safe for concurrent use.
+type MessageTracker interface {
+	Track(m CreatedMessage)
+	Forget(chatID int64, messageID int)
 }
 
 // ChatContext holds probed chat capabilities.
@@ -126,8 +91,14 @@ type Runtime struct {
 	ForumChatID int64
 	TestUserID  int64
 
+	// Tracker, if set, persists CreatedMessages across runs.
+	Tracker MessageTracker
+
 	// CallbackChan receives callback queries from polling (interactive scenarios only).
 	CallbackChan chan *tg.CallbackQuery
+
+	// MessageChan receives messages from polling (conversation scenarios only).
+	MessageChan chan *tg.Message
 }
 
 // NewRuntime creates a new runtime for scenario execution.
@@ -201,10 +172,28 @@ func (rt *Runtime) ProbeChat(ctx context.Context) error {
 
 // TrackMessage adds a message to the cleanup list.
 func (rt *Runtime) TrackMessage(chatID int64, messageID int) {
-	rt.CreatedMessages = append(rt.CreatedMessages, CreatedMessage{
+	m := CreatedMessage{
 		ChatID:    chatID,
 		MessageID: messageID,
-	})
+		SentAt:    time.Now(),
+	}
+	rt.CreatedMessages = append(rt.CreatedMessages, m)
+	if rt.Tracker != nil {
+		rt.Tracker.Track(m)
+	}
+}
+
+// UntrackMessage removes a deleted message from the cleanup list.
+func (rt *Runtime) UntrackMessage(chatID int64, messageID int) {
+	for i, cm := range rt.CreatedMessages {
+		if cm.ChatID == chatID && cm.MessageID == messageID {
+			rt.CreatedMessages = append(rt.CreatedMessages[:i], rt.CreatedMessages[i+1:]...)
+			break
+		}
+	}
+	if rt.Tracker != nil {
+		rt.Tracker.Forget(chatID, messageID)
+	}
 }
 
 // TrackStickerSet adds a sticker set name to the cleanup list.
@@ -220,7 +209,7 @@ type SenderClient interface {
 	DeleteMessage(ctx context.Context, chatID int64, messageID int) error
 	ForwardMessage(ctx context.Context, chatID, fromChatID int64, messageID int) (*tg.Message, error)
 	CopyMessage(ctx context.Context, chatID, fromChatID int64, messageID int) (*tg.MessageID, error)
-	SendChatAction(ctx context.Context, chatID int64, action string) error
+	SendChatAction(ctx context.Context, chatID int64, action tg.ChatAction) error
 
 	// Media methods (Phase B)
 	SendPhoto(ctx context.Context, chatID int64, photo MediaInput, opts ...SendOption) (*tg.Message, error)
@@ -283,6 +272,12 @@ type SenderClient interface {
 	// Extended: Gifts
 	GetAvailableGifts(ctx context.Context) (*tg.Gifts, error)
 
+	// Extended: Verification
+	VerifyChat(ctx context.Context, chatID int64, description string) error
+	RemoveChatVerification(ctx context.Context, chatID int64) error
+	VerifyUser(ctx context.Context, userID int64, description string) error
+	RemoveUserVerification(ctx context.Context, userID int64) error
+
 	// Extended: Checklists
 	SendChecklist(ctx context.Context, chatID int64, title string, tasks []string) (*tg.Message, error)
 	EditMessageChecklist(ctx context.Context, chatID int64, messageID int, title string, tasks []ChecklistTaskInput) (*tg.Message, error)
@@ -354,43 +349,31 @@ type MediaInput struct {
 	Type     string // "photo", "video", "document", etc.
 }
 
-// SendOption configures message sending.
-type SendOption func(*SendOptions)
+// SendOption configures message sending. It is sendopt.Option, so any
+// option from package sendopt can be passed as well.
+type SendOption = sendopt.Option
 
 // SendOptions holds optional parameters for sending.
-type SendOptions struct {
-	ReplyMarkup        *tg.InlineKeyboardMarkup
-	ParseMode          string
-	Caption            string                 // For media methods (sendPhoto, sendDocument, etc.)
-	LinkPreviewOptions *tg.LinkPreviewOptions // For sendMessage
-}
+type SendOptions = sendopt.Options
 
 // WithReplyMarkup sets the reply markup.
 func WithReplyMarkup(markup *tg.InlineKeyboardMarkup) SendOption {
-	return func(o *SendOptions) {
-		o.ReplyMarkup = markup
-	}
+	return sendopt.Keyboard(markup)
 }
 
 // WithParseMode sets the parse mode.
 func WithParseMode(mode string) SendOption {
-	return func(o *SendOptions) {
-		o.ParseMode = mode
-	}
+	return sendopt.ParseMode(tg.ParseMode(mode))
 }
 
 // WithCaption sets the caption for media messages.
 func WithCaption(caption string) SendOption {
-	return func(o *SendOptions) {
-		o.Caption = caption
-	}
+	return sendopt.Caption(caption)
 }
 
 // WithLinkPreviewOptions sets link preview options for sendMessage.
 func WithLinkPreviewOptions(opts *tg.LinkPreviewOptions) SendOption {
-	return func(o *SendOptions) {
-		o.LinkPreviewOptions = opts
-	}
+	return sendopt.LinkPreview(opts)
 }
 
 // MediaFromBytes creates a MediaInput from bytes.
@@ -216,12 +216,7 @@ func (s *DeleteLastMessageStep) Execute(ctx context.Context, rt *Runtime) (*Step
 	}
 
 	// Remove from tracked messages since we deleted it
-	for i, cm := range rt.CreatedMessages {
-		if cm.MessageID == rt.LastMessage.MessageID && cm.ChatID == rt.ChatID {
-			rt.CreatedMessages = append(rt.CreatedMessages[:i], rt.CreatedMessages[i+1:]...)
-			break
-		}
-	}
+	rt.UntrackMessage(rt.ChatID, rt.LastMessage.MessageID)
 
 	msgID := rt.LastMessage.MessageID
 	rt.LastMessage = nil
@@ -307,7 +302,7 @@ func (s *CopyMessageStep) Execute(ctx context.Context, rt *Runtime) (*StepResult
 
 // SendChatActionStep sends a chat action.
 type SendChatActionStep struct {
-	Action string // e.g., "typing"
+	Action tg.ChatAction // defaults to tg.ChatActionTyping
 }
 
 func (s *SendChatActionStep) Name() string { return "sendChatAction" }
@@ -315,7 +310,7 @@ func (s *SendChatActionStep) Name() string { return "sendChatAction" }
 func (s *SendChatActionStep) Execute(ctx context.Context, rt *Runtime) (*StepResult, error) {
 	action := s.Action
 	if action == "" {
-		action = "typing"
+		action = tg.ChatActionTyping
 	}
 
 	err := rt.Sender.SendChatAction(ctx, rt.ChatID, action)
@@ -343,9 +338,13 @@ func (s *CleanupStep) Execute(ctx context.Context, rt *Runtime) (*StepResult, er
 	for _, cm := range rt.CreatedMessages {
 		if err := rt.Sender.DeleteMessage(ctx, cm.ChatID, cm.MessageID); err != nil {
 			lastErr = err
-			// Continue trying to delete other messages
+			// Continue trying to delete other messages; the tracker keeps
+			// them for a later /cleanup
 		} else {
 			deleted++
+			if rt.Tracker != nil {
+				rt.Tracker.Forget(cm.ChatID, cm.MessageID)
+			}
 		}
 	}
 
@@ -129,6 +129,8 @@ func (s *DeleteMessagesStep) Execute(ctx context.Context, rt *Runtime) (*StepRes
 	for _, cm := range rt.CreatedMessages {
 		if cm.ChatID != rt.ChatID {
 			remaining = append(remaining, cm)
+		} else if rt.Tracker != nil {
+			rt.Tracker.Forget(cm.ChatID, cm.MessageID)
 		}
 	}
 	rt.CreatedMessages = remaining
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ================= Conversation Steps (black-box tests of another bot) =================
+
+// ExpectReplyStep waits on rt.MessageChan for a message in the test chat
+// whose text or caption matches Pattern. Messages older than rt.LastMessage,
+// from other senders or not matching are skipped; the skipped texts are
+// kept as evidence so a failed step shows what the bot said instead.
+type ExpectReplyStep struct {
+	Pattern *regexp.Regexp
+	From    string        // Bot username, with or without "@"; "" accepts any sender
+	Timeout time.Duration // Max wait time; defaults to 30s
+}
+
+func (s *ExpectReplyStep) Name() string { return "expectReply" }
+
+func (s *ExpectReplyStep) Execute(ctx context.Context, rt *Runtime) (*StepResult, error) {
+	if rt.MessageChan == nil {
+		return nil, fmt.Errorf("MessageChan not set — conversation scenarios require polling mode")
+	}
+
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	from := strings.TrimPrefix(s.From, "@")
+	afterID := 0
+	if rt.LastMessage != nil {
+		afterID = rt.LastMessage.MessageID
+	}
+
+	start := time.Now()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var skipped []string
+	for {
+		select {
+		case msg := <-rt.MessageChan:
+			if msg.Chat == nil || msg.Chat.ID != rt.ChatID || msg.MessageID <= afterID {
+				continue
+			}
+			// Channel posts have no sender; they are attributed to the chat.
+			if from != "" && msg.From != nil && !strings.EqualFold(msg.From.Username, from) {
+				continue
+			}
+			text := msg.Text
+			if text == "" {
+				text = msg.Caption
+			}
+			if !s.Pattern.MatchString(text) {
+				skipped = append(skipped, text)
+				continue
+			}
+			return &StepResult{
+				Method: "expectReply",
+				Evidence: map[string]any{
+					"pattern":    s.Pattern.String(),
+					"message_id": msg.MessageID,
+					"reply_text": text,
+					"latency_ms": time.Since(start).Milliseconds(),
+					"skipped":    skipped,
+				},
+			}, nil
+		case <-timer.C:
+			if len(skipped) > 0 {
+				return nil, fmt.Errorf("timeout waiting for reply matching %q (%s); other replies: %q", s.Pattern, timeout, skipped)
+			}
+			return nil, fmt.Errorf("timeout waiting for reply matching %q (%s) — no reply received", s.Pattern, timeout)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -2,7 +2,9 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/prilive-com/galigo/tg"
 )
@@ -344,6 +346,93 @@ func (s *GetAvailableGiftsStep) Execute(ctx context.Context, rt *Runtime) (*Step
 	}, nil
 }
 
+// ================= Verification Steps =================
+
+// skipIfNotVerifier turns Telegram's refusal for bots without verification
+// rights into a skip.
+func skipIfNotVerifier(err error) error {
+	var apiErr *tg.APIError
+	if errors.As(err, &apiErr) && apiErr.Code == 400 &&
+		strings.Contains(apiErr.Description, "BOT_VERIFIER_FORBIDDEN") {
+		return Skip("bot does not belong to an organization with verification rights")
+	}
+	return err
+}
+
+// VerifyChatStep verifies the test chat.
+type VerifyChatStep struct {
+	Description string
+}
+
+func (s *VerifyChatStep) Name() string { return "verifyChat" }
+
+func (s *VerifyChatStep) Execute(ctx context.Context, rt *Runtime) (*StepResult, error) {
+	if err := rt.Sender.VerifyChat(ctx, rt.ChatID, s.Description); err != nil {
+		return nil, skipIfNotVerifier(err)
+	}
+
+	return &StepResult{
+		Method:   "verifyChat",
+		Evidence: map[string]any{"chat_id": rt.ChatID, "custom_description": s.Description},
+	}, nil
+}
+
+// RemoveChatVerificationStep removes the test chat's verification.
+type RemoveChatVerificationStep struct{}
+
+func (s *RemoveChatVerificationStep) Name() string { return "removeChatVerification" }
+
+func (s *RemoveChatVerificationStep) Execute(ctx context.Context, rt *Runtime) (*StepResult, error) {
+	if err := rt.Sender.RemoveChatVerification(ctx, rt.ChatID); err != nil {
+		return nil, skipIfNotVerifier(err)
+	}
+
+	return &StepResult{
+		Method:   "removeChatVerification",
+		Evidence: map[string]any{"chat_id": rt.ChatID},
+	}, nil
+}
+
+// VerifyUserStep verifies the admin user.
+type VerifyUserStep struct {
+	Description string
+}
+
+func (s *VerifyUserStep) Name() string { return "verifyUser" }
+
+func (s *VerifyUserStep) Execute(ctx context.Context, rt *Runtime) (*StepResult, error) {
+	if rt.AdminUserID == 0 {
+		return nil, Skip("no AdminUserID for verifyUser")
+	}
+	if err := rt.Sender.VerifyUser(ctx, rt.AdminUserID, s.Description); err != nil {
+		return nil, skipIfNotVerifier(err)
+	}
+
+	return &StepResult{
+		Method:   "verifyUser",
+		Evidence: map[string]any{"user_id": rt.AdminUserID, "custom_description": s.Description},
+	}, nil
+}
+
+// RemoveUserVerificationStep removes the admin user's verification.
+type RemoveUserVerificationStep struct{}
+
+func (s *RemoveUserVerificationStep) Name() string { return "removeUserVerification" }
+
+func (s *RemoveUserVerificationStep) Execute(ctx context.Context, rt *Runtime) (*StepResult, error) {
+	if rt.AdminUserID == 0 {
+		return nil, Skip("no AdminUserID for removeUserVerification")
+	}
+	if err := rt.Sender.RemoveUserVerification(ctx, rt.AdminUserID); err != nil {
+		return nil, skipIfNotVerifier(err)
+	}
+
+	return &StepResult{
+		Method:   "removeUserVerification",
+		Evidence: map[string]any{"user_id": rt.AdminUserID},
+	}, nil
+}
+
 // ================= Checklist Steps =================
 
 // SendChecklistStep sends a checklist message.
@@ -0,0 +1,238 @@
+package evidence
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/prilive-com/galigo/cmd/galigo-testbot/engine"
+	"github.com/prilive-com/galigo/e2e"
+)
+
+// Report formats accepted by --report-format.
+const (
+	FormatJSON     = "json"
+	FormatJUnit    = "junit"
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// JSONWriter writes the native JSON report.
+type JSONWriter struct{}
+
+func (JSONWriter) Format() string    { return FormatJSON }
+func (JSONWriter) Extension() string { return ".json" }
+
+func (JSONWriter) WriteReport(w io.Writer, r *e2e.Report) error {
+	return r.WriteJSON(w)
+}
+
+// JUnitWriter writes JUnit XML for CI ingestion.
+type JUnitWriter struct{}
+
+func (JUnitWriter) Format() string    { return FormatJUnit }
+func (JUnitWriter) Extension() string { return ".xml" }
+
+func (JUnitWriter) WriteReport(w io.Writer, r *e2e.Report) error {
+	return r.WriteJUnit(w)
+}
+
+// MarkdownWriter writes a human-readable Markdown report. When ChatID is a
+// supergroup or channel, message IDs link to the messages in Telegram.
+type MarkdownWriter struct {
+	ChatID int64
+}
+
+func (MarkdownWriter) Format() string    { return FormatMarkdown }
+func (MarkdownWriter) Extension() string { return ".md" }
+
+func (m MarkdownWriter) WriteReport(w io.Writer, r *e2e.Report) error {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# Test Run %s\n\n", r.RunID)
+	fmt.Fprintf(&sb, "**Status:** %s  \n", statusLabel(r.Success))
+	fmt.Fprintf(&sb, "**Duration:** %s  \n", r.Duration.Round(time.Millisecond))
+	fmt.Fprintf(&sb, "**Scenarios:** %d/%d passed (%d skipped)  \n",
+		r.Summary.PassedScenarios, r.Summary.TotalScenarios, r.Summary.SkippedScenarios)
+	fmt.Fprintf(&sb, "**Steps:** %d/%d passed  \n", r.Summary.PassedSteps, r.Summary.TotalSteps)
+	fmt.Fprintf(&sb, "**Methods covered:** %d\n\n", len(r.Summary.MethodsCovered))
+
+	sb.WriteString("| Scenario | Result | Duration | Notes |\n")
+	sb.WriteString("|----------|--------|----------|-------|\n")
+	for _, s := range r.Scenarios {
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n",
+			mdEscape(s.ScenarioName), scenarioLabel(s), s.Duration.Round(time.Millisecond), mdEscape(scenarioNote(s)))
+	}
+
+	for _, s := range r.Scenarios {
+		fmt.Fprintf(&sb, "\n## %s — %s\n\n", s.ScenarioName, scenarioLabel(s))
+		if len(s.Covers) > 0 {
+			fmt.Fprintf(&sb, "Covers: `%s`\n\n", strings.Join(s.Covers, "`, `"))
+		}
+		if len(s.Steps) == 0 {
+			continue
+		}
+		sb.WriteString("| Step | Method | Result | Duration | Messages | Error |\n")
+		sb.WriteString("|------|--------|--------|----------|----------|-------|\n")
+		for _, st := range s.Steps {
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s | %s |\n",
+				mdEscape(st.StepName), st.Method, statusLabel(st.Success),
+				st.Duration.Round(time.Millisecond), m.messageRefs(st.MessageIDs), mdEscape(st.Error))
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func (m MarkdownWriter) messageRefs(ids []int) string {
+	refs := make([]string, len(ids))
+	for i, id := range ids {
+		if link := MessageLink(m.ChatID, id); link != "" {
+			refs[i] = fmt.Sprintf("[%d](%s)", id, link)
+		} else {
+			refs[i] = fmt.Sprint(id)
+		}
+	}
+	return strings.Join(refs, ", ")
+}
+
+// HTMLWriter writes a standalone HTML report. Message links follow the
+// same rules as MarkdownWriter.
+type HTMLWriter struct {
+	ChatID int64
+}
+
+func (HTMLWriter) Format() string    { return FormatHTML }
+func (HTMLWriter) Extension() string { return ".html" }
+
+func (h HTMLWriter) WriteReport(w io.Writer, r *e2e.Report) error {
+	return htmlReport.Execute(w, struct {
+		*e2e.Report
+		ChatID int64
+	}{r, h.ChatID})
+}
+
+var htmlReport = template.Must(template.New("report").Funcs(template.FuncMap{
+	"status":   statusLabel,
+	"scenario": scenarioLabel,
+	"round":    func(d time.Duration) time.Duration { return d.Round(time.Millisecond) },
+	"link":     func(chatID int64, id int) template.URL { return template.URL(MessageLink(chatID, id)) },
+}).Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Test Run {{.RunID}}</title>
+<style>body{font-family:sans-serif}table{border-collapse:collapse}td,th{border:1px solid #ccc;padding:4px 8px}.FAILED{color:#b00}.PASSED{color:#070}.SKIPPED{color:#888}</style>
+</head><body>
+<h1>Test Run {{.RunID}}</h1>
+<p>Status: <b class="{{status .Success}}">{{status .Success}}</b> &middot; Duration: {{round .Duration}} &middot;
+Scenarios: {{.Summary.PassedScenarios}}/{{.Summary.TotalScenarios}} passed &middot;
+Steps: {{.Summary.PassedSteps}}/{{.Summary.TotalSteps}} passed</p>
+{{range .Scenarios}}{{$s := scenario .}}
+<h2>{{.ScenarioName}} <span class="{{$s}}">{{$s}}</span></h2>
+{{if .Error}}<p class="FAILED">{{.Error}}</p>{{end}}{{if .SkipReason}}<p class="SKIPPED">{{.SkipReason}}</p>{{end}}
+<table><tr><th>Step</th><th>Method</th><th>Result</th><th>Duration</th><th>Messages</th><th>Error</th></tr>
+{{range .Steps}}<tr><td>{{.StepName}}</td><td>{{.Method}}</td><td class="{{status .Success}}">{{status .Success}}</td><td>{{round .Duration}}</td><td>{{range $i, $id := .MessageIDs}}{{if $i}}, {{end}}{{with link $.ChatID $id}}<a href="{{.}}">{{$id}}</a>{{else}}{{$id}}{{end}}{{end}}</td><td>{{.Error}}</td></tr>
+{{end}}</table>
+{{end}}</body></html>
+`))
+
+// MessageLink returns a t.me link to a message in a supergroup or channel,
+// or "" for chats without public message links (private chats, basic groups).
+func MessageLink(chatID int64, messageID int) string {
+	const channelPrefix = -1_000_000_000_000
+	if chatID > channelPrefix || messageID <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("https://t.me/c/%d/%d", -chatID+channelPrefix, messageID)
+}
+
+// Writers resolves a comma-separated --report-format value.
+// chatID is used for message links in human-readable formats.
+func Writers(formats string, chatID int64) ([]engine.ReportWriter, error) {
+	var writers []engine.ReportWriter
+	seen := make(map[string]bool)
+	for _, f := range strings.Split(formats, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" || seen[f] {
+			continue
+		}
+		seen[f] = true
+		switch f {
+		case FormatJSON:
+			writers = append(writers, JSONWriter{})
+		case FormatJUnit:
+			writers = append(writers, JUnitWriter{})
+		case FormatMarkdown, "md":
+			writers = append(writers, MarkdownWriter{ChatID: chatID})
+		case FormatHTML:
+			writers = append(writers, HTMLWriter{ChatID: chatID})
+		default:
+			return nil, fmt.Errorf("unknown report format %q (want json, junit, markdown, html)", f)
+		}
+	}
+	if len(writers) == 0 {
+		writers = append(writers, JSONWriter{})
+	}
+	return writers, nil
+}
+
+// Save writes the report once per writer to
+// <storageDir>/reports/report-<RunID><ext> and returns the file names.
+func Save(r *e2e.Report, storageDir string, writers ...engine.ReportWriter) ([]string, error) {
+	dir := filepath.Join(storageDir, "reports")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create reports directory: %w", err)
+	}
+
+	var filenames []string
+	for _, rw := range writers {
+		filename := filepath.Join(dir, "report-"+r.RunID+rw.Extension())
+		if err := writeFile(filename, r, rw); err != nil {
+			return filenames, fmt.Errorf("failed to write %s report: %w", rw.Format(), err)
+		}
+		filenames = append(filenames, filename)
+	}
+	return filenames, nil
+}
+
+func writeFile(filename string, r *e2e.Report, rw engine.ReportWriter) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := rw.WriteReport(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func statusLabel(ok bool) string {
+	if ok {
+		return "PASSED"
+	}
+	return "FAILED"
+}
+
+func scenarioLabel(s *e2e.ScenarioResult) string {
+	if s.Skipped {
+		return "SKIPPED"
+	}
+	return statusLabel(s.Success)
+}
+
+func scenarioNote(s *e2e.ScenarioResult) string {
+	if s.Skipped {
+		return s.SkipReason
+	}
+	return s.Error
+}
+
+// mdEscape keeps free text from breaking Markdown table cells.
+func mdEscape(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
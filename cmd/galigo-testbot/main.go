@@ -7,11 +7,16 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
+	"github.com/prilive-com/galigo"
+	"github.com/prilive-com/galigo/auth"
 	"github.com/prilive-com/galigo/cmd/galigo-testbot/cleanup"
 	"github.com/prilive-com/galigo/cmd/galigo-testbot/config"
+	"github.com/prilive-com/galigo/cmd/galigo-testbot/conversation"
 	"github.com/prilive-com/galigo/cmd/galigo-testbot/engine"
 	"github.com/prilive-com/galigo/cmd/galigo-testbot/evidence"
 	"github.com/prilive-com/galigo/cmd/galigo-testbot/registry"
@@ -25,11 +30,20 @@ var (
 	runSuite        = flag.String("run", "", "Run a test suite: smoke, messages, forward, actions, core, media, keyboards, all")
 	skipInteractive = flag.Bool("skip-interactive", false, "Skip interactive scenarios")
 	showStatus      = flag.Bool("status", false, "Show method coverage status")
+	showVersion     = flag.Bool("version", false, "Print galigo version and build info")
+	parallel        = flag.Int("parallel", 1, "Run up to N independent scenarios concurrently (see TESTBOT_PARALLEL_CHAT_IDS)")
+	reportFormat    = flag.String("report-format", evidence.FormatJSON, "Comma-separated report formats: json, junit, markdown, html")
+	conversations   = flag.String("conversation", "", "Comma-separated YAML conversation scripts to run against another bot")
 )
 
 func main() {
 	flag.Parse()
 
+	if *showVersion {
+		fmt.Println(galigo.ReadBuildInfo())
+		return
+	}
+
 	// Load .env file if present (doesn't override existing env vars)
 	_ = config.LoadDotEnv(".env")
 	_ = config.LoadDotEnv("cmd/galigo-testbot/.env") // Also check in subdir
@@ -41,6 +55,11 @@ func main() {
 	}
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel}))
 
+	if _, err := evidence.Writers(*reportFormat, 0); err != nil {
+		logger.Error("invalid --report-format", "error", err)
+		os.Exit(2)
+	}
+
 	// Handle --status flag early (doesn't need config)
 	if *showStatus {
 		showCoverageStatus(logger)
@@ -55,6 +74,7 @@ func main() {
 	}
 
 	logger.Info("galigo-testbot starting",
+		"build", galigo.ReadBuildInfo().String(),
 		"mode", cfg.Mode,
 		"chat_id", cfg.ChatID,
 		"admins", cfg.Admins)
@@ -71,13 +91,22 @@ func main() {
 	}
 	defer senderClient.Close()
 
+	// Messages sent by every run are tracked on disk until deleted, so
+	// /cleanup can remove what failed runs left behind.
+	store := cleanup.NewStore(filepath.Join(cfg.StorageDir, "tracked"), logger)
+
+	if *conversations != "" {
+		runConversations(cfg, senderClient, store, logger, strings.Split(*conversations, ","))
+		return
+	}
+
 	if *runSuite != "" {
-		runSuiteCommand(cfg, senderClient, logger, *runSuite, *skipInteractive)
+		runSuiteCommand(cfg, senderClient, store, logger, *runSuite, *skipInteractive)
 		return
 	}
 
 	// Interactive mode - listen for commands
-	runInteractive(cfg, senderClient, logger)
+	runInteractive(cfg, senderClient, store, logger)
 }
 
 func showCoverageStatus(logger *slog.Logger) {
@@ -88,6 +117,7 @@ func showCoverageStatus(logger *slog.Logger) {
 	scenarios = append(scenarios, suites.AllStickerScenarios()...)
 	scenarios = append(scenarios, suites.AllStarsScenarios()...)
 	scenarios = append(scenarios, suites.AllGiftScenarios()...)
+	scenarios = append(scenarios, suites.AllVerificationScenarios()...)
 	scenarios = append(scenarios, suites.AllChecklistScenarios()...)
 	scenarios = append(scenarios, suites.AllInteractiveScenarios()...)
 	scenarios = append(scenarios, suites.AllWebhookScenarios()...)
@@ -115,16 +145,19 @@ func showCoverageStatus(logger *slog.Logger) {
 	}
 }
 
-func runSuiteCommand(cfg *config.Config, senderClient *sender.Client, logger *slog.Logger, suite string, skipInteractive bool) {
+func runSuiteCommand(cfg *config.Config, senderClient *sender.Client, store *cleanup.Store, logger *slog.Logger, suite string, skipInteractive bool) {
 	adapter := engine.NewSenderAdapter(senderClient).WithToken(tg.SecretToken(cfg.Token))
 	rt := engine.NewRuntime(adapter, cfg.ChatID, cfg.Admins[0])
-	runner := engine.NewRunner(rt, engine.RunnerConfig{
+	rt.Tracker = store
+	runnerCfg := engine.RunnerConfig{
 		BaseDelay:     cfg.SendInterval,
 		Jitter:        cfg.JitterInterval,
 		MaxMessages:   cfg.MaxMessagesPerRun,
 		RetryOn429:    cfg.RetryOn429,
 		Max429Retries: cfg.Max429Retries,
-	}, logger)
+		Cooldown:      &engine.Cooldown{},
+	}
+	runner := engine.NewRunner(rt, runnerCfg, logger)
 
 	var scenarios []engine.Scenario
 
@@ -193,15 +226,18 @@ func runSuiteCommand(cfg *config.Config, senderClient *sender.Client, logger *sl
 	// Extended: Gifts
 	case "gifts":
 		scenarios = suites.AllGiftScenarios()
+	// Extended: Verification (opt-in, excluded from "all")
+	case "verification":
+		scenarios = suites.AllVerificationScenarios()
 	// Extended: Checklists
 	case "checklists":
 		scenarios = suites.AllChecklistScenarios()
 	// Interactive (opt-in, excluded from "all")
 	case "interactive":
-		runInteractiveSuite(cfg, senderClient, logger)
+		runInteractiveSuite(cfg, senderClient, store, logger)
 		return
 	case "callback":
-		runInteractiveSuite(cfg, senderClient, logger)
+		runInteractiveSuite(cfg, senderClient, store, logger)
 		return
 	// Webhook management (opt-in, excluded from "all")
 	case "webhook":
@@ -264,34 +300,40 @@ func runSuiteCommand(cfg *config.Config, senderClient *sender.Client, logger *sl
 		scenarios = append(scenarios, suites.AllAPI94Scenarios()...)
 		scenarios = append(scenarios, suites.AllAPI95Scenarios()...)
 		// Checklists require Telegram Premium — opt-in via --run checklists
+		// Verification requires organization rights — opt-in via --run verification
 	default:
 		logger.Error("unknown suite", "suite", suite)
-		fmt.Println("Available suites: smoke, identity, messages, forward, actions, core, media, media-uploads, media-groups, edit-media, get-file, edit-message-media, keyboards, inline-keyboard, chat-admin, chat-info, chat-settings, pin-messages, polls, forum-stickers, stickers, sticker-lifecycle, stars, star-balance, invoice, gifts, checklists, interactive, callback, webhook, webhook-lifecycle, get-updates, extras, geo, venue, contact-dice, bulk, reactions, user-info, chat-photo, chat-permissions, bot-config, bot-commands, bot-profile, bot-admin-defaults, api94, styled-buttons, profile-audios, chat-info-94, video-qualities, api95, all")
+		fmt.Println("Available suites: smoke, identity, messages, forward, actions, core, media, media-uploads, media-groups, edit-media, get-file, edit-message-media, keyboards, inline-keyboard, chat-admin, chat-info, chat-settings, pin-messages, polls, forum-stickers, stickers, sticker-lifecycle, stars, star-balance, invoice, gifts, verification, checklists, interactive, callback, webhook, webhook-lifecycle, get-updates, extras, geo, venue, contact-dice, bulk, reactions, user-info, chat-photo, chat-permissions, bot-config, bot-commands, bot-profile, bot-admin-defaults, api94, styled-buttons, profile-audios, chat-info-94, video-qualities, api95, all")
 		os.Exit(1)
 	}
 
 	report := evidence.NewReport()
 
 	ctx := context.Background()
-	for _, scenario := range scenarios {
-		logger.Info("running scenario", "name", scenario.Name())
-		result := runner.Run(ctx, scenario)
-		report.AddScenario(result)
+	if *parallel > 1 {
+		runners := parallelRunners(cfg, adapter, store, runner, runnerCfg, *parallel, logger)
+		logger.Info("running scenarios in parallel", "scenarios", len(scenarios), "workers", len(runners))
+		for _, result := range engine.RunParallel(ctx, scenarios, runners) {
+			report.AddScenario(result)
+			if !result.Success {
+				logger.Error("scenario failed", "name", result.ScenarioName, "error", result.Error)
+			}
+		}
+	} else {
+		for _, scenario := range scenarios {
+			logger.Info("running scenario", "name", scenario.Name())
+			result := runner.Run(ctx, scenario)
+			report.AddScenario(result)
 
-		if !result.Success {
-			logger.Error("scenario failed", "name", scenario.Name(), "error", result.Error)
+			if !result.Success {
+				logger.Error("scenario failed", "name", scenario.Name(), "error", result.Error)
+			}
 		}
 	}
 
 	report.Finalize()
 
-	// Save report
-	filename, err := report.Save(cfg.StorageDir)
-	if err != nil {
-		logger.Error("failed to save report", "error", err)
-	} else {
-		logger.Info("report saved", "filename", filename)
-	}
+	saveReport(cfg, report, logger)
 
 	// Print summary
 	fmt.Println("\n" + report.FormatSummary())
@@ -301,9 +343,50 @@ func runSuiteCommand(cfg *config.Config, senderClient *sender.Client, logger *sl
 	}
 }
 
+// parallelRunners returns n runners, each with its own Runtime so step state
+// is isolated. Worker 0 reuses the main runner on TESTBOT_CHAT_ID; the others
+// take chats from TESTBOT_PARALLEL_CHAT_IDS round-robin, or share the main chat
+// when no pool is configured. All runners share runnerCfg.Cooldown, so a 429
+// pauses every worker. The message budget applies per worker.
+func parallelRunners(cfg *config.Config, adapter *engine.SenderAdapter, store *cleanup.Store, main *engine.Runner, runnerCfg engine.RunnerConfig, n int, logger *slog.Logger) []*engine.Runner {
+	if len(cfg.ParallelChatIDs) == 0 {
+		logger.Warn("TESTBOT_PARALLEL_CHAT_IDS not set, parallel workers share the test chat")
+	}
+
+	runners := []*engine.Runner{main}
+	for i := 1; i < n; i++ {
+		chatID := cfg.ChatID
+		if len(cfg.ParallelChatIDs) > 0 {
+			chatID = cfg.ParallelChatIDs[(i-1)%len(cfg.ParallelChatIDs)]
+		}
+		rt := engine.NewRuntime(adapter, chatID, cfg.Admins[0])
+		rt.Tracker = store
+		runners = append(runners, engine.NewRunner(rt, runnerCfg, logger.With("worker", i)))
+	}
+	return runners
+}
+
+// saveReport writes the report in every --report-format and returns the file names.
+func saveReport(cfg *config.Config, report *evidence.Report, logger *slog.Logger) []string {
+	writers, err := evidence.Writers(*reportFormat, cfg.ChatID)
+	if err != nil {
+		logger.Error("failed to save report", "error", err)
+		return nil
+	}
+
+	filenames, err := evidence.Save(report, cfg.StorageDir, writers...)
+	if err != nil {
+		logger.Error("failed to save report", "error", err)
+	}
+	for _, filename := range filenames {
+		logger.Info("report saved", "filename", filename)
+	}
+	return filenames
+}
+
 // runInteractiveSuite runs interactive scenarios that require user interaction.
 // It starts a polling loop to receive callback queries from Telegram.
-func runInteractiveSuite(cfg *config.Config, senderClient *sender.Client, logger *slog.Logger) {
+func runInteractiveSuite(cfg *config.Config, senderClient *sender.Client, store *cleanup.Store, logger *slog.Logger) {
 	logger.Info("starting interactive test suite (requires user interaction)")
 
 	// Start polling to receive callback queries
@@ -332,6 +415,7 @@ func runInteractiveSuite(cfg *config.Config, senderClient *sender.Client, logger
 	adapter := engine.NewSenderAdapter(senderClient).WithToken(tg.SecretToken(cfg.Token))
 	callbackChan := make(chan *tg.CallbackQuery, 10)
 	rt := engine.NewRuntime(adapter, cfg.ChatID, cfg.Admins[0])
+	rt.Tracker = store
 	rt.CallbackChan = callbackChan
 	runner := engine.NewRunner(rt, engine.RunnerConfig{
 		BaseDelay:     cfg.SendInterval,
@@ -374,14 +458,97 @@ func runInteractiveSuite(cfg *config.Config, senderClient *sender.Client, logger
 
 	report.Finalize()
 
-	// Save report
-	filename, err := report.Save(cfg.StorageDir)
-	if err != nil {
-		logger.Error("failed to save report", "error", err)
-	} else {
-		logger.Info("report saved", "filename", filename)
+	saveReport(cfg, report, logger)
+
+	fmt.Println("\n" + report.FormatSummary())
+
+	if !report.Success {
+		os.Exit(1)
+	}
+}
+
+// runConversations runs scripted conversations against another bot in the
+// test chat. It polls for updates so the bot's replies reach the runtime.
+func runConversations(cfg *config.Config, senderClient *sender.Client, store *cleanup.Store, logger *slog.Logger, paths []string) {
+	var scenarios []engine.Scenario
+	for _, path := range paths {
+		script, err := conversation.Load(strings.TrimSpace(path))
+		if err != nil {
+			logger.Error("failed to load conversation", "error", err)
+			os.Exit(2)
+		}
+		scenarios = append(scenarios, script.Scenario())
 	}
 
+	updates := make(chan tg.Update, 100)
+	receiverCfg := receiver.DefaultConfig()
+	receiverCfg.Mode = receiver.ModeLongPolling
+	receiverCfg.PollingTimeout = 30
+
+	pollingClient := receiver.NewPollingClient(
+		tg.SecretToken(cfg.Token),
+		updates,
+		logger,
+		receiverCfg,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := pollingClient.Start(ctx); err != nil {
+		logger.Error("failed to start polling for conversations", "error", err)
+		os.Exit(1)
+	}
+	defer pollingClient.Stop()
+
+	adapter := engine.NewSenderAdapter(senderClient).WithToken(tg.SecretToken(cfg.Token))
+	messageChan := make(chan *tg.Message, 100)
+	rt := engine.NewRuntime(adapter, cfg.ChatID, cfg.Admins[0])
+	rt.Tracker = store
+	rt.MessageChan = messageChan
+	runner := engine.NewRunner(rt, engine.RunnerConfig{
+		BaseDelay:     cfg.SendInterval,
+		Jitter:        cfg.JitterInterval,
+		MaxMessages:   cfg.MaxMessagesPerRun,
+		RetryOn429:    cfg.RetryOn429,
+		Max429Retries: cfg.Max429Retries,
+	}, logger)
+
+	// Forward messages and channel posts from polling to the runtime channel
+	go func() {
+		for update := range updates {
+			msg := update.Message
+			if msg == nil {
+				msg = update.ChannelPost
+			}
+			if msg == nil {
+				continue
+			}
+			select {
+			case messageChan <- msg:
+				logger.Debug("forwarded message", "chat_id", msg.Chat.ID, "message_id", msg.MessageID)
+			default:
+				logger.Warn("message channel full, dropping message")
+			}
+		}
+	}()
+
+	report := evidence.NewReport()
+
+	for _, scenario := range scenarios {
+		logger.Info("running conversation", "name", scenario.Name())
+		result := runner.Run(ctx, scenario)
+		report.AddScenario(result)
+
+		if !result.Success {
+			logger.Error("conversation failed", "name", scenario.Name(), "error", result.Error)
+		}
+	}
+
+	report.Finalize()
+
+	saveReport(cfg, report, logger)
+
 	fmt.Println("\n" + report.FormatSummary())
 
 	if !report.Success {
@@ -389,7 +556,7 @@ func runInteractiveSuite(cfg *config.Config, senderClient *sender.Client, logger
 	}
 }
 
-func runInteractive(cfg *config.Config, senderClient *sender.Client, logger *slog.Logger) {
+func runInteractive(cfg *config.Config, senderClient *sender.Client, store *cleanup.Store, logger *slog.Logger) {
 	logger.Info("starting interactive mode")
 
 	// Create receiver for updates
@@ -428,6 +595,8 @@ func runInteractive(cfg *config.Config, senderClient *sender.Client, logger *slo
 	adapter := engine.NewSenderAdapter(senderClient).WithToken(tg.SecretToken(cfg.Token))
 	cleaner := cleanup.NewCleaner(adapter, logger)
 
+	guard := auth.NewGuard(auth.WithAdmins(cfg.Admins...))
+
 	logger.Info("listening for commands",
 		"commands", "/run, /status, /cleanup, /help")
 
@@ -438,13 +607,11 @@ func runInteractive(cfg *config.Config, senderClient *sender.Client, logger *slo
 		}
 
 		msg := update.Message
-		userID := int64(0)
-		if msg.From != nil {
-			userID = msg.From.ID
-		}
-
-		// Check admin
-		if !cfg.IsAdmin(userID) {
+		if err := guard.Check(ctx, update); err != nil {
+			userID := int64(0)
+			if msg.From != nil {
+				userID = msg.From.ID
+			}
 			logger.Warn("unauthorized user", "user_id", userID)
 			continue
 		}
@@ -461,22 +628,21 @@ func runInteractive(cfg *config.Config, senderClient *sender.Client, logger *slo
 			args = strings.Join(parts[1:], " ")
 		}
 
-		handleCommand(ctx, cfg, senderClient, adapter, cleaner, logger, msg.Chat.ID, command, args, updates)
+		handleCommand(ctx, cfg, senderClient, adapter, cleaner, store, logger, msg.Chat.ID, command, args, updates)
 	}
 }
 
 func handleCommand(ctx context.Context, cfg *config.Config, senderClient *sender.Client,
-	adapter *engine.SenderAdapter, cleaner *cleanup.Cleaner, logger *slog.Logger,
+	adapter *engine.SenderAdapter, cleaner *cleanup.Cleaner, store *cleanup.Store, logger *slog.Logger,
 	chatID int64, command, args string, updates <-chan tg.Update) {
 
 	switch command {
 	case "run":
-		handleRun(ctx, cfg, senderClient, adapter, logger, chatID, args, updates)
+		handleRun(ctx, cfg, senderClient, adapter, store, logger, chatID, args, updates)
 	case "status":
 		handleStatus(ctx, adapter, chatID)
 	case "cleanup":
-		// Not implemented yet - would need to track messages across runs
-		sendMessage(ctx, adapter, chatID, "Cleanup: No messages tracked in this session")
+		handleCleanup(ctx, adapter, cleaner, store, chatID, args)
 	case "help":
 		handleHelp(ctx, adapter, chatID)
 	default:
@@ -485,7 +651,7 @@ func handleCommand(ctx context.Context, cfg *config.Config, senderClient *sender
 }
 
 func handleRun(ctx context.Context, cfg *config.Config, senderClient *sender.Client,
-	adapter *engine.SenderAdapter, logger *slog.Logger, chatID int64, suite string, updates <-chan tg.Update) {
+	adapter *engine.SenderAdapter, store *cleanup.Store, logger *slog.Logger, chatID int64, suite string, updates <-chan tg.Update) {
 
 	if suite == "" {
 		sendMessage(ctx, adapter, chatID, "Usage: /run <suite>\nSuites: smoke, identity, messages, forward, actions, core, media, media-uploads, media-groups, edit-media, get-file, edit-message-media, keyboards, inline-keyboard, interactive, webhook, get-updates, all")
@@ -493,6 +659,7 @@ func handleRun(ctx context.Context, cfg *config.Config, senderClient *sender.Cli
 	}
 
 	rt := engine.NewRuntime(adapter, chatID, cfg.Admins[0])
+	rt.Tracker = store
 	runner := engine.NewRunner(rt, engine.RunnerConfig{
 		BaseDelay:     cfg.SendInterval,
 		Jitter:        cfg.JitterInterval,
@@ -553,6 +720,8 @@ func handleRun(ctx context.Context, cfg *config.Config, senderClient *sender.Cli
 		scenarios = []engine.Scenario{suites.S22_Invoice()}
 	case "gifts":
 		scenarios = suites.AllGiftScenarios()
+	case "verification":
+		scenarios = suites.AllVerificationScenarios()
 	case "checklists":
 		scenarios = suites.AllChecklistScenarios()
 	// Interactive (opt-in, requires user interaction)
@@ -661,20 +830,35 @@ func handleRun(ctx context.Context, cfg *config.Config, senderClient *sender.Cli
 
 	report.Finalize()
 
-	// Save report
-	filename, err := report.Save(cfg.StorageDir)
-	if err != nil {
-		logger.Error("failed to save report", "error", err)
-	}
+	filenames := saveReport(cfg, report, logger)
 
 	// Send summary
 	summary := report.FormatSummary()
-	if filename != "" {
+	for _, filename := range filenames {
 		summary += fmt.Sprintf("\nReport: %s", filename)
 	}
 	sendMessage(ctx, adapter, chatID, summary)
 }
 
+// handleCleanup deletes messages tracked by earlier runs; see
+// cleanup.ParseOptions for the arguments.
+func handleCleanup(ctx context.Context, adapter *engine.SenderAdapter, cleaner *cleanup.Cleaner,
+	store *cleanup.Store, chatID int64, args string) {
+
+	opts, err := cleanup.ParseOptions(args)
+	if err != nil {
+		sendMessage(ctx, adapter, chatID, err.Error())
+		return
+	}
+
+	result, err := cleaner.CleanupTracked(ctx, store, opts)
+	if err != nil {
+		sendMessage(ctx, adapter, chatID, "Cleanup failed: "+err.Error())
+		return
+	}
+	sendMessage(ctx, adapter, chatID, result.Summary(time.Now()))
+}
+
 func handleStatus(ctx context.Context, adapter *engine.SenderAdapter, chatID int64) {
 	scenarios := append(suites.AllPhaseAScenarios(), suites.AllPhaseBScenarios()...)
 	scenarios = append(scenarios, suites.AllPhaseCScenarios()...)
@@ -742,6 +926,7 @@ Extended:
   star-balance      - Star balance only (S21)
   invoice           - Send invoice (S22)
   gifts             - Gift catalog (S23)
+  verification      - Verify/unverify chat and user (S47, needs verification rights)
   checklists        - Checklist lifecycle (S24)
 
 Bot API 9.4 (S38-S42):
@@ -764,6 +949,12 @@ Webhook (opt-in, excluded from "all"):
 
 /status - Show method coverage
 
+/cleanup [options] - Delete messages left by earlier runs
+  dry-run      - List the messages instead of deleting them
+  older=<dur>  - Only messages sent at least <dur> ago (e.g. 1h)
+  newer=<dur>  - Only messages sent less than <dur> ago (e.g. 48h)
+  chat=<id>    - Only this chat (default: all tracked chats)
+
 /help - Show this help`
 
 	sendMessage(ctx, adapter, chatID, help)
@@ -1,6 +1,6 @@
 package registry
 
-import "slices"
+import "github.com/prilive-com/galigo/e2e"
 
 // MethodCategory groups methods by functional area.
 type MethodCategory string
@@ -123,6 +123,12 @@ var AllMethods = []Method{
 	// === Extended: Gifts ===
 	{Name: "getAvailableGifts", Category: CategoryExtended},
 
+	// === Extended: Verification ===
+	{Name: "verifyChat", Category: CategoryExtended, Notes: "requires organization verification rights"},
+	{Name: "removeChatVerification", Category: CategoryExtended, Notes: "requires organization verification rights"},
+	{Name: "verifyUser", Category: CategoryExtended, Notes: "requires organization verification rights"},
+	{Name: "removeUserVerification", Category: CategoryExtended, Notes: "requires organization verification rights"},
+
 	// === Extended: Checklists ===
 	{Name: "sendChecklist", Category: CategoryExtended},
 	{Name: "editMessageChecklist", Category: CategoryExtended},
@@ -208,43 +214,12 @@ func LegacyMethods() []Method {
 }
 
 // CoverageReport shows which methods are covered/missing.
-type CoverageReport struct {
-	Covered []string
-	Skipped []string // With reasons
-	Missing []string
-}
+type CoverageReport = e2e.CoverageReport
 
 // Coverer is implemented by scenarios to declare method coverage.
-type Coverer interface {
-	Covers() []string
-}
+type Coverer = e2e.Coverer
 
 // CheckCoverage compares scenarios against method registry.
 func CheckCoverage(scenarios []Coverer) *CoverageReport {
-	allMethods := make(map[string]bool)
-	for _, m := range AllMethods {
-		allMethods[m.Name] = false
-	}
-
-	// Mark covered methods
-	for _, s := range scenarios {
-		for _, method := range s.Covers() {
-			allMethods[method] = true
-		}
-	}
-
-	report := &CoverageReport{}
-
-	for method, isCovered := range allMethods {
-		if isCovered {
-			report.Covered = append(report.Covered, method)
-		} else {
-			report.Missing = append(report.Missing, method)
-		}
-	}
-
-	slices.Sort(report.Covered)
-	slices.Sort(report.Missing)
-
-	return report
+	return e2e.CheckCoverage(MethodNames(), scenarios)
 }
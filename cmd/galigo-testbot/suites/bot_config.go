@@ -14,6 +14,7 @@ func S33_BotCommands() engine.Scenario {
 		ScenarioDescription: "Set, get, and delete bot commands",
 		CoveredMethods:      []string{"setMyCommands", "getMyCommands", "deleteMyCommands"},
 		ScenarioTimeout:     30 * time.Second,
+		ScenarioExclusive:   true,
 		ScenarioSteps: []engine.Step{
 			// Set commands
 			&engine.SetMyCommandsStep{
@@ -40,6 +41,7 @@ func S34_BotProfile() engine.Scenario {
 		ScenarioDescription: "Set and get bot name, description, and short description",
 		CoveredMethods:      []string{"setMyName", "getMyName", "setMyDescription", "getMyDescription", "setMyShortDescription", "getMyShortDescription"},
 		ScenarioTimeout:     60 * time.Second,
+		ScenarioExclusive:   true,
 		ScenarioSteps: []engine.Step{
 			// Set name
 			&engine.SetMyNameStep{BotName: "[galigo-test] TestBot"},
@@ -67,6 +69,7 @@ func S35_BotAdminDefaults() engine.Scenario {
 		ScenarioDescription: "Set and get default administrator rights",
 		CoveredMethods:      []string{"setMyDefaultAdministratorRights", "getMyDefaultAdministratorRights"},
 		ScenarioTimeout:     30 * time.Second,
+		ScenarioExclusive:   true,
 		ScenarioSteps: []engine.Step{
 			// Set default rights for groups
 			&engine.SetMyDefaultAdministratorRightsStep{
@@ -29,6 +29,7 @@ func S16_ChatSettings() engine.Scenario {
 		ScenarioDescription: "Set and restore chat title and description",
 		CoveredMethods:      []string{"setChatTitle", "setChatDescription"},
 		ScenarioTimeout:     60 * time.Second,
+		ScenarioExclusive:   true,
 		ScenarioSteps: []engine.Step{
 			&engine.SaveChatTitleStep{},
 			&engine.SetChatTitleStep{Title: "[galigo-test] Settings Test"},
@@ -45,6 +46,7 @@ func S17_PinMessages() engine.Scenario {
 		ScenarioDescription: "Pin, unpin single, and unpin all messages",
 		CoveredMethods:      []string{"pinChatMessage", "unpinChatMessage", "unpinAllChatMessages"},
 		ScenarioTimeout:     30 * time.Second,
+		ScenarioExclusive:   true,
 		ScenarioSteps: []engine.Step{
 			&engine.SendMessageStep{Text: "[galigo-test] Pin test message"},
 			&engine.PinChatMessageStep{Silent: true},
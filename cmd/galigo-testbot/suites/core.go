@@ -100,7 +100,7 @@ func S5_ChatAction() engine.Scenario {
 		CoveredMethods:      []string{"sendChatAction"},
 		ScenarioTimeout:     30 * time.Second,
 		ScenarioSteps: []engine.Step{
-			&engine.SendChatActionStep{Action: "typing"},
+			&engine.SendChatActionStep{Action: tg.ChatActionTyping},
 		},
 	}
 }
@@ -105,6 +105,7 @@ func S31_ChatPhotoLifecycle() engine.Scenario {
 		ScenarioDescription: "Tests chat photo lifecycle (requires admin + can_change_info)",
 		CoveredMethods:      []string{"setChatPhoto", "deleteChatPhoto"},
 		ScenarioTimeout:     time.Minute,
+		ScenarioExclusive:   true,
 		ScenarioSteps: []engine.Step{
 			&engine.SaveChatPhotoStep{},
 			&engine.SetChatPhotoStep{},
@@ -120,6 +121,7 @@ func S32_ChatPermissionsLifecycle() engine.Scenario {
 		ScenarioDescription: "Tests chat permissions lifecycle (requires admin + can_restrict_members)",
 		CoveredMethods:      []string{"setChatPermissions"},
 		ScenarioTimeout:     time.Minute,
+		ScenarioExclusive:   true,
 		ScenarioSteps: []engine.Step{
 			&engine.SaveChatPermissionsStep{},
 			&engine.SetChatPermissionsStep{},
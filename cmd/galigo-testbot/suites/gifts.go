@@ -19,6 +19,31 @@ func S23_Gifts() engine.Scenario {
 	}
 }
 
+// S47_Verification verifies the test chat and the admin user and removes
+// both verifications again. It needs a bot of an organization with
+// verification rights and skips otherwise, so it is opt-in.
+func S47_Verification() engine.Scenario {
+	return &engine.BaseScenario{
+		ScenarioName:        "S47-Verification",
+		ScenarioDescription: "Verify and unverify a chat and a user",
+		CoveredMethods:      []string{"verifyChat", "removeChatVerification", "verifyUser", "removeUserVerification"},
+		ScenarioTimeout:     30 * time.Second,
+		ScenarioSteps: []engine.Step{
+			&engine.VerifyChatStep{Description: "galigo test chat"},
+			&engine.RemoveChatVerificationStep{},
+			&engine.VerifyUserStep{Description: "galigo test user"},
+			&engine.RemoveUserVerificationStep{},
+		},
+	}
+}
+
+// AllVerificationScenarios returns all verification scenarios.
+func AllVerificationScenarios() []engine.Scenario {
+	return []engine.Scenario{
+		S47_Verification(),
+	}
+}
+
 // AllGiftScenarios returns all gift scenarios.
 func AllGiftScenarios() []engine.Scenario {
 	return []engine.Scenario{
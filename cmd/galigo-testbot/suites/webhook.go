@@ -15,6 +15,7 @@ func S13_WebhookLifecycle() engine.Scenario {
 		ScenarioDescription: "Test setWebhook, getWebhookInfo, deleteWebhook (with backup/restore)",
 		CoveredMethods:      []string{"setWebhook", "getWebhookInfo", "deleteWebhook"},
 		ScenarioTimeout:     30 * time.Second,
+		ScenarioExclusive:   true,
 		ScenarioSteps: []engine.Step{
 			// 1. Backup current webhook URL
 			&engine.GetWebhookInfoStep{StoreAs: "original_webhook_url"},
@@ -41,6 +42,7 @@ func S14_GetUpdates() engine.Scenario {
 		ScenarioDescription: "Test getUpdates with non-blocking call (timeout=0)",
 		CoveredMethods:      []string{"getUpdates"},
 		ScenarioTimeout:     15 * time.Second,
+		ScenarioExclusive:   true,
 		ScenarioSteps: []engine.Step{
 			// Ensure no webhook is active (getUpdates requires this)
 			&engine.DeleteWebhookStep{},
@@ -0,0 +1,208 @@
+// Command galigo-webhook-load sends Telegram-shaped webhook requests as
+// fast as possible (or at a fixed rate) and reports throughput and latency.
+//
+// Without -url it serves a receiver.WebhookHandler on loopback, drains its
+// updates channel and measures galigo itself; with -url it load-tests a
+// deployed bot, which must accept the same secret.
+//
+// Usage:
+//
+//	go run ./cmd/galigo-webhook-load -c 64 -d 10s
+//	go run ./cmd/galigo-webhook-load -url https://bot.example.com/webhook -secret s3cret -rate 2000
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+var (
+	target      = flag.String("url", "", "webhook URL (default: an in-process handler on loopback)")
+	secret      = flag.String("secret", "load-test-secret", "X-Telegram-Bot-Api-Secret-Token value")
+	concurrency = flag.Int("c", 64, "concurrent connections")
+	duration    = flag.Duration("d", 10*time.Second, "test duration")
+	reqRate     = flag.Float64("rate", 0, "total requests per second (0 = as fast as possible)")
+	chats       = flag.Int("chats", 1000, "distinct chat IDs in the generated updates")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("galigo-webhook-load: ")
+	flag.Parse()
+	if *concurrency <= 0 || *chats <= 0 {
+		log.Fatal("-c and -chats must be positive")
+	}
+
+	url := *target
+	if url == "" {
+		var stop func()
+		url, stop = serveLocal()
+		defer stop()
+	}
+
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			MaxIdleConns:        *concurrency,
+			MaxIdleConnsPerHost: *concurrency,
+			IdleConnTimeout:     30 * time.Second,
+		},
+	}
+
+	var interval time.Duration
+	if *reqRate > 0 {
+		interval = time.Duration(float64(*concurrency) / *reqRate * float64(time.Second))
+	}
+
+	var (
+		nextID  atomic.Int64
+		results = make([]workerResult, *concurrency)
+		wg      sync.WaitGroup
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), *duration)
+	defer cancel()
+
+	log.Printf("sending to %s with %d connections for %s", url, *concurrency, *duration)
+	start := time.Now()
+	for w := range *concurrency {
+		wg.Go(func() {
+			res := workerResult{codes: make(map[int]int)}
+			var tick <-chan time.Time
+			if interval > 0 {
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				tick = ticker.C
+			}
+			for ctx.Err() == nil {
+				if tick != nil {
+					select {
+					case <-tick:
+					case <-ctx.Done():
+						continue
+					}
+				}
+				id := nextID.Add(1)
+				began := time.Now()
+				code, err := send(ctx, client, url, updateBody(id))
+				if ctx.Err() != nil {
+					break
+				}
+				res.latencies = append(res.latencies, time.Since(began))
+				if err != nil {
+					res.errors++
+				} else {
+					res.codes[code]++
+				}
+			}
+			results[w] = res
+		})
+	}
+	wg.Wait()
+	report(results, time.Since(start))
+}
+
+type workerResult struct {
+	latencies []time.Duration
+	codes     map[int]int
+	errors    int
+}
+
+// serveLocal serves a WebhookHandler on loopback and returns its URL.
+func serveLocal() (string, func()) {
+	cfg := receiver.DefaultConfig()
+	cfg.WebhookSecret = *secret
+	cfg.RateLimitRequests = 1e9 // measure the handler, not the limiter
+	cfg.RateLimitBurst = 1e9
+	cfg.PerIPRateLimit = 0
+	cfg.MaxConcurrentRequests = 0
+
+	updates := make(chan tg.Update, 4096)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := receiver.NewWebhookHandler(logger, updates, cfg)
+	go func() {
+		for range updates {
+		}
+	}()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		log.Fatal(err)
+	}
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: 5 * time.Second}
+	go srv.Serve(ln)
+	return "http://" + ln.Addr().String() + "/webhook", func() {
+		srv.Close()
+		close(updates)
+	}
+}
+
+// updateBody returns a text message update from one of -chats chats.
+func updateBody(id int64) []byte {
+	chat := strconv.FormatInt(100000+id%int64(*chats), 10)
+	return []byte(`{"update_id":` + strconv.FormatInt(id, 10) +
+		`,"message":{"message_id":` + strconv.FormatInt(id, 10) +
+		`,"date":` + strconv.FormatInt(time.Now().Unix(), 10) +
+		`,"chat":{"id":` + chat + `,"type":"private","first_name":"Load"}` +
+		`,"from":{"id":` + chat + `,"is_bot":false,"first_name":"Load","language_code":"en"}` +
+		`,"text":"/start load test message","entities":[{"type":"bot_command","offset":0,"length":6}]}}`)
+}
+
+func send(ctx context.Context, client *http.Client, url string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", *secret)
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func report(results []workerResult, elapsed time.Duration) {
+	var all []time.Duration
+	codes := make(map[int]int)
+	errors := 0
+	for _, r := range results {
+		all = append(all, r.latencies...)
+		for code, n := range r.codes {
+			codes[code] += n
+		}
+		errors += r.errors
+	}
+	if len(all) == 0 {
+		log.Fatal("no requests completed")
+	}
+	slices.Sort(all)
+	pct := func(p float64) time.Duration { return all[int(p*float64(len(all)-1))] }
+
+	fmt.Printf("requests:  %d in %s\n", len(all), elapsed.Round(time.Millisecond))
+	fmt.Printf("rate:      %.0f req/s\n", float64(len(all))/elapsed.Seconds())
+	fmt.Printf("latency:   p50 %s  p90 %s  p99 %s  max %s\n", pct(0.5), pct(0.9), pct(0.99), all[len(all)-1])
+	for _, code := range slices.Sorted(maps.Keys(codes)) {
+		fmt.Printf("status %d: %d\n", code, codes[code])
+	}
+	if errors > 0 {
+		fmt.Printf("errors:    %d\n", errors)
+	}
+}
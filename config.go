@@ -0,0 +1,646 @@
+package galigo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/prilive-com/galigo/internal/validate"
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Configuration ==================
+//
+// Config gathers every knob of a Bot in one value that can be loaded from
+// the environment or a file. Each setting has one key, used as-is in files
+// and as GALIGO_<KEY> in the environment (e.g. polling_timeout and
+// GALIGO_POLLING_TIMEOUT); configFields lists them all.
+
+// ConfigEnvPrefix prefixes the environment variable of every config key.
+const ConfigEnvPrefix = "GALIGO_"
+
+// Config is the complete configuration of a Bot. Start from DefaultConfig,
+// ConfigFromEnv or ConfigFromFile and pass it to NewFromConfig.
+//
+// The Token fields of Sender and Receiver are ignored in favour of Token.
+type Config struct {
+	Token   tg.SecretToken
+	Mode    receiver.Mode
+	BaseURL string // Bot API server, without the "/bot<token>" suffix
+
+	Sender   sender.Config
+	Receiver receiver.Config
+}
+
+// DefaultConfig returns a Config with the defaults of New: long polling
+// and the sender and receiver package defaults.
+func DefaultConfig() Config {
+	cfg := Config{
+		Mode:     receiver.ModeLongPolling,
+		Sender:   sender.DefaultConfig(),
+		Receiver: receiver.DefaultConfig(),
+	}
+	cfg.BaseURL = cfg.Sender.BaseURL
+	return cfg
+}
+
+// ConfigFromEnv returns DefaultConfig overridden by the GALIGO_<KEY>
+// environment variables that are set. The token is read from
+// GALIGO_TOKEN, falling back to TELEGRAM_BOT_TOKEN.
+//
+// Values that cannot be parsed and settings that fail Validate are
+// reported together in a ConfigErrors.
+func ConfigFromEnv() (*Config, error) {
+	cfg := DefaultConfig()
+	var problems ConfigErrors
+	for _, f := range configFields {
+		name := ConfigEnvPrefix + strings.ToUpper(f.key)
+		v, ok := os.LookupEnv(name)
+		if !ok && f.key == "token" {
+			name = "TELEGRAM_BOT_TOKEN"
+			v, ok = os.LookupEnv(name)
+		}
+		if !ok {
+			continue
+		}
+		if err := f.parse(&cfg, strings.TrimSpace(v)); err != nil {
+			problems = append(problems, tg.NewConfigError(name, err.Error()))
+		}
+	}
+	return finishConfig(&cfg, problems)
+}
+
+// ConfigFromFile loads a Config from a JSON (.json) or YAML (.yaml, .yml)
+// file holding a flat mapping of config keys to values, on top of
+// DefaultConfig. Lists, such as allowed_updates, may be given as arrays
+// or comma-separated strings.
+func ConfigFromFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("galigo: load config: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return ParseConfig(data, json.Unmarshal)
+	case ".yaml", ".yml":
+		return ParseConfig(data, unmarshalYAML)
+	}
+	return nil, fmt.Errorf("galigo: load config: unsupported file type %q (want .json, .yaml or .yml)", filepath.Ext(path))
+}
+
+// ParseConfig loads a Config from a document mapping config keys to
+// values, decoded with unmarshal (json.Unmarshal, yaml.Unmarshal, ...),
+// on top of DefaultConfig. Unknown keys, bad values and settings that
+// fail Validate are reported together in a ConfigErrors.
+func ParseConfig(data []byte, unmarshal func([]byte, any) error) (*Config, error) {
+	var doc map[string]any
+	if err := unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("galigo: load config: %w", err)
+	}
+
+	cfg := DefaultConfig()
+	var problems ConfigErrors
+	for key, raw := range doc {
+		f, ok := configFieldByKey(key)
+		if !ok {
+			problems = append(problems, tg.NewConfigError(key, "unknown key"))
+			continue
+		}
+		v, err := scalarString(raw)
+		if err == nil {
+			err = f.parse(&cfg, v)
+		}
+		if err != nil {
+			problems = append(problems, tg.NewConfigError(key, err.Error()))
+		}
+	}
+	return finishConfig(&cfg, problems)
+}
+
+// finishConfig validates cfg and returns it, or all problems found while
+// loading and validating it.
+func finishConfig(cfg *Config, problems ConfigErrors) (*Config, error) {
+	var verr ConfigErrors
+	if errors.As(cfg.Validate(), &verr) {
+		problems = append(problems, verr...)
+	}
+	if len(problems) > 0 {
+		problems.sort()
+		return nil, problems
+	}
+	return cfg, nil
+}
+
+// Validate checks every setting and returns a ConfigErrors listing all
+// problems, or nil.
+func (c *Config) Validate() error {
+	var problems ConfigErrors
+	check := func(ok bool, key, msg string) {
+		if !ok {
+			problems = append(problems, tg.NewConfigError(key, msg))
+		}
+	}
+
+	if err := validate.Token(c.Token.Value()); err != nil {
+		var verr *validate.Error
+		msg := err.Error()
+		if errors.As(err, &verr) {
+			msg = verr.Message
+		}
+		check(false, "token", msg)
+	}
+	check(c.Mode == receiver.ModeLongPolling || c.Mode == receiver.ModeWebhook,
+		"mode", "must be 'longpolling' or 'webhook'")
+	check(validate.URL(c.BaseURL) == nil, "base_url", "must start with http:// or https://")
+
+	s := &c.Sender
+	check(s.RequestTimeout > 0, "request_timeout", "must be positive")
+	check(s.GlobalRPS > 0, "rate_limit", "must be positive")
+	check(s.GlobalBurst >= 1, "rate_limit_burst", "must be at least 1")
+	check(s.PerChatRPS > 0, "per_chat_rps", "must be positive")
+	check(s.PerChatBurst >= 1, "per_chat_burst", "must be at least 1")
+	check(s.GroupRPS >= 0, "group_rps", "must not be negative")
+	check(s.GroupBurst >= 0, "group_burst", "must not be negative")
+	check(s.MaxChatLimiters >= 0, "max_chat_limiters", "must not be negative")
+	check(s.MaxRetries >= 0, "max_retries", "must not be negative")
+	check(s.RetryFactor >= 1, "retry_factor", "must be at least 1")
+	check(s.RetryMaxWait >= s.RetryBaseWait, "retry_max_wait", "must not be less than retry_base_wait")
+	check(s.MaxTextLength > 0, "max_text_length", "must be positive")
+	check(s.MaxCaptionLength > 0, "max_caption_length", "must be positive")
+
+	r := &c.Receiver
+	check(r.PollingTimeout >= 0 && r.PollingTimeout <= 60, "polling_timeout", "must be 0-60")
+	check(r.PollingLimit >= 1 && r.PollingLimit <= 100, "polling_limit", "must be 1-100")
+	check(r.PollingMaxErrors >= 0, "polling_max_errors", "must not be negative")
+	check(r.RetryBackoffFactor >= 1, "polling_retry_backoff_factor", "must be at least 1")
+	check(r.RetryMaxDelay >= r.RetryInitialDelay, "polling_retry_max_delay", "must not be less than polling_retry_initial_delay")
+	check(r.UpdateBufferSize >= 0, "update_buffer_size", "must not be negative")
+
+	if c.Mode == receiver.ModeWebhook {
+		check(r.WebhookPort >= 1 && r.WebhookPort <= 65535, "webhook_port", "must be 1-65535")
+		check(r.MaxBodySize > 0, "webhook_max_body_size", "must be positive")
+		check(r.RateLimitRequests > 0, "webhook_rate_limit", "must be positive")
+		check(r.RateLimitBurst >= 1, "webhook_rate_limit_burst", "must be at least 1")
+		check(r.MaxConcurrentRequests >= 0, "webhook_max_concurrent", "must not be negative")
+		check(r.PerIPRateLimit >= 0, "webhook_per_ip_rate_limit", "must not be negative")
+		check(r.PerIPRateLimit == 0 || r.PerIPBurst >= 1, "webhook_per_ip_burst", "must be at least 1 when webhook_per_ip_rate_limit is set")
+	}
+	check(r.WebhookURL == "" || validate.WebhookURL(r.WebhookURL) == nil, "webhook_url", "must start with https://")
+	check(r.WebhookSecret == "" || webhookSecretRe.MatchString(r.WebhookSecret),
+		"webhook_secret", "must be 1-256 characters of A-Z, a-z, 0-9, _ and -")
+	check((r.TLSCertPath == "") == (r.TLSKeyPath == ""), "tls_cert_path", "tls_cert_path and tls_key_path must be set together")
+
+	for _, f := range configFields {
+		if f.duration != nil {
+			check(f.duration(c) >= 0, f.key, "must not be negative")
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	problems.sort()
+	return problems
+}
+
+// webhookSecretRe matches the secret_token values accepted by setWebhook.
+var webhookSecretRe = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
+// String lists every setting as key=value with the token and webhook
+// secret redacted, so a Config can be logged or printed safely.
+func (c Config) String() string {
+	var b strings.Builder
+	b.WriteString("galigo.Config{")
+	for i, f := range configFields {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(f.key)
+		b.WriteByte('=')
+		b.WriteString(f.display(&c))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// GoString redacts like String, for %#v.
+func (c Config) GoString() string { return c.String() }
+
+// LogValue implements slog.LogValuer with the token and webhook secret
+// redacted.
+func (c Config) LogValue() slog.Value {
+	attrs := make([]slog.Attr, len(configFields))
+	for i, f := range configFields {
+		attrs[i] = slog.String(f.key, f.display(&c))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// WithConfig applies every setting of cfg except Token, which is passed
+// to New. Options after it override single settings.
+func WithConfig(cfg Config) Option {
+	return func(c *botConfig) {
+		c.senderConfig = cfg.Sender
+		c.receiverConfig = cfg.Receiver
+		if cfg.BaseURL != "" {
+			WithBaseURL(cfg.BaseURL)(c)
+		}
+		c.mode = cfg.Mode
+		c.pollingTimeout = cfg.Receiver.PollingTimeout
+		c.pollingLimit = cfg.Receiver.PollingLimit
+		c.pollingMaxErrors = cfg.Receiver.PollingMaxErrors
+		c.deleteWebhook = cfg.Receiver.DeleteWebhookFirst
+		c.allowedUpdates = cfg.Receiver.AllowedUpdates
+		c.webhookPort = cfg.Receiver.WebhookPort
+		c.webhookSecret = cfg.Receiver.WebhookSecret
+		c.updateBufferSize = cfg.Receiver.UpdateBufferSize
+	}
+}
+
+// NewFromConfig validates cfg and creates a Bot from it. opts are applied
+// after cfg.
+func NewFromConfig(cfg *Config, opts ...Option) (*Bot, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return New(cfg.Token.Value(), append([]Option{WithConfig(*cfg)}, opts...)...)
+}
+
+// ConfigErrors lists every problem found in a configuration. It matches
+// tg.ErrInvalidConfig with errors.Is, and each *tg.ConfigError with
+// errors.As.
+type ConfigErrors []*tg.ConfigError
+
+func (e ConfigErrors) Error() string {
+	var b strings.Builder
+	b.WriteString(tg.ErrInvalidConfig.Error())
+	for _, p := range e {
+		fmt.Fprintf(&b, "\n  %s: %s", p.Key, p.Message)
+	}
+	return b.String()
+}
+
+// Is reports whether target is tg.ErrInvalidConfig.
+func (e ConfigErrors) Is(target error) bool { return target == tg.ErrInvalidConfig }
+
+// Unwrap returns the individual problems.
+func (e ConfigErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, p := range e {
+		errs[i] = p
+	}
+	return errs
+}
+
+// sort orders problems by configFields order, unknown keys last, so
+// reports are stable.
+func (e ConfigErrors) sort() {
+	rank := func(key string) int {
+		key = strings.ToLower(strings.TrimPrefix(key, ConfigEnvPrefix))
+		if key == "telegram_bot_token" {
+			key = "token"
+		}
+		for i, f := range configFields {
+			if f.key == key {
+				return i
+			}
+		}
+		return len(configFields)
+	}
+	slices.SortStableFunc(e, func(a, b *tg.ConfigError) int { return rank(a.Key) - rank(b.Key) })
+}
+
+// ================== Config keys ==================
+
+// configField is one config key with its parser and formatter.
+type configField struct {
+	key      string
+	secret   bool
+	parse    func(c *Config, v string) error
+	format   func(c *Config) string
+	duration func(c *Config) time.Duration // nil for non-durations
+}
+
+func (f configField) display(c *Config) string {
+	v := f.format(c)
+	if f.secret && v != "" {
+		return "[REDACTED]"
+	}
+	return v
+}
+
+func configFieldByKey(key string) (configField, bool) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	for _, f := range configFields {
+		if f.key == key {
+			return f, true
+		}
+	}
+	return configField{}, false
+}
+
+// configFields lists every key in documentation order.
+var configFields = []configField{
+	// General
+	secretField("token", func(c *Config) *tg.SecretToken { return &c.Token }),
+	{
+		key: "mode",
+		parse: func(c *Config, v string) error {
+			switch strings.ToLower(v) {
+			case "longpolling", "polling":
+				c.Mode = receiver.ModeLongPolling
+			case "webhook":
+				c.Mode = receiver.ModeWebhook
+			default:
+				return errors.New("must be 'longpolling' or 'webhook'")
+			}
+			return nil
+		},
+		format: func(c *Config) string { return string(c.Mode) },
+	},
+	stringField("base_url", func(c *Config) *string { return &c.BaseURL }),
+
+	// Sending
+	durationField("request_timeout", func(c *Config) *time.Duration { return &c.Sender.RequestTimeout }),
+	durationField("send_timeout", func(c *Config) *time.Duration { return &c.Sender.SendTimeout }),
+	durationField("upload_timeout", func(c *Config) *time.Duration { return &c.Sender.UploadTimeout }),
+	durationField("download_timeout", func(c *Config) *time.Duration { return &c.Sender.DownloadTimeout }),
+	durationField("keep_alive", func(c *Config) *time.Duration { return &c.Sender.KeepAlive }),
+	intField("max_idle_conns", func(c *Config) *int { return &c.Sender.MaxIdleConns }),
+	durationField("idle_conn_timeout", func(c *Config) *time.Duration { return &c.Sender.IdleTimeout }),
+	floatField("rate_limit", func(c *Config) *float64 { return &c.Sender.GlobalRPS }),
+	intField("rate_limit_burst", func(c *Config) *int { return &c.Sender.GlobalBurst }),
+	floatField("per_chat_rps", func(c *Config) *float64 { return &c.Sender.PerChatRPS }),
+	intField("per_chat_burst", func(c *Config) *int { return &c.Sender.PerChatBurst }),
+	floatField("group_rps", func(c *Config) *float64 { return &c.Sender.GroupRPS }),
+	intField("group_burst", func(c *Config) *int { return &c.Sender.GroupBurst }),
+	intField("max_chat_limiters", func(c *Config) *int { return &c.Sender.MaxChatLimiters }),
+	uint32Field("breaker_max_requests", func(c *Config) *uint32 { return &c.Sender.BreakerMaxRequests }),
+	durationField("breaker_interval", func(c *Config) *time.Duration { return &c.Sender.BreakerInterval }),
+	durationField("breaker_timeout", func(c *Config) *time.Duration { return &c.Sender.BreakerTimeout }),
+	intField("max_retries", func(c *Config) *int { return &c.Sender.MaxRetries }),
+	durationField("retry_base_wait", func(c *Config) *time.Duration { return &c.Sender.RetryBaseWait }),
+	durationField("retry_max_wait", func(c *Config) *time.Duration { return &c.Sender.RetryMaxWait }),
+	floatField("retry_factor", func(c *Config) *float64 { return &c.Sender.RetryFactor }),
+	intField("max_text_length", func(c *Config) *int { return &c.Sender.MaxTextLength }),
+	intField("max_caption_length", func(c *Config) *int { return &c.Sender.MaxCaptionLength }),
+
+	// Receiving
+	intField("polling_timeout", func(c *Config) *int { return &c.Receiver.PollingTimeout }),
+	intField("polling_limit", func(c *Config) *int { return &c.Receiver.PollingLimit }),
+	intField("polling_max_errors", func(c *Config) *int { return &c.Receiver.PollingMaxErrors }),
+	boolField("polling_delete_webhook", func(c *Config) *bool { return &c.Receiver.DeleteWebhookFirst }),
+	boolField("polling_use_post", func(c *Config) *bool { return &c.Receiver.PollingPOST }),
+	durationField("polling_retry_initial_delay", func(c *Config) *time.Duration { return &c.Receiver.RetryInitialDelay }),
+	durationField("polling_retry_max_delay", func(c *Config) *time.Duration { return &c.Receiver.RetryMaxDelay }),
+	floatField("polling_retry_backoff_factor", func(c *Config) *float64 { return &c.Receiver.RetryBackoffFactor }),
+	listField("allowed_updates", func(c *Config) *[]string { return &c.Receiver.AllowedUpdates }),
+	intField("update_buffer_size", func(c *Config) *int { return &c.Receiver.UpdateBufferSize }),
+	{
+		key: "update_delivery_policy",
+		parse: func(c *Config, v string) error {
+			switch strings.ToLower(v) {
+			case "block":
+				c.Receiver.UpdateDeliveryPolicy = receiver.DeliveryPolicyBlock
+			case "drop_newest", "dropnewest":
+				c.Receiver.UpdateDeliveryPolicy = receiver.DeliveryPolicyDropNewest
+			case "drop_oldest", "dropoldest":
+				c.Receiver.UpdateDeliveryPolicy = receiver.DeliveryPolicyDropOldest
+			default:
+				return errors.New("must be 'block', 'drop_newest' or 'drop_oldest'")
+			}
+			return nil
+		},
+		format: func(c *Config) string {
+			switch c.Receiver.UpdateDeliveryPolicy {
+			case receiver.DeliveryPolicyDropNewest:
+				return "drop_newest"
+			case receiver.DeliveryPolicyDropOldest:
+				return "drop_oldest"
+			}
+			return "block"
+		},
+	},
+	durationField("update_delivery_timeout", func(c *Config) *time.Duration { return &c.Receiver.UpdateDeliveryTimeout }),
+	boolField("keep_raw_updates", func(c *Config) *bool { return &c.Receiver.KeepRawUpdates }),
+	uint32Field("receiver_breaker_max_requests", func(c *Config) *uint32 { return &c.Receiver.BreakerMaxRequests }),
+	durationField("receiver_breaker_interval", func(c *Config) *time.Duration { return &c.Receiver.BreakerInterval }),
+	durationField("receiver_breaker_timeout", func(c *Config) *time.Duration { return &c.Receiver.BreakerTimeout }),
+
+	// Webhook
+	intField("webhook_port", func(c *Config) *int { return &c.Receiver.WebhookPort }),
+	stringField("webhook_url", func(c *Config) *string { return &c.Receiver.WebhookURL }),
+	{
+		key:    "webhook_secret",
+		secret: true,
+		parse:  func(c *Config, v string) error { c.Receiver.WebhookSecret = v; return nil },
+		format: func(c *Config) string { return c.Receiver.WebhookSecret },
+	},
+	stringField("webhook_allowed_domain", func(c *Config) *string { return &c.Receiver.AllowedDomain }),
+	int64Field("webhook_max_body_size", func(c *Config) *int64 { return &c.Receiver.MaxBodySize }),
+	floatField("webhook_rate_limit", func(c *Config) *float64 { return &c.Receiver.RateLimitRequests }),
+	intField("webhook_rate_limit_burst", func(c *Config) *int { return &c.Receiver.RateLimitBurst }),
+	intField("webhook_max_concurrent", func(c *Config) *int { return &c.Receiver.MaxConcurrentRequests }),
+	floatField("webhook_per_ip_rate_limit", func(c *Config) *float64 { return &c.Receiver.PerIPRateLimit }),
+	intField("webhook_per_ip_burst", func(c *Config) *int { return &c.Receiver.PerIPBurst }),
+	durationField("webhook_read_timeout", func(c *Config) *time.Duration { return &c.Receiver.ReadTimeout }),
+	durationField("webhook_read_header_timeout", func(c *Config) *time.Duration { return &c.Receiver.ReadHeaderTimeout }),
+	durationField("webhook_write_timeout", func(c *Config) *time.Duration { return &c.Receiver.WriteTimeout }),
+	durationField("webhook_idle_timeout", func(c *Config) *time.Duration { return &c.Receiver.IdleTimeout }),
+	durationField("webhook_drain_delay", func(c *Config) *time.Duration { return &c.Receiver.DrainDelay }),
+	durationField("webhook_shutdown_timeout", func(c *Config) *time.Duration { return &c.Receiver.ShutdownTimeout }),
+
+	// TLS
+	stringField("tls_cert_path", func(c *Config) *string { return &c.Receiver.TLSCertPath }),
+	stringField("tls_key_path", func(c *Config) *string { return &c.Receiver.TLSKeyPath }),
+}
+
+func stringField(key string, p func(*Config) *string) configField {
+	return configField{
+		key:    key,
+		parse:  func(c *Config, v string) error { *p(c) = v; return nil },
+		format: func(c *Config) string { return *p(c) },
+	}
+}
+
+func secretField(key string, p func(*Config) *tg.SecretToken) configField {
+	return configField{
+		key:    key,
+		secret: true,
+		parse:  func(c *Config, v string) error { *p(c) = tg.SecretToken(v); return nil },
+		format: func(c *Config) string { return p(c).Value() },
+	}
+}
+
+func durationField(key string, p func(*Config) *time.Duration) configField {
+	return configField{
+		key: key,
+		parse: func(c *Config, v string) error {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("invalid duration %q", v)
+			}
+			*p(c) = d
+			return nil
+		},
+		format:   func(c *Config) string { return p(c).String() },
+		duration: func(c *Config) time.Duration { return *p(c) },
+	}
+}
+
+func intField(key string, p func(*Config) *int) configField {
+	return configField{
+		key: key,
+		parse: func(c *Config, v string) error {
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("invalid integer %q", v)
+			}
+			*p(c) = i
+			return nil
+		},
+		format: func(c *Config) string { return strconv.Itoa(*p(c)) },
+	}
+}
+
+func int64Field(key string, p func(*Config) *int64) configField {
+	return configField{
+		key: key,
+		parse: func(c *Config, v string) error {
+			i, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid integer %q", v)
+			}
+			*p(c) = i
+			return nil
+		},
+		format: func(c *Config) string { return strconv.FormatInt(*p(c), 10) },
+	}
+}
+
+func uint32Field(key string, p func(*Config) *uint32) configField {
+	return configField{
+		key: key,
+		parse: func(c *Config, v string) error {
+			i, err := strconv.ParseUint(v, 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid unsigned integer %q", v)
+			}
+			*p(c) = uint32(i)
+			return nil
+		},
+		format: func(c *Config) string { return strconv.FormatUint(uint64(*p(c)), 10) },
+	}
+}
+
+func floatField(key string, p func(*Config) *float64) configField {
+	return configField{
+		key: key,
+		parse: func(c *Config, v string) error {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return fmt.Errorf("invalid number %q", v)
+			}
+			*p(c) = f
+			return nil
+		},
+		format: func(c *Config) string { return strconv.FormatFloat(*p(c), 'g', -1, 64) },
+	}
+}
+
+func boolField(key string, p func(*Config) *bool) configField {
+	return configField{
+		key: key,
+		parse: func(c *Config, v string) error {
+			switch strings.ToLower(v) {
+			case "1", "t", "true", "yes", "on":
+				*p(c) = true
+			case "0", "f", "false", "no", "off":
+				*p(c) = false
+			default:
+				return fmt.Errorf("invalid boolean %q", v)
+			}
+			return nil
+		},
+		format: func(c *Config) string { return strconv.FormatBool(*p(c)) },
+	}
+}
+
+// listField parses comma-separated values; file arrays are joined with
+// commas by scalarString first.
+func listField(key string, p func(*Config) *[]string) configField {
+	return configField{
+		key: key,
+		parse: func(c *Config, v string) error {
+			var list []string
+			for item := range strings.SplitSeq(v, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					list = append(list, item)
+				}
+			}
+			*p(c) = list
+			return nil
+		},
+		format: func(c *Config) string { return strings.Join(*p(c), ",") },
+	}
+}
+
+// scalarString converts a decoded document value to the string form the
+// config parsers take. Arrays become comma-separated lists.
+func scalarString(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return strings.TrimSpace(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			s, err := scalarString(item)
+			if err != nil {
+				return "", err
+			}
+			if _, isList := item.([]any); isList {
+				return "", errors.New("nested lists are not supported")
+			}
+			items[i] = s
+		}
+		return strings.Join(items, ","), nil
+	}
+	return "", fmt.Errorf("must be a scalar or a list, got %T", v)
+}
+
+// unmarshalYAML decodes a YAML document into v, rejecting keys v does not
+// have when it is a struct. An empty document leaves v unchanged.
+func unmarshalYAML(data []byte, v any) error {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package galigo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+const configTestToken = "123456789:ABCdefGHIjklMNOpqrSTUvwxYZ"
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func configProblems(t *testing.T, err error) map[string]string {
+	t.Helper()
+	var problems ConfigErrors
+	require.ErrorAs(t, err, &problems)
+	assert.ErrorIs(t, err, tg.ErrInvalidConfig)
+	m := make(map[string]string, len(problems))
+	for _, p := range problems {
+		m[p.Key] = p.Message
+	}
+	return m
+}
+
+func TestDefaultConfig_ValidWithToken(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Token = configTestToken
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, receiver.ModeLongPolling, cfg.Mode)
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("TELEGRAM_BOT_TOKEN", configTestToken)
+	t.Setenv("GALIGO_MODE", "webhook")
+	t.Setenv("GALIGO_WEBHOOK_PORT", "9443")
+	t.Setenv("GALIGO_WEBHOOK_SECRET", "s3cret_value")
+	t.Setenv("GALIGO_RATE_LIMIT", "25")
+	t.Setenv("GALIGO_MAX_RETRIES", "7")
+	t.Setenv("GALIGO_BREAKER_TIMEOUT", "45s")
+	t.Setenv("GALIGO_ALLOWED_UPDATES", "message, callback_query")
+	t.Setenv("GALIGO_POLLING_DELETE_WEBHOOK", "yes")
+
+	cfg, err := ConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, configTestToken, cfg.Token.Value())
+	assert.Equal(t, receiver.ModeWebhook, cfg.Mode)
+	assert.Equal(t, 9443, cfg.Receiver.WebhookPort)
+	assert.Equal(t, "s3cret_value", cfg.Receiver.WebhookSecret)
+	assert.Equal(t, 25.0, cfg.Sender.GlobalRPS)
+	assert.Equal(t, 7, cfg.Sender.MaxRetries)
+	assert.Equal(t, 45*time.Second, cfg.Sender.BreakerTimeout)
+	assert.Equal(t, []string{"message", "callback_query"}, cfg.Receiver.AllowedUpdates)
+	assert.True(t, cfg.Receiver.DeleteWebhookFirst)
+	// Untouched settings keep their defaults.
+	assert.Equal(t, 100, cfg.Receiver.PollingLimit)
+}
+
+func TestConfigFromEnv_ReportsAllProblems(t *testing.T) {
+	t.Setenv("GALIGO_TOKEN", "")
+	t.Setenv("TELEGRAM_BOT_TOKEN", "")
+	t.Setenv("GALIGO_POLLING_TIMEOUT", "90")
+	t.Setenv("GALIGO_REQUEST_TIMEOUT", "soon")
+	t.Setenv("GALIGO_MAX_RETRIES", "-1")
+
+	cfg, err := ConfigFromEnv()
+	require.Error(t, err)
+	assert.Nil(t, cfg)
+
+	problems := configProblems(t, err)
+	assert.Equal(t, map[string]string{
+		"token":                  "cannot be empty",
+		"GALIGO_REQUEST_TIMEOUT": `invalid duration "soon"`,
+		"max_retries":            "must not be negative",
+		"polling_timeout":        "must be 0-60",
+	}, problems)
+	assert.Contains(t, err.Error(), "polling_timeout: must be 0-60")
+}
+
+func TestConfigFromFile_JSON(t *testing.T) {
+	path := writeConfigFile(t, "bot.json", `{
+		"token": "`+configTestToken+`",
+		"polling_timeout": 50,
+		"per_chat_rps": 0.5,
+		"keep_raw_updates": true,
+		"allowed_updates": ["message", "edited_message"],
+		"update_delivery_policy": "drop_oldest"
+	}`)
+
+	cfg, err := ConfigFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 50, cfg.Receiver.PollingTimeout)
+	assert.Equal(t, 0.5, cfg.Sender.PerChatRPS)
+	assert.True(t, cfg.Receiver.KeepRawUpdates)
+	assert.Equal(t, []string{"message", "edited_message"}, cfg.Receiver.AllowedUpdates)
+	assert.Equal(t, receiver.DeliveryPolicyDropOldest, cfg.Receiver.UpdateDeliveryPolicy)
+}
+
+func TestConfigFromFile_YAML(t *testing.T) {
+	path := writeConfigFile(t, "bot.yaml", `# bot settings
+token: "`+configTestToken+`"
+mode: webhook            # behind the load balancer
+webhook_port: 8443
+webhook_url: https://bot.example.com/hook
+tls_cert_path: /etc/bot/cert.pem
+tls_key_path: '/etc/bot/key.pem'
+allowed_updates:
+  - message
+  - "callback_query"
+retry_base_wait: 500ms
+`)
+
+	cfg, err := ConfigFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, receiver.ModeWebhook, cfg.Mode)
+	assert.Equal(t, "https://bot.example.com/hook", cfg.Receiver.WebhookURL)
+	assert.Equal(t, "/etc/bot/key.pem", cfg.Receiver.TLSKeyPath)
+	assert.Equal(t, []string{"message", "callback_query"}, cfg.Receiver.AllowedUpdates)
+	assert.Equal(t, 500*time.Millisecond, cfg.Sender.RetryBaseWait)
+}
+
+func TestConfigFromFile_ReportsAllProblems(t *testing.T) {
+	path := writeConfigFile(t, "bot.yml", `
+token: `+configTestToken+`
+mode: webhook
+webhook_port: 0
+webhook_url: http://bot.example.com
+tls_cert_path: /etc/bot/cert.pem
+polling_limit: lots
+colour: blue
+`)
+
+	_, err := ConfigFromFile(path)
+	problems := configProblems(t, err)
+	assert.Equal(t, map[string]string{
+		"webhook_port":  "must be 1-65535",
+		"webhook_url":   "must start with https://",
+		"tls_cert_path": "tls_cert_path and tls_key_path must be set together",
+		"polling_limit": `invalid integer "lots"`,
+		"colour":        "unknown key",
+	}, problems)
+}
+
+func TestConfigFromFile_Errors(t *testing.T) {
+	_, err := ConfigFromFile(writeConfigFile(t, "bot.toml", "token = 1"))
+	assert.ErrorContains(t, err, "unsupported file type")
+
+	_, err = ConfigFromFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = ConfigFromFile(writeConfigFile(t, "bot.yaml", "sender:\n  max_retries: 3\n"))
+	assert.ErrorContains(t, err, "sender: unknown key")
+
+	_, err = ConfigFromFile(writeConfigFile(t, "bot.yaml", "max_retries: {value: 3}\n"))
+	assert.ErrorContains(t, err, "max_retries: must be a scalar or a list")
+
+	_, err = ConfigFromFile(writeConfigFile(t, "bot.yaml", "max_retries: [1\n"))
+	assert.ErrorContains(t, err, "galigo: load config: yaml:")
+
+	_, err = ConfigFromFile(writeConfigFile(t, "bot.json", `{"max_retries": {"value": 3}}`))
+	assert.ErrorContains(t, err, "max_retries: must be a scalar or a list")
+}
+
+func TestParseConfig_CustomUnmarshal(t *testing.T) {
+	unmarshal := func(data []byte, v any) error {
+		*v.(*map[string]any) = map[string]any{"token": string(data), "max_retries": 2}
+		return nil
+	}
+	cfg, err := ParseConfig([]byte(configTestToken), unmarshal)
+	require.NoError(t, err)
+	assert.Equal(t, 2, cfg.Sender.MaxRetries)
+
+	_, err = ParseConfig([]byte("x"), func([]byte, any) error { return errors.New("boom") })
+	assert.ErrorContains(t, err, "galigo: load config: boom")
+}
+
+func TestConfig_StringRedactsSecrets(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Token = configTestToken
+	cfg.Receiver.WebhookSecret = "hook_secret_value"
+
+	for _, out := range []string{
+		cfg.String(),
+		fmt.Sprintf("%v", cfg),
+		fmt.Sprintf("%+v", cfg),
+		fmt.Sprintf("%#v", cfg),
+		fmt.Sprintf("%v", &cfg),
+	} {
+		assert.NotContains(t, out, "ABCdefGHI")
+		assert.NotContains(t, out, "hook_secret_value")
+		assert.Contains(t, out, "token=[REDACTED]")
+		assert.Contains(t, out, "webhook_secret=[REDACTED]")
+		assert.Contains(t, out, "max_retries=3")
+	}
+
+	value, err := json.Marshal(cfg.LogValue().Group()[0].Value.Any())
+	require.NoError(t, err)
+	assert.Equal(t, `"[REDACTED]"`, string(value))
+}
+
+func TestNewFromConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Token = configTestToken
+	cfg.Sender.MaxRetries = 6
+	cfg.Receiver.PollingTimeout = 15
+	cfg.Receiver.UpdateBufferSize = 7
+	cfg.BaseURL = "http://localhost:8081"
+
+	bot, err := NewFromConfig(&cfg, WithRetries(1))
+	require.NoError(t, err)
+	defer bot.Close()
+
+	assert.Equal(t, 1, bot.config.senderConfig.MaxRetries, "options after the config win")
+	assert.Equal(t, 15, bot.config.receiverConfig.PollingTimeout)
+	assert.Equal(t, "http://localhost:8081/bot", bot.config.receiverConfig.BaseURL)
+	assert.Equal(t, 7, cap(bot.updates))
+
+	cfg.Receiver.PollingLimit = 0
+	_, err = NewFromConfig(&cfg)
+	assert.Equal(t, "must be 1-100", configProblems(t, err)["polling_limit"])
+}
+
+func TestConfigFields_UniqueKeys(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, f := range configFields {
+		assert.False(t, seen[f.key], "duplicate key %s", f.key)
+		seen[f.key] = true
+	}
+}
@@ -0,0 +1,434 @@
+package galigo
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Chat & User Directory ==================
+//
+// Handlers often need to turn an ID into a name or ask whether a user is a
+// chat admin. A Directory remembers every chat and user seen in updates,
+// refreshes chats in the background with getChat, and answers admin checks
+// from a TTL-bounded copy of getChatAdministrators.
+
+// DirectoryAPI is the subset of the Bot API a Directory calls.
+// Both *Bot and *sender.Client implement it.
+type DirectoryAPI interface {
+	GetChat(ctx context.Context, chatID tg.ChatID) (*tg.ChatFullInfo, error)
+	GetChatAdministrators(ctx context.Context, chatID tg.ChatID) ([]tg.ChatMember, error)
+}
+
+// DirectoryEntry is what a Directory knows about a chat or user.
+// Chats and users share an ID space (a private chat has the user's ID), so
+// there is one entry per ID.
+type DirectoryEntry struct {
+	ID int64
+	// Type is the chat type ("private", "group", "supergroup", "channel"),
+	// or "" for users only seen as message senders.
+	Type      string
+	Title     string
+	Username  string
+	FirstName string
+	LastName  string
+	IsBot     bool
+	// SeenAt is when the entry last appeared in an update.
+	SeenAt time.Time
+	// RefreshedAt is when the entry was last refreshed with getChat.
+	RefreshedAt time.Time
+}
+
+// DisplayName returns the entry's title, full name or @username, falling
+// back to the numeric ID.
+func (e DirectoryEntry) DisplayName() string {
+	if e.Title != "" {
+		return e.Title
+	}
+	if name := strings.TrimSpace(e.FirstName + " " + e.LastName); name != "" {
+		return name
+	}
+	if e.Username != "" {
+		return "@" + e.Username
+	}
+	return strconv.FormatInt(e.ID, 10)
+}
+
+// DirectoryStore persists directory entries across restarts.
+// Implementations must be safe for concurrent use.
+type DirectoryStore interface {
+	Load(ctx context.Context) ([]DirectoryEntry, error)
+	Save(ctx context.Context, entry DirectoryEntry) error
+}
+
+// MemoryDirectoryStore is an in-memory DirectoryStore.
+// The zero value is ready to use.
+type MemoryDirectoryStore struct {
+	mu      sync.Mutex
+	entries map[int64]DirectoryEntry
+}
+
+var _ DirectoryStore = (*MemoryDirectoryStore)(nil)
+
+// Load returns all saved entries ordered by ID.
+func (s *MemoryDirectoryStore) Load(_ context.Context) ([]DirectoryEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make([]DirectoryEntry, 0, len(s.entries))
+	for _, e := range s.entries {
+		entries = append(entries, e)
+	}
+	slices.SortFunc(entries, func(a, b DirectoryEntry) int { return cmp.Compare(a.ID, b.ID) })
+	return entries, nil
+}
+
+// Save stores entry, replacing any entry with the same ID.
+func (s *MemoryDirectoryStore) Save(_ context.Context, entry DirectoryEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entries == nil {
+		s.entries = make(map[int64]DirectoryEntry)
+	}
+	s.entries[entry.ID] = entry
+	return nil
+}
+
+// DirectoryConfig tunes a Directory. Zero fields use defaults.
+type DirectoryConfig struct {
+	// Store persists entries (default: in memory only).
+	Store DirectoryStore
+	// RefreshInterval is how old a chat entry may get before the background
+	// loop refreshes it with getChat (default 1h).
+	RefreshInterval time.Duration
+	// RefreshBatch bounds the getChat calls per refresh round (default 20).
+	RefreshBatch int
+	// AdminTTL is how long a getChatAdministrators result is trusted
+	// (default 10m). chat_member updates that change admin status drop it
+	// early.
+	AdminTTL time.Duration
+	// Logger receives refresh and store errors (default slog.Default()).
+	Logger *slog.Logger
+}
+
+func (c DirectoryConfig) withDefaults() DirectoryConfig {
+	if c.Store == nil {
+		c.Store = &MemoryDirectoryStore{}
+	}
+	if c.RefreshInterval <= 0 {
+		c.RefreshInterval = time.Hour
+	}
+	if c.RefreshBatch <= 0 {
+		c.RefreshBatch = 20
+	}
+	if c.AdminTTL <= 0 {
+		c.AdminTTL = 10 * time.Minute
+	}
+	if c.Logger == nil {
+		c.Logger = slog.Default()
+	}
+	return c
+}
+
+// Directory tracks chats and users seen in updates. Feed it with Observe
+// from the update loop, then call Start to load the store and begin
+// background refreshes.
+type Directory struct {
+	api DirectoryAPI
+	cfg DirectoryConfig
+
+	mu      sync.RWMutex
+	entries map[int64]*DirectoryEntry
+	admins  map[int64]adminList
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+type adminList struct {
+	userIDs   []int64
+	fetchedAt time.Time
+}
+
+// NewDirectory creates a Directory that refreshes entries through api.
+func NewDirectory(api DirectoryAPI, cfg DirectoryConfig) *Directory {
+	return &Directory{
+		api:     api,
+		cfg:     cfg.withDefaults(),
+		entries: make(map[int64]*DirectoryEntry),
+		admins:  make(map[int64]adminList),
+		stop:    make(chan struct{}),
+	}
+}
+
+// Start loads saved entries from the store and starts the background
+// refresh loop, which runs until ctx is done or Close is called.
+func (d *Directory) Start(ctx context.Context) error {
+	saved, err := d.cfg.Store.Load(ctx)
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	for _, e := range saved {
+		if _, ok := d.entries[e.ID]; !ok {
+			d.entries[e.ID] = &e
+		}
+	}
+	d.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(d.cfg.RefreshInterval / 4)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				d.refresh(ctx, time.Now())
+			case <-ctx.Done():
+				return
+			case <-d.stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops the refresh loop started by Start.
+func (d *Directory) Close() {
+	d.stopOnce.Do(func() {
+		close(d.stop)
+	})
+}
+
+// Observe records the chats and users carried by update.
+func (d *Directory) Observe(ctx context.Context, update tg.Update) {
+	chats := []*tg.Chat{update.EffectiveChat()}
+	users := []*tg.User{update.EffectiveUser()}
+
+	if m := update.EffectiveMessage(); m != nil {
+		chats = append(chats, m.SenderChat)
+	}
+	if m := update.MemberUpdate(); m != nil {
+		if m.NewChatMember != nil {
+			users = append(users, m.NewChatMember.GetUser())
+		}
+		if m.Chat != nil && tg.IsAdmin(m.OldChatMember) != tg.IsAdmin(m.NewChatMember) {
+			d.InvalidateAdmins(m.Chat.ID)
+		}
+	}
+	if r := update.MessageReaction; r != nil {
+		chats = append(chats, r.ActorChat)
+	}
+	if a := update.PollAnswer; a != nil {
+		chats = append(chats, a.VoterChat)
+	}
+
+	now := time.Now()
+	for _, c := range chats {
+		if c != nil {
+			d.upsert(ctx, DirectoryEntry{
+				ID: c.ID, Type: c.Type, Title: c.Title, Username: c.Username,
+				FirstName: c.FirstName, LastName: c.LastName,
+			}, now)
+		}
+	}
+	for _, u := range users {
+		if u != nil {
+			d.upsert(ctx, DirectoryEntry{
+				ID: u.ID, Username: u.Username, FirstName: u.FirstName,
+				LastName: u.LastName, IsBot: u.IsBot,
+			}, now)
+		}
+	}
+}
+
+// upsert merges seen into the directory and saves the entry when its
+// names changed. Store failures are logged: the in-memory copy stays
+// authoritative for this process.
+func (d *Directory) upsert(ctx context.Context, seen DirectoryEntry, now time.Time) {
+	d.mu.Lock()
+	e, ok := d.entries[seen.ID]
+	if !ok {
+		e = &DirectoryEntry{ID: seen.ID}
+		d.entries[seen.ID] = e
+	}
+	changed := mergeEntry(e, seen) || !ok
+	e.SeenAt = now
+	snapshot := *e
+	d.mu.Unlock()
+
+	if changed {
+		d.save(ctx, snapshot)
+	}
+}
+
+// mergeEntry copies the non-empty fields of src into dst and reports
+// whether anything changed.
+func mergeEntry(dst *DirectoryEntry, src DirectoryEntry) bool {
+	before := *dst
+	setIfNonZero(&dst.Type, src.Type)
+	setIfNonZero(&dst.Title, src.Title)
+	setIfNonZero(&dst.Username, src.Username)
+	setIfNonZero(&dst.FirstName, src.FirstName)
+	setIfNonZero(&dst.LastName, src.LastName)
+	dst.IsBot = dst.IsBot || src.IsBot
+	return before != *dst
+}
+
+func setIfNonZero[T comparable](dst *T, v T) {
+	var zero T
+	if v != zero {
+		*dst = v
+	}
+}
+
+func (d *Directory) save(ctx context.Context, e DirectoryEntry) {
+	if err := d.cfg.Store.Save(ctx, e); err != nil {
+		d.cfg.Logger.Warn("directory store save failed", "id", e.ID, "error", err)
+	}
+}
+
+// Lookup returns the entry for id.
+func (d *Directory) Lookup(id int64) (DirectoryEntry, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	e, ok := d.entries[id]
+	if !ok {
+		return DirectoryEntry{}, false
+	}
+	return *e, true
+}
+
+// DisplayName returns a human-readable name for a chat or user ID: the
+// chat title, the user's full name or @username. Unknown IDs are returned
+// as numbers.
+func (d *Directory) DisplayName(id int64) string {
+	e, ok := d.Lookup(id)
+	if !ok {
+		return strconv.FormatInt(id, 10)
+	}
+	return e.DisplayName()
+}
+
+// Len returns the number of known chats and users.
+func (d *Directory) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.entries)
+}
+
+// IsAdmin reports whether userID is an administrator (or the owner) of
+// chatID. Admin lists are fetched with getChatAdministrators and reused for
+// AdminTTL. Private chats have no administrators.
+func (d *Directory) IsAdmin(ctx context.Context, chatID, userID int64) (bool, error) {
+	if chatID > 0 {
+		return false, nil
+	}
+	admins, err := d.Admins(ctx, chatID)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(admins, userID), nil
+}
+
+// Admins returns the user IDs of chatID's administrators, including the
+// owner, from the cache or getChatAdministrators.
+func (d *Directory) Admins(ctx context.Context, chatID int64) ([]int64, error) {
+	d.mu.RLock()
+	cached, ok := d.admins[chatID]
+	d.mu.RUnlock()
+	if ok && time.Since(cached.fetchedAt) < d.cfg.AdminTTL {
+		return cached.userIDs, nil
+	}
+
+	members, err := d.api.GetChatAdministrators(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(members))
+	for _, m := range members {
+		if u := m.GetUser(); u != nil {
+			ids = append(ids, u.ID)
+		}
+	}
+
+	d.mu.Lock()
+	d.admins[chatID] = adminList{userIDs: ids, fetchedAt: time.Now()}
+	d.mu.Unlock()
+	return ids, nil
+}
+
+// InvalidateAdmins drops the cached admin list of chatID.
+func (d *Directory) InvalidateAdmins(chatID int64) {
+	d.mu.Lock()
+	delete(d.admins, chatID)
+	d.mu.Unlock()
+}
+
+// Refresh updates chatID's entry with getChat now.
+func (d *Directory) Refresh(ctx context.Context, chatID int64) error {
+	info, err := d.api.GetChat(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	d.mu.Lock()
+	e, ok := d.entries[chatID]
+	if !ok {
+		e = &DirectoryEntry{ID: chatID}
+		d.entries[chatID] = e
+	}
+	e.Type = info.Type
+	e.Title = info.Title
+	e.Username = info.Username
+	e.FirstName = info.FirstName
+	e.LastName = info.LastName
+	e.RefreshedAt = now
+	snapshot := *e
+	d.mu.Unlock()
+
+	d.save(ctx, snapshot)
+	return nil
+}
+
+// refresh calls getChat for up to RefreshBatch chats whose data is older
+// than RefreshInterval, oldest first. The calls carry bulk priority so they
+// yield to interactive traffic.
+func (d *Directory) refresh(ctx context.Context, now time.Time) {
+	d.mu.RLock()
+	var stale []DirectoryEntry
+	for _, e := range d.entries {
+		if e.Type != "" && now.Sub(e.RefreshedAt) >= d.cfg.RefreshInterval {
+			stale = append(stale, *e)
+		}
+	}
+	d.mu.RUnlock()
+
+	slices.SortFunc(stale, func(a, b DirectoryEntry) int { return a.RefreshedAt.Compare(b.RefreshedAt) })
+	if len(stale) > d.cfg.RefreshBatch {
+		stale = stale[:d.cfg.RefreshBatch]
+	}
+
+	bulkCtx := sender.WithBulkPriority(ctx)
+	for _, e := range stale {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := d.Refresh(bulkCtx, e.ID); err != nil {
+			d.cfg.Logger.Debug("directory refresh failed", "chat_id", e.ID, "error", err)
+			// Back off until the next interval instead of retrying every round.
+			d.mu.Lock()
+			if cur, ok := d.entries[e.ID]; ok {
+				cur.RefreshedAt = now
+			}
+			d.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package galigo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+type fakeDirectoryAPI struct {
+	mu         sync.Mutex
+	chats      map[int64]*tg.ChatFullInfo
+	admins     map[int64][]tg.ChatMember
+	chatCalls  int
+	adminCalls int
+}
+
+func (f *fakeDirectoryAPI) GetChat(_ context.Context, chatID tg.ChatID) (*tg.ChatFullInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.chatCalls++
+	info, ok := f.chats[chatID.(int64)]
+	if !ok {
+		return nil, errors.New("chat not found")
+	}
+	return info, nil
+}
+
+func (f *fakeDirectoryAPI) GetChatAdministrators(_ context.Context, chatID tg.ChatID) ([]tg.ChatMember, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.adminCalls++
+	return f.admins[chatID.(int64)], nil
+}
+
+func admin(userID int64) tg.ChatMember {
+	m := tg.ChatMemberAdministrator{}
+	m.User = &tg.User{ID: userID}
+	return m
+}
+
+func groupMessage(chatID, userID int64) tg.Update {
+	return tg.Update{Message: &tg.Message{
+		Chat: &tg.Chat{ID: chatID, Type: "supergroup", Title: "Gophers"},
+		From: &tg.User{ID: userID, FirstName: "Ann", LastName: "Lee", Username: "ann"},
+	}}
+}
+
+func TestDirectory_ObserveAndDisplayName(t *testing.T) {
+	d := NewDirectory(&fakeDirectoryAPI{}, DirectoryConfig{})
+	d.Observe(context.Background(), groupMessage(-100, 7))
+	d.Observe(context.Background(), tg.Update{CallbackQuery: &tg.CallbackQuery{From: &tg.User{ID: 8, Username: "bob"}}})
+
+	assert.Equal(t, "Gophers", d.DisplayName(-100))
+	assert.Equal(t, "Ann Lee", d.DisplayName(7))
+	assert.Equal(t, "@bob", d.DisplayName(8))
+	assert.Equal(t, "42", d.DisplayName(42))
+	assert.Equal(t, 3, d.Len())
+
+	e, ok := d.Lookup(-100)
+	require.True(t, ok)
+	assert.Equal(t, "supergroup", e.Type)
+	assert.False(t, e.SeenAt.IsZero())
+}
+
+func TestDirectory_IsAdminCachesAdministrators(t *testing.T) {
+	api := &fakeDirectoryAPI{admins: map[int64][]tg.ChatMember{-100: {admin(7)}}}
+	d := NewDirectory(api, DirectoryConfig{})
+	ctx := context.Background()
+
+	ok, err := d.IsAdmin(ctx, -100, 7)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = d.IsAdmin(ctx, -100, 8)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 1, api.adminCalls)
+
+	ok, err = d.IsAdmin(ctx, 7, 7)
+	require.NoError(t, err)
+	assert.False(t, ok, "private chats have no admins")
+	assert.Equal(t, 1, api.adminCalls)
+}
+
+func TestDirectory_AdminChangeInvalidates(t *testing.T) {
+	api := &fakeDirectoryAPI{admins: map[int64][]tg.ChatMember{-100: {admin(7)}}}
+	d := NewDirectory(api, DirectoryConfig{})
+	ctx := context.Background()
+
+	_, err := d.IsAdmin(ctx, -100, 8)
+	require.NoError(t, err)
+
+	promoted := admin(8)
+	left := tg.ChatMemberMember{}
+	left.User = &tg.User{ID: 8}
+	d.Observe(ctx, tg.Update{ChatMember: &tg.ChatMemberUpdated{
+		Chat:          &tg.Chat{ID: -100, Type: "supergroup"},
+		From:          &tg.User{ID: 7},
+		OldChatMember: left,
+		NewChatMember: promoted,
+	}})
+	api.admins[-100] = append(api.admins[-100], promoted)
+
+	ok, err := d.IsAdmin(ctx, -100, 8)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, api.adminCalls)
+}
+
+func TestDirectory_RefreshStaleChats(t *testing.T) {
+	api := &fakeDirectoryAPI{chats: map[int64]*tg.ChatFullInfo{
+		-100: {ID: -100, Type: "supergroup", Title: "Renamed"},
+	}}
+	d := NewDirectory(api, DirectoryConfig{RefreshInterval: time.Minute})
+	ctx := context.Background()
+	d.Observe(ctx, groupMessage(-100, 7))
+
+	d.refresh(ctx, time.Now())
+	assert.Equal(t, "Renamed", d.DisplayName(-100))
+	assert.Equal(t, 1, api.chatCalls, "users without a chat type are not refreshed")
+
+	d.refresh(ctx, time.Now())
+	assert.Equal(t, 1, api.chatCalls, "fresh entries are skipped")
+}
+
+func TestDirectory_Persistence(t *testing.T) {
+	store := &MemoryDirectoryStore{}
+	ctx := context.Background()
+
+	first := NewDirectory(&fakeDirectoryAPI{}, DirectoryConfig{Store: store})
+	first.Observe(ctx, groupMessage(-100, 7))
+
+	saved, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Len(t, saved, 2)
+
+	second := NewDirectory(&fakeDirectoryAPI{}, DirectoryConfig{Store: store})
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	require.NoError(t, second.Start(runCtx))
+	defer second.Close()
+
+	assert.Equal(t, "Gophers", second.DisplayName(-100))
+	assert.Equal(t, "Ann Lee", second.DisplayName(7))
+}
@@ -0,0 +1,54 @@
+package e2e
+
+import "slices"
+
+// Coverer is implemented by scenarios to declare method coverage.
+type Coverer interface {
+	Covers() []string
+}
+
+// CoverageReport shows which registry methods are covered or missing.
+// Unknown lists methods declared by scenarios but absent from the registry.
+type CoverageReport struct {
+	Covered []string
+	Missing []string
+	Unknown []string
+}
+
+// CheckCoverage compares the methods declared by scenarios against the
+// registry of methods that should be exercised.
+func CheckCoverage(registry []string, scenarios []Coverer) *CoverageReport {
+	covered := make(map[string]bool, len(registry))
+	for _, m := range registry {
+		covered[m] = false
+	}
+
+	unknown := make(map[string]bool)
+	for _, s := range scenarios {
+		for _, method := range s.Covers() {
+			if _, ok := covered[method]; ok {
+				covered[method] = true
+			} else {
+				unknown[method] = true
+			}
+		}
+	}
+
+	report := &CoverageReport{}
+	for method, isCovered := range covered {
+		if isCovered {
+			report.Covered = append(report.Covered, method)
+		} else {
+			report.Missing = append(report.Missing, method)
+		}
+	}
+	for method := range unknown {
+		report.Unknown = append(report.Unknown, method)
+	}
+
+	slices.Sort(report.Covered)
+	slices.Sort(report.Missing)
+	slices.Sort(report.Unknown)
+
+	return report
+}
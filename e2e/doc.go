@@ -0,0 +1,49 @@
+// Package e2e is a small engine for live end-to-end conformance suites
+// against the real Telegram Bot API (or a staging bot). It is the engine
+// behind cmd/galigo-testbot, exposed so applications can run the same kind
+// of suites against their own bots.
+//
+// # Scenarios and Steps
+//
+// A Scenario is a named, ordered list of Steps that declares which API
+// methods it covers. Steps receive a caller-defined runtime value R that
+// carries the client and any state shared between steps:
+//
+//	type Env struct {
+//	    Client *sender.Client
+//	    ChatID int64
+//	}
+//
+//	scenario := &e2e.BaseScenario[*Env]{
+//	    ScenarioName:   "greeting",
+//	    CoveredMethods: []string{"sendMessage"},
+//	    ScenarioSteps: []e2e.Step[*Env]{
+//	        e2e.NewStep("send", func(ctx context.Context, env *Env) (*e2e.StepResult, error) {
+//	            msg, err := env.Client.SendMessage(ctx, sender.SendMessageRequest{ChatID: env.ChatID, Text: "hi"})
+//	            if err != nil {
+//	                return nil, err
+//	            }
+//	            return &e2e.StepResult{Method: "sendMessage", MessageIDs: []int{msg.MessageID}}, nil
+//	        }),
+//	    },
+//	}
+//
+// A step returning Skip(reason) marks the scenario as skipped rather than
+// failed, e.g. when the bot lacks admin rights in the test chat.
+//
+// # Running
+//
+// Runner executes scenarios with pacing, a per-run message budget and
+// automatic retry of steps that hit 429 Too Many Requests:
+//
+//	runner := e2e.NewRunner(env, e2e.RunnerConfig{MaxMessages: 40, RetryOn429: true, Max429Retries: 2}, logger)
+//	report := e2e.NewReport()
+//	report.AddScenario(runner.Run(ctx, scenario))
+//	report.Finalize()
+//
+// # Reports and Coverage
+//
+// Report is the evidence of a run. It can be written as JSON (WriteJSON,
+// Save) or JUnit XML (WriteJUnit) for CI ingestion. CheckCoverage compares
+// the methods declared by scenarios against a method registry.
+package e2e
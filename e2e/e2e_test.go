@@ -0,0 +1,279 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/e2e"
+	"github.com/prilive-com/galigo/tg"
+)
+
+type env struct {
+	calls []string
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+}
+
+func record(name string, msgIDs ...int) e2e.Step[*env] {
+	return e2e.NewStep(name, func(_ context.Context, rt *env) (*e2e.StepResult, error) {
+		rt.calls = append(rt.calls, name)
+		return &e2e.StepResult{StepName: name, MessageIDs: msgIDs}, nil
+	})
+}
+
+func scenario(name string, steps ...e2e.Step[*env]) *e2e.BaseScenario[*env] {
+	return &e2e.BaseScenario[*env]{
+		ScenarioName:   name,
+		CoveredMethods: []string{"sendMessage"},
+		ScenarioSteps:  steps,
+	}
+}
+
+func TestRunner_RunsStepsInOrder(t *testing.T) {
+	rt := &env{}
+	runner := e2e.NewRunner(rt, e2e.RunnerConfig{}, discardLogger())
+
+	result := runner.Run(context.Background(), scenario("ok", record("a", 1), record("b", 2, 3)))
+
+	assert.True(t, result.Success)
+	assert.Equal(t, []string{"a", "b"}, rt.calls)
+	require.Len(t, result.Steps, 2)
+	assert.True(t, result.Steps[1].Success)
+	assert.Equal(t, 3, runner.MessageCount())
+	assert.Same(t, rt, runner.Runtime())
+}
+
+func TestRunner_StepFailureStopsScenario(t *testing.T) {
+	rt := &env{}
+	fail := e2e.NewStep("boom", func(context.Context, *env) (*e2e.StepResult, error) {
+		return nil, errors.New("boom")
+	})
+	runner := e2e.NewRunner(rt, e2e.RunnerConfig{}, nil)
+
+	result := runner.Run(context.Background(), scenario("fail", fail, record("never")))
+
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, `step "boom" failed`)
+	assert.Empty(t, rt.calls)
+	require.Len(t, result.Steps, 1)
+	assert.Equal(t, "boom", result.Steps[0].StepName)
+}
+
+func TestRunner_SkipMarksScenarioSkipped(t *testing.T) {
+	skip := e2e.NewStep("needs-admin", func(context.Context, *env) (*e2e.StepResult, error) {
+		return nil, e2e.Skip("bot is not admin")
+	})
+	runner := e2e.NewRunner(&env{}, e2e.RunnerConfig{}, discardLogger())
+
+	result := runner.Run(context.Background(), scenario("skip", skip))
+
+	assert.True(t, result.Success)
+	assert.True(t, result.Skipped)
+	assert.Contains(t, result.SkipReason, "bot is not admin")
+}
+
+func TestRunner_MessageBudget(t *testing.T) {
+	runner := e2e.NewRunner(&env{}, e2e.RunnerConfig{MaxMessages: 1}, discardLogger())
+
+	result := runner.Run(context.Background(), scenario("budget", record("a", 1), record("b", 2)))
+
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, "message budget exceeded")
+}
+
+func TestRunner_RetriesOn429(t *testing.T) {
+	attempts := 0
+	flaky := e2e.NewStep("flaky", func(context.Context, *env) (*e2e.StepResult, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, &tg.APIError{Code: 429, RetryAfter: time.Millisecond}
+		}
+		return nil, nil
+	})
+	runner := e2e.NewRunner(&env{}, e2e.RunnerConfig{RetryOn429: true, Max429Retries: 1}, discardLogger())
+
+	result := runner.Run(context.Background(), scenario("retry", flaky))
+
+	assert.True(t, result.Success)
+	assert.Equal(t, 2, attempts)
+}
+
+func finalizedReport(t *testing.T) *e2e.Report {
+	t.Helper()
+	report := e2e.NewReport()
+	report.AddScenario(&e2e.ScenarioResult{
+		ScenarioName: "pass",
+		Covers:       []string{"sendMessage", "getMe"},
+		Success:      true,
+		Duration:     1500 * time.Millisecond,
+		Steps:        []e2e.StepResult{{StepName: "send", Method: "sendMessage", Success: true, MessageIDs: []int{7}}},
+	})
+	report.AddScenario(&e2e.ScenarioResult{
+		ScenarioName: "fail",
+		Error:        `step "x" failed: <bad>`,
+		Steps:        []e2e.StepResult{{StepName: "x", Error: "<bad>"}},
+	})
+	report.AddScenario(&e2e.ScenarioResult{
+		ScenarioName: "skip",
+		Success:      true,
+		Skipped:      true,
+		SkipReason:   "no forum",
+	})
+	report.Finalize()
+	return report
+}
+
+func TestReport_Finalize(t *testing.T) {
+	report := finalizedReport(t)
+
+	assert.False(t, report.Success)
+	assert.Equal(t, 3, report.Summary.TotalScenarios)
+	assert.Equal(t, 2, report.Summary.PassedScenarios)
+	assert.Equal(t, 1, report.Summary.FailedScenarios)
+	assert.Equal(t, 1, report.Summary.SkippedScenarios)
+	assert.Equal(t, []string{"getMe", "sendMessage"}, report.Summary.MethodsCovered)
+	assert.Contains(t, report.FormatSummary(), "FAILED: fail")
+}
+
+func TestReport_SaveJSON(t *testing.T) {
+	report := finalizedReport(t)
+	dir := t.TempDir()
+
+	filename, err := report.Save(dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "reports", "report-"+report.RunID+".json"), filename)
+
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	var decoded e2e.Report
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Len(t, decoded.Scenarios, 3)
+}
+
+func TestReport_WriteJUnit(t *testing.T) {
+	report := finalizedReport(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteJUnit(&buf))
+
+	var doc struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Skipped  int `xml:"skipped,attr"`
+		Suites   []struct {
+			Cases []struct {
+				Name    string `xml:"name,attr"`
+				Time    string `xml:"time,attr"`
+				Failure *struct {
+					Message string `xml:"message,attr"`
+				} `xml:"failure"`
+				Skipped *struct{} `xml:"skipped"`
+				Out     string    `xml:"system-out"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc), buf.String())
+
+	assert.Equal(t, 3, doc.Tests)
+	assert.Equal(t, 1, doc.Failures)
+	assert.Equal(t, 1, doc.Skipped)
+	require.Len(t, doc.Suites, 1)
+	cases := doc.Suites[0].Cases
+	require.Len(t, cases, 3)
+	assert.Equal(t, "1.500", cases[0].Time)
+	assert.Contains(t, cases[0].Out, "message_ids=[7]")
+	require.NotNil(t, cases[1].Failure)
+	assert.Equal(t, `step "x" failed: <bad>`, cases[1].Failure.Message)
+	assert.NotNil(t, cases[2].Skipped)
+}
+
+func TestCheckCoverage(t *testing.T) {
+	scenarios := []e2e.Coverer{
+		scenario("a"),
+		&e2e.BaseScenario[*env]{CoveredMethods: []string{"getMe", "madeUp"}},
+	}
+
+	report := e2e.CheckCoverage([]string{"getMe", "sendMessage", "sendPhoto"}, scenarios)
+
+	assert.Equal(t, []string{"getMe", "sendMessage"}, report.Covered)
+	assert.Equal(t, []string{"sendPhoto"}, report.Missing)
+	assert.Equal(t, []string{"madeUp"}, report.Unknown)
+}
+
+func TestRunParallel_OrderAndExclusive(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	inFlight, maxInFlight := 0, 0
+
+	step := func(name string, exclusive bool) e2e.Step[*env] {
+		return e2e.NewStep(name, func(context.Context, *env) (*e2e.StepResult, error) {
+			mu.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			if exclusive && inFlight > 1 {
+				t.Errorf("exclusive scenario %s ran concurrently", name)
+			}
+			mu.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			order = append(order, name)
+			mu.Unlock()
+			return nil, nil
+		})
+	}
+
+	var scenarios []e2e.Scenario[*env]
+	for _, name := range []string{"a", "b", "x", "c", "d"} {
+		s := scenario(name, step(name, name == "x"))
+		s.ScenarioExclusive = name == "x"
+		scenarios = append(scenarios, s)
+	}
+
+	runners := []*e2e.Runner[*env]{
+		e2e.NewRunner(&env{}, e2e.RunnerConfig{}, discardLogger()),
+		e2e.NewRunner(&env{}, e2e.RunnerConfig{}, discardLogger()),
+	}
+	results := e2e.RunParallel(context.Background(), scenarios, runners)
+
+	require.Len(t, results, 5)
+	for i, name := range []string{"a", "b", "x", "c", "d"} {
+		assert.Equal(t, name, results[i].ScenarioName)
+		assert.True(t, results[i].Success)
+	}
+	assert.Equal(t, 2, maxInFlight)
+	assert.Equal(t, "x", order[len(order)-1], "exclusive scenarios run after the parallel batch")
+}
+
+func TestCooldown_SharedAcrossRunners(t *testing.T) {
+	cooldown := &e2e.Cooldown{}
+	cooldown.Extend(30 * time.Millisecond)
+
+	start := time.Now()
+	require.NoError(t, cooldown.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+
+	var nilCooldown *e2e.Cooldown
+	assert.NoError(t, nilCooldown.Wait(context.Background()))
+
+	cooldown.Extend(time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, cooldown.Wait(ctx), context.Canceled)
+}
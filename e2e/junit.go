@@ -0,0 +1,121 @@
+package e2e
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// JUnit XML schema subset understood by common CI systems
+// (GitHub Actions, GitLab, Jenkins).
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as JUnit XML. Each scenario becomes a test
+// case; its steps are listed in <system-out>.
+func (r *Report) WriteJUnit(w io.Writer) error {
+	suite := junitTestSuite{
+		Name:      "galigo-e2e-" + r.RunID,
+		Time:      junitSeconds(r.Duration),
+		Timestamp: r.StartTime.UTC().Format(time.RFC3339),
+	}
+
+	for _, s := range r.Scenarios {
+		tc := junitTestCase{
+			Name:      s.ScenarioName,
+			Classname: "e2e",
+			Time:      junitSeconds(s.Duration),
+			SystemOut: junitSteps(s.Steps),
+		}
+		switch {
+		case s.Skipped:
+			tc.Skipped = &junitMessage{Message: s.SkipReason}
+			suite.Skipped++
+		case !s.Success:
+			tc.Failure = &junitMessage{Message: s.Error, Body: s.Error}
+			suite.Failures++
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	suite.Tests = len(suite.Cases)
+
+	doc := junitTestSuites{
+		Name:     "galigo-e2e",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode junit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+func junitSteps(steps []StepResult) string {
+	var sb strings.Builder
+	for _, st := range steps {
+		status := "ok"
+		if !st.Success {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&sb, "%s %s (%s)", status, st.StepName, st.Duration.Round(time.Millisecond))
+		if st.Method != "" {
+			fmt.Fprintf(&sb, " method=%s", st.Method)
+		}
+		if len(st.MessageIDs) > 0 {
+			fmt.Fprintf(&sb, " message_ids=%v", st.MessageIDs)
+		}
+		if st.Error != "" {
+			fmt.Fprintf(&sb, " error=%q", st.Error)
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
@@ -0,0 +1,89 @@
+package e2e
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Cooldown is a shared back-off window for runners using the same bot
+// token. A nil *Cooldown is valid and never waits.
+type Cooldown struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// Extend makes the cooldown last at least d from now.
+func (c *Cooldown) Extend(d time.Duration) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if until := time.Now().Add(d); until.After(c.until) {
+		c.until = until
+	}
+}
+
+// Wait blocks until the cooldown has passed or ctx is done.
+func (c *Cooldown) Wait(ctx context.Context) error {
+	if c == nil {
+		return nil
+	}
+	for {
+		c.mu.Lock()
+		remaining := time.Until(c.until)
+		c.mu.Unlock()
+		if remaining <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(remaining)
+		select {
+		case <-timer.C:
+			// Re-check: another runner may have extended the window.
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// RunParallel runs scenarios concurrently, one worker per runner, and
+// returns their results in the order of scenarios.
+//
+// Each runner should own its runtime (and ideally its own test chat) so
+// step state does not leak between scenarios. Scenarios implementing
+// Exclusive with true run afterwards, one at a time, on runners[0].
+// Give all runners the same RunnerConfig.Cooldown so a 429 pauses every
+// worker instead of each one hammering the API.
+func RunParallel[R any](ctx context.Context, scenarios []Scenario[R], runners []*Runner[R]) []*ScenarioResult {
+	results := make([]*ScenarioResult, len(scenarios))
+	if len(runners) == 0 {
+		return results
+	}
+
+	var exclusive []int
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for _, runner := range runners {
+		wg.Go(func() {
+			for i := range jobs {
+				results[i] = runner.Run(ctx, scenarios[i])
+			}
+		})
+	}
+	for i, s := range scenarios {
+		if ex, ok := s.(Exclusive); ok && ex.Exclusive() {
+			exclusive = append(exclusive, i)
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, i := range exclusive {
+		results[i] = runners[0].Run(ctx, scenarios[i])
+	}
+	return results
+}
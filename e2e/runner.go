@@ -0,0 +1,254 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// DefaultScenarioTimeout applies to scenarios whose Timeout is zero.
+const DefaultScenarioTimeout = 5 * time.Minute
+
+// Runner executes scenarios with safety limits.
+type Runner[R any] struct {
+	runtime       R
+	baseDelay     time.Duration
+	jitter        time.Duration
+	maxMessages   int
+	messageCount  int
+	retryOn429    bool
+	max429Retries int
+	cooldown      *Cooldown
+	logger        *slog.Logger
+}
+
+// RunnerConfig holds runner configuration.
+type RunnerConfig struct {
+	BaseDelay     time.Duration // Pause between steps
+	Jitter        time.Duration // Random extra pause in [0, Jitter)
+	MaxMessages   int           // Message budget per run; 0 means unlimited
+	RetryOn429    bool
+	Max429Retries int
+
+	// Cooldown, when shared by several runners, makes a 429 seen by one
+	// runner pause all of them (see RunParallel).
+	Cooldown *Cooldown
+}
+
+// NewRunner creates a new scenario runner.
+// A nil logger uses slog.Default().
+func NewRunner[R any](rt R, cfg RunnerConfig, logger *slog.Logger) *Runner[R] {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Runner[R]{
+		runtime:       rt,
+		baseDelay:     cfg.BaseDelay,
+		jitter:        cfg.Jitter,
+		maxMessages:   cfg.MaxMessages,
+		retryOn429:    cfg.RetryOn429,
+		max429Retries: cfg.Max429Retries,
+		cooldown:      cfg.Cooldown,
+		logger:        logger,
+	}
+}
+
+// Run executes a scenario and returns the result.
+func (r *Runner[R]) Run(ctx context.Context, scenario Scenario[R]) *ScenarioResult {
+	result := &ScenarioResult{
+		ScenarioName: scenario.Name(),
+		Covers:       scenario.Covers(),
+		StartTime:    time.Now(),
+		Steps:        make([]StepResult, 0),
+	}
+
+	// Apply timeout
+	timeout := scenario.Timeout()
+	if timeout == 0 {
+		timeout = DefaultScenarioTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r.logger.Info("starting scenario",
+		"name", scenario.Name(),
+		"description", scenario.Description(),
+		"covers", scenario.Covers())
+
+	for _, step := range scenario.Steps() {
+		// Check context cancellation
+		if ctx.Err() != nil {
+			result.Error = ctx.Err().Error()
+			break
+		}
+
+		// Check message budget
+		if r.maxMessages > 0 && r.messageCount >= r.maxMessages {
+			result.Error = fmt.Sprintf("message budget exceeded (%d)", r.maxMessages)
+			break
+		}
+
+		// Execute step with 429 retry
+		stepResult, err := r.runStepWithRetry(ctx, step)
+		result.Steps = append(result.Steps, *stepResult)
+
+		if err != nil {
+			if IsSkip(err) {
+				result.Success = true
+				result.Skipped = true
+				result.SkipReason = err.Error()
+				r.logger.Info("scenario skipped", "step", step.Name(), "reason", err.Error())
+				break
+			}
+			result.Success = false
+			result.Error = fmt.Sprintf("step %q failed: %v", step.Name(), err)
+			break
+		}
+
+		// Pace between steps: base delay + random jitter
+		if err := r.pace(ctx); err != nil {
+			result.Error = err.Error()
+			break
+		}
+	}
+
+	if result.Error == "" {
+		result.Success = true
+	}
+
+	result.EndTime = time.Now()
+	result.Duration = result.EndTime.Sub(result.StartTime)
+
+	r.logger.Info("scenario completed",
+		"name", scenario.Name(),
+		"success", result.Success,
+		"duration", result.Duration,
+		"messages", r.messageCount)
+
+	return result
+}
+
+// runStepWithRetry executes a step, retrying on 429 errors.
+func (r *Runner[R]) runStepWithRetry(ctx context.Context, step Step[R]) (*StepResult, error) {
+	maxAttempts := 1
+	if r.retryOn429 {
+		maxAttempts = 1 + r.max429Retries
+	}
+
+	var stepResult *StepResult
+	var err error
+
+	for attempt := range maxAttempts {
+		if err := r.cooldown.Wait(ctx); err != nil {
+			return &StepResult{StepName: step.Name(), Error: err.Error()}, err
+		}
+
+		stepResult, err = r.runStep(ctx, step)
+		if err == nil {
+			return stepResult, nil
+		}
+
+		// Check for 429 rate limit
+		var apiErr *tg.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != 429 {
+			return stepResult, err // Not a 429, fail immediately
+		}
+
+		// 429 — wait and retry
+		retryAfter := apiErr.RetryAfter
+		if retryAfter == 0 {
+			retryAfter = 5 * time.Second
+		}
+		// Add 500ms safety margin
+		waitTime := retryAfter + 500*time.Millisecond
+		r.cooldown.Extend(waitTime)
+		if attempt == maxAttempts-1 {
+			break
+		}
+
+		r.logger.Warn("rate limited, retrying step",
+			"step", step.Name(),
+			"attempt", attempt+1,
+			"max_attempts", maxAttempts,
+			"retry_after", retryAfter,
+			"wait", waitTime)
+
+		select {
+		case <-time.After(waitTime):
+			continue
+		case <-ctx.Done():
+			return stepResult, ctx.Err()
+		}
+	}
+
+	return stepResult, err
+}
+
+func (r *Runner[R]) runStep(ctx context.Context, step Step[R]) (*StepResult, error) {
+	start := time.Now()
+
+	r.logger.Debug("executing step", "step", step.Name())
+
+	stepResult, err := step.Execute(ctx, r.runtime)
+	if stepResult == nil {
+		stepResult = &StepResult{StepName: step.Name()}
+	}
+
+	stepResult.Duration = time.Since(start)
+
+	if err != nil {
+		stepResult.Success = false
+		stepResult.Error = err.Error()
+		r.logger.Error("step failed", "step", step.Name(), "error", err, "duration", stepResult.Duration)
+		return stepResult, err
+	}
+
+	stepResult.Success = true
+	r.messageCount += len(stepResult.MessageIDs)
+
+	r.logger.Info("step completed",
+		"step", step.Name(),
+		"duration", stepResult.Duration,
+		"messages", len(stepResult.MessageIDs))
+
+	return stepResult, nil
+}
+
+// pace waits for base delay + random jitter between steps.
+func (r *Runner[R]) pace(ctx context.Context) error {
+	if r.baseDelay == 0 && r.jitter == 0 {
+		return nil
+	}
+
+	delay := r.baseDelay
+	if r.jitter > 0 {
+		delay += time.Duration(rand.Int64N(int64(r.jitter)))
+	}
+
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Runtime returns the runner's runtime for access to state.
+func (r *Runner[R]) Runtime() R {
+	return r.runtime
+}
+
+// MessageCount returns the number of messages sent.
+func (r *Runner[R]) MessageCount() int {
+	return r.messageCount
+}
+
+// ResetMessageCount resets the message counter (for new runs).
+func (r *Runner[R]) ResetMessageCount() {
+	r.messageCount = 0
+}
@@ -0,0 +1,95 @@
+package e2e
+
+import (
+	"context"
+	"time"
+)
+
+// Scenario is a named sequence of steps that declares method coverage.
+// R is the runtime passed to every step.
+type Scenario[R any] interface {
+	Name() string
+	Description() string
+	Covers() []string // Methods this scenario exercises
+	Steps() []Step[R]
+	Timeout() time.Duration // 0 means DefaultScenarioTimeout
+}
+
+// Exclusive is implemented by scenarios that touch chat-wide or bot-wide
+// state (chat title, pins, bot commands) and therefore must not run
+// concurrently with other scenarios. See RunParallel.
+type Exclusive interface {
+	Exclusive() bool
+}
+
+// BaseScenario provides common implementation.
+type BaseScenario[R any] struct {
+	ScenarioName        string
+	ScenarioDescription string
+	CoveredMethods      []string
+	ScenarioSteps       []Step[R]
+	ScenarioTimeout     time.Duration
+	ScenarioExclusive   bool
+}
+
+func (s *BaseScenario[R]) Name() string           { return s.ScenarioName }
+func (s *BaseScenario[R]) Description() string    { return s.ScenarioDescription }
+func (s *BaseScenario[R]) Covers() []string       { return s.CoveredMethods }
+func (s *BaseScenario[R]) Steps() []Step[R]       { return s.ScenarioSteps }
+func (s *BaseScenario[R]) Timeout() time.Duration { return s.ScenarioTimeout }
+func (s *BaseScenario[R]) Exclusive() bool        { return s.ScenarioExclusive }
+
+// Step represents a single test step.
+//
+// Execute may return a nil StepResult; the runner then records one with
+// only the step name. Returning an error fails the scenario, unless the
+// error is a SkipError.
+type Step[R any] interface {
+	Name() string
+	Execute(ctx context.Context, rt R) (*StepResult, error)
+}
+
+// StepFunc is the signature of a step implemented as a function.
+type StepFunc[R any] func(ctx context.Context, rt R) (*StepResult, error)
+
+type funcStep[R any] struct {
+	name string
+	fn   StepFunc[R]
+}
+
+// NewStep creates a Step from a function.
+func NewStep[R any](name string, fn StepFunc[R]) Step[R] {
+	return &funcStep[R]{name: name, fn: fn}
+}
+
+func (s *funcStep[R]) Name() string { return s.name }
+
+func (s *funcStep[R]) Execute(ctx context.Context, rt R) (*StepResult, error) {
+	return s.fn(ctx, rt)
+}
+
+// StepResult captures evidence from step execution.
+type StepResult struct {
+	StepName   string        `json:"step_name"`
+	Method     string        `json:"method,omitempty"`
+	Duration   time.Duration `json:"duration"`
+	Success    bool          `json:"success"`
+	Error      string        `json:"error,omitempty"`
+	MessageIDs []int         `json:"message_ids,omitempty"`
+	FileIDs    []string      `json:"file_ids,omitempty"`
+	Evidence   any           `json:"evidence,omitempty"`
+}
+
+// ScenarioResult captures the result of running a scenario.
+type ScenarioResult struct {
+	ScenarioName string        `json:"scenario_name"`
+	Covers       []string      `json:"covers"`
+	StartTime    time.Time     `json:"start_time"`
+	EndTime      time.Time     `json:"end_time"`
+	Duration     time.Duration `json:"duration"`
+	Success      bool          `json:"success"`
+	Skipped      bool          `json:"skipped,omitempty"`
+	SkipReason   string        `json:"skip_reason,omitempty"`
+	Error        string        `json:"error,omitempty"`
+	Steps        []StepResult  `json:"steps"`
+}
@@ -0,0 +1,14 @@
+package galigo
+
+import "github.com/prilive-com/galigo/tg"
+
+// RequestError is the error returned by Bot methods once a request has
+// finally failed. It carries the method, chat, attempts, total backoff and
+// circuit breaker state, and unwraps to the underlying *tg.APIError or
+// sentinel, so errors.Is checks keep working:
+//
+//	var reqErr *galigo.RequestError
+//	if errors.As(err, &reqErr) {
+//		logger.Error("send failed", "request", reqErr)
+//	}
+type RequestError = tg.RequestError
@@ -0,0 +1,54 @@
+package galigotest
+
+import (
+	"testing"
+
+	"github.com/prilive-com/galigo"
+)
+
+// NewBot creates a polling galigo.Bot wired to the mock server and starts it.
+// The bot is stopped and closed when the test completes.
+//
+// Example:
+//
+//	server := galigotest.NewMockServer(t)
+//	bot := galigotest.NewBot(t, server)
+//	server.PushUpdate(galigotest.TestUpdate(0, "/start"))
+//	update := <-bot.Updates()
+func NewBot(t testing.TB, server *MockTelegramServer, opts ...galigo.Option) *galigo.Bot {
+	t.Helper()
+
+	defaults := []galigo.Option{
+		galigo.WithBaseURL(server.BaseURL()),
+		galigo.WithPolling(1, 100),
+		galigo.WithRetries(0),
+	}
+	bot, err := galigo.New(TestToken, append(defaults, opts...)...)
+	if err != nil {
+		t.Fatalf("galigotest: create bot: %v", err)
+	}
+	t.Cleanup(func() { _ = bot.Close() })
+
+	if err := bot.Start(t.Context()); err != nil {
+		t.Fatalf("galigotest: start bot: %v", err)
+	}
+	return bot
+}
+
+// NewWebhookBot creates a webhook-mode galigo.Bot whose sender talks to the
+// mock server. Deliver updates with ServeWebhook(t, bot.WebhookHandler(), ...).
+func NewWebhookBot(t testing.TB, server *MockTelegramServer, secret string, opts ...galigo.Option) *galigo.Bot {
+	t.Helper()
+
+	defaults := []galigo.Option{
+		galigo.WithBaseURL(server.BaseURL()),
+		galigo.WithWebhook(0, secret),
+		galigo.WithRetries(0),
+	}
+	bot, err := galigo.New(TestToken, append(defaults, opts...)...)
+	if err != nil {
+		t.Fatalf("galigotest: create bot: %v", err)
+	}
+	t.Cleanup(func() { _ = bot.Close() })
+	return bot
+}
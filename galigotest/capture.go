@@ -1,4 +1,4 @@
-package testutil
+package galigotest
 
 import (
 	"encoding/json"
@@ -22,37 +22,37 @@ type Capture struct {
 }
 
 // AssertPath verifies the request path.
-func (c *Capture) AssertPath(t *testing.T, expected string) {
+func (c *Capture) AssertPath(t testing.TB, expected string) {
 	t.Helper()
 	assert.Equal(t, expected, c.Path, "unexpected path")
 }
 
 // AssertMethod verifies the HTTP method.
-func (c *Capture) AssertMethod(t *testing.T, expected string) {
+func (c *Capture) AssertMethod(t testing.TB, expected string) {
 	t.Helper()
 	assert.Equal(t, expected, c.Method, "unexpected method")
 }
 
 // AssertContentType verifies the Content-Type header contains expected value.
-func (c *Capture) AssertContentType(t *testing.T, expected string) {
+func (c *Capture) AssertContentType(t testing.TB, expected string) {
 	t.Helper()
 	assert.Contains(t, c.ContentType, expected, "unexpected content-type")
 }
 
 // AssertHeader verifies a specific header value.
-func (c *Capture) AssertHeader(t *testing.T, key, expected string) {
+func (c *Capture) AssertHeader(t testing.TB, key, expected string) {
 	t.Helper()
 	assert.Equal(t, expected, c.Headers.Get(key), "unexpected header: "+key)
 }
 
 // AssertHeaderExists verifies a header exists (with any value).
-func (c *Capture) AssertHeaderExists(t *testing.T, key string) {
+func (c *Capture) AssertHeaderExists(t testing.TB, key string) {
 	t.Helper()
 	assert.NotEmpty(t, c.Headers.Get(key), "header should exist: "+key)
 }
 
 // AssertQuery verifies a query parameter value.
-func (c *Capture) AssertQuery(t *testing.T, key, expected string) {
+func (c *Capture) AssertQuery(t testing.TB, key, expected string) {
 	t.Helper()
 	values := c.Query[key]
 	if len(values) == 0 {
@@ -63,7 +63,7 @@ func (c *Capture) AssertQuery(t *testing.T, key, expected string) {
 }
 
 // AssertJSONField verifies a field in the JSON body.
-func (c *Capture) AssertJSONField(t *testing.T, field string, expected any) {
+func (c *Capture) AssertJSONField(t testing.TB, field string, expected any) {
 	t.Helper()
 	var body map[string]any
 	require.NoError(t, json.Unmarshal(c.Body, &body), "failed to parse JSON body")
@@ -71,7 +71,7 @@ func (c *Capture) AssertJSONField(t *testing.T, field string, expected any) {
 }
 
 // AssertJSONFieldExists verifies a field exists in the JSON body.
-func (c *Capture) AssertJSONFieldExists(t *testing.T, field string) {
+func (c *Capture) AssertJSONFieldExists(t testing.TB, field string) {
 	t.Helper()
 	var body map[string]any
 	require.NoError(t, json.Unmarshal(c.Body, &body), "failed to parse JSON body")
@@ -79,7 +79,7 @@ func (c *Capture) AssertJSONFieldExists(t *testing.T, field string) {
 }
 
 // AssertJSONFieldAbsent verifies a field does NOT exist in the JSON body.
-func (c *Capture) AssertJSONFieldAbsent(t *testing.T, field string) {
+func (c *Capture) AssertJSONFieldAbsent(t testing.TB, field string) {
 	t.Helper()
 	var body map[string]any
 	require.NoError(t, json.Unmarshal(c.Body, &body), "failed to parse JSON body")
@@ -88,7 +88,7 @@ func (c *Capture) AssertJSONFieldAbsent(t *testing.T, field string) {
 
 // AssertJSONFieldNested verifies a nested field in the JSON body.
 // Use dot notation: "chat.id", "reply_markup.inline_keyboard"
-func (c *Capture) AssertJSONFieldNested(t *testing.T, path string, expected any) {
+func (c *Capture) AssertJSONFieldNested(t testing.TB, path string, expected any) {
 	t.Helper()
 	var body map[string]any
 	require.NoError(t, json.Unmarshal(c.Body, &body), "failed to parse JSON body")
@@ -99,13 +99,13 @@ func (c *Capture) AssertJSONFieldNested(t *testing.T, path string, expected any)
 }
 
 // BodyJSON decodes the body as JSON into target.
-func (c *Capture) BodyJSON(t *testing.T, target any) {
+func (c *Capture) BodyJSON(t testing.TB, target any) {
 	t.Helper()
 	require.NoError(t, json.Unmarshal(c.Body, target), "failed to decode JSON body")
 }
 
 // BodyMap returns the body as a map.
-func (c *Capture) BodyMap(t *testing.T) map[string]any {
+func (c *Capture) BodyMap(t testing.TB) map[string]any {
 	t.Helper()
 	var m map[string]any
 	require.NoError(t, json.Unmarshal(c.Body, &m), "failed to decode JSON body")
@@ -0,0 +1,198 @@
+package galigotest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prilive-com/galigo/clock"
+)
+
+// FakeClock is a clock.Clock that only moves when told to. Sleeps, timers
+// and tickers fire as Advance passes their deadlines, so backoff, cleanup
+// and scheduler tests run without real waiting:
+//
+//	clk := galigotest.NewFakeClock(time.Now())
+//	bot := galigotest.NewBot(t, server, galigo.WithClock(clk))
+//	clk.BlockUntil(1)          // the poller is backing off
+//	clk.Advance(2 * time.Second)
+//
+// It is safe for concurrent use.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+	changed chan struct{} // closed and replaced when waiters change
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	period   time.Duration // > 0 for tickers
+	ch       chan time.Time
+}
+
+var _ clock.Clock = (*FakeClock)(nil)
+
+// NewFakeClock returns a FakeClock set to start.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start, changed: make(chan struct{})}
+}
+
+// Now returns the fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the fake time once Advance has
+// moved it d forward. The wait stays pending until then; use NewTimer for
+// waits that may be abandoned.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.add(d, 0).ch
+}
+
+// NewTimer returns a timer that fires once Advance has moved the time d
+// forward. Stop removes it from the pending waits.
+func (c *FakeClock) NewTimer(d time.Duration) clock.Timer {
+	return &fakeTimer{clock: c, w: c.add(d, 0)}
+}
+
+// Sleep blocks until Advance has moved the time d forward or ctx is done.
+func (c *FakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	w := c.add(d, 0)
+	select {
+	case <-ctx.Done():
+		c.remove(w)
+		return ctx.Err()
+	case <-w.ch:
+		return nil
+	}
+}
+
+// NewTicker returns a ticker that ticks every d of fake time. Like
+// time.Ticker, it drops ticks the receiver is not ready for.
+func (c *FakeClock) NewTicker(d time.Duration) clock.Ticker {
+	if d <= 0 {
+		panic("galigotest: non-positive interval for FakeClock.NewTicker")
+	}
+	return &fakeTicker{clock: c, w: c.add(d, d)}
+}
+
+// Advance moves the time forward by d, firing everything due on the way
+// in deadline order.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	for {
+		next := c.nextDue(end)
+		if next == nil {
+			break
+		}
+		c.now = next.deadline
+		c.fire(next)
+	}
+	c.now = end
+	c.mu.Unlock()
+}
+
+// Waiters returns the number of pending sleeps, timers and tickers.
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// BlockUntil waits until at least n sleeps, timers or tickers are
+// pending, so a test can Advance only once the code under test waits.
+func (c *FakeClock) BlockUntil(n int) {
+	for {
+		c.mu.Lock()
+		if len(c.waiters) >= n {
+			c.mu.Unlock()
+			return
+		}
+		changed := c.changed
+		c.mu.Unlock()
+		<-changed
+	}
+}
+
+func (c *FakeClock) add(d, period time.Duration) *fakeWaiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &fakeWaiter{deadline: c.now.Add(d), period: period, ch: make(chan time.Time, 1)}
+	if d <= 0 && period == 0 {
+		w.ch <- c.now
+		return w
+	}
+	c.waiters = append(c.waiters, w)
+	c.notify()
+	return w
+}
+
+// remove drops w from the pending waits, reporting whether it was there.
+func (c *FakeClock) remove(w *fakeWaiter) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, other := range c.waiters {
+		if other == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			c.notify()
+			return true
+		}
+	}
+	return false
+}
+
+// nextDue returns the waiter with the earliest deadline not after end.
+func (c *FakeClock) nextDue(end time.Time) *fakeWaiter {
+	var next *fakeWaiter
+	for _, w := range c.waiters {
+		if !w.deadline.After(end) && (next == nil || w.deadline.Before(next.deadline)) {
+			next = w
+		}
+	}
+	return next
+}
+
+// fire delivers the time to w and re-arms or removes it. c.mu is held.
+func (c *FakeClock) fire(w *fakeWaiter) {
+	select {
+	case w.ch <- c.now:
+	default:
+	}
+	if w.period > 0 {
+		w.deadline = w.deadline.Add(w.period)
+		return
+	}
+	for i, other := range c.waiters {
+		if other == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			break
+		}
+	}
+	c.notify()
+}
+
+// notify wakes BlockUntil callers. c.mu is held.
+func (c *FakeClock) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+type fakeTicker struct {
+	clock *FakeClock
+	w     *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.w.ch }
+func (t *fakeTicker) Stop()               { t.clock.remove(t.w) }
+
+type fakeTimer struct {
+	clock *FakeClock
+	w     *fakeWaiter
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.w.ch }
+func (t *fakeTimer) Stop() bool          { return t.clock.remove(t.w) }
@@ -0,0 +1,64 @@
+// Package galigotest provides a mock Telegram Bot API server and helpers for
+// testing bots built with galigo, without network access or a real token.
+//
+// # Mock Telegram Server
+//
+// MockTelegramServer records every request and replies with canned responses:
+//
+//	server := galigotest.NewMockServer(t)
+//	server.On("/bot"+galigotest.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+//	    galigotest.ReplyMessage(w, 123)
+//	})
+//	client, _ := sender.New(galigotest.TestToken, sender.WithBaseURL(server.BaseURL()))
+//
+// Methods without a registered handler reply {"ok":true,"result":{}}.
+//
+// # Request Capture
+//
+//	cap := server.LastCapture()
+//	cap.AssertJSONField(t, "chat_id", float64(galigotest.TestChatID))
+//
+// # Update Injection
+//
+// Updates pushed onto the server are served to any client polling getUpdates,
+// so a Bot under test receives them exactly as it would from Telegram:
+//
+//	bot := galigotest.NewBot(t, server)
+//	server.PushUpdate(galigotest.TestUpdate(0, "/start"))
+//	update := <-bot.Updates()
+//
+// For webhook bots, ServeWebhook posts an update straight to the handler:
+//
+//	bot := galigotest.NewWebhookBot(t, server, "secret")
+//	rec := galigotest.ServeWebhook(t, bot.WebhookHandler(), galigotest.TestUpdate(1, "hi"), "secret")
+//
+// # Webhook Delivery
+//
+// WebhookTester posts updates over real HTTP the way Telegram does, to a
+// handler or to a running deployment, and covers what Telegram may do:
+// redeliver an update, deliver out of order or concurrently, or send
+// slowly:
+//
+//	wt := galigotest.NewWebhookTester(t, "http://localhost:8443/webhook", "secret")
+//	wt.Deliver(galigotest.TestUpdate(0, "/start"))
+//	wt.DeliverDuplicate(galigotest.TestUpdate(0, "/pay"), 2)
+//	wt.DeliverWithSecret(galigotest.TestUpdate(0, "hi"), "wrong") // expect 401
+//
+// # Fake Clock
+//
+// FakeClock stands in for real time in retries, polling backoff, limiter
+// cleanup and the scheduler; pass it with galigo.WithClock (or
+// sender.WithClock, receiver.WithPollingClock) and move it with Advance:
+//
+//	clk := galigotest.NewFakeClock(time.Now())
+//	bot := galigotest.NewBot(t, server, galigo.WithClock(clk))
+//	clk.BlockUntil(2)         // wait until the bot is waiting
+//	clk.Advance(time.Minute)  // fire everything due
+//
+// # Fixtures
+//
+//	galigotest.TestToken    // Valid bot token format
+//	galigotest.TestChatID   // Test chat ID
+//	galigotest.TestUser()   // Test user fixture
+//	galigotest.TestMessage(1, "Hello") // Test message fixture
+package galigotest
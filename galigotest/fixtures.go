@@ -1,58 +1,59 @@
-package testutil
+package galigotest
 
-import "github.com/prilive-com/galigo/tg"
+import (
+	"github.com/prilive-com/galigo/internal/fixture"
+	"github.com/prilive-com/galigo/tg"
+)
 
 // Test constants for consistent test data.
 const (
 	// TestToken is a valid-format bot token for testing.
-	TestToken = "123456789:ABCdefGHIjklMNOpqrsTUVwxyz"
+	TestToken = fixture.Token
 
 	// TestChatID is a test chat ID.
-	TestChatID = int64(123456789)
+	TestChatID = fixture.ChatID
 
 	// TestUserID is a test user ID.
-	TestUserID = int64(987654321)
+	TestUserID = fixture.UserID
 
 	// TestBotID is a test bot ID.
-	TestBotID = int64(123456789)
+	TestBotID = fixture.BotID
 
 	// TestUsername is a test username.
-	TestUsername = "testuser"
+	TestUsername = fixture.Username
 
 	// TestBotUsername is a test bot username.
-	TestBotUsername = "testbot"
+	TestBotUsername = fixture.BotUsername
 )
 
 // TestUser returns a test user fixture.
 func TestUser() *tg.User {
-	return &tg.User{
-		ID:        TestUserID,
-		IsBot:     false,
-		FirstName: "Test",
-		LastName:  "User",
-		Username:  TestUsername,
-	}
+	return fixture.User()
 }
 
 // TestBot returns a test bot user fixture.
 func TestBot() *tg.User {
-	return &tg.User{
-		ID:        TestBotID,
-		IsBot:     true,
-		FirstName: "Test Bot",
-		Username:  TestBotUsername,
-	}
+	return fixture.Bot()
 }
 
 // TestChat returns a test private chat fixture.
 func TestChat() *tg.Chat {
-	return &tg.Chat{
-		ID:        TestChatID,
-		Type:      "private",
-		FirstName: "Test",
-		LastName:  "User",
-		Username:  TestUsername,
-	}
+	return fixture.Chat()
+}
+
+// TestChatFullInfo returns the getChat result for TestChat.
+func TestChatFullInfo() *tg.ChatFullInfo {
+	return fixture.ChatFullInfo(fixture.Chat())
+}
+
+// TestChatMember returns TestUser as a regular chat member.
+func TestChatMember() *tg.ChatMemberMember {
+	return fixture.ChatMember(fixture.User())
+}
+
+// TestFile returns a test file fixture with the given file ID.
+func TestFile(fileID string) *tg.File {
+	return fixture.File(fileID)
 }
 
 // TestGroupChat returns a test group chat fixture.
@@ -66,22 +67,12 @@ func TestGroupChat(id int64, title string) *tg.Chat {
 
 // TestSuperGroupChat returns a test supergroup chat fixture.
 func TestSuperGroupChat(id int64, title, username string) *tg.Chat {
-	return &tg.Chat{
-		ID:       id,
-		Type:     "supergroup",
-		Title:    title,
-		Username: username,
-	}
+	return fixture.SuperGroupChat(id, title, username)
 }
 
 // TestChannelChat returns a test channel chat fixture.
 func TestChannelChat(id int64, title, username string) *tg.Chat {
-	return &tg.Chat{
-		ID:       id,
-		Type:     "channel",
-		Title:    title,
-		Username: username,
-	}
+	return fixture.ChannelChat(id, title, username)
 }
 
 // TestMessage returns a test message fixture.
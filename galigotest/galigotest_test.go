@@ -0,0 +1,207 @@
+package galigotest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/galigotest"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestPushUpdate_DeliveredToBot(t *testing.T) {
+	server := galigotest.NewMockServer(t)
+	bot := galigotest.NewBot(t, server)
+
+	server.PushUpdate(galigotest.TestUpdate(0, "/start"))
+
+	select {
+	case u := <-bot.Updates():
+		assert.Equal(t, 1, u.UpdateID)
+		require.NotNil(t, u.Message)
+		assert.Equal(t, "/start", u.Message.Text)
+	case <-time.After(5 * time.Second):
+		t.Fatal("update not delivered")
+	}
+
+	require.Eventually(t, func() bool { return server.PendingUpdates() == 0 },
+		5*time.Second, 10*time.Millisecond, "update should be acknowledged via offset")
+}
+
+func TestPushUpdate_BotCanReply(t *testing.T) {
+	server := galigotest.NewMockServer(t)
+	server.On("/bot"+galigotest.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		galigotest.ReplyMessage(w, 10)
+	})
+	bot := galigotest.NewBot(t, server)
+
+	server.PushUpdate(galigotest.TestUpdate(0, "ping"))
+	u := <-bot.Updates()
+
+	msg, err := bot.SendMessage(context.Background(), u.Message.Chat.ID, "pong")
+	require.NoError(t, err)
+	assert.Equal(t, 10, msg.MessageID)
+
+	var found bool
+	for _, c := range server.Captures() {
+		if c.Path == "/bot"+galigotest.TestToken+"/sendMessage" {
+			c.AssertJSONField(t, "text", "pong")
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestServeWebhook(t *testing.T) {
+	server := galigotest.NewMockServer(t)
+	bot := galigotest.NewWebhookBot(t, server, "s3cret")
+
+	rec := galigotest.ServeWebhook(t, bot.WebhookHandler(), galigotest.TestUpdate(7, "hi"), "s3cret")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	select {
+	case u := <-bot.Updates():
+		assert.Equal(t, 7, u.UpdateID)
+	default:
+		t.Fatal("webhook update not delivered")
+	}
+
+	rec = galigotest.ServeWebhook(t, bot.WebhookHandler(), tg.Update{UpdateID: 8}, "wrong")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func receiveUpdate(t *testing.T, ch <-chan tg.Update) tg.Update {
+	t.Helper()
+	select {
+	case u := <-ch:
+		return u
+	case <-time.After(2 * time.Second):
+		t.Fatal("webhook update not delivered")
+		return tg.Update{}
+	}
+}
+
+func TestWebhookTester(t *testing.T) {
+	server := galigotest.NewMockServer(t)
+	bot := galigotest.NewWebhookBot(t, server, "s3cret")
+	wt := galigotest.NewHandlerWebhookTester(t, bot.WebhookHandler(), "s3cret")
+
+	d := wt.Deliver(galigotest.TestUpdate(0, "hi"))
+	require.True(t, d.OK(), "status %d, err %v", d.StatusCode, d.Err)
+	assert.Equal(t, 1, d.UpdateID)
+	assert.Equal(t, "hi", receiveUpdate(t, bot.Updates()).Message.Text)
+
+	d = wt.DeliverWithSecret(galigotest.TestUpdate(0, "x"), "wrong")
+	assert.Equal(t, http.StatusUnauthorized, d.StatusCode)
+	assert.False(t, d.OK())
+
+	d = wt.DeliverRaw([]byte(`{"update_id":`))
+	assert.Equal(t, http.StatusBadRequest, d.StatusCode)
+}
+
+func TestWebhookTester_DuplicateAndOutOfOrder(t *testing.T) {
+	server := galigotest.NewMockServer(t)
+	bot := galigotest.NewWebhookBot(t, server, "")
+	wt := galigotest.NewHandlerWebhookTester(t, bot.WebhookHandler(), "")
+
+	for _, d := range wt.DeliverDuplicate(galigotest.TestUpdate(5, "dup"), 2) {
+		assert.True(t, d.OK())
+	}
+	assert.Equal(t, 5, receiveUpdate(t, bot.Updates()).UpdateID)
+	assert.Equal(t, 5, receiveUpdate(t, bot.Updates()).UpdateID)
+
+	ds := wt.DeliverOutOfOrder(galigotest.TestUpdate(0, "a"), galigotest.TestUpdate(0, "b"), galigotest.TestUpdate(0, "c"))
+	require.Len(t, ds, 3)
+	for i, want := range []int{8, 7, 6} {
+		assert.Equal(t, want, ds[i].UpdateID)
+		assert.Equal(t, want, receiveUpdate(t, bot.Updates()).UpdateID)
+	}
+}
+
+func TestWebhookTester_ConcurrentAndSlow(t *testing.T) {
+	server := galigotest.NewMockServer(t)
+	bot := galigotest.NewWebhookBot(t, server, "")
+	wt := galigotest.NewHandlerWebhookTester(t, bot.WebhookHandler(), "")
+
+	ds := wt.DeliverConcurrently(galigotest.TestUpdate(0, "a"), galigotest.TestUpdate(0, "b"), galigotest.TestUpdate(0, "c"))
+	seen := map[int]bool{}
+	for i, d := range ds {
+		assert.True(t, d.OK())
+		assert.Equal(t, i+1, d.UpdateID, "numbered in argument order")
+		seen[receiveUpdate(t, bot.Updates()).UpdateID] = true
+	}
+	assert.Len(t, seen, 3)
+
+	d := wt.DeliverSlowly(galigotest.TestUpdate(0, "slow"), 16, 5*time.Millisecond)
+	assert.True(t, d.OK())
+	assert.GreaterOrEqual(t, d.Duration, 5*time.Millisecond)
+	assert.Equal(t, "slow", receiveUpdate(t, bot.Updates()).Message.Text)
+}
+
+func TestWebhookTester_Timeout(t *testing.T) {
+	wt := galigotest.NewHandlerWebhookTester(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		<-r.Context().Done() // cancelled when the tester gives up
+	}), "")
+	wt.Client = &http.Client{Timeout: 20 * time.Millisecond}
+
+	d := wt.Deliver(galigotest.TestUpdate(0, "hi"))
+	assert.Error(t, d.Err)
+	assert.Zero(t, d.StatusCode)
+	assert.False(t, d.OK())
+}
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := galigotest.NewFakeClock(start)
+
+	slept := make(chan error, 1)
+	go func() { slept <- clk.Sleep(context.Background(), time.Minute) }()
+	ticker := clk.NewTicker(20 * time.Second)
+	defer ticker.Stop()
+	after := clk.After(30 * time.Second)
+
+	clk.BlockUntil(3)
+	clk.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(20*time.Second), <-ticker.C(), "ticks at its own deadline")
+	assert.Equal(t, start.Add(30*time.Second), <-after)
+	select {
+	case <-slept:
+		t.Fatal("sleep returned early")
+	default:
+	}
+
+	clk.Advance(30 * time.Second)
+	require.NoError(t, <-slept)
+	assert.Equal(t, start.Add(time.Minute), clk.Now())
+	assert.Equal(t, start.Add(40*time.Second), <-ticker.C(), "like time.Ticker, later ticks are dropped while one is pending")
+	assert.Equal(t, 1, clk.Waiters(), "only the ticker is left")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, clk.Sleep(ctx, time.Hour), context.Canceled)
+	assert.Equal(t, 1, clk.Waiters())
+}
+
+func TestFakeClock_Timer(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := galigotest.NewFakeClock(start)
+
+	fired := clk.NewTimer(time.Second)
+	stopped := clk.NewTimer(time.Second)
+	assert.Equal(t, 2, clk.Waiters())
+
+	assert.True(t, stopped.Stop())
+	assert.False(t, stopped.Stop(), "already stopped")
+	assert.Equal(t, 1, clk.Waiters(), "a stopped timer is no longer pending")
+
+	clk.Advance(time.Second)
+	assert.Equal(t, start.Add(time.Second), <-fired.C())
+	assert.False(t, fired.Stop(), "already fired")
+	assert.Zero(t, clk.Waiters())
+}
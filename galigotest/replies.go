@@ -1,4 +1,4 @@
-package testutil
+package galigotest
 
 import (
 	"encoding/json"
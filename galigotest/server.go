@@ -1,10 +1,11 @@
-package testutil
+package galigotest
 
 import (
 	"bytes"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -13,21 +14,23 @@ import (
 // MockTelegramServer provides a mock Telegram Bot API server for testing.
 type MockTelegramServer struct {
 	*httptest.Server
-	t        *testing.T
+	t        testing.TB
 	mu       sync.Mutex
 	handlers map[string]http.HandlerFunc
 	captures []Capture
+	updates  *updateQueue
 }
 
 // NewMockServer creates a mock Telegram API server.
 // The server is automatically closed when the test completes.
-func NewMockServer(t *testing.T) *MockTelegramServer {
+func NewMockServer(t testing.TB) *MockTelegramServer {
 	t.Helper()
 
 	m := &MockTelegramServer{
 		t:        t,
 		handlers: make(map[string]http.HandlerFunc),
 		captures: make([]Capture, 0),
+		updates:  newUpdateQueue(),
 	}
 
 	m.Server = httptest.NewServer(http.HandlerFunc(m.handle))
@@ -64,6 +67,12 @@ func (m *MockTelegramServer) handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Serve injected updates to pollers unless a custom handler is registered
+	if strings.HasSuffix(r.URL.Path, "/getUpdates") {
+		m.updates.serve(w, r, body)
+		return
+	}
+
 	// Default success response
 	ReplyOK(w, map[string]any{})
 }
@@ -73,7 +82,7 @@ func (m *MockTelegramServer) handle(w http.ResponseWriter, r *http.Request) {
 // Example:
 //
 //	server.OnMethod("POST", "/bot123:ABC/sendMessage", func(w http.ResponseWriter, r *http.Request) {
-//	    testutil.ReplyMessage(w, 123)
+//	    galigotest.ReplyMessage(w, 123)
 //	})
 func (m *MockTelegramServer) OnMethod(method, path string, handler http.HandlerFunc) {
 	m.mu.Lock()
@@ -153,7 +162,7 @@ func (m *MockTelegramServer) BaseURL() string {
 }
 
 // BotURL returns the full bot API URL for a given token.
-// Example: server.BotURL(testutil.TestToken) returns "http://127.0.0.1:port/bot123:ABC"
+// Example: server.BotURL(galigotest.TestToken) returns "http://127.0.0.1:port/bot123:ABC"
 func (m *MockTelegramServer) BotURL(token string) string {
 	return m.Server.URL + "/bot" + token
 }
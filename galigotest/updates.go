@@ -0,0 +1,151 @@
+package galigotest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// longPollWait is how long an empty getUpdates call blocks before returning,
+// emulating Telegram long polling without busy-looping the client.
+const longPollWait = 100 * time.Millisecond
+
+// updateQueue holds injected updates until a poller acknowledges them via offset.
+type updateQueue struct {
+	mu      sync.Mutex
+	pending []tg.Update
+	nextID  int
+	notify  chan struct{}
+}
+
+func newUpdateQueue() *updateQueue {
+	return &updateQueue{nextID: 1, notify: make(chan struct{})}
+}
+
+func (q *updateQueue) push(updates ...tg.Update) {
+	q.mu.Lock()
+	for _, u := range updates {
+		if u.UpdateID == 0 {
+			u.UpdateID = q.nextID
+		}
+		if u.UpdateID >= q.nextID {
+			q.nextID = u.UpdateID + 1
+		}
+		q.pending = append(q.pending, u)
+	}
+	close(q.notify)
+	q.notify = make(chan struct{})
+	q.mu.Unlock()
+}
+
+// take drops updates below offset and returns up to limit of the remainder.
+func (q *updateQueue) take(offset int64, limit int) ([]tg.Update, <-chan struct{}) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	kept := q.pending[:0]
+	for _, u := range q.pending {
+		if int64(u.UpdateID) >= offset {
+			kept = append(kept, u)
+		}
+	}
+	q.pending = kept
+
+	n := min(len(q.pending), limit)
+	return append([]tg.Update{}, q.pending[:n]...), q.notify
+}
+
+func (q *updateQueue) serve(w http.ResponseWriter, r *http.Request, body []byte) {
+	offset, limit := getUpdatesParams(r, body)
+
+	updates, notify := q.take(offset, limit)
+	if len(updates) == 0 {
+		select {
+		case <-notify:
+			updates, _ = q.take(offset, limit)
+		case <-time.After(longPollWait):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	ReplyOK(w, updates)
+}
+
+// getUpdatesParams reads offset and limit from the query string or a JSON body.
+func getUpdatesParams(r *http.Request, body []byte) (offset int64, limit int) {
+	limit = 100
+	var params struct {
+		Offset int64 `json:"offset"`
+		Limit  int   `json:"limit"`
+	}
+	if len(body) > 0 && json.Unmarshal(body, &params) == nil {
+		offset = params.Offset
+		if params.Limit > 0 {
+			limit = params.Limit
+		}
+	}
+	q := r.URL.Query()
+	if v, err := strconv.ParseInt(q.Get("offset"), 10, 64); err == nil {
+		offset = v
+	}
+	if v, err := strconv.Atoi(q.Get("limit")); err == nil && v > 0 {
+		limit = v
+	}
+	return offset, limit
+}
+
+// PushUpdate queues updates for delivery to any client polling getUpdates
+// on this server. Updates with a zero UpdateID are numbered automatically.
+// Updates stay queued until the poller acknowledges them via offset.
+//
+// Example:
+//
+//	server.PushUpdate(galigotest.TestUpdate(0, "/start"))
+//	update := <-bot.Updates()
+func (m *MockTelegramServer) PushUpdate(updates ...tg.Update) {
+	m.updates.push(updates...)
+}
+
+// PendingUpdates returns the number of injected updates not yet acknowledged.
+func (m *MockTelegramServer) PendingUpdates() int {
+	m.updates.mu.Lock()
+	defer m.updates.mu.Unlock()
+	return len(m.updates.pending)
+}
+
+// WebhookRequest builds a Telegram-style webhook POST carrying update.
+// If secret is non-empty it is set as the X-Telegram-Bot-Api-Secret-Token header.
+func WebhookRequest(t testing.TB, update tg.Update, secret string) *http.Request {
+	t.Helper()
+	body, err := json.Marshal(update)
+	if err != nil {
+		t.Fatalf("galigotest: marshal update: %v", err)
+	}
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+	}
+	return req
+}
+
+// ServeWebhook delivers update to a webhook handler and returns the recorded response.
+//
+// Example:
+//
+//	rec := galigotest.ServeWebhook(t, bot.WebhookHandler(), galigotest.TestUpdate(1, "hi"), "secret")
+//	assert.Equal(t, http.StatusOK, rec.Code)
+func ServeWebhook(t testing.TB, h http.Handler, update tg.Update, secret string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, WebhookRequest(t, update, secret))
+	return rec
+}
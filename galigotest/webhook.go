@@ -0,0 +1,228 @@
+package galigotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// DefaultWebhookTimeout is how long a WebhookTester waits for a response
+// when no Client is set.
+const DefaultWebhookTimeout = 30 * time.Second
+
+// WebhookTester plays Telegram's side of a webhook: it POSTs updates over
+// real HTTP, as JSON with the secret header, so a deployed handler can be
+// tested end-to-end, including middleware, proxies and server timeouts.
+// Telegram counts a 2xx response as delivered and redelivers the update
+// otherwise; the Deliver methods report the response for each attempt.
+//
+// Example:
+//
+//	wt := galigotest.NewHandlerWebhookTester(t, bot.WebhookHandler(), "secret")
+//	d := wt.Deliver(galigotest.TestUpdate(0, "/start"))
+//	assert.True(t, d.OK())
+type WebhookTester struct {
+	// URL receives the updates.
+	URL string
+	// Secret is sent as X-Telegram-Bot-Api-Secret-Token unless empty.
+	Secret string
+	// Client sends the requests (nil = a client with DefaultWebhookTimeout).
+	Client *http.Client
+
+	t      testing.TB
+	mu     sync.Mutex
+	nextID int
+}
+
+// NewWebhookTester returns a WebhookTester posting to url, e.g. a server
+// started by the test or a local deployment.
+func NewWebhookTester(t testing.TB, url, secret string) *WebhookTester {
+	return &WebhookTester{URL: url, Secret: secret, t: t, nextID: 1}
+}
+
+// NewHandlerWebhookTester serves h on a local HTTP server, closed when the
+// test completes, and returns a WebhookTester posting to it.
+func NewHandlerWebhookTester(t testing.TB, h http.Handler, secret string) *WebhookTester {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return NewWebhookTester(t, srv.URL, secret)
+}
+
+// WebhookDelivery is the outcome of one webhook POST.
+type WebhookDelivery struct {
+	UpdateID   int
+	StatusCode int // 0 if the request failed
+	Body       []byte
+	Duration   time.Duration
+	Err        error // transport error or timeout
+}
+
+// OK reports whether Telegram would consider the update delivered.
+func (d *WebhookDelivery) OK() bool {
+	return d.Err == nil && d.StatusCode >= 200 && d.StatusCode < 300
+}
+
+// Deliver posts update. An update with a zero UpdateID is numbered
+// automatically, continuing after the highest ID delivered so far.
+func (w *WebhookTester) Deliver(update tg.Update) *WebhookDelivery {
+	w.t.Helper()
+	update = w.number(update)
+	return w.post(update.UpdateID, w.marshal(update), w.Secret, 0, 0)
+}
+
+// DeliverWithSecret posts update with a different secret header ("" =
+// none), to check that unauthenticated requests are rejected.
+func (w *WebhookTester) DeliverWithSecret(update tg.Update, secret string) *WebhookDelivery {
+	w.t.Helper()
+	update = w.number(update)
+	return w.post(update.UpdateID, w.marshal(update), secret, 0, 0)
+}
+
+// DeliverRaw posts body as is, e.g. malformed JSON or an update type
+// galigo does not know yet.
+func (w *WebhookTester) DeliverRaw(body []byte) *WebhookDelivery {
+	w.t.Helper()
+	return w.post(0, body, w.Secret, 0, 0)
+}
+
+// DeliverDuplicate posts the same update n times in a row, as Telegram
+// does when a response is lost or times out. A handler should process it
+// once.
+func (w *WebhookTester) DeliverDuplicate(update tg.Update, n int) []*WebhookDelivery {
+	w.t.Helper()
+	update = w.number(update)
+	body := w.marshal(update)
+	out := make([]*WebhookDelivery, n)
+	for i := range out {
+		out[i] = w.post(update.UpdateID, body, w.Secret, 0, 0)
+	}
+	return out
+}
+
+// DeliverOutOfOrder numbers updates and posts them in reverse order of
+// UpdateID, as can happen after redeliveries. The deliveries are returned
+// in the order they were made.
+func (w *WebhookTester) DeliverOutOfOrder(updates ...tg.Update) []*WebhookDelivery {
+	w.t.Helper()
+	numbered := make([]tg.Update, len(updates))
+	for i, u := range updates {
+		numbered[i] = w.number(u)
+	}
+	slices.SortFunc(numbered, func(a, b tg.Update) int { return b.UpdateID - a.UpdateID })
+
+	out := make([]*WebhookDelivery, len(numbered))
+	for i, u := range numbered {
+		out[i] = w.post(u.UpdateID, w.marshal(u), w.Secret, 0, 0)
+	}
+	return out
+}
+
+// DeliverConcurrently posts all updates at once, as Telegram does with
+// max_connections above 1. The deliveries are returned in the order of
+// updates.
+func (w *WebhookTester) DeliverConcurrently(updates ...tg.Update) []*WebhookDelivery {
+	w.t.Helper()
+	out := make([]*WebhookDelivery, len(updates))
+	var wg sync.WaitGroup
+	for i, u := range updates {
+		u = w.number(u)
+		body := w.marshal(u)
+		wg.Go(func() {
+			out[i] = w.post(u.UpdateID, body, w.Secret, 0, 0)
+		})
+	}
+	wg.Wait()
+	return out
+}
+
+// DeliverSlowly posts update while sending its body chunk bytes at a time,
+// pausing delay between chunks, like a client on a slow network. Use it to
+// check server read timeouts and that one slow request does not hold up
+// others.
+func (w *WebhookTester) DeliverSlowly(update tg.Update, chunk int, delay time.Duration) *WebhookDelivery {
+	w.t.Helper()
+	update = w.number(update)
+	return w.post(update.UpdateID, w.marshal(update), w.Secret, max(chunk, 1), delay)
+}
+
+func (w *WebhookTester) number(update tg.Update) tg.Update {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if update.UpdateID == 0 {
+		update.UpdateID = w.nextID
+	}
+	if update.UpdateID >= w.nextID {
+		w.nextID = update.UpdateID + 1
+	}
+	return update
+}
+
+func (w *WebhookTester) marshal(update tg.Update) []byte {
+	w.t.Helper()
+	body, err := json.Marshal(update)
+	if err != nil {
+		w.t.Fatalf("galigotest: marshal update: %v", err)
+	}
+	return body
+}
+
+// post sends body, trickled chunk bytes every delay if chunk > 0.
+func (w *WebhookTester) post(updateID int, body []byte, secret string, chunk int, delay time.Duration) *WebhookDelivery {
+	var r io.Reader = bytes.NewReader(body)
+	if chunk > 0 {
+		pr, pw := io.Pipe()
+		go trickle(pw, body, chunk, delay)
+		r = pr
+	}
+
+	d := &WebhookDelivery{UpdateID: updateID}
+	req, err := http.NewRequestWithContext(w.t.Context(), http.MethodPost, w.URL, r)
+	if err != nil {
+		d.Err = err
+		return d
+	}
+	req.ContentLength = int64(len(body))
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+	}
+
+	client := w.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultWebhookTimeout}
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		d.Err = err
+		d.Duration = time.Since(start)
+		return d
+	}
+	defer resp.Body.Close()
+	d.Body, d.Err = io.ReadAll(resp.Body)
+	d.StatusCode = resp.StatusCode
+	d.Duration = time.Since(start)
+	return d
+}
+
+// trickle writes body to pw in chunks, pausing delay before each.
+func trickle(pw *io.PipeWriter, body []byte, chunk int, delay time.Duration) {
+	for len(body) > 0 {
+		time.Sleep(delay)
+		n := min(chunk, len(body))
+		if _, err := pw.Write(body[:n]); err != nil {
+			return
+		}
+		body = body[n:]
+	}
+	pw.Close()
+}
@@ -0,0 +1,229 @@
+package galigo
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Message History ==================
+//
+// Telegram sends edits as the full new message, without the old version,
+// and reports deletions only for business chats, by ID. A MessageHistory
+// remembers the messages it sees so that edit and delete updates can be
+// correlated with what was there before.
+
+// MessageStore persists messages for MessageHistory.
+// Implementations must be safe for concurrent use.
+type MessageStore interface {
+	Put(ctx context.Context, msg *tg.Message) error
+	Get(ctx context.Context, chatID int64, messageID int) (*tg.Message, bool, error)
+	Delete(ctx context.Context, chatID int64, messageID int) error
+}
+
+type messageKey struct {
+	chatID    int64
+	messageID int
+}
+
+// MemoryMessageStore is an in-memory MessageStore that keeps the most
+// recently stored messages. The zero value is ready to use.
+type MemoryMessageStore struct {
+	// MaxMessages bounds the number of stored messages (0 = 10000); the
+	// oldest are dropped first.
+	MaxMessages int
+
+	mu       sync.Mutex
+	messages map[messageKey]*tg.Message
+	order    []messageKey // insertion order
+}
+
+var _ MessageStore = (*MemoryMessageStore)(nil)
+
+// Put stores msg, replacing an earlier version.
+func (s *MemoryMessageStore) Put(_ context.Context, msg *tg.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.messages == nil {
+		s.messages = make(map[messageKey]*tg.Message)
+	}
+	key := messageKey{msg.Chat.ID, msg.MessageID}
+	if _, exists := s.messages[key]; !exists {
+		s.order = append(s.order, key)
+	}
+	s.messages[key] = msg
+
+	limit := s.MaxMessages
+	if limit <= 0 {
+		limit = 10000
+	}
+	for len(s.messages) > limit {
+		delete(s.messages, s.order[0])
+		s.order = s.order[1:]
+	}
+	return nil
+}
+
+// Get returns the stored version of a message.
+func (s *MemoryMessageStore) Get(_ context.Context, chatID int64, messageID int) (*tg.Message, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg, ok := s.messages[messageKey{chatID, messageID}]
+	return msg, ok, nil
+}
+
+// Delete forgets a message.
+func (s *MemoryMessageStore) Delete(_ context.Context, chatID int64, messageID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := messageKey{chatID, messageID}
+	if _, ok := s.messages[key]; ok {
+		delete(s.messages, key)
+		s.order = slices.DeleteFunc(s.order, func(k messageKey) bool { return k == key })
+	}
+	return nil
+}
+
+// Edit describes an edited message or channel post.
+type Edit struct {
+	// Previous is the last version seen before the edit, or nil if the
+	// original was never observed (or has been evicted).
+	Previous *tg.Message
+	// Current is the edited message as sent by Telegram.
+	Current *tg.Message
+	// Kind is the update type: tg.UpdateTypeEditedMessage,
+	// tg.UpdateTypeEditedChannelPost or tg.UpdateTypeEditedBusinessMessage.
+	Kind string
+}
+
+// TextChanged reports whether the text or caption differs from the
+// previous version. It is true when the previous version is unknown.
+func (e *Edit) TextChanged() bool {
+	if e.Previous == nil {
+		return true
+	}
+	return e.Previous.Text != e.Current.Text || e.Previous.Caption != e.Current.Caption
+}
+
+// MessageHistory correlates edits and deletions with previously seen
+// messages. Feed every update to Observe.
+type MessageHistory struct {
+	store MessageStore
+}
+
+// NewMessageHistory returns a MessageHistory backed by store
+// (nil = a new MemoryMessageStore).
+func NewMessageHistory(store MessageStore) *MessageHistory {
+	if store == nil {
+		store = &MemoryMessageStore{}
+	}
+	return &MessageHistory{store: store}
+}
+
+// Observe records the messages carried by update. For edited messages and
+// channel posts it returns the Edit, with the version seen before; for
+// other updates it returns nil. The stored version is replaced by the
+// edited one, so consecutive edits diff against each other.
+func (h *MessageHistory) Observe(ctx context.Context, update tg.Update) (*Edit, error) {
+	if msg := newMessage(update); msg != nil {
+		return nil, h.store.Put(ctx, msg)
+	}
+
+	current, kind := editedMessage(update)
+	if current == nil {
+		return nil, nil
+	}
+	prev, _, err := h.store.Get(ctx, current.Chat.ID, current.MessageID)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.store.Put(ctx, current); err != nil {
+		return nil, err
+	}
+	return &Edit{Previous: prev, Current: current, Kind: kind}, nil
+}
+
+// Deleted returns the previously seen versions of the messages removed by
+// a deleted_business_messages update and forgets them. Messages that were
+// never observed are omitted. Telegram reports deletions only for business
+// chats.
+func (h *MessageHistory) Deleted(ctx context.Context, update tg.Update) ([]*tg.Message, error) {
+	d := update.DeletedBusinessMessages
+	if d == nil {
+		return nil, nil
+	}
+	var msgs []*tg.Message
+	for _, id := range d.MessageIDs {
+		msg, ok, err := h.store.Get(ctx, d.Chat.ID, id)
+		if err != nil {
+			return msgs, err
+		}
+		if ok {
+			msgs = append(msgs, msg)
+		}
+		if err := h.store.Delete(ctx, d.Chat.ID, id); err != nil {
+			return msgs, err
+		}
+	}
+	return msgs, nil
+}
+
+// Edits observes every update from in and emits only edits, for bots that
+// consume edits on their own (e.g. a moderation log). It takes ownership
+// of in; to handle other updates too, call Observe from the update loop
+// instead. The returned channel is closed when in is closed or ctx is
+// done. Store errors are skipped.
+func (h *MessageHistory) Edits(ctx context.Context, in <-chan tg.Update) <-chan *Edit {
+	out := make(chan *Edit, cap(in))
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case update, ok := <-in:
+				if !ok {
+					return
+				}
+				edit, err := h.Observe(ctx, update)
+				if err != nil || edit == nil {
+					continue
+				}
+				select {
+				case out <- edit:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Lookup returns the last seen version of a message.
+func (h *MessageHistory) Lookup(ctx context.Context, chatID int64, messageID int) (*tg.Message, bool, error) {
+	return h.store.Get(ctx, chatID, messageID)
+}
+
+func newMessage(u tg.Update) *tg.Message {
+	for _, m := range []*tg.Message{u.Message, u.ChannelPost, u.BusinessMessage} {
+		if m != nil && m.Chat != nil {
+			return m
+		}
+	}
+	return nil
+}
+
+func editedMessage(u tg.Update) (*tg.Message, string) {
+	switch {
+	case u.EditedMessage != nil && u.EditedMessage.Chat != nil:
+		return u.EditedMessage, tg.UpdateTypeEditedMessage
+	case u.EditedChannelPost != nil && u.EditedChannelPost.Chat != nil:
+		return u.EditedChannelPost, tg.UpdateTypeEditedChannelPost
+	case u.EditedBusinessMessage != nil && u.EditedBusinessMessage.Chat != nil:
+		return u.EditedBusinessMessage, tg.UpdateTypeEditedBusinessMessage
+	}
+	return nil, ""
+}
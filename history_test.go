@@ -0,0 +1,102 @@
+package galigo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+func textMessage(chatID int64, id int, text string) *tg.Message {
+	return &tg.Message{MessageID: id, Chat: &tg.Chat{ID: chatID}, Text: text}
+}
+
+func TestMessageHistory_EditCorrelation(t *testing.T) {
+	ctx := context.Background()
+	h := NewMessageHistory(nil)
+
+	edit, err := h.Observe(ctx, tg.Update{Message: textMessage(1, 10, "helo")})
+	require.NoError(t, err)
+	assert.Nil(t, edit)
+
+	edit, err = h.Observe(ctx, tg.Update{EditedMessage: textMessage(1, 10, "hello")})
+	require.NoError(t, err)
+	require.NotNil(t, edit)
+	assert.Equal(t, tg.UpdateTypeEditedMessage, edit.Kind)
+	assert.Equal(t, "helo", edit.Previous.Text)
+	assert.Equal(t, "hello", edit.Current.Text)
+	assert.True(t, edit.TextChanged())
+
+	edit, err = h.Observe(ctx, tg.Update{EditedMessage: textMessage(1, 10, "hello")})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", edit.Previous.Text, "consecutive edits diff against each other")
+	assert.False(t, edit.TextChanged())
+}
+
+func TestMessageHistory_UnseenOriginal(t *testing.T) {
+	h := NewMessageHistory(nil)
+
+	edit, err := h.Observe(context.Background(), tg.Update{EditedChannelPost: textMessage(-100, 5, "news")})
+	require.NoError(t, err)
+	require.NotNil(t, edit)
+	assert.Nil(t, edit.Previous)
+	assert.Equal(t, tg.UpdateTypeEditedChannelPost, edit.Kind)
+	assert.True(t, edit.TextChanged())
+}
+
+func TestMessageHistory_DeletedBusinessMessages(t *testing.T) {
+	ctx := context.Background()
+	h := NewMessageHistory(nil)
+	_, err := h.Observe(ctx, tg.Update{BusinessMessage: textMessage(7, 1, "secret")})
+	require.NoError(t, err)
+
+	deleted, err := h.Deleted(ctx, tg.Update{DeletedBusinessMessages: &tg.BusinessMessagesDeleted{
+		Chat:       tg.Chat{ID: 7},
+		MessageIDs: []int{1, 2},
+	}})
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, "secret", deleted[0].Text)
+
+	_, ok, err := h.Lookup(ctx, 7, 1)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestMemoryMessageStore_Evicts(t *testing.T) {
+	ctx := context.Background()
+	s := &MemoryMessageStore{MaxMessages: 2}
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, s.Put(ctx, textMessage(1, i, "x")))
+	}
+
+	_, ok, _ := s.Get(ctx, 1, 1)
+	assert.False(t, ok, "oldest message evicted")
+	_, ok, _ = s.Get(ctx, 1, 3)
+	assert.True(t, ok)
+}
+
+func TestMessageHistory_EditsStream(t *testing.T) {
+	in := make(chan tg.Update, 4)
+	h := NewMessageHistory(nil)
+	edits := h.Edits(context.Background(), in)
+
+	in <- tg.Update{Message: textMessage(1, 1, "a")}
+	in <- tg.Update{Message: textMessage(1, 2, "b")}
+	in <- tg.Update{EditedMessage: textMessage(1, 2, "b2")}
+	close(in)
+
+	select {
+	case e := <-edits:
+		assert.Equal(t, "b", e.Previous.Text)
+		assert.Equal(t, "b2", e.Current.Text)
+	case <-time.After(2 * time.Second):
+		t.Fatal("edit not delivered")
+	}
+	_, ok := <-edits
+	assert.False(t, ok)
+}
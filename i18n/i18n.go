@@ -0,0 +1,277 @@
+// Package i18n translates bot replies using per-locale message catalogs.
+//
+// Catalogs are JSON objects mapping keys to text/template strings, or to
+// plural forms keyed by CLDR category:
+//
+//	{
+//	  "welcome": "Hello, {{.Name}}!",
+//	  "apples": {"one": "{{.Count}} apple", "other": "{{.Count}} apples"},
+//	  "menu": {"title": "Main menu"}
+//	}
+//
+// Nested objects that are not plural forms are flattened with dots
+// ("menu.title"). Other formats such as TOML are supported by registering
+// their unmarshal function with Bundle.RegisterFormat.
+//
+// Attach a localizer to every update context with Decorator, then
+// translate in handlers:
+//
+//	bot, _ := galigo.New(token, galigo.WithContextDecorator(i18n.Decorator(bundle)))
+//	...
+//	ctx := bot.UpdateContext(update)
+//	bot.SendMessage(ctx, chatID, i18n.T(ctx, "welcome", i18n.Args{"Name": name}))
+//
+// The locale comes from the update sender's language_code, falling back
+// from "pt-br" to "pt" and then to the bundle's default locale.
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Args holds template variables.
+type Args = map[string]any
+
+// UnmarshalFunc decodes a catalog file, e.g. json.Unmarshal or
+// toml.Unmarshal.
+type UnmarshalFunc func(data []byte, v any) error
+
+// pluralCategories are the CLDR plural categories a catalog entry may use.
+var pluralCategories = []string{"zero", "one", "two", "few", "many", "other"}
+
+// message is one catalog entry: a single text, or texts per plural
+// category.
+type message struct {
+	text   form
+	plural map[string]form
+}
+
+// form is a catalog text; tmpl is nil for plain text without actions.
+type form struct {
+	src  string
+	tmpl *template.Template
+}
+
+// Bundle holds the catalogs of all locales. It is safe for concurrent use;
+// load catalogs at startup, before serving updates.
+type Bundle struct {
+	defaultLocale string
+
+	mu       sync.RWMutex
+	catalogs map[string]map[string]message
+	formats  map[string]UnmarshalFunc
+	rules    map[string]PluralRule
+}
+
+// NewBundle returns an empty Bundle that falls back to defaultLocale.
+func NewBundle(defaultLocale string) *Bundle {
+	return &Bundle{
+		defaultLocale: normalizeLocale(defaultLocale),
+		catalogs:      make(map[string]map[string]message),
+		formats:       map[string]UnmarshalFunc{".json": json.Unmarshal},
+		rules:         make(map[string]PluralRule),
+	}
+}
+
+// DefaultLocale returns the locale used when no better match exists.
+func (b *Bundle) DefaultLocale() string {
+	return b.defaultLocale
+}
+
+// RegisterFormat makes LoadFS read files with extension ext (such as
+// ".toml") using unmarshal.
+func (b *Bundle) RegisterFormat(ext string, unmarshal UnmarshalFunc) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.formats[strings.ToLower(ext)] = unmarshal
+}
+
+// LoadJSON adds the JSON catalog data to locale.
+func (b *Bundle) LoadJSON(locale string, data []byte) error {
+	return b.Load(locale, data, json.Unmarshal)
+}
+
+// Load adds a catalog decoded with unmarshal to locale. Keys already loaded
+// for the locale are replaced.
+func (b *Bundle) Load(locale string, data []byte, unmarshal UnmarshalFunc) error {
+	var raw map[string]any
+	if err := unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("i18n: decode %s catalog: %w", locale, err)
+	}
+	msgs := make(map[string]message)
+	if err := flatten(msgs, "", raw); err != nil {
+		return fmt.Errorf("i18n: %s catalog: %w", locale, err)
+	}
+
+	locale = normalizeLocale(locale)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	catalog := b.catalogs[locale]
+	if catalog == nil {
+		catalog = make(map[string]message, len(msgs))
+		b.catalogs[locale] = catalog
+	}
+	maps.Copy(catalog, msgs)
+	return nil
+}
+
+// LoadFS loads every catalog file in dir of fsys whose extension has a
+// registered format. The file name without extension is the locale, e.g.
+// "locales/de.json" or "locales/pt-BR.toml".
+func (b *Bundle) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("i18n: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := strings.ToLower(path.Ext(entry.Name()))
+		b.mu.RLock()
+		unmarshal, ok := b.formats[ext]
+		b.mu.RUnlock()
+		if !ok {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("i18n: %w", err)
+		}
+		if err := b.Load(strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())), data, unmarshal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Locales returns the locales with a loaded catalog.
+func (b *Bundle) Locales() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	locales := make([]string, 0, len(b.catalogs))
+	for l := range b.catalogs {
+		locales = append(locales, l)
+	}
+	return locales
+}
+
+// flatten parses raw into msgs, joining nested keys with dots.
+func flatten(msgs map[string]message, prefix string, raw map[string]any) error {
+	for k, v := range raw {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch v := v.(type) {
+		case string:
+			f, err := parseForm(key, v)
+			if err != nil {
+				return err
+			}
+			msgs[key] = message{text: f}
+		case map[string]any:
+			if isPlural(v) {
+				m := message{plural: make(map[string]form, len(v))}
+				for cat, text := range v {
+					s, ok := text.(string)
+					if !ok {
+						return fmt.Errorf("%s.%s: plural form must be a string", key, cat)
+					}
+					f, err := parseForm(key+"."+cat, s)
+					if err != nil {
+						return err
+					}
+					m.plural[cat] = f
+				}
+				msgs[key] = m
+				continue
+			}
+			if err := flatten(msgs, key, v); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%s: unsupported value of type %T", key, v)
+		}
+	}
+	return nil
+}
+
+// isPlural reports whether all keys of v are plural categories and
+// "other" is present.
+func isPlural(v map[string]any) bool {
+	if _, ok := v["other"]; !ok {
+		return false
+	}
+	for k := range v {
+		if !slices.Contains(pluralCategories, k) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseForm(name, text string) (form, error) {
+	f := form{src: text}
+	if !strings.Contains(text, "{{") {
+		return f, nil
+	}
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return form{}, err
+	}
+	f.tmpl = tmpl
+	return f, nil
+}
+
+// lookup returns the message for key in the first locale of the fallback
+// chain that has it.
+func (b *Bundle) lookup(locale, key string) (message, string, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, l := range b.fallbacks(locale) {
+		if m, ok := b.catalogs[l][key]; ok {
+			return m, l, true
+		}
+	}
+	return message{}, "", false
+}
+
+// fallbacks returns locale, its base language and the default locale.
+func (b *Bundle) fallbacks(locale string) []string {
+	chain := make([]string, 0, 3)
+	if locale != "" {
+		chain = append(chain, locale)
+		if base, _, ok := strings.Cut(locale, "-"); ok {
+			chain = append(chain, base)
+		}
+	}
+	return append(chain, b.defaultLocale)
+}
+
+// render executes the form's template with args. A template that fails
+// to execute renders as its source text.
+func (f form) render(args Args) string {
+	if f.tmpl == nil {
+		return f.src
+	}
+	var buf bytes.Buffer
+	if err := f.tmpl.Execute(&buf, args); err != nil {
+		return f.src
+	}
+	return buf.String()
+}
+
+// normalizeLocale lowercases a language tag and uses "-" as separator.
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
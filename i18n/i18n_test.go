@@ -0,0 +1,119 @@
+package i18n_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/i18n"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func loadBundle(t *testing.T) *i18n.Bundle {
+	t.Helper()
+	b := i18n.NewBundle("en")
+	require.NoError(t, b.LoadFS(os.DirFS("testdata"), "."))
+	return b
+}
+
+func TestBundle_LoadFS(t *testing.T) {
+	b := loadBundle(t)
+	assert.ElementsMatch(t, []string{"en", "ru", "pt-br"}, b.Locales())
+}
+
+func TestLocalizer_TemplatesAndFallback(t *testing.T) {
+	b := loadBundle(t)
+
+	assert.Equal(t, "Hello, Ann!", b.Localizer("en").T("welcome", i18n.Args{"Name": "Ann"}))
+	assert.Equal(t, "Привет, Ann!", b.Localizer("ru").T("welcome", i18n.Args{"Name": "Ann"}))
+	assert.Equal(t, "Olá, Ann!", b.Localizer("pt-BR").T("welcome", i18n.Args{"Name": "Ann"}))
+	assert.Equal(t, "Привет, Ann!", b.Localizer("ru-RU").T("welcome", i18n.Args{"Name": "Ann"}), "region falls back to language")
+	assert.Equal(t, "Goodbye", b.Localizer("ru").T("bye"), "missing key falls back to default locale")
+	assert.Equal(t, "Main menu", b.Localizer("en").T("menu.title"))
+	assert.Equal(t, "unknown.key", b.Localizer("en").T("unknown.key"))
+}
+
+func TestLocalizer_Plurals(t *testing.T) {
+	b := loadBundle(t)
+	en, ru := b.Localizer("en"), b.Localizer("ru")
+
+	assert.Equal(t, "No apples", en.N("apples", 0))
+	assert.Equal(t, "1 apple", en.N("apples", 1))
+	assert.Equal(t, "5 apples", en.N("apples", 5))
+	assert.Equal(t, "2 apples", en.T("apples", i18n.Args{"Count": 2}))
+
+	assert.Equal(t, "1 яблоко", ru.N("apples", 1))
+	assert.Equal(t, "21 яблоко", ru.N("apples", 21))
+	assert.Equal(t, "3 яблока", ru.N("apples", 3))
+	assert.Equal(t, "11 яблок", ru.N("apples", 11))
+	assert.Equal(t, "25 яблок", ru.N("apples", 25))
+}
+
+func TestBundle_RegisterPluralRule(t *testing.T) {
+	b := i18n.NewBundle("xx")
+	require.NoError(t, b.LoadJSON("xx", []byte(`{"n": {"two": "pair", "other": "many"}}`)))
+	b.RegisterPluralRule("xx", func(n int) string {
+		if n == 2 {
+			return "two"
+		}
+		return "other"
+	})
+
+	assert.Equal(t, "pair", b.Localizer("xx").N("n", 2))
+	assert.Equal(t, "many", b.Localizer("xx").N("n", 3))
+}
+
+func TestBundle_RegisterFormat(t *testing.T) {
+	// A toy "key = value" format standing in for TOML.
+	kv := func(data []byte, v any) error {
+		m := v.(*map[string]any)
+		*m = make(map[string]any)
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			k, val, _ := strings.Cut(line, " = ")
+			(*m)[k] = val
+		}
+		return nil
+	}
+	b := i18n.NewBundle("en")
+	require.NoError(t, b.Load("de", []byte("bye = Tschüss"), kv))
+
+	assert.Equal(t, "Tschüss", b.Localizer("de").T("bye"))
+}
+
+func TestBundle_LoadErrors(t *testing.T) {
+	b := i18n.NewBundle("en")
+
+	err := b.LoadJSON("en", []byte(`{"bad": "{{.Name"}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "i18n: en catalog")
+
+	err = b.LoadJSON("en", []byte(`{"num": 1}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported value")
+}
+
+func TestDecorator_AttachesSenderLocale(t *testing.T) {
+	b := loadBundle(t)
+	update := tg.Update{Message: &tg.Message{From: &tg.User{ID: 1, LanguageCode: "ru"}}}
+
+	ctx := i18n.Decorator(b)(context.Background(), update)
+
+	l, ok := i18n.FromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "ru", l.Locale())
+	assert.Equal(t, "Привет, Ann!", i18n.T(ctx, "welcome", i18n.Args{"Name": "Ann"}))
+	assert.Equal(t, "5 яблок", i18n.N(ctx, "apples", 5))
+}
+
+func TestT_WithoutLocalizer(t *testing.T) {
+	assert.Equal(t, "welcome", i18n.T(context.Background(), "welcome"))
+}
+
+func TestLocaleOf(t *testing.T) {
+	assert.Equal(t, "de", i18n.LocaleOf(tg.Update{CallbackQuery: &tg.CallbackQuery{From: &tg.User{LanguageCode: "de"}}}))
+	assert.Equal(t, "", i18n.LocaleOf(tg.Update{ChannelPost: &tg.Message{}}))
+}
@@ -0,0 +1,139 @@
+package i18n
+
+import (
+	"context"
+	"maps"
+	"strings"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// Localizer translates keys for one locale.
+type Localizer struct {
+	bundle *Bundle
+	locale string
+}
+
+// Localizer returns a Localizer for locale (an IETF tag such as "de" or
+// "pt-BR"). An empty locale uses the default locale.
+func (b *Bundle) Localizer(locale string) *Localizer {
+	locale = normalizeLocale(locale)
+	if locale == "" {
+		locale = b.defaultLocale
+	}
+	return &Localizer{bundle: b, locale: locale}
+}
+
+// Locale returns the localizer's requested locale.
+func (l *Localizer) Locale() string {
+	return l.locale
+}
+
+// T translates key, executing its template with args. Plural entries
+// select their form from args["Count"] when it is an int, else "other".
+// Missing keys are returned unchanged so gaps are visible, not fatal.
+func (l *Localizer) T(key string, args ...Args) string {
+	data := mergeArgs(args)
+	if n, ok := data["Count"].(int); ok {
+		return l.translate(key, n, true, data)
+	}
+	return l.translate(key, 0, false, data)
+}
+
+// N translates the plural entry key for count, which is also available to
+// the template as {{.Count}}. A "zero" form, if present, is used for 0 in
+// every language.
+func (l *Localizer) N(key string, count int, args ...Args) string {
+	data := mergeArgs(args)
+	data["Count"] = count
+	return l.translate(key, count, true, data)
+}
+
+func (l *Localizer) translate(key string, n int, counted bool, data Args) string {
+	m, locale, ok := l.bundle.lookup(l.locale, key)
+	if !ok {
+		return key
+	}
+	if m.plural == nil {
+		return m.text.render(data)
+	}
+
+	category := "other"
+	if counted {
+		category = l.bundle.pluralCategory(locale, n)
+		if _, ok := m.plural["zero"]; ok && n == 0 {
+			category = "zero"
+		}
+	}
+	f, ok := m.plural[category]
+	if !ok {
+		f = m.plural["other"]
+	}
+	return f.render(data)
+}
+
+func mergeArgs(args []Args) Args {
+	data := make(Args)
+	for _, a := range args {
+		maps.Copy(data, a)
+	}
+	return data
+}
+
+// ================== Context Integration ==================
+
+type localizerKey struct{}
+
+// NewContext returns ctx carrying l.
+func NewContext(ctx context.Context, l *Localizer) context.Context {
+	return context.WithValue(ctx, localizerKey{}, l)
+}
+
+// FromContext returns the Localizer attached to ctx, if any.
+func FromContext(ctx context.Context) (*Localizer, bool) {
+	l, ok := ctx.Value(localizerKey{}).(*Localizer)
+	return l, ok
+}
+
+// T translates key with the Localizer attached to ctx. Without one the key
+// is returned unchanged.
+func T(ctx context.Context, key string, args ...Args) string {
+	if l, ok := FromContext(ctx); ok {
+		return l.T(key, args...)
+	}
+	return key
+}
+
+// N translates the plural entry key for count with the Localizer attached
+// to ctx. Without one the key is returned unchanged.
+func N(ctx context.Context, key string, count int, args ...Args) string {
+	if l, ok := FromContext(ctx); ok {
+		return l.N(key, count, args...)
+	}
+	return key
+}
+
+// Decorator returns a receiver.ContextDecorator that attaches a Localizer
+// for the update's sender language (see LocaleOf) to every update context.
+// Use it with galigo.WithContextDecorator or receiver.WithContextDecorator.
+func Decorator(b *Bundle) receiver.ContextDecorator {
+	return func(ctx context.Context, update tg.Update) context.Context {
+		return NewContext(ctx, b.Localizer(LocaleOf(update)))
+	}
+}
+
+// LocaleOf returns the language_code of the user who caused update, or ""
+// when the update has no user or the user's client did not report one.
+func LocaleOf(update tg.Update) string {
+	if u := update.EffectiveUser(); u != nil {
+		return u.LanguageCode
+	}
+	return ""
+}
+
+// baseLanguage returns the language subtag of locale ("pt" for "pt-br").
+func baseLanguage(locale string) string {
+	lang, _, _ := strings.Cut(locale, "-")
+	return lang
+}
@@ -0,0 +1,107 @@
+package i18n
+
+// PluralRule returns the CLDR plural category ("zero", "one", "two",
+// "few", "many" or "other") of n.
+type PluralRule func(n int) string
+
+// builtinRules covers common bot languages; others use pluralOneOther.
+var builtinRules = map[string]PluralRule{
+	"fr": pluralFrench,
+	"ru": pluralEastSlavic,
+	"uk": pluralEastSlavic,
+	"be": pluralEastSlavic,
+	"pl": pluralPolish,
+	"cs": pluralCzech,
+	"sk": pluralCzech,
+	"ja": pluralNone,
+	"ko": pluralNone,
+	"zh": pluralNone,
+	"vi": pluralNone,
+	"th": pluralNone,
+	"id": pluralNone,
+}
+
+// RegisterPluralRule sets the plural rule of a language (base tag such as
+// "ar"), overriding the built-in rule.
+func (b *Bundle) RegisterPluralRule(lang string, rule PluralRule) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rules[normalizeLocale(lang)] = rule
+}
+
+// pluralCategory returns the category of n in locale.
+func (b *Bundle) pluralCategory(locale string, n int) string {
+	lang := baseLanguage(locale)
+	b.mu.RLock()
+	rule, ok := b.rules[lang]
+	b.mu.RUnlock()
+	if !ok {
+		if rule, ok = builtinRules[lang]; !ok {
+			rule = pluralOneOther
+		}
+	}
+	return rule(n)
+}
+
+// pluralOneOther is the rule of English, German, Spanish, Italian and
+// most other European languages.
+func pluralOneOther(n int) string {
+	if n == 1 || n == -1 {
+		return "one"
+	}
+	return "other"
+}
+
+func pluralFrench(n int) string {
+	if abs(n) < 2 {
+		return "one"
+	}
+	return "other"
+}
+
+func pluralEastSlavic(n int) string {
+	n = abs(n)
+	mod10, mod100 := n%10, n%100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return "one"
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return "few"
+	default:
+		return "many"
+	}
+}
+
+func pluralPolish(n int) string {
+	n = abs(n)
+	mod10, mod100 := n%10, n%100
+	switch {
+	case n == 1:
+		return "one"
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return "few"
+	default:
+		return "many"
+	}
+}
+
+func pluralCzech(n int) string {
+	n = abs(n)
+	switch {
+	case n == 1:
+		return "one"
+	case n >= 2 && n <= 4:
+		return "few"
+	default:
+		return "other"
+	}
+}
+
+func pluralNone(int) string { return "other" }
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
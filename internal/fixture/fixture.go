@@ -0,0 +1,137 @@
+// Package fixture holds the Bot API objects behind galigotest's fixtures.
+// It depends only on tg, so the sender's dry-run mode can synthesize its
+// results from the same objects; galigotest itself imports sender through
+// galigo.
+package fixture
+
+import "github.com/prilive-com/galigo/tg"
+
+// IDs and names shared by the fixtures.
+const (
+	Token       = "123456789:ABCdefGHIjklMNOpqrsTUVwxyz"
+	ChatID      = int64(123456789)
+	UserID      = int64(987654321)
+	BotID       = int64(123456789)
+	Username    = "testuser"
+	BotUsername = "testbot"
+)
+
+// User returns a user.
+func User() *tg.User {
+	return &tg.User{
+		ID:        UserID,
+		IsBot:     false,
+		FirstName: "Test",
+		LastName:  "User",
+		Username:  Username,
+	}
+}
+
+// Bot returns a bot user.
+func Bot() *tg.User {
+	return &tg.User{
+		ID:        BotID,
+		IsBot:     true,
+		FirstName: "Test Bot",
+		Username:  BotUsername,
+	}
+}
+
+// Chat returns the private chat with User.
+func Chat() *tg.Chat {
+	return &tg.Chat{
+		ID:        ChatID,
+		Type:      "private",
+		FirstName: "Test",
+		LastName:  "User",
+		Username:  Username,
+	}
+}
+
+// SuperGroupChat returns a supergroup.
+func SuperGroupChat(id int64, title, username string) *tg.Chat {
+	return &tg.Chat{
+		ID:       id,
+		Type:     "supergroup",
+		Title:    title,
+		Username: username,
+	}
+}
+
+// ChannelChat returns a channel.
+func ChannelChat(id int64, title, username string) *tg.Chat {
+	return &tg.Chat{
+		ID:       id,
+		Type:     "channel",
+		Title:    title,
+		Username: username,
+	}
+}
+
+// ChatFullInfo returns the full information of chat, as getChat reports it.
+func ChatFullInfo(chat *tg.Chat) *tg.ChatFullInfo {
+	return &tg.ChatFullInfo{
+		ID:               chat.ID,
+		Type:             chat.Type,
+		Title:            chat.Title,
+		Username:         chat.Username,
+		FirstName:        chat.FirstName,
+		LastName:         chat.LastName,
+		AccentColorID:    0,
+		MaxReactionCount: 11,
+	}
+}
+
+// ChatMember returns user as a regular member of a chat.
+func ChatMember(user *tg.User) *tg.ChatMemberMember {
+	m := &tg.ChatMemberMember{}
+	m.User = user
+	return m
+}
+
+// ChatOwner returns user as the owner of a chat.
+func ChatOwner(user *tg.User) *tg.ChatMemberOwner {
+	m := &tg.ChatMemberOwner{}
+	m.User = user
+	return m
+}
+
+// File returns a downloadable file with the given ID.
+func File(fileID string) *tg.File {
+	return &tg.File{
+		FileID:       fileID,
+		FileUniqueID: "unique_" + fileID,
+		FileSize:     1024,
+		FilePath:     "documents/" + fileID,
+	}
+}
+
+// StickerSet returns an empty regular sticker set.
+func StickerSet(name string) *tg.StickerSet {
+	return &tg.StickerSet{
+		Name:        name,
+		Title:       "Test Stickers",
+		StickerType: "regular",
+		Stickers:    []tg.Sticker{},
+	}
+}
+
+// InviteLink returns a primary invite link created by creator.
+func InviteLink(creator *tg.User) *tg.ChatInviteLink {
+	return &tg.ChatInviteLink{
+		InviteLink: "https://t.me/+AAAAAAAAAAAAAAAA",
+		Creator:    creator,
+		IsPrimary:  true,
+	}
+}
+
+// BusinessConnection returns an enabled business connection of User.
+func BusinessConnection(id string) *tg.BusinessConnection {
+	return &tg.BusinessConnection{
+		ID:         id,
+		User:       *User(),
+		UserChatID: UserID,
+		Date:       1234567890,
+		IsEnabled:  true,
+	}
+}
@@ -0,0 +1,247 @@
+// Command facade generates bot_methods.go: pass-through methods on
+// galigo.Bot for every exported sender.Client method the facade does not
+// define itself.
+//
+// Run from the repository root via go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const output = "bot_methods.go"
+
+// skip lists Client methods that are not API calls.
+var skip = map[string]bool{
+	"Close":            true, // Bot.Close closes the sender
+	"ChatLimiterCount": true,
+}
+
+func main() {
+	fset := token.NewFileSet()
+	own, err := botMethods(fset, ".")
+	if err != nil {
+		log.Fatal(err)
+	}
+	methods, err := clientMethods(fset, "sender")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by internal/gen/facade; DO NOT EDIT.\n\npackage galigo\n\n")
+	buf.WriteString("import (\n\t\"context\"\n")
+	var body bytes.Buffer
+	for _, m := range methods {
+		if own[m.Name.Name] || skip[m.Name.Name] {
+			continue
+		}
+		writeMethod(&body, fset, m)
+	}
+	for _, pkg := range []string{"encoding/json", "io", "iter", "time"} {
+		if usesPackage(body.Bytes(), filepath.Base(pkg)) {
+			fmt.Fprintf(&buf, "\t%q\n", pkg)
+		}
+	}
+	buf.WriteString("\n\t\"github.com/prilive-com/galigo/sender\"\n\t\"github.com/prilive-com/galigo/tg\"\n)\n")
+	buf.Write(body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("format: %v\n%s", err, buf.Bytes())
+	}
+	if err := os.WriteFile(output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// botMethods returns the methods declared on *Bot outside the generated file.
+func botMethods(fset *token.FileSet, dir string) (map[string]bool, error) {
+	files, err := parseDir(fset, dir, func(name string) bool { return name != output })
+	if err != nil {
+		return nil, err
+	}
+	own := make(map[string]bool)
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && receiverName(fn) == "Bot" {
+				own[fn.Name.Name] = true
+			}
+		}
+	}
+	return own, nil
+}
+
+// clientMethods returns the exported *Client methods, sorted by name.
+func clientMethods(fset *token.FileSet, dir string) ([]*ast.FuncDecl, error) {
+	files, err := parseDir(fset, dir, func(string) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	var methods []*ast.FuncDecl
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if ok && fn.Name.IsExported() && receiverName(fn) == "Client" {
+				methods = append(methods, fn)
+			}
+		}
+	}
+	slices.SortFunc(methods, func(a, b *ast.FuncDecl) int { return strings.Compare(a.Name.Name, b.Name.Name) })
+	return methods, nil
+}
+
+func parseDir(fset *token.FileSet, dir string, keep func(string) bool) ([]*ast.File, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, name := range names {
+		base := filepath.Base(name)
+		if strings.HasSuffix(base, "_test.go") || !keep(base) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) != 1 {
+		return ""
+	}
+	star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return ""
+	}
+	id, ok := star.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	return id.Name
+}
+
+// writeMethod emits a Bot method forwarding to the sender client.
+func writeMethod(w *bytes.Buffer, fset *token.FileSet, fn *ast.FuncDecl) {
+	ftype := qualify(fn.Type).(*ast.FuncType)
+
+	var args []string
+	variadic := false
+	for i, p := range ftype.Params.List {
+		if len(p.Names) == 0 {
+			p.Names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("arg%d", i))}
+		}
+		for _, n := range p.Names {
+			args = append(args, n.Name)
+		}
+		if _, ok := p.Type.(*ast.Ellipsis); ok {
+			variadic = true
+		}
+	}
+	call := fmt.Sprintf("b.sender.%s(%s", fn.Name.Name, strings.Join(args, ", "))
+	if variadic {
+		call += "..."
+	}
+	call += ")"
+
+	var sig bytes.Buffer
+	printer.Fprint(&sig, fset, ftype)
+
+	w.WriteString("\n")
+	if fn.Doc != nil {
+		for _, c := range fn.Doc.List {
+			w.WriteString(c.Text + "\n")
+		}
+	} else {
+		fmt.Fprintf(w, "// %s calls sender.Client.%s.\n", fn.Name.Name, fn.Name.Name)
+	}
+	fmt.Fprintf(w, "func (b *Bot) %s%s {\n", fn.Name.Name, strings.TrimPrefix(sig.String(), "func"))
+	if ftype.Results == nil {
+		fmt.Fprintf(w, "\t%s\n}\n", call)
+	} else {
+		fmt.Fprintf(w, "\treturn %s\n}\n", call)
+	}
+}
+
+// qualify returns a copy of expr with sender-local types prefixed by "sender.".
+func qualify(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if e.IsExported() {
+			return &ast.SelectorExpr{X: ast.NewIdent("sender"), Sel: ast.NewIdent(e.Name)}
+		}
+		return ast.NewIdent(e.Name)
+	case *ast.SelectorExpr:
+		return &ast.SelectorExpr{X: ast.NewIdent(e.X.(*ast.Ident).Name), Sel: ast.NewIdent(e.Sel.Name)}
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualify(e.X)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Elt: qualify(e.Elt)}
+	case *ast.MapType:
+		return &ast.MapType{Key: qualify(e.Key), Value: qualify(e.Value)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: qualify(e.Elt)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: e.Dir, Value: qualify(e.Value)}
+	case *ast.IndexExpr:
+		return &ast.IndexExpr{X: qualify(e.X), Index: qualify(e.Index)}
+	case *ast.IndexListExpr:
+		list := make([]ast.Expr, len(e.Indices))
+		for i, idx := range e.Indices {
+			list[i] = qualify(idx)
+		}
+		return &ast.IndexListExpr{X: qualify(e.X), Indices: list}
+	case *ast.FuncType:
+		return &ast.FuncType{Params: qualifyFields(e.Params), Results: qualifyFields(e.Results)}
+	case *ast.InterfaceType, *ast.StructType:
+		return e
+	}
+	panic(fmt.Sprintf("facade: unsupported type expression %T", expr))
+}
+
+func qualifyFields(fl *ast.FieldList) *ast.FieldList {
+	if fl == nil {
+		return nil
+	}
+	out := &ast.FieldList{}
+	for _, f := range fl.List {
+		names := make([]*ast.Ident, len(f.Names))
+		for i, n := range f.Names {
+			names[i] = ast.NewIdent(n.Name)
+		}
+		out.List = append(out.List, &ast.Field{Names: names, Type: qualify(f.Type)})
+	}
+	return out
+}
+
+// usesPackage reports whether code outside comments refers to package
+// name, e.g. "io.Writer" but not "ratio." in prose.
+func usesPackage(src []byte, name string) bool {
+	ref := regexp.MustCompile(`(^|[^\w.])` + regexp.QuoteMeta(name) + `\.`)
+	for line := range bytes.Lines(src) {
+		if bytes.HasPrefix(bytes.TrimSpace(line), []byte("//")) {
+			continue
+		}
+		if ref.Match(line) {
+			return true
+		}
+	}
+	return false
+}
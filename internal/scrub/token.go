@@ -2,6 +2,8 @@
 package scrub
 
 import (
+	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/prilive-com/galigo/tg"
@@ -9,6 +11,7 @@ import (
 
 // TokenFromError removes the bot token from error messages.
 // Go's http.Client.Do() includes the request URL (containing the token) in error strings.
+// URL-escaped copies ("123%3AABC"), as found in redirect errors, are removed too.
 // Preserves the error chain for errors.Is/As via Unwrap().
 func TokenFromError(err error, token tg.SecretToken) error {
 	if err == nil {
@@ -19,15 +22,50 @@ func TokenFromError(err error, token tg.SecretToken) error {
 		return err
 	}
 	msg := err.Error()
-	if strings.Contains(msg, tokenVal) {
-		return &scrubbedError{
-			msg: strings.ReplaceAll(msg, tokenVal, "[REDACTED]"),
-			err: err,
-		}
+	scrubbed := msg
+	for _, form := range tokenForms(tokenVal) {
+		scrubbed = strings.ReplaceAll(scrubbed, form, "[REDACTED]")
+	}
+	if scrubbed != msg {
+		return &scrubbedError{msg: scrubbed, err: err}
 	}
 	return err
 }
 
+// tokenForms returns token as it may appear in an error: verbatim, and
+// escaped for a URL path or query with upper- or lowercase hex.
+func tokenForms(token string) []string {
+	forms := []string{token}
+	for _, escaped := range []string{url.PathEscape(token), url.QueryEscape(token)} {
+		for _, form := range []string{escaped, lowerHexEscapes(escaped)} {
+			if !slices.Contains(forms, form) {
+				forms = append(forms, form)
+			}
+		}
+	}
+	return forms
+}
+
+// lowerHexEscapes lowercases the hex digits of %XX escapes in s.
+func lowerHexEscapes(s string) string {
+	b := []byte(s)
+	for i := 0; i+2 < len(b); i++ {
+		if b[i] == '%' {
+			b[i+1] = lowerHex(b[i+1])
+			b[i+2] = lowerHex(b[i+2])
+			i += 2
+		}
+	}
+	return string(b)
+}
+
+func lowerHex(c byte) byte {
+	if c >= 'A' && c <= 'F' {
+		return c + ('a' - 'A')
+	}
+	return c
+}
+
 type scrubbedError struct {
 	msg string
 	err error
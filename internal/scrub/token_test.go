@@ -51,3 +51,16 @@ func TestTokenFromError_PreservesErrorChain(t *testing.T) {
 	var opErr *net.OpError
 	assert.True(t, errors.As(result, &opErr))
 }
+
+func TestTokenFromError_ScrubsEscapedToken(t *testing.T) {
+	token := tg.SecretToken("123456:ABCdef")
+	tests := []string{
+		`Get "https://proxy.example/login?next=https%3A%2F%2Fapi.telegram.org%2Fbot123456%3AABCdef%2FgetUpdates": stopped after 10 redirects`,
+		`Get "https://proxy.example/login?next=/bot123456%3aABCdef/getUpdates": EOF`,
+	}
+	for _, msg := range tests {
+		result := scrub.TokenFromError(errors.New(msg), token)
+		assert.Contains(t, result.Error(), "[REDACTED]")
+		assert.NotContains(t, result.Error(), "ABCdef")
+	}
+}
@@ -1,7 +1,9 @@
 // Package testutil provides testing utilities for galigo.
 //
 // This package is intended for internal testing only and should not be imported
-// by external packages.
+// by external packages. The mock server, captures, replies and fixtures live in
+// the public galigotest package and are aliased here; sender-specific client
+// constructors and the fake sleeper remain internal.
 //
 // # Mock Telegram Server
 //
@@ -0,0 +1,69 @@
+package testutil
+
+import "github.com/prilive-com/galigo/galigotest"
+
+// The mock server, request capture, canned replies and fixtures were promoted
+// to the public galigotest package. These aliases keep internal tests unchanged.
+
+// MockTelegramServer is an alias for galigotest.MockTelegramServer.
+type MockTelegramServer = galigotest.MockTelegramServer
+
+// Capture is an alias for galigotest.Capture.
+type Capture = galigotest.Capture
+
+// TelegramEnvelope is an alias for galigotest.TelegramEnvelope.
+type TelegramEnvelope = galigotest.TelegramEnvelope
+
+// Parameters is an alias for galigotest.Parameters.
+type Parameters = galigotest.Parameters
+
+// Test constants for consistent test data.
+const (
+	TestToken       = galigotest.TestToken
+	TestChatID      = galigotest.TestChatID
+	TestUserID      = galigotest.TestUserID
+	TestBotID       = galigotest.TestBotID
+	TestUsername    = galigotest.TestUsername
+	TestBotUsername = galigotest.TestBotUsername
+)
+
+// Server and replies.
+var (
+	NewMockServer            = galigotest.NewMockServer
+	ReplyOK                  = galigotest.ReplyOK
+	ReplyError               = galigotest.ReplyError
+	ReplyRateLimit           = galigotest.ReplyRateLimit
+	ReplyRateLimitHeaderOnly = galigotest.ReplyRateLimitHeaderOnly
+	ReplyServerError         = galigotest.ReplyServerError
+	ReplyBadRequest          = galigotest.ReplyBadRequest
+	ReplyForbidden           = galigotest.ReplyForbidden
+	ReplyNotFound            = galigotest.ReplyNotFound
+	ReplyMessage             = galigotest.ReplyMessage
+	ReplyMessageWithChat     = galigotest.ReplyMessageWithChat
+	ReplyBool                = galigotest.ReplyBool
+	ReplyMessageID           = galigotest.ReplyMessageID
+	ReplyUpdates             = galigotest.ReplyUpdates
+	ReplyEmptyUpdates        = galigotest.ReplyEmptyUpdates
+	ReplyUser                = galigotest.ReplyUser
+	ReplyWebhookInfo         = galigotest.ReplyWebhookInfo
+)
+
+// Fixtures.
+var (
+	TestUser                     = galigotest.TestUser
+	TestBot                      = galigotest.TestBot
+	TestChat                     = galigotest.TestChat
+	TestGroupChat                = galigotest.TestGroupChat
+	TestSuperGroupChat           = galigotest.TestSuperGroupChat
+	TestChannelChat              = galigotest.TestChannelChat
+	TestMessage                  = galigotest.TestMessage
+	TestMessageInChat            = galigotest.TestMessageInChat
+	TestUpdate                   = galigotest.TestUpdate
+	TestUpdateWithMessage        = galigotest.TestUpdateWithMessage
+	TestCallbackQuery            = galigotest.TestCallbackQuery
+	TestCallbackQueryWithMessage = galigotest.TestCallbackQueryWithMessage
+	TestUpdateWithCallback       = galigotest.TestUpdateWithCallback
+	TestInlineKeyboard           = galigotest.TestInlineKeyboard
+	TestInlineButton             = galigotest.TestInlineButton
+	TestURLButton                = galigotest.TestURLButton
+)
@@ -0,0 +1,68 @@
+// Package version reports the galigo module version and the default
+// User-Agent sent with Bot API requests.
+package version
+
+import (
+	"net/http"
+	"runtime/debug"
+	"sync"
+)
+
+// ModulePath is the galigo module path.
+const ModulePath = "github.com/prilive-com/galigo"
+
+// BotAPIVersion is the Telegram Bot API version galigo targets.
+const BotAPIVersion = "9.5"
+
+// Version returns the galigo module version recorded in the binary's build
+// info, e.g. "v1.4.0". It returns "devel" when galigo is the main module or
+// build info is unavailable.
+var Version = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "devel"
+	}
+	if info.Main.Path == ModulePath {
+		return normalize(info.Main.Version)
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != ModulePath {
+			continue
+		}
+		if dep.Replace != nil {
+			return normalize(dep.Replace.Version)
+		}
+		return normalize(dep.Version)
+	}
+	return "devel"
+})
+
+func normalize(v string) string {
+	if v == "" || v == "(devel)" {
+		return "devel"
+	}
+	return v
+}
+
+// UserAgent returns the default User-Agent, e.g.
+// "galigo/v1.4.0 (Bot API 9.5; +https://github.com/prilive-com/galigo)".
+func UserAgent() string {
+	return "galigo/" + Version() + " (Bot API " + BotAPIVersion + "; +https://" + ModulePath + ")"
+}
+
+// SetHeaders adds the extra headers req does not already carry, then the
+// User-Agent. A non-empty userAgent wins over a User-Agent in extra; when
+// neither is set, UserAgent() is used.
+func SetHeaders(req *http.Request, userAgent string, extra map[string]string) {
+	for k, v := range extra {
+		if req.Header.Get(k) == "" {
+			req.Header.Set(k, v)
+		}
+	}
+	switch {
+	case userAgent != "":
+		req.Header.Set("User-Agent", userAgent)
+	case req.Header.Get("User-Agent") == "":
+		req.Header.Set("User-Agent", UserAgent())
+	}
+}
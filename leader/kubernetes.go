@@ -0,0 +1,285 @@
+package leader
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// In-cluster service account files.
+const (
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount/"
+	leaseTimeFormat   = "2006-01-02T15:04:05.000000Z07:00" // MicroTime
+)
+
+// KubernetesLeaseConfig configures a KubernetesLease. Inside a pod only
+// Name is required; the pod's service account needs get, create and
+// update on leases.coordination.k8s.io in the namespace.
+type KubernetesLeaseConfig struct {
+	// Name of the Lease object, e.g. "mybot-polling".
+	Name string
+
+	// Namespace of the Lease (default: the pod's namespace).
+	Namespace string
+
+	// Identity names this holder (default: DefaultIdentity()).
+	Identity string
+
+	// APIServer is the API server URL (default: from
+	// KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT).
+	APIServer string
+
+	// TokenFile holds the bearer token, re-read on every request as
+	// Kubernetes rotates it (default: the service account token).
+	TokenFile string
+
+	// HTTPClient sends the requests (default: a client trusting the
+	// service account CA).
+	HTTPClient *http.Client
+}
+
+// KubernetesLease is a Lock backed by a coordination.k8s.io/v1 Lease, the
+// object Kubernetes controllers use for leader election. It talks to the
+// API server directly, without client-go.
+type KubernetesLease struct {
+	leases    string // the namespace's leases collection
+	url       string // the Lease object
+	namespace string
+	name      string
+	identity  string
+	tokenFile string
+	client    *http.Client
+
+	// observed is the holder and renew time last read from the Lease and
+	// observedAt when this node first read them. Expiry is measured from
+	// observedAt on the local clock, so clock skew between nodes cannot
+	// end a lease early.
+	mu         sync.Mutex
+	observed   string
+	observedAt time.Time
+}
+
+var _ Lock = (*KubernetesLease)(nil)
+
+// NewKubernetesLease returns a KubernetesLease, filling in cfg's defaults
+// from the pod's service account.
+func NewKubernetesLease(cfg KubernetesLeaseConfig) (*KubernetesLease, error) {
+	if cfg.Name == "" {
+		return nil, errors.New("galigo: kubernetes lease: name is required")
+	}
+	if cfg.Namespace == "" {
+		ns, err := os.ReadFile(serviceAccountDir + "namespace")
+		if err != nil {
+			return nil, fmt.Errorf("galigo: kubernetes lease: namespace: %w", err)
+		}
+		cfg.Namespace = strings.TrimSpace(string(ns))
+	}
+	if cfg.Identity == "" {
+		cfg.Identity = DefaultIdentity()
+	}
+	if cfg.APIServer == "" {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host == "" || port == "" {
+			return nil, errors.New("galigo: kubernetes lease: not running in a cluster and no APIServer set")
+		}
+		cfg.APIServer = "https://" + net.JoinHostPort(host, port)
+	}
+	if cfg.TokenFile == "" {
+		cfg.TokenFile = serviceAccountDir + "token"
+	}
+	if cfg.HTTPClient == nil {
+		ca, err := os.ReadFile(serviceAccountDir + "ca.crt")
+		if err != nil {
+			return nil, fmt.Errorf("galigo: kubernetes lease: CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("galigo: kubernetes lease: CA: no certificates")
+		}
+		cfg.HTTPClient = &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+			},
+		}
+	}
+	leases := strings.TrimSuffix(cfg.APIServer, "/") + "/apis/coordination.k8s.io/v1/namespaces/" +
+		url.PathEscape(cfg.Namespace) + "/leases"
+	return &KubernetesLease{
+		leases:    leases,
+		url:       leases + "/" + url.PathEscape(cfg.Name),
+		namespace: cfg.Namespace,
+		name:      cfg.Name,
+		identity:  cfg.Identity,
+		tokenFile: cfg.TokenFile,
+		client:    cfg.HTTPClient,
+	}, nil
+}
+
+// Identity returns the holder name written to the Lease.
+func (l *KubernetesLease) Identity() string { return l.identity }
+
+type lease struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Metadata   leaseMetadata `json:"metadata"`
+	Spec       leaseSpec     `json:"spec"`
+}
+
+type leaseMetadata struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type leaseSpec struct {
+	HolderIdentity       string `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds int    `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          string `json:"acquireTime,omitempty"`
+	RenewTime            string `json:"renewTime,omitempty"`
+	LeaseTransitions     int    `json:"leaseTransitions,omitempty"`
+}
+
+// expired reports whether the holder of spec has not renewed it for its
+// lease duration, as observed by this node up to now. Like client-go, it
+// compares RenewTime with the previous read rather than with the local
+// clock.
+func (l *KubernetesLease) expired(spec leaseSpec, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if record := spec.HolderIdentity + "@" + spec.RenewTime; record != l.observed {
+		l.observed, l.observedAt = record, now
+	}
+	return now.After(l.observedAt.Add(time.Duration(spec.LeaseDurationSeconds) * time.Second))
+}
+
+// Acquire takes the Lease if it is free or expired, or renews it, for ttl
+// rounded up to whole seconds. Losing a write race to another holder is
+// reported as false, not as an error.
+func (l *KubernetesLease) Acquire(ctx context.Context, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	seconds := max(int(math.Ceil(ttl.Seconds())), 1)
+
+	cur, found, err := l.get(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		cur = &lease{
+			APIVersion: "coordination.k8s.io/v1",
+			Kind:       "Lease",
+			Metadata:   leaseMetadata{Name: l.name, Namespace: l.namespace},
+		}
+	}
+	spec := &cur.Spec
+	if spec.HolderIdentity != l.identity {
+		if spec.HolderIdentity != "" && !l.expired(*spec, now) {
+			return false, nil
+		}
+		if spec.HolderIdentity != "" {
+			spec.LeaseTransitions++
+		}
+		spec.HolderIdentity = l.identity
+		spec.AcquireTime = now.UTC().Format(leaseTimeFormat)
+	}
+	spec.LeaseDurationSeconds = seconds
+	spec.RenewTime = now.UTC().Format(leaseTimeFormat)
+
+	if !found {
+		return l.write(ctx, http.MethodPost, l.leases, cur)
+	}
+	return l.write(ctx, http.MethodPut, l.url, cur)
+}
+
+// Release frees the Lease if this holder has it, so a standby replica
+// takes over without waiting for it to expire.
+func (l *KubernetesLease) Release(ctx context.Context) error {
+	cur, found, err := l.get(ctx)
+	if err != nil || !found || cur.Spec.HolderIdentity != l.identity {
+		return err
+	}
+	cur.Spec.HolderIdentity = ""
+	cur.Spec.LeaseDurationSeconds = 1
+	cur.Spec.RenewTime = time.Now().UTC().Format(leaseTimeFormat)
+	_, err = l.write(ctx, http.MethodPut, l.url, cur)
+	return err
+}
+
+func (l *KubernetesLease) get(ctx context.Context) (*lease, bool, error) {
+	resp, err := l.do(ctx, http.MethodGet, l.url, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, statusError(resp)
+	}
+	var cur lease
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&cur); err != nil {
+		return nil, false, fmt.Errorf("galigo: kubernetes lease: decode: %w", err)
+	}
+	return &cur, true, nil
+}
+
+// write creates or updates the Lease. A 409 Conflict means another holder
+// wrote it first.
+func (l *KubernetesLease) write(ctx context.Context, method, target string, obj *lease) (bool, error) {
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return false, err
+	}
+	resp, err := l.do(ctx, method, target, body)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		return true, nil
+	case http.StatusConflict:
+		return false, nil
+	}
+	return false, statusError(resp)
+}
+
+func (l *KubernetesLease) do(ctx context.Context, method, target string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	token, err := os.ReadFile(l.tokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("galigo: kubernetes lease: token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("galigo: kubernetes lease: %w", err)
+	}
+	return resp, nil
+}
+
+func statusError(resp *http.Response) error {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("galigo: kubernetes lease: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+}
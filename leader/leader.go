@@ -0,0 +1,46 @@
+// Package leader provides the locks used to elect one polling replica.
+//
+// Telegram hands each update to a single getUpdates caller, so replicas
+// polling the same token split updates between them unpredictably (and
+// get 409 Conflict errors). With receiver.WithPollingLeaderLock (or
+// galigo.WithPollingLeaderLock), a PollingClient polls only while it holds
+// a Lock; the others wait on standby and take over once the leader stops
+// renewing it:
+//
+//	lock, _ := leader.NewKubernetesLease(leader.KubernetesLeaseConfig{Name: "mybot-polling"})
+//	bot, _ := galigo.New(token, galigo.WithPollingLeaderLock(lock, 15*time.Second))
+//
+// RedisLock and KubernetesLease are built in; other backends implement the
+// two-method Lock interface.
+package leader
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"time"
+)
+
+// Lock is a lease that at most one holder has at a time. Each Lock value
+// acts for one holder. Implementations must be safe for concurrent use.
+type Lock interface {
+	// Acquire takes the lease for ttl, or extends it if already held, and
+	// reports whether the caller holds it.
+	Acquire(ctx context.Context, ttl time.Duration) (bool, error)
+
+	// Release gives up the lease if the caller holds it.
+	Release(ctx context.Context) error
+}
+
+// DefaultIdentity returns the host name followed by a random suffix,
+// unique per call.
+func DefaultIdentity() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "galigo"
+	}
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return host + "-" + hex.EncodeToString(b)
+}
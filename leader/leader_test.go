@@ -0,0 +1,216 @@
+package leader_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/leader"
+)
+
+// fakeRedis emulates the lock scripts on a single key: acquire calls carry
+// the identity and TTL, release calls only the identity.
+type fakeRedis struct {
+	mu     sync.Mutex
+	holder string
+	ttl    any
+	err    error
+}
+
+func (r *fakeRedis) eval(_ context.Context, _ string, keys []string, args ...any) (any, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if keys[0] != "mybot:polling" {
+		return nil, errors.New("unexpected key " + keys[0])
+	}
+	id := args[0].(string)
+	if len(args) == 1 { // release
+		if r.holder == id {
+			r.holder = ""
+			return int64(1), nil
+		}
+		return int64(0), nil
+	}
+	if r.holder != "" && r.holder != id {
+		return int64(0), nil
+	}
+	r.holder, r.ttl = id, args[1]
+	return int64(1), nil
+}
+
+func TestRedisLock(t *testing.T) {
+	ctx := context.Background()
+	redis := &fakeRedis{}
+	a := leader.NewRedisLock(redis.eval, "mybot:polling", "a")
+	b := leader.NewRedisLock(redis.eval, "mybot:polling", "")
+	assert.NotEmpty(t, b.Identity())
+
+	held, err := a.Acquire(ctx, 15*time.Second)
+	require.NoError(t, err)
+	assert.True(t, held)
+	assert.Equal(t, int64(15000), redis.ttl)
+
+	held, err = b.Acquire(ctx, 15*time.Second)
+	require.NoError(t, err)
+	assert.False(t, held)
+
+	require.NoError(t, b.Release(ctx))
+	assert.Equal(t, "a", redis.holder, "only the holder releases")
+	require.NoError(t, a.Release(ctx))
+	held, err = b.Acquire(ctx, 15*time.Second)
+	require.NoError(t, err)
+	assert.True(t, held)
+
+	redis.err = errors.New("connection refused")
+	_, err = a.Acquire(ctx, time.Second)
+	assert.ErrorContains(t, err, "galigo: redis lock: connection refused")
+}
+
+// fakeLeaseAPI serves one Lease object with resourceVersion checks, like
+// the Kubernetes API server.
+type fakeLeaseAPI struct {
+	mu      sync.Mutex
+	lease   map[string]any // nil = not found
+	version int
+}
+
+func (api *fakeLeaseAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if r.Header.Get("Authorization") != "Bearer secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	const collection = "/apis/coordination.k8s.io/v1/namespaces/bots/leases"
+	var body map[string]any
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == collection+"/mybot":
+		if api.lease == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(api.lease)
+	case r.Method == http.MethodPost && r.URL.Path == collection:
+		if api.lease != nil {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		api.store(body)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && r.URL.Path == collection+"/mybot":
+		meta := body["metadata"].(map[string]any)
+		if meta["resourceVersion"] != api.lease["metadata"].(map[string]any)["resourceVersion"] {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		api.store(body)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func (api *fakeLeaseAPI) store(obj map[string]any) {
+	api.version++
+	obj["metadata"].(map[string]any)["resourceVersion"] = strings.Repeat("v", api.version)
+	api.lease = obj
+}
+
+func (api *fakeLeaseAPI) spec() map[string]any {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return api.lease["spec"].(map[string]any)
+}
+
+func TestKubernetesLease(t *testing.T) {
+	api := &fakeLeaseAPI{}
+	server := httptest.NewServer(api)
+	defer server.Close()
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0o600))
+
+	newLease := func(id string) *leader.KubernetesLease {
+		l, err := leader.NewKubernetesLease(leader.KubernetesLeaseConfig{
+			Name: "mybot", Namespace: "bots", Identity: id,
+			APIServer: server.URL, TokenFile: tokenFile, HTTPClient: server.Client(),
+		})
+		require.NoError(t, err)
+		return l
+	}
+	ctx := context.Background()
+	a, b := newLease("a"), newLease("b")
+
+	held, err := a.Acquire(ctx, 1500*time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, held, "creates the lease")
+	assert.Equal(t, "a", api.spec()["holderIdentity"])
+	assert.EqualValues(t, 2, api.spec()["leaseDurationSeconds"])
+
+	held, err = a.Acquire(ctx, time.Second)
+	require.NoError(t, err)
+	assert.True(t, held, "renews its own lease")
+
+	held, err = b.Acquire(ctx, time.Second)
+	require.NoError(t, err)
+	assert.False(t, held, "lease held by a")
+
+	require.NoError(t, b.Release(ctx))
+	assert.Equal(t, "a", api.spec()["holderIdentity"])
+	require.NoError(t, a.Release(ctx))
+	assert.Nil(t, api.spec()["holderIdentity"])
+
+	held, err = b.Acquire(ctx, time.Second)
+	require.NoError(t, err)
+	assert.True(t, held, "released lease is free")
+
+	// Expiry is measured from when a saw the renewal, not from renewTime,
+	// so a holder whose clock is behind keeps its lease.
+	api.mu.Lock()
+	api.lease["spec"].(map[string]any)["renewTime"] = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339Nano)
+	api.mu.Unlock()
+	held, err = a.Acquire(ctx, time.Second)
+	require.NoError(t, err)
+	assert.False(t, held, "renewal just observed")
+
+	// A lease not renewed for its duration is taken over.
+	time.Sleep(1100 * time.Millisecond)
+	held, err = a.Acquire(ctx, time.Second)
+	require.NoError(t, err)
+	assert.True(t, held)
+	assert.Equal(t, "a", api.spec()["holderIdentity"])
+	assert.EqualValues(t, 1, api.spec()["leaseTransitions"])
+}
+
+func TestKubernetesLease_Errors(t *testing.T) {
+	_, err := leader.NewKubernetesLease(leader.KubernetesLeaseConfig{})
+	assert.ErrorContains(t, err, "name is required")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden: leases", http.StatusForbidden)
+	}))
+	defer server.Close()
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret"), 0o600))
+	l, err := leader.NewKubernetesLease(leader.KubernetesLeaseConfig{
+		Name: "mybot", Namespace: "bots", APIServer: server.URL, TokenFile: tokenFile, HTTPClient: server.Client(),
+	})
+	require.NoError(t, err)
+	_, err = l.Acquire(context.Background(), time.Second)
+	assert.ErrorContains(t, err, "403 Forbidden: forbidden: leases")
+}
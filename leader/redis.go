@@ -0,0 +1,98 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// RedisEval runs a Lua script on Redis and returns its reply. With
+// go-redis:
+//
+//	eval := func(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+//	    return rdb.Eval(ctx, script, keys, args...).Result()
+//	}
+type RedisEval func(ctx context.Context, script string, keys []string, args ...any) (any, error)
+
+// redisAcquire sets KEYS[1] to ARGV[1] for ARGV[2] ms unless another
+// holder has it, returning 1 if ARGV[1] holds it afterwards.
+const redisAcquire = `
+local holder = redis.call('GET', KEYS[1])
+if holder == ARGV[1] then
+  redis.call('PEXPIRE', KEYS[1], ARGV[2])
+  return 1
+end
+if holder then
+  return 0
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return 1
+`
+
+// redisRelease deletes KEYS[1] if ARGV[1] holds it.
+const redisRelease = `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('DEL', KEYS[1])
+end
+return 0
+`
+
+// RedisLock is a Lock held in a Redis key whose value names the holder.
+type RedisLock struct {
+	eval     RedisEval
+	key      string
+	identity string
+}
+
+var _ Lock = (*RedisLock)(nil)
+
+// NewRedisLock returns a RedisLock on key, running its scripts with eval.
+// identity names this holder; "" means DefaultIdentity().
+func NewRedisLock(eval RedisEval, key, identity string) *RedisLock {
+	if identity == "" {
+		identity = DefaultIdentity()
+	}
+	return &RedisLock{eval: eval, key: key, identity: identity}
+}
+
+// Identity returns the holder name written to the key.
+func (l *RedisLock) Identity() string { return l.identity }
+
+// Acquire takes or extends the lock for ttl.
+func (l *RedisLock) Acquire(ctx context.Context, ttl time.Duration) (bool, error) {
+	reply, err := l.eval(ctx, redisAcquire, []string{l.key}, l.identity, max(ttl.Milliseconds(), 1))
+	if err != nil {
+		return false, fmt.Errorf("galigo: redis lock: %w", err)
+	}
+	n, err := redisInt(reply)
+	if err != nil {
+		return false, fmt.Errorf("galigo: redis lock: %w", err)
+	}
+	return n == 1, nil
+}
+
+// Release deletes the key if this holder has it.
+func (l *RedisLock) Release(ctx context.Context) error {
+	if _, err := l.eval(ctx, redisRelease, []string{l.key}, l.identity); err != nil {
+		return fmt.Errorf("galigo: redis lock: %w", err)
+	}
+	return nil
+}
+
+// redisInt converts an integer script reply.
+func redisInt(reply any) (int64, error) {
+	switch v := reply.(type) {
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("unexpected reply %q", v)
+		}
+		return n, nil
+	}
+	return 0, fmt.Errorf("unexpected reply %T", reply)
+}
@@ -0,0 +1,376 @@
+package galigo
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/prilive-com/galigo/receiver"
+)
+
+// ================== Multi-Bot Manager ==================
+//
+// A Manager hosts many bots in one process. Its bots share one HTTP
+// transport and, optionally, one rate limiter; webhook bots are served by
+// the Manager's single http.Handler. Bots can be added, removed and given
+// a new token while the Manager runs.
+
+// ErrBotExists is returned by AddBot for an ID already in use.
+var ErrBotExists = errors.New("galigo: bot ID already in use")
+
+// ErrBotNotFound is returned for IDs the Manager does not host.
+var ErrBotNotFound = errors.New("galigo: bot not found")
+
+// ManagerConfig configures a Manager.
+type ManagerConfig struct {
+	// Transport is shared by all bots (nil = NewTransport with defaults,
+	// sized for many bots talking to one host).
+	Transport http.RoundTripper
+
+	// GlobalRPS and GlobalBurst cap the combined send rate of all bots
+	// (0 = no shared cap; each bot keeps its own limits).
+	GlobalRPS   float64
+	GlobalBurst int
+
+	// Options are applied to every bot before its own options.
+	Options []Option
+
+	// PathPrefix is where webhook bots are served: a bot with ID "shop"
+	// receives updates at PathPrefix + "shop" (default "/").
+	PathPrefix string
+
+	// OnBot is called with each new Bot, including the replacement created
+	// by ReloadToken, typically to start a goroutine consuming its
+	// Updates. The channel of a removed or replaced bot is closed in
+	// polling mode.
+	OnBot func(id string, bot *Bot)
+}
+
+// Manager hosts multiple bots. It is safe for concurrent use.
+type Manager struct {
+	cfg           ManagerConfig
+	sharedLimiter *rate.Limiter
+	ownTransport  *Transport // created by NewManager; closed by Close
+
+	mu     sync.RWMutex
+	bots   map[string]*managedBot
+	ctx    context.Context // set by Start; nil while not running
+	closed bool
+}
+
+type managedBot struct {
+	bot    *Bot
+	secret string
+	opts   []Option
+}
+
+// BotStats is a snapshot of one managed bot.
+type BotStats struct {
+	ID                string
+	Mode              receiver.Mode
+	Healthy           bool
+	PendingUpdates    int // updates buffered but not yet consumed
+	UnderBackpressure bool
+	Polling           *receiver.PollingStats // nil in webhook mode
+}
+
+// NewManager returns an empty Manager.
+func NewManager(cfg ManagerConfig) *Manager {
+	m := &Manager{
+		cfg:  cfg,
+		bots: make(map[string]*managedBot),
+	}
+	if m.cfg.PathPrefix == "" {
+		m.cfg.PathPrefix = "/"
+	}
+	if m.cfg.Transport == nil {
+		m.ownTransport = NewTransport(TransportConfig{})
+		m.cfg.Transport = m.ownTransport
+	}
+	if cfg.GlobalRPS > 0 {
+		burst := max(cfg.GlobalBurst, 1)
+		m.sharedLimiter = rate.NewLimiter(rate.Limit(cfg.GlobalRPS), burst)
+	}
+	return m
+}
+
+// AddBot creates a bot with the given ID and token and, if the Manager is
+// running, starts it.
+func (m *Manager) AddBot(id, token string, opts ...Option) (*Bot, error) {
+	if id == "" || strings.Contains(id, "/") {
+		return nil, fmt.Errorf("galigo: invalid bot ID %q", id)
+	}
+
+	m.mu.RLock()
+	err := m.checkAdd(id)
+	ctx := m.ctx
+	m.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// New and Start may call the API, so the bot is built without m.mu.
+	mb, err := m.newBot(ctx, token, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	if err := m.checkAdd(id); err != nil {
+		m.mu.Unlock()
+		_ = mb.bot.Close()
+		return nil, err
+	}
+	m.bots[id] = mb
+	m.announce(id, mb.bot)
+	lateCtx := m.ctx
+	m.mu.Unlock()
+
+	if ctx == nil && lateCtx != nil {
+		// Start ran while the bot was built and did not see it
+		if err := mb.bot.Start(lateCtx); err != nil {
+			return mb.bot, fmt.Errorf("galigo: start bot %s: %w", id, err)
+		}
+	}
+	return mb.bot, nil
+}
+
+// checkAdd reports why a bot with id cannot be added. Callers hold m.mu.
+func (m *Manager) checkAdd(id string) error {
+	if m.closed {
+		return errors.New("galigo: manager closed")
+	}
+	if _, ok := m.bots[id]; ok {
+		return fmt.Errorf("%w: %s", ErrBotExists, id)
+	}
+	return nil
+}
+
+// RemoveBot stops and closes the bot with id.
+func (m *Manager) RemoveBot(id string) error {
+	m.mu.Lock()
+	mb, ok := m.bots[id]
+	delete(m.bots, id)
+	m.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrBotNotFound, id)
+	}
+	return mb.bot.Close()
+}
+
+// ReloadToken replaces the bot with id by one using token and the same
+// options, e.g. after the token was revoked in BotFather. A polling bot is
+// stopped first and, for a token of the same bot, the replacement
+// continues from its offset, so no update is delivered twice; if the replacement cannot be created, the old
+// bot resumes polling. Webhook bots must be registered again with
+// setWebhook under the new token.
+func (m *Manager) ReloadToken(id, token string) (*Bot, error) {
+	m.mu.RLock()
+	old, ok := m.bots[id]
+	ctx := m.ctx
+	m.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrBotNotFound, id)
+	}
+
+	old.bot.Stop()
+	opts := old.opts
+	oldID, _, _ := strings.Cut(old.bot.token.Value(), ":")
+	newID, _, _ := strings.Cut(token, ":")
+	if old.bot.receiver != nil && oldID == newID {
+		if offset := old.bot.receiver.Offset(); offset > 0 {
+			opts = append(slices.Clip(opts), func(c *botConfig) { c.pollingOffset = offset })
+		}
+	}
+	mb, err := m.newBot(ctx, token, opts)
+	if err != nil {
+		if ctx != nil {
+			_ = old.bot.Start(ctx)
+		}
+		return nil, err
+	}
+	mb.opts = old.opts
+
+	m.mu.Lock()
+	if m.bots[id] != old {
+		m.mu.Unlock()
+		_ = mb.bot.Close()
+		return nil, fmt.Errorf("%w: %s was removed or replaced during reload", ErrBotNotFound, id)
+	}
+	m.bots[id] = mb
+	m.announce(id, mb.bot)
+	lateCtx := m.ctx
+	m.mu.Unlock()
+
+	if err := old.bot.Close(); err != nil {
+		return mb.bot, fmt.Errorf("galigo: close replaced bot %s: %w", id, err)
+	}
+	if ctx == nil && lateCtx != nil {
+		// Start ran during the reload and did not see the replacement
+		if err := mb.bot.Start(lateCtx); err != nil {
+			return mb.bot, fmt.Errorf("galigo: start bot %s: %w", id, err)
+		}
+	}
+	return mb.bot, nil
+}
+
+// newBot creates a bot and, when ctx is set, starts it. Callers must not
+// hold m.mu.
+func (m *Manager) newBot(ctx context.Context, token string, opts []Option) (*managedBot, error) {
+	all := make([]Option, 0, len(m.cfg.Options)+len(opts)+2)
+	all = append(all, m.cfg.Options...)
+	all = append(all, WithTransport(m.cfg.Transport))
+	if m.sharedLimiter != nil {
+		all = append(all, WithSharedRateLimiter(m.sharedLimiter))
+	}
+	all = append(all, opts...)
+
+	bot, err := New(token, all...)
+	if err != nil {
+		return nil, err
+	}
+	if ctx != nil {
+		if err := bot.Start(ctx); err != nil {
+			_ = bot.Close()
+			return nil, err
+		}
+	}
+	return &managedBot{bot: bot, secret: bot.config.webhookSecret, opts: opts}, nil
+}
+
+// announce calls OnBot. Callers hold m.mu, so OnBot must not call back
+// into the Manager synchronously.
+func (m *Manager) announce(id string, bot *Bot) {
+	if m.cfg.OnBot != nil {
+		m.cfg.OnBot(id, bot)
+	}
+}
+
+// Transport returns the transport shared by all bots. Use a *Transport
+// (the default) to read connection metrics with Stats.
+func (m *Manager) Transport() http.RoundTripper {
+	return m.cfg.Transport
+}
+
+// Bot returns the bot with id.
+func (m *Manager) Bot(id string) (*Bot, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	mb, ok := m.bots[id]
+	if !ok {
+		return nil, false
+	}
+	return mb.bot, true
+}
+
+// IDs returns the IDs of all hosted bots, sorted.
+func (m *Manager) IDs() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ids := make([]string, 0, len(m.bots))
+	for id := range m.bots {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// Start starts all polling bots and every bot added later, which inherit
+// ctx. It returns the first start error; bots started before it keep
+// running.
+func (m *Manager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ctx != nil {
+		return receiver.ErrAlreadyRunning
+	}
+	m.ctx = ctx
+	for id, mb := range m.bots {
+		if err := mb.bot.Start(ctx); err != nil {
+			return fmt.Errorf("galigo: start bot %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// Close closes all bots and the Manager's own transport, joining their
+// errors.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	bots := m.bots
+	m.bots = make(map[string]*managedBot)
+	m.closed = true
+	m.mu.Unlock()
+
+	var errs []error
+	for _, mb := range bots {
+		errs = append(errs, mb.bot.Close())
+	}
+	if m.ownTransport != nil {
+		m.ownTransport.CloseIdleConnections()
+	}
+	return errors.Join(errs...)
+}
+
+// Stats returns a snapshot of every bot, sorted by ID.
+func (m *Manager) Stats() []BotStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	stats := make([]BotStats, 0, len(m.bots))
+	for id, mb := range m.bots {
+		s := BotStats{
+			ID:                id,
+			Mode:              mb.bot.config.mode,
+			Healthy:           mb.bot.IsHealthy(),
+			PendingUpdates:    len(mb.bot.updates),
+			UnderBackpressure: mb.bot.UnderBackpressure(),
+		}
+		if polling, ok := mb.bot.PollingStats(); ok {
+			s.Polling = &polling
+		}
+		stats = append(stats, s)
+	}
+	slices.SortFunc(stats, func(a, b BotStats) int { return strings.Compare(a.ID, b.ID) })
+	return stats
+}
+
+// ServeHTTP routes webhook requests to the webhook bot named by the path
+// (PathPrefix + ID) or, for other paths, to the bot whose webhook secret
+// matches the X-Telegram-Bot-Api-Secret-Token header.
+func (m *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h := m.route(r); h != nil {
+		h.ServeHTTP(w, r)
+		return
+	}
+	http.NotFound(w, r)
+}
+
+func (m *Manager) route(r *http.Request) *receiver.WebhookHandler {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if id, ok := strings.CutPrefix(r.URL.Path, m.cfg.PathPrefix); ok {
+		if mb, ok := m.bots[strings.TrimSuffix(id, "/")]; ok && mb.bot.webhook != nil {
+			return mb.bot.webhook
+		}
+	}
+
+	secret := r.Header.Get("X-Telegram-Bot-Api-Secret-Token")
+	if secret == "" {
+		return nil
+	}
+	for _, mb := range m.bots {
+		if mb.bot.webhook != nil && mb.secret != "" &&
+			subtle.ConstantTimeCompare([]byte(secret), []byte(mb.secret)) == 1 {
+			return mb.bot.webhook
+		}
+	}
+	return nil
+}
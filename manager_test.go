@@ -0,0 +1,233 @@
+package galigo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+const (
+	managerTokenA = "111111111:ABCdefGHIjklMNOpqrSTUvwxYZ"
+	managerTokenB = "222222222:ABCdefGHIjklMNOpqrSTUvwxYZ"
+)
+
+// countingTransport counts requests per bot token.
+type countingTransport struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	if t.calls == nil {
+		t.calls = make(map[string]int)
+	}
+	t.calls[strings.Split(r.URL.Path, "/")[1]]++
+	t.mu.Unlock()
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func (t *countingTransport) count(token string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.calls["bot"+token]
+}
+
+func newManagerAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var result any = true
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/getUpdates"):
+			time.Sleep(20 * time.Millisecond)
+			result = []any{}
+		case strings.HasSuffix(r.URL.Path, "/sendMessage"):
+			result = map[string]any{"message_id": 1, "date": 0, "chat": map[string]any{"id": 1, "type": "private"}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestManager_SharedTransportAndLifecycle(t *testing.T) {
+	server := newManagerAPI(t)
+	transport := &countingTransport{}
+	var announced atomic.Int32
+	m := NewManager(ManagerConfig{
+		Transport: transport,
+		Options:   []Option{WithBaseURL(server.URL), WithPolling(1, 10)},
+		OnBot:     func(string, *Bot) { announced.Add(1) },
+	})
+	defer m.Close()
+
+	a, err := m.AddBot("a", managerTokenA)
+	require.NoError(t, err)
+	require.NoError(t, m.Start(context.Background()))
+
+	_, err = m.AddBot("b", managerTokenB)
+	require.NoError(t, err, "bots added while running are started")
+	_, err = m.AddBot("b", managerTokenB)
+	assert.ErrorIs(t, err, ErrBotExists)
+
+	_, err = a.SendMessage(context.Background(), tg.ChatID(1), "hi")
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return transport.count(managerTokenA) >= 2 && transport.count(managerTokenB) >= 1
+	}, 2*time.Second, 10*time.Millisecond, "sender and poller use the shared transport")
+	assert.Equal(t, []string{"a", "b"}, m.IDs())
+	assert.Equal(t, int32(2), announced.Load())
+
+	stats := m.Stats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, "a", stats[0].ID)
+	assert.Equal(t, receiver.ModeLongPolling, stats[0].Mode)
+	assert.True(t, stats[0].Healthy)
+	require.NotNil(t, stats[0].Polling)
+	assert.Positive(t, stats[0].Polling.QueueCap)
+
+	require.NoError(t, m.RemoveBot("a"))
+	assert.ErrorIs(t, m.RemoveBot("a"), ErrBotNotFound)
+	_, open := <-a.Updates()
+	assert.False(t, open, "removed polling bot is closed")
+	assert.Equal(t, []string{"b"}, m.IDs())
+}
+
+func TestManager_ReloadToken(t *testing.T) {
+	server := newManagerAPI(t)
+	m := NewManager(ManagerConfig{Options: []Option{WithBaseURL(server.URL), WithPolling(1, 10)}})
+	defer m.Close()
+	require.NoError(t, m.Start(context.Background()))
+
+	old, err := m.AddBot("shop", managerTokenA)
+	require.NoError(t, err)
+
+	bot, err := m.ReloadToken("shop", managerTokenB)
+	require.NoError(t, err)
+	assert.NotSame(t, old, bot)
+	assert.Equal(t, managerTokenB, bot.token.Value())
+
+	got, ok := m.Bot("shop")
+	require.True(t, ok)
+	assert.Same(t, bot, got)
+
+	_, err = m.ReloadToken("missing", managerTokenB)
+	assert.ErrorIs(t, err, ErrBotNotFound)
+	_, err = m.ReloadToken("shop", "not-a-token")
+	assert.Error(t, err)
+	got, _ = m.Bot("shop")
+	assert.Same(t, bot, got, "failed reload keeps the running bot")
+}
+
+func TestManager_ReloadToken_CarriesOffset(t *testing.T) {
+	var mu sync.Mutex
+	served := false
+	offsets := map[string][]string{} // token -> offsets of getUpdates calls
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var result any = []any{}
+		if strings.HasSuffix(r.URL.Path, "/getUpdates") {
+			token := strings.TrimPrefix(strings.Split(r.URL.Path, "/")[1], "bot")
+			mu.Lock()
+			offsets[token] = append(offsets[token], r.URL.Query().Get("offset"))
+			if token == managerTokenA && !served {
+				served = true
+				result = []any{map[string]any{"update_id": 41}}
+			}
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+	}))
+	defer server.Close()
+
+	m := NewManager(ManagerConfig{Options: []Option{WithBaseURL(server.URL), WithPolling(1, 10)}})
+	defer m.Close()
+	require.NoError(t, m.Start(context.Background()))
+	old, err := m.AddBot("shop", managerTokenA)
+	require.NoError(t, err)
+	<-old.Updates()
+
+	const rotatedA = "111111111:ZYXwvuTSRqpoNMLkjiHGFedcBA" // same bot
+	bot, err := m.ReloadToken("shop", rotatedA)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(offsets[rotatedA]) > 0
+	}, 2*time.Second, 5*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, "42", offsets[rotatedA][0], "the replacement continues after update 41")
+	assert.Equal(t, int64(42), bot.receiver.Offset())
+}
+
+func TestManager_WebhookRouting(t *testing.T) {
+	m := NewManager(ManagerConfig{PathPrefix: "/hook/"})
+	defer m.Close()
+	a, err := m.AddBot("a", managerTokenA, WithWebhook(0, "secret-a"))
+	require.NoError(t, err)
+	b, err := m.AddBot("b", managerTokenB, WithWebhook(0, "secret-b"))
+	require.NoError(t, err)
+
+	post := func(path, secret string) int {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"update_id":1}`))
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, post("/hook/a", "secret-a"), "routed by path")
+	assert.Len(t, a.Updates(), 1)
+	assert.Equal(t, http.StatusUnauthorized, post("/hook/a", "secret-b"), "path bot still checks its secret")
+
+	assert.Equal(t, http.StatusOK, post("/other", "secret-b"), "routed by secret")
+	assert.Len(t, b.Updates(), 1)
+
+	assert.Equal(t, http.StatusNotFound, post("/other", "unknown"))
+	assert.Equal(t, http.StatusNotFound, post("/hook/c", ""))
+}
+
+func TestManager_SharedRateLimit(t *testing.T) {
+	server := newManagerAPI(t)
+	m := NewManager(ManagerConfig{
+		GlobalRPS:   10,
+		GlobalBurst: 1,
+		Options:     []Option{WithBaseURL(server.URL)},
+	})
+	defer m.Close()
+	a, err := m.AddBot("a", managerTokenA)
+	require.NoError(t, err)
+	b, err := m.AddBot("b", managerTokenB)
+	require.NoError(t, err)
+
+	start := time.Now()
+	for _, bot := range []*Bot{a, b, a, b} {
+		_, err := bot.SendMessage(context.Background(), tg.ChatID(1), "hi")
+		require.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 250*time.Millisecond, "4 sends at 10 rps across bots")
+}
+
+func TestManager_InvalidID(t *testing.T) {
+	m := NewManager(ManagerConfig{})
+	defer m.Close()
+	_, err := m.AddBot("", managerTokenA)
+	assert.Error(t, err)
+	_, err = m.AddBot("a/b", managerTokenA)
+	assert.Error(t, err)
+}
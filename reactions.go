@@ -0,0 +1,219 @@
+package galigo
+
+import (
+	"cmp"
+	"container/list"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Reaction Watching ==================
+//
+// Telegram reports reactions as message_reaction_count updates, holding
+// the current anonymous totals of a message (channels), and as
+// message_reaction updates, holding one user's change (groups). Neither is
+// kept anywhere the bot can query later. A ReactionWatcher folds both into
+// a running tally per message, e.g. to count votes cast by reaction. Both
+// update types must be requested in allowed_updates, and the bot must be
+// an administrator of the chat.
+
+// ReactionTally is the reaction count of one message.
+type ReactionTally struct {
+	ChatID    int64
+	MessageID int
+	// Reactions holds the count of each reaction, most frequent first.
+	Reactions []tg.ReactionCount
+	// Date is the Unix time of the latest update folded in.
+	Date int64
+}
+
+// Count returns the count of reaction r, or 0.
+func (t ReactionTally) Count(r tg.ReactionType) int {
+	for _, rc := range t.Reactions {
+		if rc.Type == r {
+			return rc.TotalCount
+		}
+	}
+	return 0
+}
+
+// Total returns the sum of all reaction counts.
+func (t ReactionTally) Total() int {
+	var n int
+	for _, rc := range t.Reactions {
+		n += rc.TotalCount
+	}
+	return n
+}
+
+// Top returns the most frequent reaction. Ties go to the reaction seen
+// first. It reports false if the message has no reactions.
+func (t ReactionTally) Top() (tg.ReactionCount, bool) {
+	if len(t.Reactions) == 0 {
+		return tg.ReactionCount{}, false
+	}
+	return t.Reactions[0], true
+}
+
+// ReactionWatcher aggregates reaction updates into per-message tallies.
+// Feed every update to Observe. The zero value is ready to use and safe
+// for concurrent use.
+//
+// Counts from message_reaction_count replace the tally of the message.
+// Changes from message_reaction are added to it, so reactions set before
+// the watcher started are not counted.
+type ReactionWatcher struct {
+	// OnChange, if set, is called with the new tally whenever a message's
+	// reactions change. It runs on the goroutine that called Observe.
+	OnChange func(ctx context.Context, tally ReactionTally)
+	// MaxMessages bounds the number of tracked messages (0 = 10000); the
+	// least recently updated are dropped first.
+	MaxMessages int
+
+	mu      sync.Mutex
+	tallies map[messageKey]*list.Element // value *ReactionTally
+	lru     *list.List                   // front = most recently updated
+}
+
+// Observe folds a message_reaction or message_reaction_count update into
+// the tally of its message and reports whether it did. Counts older than
+// the tally are ignored and reported false, as Telegram may deliver them
+// late.
+func (w *ReactionWatcher) Observe(ctx context.Context, update tg.Update) bool {
+	var tally ReactionTally
+	var changed bool
+	switch {
+	case update.MessageReactionCount != nil && update.MessageReactionCount.Chat != nil:
+		var ok bool
+		if tally, ok = w.setCounts(update.MessageReactionCount); !ok {
+			return false
+		}
+		changed = true
+	case update.MessageReaction != nil && update.MessageReaction.Chat != nil:
+		tally, changed = w.addChange(update.MessageReaction)
+	default:
+		return false
+	}
+	if changed && w.OnChange != nil {
+		w.OnChange(ctx, tally)
+	}
+	return true
+}
+
+// Tally returns the current tally of a message.
+func (w *ReactionWatcher) Tally(chatID int64, messageID int) (ReactionTally, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	elem, ok := w.tallies[messageKey{chatID, messageID}]
+	if !ok {
+		return ReactionTally{}, false
+	}
+	return elem.Value.(*ReactionTally).clone(), true
+}
+
+// Forget stops tracking a message, e.g. when its poll is closed.
+func (w *ReactionWatcher) Forget(chatID int64, messageID int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	key := messageKey{chatID, messageID}
+	if elem, ok := w.tallies[key]; ok {
+		delete(w.tallies, key)
+		w.lru.Remove(elem)
+	}
+}
+
+func (w *ReactionWatcher) setCounts(u *tg.MessageReactionCountUpdated) (ReactionTally, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if elem, ok := w.tallies[messageKey{u.Chat.ID, u.MessageID}]; ok && u.Date < elem.Value.(*ReactionTally).Date {
+		return ReactionTally{}, false
+	}
+	t := w.tally(u.Chat.ID, u.MessageID)
+	t.Date = u.Date
+	t.Reactions = t.Reactions[:0]
+	for _, rc := range u.Reactions {
+		if rc.TotalCount > 0 {
+			t.Reactions = append(t.Reactions, rc)
+		}
+	}
+	t.sort()
+	return t.clone(), true
+}
+
+func (w *ReactionWatcher) addChange(u *tg.MessageReactionUpdated) (ReactionTally, bool) {
+	added, removed := u.Added(), u.Removed()
+	if len(added) == 0 && len(removed) == 0 {
+		return ReactionTally{}, false
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	t := w.tally(u.Chat.ID, u.MessageID)
+	t.Date = max(t.Date, u.Date)
+	for _, r := range added {
+		t.add(r, 1)
+	}
+	for _, r := range removed {
+		t.add(r, -1)
+	}
+	t.sort()
+	return t.clone(), true
+}
+
+// tally returns the tally of a message, creating it if needed, and marks
+// it most recently updated. w.mu must be held.
+func (w *ReactionWatcher) tally(chatID int64, messageID int) *ReactionTally {
+	if w.tallies == nil {
+		w.tallies = make(map[messageKey]*list.Element)
+		w.lru = list.New()
+	}
+	key := messageKey{chatID, messageID}
+	if elem, ok := w.tallies[key]; ok {
+		w.lru.MoveToFront(elem)
+		return elem.Value.(*ReactionTally)
+	}
+
+	limit := w.MaxMessages
+	if limit <= 0 {
+		limit = 10000
+	}
+	for w.lru.Len() >= limit {
+		oldest := w.lru.Back()
+		w.lru.Remove(oldest)
+		old := oldest.Value.(*ReactionTally)
+		delete(w.tallies, messageKey{old.ChatID, old.MessageID})
+	}
+	t := &ReactionTally{ChatID: chatID, MessageID: messageID}
+	w.tallies[key] = w.lru.PushFront(t)
+	return t
+}
+
+// add changes the count of r by delta, dropping it when it reaches 0.
+func (t *ReactionTally) add(r tg.ReactionType, delta int) {
+	i := slices.IndexFunc(t.Reactions, func(rc tg.ReactionCount) bool { return rc.Type == r })
+	if i < 0 {
+		if delta > 0 {
+			t.Reactions = append(t.Reactions, tg.ReactionCount{Type: r, TotalCount: delta})
+		}
+		return
+	}
+	t.Reactions[i].TotalCount += delta
+	if t.Reactions[i].TotalCount <= 0 {
+		t.Reactions = slices.Delete(t.Reactions, i, i+1)
+	}
+}
+
+func (t *ReactionTally) sort() {
+	slices.SortStableFunc(t.Reactions, func(a, b tg.ReactionCount) int {
+		return cmp.Compare(b.TotalCount, a.TotalCount)
+	})
+}
+
+func (t *ReactionTally) clone() ReactionTally {
+	c := *t
+	c.Reactions = slices.Clone(t.Reactions)
+	return c
+}
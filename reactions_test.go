@@ -0,0 +1,152 @@
+package galigo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+var (
+	thumbsUp = tg.EmojiReaction(tg.ReactionThumbsUp)
+	fire     = tg.EmojiReaction(tg.ReactionFire)
+)
+
+func reactionCount(date int64, counts ...tg.ReactionCount) tg.Update {
+	return tg.Update{MessageReactionCount: &tg.MessageReactionCountUpdated{
+		Chat:      &tg.Chat{ID: -1001},
+		MessageID: 5,
+		Date:      date,
+		Reactions: counts,
+	}}
+}
+
+func reactionChange(userID int64, date int64, old, new []tg.ReactionType) tg.Update {
+	return tg.Update{MessageReaction: &tg.MessageReactionUpdated{
+		Chat:        &tg.Chat{ID: -100},
+		MessageID:   7,
+		User:        &tg.User{ID: userID},
+		Date:        date,
+		OldReaction: old,
+		NewReaction: new,
+	}}
+}
+
+func TestReactionWatcher_Counts(t *testing.T) {
+	ctx := context.Background()
+	var calls []ReactionTally
+	w := &ReactionWatcher{OnChange: func(_ context.Context, tally ReactionTally) {
+		calls = append(calls, tally)
+	}}
+
+	assert.True(t, w.Observe(ctx, reactionCount(100,
+		tg.ReactionCount{Type: thumbsUp, TotalCount: 2},
+		tg.ReactionCount{Type: fire, TotalCount: 5},
+	)))
+	tally, ok := w.Tally(-1001, 5)
+	require.True(t, ok)
+	assert.Equal(t, 5, tally.Count(fire))
+	assert.Equal(t, 7, tally.Total())
+	top, ok := tally.Top()
+	require.True(t, ok)
+	assert.Equal(t, fire, top.Type, "most frequent first")
+
+	// Counts replace the tally; late ones are ignored.
+	w.Observe(ctx, reactionCount(200, tg.ReactionCount{Type: thumbsUp, TotalCount: 3}))
+	assert.False(t, w.Observe(ctx, reactionCount(150, tg.ReactionCount{Type: fire, TotalCount: 9})), "stale counts are reported")
+	tally, _ = w.Tally(-1001, 5)
+	assert.Equal(t, []tg.ReactionCount{{Type: thumbsUp, TotalCount: 3}}, tally.Reactions)
+	assert.Equal(t, int64(200), tally.Date)
+
+	require.Len(t, calls, 2)
+	assert.Equal(t, 3, calls[1].Count(thumbsUp))
+}
+
+func TestReactionWatcher_UserChanges(t *testing.T) {
+	ctx := context.Background()
+	w := &ReactionWatcher{}
+
+	w.Observe(ctx, reactionChange(1, 100, nil, []tg.ReactionType{thumbsUp}))
+	w.Observe(ctx, reactionChange(2, 101, nil, []tg.ReactionType{thumbsUp}))
+	w.Observe(ctx, reactionChange(3, 102, nil, []tg.ReactionType{fire}))
+	w.Observe(ctx, reactionChange(1, 103, []tg.ReactionType{thumbsUp}, []tg.ReactionType{fire}))
+	w.Observe(ctx, reactionChange(2, 104, []tg.ReactionType{thumbsUp}, nil))
+
+	tally, ok := w.Tally(-100, 7)
+	require.True(t, ok)
+	assert.Equal(t, []tg.ReactionCount{{Type: fire, TotalCount: 2}}, tally.Reactions, "reactions at 0 are dropped")
+	assert.Equal(t, int64(104), tally.Date)
+}
+
+func TestReactionWatcher_TopTieKeepsFirstSeen(t *testing.T) {
+	ctx := context.Background()
+	w := &ReactionWatcher{}
+	w.Observe(ctx, reactionChange(1, 100, nil, []tg.ReactionType{fire}))
+	w.Observe(ctx, reactionChange(2, 101, nil, []tg.ReactionType{thumbsUp}))
+
+	tally, _ := w.Tally(-100, 7)
+	top, _ := tally.Top()
+	assert.Equal(t, fire, top.Type)
+}
+
+func TestReactionWatcher_IgnoresOtherUpdates(t *testing.T) {
+	called := false
+	w := &ReactionWatcher{OnChange: func(context.Context, ReactionTally) { called = true }}
+
+	assert.False(t, w.Observe(context.Background(), tg.Update{Message: textMessage(1, 10, "hi")}))
+	assert.True(t, w.Observe(context.Background(), reactionChange(1, 100, nil, nil)), "no-op change")
+	assert.False(t, called)
+	_, ok := w.Tally(-100, 7)
+	assert.False(t, ok)
+}
+
+func TestReactionWatcher_TallyIsCopy(t *testing.T) {
+	ctx := context.Background()
+	w := &ReactionWatcher{}
+	w.Observe(ctx, reactionCount(100, tg.ReactionCount{Type: fire, TotalCount: 1}))
+
+	tally, _ := w.Tally(-1001, 5)
+	tally.Reactions[0].TotalCount = 99
+
+	again, _ := w.Tally(-1001, 5)
+	assert.Equal(t, 1, again.Count(fire))
+}
+
+func TestReactionWatcher_MaxMessagesAndForget(t *testing.T) {
+	ctx := context.Background()
+	w := &ReactionWatcher{MaxMessages: 2}
+	for id := 1; id <= 3; id++ {
+		w.Observe(ctx, tg.Update{MessageReactionCount: &tg.MessageReactionCountUpdated{
+			Chat:      &tg.Chat{ID: 1},
+			MessageID: id,
+			Reactions: []tg.ReactionCount{{Type: fire, TotalCount: id}},
+		}})
+	}
+
+	_, ok := w.Tally(1, 1)
+	assert.False(t, ok, "least recently updated dropped")
+	_, ok = w.Tally(1, 3)
+	assert.True(t, ok)
+
+	// Updating message 2 makes message 3 the least recently updated.
+	w.Observe(ctx, tg.Update{MessageReactionCount: &tg.MessageReactionCountUpdated{
+		Chat: &tg.Chat{ID: 1}, MessageID: 2, Date: 1,
+	}})
+	w.Observe(ctx, tg.Update{MessageReactionCount: &tg.MessageReactionCountUpdated{
+		Chat: &tg.Chat{ID: 1}, MessageID: 4,
+	}})
+	_, ok = w.Tally(1, 3)
+	assert.False(t, ok)
+	_, ok = w.Tally(1, 2)
+	assert.True(t, ok)
+	w.Observe(ctx, tg.Update{MessageReactionCount: &tg.MessageReactionCountUpdated{
+		Chat: &tg.Chat{ID: 1}, MessageID: 3,
+	}})
+
+	w.Forget(1, 3)
+	_, ok = w.Tally(1, 3)
+	assert.False(t, ok)
+}
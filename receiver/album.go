@@ -0,0 +1,201 @@
+package receiver
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/prilive-com/galigo/clock"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// maxAlbumSize is Telegram's limit on media group parts.
+const maxAlbumSize = 10
+
+// DefaultAlbumWindow is how long CollectAlbums waits for further parts of
+// a media group when no window is given.
+const DefaultAlbumWindow = 500 * time.Millisecond
+
+// CollectAlbums merges the parts of incoming albums into single updates.
+// Messages, channel posts and business messages that share a media group
+// are held until no further part arrived for window (or all 10 possible
+// parts arrived) and then emitted as one update: Album lists every part,
+// and Message (or ChannelPost / BusinessMessage) is the first part, so
+// code unaware of albums still sees one message per album. Other updates
+// pass through unchanged.
+//
+// The returned channel is closed after in is closed and pending albums are
+// flushed. Cancelling ctx stops collection without closing it.
+func CollectAlbums(ctx context.Context, in <-chan tg.Update, window time.Duration, opts ...AlbumOption) <-chan tg.Update {
+	if window <= 0 {
+		window = DefaultAlbumWindow
+	}
+	out := make(chan tg.Update, cap(in))
+	c := &albumCollector{
+		out:     out,
+		window:  window,
+		clock:   clock.Real,
+		pending: make(map[string]*pendingAlbum),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	go c.run(ctx, in)
+	return out
+}
+
+// AlbumOption configures CollectAlbums.
+type AlbumOption func(*albumCollector)
+
+// WithAlbumClock sets the clock that times the album window. Tests pass a
+// fake clock to flush albums without waiting.
+func WithAlbumClock(clk clock.Clock) AlbumOption {
+	return func(c *albumCollector) {
+		c.clock = clk
+	}
+}
+
+type albumCollector struct {
+	out     chan tg.Update
+	window  time.Duration
+	clock   clock.Clock
+	pending map[string]*pendingAlbum
+	order   []string // pending keys in arrival order
+}
+
+type pendingAlbum struct {
+	update   tg.Update // first part, Album filled on flush
+	parts    []*tg.Message
+	deadline time.Time
+}
+
+func (c *albumCollector) run(ctx context.Context, in <-chan tg.Update) {
+	for {
+		if !c.step(ctx, in) {
+			return
+		}
+	}
+}
+
+// step handles the next update or album deadline. It reports false once
+// collection is over.
+func (c *albumCollector) step(ctx context.Context, in <-chan tg.Update) bool {
+	var timeout <-chan time.Time
+	if next, ok := c.nextDeadline(); ok {
+		timer := c.clock.NewTimer(next.Sub(c.clock.Now()))
+		defer timer.Stop()
+		timeout = timer.C()
+	}
+
+	select {
+	case update, ok := <-in:
+		if !ok {
+			c.flush(ctx, time.Time{})
+			close(c.out)
+			return false
+		}
+		if !c.add(ctx, update) {
+			return c.emit(ctx, update)
+		}
+		return true
+	case now := <-timeout:
+		return c.flush(ctx, now)
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// add buffers update if it is an album part. It reports whether it did.
+func (c *albumCollector) add(ctx context.Context, update tg.Update) bool {
+	msg, kind := albumPart(update)
+	if msg == nil {
+		return false
+	}
+	key := kind + ":" + strconv.FormatInt(msg.Chat.ID, 10) + ":" + msg.MediaGroupID
+
+	p, ok := c.pending[key]
+	if !ok {
+		p = &pendingAlbum{update: update}
+		c.pending[key] = p
+		c.order = append(c.order, key)
+	}
+	p.parts = append(p.parts, msg)
+	p.deadline = c.clock.Now().Add(c.window)
+	if len(p.parts) >= maxAlbumSize {
+		c.emitAlbum(ctx, key)
+	}
+	return true
+}
+
+// albumPart returns the media group message carried by update, if any.
+func albumPart(u tg.Update) (*tg.Message, string) {
+	var msg *tg.Message
+	var kind string
+	switch {
+	case u.Message != nil:
+		msg, kind = u.Message, tg.UpdateTypeMessage
+	case u.ChannelPost != nil:
+		msg, kind = u.ChannelPost, tg.UpdateTypeChannelPost
+	case u.BusinessMessage != nil:
+		msg, kind = u.BusinessMessage, tg.UpdateTypeBusinessMessage
+	default:
+		return nil, ""
+	}
+	if msg.MediaGroupID == "" || msg.Chat == nil {
+		return nil, ""
+	}
+	return msg, kind
+}
+
+func (c *albumCollector) nextDeadline() (time.Time, bool) {
+	var next time.Time
+	for _, p := range c.pending {
+		if next.IsZero() || p.deadline.Before(next) {
+			next = p.deadline
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// flush emits albums whose deadline passed by now, or all of them when now
+// is zero. It reports false if ctx was cancelled.
+func (c *albumCollector) flush(ctx context.Context, now time.Time) bool {
+	for _, key := range slices.Clone(c.order) {
+		if p := c.pending[key]; now.IsZero() || !p.deadline.After(now) {
+			if !c.emitAlbum(ctx, key) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (c *albumCollector) emitAlbum(ctx context.Context, key string) bool {
+	p := c.pending[key]
+	delete(c.pending, key)
+	c.order = slices.DeleteFunc(c.order, func(k string) bool { return k == key })
+
+	slices.SortFunc(p.parts, func(a, b *tg.Message) int { return cmp.Compare(a.MessageID, b.MessageID) })
+	update := p.update
+	update.Album = &tg.Album{MediaGroupID: p.parts[0].MediaGroupID, Messages: p.parts}
+	switch {
+	case update.Message != nil:
+		update.Message = p.parts[0]
+	case update.ChannelPost != nil:
+		update.ChannelPost = p.parts[0]
+	case update.BusinessMessage != nil:
+		update.BusinessMessage = p.parts[0]
+	}
+	return c.emit(ctx, update)
+}
+
+func (c *albumCollector) emit(ctx context.Context, update tg.Update) bool {
+	select {
+	case c.out <- update:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
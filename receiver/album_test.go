@@ -0,0 +1,124 @@
+package receiver_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/galigotest"
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func albumPart(updateID, messageID int, group, caption string) tg.Update {
+	return tg.Update{UpdateID: updateID, Message: &tg.Message{
+		MessageID:    messageID,
+		Chat:         &tg.Chat{ID: 42, Type: "private"},
+		MediaGroupID: group,
+		Caption:      caption,
+		Photo:        []tg.PhotoSize{{FileID: "small"}, {FileID: "large-" + caption}},
+	}}
+}
+
+func receive(t *testing.T, ch <-chan tg.Update) tg.Update {
+	t.Helper()
+	select {
+	case u, ok := <-ch:
+		require.True(t, ok, "channel closed")
+		return u
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for update")
+		return tg.Update{}
+	}
+}
+
+func TestCollectAlbums_MergesParts(t *testing.T) {
+	in := make(chan tg.Update, 10)
+	out := receiver.CollectAlbums(context.Background(), in, 50*time.Millisecond)
+
+	in <- albumPart(1, 11, "g1", "")
+	in <- albumPart(2, 10, "g1", "holiday")
+	in <- tg.Update{UpdateID: 3, Message: &tg.Message{MessageID: 12, Chat: &tg.Chat{ID: 42}, Text: "hi"}}
+
+	plain := receive(t, out)
+	assert.Equal(t, "hi", plain.Message.Text, "non-album updates are not delayed")
+	assert.Nil(t, plain.Album)
+
+	album := receive(t, out)
+	require.NotNil(t, album.Album)
+	assert.Equal(t, "g1", album.Album.MediaGroupID)
+	require.Len(t, album.Album.Messages, 2)
+	assert.Equal(t, 10, album.Album.Messages[0].MessageID, "parts sorted by message_id")
+	assert.Equal(t, 10, album.Message.MessageID)
+	assert.Equal(t, "holiday", album.Album.Caption())
+	assert.Equal(t, int64(42), album.Album.Chat().ID)
+	assert.Len(t, album.Album.Photos(), 2)
+}
+
+func TestCollectAlbums_FullAlbumEmittedImmediately(t *testing.T) {
+	in := make(chan tg.Update, 10)
+	out := receiver.CollectAlbums(context.Background(), in, time.Hour)
+
+	for i := range 10 {
+		in <- albumPart(i, i, "g1", "")
+	}
+
+	album := receive(t, out)
+	assert.Len(t, album.Album.Messages, 10)
+}
+
+func TestCollectAlbums_FlushesOnClose(t *testing.T) {
+	in := make(chan tg.Update, 10)
+	out := receiver.CollectAlbums(context.Background(), in, time.Hour)
+
+	in <- albumPart(1, 1, "g1", "")
+	in <- albumPart(2, 2, "g2", "")
+	close(in)
+
+	first := receive(t, out)
+	second := receive(t, out)
+	assert.Equal(t, "g1", first.Album.MediaGroupID)
+	assert.Equal(t, "g2", second.Album.MediaGroupID)
+
+	_, ok := <-out
+	assert.False(t, ok)
+}
+
+func TestCollectAlbums_SeparatesChats(t *testing.T) {
+	in := make(chan tg.Update, 10)
+	out := receiver.CollectAlbums(context.Background(), in, 20*time.Millisecond)
+
+	a := albumPart(1, 1, "g1", "")
+	b := albumPart(2, 1, "g1", "")
+	b.Message.Chat = &tg.Chat{ID: 43}
+	in <- a
+	in <- b
+
+	first, second := receive(t, out), receive(t, out)
+	assert.Len(t, first.Album.Messages, 1)
+	assert.Len(t, second.Album.Messages, 1)
+}
+
+func TestCollectAlbums_FakeClock(t *testing.T) {
+	clk := galigotest.NewFakeClock(time.Now())
+	in := make(chan tg.Update, 10)
+	out := receiver.CollectAlbums(context.Background(), in, time.Hour, receiver.WithAlbumClock(clk))
+
+	in <- albumPart(1, 10, "g1", "")
+	clk.BlockUntil(1) // the collector waits for further parts
+	select {
+	case <-out:
+		t.Fatal("album emitted before its window")
+	default:
+	}
+
+	clk.Advance(time.Hour)
+	album := receive(t, out)
+	require.NotNil(t, album.Album)
+	assert.Len(t, album.Album.Messages, 1)
+	require.Eventually(t, func() bool { return clk.Waiters() == 0 }, time.Second, time.Millisecond,
+		"no timer left pending")
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/prilive-com/galigo/internal/scrub"
+	"github.com/prilive-com/galigo/internal/version"
 	"github.com/prilive-com/galigo/tg"
 )
 
@@ -55,6 +56,12 @@ type apiResponse struct {
 
 // SetWebhook registers a webhook URL with Telegram.
 func SetWebhook(ctx context.Context, client *http.Client, token tg.SecretToken, url, secret string) error {
+	return setWebhook(ctx, client, telegramAPIBaseURL, token, url, secret, "", nil)
+}
+
+// setWebhook is SetWebhook against baseURL (ending in "/bot") with the
+// caller's outgoing headers.
+func setWebhook(ctx context.Context, client *http.Client, baseURL string, token tg.SecretToken, url, secret, userAgent string, extraHeaders map[string]string) error {
 	if client == nil {
 		client = defaultAPIClient
 	}
@@ -71,12 +78,13 @@ func SetWebhook(ctx context.Context, client *http.Client, token tg.SecretToken,
 		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	apiURL := fmt.Sprintf("%s%s/setWebhook", telegramAPIBaseURL, token.Value())
+	apiURL := fmt.Sprintf("%s%s/setWebhook", baseURL, token.Value())
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", scrub.TokenFromError(err, token))
 	}
 	req.Header.Set("Content-Type", "application/json")
+	version.SetHeaders(req, userAgent, extraHeaders)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -104,17 +112,24 @@ func SetWebhook(ctx context.Context, client *http.Client, token tg.SecretToken,
 
 // DeleteWebhook removes the webhook from Telegram.
 func DeleteWebhook(ctx context.Context, client *http.Client, token tg.SecretToken, dropPending bool) error {
+	return deleteWebhook(ctx, client, telegramAPIBaseURL, token, dropPending, "", nil)
+}
+
+// deleteWebhook is DeleteWebhook against baseURL (ending in "/bot") with the
+// caller's outgoing headers.
+func deleteWebhook(ctx context.Context, client *http.Client, baseURL string, token tg.SecretToken, dropPending bool, userAgent string, extraHeaders map[string]string) error {
 	if client == nil {
 		client = defaultAPIClient
 	}
 
 	apiURL := fmt.Sprintf("%s%s/deleteWebhook?drop_pending_updates=%t",
-		telegramAPIBaseURL, token.Value(), dropPending)
+		baseURL, token.Value(), dropPending)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", scrub.TokenFromError(err, token))
 	}
+	version.SetHeaders(req, userAgent, extraHeaders)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -142,16 +157,23 @@ func DeleteWebhook(ctx context.Context, client *http.Client, token tg.SecretToke
 
 // GetWebhookInfo retrieves the current webhook configuration.
 func GetWebhookInfo(ctx context.Context, client *http.Client, token tg.SecretToken) (*WebhookInfo, error) {
+	return getWebhookInfo(ctx, client, telegramAPIBaseURL, token, "", nil)
+}
+
+// getWebhookInfo is GetWebhookInfo against baseURL (ending in "/bot") with
+// the caller's outgoing headers.
+func getWebhookInfo(ctx context.Context, client *http.Client, baseURL string, token tg.SecretToken, userAgent string, extraHeaders map[string]string) (*WebhookInfo, error) {
 	if client == nil {
 		client = defaultAPIClient
 	}
 
-	apiURL := fmt.Sprintf("%s%s/getWebhookInfo", telegramAPIBaseURL, token.Value())
+	apiURL := fmt.Sprintf("%s%s/getWebhookInfo", baseURL, token.Value())
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", scrub.TokenFromError(err, token))
 	}
+	version.SetHeaders(req, userAgent, extraHeaders)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -181,3 +203,81 @@ func GetWebhookInfo(ctx context.Context, client *http.Client, token tg.SecretTok
 
 	return &info, nil
 }
+
+// confirmUpdates calls getUpdates with offset and no timeout, which marks
+// every update below offset as processed at Telegram. The result is
+// ignored: any update it returns stays unconfirmed.
+func confirmUpdates(ctx context.Context, client *http.Client, baseURL string, token tg.SecretToken, offset int64, userAgent string, extraHeaders map[string]string) error {
+	if client == nil {
+		client = defaultAPIClient
+	}
+
+	apiURL := fmt.Sprintf("%s%s/getUpdates?offset=%d&timeout=0&limit=1", baseURL, token.Value(), offset)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", scrub.TokenFromError(err, token))
+	}
+	version.SetHeaders(req, userAgent, extraHeaders)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", scrub.TokenFromError(err, token))
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if !result.OK {
+		return &APIError{
+			Code:        result.ErrorCode,
+			Description: result.Description,
+		}
+	}
+
+	return nil
+}
+
+// getMe calls getMe against baseURL (ending in "/bot") to check token.
+func getMe(ctx context.Context, client *http.Client, baseURL string, token tg.SecretToken, userAgent string, extraHeaders map[string]string) error {
+	if client == nil {
+		client = defaultAPIClient
+	}
+
+	apiURL := fmt.Sprintf("%s%s/getMe", baseURL, token.Value())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", scrub.TokenFromError(err, token))
+	}
+	version.SetHeaders(req, userAgent, extraHeaders)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", scrub.TokenFromError(err, token))
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	var result apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if !result.OK {
+		return &APIError{
+			Code:        result.ErrorCode,
+			Description: result.Description,
+		}
+	}
+
+	return nil
+}
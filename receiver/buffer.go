@@ -0,0 +1,28 @@
+package receiver
+
+import (
+	"bytes"
+	"sync"
+)
+
+// bodyBufferPool holds buffers for getUpdates responses and webhook
+// request bodies, shared by all clients and handlers.
+var bodyBufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// maxPooledBodyBuffer caps the buffers kept in bodyBufferPool, so a rare
+// huge body does not stay allocated.
+const maxPooledBodyBuffer = 4 << 20
+
+func getBodyBuffer() *bytes.Buffer {
+	buf := bodyBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBodyBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBodyBuffer {
+		bodyBufferPool.Put(buf)
+	}
+}
@@ -0,0 +1,50 @@
+package receiver
+
+import (
+	"context"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// IsForeignCommand reports whether update is a message whose command
+// mentions a bot other than botUsername, e.g. "/start@otherbot" in a group
+// with several bots. Commands without a mention are for every bot and are
+// not foreign; an empty botUsername matches nothing.
+func IsForeignCommand(update tg.Update, botUsername string) bool {
+	if botUsername == "" || update.CallbackQuery != nil {
+		return false // a callback's message is the bot's own
+	}
+	cmd, ok := tg.ParseCommand(update.EffectiveMessage())
+	return ok && !cmd.IsFor(botUsername)
+}
+
+// DropForeignCommands passes the updates of in through, except those for
+// which IsForeignCommand reports true.
+//
+// The returned channel is closed after in is closed. Cancelling ctx stops
+// filtering without closing it.
+func DropForeignCommands(ctx context.Context, in <-chan tg.Update, botUsername string) <-chan tg.Update {
+	out := make(chan tg.Update, cap(in))
+	go func() {
+		for {
+			select {
+			case update, ok := <-in:
+				if !ok {
+					close(out)
+					return
+				}
+				if IsForeignCommand(update, botUsername) {
+					continue
+				}
+				select {
+				case out <- update:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
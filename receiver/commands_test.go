@@ -0,0 +1,61 @@
+package receiver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func groupText(updateID int, text string) tg.Update {
+	return tg.Update{UpdateID: updateID, Message: &tg.Message{
+		MessageID: updateID,
+		Chat:      &tg.Chat{ID: -100123, Type: "supergroup"},
+		Text:      text,
+	}}
+}
+
+func TestIsForeignCommand(t *testing.T) {
+	tests := []struct {
+		name   string
+		update tg.Update
+		want   bool
+	}{
+		{"other bot", groupText(1, "/start@otherbot"), true},
+		{"this bot", groupText(2, "/start@MyBot hello"), false},
+		{"this bot any case", groupText(3, "/start@mybot"), false},
+		{"no mention", groupText(4, "/help"), false},
+		{"not a command", groupText(5, "hello @otherbot"), false},
+		{"edited", tg.Update{UpdateID: 6, EditedMessage: &tg.Message{Text: "/ban@otherbot 42"}}, true},
+		{"caption", tg.Update{UpdateID: 7, ChannelPost: &tg.Message{Caption: "/post@otherbot"}}, true},
+		{"callback", tg.Update{UpdateID: 8, CallbackQuery: &tg.CallbackQuery{ID: "q"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, receiver.IsForeignCommand(tt.update, "MyBot"))
+		})
+	}
+
+	assert.False(t, receiver.IsForeignCommand(groupText(9, "/start@otherbot"), ""), "unknown username keeps everything")
+	assert.False(t, receiver.IsForeignCommand(groupText(10, "/start@MyBot"), "@MyBot"))
+}
+
+func TestDropForeignCommands(t *testing.T) {
+	in := make(chan tg.Update, 10)
+	out := receiver.DropForeignCommands(context.Background(), in, "MyBot")
+
+	in <- groupText(1, "/start@otherbot")
+	in <- groupText(2, "/start@MyBot")
+	in <- groupText(3, "/stats@otherbot")
+	in <- groupText(4, "/help")
+	close(in)
+
+	assert.Equal(t, 2, receive(t, out).UpdateID)
+	assert.Equal(t, 4, receive(t, out).UpdateID)
+	_, ok := <-out
+	require.False(t, ok, "closed after in")
+}
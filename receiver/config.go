@@ -58,6 +58,7 @@ type Config struct {
 	PollingLimit       int           // Max updates per request (1-100)
 	PollingMaxErrors   int           // Max consecutive errors (0 = unlimited)
 	DeleteWebhookFirst bool          // Delete webhook before starting
+	PollingPOST        bool          // Send getUpdates as POST with a JSON body
 	AllowedUpdates     []string      // Filter update types
 	RetryInitialDelay  time.Duration // Initial retry delay
 	RetryMaxDelay      time.Duration // Maximum retry delay
@@ -68,6 +69,12 @@ type Config struct {
 	RateLimitRequests float64 // Requests per second
 	RateLimitBurst    int     // Burst size
 	MaxBodySize       int64   // Max webhook body size
+	KeepRawUpdates    bool    // Retain each update's JSON (tg.Update.Raw)
+
+	// Webhook self-protection
+	MaxConcurrentRequests int     // Max webhook requests processed at once (0 = unlimited)
+	PerIPRateLimit        float64 // Webhook requests per second per client IP (0 = off)
+	PerIPBurst            int     // Burst per client IP
 
 	// Update delivery policy (for long polling)
 	UpdateDeliveryPolicy  UpdateDeliveryPolicy // Behavior when update channel is full
@@ -173,6 +180,7 @@ func LoadConfig() (*Config, error) {
 	}
 
 	cfg.DeleteWebhookFirst = strings.ToLower(getEnv("POLLING_DELETE_WEBHOOK", "false")) == "true"
+	cfg.PollingPOST = strings.ToLower(getEnv("POLLING_USE_POST", "false")) == "true"
 
 	// Allowed updates
 	if updates := getEnv("ALLOWED_UPDATES", ""); updates != "" {
@@ -207,6 +215,16 @@ func LoadConfig() (*Config, error) {
 		cfg.MaxBodySize = i
 	}
 
+	if i, err := strconv.Atoi(getEnv("WEBHOOK_MAX_CONCURRENT", "0")); err == nil {
+		cfg.MaxConcurrentRequests = i
+	}
+	if f, err := strconv.ParseFloat(getEnv("WEBHOOK_PER_IP_RPS", "0"), 64); err == nil {
+		cfg.PerIPRateLimit = f
+	}
+	if i, err := strconv.Atoi(getEnv("WEBHOOK_PER_IP_BURST", "0")); err == nil {
+		cfg.PerIPBurst = i
+	}
+
 	// Update delivery policy
 	policyStr := strings.ToLower(getEnv("UPDATE_DELIVERY_POLICY", "block"))
 	switch policyStr {
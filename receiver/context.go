@@ -0,0 +1,93 @@
+package receiver
+
+import (
+	"context"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ContextDecorator enriches the context derived for a single update,
+// e.g. to attach a trace span, a request-scoped logger or a tenant ID.
+// It must return a context derived from ctx.
+type ContextDecorator func(ctx context.Context, update tg.Update) context.Context
+
+// Per-update context derivation chain:
+//
+//	base context                       polling: the ctx passed to Start
+//	                                   webhook: the ctx passed to SetBaseContext
+//	                                   (galigo's Bot.Start calls it), else
+//	                                   WithWebhookBaseContext, else Background
+//	  └─ ContextDecorator(base, update) optional, see WithContextDecorator
+//	       └─ handler context           returned by UpdateContext
+//
+// Values set on the base context (loggers, trace providers, tenant IDs) are
+// therefore visible to every handler, and cancelling the base context
+// cancels all per-update contexts. Webhook request contexts are never part
+// of the chain: they end as soon as Telegram's request is answered.
+
+// deriveUpdateContext applies the decorator to base.
+func deriveUpdateContext(base context.Context, decorate ContextDecorator, update tg.Update) context.Context {
+	if base == nil {
+		base = context.Background()
+	}
+	if decorate == nil {
+		return base
+	}
+	if ctx := decorate(base, update); ctx != nil {
+		return ctx
+	}
+	return base
+}
+
+// WithContextDecorator sets a hook that enriches per-update contexts
+// returned by PollingClient.UpdateContext.
+func WithContextDecorator(fn ContextDecorator) PollingOption {
+	return func(c *PollingClient) {
+		c.decorate = fn
+	}
+}
+
+// UpdateContext returns the context a handler should use for update.
+// It inherits all values (and cancellation) from the context passed to
+// Start, enriched by the ContextDecorator if one is configured.
+// Before Start is called the base is context.Background().
+func (c *PollingClient) UpdateContext(update tg.Update) context.Context {
+	c.mu.Lock()
+	base := c.baseCtx
+	c.mu.Unlock()
+	return deriveUpdateContext(base, c.decorate, update)
+}
+
+// WithWebhookBaseContext sets the base context for per-update contexts.
+// Webhook request contexts end when Telegram's request completes, so they
+// are not used as the base.
+func WithWebhookBaseContext(ctx context.Context) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.SetBaseContext(ctx)
+	}
+}
+
+// SetBaseContext replaces the base context for per-update contexts. It is
+// safe to call while the handler is serving requests; updates already
+// handed out keep the context they were derived from.
+func (h *WebhookHandler) SetBaseContext(ctx context.Context) {
+	h.baseCtx.Store(&ctx)
+}
+
+// WithWebhookContextDecorator sets a hook that enriches per-update contexts
+// returned by WebhookHandler.UpdateContext.
+func WithWebhookContextDecorator(fn ContextDecorator) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.decorate = fn
+	}
+}
+
+// UpdateContext returns the context a handler should use for update.
+// See SetBaseContext and WithWebhookBaseContext for the base context.
+func (h *WebhookHandler) UpdateContext(update tg.Update) context.Context {
+	var base context.Context
+	if p := h.baseCtx.Load(); p != nil {
+		base = *p
+	}
+	return deriveUpdateContext(base, h.decorate, update)
+}
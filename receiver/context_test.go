@@ -0,0 +1,98 @@
+package receiver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+type ctxKey string
+
+func TestPolling_UpdateContext_InheritsStartContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": []any{}})
+	}))
+	defer server.Close()
+
+	cfg := pollingTestConfig()
+	cfg.BaseURL = server.URL + "/bot"
+
+	client := receiver.NewPollingClient(
+		tg.SecretToken("test:token"),
+		make(chan tg.Update, 10),
+		pollingTestLogger(),
+		cfg,
+		receiver.WithContextDecorator(func(ctx context.Context, u tg.Update) context.Context {
+			return context.WithValue(ctx, ctxKey("update_id"), u.UpdateID)
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey("tenant"), "acme"))
+	require.NoError(t, client.Start(ctx))
+	defer client.Stop()
+
+	uctx := client.UpdateContext(tg.Update{UpdateID: 42})
+	assert.Equal(t, "acme", uctx.Value(ctxKey("tenant")))
+	assert.Equal(t, 42, uctx.Value(ctxKey("update_id")))
+
+	cancel()
+	<-uctx.Done()
+	assert.ErrorIs(t, uctx.Err(), context.Canceled)
+}
+
+func TestPolling_UpdateContext_BeforeStart(t *testing.T) {
+	client := receiver.NewPollingClient(
+		tg.SecretToken("test:token"),
+		make(chan tg.Update, 10),
+		pollingTestLogger(),
+		pollingTestConfig(),
+	)
+
+	uctx := client.UpdateContext(tg.Update{UpdateID: 1})
+	require.NotNil(t, uctx)
+	assert.NoError(t, uctx.Err())
+}
+
+func TestWebhook_UpdateContext_BaseAndDecorator(t *testing.T) {
+	base := context.WithValue(context.Background(), ctxKey("tenant"), "acme")
+	handler := receiver.NewWebhookHandler(testLogger(), make(chan tg.Update, 10), testConfig(),
+		receiver.WithWebhookBaseContext(base),
+		receiver.WithWebhookContextDecorator(func(ctx context.Context, u tg.Update) context.Context {
+			return context.WithValue(ctx, ctxKey("update_id"), u.UpdateID)
+		}),
+	)
+
+	uctx := handler.UpdateContext(tg.Update{UpdateID: 7})
+	assert.Equal(t, "acme", uctx.Value(ctxKey("tenant")))
+	assert.Equal(t, 7, uctx.Value(ctxKey("update_id")))
+}
+
+func TestWebhook_UpdateContext_NilDecoratorResultFallsBack(t *testing.T) {
+	handler := receiver.NewWebhookHandler(testLogger(), make(chan tg.Update, 10), testConfig(),
+		receiver.WithWebhookContextDecorator(func(context.Context, tg.Update) context.Context { return nil }),
+	)
+
+	assert.NotNil(t, handler.UpdateContext(tg.Update{UpdateID: 1}))
+}
+
+func TestWebhook_SetBaseContext(t *testing.T) {
+	handler := receiver.NewWebhookHandler(testLogger(), make(chan tg.Update, 10), testConfig())
+	require.NoError(t, handler.UpdateContext(tg.Update{UpdateID: 1}).Err())
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey("tenant"), "acme"))
+	handler.SetBaseContext(ctx)
+
+	uctx := handler.UpdateContext(tg.Update{UpdateID: 2})
+	assert.Equal(t, "acme", uctx.Value(ctxKey("tenant")))
+
+	cancel()
+	assert.ErrorIs(t, uctx.Err(), context.Canceled)
+}
@@ -0,0 +1,258 @@
+package receiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/prilive-com/galigo/clock"
+)
+
+// ================== Webhook Failover ==================
+//
+// A Failover serves updates through a webhook and falls back to long
+// polling when the webhook stops delivering: it deletes the webhook, starts
+// the PollingClient, and restores the webhook once its endpoint is
+// reachable again.
+
+// FailoverState is the delivery path a Failover is using.
+type FailoverState int32
+
+const (
+	// FailoverWebhook means Telegram pushes updates to the webhook.
+	FailoverWebhook FailoverState = iota
+	// FailoverPolling means the webhook is deleted and updates are polled.
+	FailoverPolling
+)
+
+// String returns "webhook" or "polling".
+func (s FailoverState) String() string {
+	if s == FailoverPolling {
+		return "polling"
+	}
+	return "webhook"
+}
+
+// FailoverConfig configures a Failover.
+type FailoverConfig struct {
+	// WebhookURL is the public URL registered with setWebhook (required).
+	WebhookURL string
+	// WebhookSecret is sent as secret_token when the webhook is restored.
+	WebhookSecret string
+
+	// SilenceTimeout is how long the webhook may go without updates before
+	// its health is checked with getWebhookInfo (default 5m). A silent
+	// webhook fails over only if Telegram reports pending updates or a
+	// delivery error since the last update, so idle bots stay on webhook.
+	SilenceTimeout time.Duration
+	// CheckInterval is how often health is checked in either state
+	// (default 1m).
+	CheckInterval time.Duration
+
+	// Probe reports whether the webhook endpoint is reachable again while
+	// polling. The default sends a GET to WebhookURL and accepts any
+	// response below 500 (the handler rejects GET, which proves it is up).
+	Probe func(ctx context.Context) error
+
+	// OnStateChange is called after every transition with the reason.
+	OnStateChange func(from, to FailoverState, reason string)
+
+	Logger *slog.Logger
+
+	// Clock times the checks (default: the PollingClient's clock, set
+	// with WithPollingClock).
+	Clock clock.Clock
+}
+
+func (c FailoverConfig) withDefaults() FailoverConfig {
+	if c.SilenceTimeout <= 0 {
+		c.SilenceTimeout = 5 * time.Minute
+	}
+	if c.CheckInterval <= 0 {
+		c.CheckInterval = time.Minute
+	}
+	if c.Logger == nil {
+		c.Logger = slog.Default()
+	}
+	return c
+}
+
+// Failover switches between a WebhookHandler and a PollingClient. Both
+// must deliver to the same updates channel; the PollingClient must not be
+// started by the caller. Serve the WebhookHandler as usual and call Run.
+type Failover struct {
+	webhook *WebhookHandler
+	poller  *PollingClient
+	cfg     FailoverConfig
+
+	state       atomic.Int32
+	transitions atomic.Int64
+	since       time.Time // start of the current webhook period; Run goroutine only
+}
+
+// NewFailover returns a Failover in webhook state.
+func NewFailover(webhook *WebhookHandler, poller *PollingClient, cfg FailoverConfig) *Failover {
+	cfg = cfg.withDefaults()
+	if cfg.Clock == nil {
+		cfg.Clock = clock.Real
+		if poller != nil {
+			cfg.Clock = poller.clock
+		}
+	}
+	return &Failover{
+		webhook: webhook,
+		poller:  poller,
+		cfg:     cfg,
+	}
+}
+
+// State returns the current delivery path.
+func (f *Failover) State() FailoverState {
+	return FailoverState(f.state.Load())
+}
+
+// Transitions returns the number of state changes so far.
+func (f *Failover) Transitions() int64 {
+	return f.transitions.Load()
+}
+
+// Run monitors delivery until ctx is done, then stops polling if active.
+// It assumes the webhook is registered when called.
+func (f *Failover) Run(ctx context.Context) error {
+	if f.cfg.WebhookURL == "" {
+		return errors.New("failover: WebhookURL is required")
+	}
+	f.since = f.cfg.Clock.Now()
+
+	ticker := f.cfg.Clock.NewTicker(f.cfg.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if f.State() == FailoverPolling {
+				f.poller.Stop()
+			}
+			return ctx.Err()
+		case <-ticker.C():
+		}
+
+		if f.State() == FailoverWebhook {
+			if reason := f.webhookFailure(ctx); reason != "" {
+				f.toPolling(ctx, reason)
+			}
+		} else {
+			f.tryRestore(ctx)
+		}
+	}
+}
+
+// webhookFailure returns why the webhook should be abandoned, or "" if it
+// is healthy.
+func (f *Failover) webhookFailure(ctx context.Context) string {
+	last := f.webhook.LastUpdate()
+	if last.Before(f.since) {
+		last = f.since
+	}
+	if f.cfg.Clock.Now().Sub(last) < f.cfg.SilenceTimeout {
+		return ""
+	}
+
+	p := f.poller
+	info, err := getWebhookInfo(ctx, p.client, p.baseURL, *p.token.Load(), p.userAgent, p.extraHeaders)
+	if err != nil {
+		// The Bot API itself is unreachable; polling would not help.
+		f.cfg.Logger.Warn("failover: getWebhookInfo failed", "error", err)
+		return ""
+	}
+	switch {
+	case info.URL == "":
+		return "webhook not registered"
+	case info.LastErrorDate > 0 && time.Unix(info.LastErrorDate, 0).After(last):
+		return "delivery error: " + info.LastErrorMessage
+	case info.PendingUpdateCount > 0:
+		return fmt.Sprintf("no updates for %s with %d pending", f.cfg.Clock.Now().Sub(last).Round(time.Second), info.PendingUpdateCount)
+	}
+	return ""
+}
+
+func (f *Failover) toPolling(ctx context.Context, reason string) {
+	p := f.poller
+	if err := deleteWebhook(ctx, p.client, p.baseURL, *p.token.Load(), false, p.userAgent, p.extraHeaders); err != nil {
+		f.cfg.Logger.Error("failover: deleteWebhook failed", "error", err)
+		return
+	}
+	if err := p.Start(ctx); err != nil {
+		f.cfg.Logger.Error("failover: start polling failed", "error", err)
+		return
+	}
+	f.transition(FailoverPolling, reason)
+}
+
+func (f *Failover) tryRestore(ctx context.Context) {
+	if err := f.probe(ctx); err != nil {
+		f.cfg.Logger.Debug("failover: webhook still unreachable", "error", err)
+		return
+	}
+
+	// getUpdates and a webhook are mutually exclusive, so stop polling
+	// first; updates arriving in between wait at Telegram. Telegram only
+	// learns that the last polled batch was processed from the next
+	// getUpdates offset, so confirm it before the webhook takes over, or
+	// the batch is redelivered to the webhook.
+	p := f.poller
+	p.Stop()
+	if offset := p.Offset(); offset > 0 {
+		if err := confirmUpdates(ctx, p.client, p.baseURL, *p.token.Load(), offset, p.userAgent, p.extraHeaders); err != nil {
+			f.cfg.Logger.Error("failover: confirming polled updates failed", "error", err)
+			if err := p.Start(ctx); err != nil {
+				f.cfg.Logger.Error("failover: restart polling failed", "error", err)
+			}
+			return
+		}
+	}
+	if err := setWebhook(ctx, p.client, p.baseURL, *p.token.Load(), f.cfg.WebhookURL, f.cfg.WebhookSecret, p.userAgent, p.extraHeaders); err != nil {
+		f.cfg.Logger.Error("failover: setWebhook failed", "error", err)
+		if err := p.Start(ctx); err != nil {
+			f.cfg.Logger.Error("failover: restart polling failed", "error", err)
+		}
+		return
+	}
+	f.since = f.cfg.Clock.Now()
+	f.transition(FailoverWebhook, "webhook reachable")
+}
+
+func (f *Failover) transition(to FailoverState, reason string) {
+	from := FailoverState(f.state.Swap(int32(to)))
+	f.transitions.Add(1)
+	f.cfg.Logger.Warn("failover: switched delivery", "from", from, "to", to, "reason", reason)
+	if f.cfg.OnStateChange != nil {
+		f.cfg.OnStateChange(from, to, reason)
+	}
+}
+
+func (f *Failover) probe(ctx context.Context) error {
+	if f.cfg.Probe != nil {
+		return f.cfg.Probe(ctx)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.cfg.WebhookURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := defaultAPIClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("webhook endpoint returned %s", resp.Status)
+	}
+	return nil
+}
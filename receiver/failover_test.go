@@ -0,0 +1,162 @@
+package receiver_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// failoverAPI fakes the Bot API methods used by Failover.
+type failoverAPI struct {
+	pending atomic.Int32
+	calls   sync.Map // method -> *atomic.Int32
+
+	mu        sync.Mutex
+	confirmed string // offset of the last getUpdates with timeout=0
+	sequence  []string
+}
+
+func (a *failoverAPI) count(method string) int32 {
+	v, ok := a.calls.Load(method)
+	if !ok {
+		return 0
+	}
+	return v.(*atomic.Int32).Load()
+}
+
+func (a *failoverAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	v, _ := a.calls.LoadOrStore(method, new(atomic.Int32))
+	v.(*atomic.Int32).Add(1)
+	a.mu.Lock()
+	a.sequence = append(a.sequence, method)
+	if method == "getUpdates" && r.URL.Query().Get("timeout") == "0" {
+		a.confirmed = r.URL.Query().Get("offset")
+	}
+	a.mu.Unlock()
+
+	var result any = true
+	switch method {
+	case "getWebhookInfo":
+		result = map[string]any{"url": "https://example.com/hook", "pending_update_count": a.pending.Load()}
+	case "getUpdates":
+		result = []any{}
+		if a.pending.Swap(0) > 0 {
+			result = []any{map[string]any{"update_id": 7}}
+		}
+	}
+	json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+}
+
+func newTestFailover(t *testing.T, api *failoverAPI, cfg receiver.FailoverConfig) (*receiver.Failover, chan tg.Update) {
+	t.Helper()
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	rcfg := pollingTestConfig()
+	rcfg.BaseURL = server.URL + "/bot"
+	updates := make(chan tg.Update, 10)
+	webhook := receiver.NewWebhookHandler(pollingTestLogger(), updates, rcfg)
+	poller := receiver.NewPollingClient(tg.SecretToken("test:token"), updates, pollingTestLogger(), rcfg)
+
+	cfg.WebhookURL = "https://example.com/hook"
+	cfg.SilenceTimeout = 30 * time.Millisecond
+	cfg.CheckInterval = 10 * time.Millisecond
+	cfg.Logger = pollingTestLogger()
+	return receiver.NewFailover(webhook, poller, cfg), updates
+}
+
+func TestFailover_FallsBackToPollingAndRestores(t *testing.T) {
+	api := &failoverAPI{}
+	api.pending.Store(1)
+
+	var reachable atomic.Bool
+	var mu sync.Mutex
+	var changes []receiver.FailoverState
+	f, updates := newTestFailover(t, api, receiver.FailoverConfig{
+		Probe: func(context.Context) error {
+			if !reachable.Load() {
+				return errors.New("connection refused")
+			}
+			return nil
+		},
+		OnStateChange: func(from, to receiver.FailoverState, reason string) {
+			mu.Lock()
+			changes = append(changes, to)
+			mu.Unlock()
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- f.Run(ctx) }()
+
+	require.Eventually(t, func() bool { return f.State() == receiver.FailoverPolling }, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(1), api.count("deleteWebhook"))
+
+	select {
+	case u := <-updates:
+		assert.Equal(t, 7, u.UpdateID, "pending update received by polling")
+	case <-time.After(2 * time.Second):
+		t.Fatal("no update polled")
+	}
+
+	reachable.Store(true)
+	require.Eventually(t, func() bool { return f.State() == receiver.FailoverWebhook }, 3*time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(1), api.count("setWebhook"))
+
+	// The polled batch is confirmed before the webhook is registered, so
+	// Telegram does not redeliver update 7 to the webhook.
+	api.mu.Lock()
+	assert.Equal(t, "8", api.confirmed)
+	confirmAt := slices.Index(api.sequence, "setWebhook") - 1
+	assert.Equal(t, "getUpdates", api.sequence[confirmAt])
+	api.mu.Unlock()
+	assert.Equal(t, int64(2), f.Transitions())
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []receiver.FailoverState{receiver.FailoverPolling, receiver.FailoverWebhook}, changes)
+}
+
+func TestFailover_IdleWebhookStays(t *testing.T) {
+	api := &failoverAPI{} // no pending updates, no errors
+	f, _ := newTestFailover(t, api, receiver.FailoverConfig{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	err := f.Run(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, receiver.FailoverWebhook, f.State())
+	assert.Positive(t, api.count("getWebhookInfo"), "health checked after silence")
+	assert.Zero(t, api.count("deleteWebhook"))
+}
+
+func TestFailover_RequiresWebhookURL(t *testing.T) {
+	f := receiver.NewFailover(nil, nil, receiver.FailoverConfig{})
+	assert.Error(t, f.Run(context.Background()))
+}
+
+func TestFailoverState_String(t *testing.T) {
+	assert.Equal(t, "webhook", receiver.FailoverWebhook.String())
+	assert.Equal(t, "polling", receiver.FailoverPolling.String())
+}
@@ -0,0 +1,151 @@
+package receiver
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prilive-com/galigo/leader"
+)
+
+// ================== Leader Election ==================
+//
+// Replicas polling one token split its updates between them. With a
+// leader lock only the holder polls; the others retry the lock every
+// third of its TTL and take over once the leader releases it on Stop or
+// stops renewing it. Updates the old leader fetched but had not yet
+// confirmed may be delivered again by the new one.
+
+// DefaultLeaderTTL is the lease duration used when none is given.
+const DefaultLeaderTTL = 15 * time.Second
+
+// leaderReleaseTimeout bounds releasing the lock when polling ends.
+const leaderReleaseTimeout = 5 * time.Second
+
+// WithPollingLeaderLock makes the client poll only while it holds lock,
+// leased for ttl (DefaultLeaderTTL if <= 0) and renewed every ttl/3. A
+// replica that fails to renew within ttl stops polling and goes back to
+// standby. Running and IsHealthy stay true on standby; see IsLeader.
+func WithPollingLeaderLock(lock leader.Lock, ttl time.Duration) PollingOption {
+	return func(c *PollingClient) {
+		if ttl <= 0 {
+			ttl = DefaultLeaderTTL
+		}
+		c.leaderLock = lock
+		c.leaderTTL = ttl
+	}
+}
+
+// IsLeader reports whether the client is polling: it holds the leader
+// lock, or has none and is running.
+func (c *PollingClient) IsLeader() bool {
+	if c.leaderLock == nil {
+		return c.running.Load()
+	}
+	return c.leading.Load()
+}
+
+// lead alternates between standby and polling until stopped.
+func (c *PollingClient) lead(ctx context.Context) {
+	for {
+		if !c.awaitLeadership(ctx) {
+			return
+		}
+
+		leadCtx, cancel := context.WithCancel(ctx)
+		var lost atomic.Bool
+		var renew sync.WaitGroup
+		c.leading.Store(true)
+		renew.Go(func() {
+			c.renewLeadership(leadCtx, cancel, &lost)
+		})
+
+		c.poll(leadCtx)
+
+		cancel()
+		renew.Wait()
+		c.leading.Store(false)
+		c.releaseLeadership(ctx)
+		if !lost.Load() {
+			return
+		}
+		c.logger.Warn("polling leadership lost, standing by")
+	}
+}
+
+// awaitLeadership retries the lock until it is acquired (true) or the
+// client stops (false).
+func (c *PollingClient) awaitLeadership(ctx context.Context) bool {
+	logged := false
+	for {
+		held, err := c.leaderLock.Acquire(ctx, c.leaderTTL)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			c.logger.Warn("polling leader lock failed", "error", err)
+		case held:
+			c.logger.Info("polling leadership acquired")
+			return true
+		case !logged:
+			c.logger.Info("polling on standby: another replica holds the leader lock")
+			logged = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-c.stopCh:
+			return false
+		case <-c.clock.After(c.leaderTTL / 3):
+		}
+	}
+}
+
+// renewLeadership extends the lock until ctx is done, calling cancel and
+// setting lost once another replica holds it or renewal has failed for
+// two thirds of the TTL. Stepping down before the lease runs out leaves a
+// margin for clock drift and the poll in flight, so two replicas never
+// poll at once.
+func (c *PollingClient) renewLeadership(ctx context.Context, cancel context.CancelFunc, lost *atomic.Bool) {
+	ticker := c.clock.NewTicker(c.leaderTTL / 3)
+	defer ticker.Stop()
+	renewed := c.clock.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+
+		// The lease runs from when it was requested, not answered
+		attempted := c.clock.Now()
+		held, err := c.leaderLock.Acquire(ctx, c.leaderTTL)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil && held {
+			renewed = attempted
+			continue
+		}
+		if err != nil {
+			c.logger.Warn("polling leader lock renewal failed", "error", err)
+			if c.clock.Now().Sub(renewed) < c.leaderTTL-c.leaderTTL/3 {
+				continue // the lease has not nearly run out yet
+			}
+		}
+		lost.Store(true)
+		cancel()
+		return
+	}
+}
+
+// releaseLeadership gives the lock up so a standby replica takes over
+// without waiting for it to expire.
+func (c *PollingClient) releaseLeadership(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), leaderReleaseTimeout)
+	defer cancel()
+	if err := c.leaderLock.Release(ctx); err != nil {
+		c.logger.Warn("polling leader lock release failed", "error", err)
+	}
+}
@@ -0,0 +1,157 @@
+package receiver_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// memoryLease is a lease shared by the replicaLocks of one test.
+type memoryLease struct {
+	mu      sync.Mutex
+	holder  string
+	expires time.Time
+}
+
+func (l *memoryLease) set(holder string, ttl time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.holder, l.expires = holder, time.Now().Add(ttl)
+}
+
+type replicaLock struct {
+	lease *memoryLease
+	id    string
+}
+
+func (l replicaLock) Acquire(_ context.Context, ttl time.Duration) (bool, error) {
+	l.lease.mu.Lock()
+	defer l.lease.mu.Unlock()
+	if l.lease.holder != "" && l.lease.holder != l.id && time.Now().Before(l.lease.expires) {
+		return false, nil
+	}
+	l.lease.holder, l.lease.expires = l.id, time.Now().Add(ttl)
+	return true, nil
+}
+
+func (l replicaLock) Release(context.Context) error {
+	l.lease.mu.Lock()
+	defer l.lease.mu.Unlock()
+	if l.lease.holder == l.id {
+		l.lease.holder = ""
+	}
+	return nil
+}
+
+func leaderTestReplica(t *testing.T, lease *memoryLease, id string) (*receiver.PollingClient, *atomic.Int32) {
+	t.Helper()
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls.Add(1)
+		time.Sleep(5 * time.Millisecond)
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": []any{}})
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := pollingTestConfig()
+	cfg.BaseURL = server.URL + "/bot"
+	client := receiver.NewPollingClient(tg.SecretToken("test:token"), make(chan tg.Update, 1),
+		pollingTestLogger(), cfg, receiver.WithPollingLeaderLock(replicaLock{lease, id}, 300*time.Millisecond))
+	return client, &polls
+}
+
+func TestPolling_LeaderLock(t *testing.T) {
+	lease := &memoryLease{}
+	a, aPolls := leaderTestReplica(t, lease, "a")
+	b, bPolls := leaderTestReplica(t, lease, "b")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, a.Start(ctx))
+	require.Eventually(t, func() bool { return aPolls.Load() > 0 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, b.Start(ctx))
+	defer b.Stop()
+
+	time.Sleep(200 * time.Millisecond)
+	assert.True(t, a.IsLeader())
+	assert.False(t, b.IsLeader())
+	assert.True(t, b.Running(), "standby replicas keep running")
+	assert.Zero(t, bPolls.Load())
+
+	// Stopping the leader releases the lock to the standby replica.
+	a.Stop()
+	require.Eventually(t, func() bool { return bPolls.Load() > 0 }, time.Second, 5*time.Millisecond)
+	assert.True(t, b.IsLeader())
+
+	// Losing the lock to another holder stops polling.
+	lease.set("c", time.Hour)
+	require.Eventually(t, func() bool { return !b.IsLeader() }, time.Second, 5*time.Millisecond)
+	stalled := bPolls.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, stalled, bPolls.Load())
+	assert.True(t, b.Running())
+
+	// and it takes over again once the lease is free.
+	lease.set("", 0)
+	require.Eventually(t, b.IsLeader, time.Second, 5*time.Millisecond)
+}
+
+// flakyLock grants the first Acquire and fails every renewal.
+type flakyLock struct{ calls atomic.Int32 }
+
+func (l *flakyLock) Acquire(context.Context, time.Duration) (bool, error) {
+	if l.calls.Add(1) == 1 {
+		return true, nil
+	}
+	return false, errors.New("lock backend down")
+}
+
+func (l *flakyLock) Release(context.Context) error { return nil }
+
+func TestPolling_LeaderStepsDownBeforeLeaseExpires(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": []any{}})
+	}))
+	defer server.Close()
+
+	const ttl = 600 * time.Millisecond
+	cfg := pollingTestConfig()
+	cfg.BaseURL = server.URL + "/bot"
+	client := receiver.NewPollingClient(tg.SecretToken("test:token"), make(chan tg.Update, 1),
+		pollingTestLogger(), cfg, receiver.WithPollingLeaderLock(&flakyLock{}, ttl))
+
+	start := time.Now()
+	require.NoError(t, client.Start(context.Background()))
+	defer client.Stop()
+	require.Eventually(t, client.IsLeader, time.Second, time.Millisecond)
+	require.Eventually(t, func() bool { return !client.IsLeader() }, 2*time.Second, time.Millisecond)
+	assert.Less(t, time.Since(start), ttl, "stepped down before the lease ran out")
+}
+
+func TestPolling_IsLeader_WithoutLock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": []any{}})
+	}))
+	defer server.Close()
+
+	cfg := pollingTestConfig()
+	cfg.BaseURL = server.URL + "/bot"
+	client := receiver.NewPollingClient(tg.SecretToken("test:token"), make(chan tg.Update, 1), pollingTestLogger(), cfg)
+	assert.False(t, client.IsLeader())
+	require.NoError(t, client.Start(context.Background()))
+	defer client.Stop()
+	assert.True(t, client.IsLeader())
+}
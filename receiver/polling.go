@@ -1,6 +1,7 @@
 package receiver
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"crypto/tls"
@@ -9,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"math"
 	"math/big"
 	"net"
@@ -22,6 +24,11 @@ import (
 
 	"github.com/sony/gobreaker/v2"
 
+	"github.com/prilive-com/galigo/clock"
+	"github.com/prilive-com/galigo/internal/proxy"
+	"github.com/prilive-com/galigo/internal/scrub"
+	"github.com/prilive-com/galigo/internal/version"
+	"github.com/prilive-com/galigo/leader"
 	"github.com/prilive-com/galigo/tg"
 )
 
@@ -32,7 +39,7 @@ const (
 
 // PollingClient polls Telegram's getUpdates API for updates.
 type PollingClient struct {
-	token   tg.SecretToken
+	token   atomic.Pointer[tg.SecretToken] // swapped by SetToken
 	baseURL string
 	updates chan tg.Update // Changed to bidirectional for DropOldest policy
 	logger  *slog.Logger
@@ -42,6 +49,9 @@ type PollingClient struct {
 	limit                int
 	maxErrors            int
 	allowedUpdates       []string
+	keepRaw              bool
+	usePOST              bool
+	decoder              tg.Decoder
 	deleteWebhookOnStart bool
 
 	// Retry configuration
@@ -54,12 +64,45 @@ type PollingClient struct {
 	deliveryTimeout time.Duration
 	onUpdateDropped func(int, string)
 
+	// Per-update context derivation
+	baseCtx  context.Context // ctx passed to Start; guarded by mu
+	decorate ContextDecorator
+
+	// Optional copy of every update to a message bus
+	sink *sinkQueue
+
+	// Optional JSON Lines recording of every update
+	recorder *UpdateRecorder
+
 	// HTTP client
 	client *http.Client
 
+	// Time source for backoff and stats
+	clock clock.Clock
+
+	// Proxy selection for the default client (nil = direct); proxyErr is
+	// reported by Start
+	proxy    *proxy.Pool
+	proxyErr error
+
+	// Outgoing request headers ("" = default galigo User-Agent)
+	userAgent    string
+	extraHeaders map[string]string
+
 	// Circuit breaker
 	breaker *gobreaker.CircuitBreaker[[]byte]
 
+	// Leader election among replicas (nil = always poll)
+	leaderLock leader.Lock
+	leaderTTL  time.Duration
+	leading    atomic.Bool
+
+	// Poll and update age counters, see Stats
+	stats pollingStats
+
+	// Decoded updates of the last poll; only the poll loop touches it
+	batch []tg.Update
+
 	// State
 	running           atomic.Bool
 	offset            atomic.Int64 // P1.1: Use atomic for thread-safe access
@@ -80,6 +123,51 @@ func WithPollingHTTPClient(client *http.Client) PollingOption {
 	}
 }
 
+// WithPollingProxy polls through the first reachable of the given proxy
+// URLs (http://, https:// or socks5://, with optional user:pass@), moving
+// to the next when one cannot be reached. Invalid URLs make Start fail.
+// It has no effect with WithPollingHTTPClient.
+func WithPollingProxy(urls ...string) PollingOption {
+	return func(c *PollingClient) {
+		c.proxy, c.proxyErr = proxy.Parse(urls...)
+	}
+}
+
+// WithPollingUserAgent sets the User-Agent header for getUpdates and
+// deleteWebhook requests.
+func WithPollingUserAgent(ua string) PollingOption {
+	return func(c *PollingClient) {
+		c.userAgent = ua
+	}
+}
+
+// WithPollingExtraHeaders adds headers to getUpdates and deleteWebhook
+// requests. Repeated calls merge.
+func WithPollingExtraHeaders(headers map[string]string) PollingOption {
+	return func(c *PollingClient) {
+		if c.extraHeaders == nil {
+			c.extraHeaders = make(map[string]string, len(headers))
+		}
+		maps.Copy(c.extraHeaders, headers)
+	}
+}
+
+// WithPollingClock sets the clock used for retry backoff and polling
+// stats. Tests pass a fake clock to skip the backoff waits.
+func WithPollingClock(clk clock.Clock) PollingOption {
+	return func(c *PollingClient) {
+		c.clock = clk
+	}
+}
+
+// WithPollingDecoder sets how getUpdates responses are decoded. Use
+// tg.StrictDecoder in tests to fail on fields galigo does not model.
+func WithPollingDecoder(d tg.Decoder) PollingOption {
+	return func(c *PollingClient) {
+		c.decoder = d
+	}
+}
+
 // WithPollingCircuitBreaker sets a custom circuit breaker.
 func WithPollingCircuitBreaker(breaker *gobreaker.CircuitBreaker[[]byte]) PollingOption {
 	return func(c *PollingClient) {
@@ -101,6 +189,15 @@ func WithPollingAllowedUpdates(types []string) PollingOption {
 	}
 }
 
+// WithPollingPOST sends getUpdates as a POST with a JSON body instead of
+// a GET with a query string, keeping offsets and filters out of proxy and
+// access logs. The token stays in the URL path either way.
+func WithPollingPOST(enabled bool) PollingOption {
+	return func(c *PollingClient) {
+		c.usePOST = enabled
+	}
+}
+
 // WithPollingDeleteWebhook enables webhook deletion before starting.
 func WithPollingDeleteWebhook(delete bool) PollingOption {
 	return func(c *PollingClient) {
@@ -144,6 +241,15 @@ func WithUpdateDroppedCallback(fn func(updateID int, reason string)) PollingOpti
 	}
 }
 
+// WithPollingOffset sets the offset of the first getUpdates call, e.g. to
+// continue where another client stopped. Telegram treats updates below it
+// as processed.
+func WithPollingOffset(offset int64) PollingOption {
+	return func(c *PollingClient) {
+		c.offset.Store(offset)
+	}
+}
+
 // NewPollingClient creates a new long polling client.
 // Note: The updates channel must be bidirectional (chan tg.Update) if using DeliveryPolicyDropOldest.
 func NewPollingClient(
@@ -163,20 +269,23 @@ func NewPollingClient(
 	}
 
 	c := &PollingClient{
-		token:              token,
 		baseURL:            baseURL,
 		updates:            updates,
 		logger:             logger,
 		timeout:            cfg.PollingTimeout,
 		limit:              cfg.PollingLimit,
 		maxErrors:          cfg.PollingMaxErrors,
+		allowedUpdates:     cfg.AllowedUpdates,
+		keepRaw:            cfg.KeepRawUpdates,
+		usePOST:            cfg.PollingPOST,
 		retryInitialDelay:  cfg.RetryInitialDelay,
 		retryMaxDelay:      cfg.RetryMaxDelay,
 		retryBackoffFactor: cfg.RetryBackoffFactor,
 		deliveryPolicy:     cfg.UpdateDeliveryPolicy,
 		deliveryTimeout:    cfg.UpdateDeliveryTimeout,
 		onUpdateDropped:    cfg.OnUpdateDropped,
-		client:             defaultPollingHTTPClient(cfg.PollingTimeout),
+		client:             defaultPollingHTTPClient(cfg.PollingTimeout, nil),
+		clock:              clock.Real,
 		stopCh:             make(chan struct{}),
 	}
 
@@ -213,37 +322,62 @@ func NewPollingClient(
 		},
 	})
 
+	c.token.Store(&token)
+
+	defaultClient := c.client
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.proxy != nil && c.client == defaultClient {
+		c.client = defaultPollingHTTPClient(cfg.PollingTimeout, c.proxy)
+	}
 
 	return c
 }
 
-func defaultPollingHTTPClient(timeoutSeconds int) *http.Client {
+func defaultPollingHTTPClient(timeoutSeconds int, pool *proxy.Pool) *http.Client {
 	httpTimeout := time.Duration(timeoutSeconds+10) * time.Second
-	return &http.Client{
-		Timeout: httpTimeout,
-		Transport: &http.Transport{
-			DialContext: (&net.Dialer{
-				Timeout:   10 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).DialContext,
-			TLSClientConfig: &tls.Config{
-				MinVersion: tls.VersionTLS12,
-			},
-			TLSHandshakeTimeout:   10 * time.Second,
-			MaxIdleConns:          10,
-			MaxIdleConnsPerHost:   10,
-			IdleConnTimeout:       90 * time.Second,
-			ResponseHeaderTimeout: time.Duration(timeoutSeconds+5) * time.Second,
-			ForceAttemptHTTP2:     true,
+	dial := (&net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport := &http.Transport{
+		DialContext: dial,
+		TLSClientConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
 		},
+		TLSHandshakeTimeout:   10 * time.Second,
+		MaxIdleConns:          10,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		ResponseHeaderTimeout: time.Duration(timeoutSeconds+5) * time.Second,
+		ForceAttemptHTTP2:     true,
+	}
+	if pool != nil {
+		transport.Proxy = pool.Proxy
+		transport.DialContext = pool.Dialer(dial)
+	}
+	return &http.Client{
+		Timeout:   httpTimeout,
+		Transport: transport,
 	}
 }
 
+// CheckProxy connects to the configured proxies, starting with the
+// current one, and switches to the first reachable one. It returns an
+// error if none can be reached, and nil without WithPollingProxy.
+func (c *PollingClient) CheckProxy(ctx context.Context) error {
+	if c.proxy == nil {
+		return nil
+	}
+	return c.proxy.Check(ctx)
+}
+
 // Start begins polling for updates.
 func (c *PollingClient) Start(ctx context.Context) error {
+	if c.proxyErr != nil {
+		return c.proxyErr
+	}
 	if !c.running.CompareAndSwap(false, true) {
 		return ErrAlreadyRunning
 	}
@@ -254,11 +388,12 @@ func (c *PollingClient) Start(ctx context.Context) error {
 		c.stopCh = make(chan struct{})
 		c.stopped.Store(false)
 	}
+	c.baseCtx = ctx
 	c.mu.Unlock()
 
 	if c.deleteWebhookOnStart {
 		c.logger.Info("deleting existing webhook")
-		if err := DeleteWebhook(ctx, c.client, c.token, false); err != nil {
+		if err := deleteWebhook(ctx, c.client, c.baseURL, *c.token.Load(), false, c.userAgent, c.extraHeaders); err != nil {
 			c.running.Store(false)
 			return fmt.Errorf("failed to delete webhook: %w", err)
 		}
@@ -298,6 +433,28 @@ func (c *PollingClient) Stop() {
 	c.logger.Info("long polling stopped")
 }
 
+// SetToken switches polling to token, e.g. after the old one was revoked
+// in @BotFather. The token is first checked with getMe; if that fails, or
+// the token belongs to another bot (tg.ErrTokenBotMismatch), the client
+// keeps its current token. A getUpdates call in flight finishes with the
+// old token; the next one uses token. Polling need not be stopped.
+func (c *PollingClient) SetToken(ctx context.Context, token tg.SecretToken) error {
+	if token.IsEmpty() {
+		return tg.ErrInvalidToken
+	}
+	oldID, _, _ := strings.Cut(c.token.Load().Value(), ":")
+	newID, _, _ := strings.Cut(token.Value(), ":")
+	if oldID != newID {
+		return tg.ErrTokenBotMismatch
+	}
+	if err := getMe(ctx, c.client, c.baseURL, token, c.userAgent, c.extraHeaders); err != nil {
+		return fmt.Errorf("validate token: %w", err)
+	}
+	c.token.Store(&token)
+	c.logger.Info("polling token rotated")
+	return nil
+}
+
 // Running returns true if polling is active.
 func (c *PollingClient) Running() bool {
 	return c.running.Load()
@@ -325,6 +482,16 @@ func (c *PollingClient) pollLoop(ctx context.Context) {
 	// Note: No defer c.wg.Done() needed when using wg.Go()
 	defer c.running.Store(false)
 
+	if c.leaderLock != nil {
+		c.lead(ctx)
+		return
+	}
+	c.poll(ctx)
+}
+
+// poll fetches and delivers updates until ctx is done, Stop is called, or
+// too many consecutive errors occur.
+func (c *PollingClient) poll(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -338,6 +505,9 @@ func (c *PollingClient) pollLoop(ctx context.Context) {
 
 		updates, err := c.fetchUpdates(ctx)
 		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
 			errCount := c.consecutiveErrors.Add(1)
 			backoff := c.calculateBackoff(errCount)
 			c.logger.Error("fetch updates failed",
@@ -356,12 +526,13 @@ func (c *PollingClient) pollLoop(ctx context.Context) {
 				return
 			case <-c.stopCh:
 				return
-			case <-time.After(backoff):
+			case <-c.clock.After(backoff):
 				continue
 			}
 		}
 
 		c.consecutiveErrors.Store(0)
+		c.stats.recordPoll(len(updates), c.clock.Now())
 
 		// Deliver updates using configured policy
 		if err := c.deliverUpdates(ctx, updates); err != nil {
@@ -387,6 +558,10 @@ func (c *PollingClient) deliverUpdates(ctx context.Context, updates []tg.Update)
 
 // deliverUpdate delivers a single update using the configured policy.
 func (c *PollingClient) deliverUpdate(ctx context.Context, update tg.Update) error {
+	c.stats.recordAge(&update, c.clock.Now())
+	recordUpdate(c.recorder, update, c.logger)
+	c.sink.publish(ctx, update, c.logger, c.onUpdateDropped)
+
 	switch c.deliveryPolicy {
 	case DeliveryPolicyBlock:
 		return c.deliverBlocking(ctx, update)
@@ -401,6 +576,15 @@ func (c *PollingClient) deliverUpdate(ctx context.Context, update tg.Update) err
 
 // deliverBlocking waits for channel space with optional timeout.
 func (c *PollingClient) deliverBlocking(ctx context.Context, update tg.Update) error {
+	// Fast path: the channel has room, no timer needed
+	select {
+	case c.updates <- update:
+		c.advanceOffset(update.UpdateID)
+		c.logger.Debug("update sent", "update_id", update.UpdateID)
+		return nil
+	default:
+	}
+
 	// Create delivery context with timeout (if configured)
 	deliveryCtx := ctx
 	var cancel context.CancelFunc
@@ -507,41 +691,29 @@ func (c *PollingClient) advanceOffset(updateID int) {
 }
 
 type getUpdatesResponse struct {
-	OK          bool        `json:"ok"`
-	Result      []tg.Update `json:"result,omitempty"`
-	ErrorCode   int         `json:"error_code,omitempty"`
-	Description string      `json:"description,omitempty"`
+	OK          bool            `json:"ok"`
+	Result      []tg.Update     `json:"result,omitempty"`
+	ErrorCode   int             `json:"error_code,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"` // for strict decoding
 }
 
 func (c *PollingClient) fetchUpdates(ctx context.Context) ([]tg.Update, error) {
-	// P0.2 FIX: Use url.Values for proper URL encoding
-	params := url.Values{}
-	params.Set("timeout", strconv.Itoa(c.timeout))
-	params.Set("limit", strconv.Itoa(c.limit))
-	params.Set("offset", strconv.FormatInt(c.offset.Load(), 10))
-
-	if len(c.allowedUpdates) > 0 {
-		encoded, err := json.Marshal(c.allowedUpdates)
-		if err == nil {
-			params.Set("allowed_updates", string(encoded))
-		}
-	}
-
-	apiURL := fmt.Sprintf("%s%s/getUpdates?%s",
-		c.baseURL,
-		c.token.Value(),
-		params.Encode(),
-	)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	token := *c.token.Load()
+	req, err := c.newGetUpdatesRequest(ctx, token)
 	if err != nil {
 		return nil, &APIError{Description: "failed to create request", Err: err}
 	}
+	version.SetHeaders(req, c.userAgent, c.extraHeaders)
 
-	respBody, err := c.breaker.Execute(func() ([]byte, error) {
+	// The body is read into a pooled buffer and decoded from there; the
+	// breaker's result is unused so the body is not copied out.
+	buf := getBodyBuffer()
+	defer putBodyBuffer(buf)
+	_, err = c.breaker.Execute(func() ([]byte, error) {
 		resp, doErr := c.client.Do(req)
 		if doErr != nil {
-			return nil, scrubTokenFromError(doErr, c.token)
+			return nil, scrub.TokenFromError(doErr, token)
 		}
 		defer func() {
 			io.Copy(io.Discard, resp.Body)
@@ -550,12 +722,11 @@ func (c *PollingClient) fetchUpdates(ctx context.Context) ([]tg.Update, error) {
 
 		// P0.9 FIX: Add response size limit to prevent memory exhaustion
 		limitedReader := io.LimitReader(resp.Body, maxPollResponseSize+1)
-		body, readErr := io.ReadAll(limitedReader)
-		if readErr != nil {
+		if _, readErr := buf.ReadFrom(limitedReader); readErr != nil {
 			return nil, readErr
 		}
 
-		if int64(len(body)) > maxPollResponseSize {
+		if int64(buf.Len()) > maxPollResponseSize {
 			return nil, errors.New("response too large")
 		}
 
@@ -563,15 +734,24 @@ func (c *PollingClient) fetchUpdates(ctx context.Context) ([]tg.Update, error) {
 			return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 		}
 
-		return body, nil
+		return nil, nil
 	})
 
 	if err != nil {
 		return nil, &APIError{Description: "request failed", Err: err}
 	}
 
-	var response getUpdatesResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if c.keepRaw {
+		return parseRawUpdates(buf.Bytes(), c.decoder)
+	}
+
+	// Updates are delivered by value before the next poll, so the batch's
+	// backing array is reused. Decoding into a slice reuses its elements,
+	// hence the clear.
+	batch := c.batch[:cap(c.batch)]
+	clear(batch)
+	response := getUpdatesResponse{Result: batch[:0]}
+	if err := c.decoder.Decode(buf.Bytes(), &response); err != nil {
 		return nil, &APIError{Description: "failed to parse response", Err: err}
 	}
 
@@ -582,33 +762,87 @@ func (c *PollingClient) fetchUpdates(ctx context.Context) ([]tg.Update, error) {
 		}
 	}
 
+	c.batch = response.Result
 	return response.Result, nil
 }
 
-// scrubbedError wraps an error with a token-scrubbed message while preserving the error chain.
-type scrubbedError struct {
-	msg string
-	err error
+// newGetUpdatesRequest builds the getUpdates request: a GET with the
+// parameters in the query string, or with WithPollingPOST a POST with a
+// JSON body, so only the token is in the URL.
+func (c *PollingClient) newGetUpdatesRequest(ctx context.Context, token tg.SecretToken) (*http.Request, error) {
+	apiURL := c.baseURL + token.Value() + "/getUpdates"
+
+	if c.usePOST {
+		payload := getUpdatesRequest{
+			Offset:         c.offset.Load(),
+			Limit:          c.limit,
+			Timeout:        c.timeout,
+			AllowedUpdates: c.allowedUpdates,
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}
+
+	// P0.2 FIX: Use url.Values for proper URL encoding
+	params := url.Values{}
+	params.Set("timeout", strconv.Itoa(c.timeout))
+	params.Set("limit", strconv.Itoa(c.limit))
+	params.Set("offset", strconv.FormatInt(c.offset.Load(), 10))
+
+	if len(c.allowedUpdates) > 0 {
+		encoded, err := json.Marshal(c.allowedUpdates)
+		if err == nil {
+			params.Set("allowed_updates", string(encoded))
+		}
+	}
+	return http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"?"+params.Encode(), nil)
+}
+
+// getUpdatesRequest is the JSON body of a POST getUpdates request.
+type getUpdatesRequest struct {
+	Offset         int64    `json:"offset"`
+	Limit          int      `json:"limit"`
+	Timeout        int      `json:"timeout"`
+	AllowedUpdates []string `json:"allowed_updates,omitempty"`
 }
 
-func (e *scrubbedError) Error() string { return e.msg }
-func (e *scrubbedError) Unwrap() error { return e.err }
+// parseRawUpdates parses a getUpdates response, keeping each update's JSON.
+func parseRawUpdates(body []byte, dec tg.Decoder) ([]tg.Update, error) {
+	var response struct {
+		OK          bool              `json:"ok"`
+		Result      []json.RawMessage `json:"result,omitempty"`
+		ErrorCode   int               `json:"error_code,omitempty"`
+		Description string            `json:"description,omitempty"`
+		Parameters  json.RawMessage   `json:"parameters,omitempty"`
+	}
+	if err := dec.Decode(body, &response); err != nil {
+		return nil, &APIError{Description: "failed to parse response", Err: err}
+	}
 
-// scrubTokenFromError removes the bot token from error messages.
-// Preserves the error chain for errors.Is/As.
-func scrubTokenFromError(err error, token tg.SecretToken) error {
-	if err == nil {
-		return nil
+	if !response.OK {
+		return nil, &APIError{
+			Code:        response.ErrorCode,
+			Description: response.Description,
+		}
 	}
-	msg := err.Error()
-	tokenVal := token.Value()
-	if tokenVal != "" && strings.Contains(msg, tokenVal) {
-		return &scrubbedError{
-			msg: strings.ReplaceAll(msg, tokenVal, "[REDACTED]"),
-			err: err,
+
+	updates := make([]tg.Update, 0, len(response.Result))
+	for _, raw := range response.Result {
+		update, err := tg.UnmarshalUpdateWithRaw(raw)
+		if err != nil {
+			return nil, &APIError{Description: "failed to parse response", Err: dec.Wrap(raw, err)}
 		}
+		updates = append(updates, update)
 	}
-	return err
+	return updates, nil
 }
 
 func (c *PollingClient) calculateBackoff(attempt int32) time.Duration {
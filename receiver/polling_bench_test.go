@@ -0,0 +1,89 @@
+package receiver_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// cannedTransport answers every request with body, without a network.
+type cannedTransport struct{ body []byte }
+
+func (t cannedTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(t.body)),
+	}, nil
+}
+
+// benchmarkUpdatesBody returns a getUpdates response with n text messages.
+func benchmarkUpdatesBody(b *testing.B, n int) []byte {
+	b.Helper()
+	result := make([]map[string]any, n)
+	for i := range result {
+		result[i] = map[string]any{
+			"update_id": i + 1,
+			"message": map[string]any{
+				"message_id": i + 1,
+				"date":       1700000000,
+				"chat":       map[string]any{"id": 12345 + i%50, "type": "private", "first_name": "Alice"},
+				"from":       map[string]any{"id": 12345 + i%50, "is_bot": false, "first_name": "Alice", "language_code": "en"},
+				"text":       "/start hello there, this is a typical message",
+				"entities":   []map[string]any{{"type": "bot_command", "offset": 0, "length": 6}},
+			},
+		}
+	}
+	body, err := json.Marshal(map[string]any{"ok": true, "result": result})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return body
+}
+
+// benchmarkPolling measures one getUpdates round of 100 updates, from the
+// response body to the updates channel.
+func benchmarkPolling(b *testing.B, opts ...receiver.PollingOption) {
+	const batch = 100
+	body := benchmarkUpdatesBody(b, batch)
+
+	cfg := pollingTestConfig()
+	cfg.BaseURL = "http://telegram.invalid/bot"
+	updates := make(chan tg.Update, batch)
+	opts = append(opts, receiver.WithPollingHTTPClient(&http.Client{Transport: cannedTransport{body}}))
+	client := receiver.NewPollingClient(tg.SecretToken("test:token"), updates, pollingTestLogger(), cfg, opts...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	b.ResetTimer()
+	if err := client.Start(ctx); err != nil {
+		b.Fatal(err)
+	}
+	for range b.N * batch {
+		<-updates
+	}
+	b.StopTimer()
+	cancel()
+	go func() {
+		for range updates {
+		}
+	}()
+	client.Stop()
+}
+
+func BenchmarkPolling_Decode(b *testing.B) {
+	benchmarkPolling(b)
+}
+
+func BenchmarkPolling_DecodeStrict(b *testing.B) {
+	benchmarkPolling(b, receiver.WithPollingDecoder(tg.StrictDecoder()))
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -17,6 +18,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/prilive-com/galigo/galigotest"
 	"github.com/prilive-com/galigo/receiver"
 	"github.com/prilive-com/galigo/tg"
 )
@@ -807,6 +809,87 @@ func TestPollingOption_WithPollingAllowedUpdates(t *testing.T) {
 	assert.Contains(t, capturedQuery, "callback_query")
 }
 
+func TestPollingOption_WithPollingPOST(t *testing.T) {
+	type captured struct {
+		method, path, rawQuery, contentType string
+		body                                map[string]any
+	}
+	reqCh := make(chan captured, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := captured{method: r.Method, path: r.URL.Path, rawQuery: r.URL.RawQuery, contentType: r.Header.Get("Content-Type")}
+		_ = json.NewDecoder(r.Body).Decode(&c.body)
+		select {
+		case reqCh <- c:
+		default:
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"ok":     true,
+			"result": []any{},
+		})
+	}))
+	defer server.Close()
+
+	updates := make(chan tg.Update, 10)
+	cfg := pollingTestConfig()
+	cfg.BaseURL = server.URL + "/bot"
+
+	client := receiver.NewPollingClient(
+		tg.SecretToken("test:token"),
+		updates,
+		pollingTestLogger(),
+		cfg,
+		receiver.WithPollingPOST(true),
+		receiver.WithPollingAllowedUpdates([]string{"message"}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, client.Start(ctx))
+	defer client.Stop()
+
+	got := <-reqCh
+	assert.Equal(t, http.MethodPost, got.method)
+	assert.Equal(t, "/bottest:token/getUpdates", got.path)
+	assert.Empty(t, got.rawQuery, "parameters must not be in the URL")
+	assert.Equal(t, "application/json", got.contentType)
+	assert.Equal(t, float64(1), got.body["timeout"])
+	assert.Equal(t, float64(100), got.body["limit"])
+	assert.Equal(t, float64(0), got.body["offset"])
+	assert.Equal(t, []any{"message"}, got.body["allowed_updates"])
+}
+
+func TestPollingOption_WithPollingClock_Backoff(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	cfg := pollingTestConfig()
+	cfg.BaseURL = server.URL + "/bot"
+	cfg.PollingMaxErrors = 0
+	cfg.RetryInitialDelay = time.Hour // only fake time gets past this
+	cfg.RetryMaxDelay = time.Hour
+
+	clk := galigotest.NewFakeClock(time.Now())
+	client := receiver.NewPollingClient(tg.SecretToken("test:token"), make(chan tg.Update, 1),
+		pollingTestLogger(), cfg, receiver.WithPollingClock(clk))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, client.Start(ctx))
+	defer client.Stop()
+
+	clk.BlockUntil(1) // backing off after the first failure
+	assert.Equal(t, int32(1), requests.Load())
+
+	clk.Advance(2 * time.Hour) // past the jittered delay
+	require.Eventually(t, func() bool { return requests.Load() == 2 }, 2*time.Second, time.Millisecond)
+}
+
 func TestPollingOption_WithDeliveryPolicy(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode(map[string]any{
@@ -1084,3 +1167,199 @@ func TestPolling_Start_DeleteWebhookFails(t *testing.T) {
 	assert.Error(t, err)
 	assert.False(t, client.Running())
 }
+
+func TestPolling_RequestHeaders(t *testing.T) {
+	var mu sync.Mutex
+	var seen []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Header.Clone())
+		mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": []any{}})
+	}))
+	defer server.Close()
+
+	cfg := pollingTestConfig()
+	cfg.BaseURL = server.URL + "/bot"
+
+	client := receiver.NewPollingClient(
+		tg.SecretToken("test:token"),
+		make(chan tg.Update, 10),
+		pollingTestLogger(),
+		cfg,
+		receiver.WithPollingDeleteWebhook(true),
+		receiver.WithPollingUserAgent("acme-bot/2.0"),
+		receiver.WithPollingExtraHeaders(map[string]string{"X-Egress-Route": "telegram"}),
+	)
+
+	require.NoError(t, client.Start(context.Background()))
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(seen) >= 2 // deleteWebhook + getUpdates
+	}, 2*time.Second, 10*time.Millisecond)
+	client.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, h := range seen {
+		assert.Equal(t, "acme-bot/2.0", h.Get("User-Agent"))
+		assert.Equal(t, "telegram", h.Get("X-Egress-Route"))
+	}
+}
+
+func TestPolling_AllowedUpdatesFromConfig(t *testing.T) {
+	var mu sync.Mutex
+	var allowed string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		allowed = r.URL.Query().Get("allowed_updates")
+		mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": []any{}})
+	}))
+	defer server.Close()
+
+	cfg := pollingTestConfig()
+	cfg.BaseURL = server.URL + "/bot"
+	cfg.AllowedUpdates = []string{tg.UpdateTypeMessage, tg.UpdateTypeMessageReaction}
+
+	client := receiver.NewPollingClient(tg.SecretToken("test:token"), make(chan tg.Update, 10), pollingTestLogger(), cfg)
+	require.NoError(t, client.Start(context.Background()))
+	defer client.Stop()
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return allowed == `["message","message_reaction"]`
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestPolling_KeepRawUpdates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":true,"result":[{"update_id":1,"message":{"message_id":1,"date":1,"chat":{"id":1,"type":"private"},"future_field":"x"}}]}`))
+	}))
+	defer server.Close()
+
+	cfg := pollingTestConfig()
+	cfg.BaseURL = server.URL + "/bot"
+	cfg.KeepRawUpdates = true
+
+	updates := make(chan tg.Update, 10)
+	client := receiver.NewPollingClient(tg.SecretToken("test:token"), updates, pollingTestLogger(), cfg)
+
+	require.NoError(t, client.Start(context.Background()))
+	defer client.Stop()
+
+	select {
+	case u := <-updates:
+		assert.Equal(t, 1, u.UpdateID)
+		assert.Contains(t, string(u.Raw()), `"future_field":"x"`)
+		assert.Contains(t, string(u.Message.Raw()), `"future_field":"x"`)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no update delivered")
+	}
+}
+
+func TestPolling_BatchReuseKeepsUpdatesIndependent(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch polls.Add(1) {
+		case 1:
+			w.Write([]byte(`{"ok":true,"result":[{"update_id":1,"message":{"message_id":1,"date":1,"chat":{"id":1,"type":"private"},"text":"first"}},{"update_id":2,"message":{"message_id":2,"date":1,"chat":{"id":1,"type":"private"}}}]}`))
+		case 2:
+			w.Write([]byte(`{"ok":true,"result":[{"update_id":3,"callback_query":{"id":"q","from":{"id":1,"is_bot":false,"first_name":"A"},"chat_instance":"c"}}]}`))
+		default:
+			w.Write([]byte(`{"ok":true,"result":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	cfg := pollingTestConfig()
+	cfg.BaseURL = server.URL + "/bot"
+	updates := make(chan tg.Update, 10)
+	client := receiver.NewPollingClient(tg.SecretToken("test:token"), updates, pollingTestLogger(), cfg)
+	require.NoError(t, client.Start(context.Background()))
+	defer client.Stop()
+
+	var got []tg.Update
+	for len(got) < 3 {
+		select {
+		case u := <-updates:
+			got = append(got, u)
+		case <-time.After(2 * time.Second):
+			t.Fatal("updates not delivered")
+		}
+	}
+	assert.Equal(t, "first", got[0].Message.Text, "earlier updates are not overwritten")
+	assert.Empty(t, got[1].Message.Text)
+	assert.Nil(t, got[2].Message, "no fields left over from the previous batch")
+	assert.Equal(t, "q", got[2].CallbackQuery.ID)
+}
+
+// ==================== Proxy ====================
+
+func TestPolling_WithProxy(t *testing.T) {
+	// A plain-HTTP forward proxy receives the full request and can answer
+	// in the API's place.
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Add(1)
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": []any{}})
+	}))
+	defer proxy.Close()
+
+	cfg := pollingTestConfig()
+	cfg.BaseURL = "http://api.telegram.invalid/bot"
+	client := receiver.NewPollingClient(tg.SecretToken("test:token"), make(chan tg.Update, 10),
+		pollingTestLogger(), cfg, receiver.WithPollingProxy(proxy.URL))
+
+	require.NoError(t, client.CheckProxy(context.Background()))
+	require.NoError(t, client.Start(context.Background()))
+	defer client.Stop()
+	require.Eventually(t, func() bool { return proxied.Load() > 0 }, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestPolling_WithProxy_InvalidURL(t *testing.T) {
+	client := receiver.NewPollingClient(tg.SecretToken("test:token"), make(chan tg.Update, 10),
+		pollingTestLogger(), pollingTestConfig(), receiver.WithPollingProxy("ftp://proxy"))
+	assert.Error(t, client.Start(context.Background()))
+	assert.False(t, client.Running())
+}
+
+// ==================== Token Rotation ====================
+
+func TestPolling_SetToken(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		if strings.Contains(r.URL.Path, "bad:") {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]any{"ok": false, "error_code": 401, "description": "Unauthorized"})
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": []any{}})
+	}))
+	defer server.Close()
+
+	cfg := pollingTestConfig()
+	cfg.BaseURL = server.URL + "/bot"
+	client := receiver.NewPollingClient(tg.SecretToken("test:old"), make(chan tg.Update, 10), pollingTestLogger(), cfg)
+	require.NoError(t, client.Start(context.Background()))
+	defer client.Stop()
+
+	assert.ErrorIs(t, client.SetToken(context.Background(), "other:new"), tg.ErrTokenBotMismatch)
+	require.NoError(t, client.SetToken(context.Background(), "test:new"))
+
+	seen := func(path string) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Contains(paths, path)
+	}
+	assert.True(t, seen("/bottest:new/getMe"))
+	require.Eventually(t, func() bool { return seen("/bottest:new/getUpdates") }, 2*time.Second, 5*time.Millisecond)
+	assert.True(t, client.Running())
+}
@@ -0,0 +1,67 @@
+package receiver
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Update Recording ==================
+//
+// A recording is a JSON Lines file with one UpdateEnvelope per update, in
+// the order received. ReceivedAt keeps the original timing, so that
+// galigo.ReplaySource can play a recording back at its original pace.
+
+// UpdateRecorder writes updates to an io.Writer as JSON lines. It is safe
+// for concurrent use and implements UpdateSink.
+type UpdateRecorder struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewUpdateRecorder returns a recorder writing to w.
+func NewUpdateRecorder(w io.Writer) *UpdateRecorder {
+	return &UpdateRecorder{w: w}
+}
+
+// Publish appends update to the recording.
+func (r *UpdateRecorder) Publish(_ context.Context, update tg.Update) error {
+	data, err := EncodeUpdate(update)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err = r.w.Write(data)
+	return err
+}
+
+// WithRecordUpdates records every polled update to w.
+func WithRecordUpdates(w io.Writer) PollingOption {
+	return func(c *PollingClient) {
+		c.recorder = NewUpdateRecorder(w)
+	}
+}
+
+// WithWebhookRecordUpdates records every received update to w.
+func WithWebhookRecordUpdates(w io.Writer) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.recorder = NewUpdateRecorder(w)
+	}
+}
+
+// recordUpdate appends update to rec. A failed write is logged; it is not
+// a dropped update.
+func recordUpdate(rec *UpdateRecorder, update tg.Update, logger *slog.Logger) {
+	if rec == nil {
+		return
+	}
+	if err := rec.Publish(context.Background(), update); err != nil {
+		logger.Warn("update recording failed", "update_id", update.UpdateID, "error", err)
+	}
+}
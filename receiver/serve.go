@@ -0,0 +1,177 @@
+package receiver
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// ================== Webhook Server ==================
+//
+// ServeWebhook runs an HTTP server for a WebhookHandler. Public endpoints
+// need TLS. Behind a local Bot API server (--local), which accepts
+// plain-HTTP webhook URLs, or a reverse proxy on the same host, the server
+// may instead listen without TLS on a loopback address or a Unix domain
+// socket, so no extra proxy is needed just to terminate TLS.
+
+// ServeConfig configures ServeWebhook.
+type ServeConfig struct {
+	// Addr is "host:port" for TCP or "unix:/path/to.sock" for a Unix
+	// domain socket (also available on Windows 10 and later).
+	Addr string
+
+	// TLSCertPath and TLSKeyPath enable HTTPS.
+	TLSCertPath string
+	TLSKeyPath  string
+
+	// AllowInsecure permits plain HTTP, and only on loopback addresses and
+	// Unix sockets. Without TLS files and this opt-in, ServeWebhook
+	// returns ErrTLSRequired.
+	AllowInsecure bool
+
+	// SocketMode sets the permissions of a Unix socket (default 0660, so
+	// only the owner and group, e.g. the Bot API server's, can connect).
+	SocketMode fs.FileMode
+
+	// ShutdownTimeout bounds the graceful shutdown when ctx is done
+	// (default 10s).
+	ShutdownTimeout time.Duration
+
+	Logger *slog.Logger
+}
+
+// ErrInsecureAddr is returned for plain-HTTP listeners on addresses other
+// than loopback and Unix sockets.
+var ErrInsecureAddr = errors.New("galigo/receiver: plain HTTP is only allowed on loopback addresses and Unix sockets")
+
+// ServeWebhook serves handler as configured by cfg until ctx is done, then
+// shuts the server down gracefully and removes its Unix socket.
+func ServeWebhook(ctx context.Context, handler http.Handler, cfg ServeConfig) error {
+	ln, err := ListenWebhook(cfg)
+	if err != nil {
+		return err
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		cfg.ShutdownTimeout = 10 * time.Second
+	}
+
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+	tlsOn := cfg.TLSCertPath != "" && cfg.TLSKeyPath != ""
+	if tlsOn {
+		srv.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		if tlsOn {
+			errc <- srv.ServeTLS(ln, cfg.TLSCertPath, cfg.TLSKeyPath)
+		} else {
+			errc <- srv.Serve(ln)
+		}
+	}()
+	cfg.Logger.Info("webhook server listening", "addr", cfg.Addr, "tls", tlsOn)
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// ListenWebhook opens the listener described by cfg, enforcing the TLS
+// rules of ServeWebhook, for callers running their own http.Server.
+func ListenWebhook(cfg ServeConfig) (net.Listener, error) {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	path, isUnix := strings.CutPrefix(cfg.Addr, "unix:")
+	tlsOn := cfg.TLSCertPath != "" && cfg.TLSKeyPath != ""
+	if !tlsOn {
+		if !cfg.AllowInsecure {
+			return nil, ErrTLSRequired
+		}
+		if !isUnix && !isLoopback(cfg.Addr) {
+			return nil, fmt.Errorf("%w: %s", ErrInsecureAddr, cfg.Addr)
+		}
+		cfg.Logger.Warn("webhook server without TLS; only local clients must reach it", "addr", cfg.Addr)
+	}
+
+	if !isUnix {
+		return net.Listen("tcp", cfg.Addr)
+	}
+	if path == "" {
+		return nil, errors.New("galigo/receiver: empty Unix socket path")
+	}
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	mode := cfg.SocketMode
+	if mode == 0 {
+		mode = 0o660
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("galigo/receiver: chmod socket: %w", err)
+	}
+	return ln, nil
+}
+
+// isLoopback reports whether a "host:port" address only accepts local
+// connections. An empty host listens on all interfaces.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// removeStaleSocket deletes a socket file left by a previous run. Other
+// files are not touched.
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.Mode()&fs.ModeSocket == 0 {
+		return fmt.Errorf("galigo/receiver: %s exists and is not a socket", path)
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,88 @@
+package receiver_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestListenWebhook_RequiresTLSOrOptIn(t *testing.T) {
+	_, err := receiver.ListenWebhook(receiver.ServeConfig{Addr: "127.0.0.1:0", Logger: testLogger()})
+	assert.ErrorIs(t, err, receiver.ErrTLSRequired)
+}
+
+func TestListenWebhook_InsecureOnlyOnLoopback(t *testing.T) {
+	for _, addr := range []string{":0", "0.0.0.0:0", "192.0.2.1:8080"} {
+		_, err := receiver.ListenWebhook(receiver.ServeConfig{Addr: addr, AllowInsecure: true, Logger: testLogger()})
+		assert.ErrorIs(t, err, receiver.ErrInsecureAddr, addr)
+	}
+
+	ln, err := receiver.ListenWebhook(receiver.ServeConfig{Addr: "127.0.0.1:0", AllowInsecure: true, Logger: testLogger()})
+	require.NoError(t, err)
+	ln.Close()
+}
+
+func TestListenWebhook_RefusesToReplaceRegularFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bot.sock")
+	require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+
+	_, err := receiver.ListenWebhook(receiver.ServeConfig{Addr: "unix:" + path, AllowInsecure: true, Logger: testLogger()})
+	require.Error(t, err)
+	data, _ := os.ReadFile(path)
+	assert.Equal(t, "data", string(data))
+}
+
+func TestServeWebhook_UnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bot.sock")
+	updates := make(chan tg.Update, 1)
+	handler := receiver.NewWebhookHandler(testLogger(), updates, testConfig())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- receiver.ServeWebhook(ctx, handler, receiver.ServeConfig{
+			Addr:          "unix:" + path,
+			AllowInsecure: true,
+			Logger:        testLogger(),
+		})
+	}()
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o660), fi.Mode().Perm())
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+	req, err := http.NewRequest(http.MethodPost, "http://bot/webhook", strings.NewReader(`{"update_id":7}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "test-secret-token")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 7, (<-updates).UpdateID)
+
+	cancel()
+	require.NoError(t, <-done)
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "socket removed on shutdown")
+}
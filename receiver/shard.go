@@ -0,0 +1,187 @@
+package receiver
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"runtime"
+	"strconv"
+	"sync"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Sharding ==================
+//
+// To process updates on several workers or machines without reordering a
+// chat's updates, each update is assigned a shard from its chat ID. The
+// producer side publishes every shard to its own sink (a NATS subject, a
+// Kafka topic, a channel); on the consumer side a ShardedProcessor runs
+// one goroutine per shard, so updates of a chat are handled one at a
+// time, in order, while different chats proceed in parallel.
+
+// ErrProcessorClosed is returned by ShardedProcessor.Submit after Close.
+var ErrProcessorClosed = errors.New("galigo/receiver: sharded processor closed")
+
+// ShardOf returns the shard of update among shards, from 0 to shards-1.
+// It is FNV-1a of the decimal chat ID (UpdateKey), so every process and
+// language computing it agrees. Updates without a chat are spread by
+// update ID. shards <= 1 always yields 0.
+func ShardOf(update tg.Update, shards int) int {
+	if shards <= 1 {
+		return 0
+	}
+	key := UpdateKey(update)
+	if key == "" {
+		key = "u" + strconv.Itoa(update.UpdateID)
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum64() % uint64(shards))
+}
+
+// ShardedSink publishes each update to Sinks[ShardOf(update, len(Sinks))],
+// e.g. one NATSSink or KafkaSink per shard, each read by one consumer.
+type ShardedSink struct {
+	Sinks []UpdateSink
+}
+
+// Publish implements UpdateSink.
+func (s *ShardedSink) Publish(ctx context.Context, update tg.Update) error {
+	if len(s.Sinks) == 0 {
+		return nil
+	}
+	return s.Sinks[ShardOf(update, len(s.Sinks))].Publish(ctx, update)
+}
+
+// ChannelSink returns an UpdateSink sending to ch, blocking until there is
+// room or ctx is done. With one per shard in a ShardedSink it fans updates
+// out to in-process workers.
+func ChannelSink(ch chan<- tg.Update) UpdateSink {
+	return UpdateSinkFunc(func(ctx context.Context, update tg.Update) error {
+		select {
+		case ch <- update:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// ShardConfig configures a ShardedProcessor.
+type ShardConfig struct {
+	// Shards is the number of workers (default runtime.GOMAXPROCS(0)).
+	Shards int
+
+	// Buffer is the queue length of each shard (default 64). Submit
+	// blocks while the update's shard queue is full.
+	Buffer int
+
+	// OnError is called with the updates whose handler failed (nil =
+	// errors are dropped).
+	OnError func(update tg.Update, err error)
+}
+
+// ShardedProcessor handles updates on one goroutine per shard, keeping the
+// order of each chat's updates. Create it with NewShardedProcessor.
+type ShardedProcessor struct {
+	handle  func(ctx context.Context, update tg.Update) error
+	onError func(tg.Update, error)
+	queues  []chan shardItem
+
+	mu     sync.RWMutex // held for writing by Close
+	closed bool
+	wg     sync.WaitGroup
+}
+
+type shardItem struct {
+	ctx    context.Context
+	update tg.Update
+}
+
+// NewShardedProcessor starts the workers of a ShardedProcessor calling
+// handle. Call Close to stop them.
+func NewShardedProcessor(cfg ShardConfig, handle func(ctx context.Context, update tg.Update) error) *ShardedProcessor {
+	if cfg.Shards <= 0 {
+		cfg.Shards = runtime.GOMAXPROCS(0)
+	}
+	if cfg.Buffer <= 0 {
+		cfg.Buffer = 64
+	}
+	p := &ShardedProcessor{
+		handle:  handle,
+		onError: cfg.OnError,
+		queues:  make([]chan shardItem, cfg.Shards),
+	}
+	for i := range p.queues {
+		queue := make(chan shardItem, cfg.Buffer)
+		p.queues[i] = queue
+		p.wg.Go(func() {
+			for item := range queue {
+				p.process(item)
+			}
+		})
+	}
+	return p
+}
+
+// Shards returns the number of shards.
+func (p *ShardedProcessor) Shards() int {
+	return len(p.queues)
+}
+
+// Submit queues update on its shard; the handler is called with ctx. It
+// blocks while the queue is full, returning ctx.Err() if ctx ends first,
+// and ErrProcessorClosed after Close.
+func (p *ShardedProcessor) Submit(ctx context.Context, update tg.Update) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrProcessorClosed
+	}
+	select {
+	case p.queues[ShardOf(update, len(p.queues))] <- shardItem{ctx: ctx, update: update}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Run submits the updates received from in until it is closed or ctx is
+// done, then closes p and waits for the queued updates to be handled.
+func (p *ShardedProcessor) Run(ctx context.Context, in <-chan tg.Update) error {
+	defer p.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case update, ok := <-in:
+			if !ok {
+				return nil
+			}
+			if err := p.Submit(ctx, update); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Close stops accepting updates and waits until the queued ones are
+// handled. It is safe to call more than once.
+func (p *ShardedProcessor) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		for _, queue := range p.queues {
+			close(queue)
+		}
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+func (p *ShardedProcessor) process(item shardItem) {
+	if err := p.handle(item.ctx, item.update); err != nil && p.onError != nil {
+		p.onError(item.update, err)
+	}
+}
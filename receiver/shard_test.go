@@ -0,0 +1,122 @@
+package receiver_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func chatUpdate(id int, chatID int64) tg.Update {
+	return tg.Update{UpdateID: id, Message: &tg.Message{MessageID: id, Chat: &tg.Chat{ID: chatID}}}
+}
+
+func TestShardOf(t *testing.T) {
+	// FNV-1a of the decimal chat ID; pinned so producers in other
+	// languages can match it.
+	assert.Equal(t, 5, receiver.ShardOf(chatUpdate(1, -100123), 8))
+	assert.Equal(t, 3, receiver.ShardOf(chatUpdate(2, 42), 8))
+	assert.Equal(t, 5, receiver.ShardOf(tg.Update{UpdateID: 7, InlineQuery: &tg.InlineQuery{ID: "q"}}, 8))
+	assert.Equal(t, 0, receiver.ShardOf(chatUpdate(1, 42), 1))
+	assert.Equal(t, 0, receiver.ShardOf(chatUpdate(1, 42), 0))
+
+	for id := range 100 {
+		assert.Equal(t, receiver.ShardOf(chatUpdate(id, 42), 8), receiver.ShardOf(chatUpdate(id+1000, 42), 8))
+	}
+}
+
+func TestShardedSink(t *testing.T) {
+	chans := []chan tg.Update{make(chan tg.Update, 10), make(chan tg.Update, 10), make(chan tg.Update, 10)}
+	sink := &receiver.ShardedSink{}
+	for _, ch := range chans {
+		sink.Sinks = append(sink.Sinks, receiver.ChannelSink(ch))
+	}
+
+	for i, chatID := range []int64{1, 2, 3, 1, 2, 3} {
+		require.NoError(t, sink.Publish(context.Background(), chatUpdate(i, chatID)))
+	}
+	total := 0
+	for shard, ch := range chans {
+		total += len(ch)
+		for len(ch) > 0 {
+			u := <-ch
+			assert.Equal(t, shard, receiver.ShardOf(u, len(chans)))
+		}
+	}
+	assert.Equal(t, 6, total)
+
+	full := receiver.ChannelSink(make(chan tg.Update))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, full.Publish(ctx, chatUpdate(1, 1)), context.Canceled)
+}
+
+func TestShardedProcessor_PerChatOrder(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		seen     = map[int64][]int{}
+		active   = map[int64]*atomic.Int32{}
+		overlaps atomic.Int32
+	)
+	for chat := range int64(10) {
+		active[chat] = &atomic.Int32{}
+	}
+	handle := func(_ context.Context, u tg.Update) error {
+		chat := u.Message.Chat.ID
+		if active[chat].Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		seen[chat] = append(seen[chat], u.UpdateID)
+		mu.Unlock()
+		active[chat].Add(-1)
+		return nil
+	}
+
+	in := make(chan tg.Update)
+	p := receiver.NewShardedProcessor(receiver.ShardConfig{Shards: 4, Buffer: 2}, handle)
+	assert.Equal(t, 4, p.Shards())
+	done := make(chan error)
+	go func() { done <- p.Run(context.Background(), in) }()
+	for id := range 200 {
+		in <- chatUpdate(id, int64(id%10))
+	}
+	close(in)
+	require.NoError(t, <-done)
+
+	assert.Zero(t, overlaps.Load(), "a chat's updates are handled one at a time")
+	for chat, ids := range seen {
+		assert.IsIncreasing(t, ids, "chat %d", chat)
+		assert.Len(t, ids, 20)
+	}
+	assert.ErrorIs(t, p.Submit(context.Background(), chatUpdate(1, 1)), receiver.ErrProcessorClosed)
+}
+
+func TestShardedProcessor_OnError(t *testing.T) {
+	boom := errors.New("boom")
+	var failed []int
+	p := receiver.NewShardedProcessor(receiver.ShardConfig{
+		Shards:  2,
+		OnError: func(u tg.Update, err error) { failed = append(failed, u.UpdateID); assert.ErrorIs(t, err, boom) },
+	}, func(_ context.Context, u tg.Update) error {
+		if u.UpdateID%2 == 1 {
+			return boom
+		}
+		return nil
+	})
+	for id := range 4 {
+		require.NoError(t, p.Submit(context.Background(), chatUpdate(id, 7)))
+	}
+	p.Close()
+	p.Close()
+	assert.Equal(t, []int{1, 3}, failed)
+}
@@ -0,0 +1,235 @@
+package receiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Update Sinks ==================
+//
+// A sink receives a copy of every update before it is delivered to the
+// updates channel, to fan updates out to other services through a message
+// bus. Updates are serialized as an UpdateEnvelope and keyed by chat ID,
+// so a partitioned bus keeps each chat's updates in order. Updates are
+// published in the background, one at a time and in arrival order, so a
+// slow sink does not hold up delivery to the bot. A failed publish is
+// logged and reported to the dropped-update callback with reason
+// "sink_publish_failed"; an update that finds sinkQueueSize updates
+// already waiting is reported with reason "sink_queue_full".
+//
+// The NATS and Kafka adapters depend on small interfaces instead of the
+// client libraries: *nats.Conn satisfies NATSPublisher as is, and any
+// Kafka client fits KafkaProducer with a few lines of glue.
+
+// UpdateSchemaVersion is the version of the UpdateEnvelope format.
+const UpdateSchemaVersion = 1
+
+const (
+	// sinkQueueSize bounds the updates waiting for a sink.
+	sinkQueueSize = 1024
+	// sinkPublishTimeout bounds one Publish call.
+	sinkPublishTimeout = 30 * time.Second
+)
+
+// UpdateSink publishes updates.
+type UpdateSink interface {
+	Publish(ctx context.Context, update tg.Update) error
+}
+
+// UpdateSinkFunc adapts a function to UpdateSink.
+type UpdateSinkFunc func(ctx context.Context, update tg.Update) error
+
+// Publish calls f.
+func (f UpdateSinkFunc) Publish(ctx context.Context, update tg.Update) error {
+	return f(ctx, update)
+}
+
+// UpdateEnvelope is the serialized form of an update on a message bus.
+type UpdateEnvelope struct {
+	SchemaVersion int       `json:"schema_version"`
+	ReceivedAt    time.Time `json:"received_at"`
+	Update        tg.Update `json:"update"`
+}
+
+// EncodeUpdate serializes update as an UpdateEnvelope.
+func EncodeUpdate(update tg.Update) ([]byte, error) {
+	return json.Marshal(UpdateEnvelope{
+		SchemaVersion: UpdateSchemaVersion,
+		ReceivedAt:    time.Now().UTC(),
+		Update:        update,
+	})
+}
+
+// DecodeUpdate parses an UpdateEnvelope written by EncodeUpdate. Envelopes
+// of a newer schema version are rejected.
+func DecodeUpdate(data []byte) (UpdateEnvelope, error) {
+	var env UpdateEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return env, fmt.Errorf("galigo/receiver: decode update: %w", err)
+	}
+	if env.SchemaVersion < 1 || env.SchemaVersion > UpdateSchemaVersion {
+		return env, fmt.Errorf("galigo/receiver: unsupported update schema version %d", env.SchemaVersion)
+	}
+	return env, nil
+}
+
+// UpdateKey returns the partition key of update: its chat ID, or "" for
+// updates without a chat (inline queries, polls, ...).
+func UpdateKey(update tg.Update) string {
+	if chat := update.EffectiveChat(); chat != nil {
+		return strconv.FormatInt(chat.ID, 10)
+	}
+	return ""
+}
+
+// NATSPublisher publishes a message; *nats.Conn implements it.
+type NATSPublisher interface {
+	Publish(subject string, data []byte) error
+}
+
+// NATSSink publishes updates to NATS on Subject + "." + chat ID, or
+// Subject + ".none" for updates without a chat, so that subscribers and
+// JetStream consumers can filter and order by chat.
+type NATSSink struct {
+	Conn    NATSPublisher
+	Subject string // default "telegram.updates"
+}
+
+// Publish implements UpdateSink.
+func (s *NATSSink) Publish(_ context.Context, update tg.Update) error {
+	data, err := EncodeUpdate(update)
+	if err != nil {
+		return err
+	}
+	subject := s.Subject
+	if subject == "" {
+		subject = "telegram.updates"
+	}
+	key := UpdateKey(update)
+	if key == "" {
+		key = "none"
+	}
+	return s.Conn.Publish(subject+"."+key, data)
+}
+
+// KafkaProducer writes one record. Adapt your Kafka client to it, e.g. for
+// segmentio/kafka-go:
+//
+//	func (p kafkaGo) Produce(ctx context.Context, topic string, key, value []byte) error {
+//	    return p.w.WriteMessages(ctx, kafka.Message{Topic: topic, Key: key, Value: value})
+//	}
+type KafkaProducer interface {
+	Produce(ctx context.Context, topic string, key, value []byte) error
+}
+
+// KafkaSink publishes updates to a Kafka topic keyed by chat ID, so each
+// chat's updates land in one partition, in order. Updates without a chat
+// have a nil key.
+type KafkaSink struct {
+	Producer KafkaProducer
+	Topic    string
+}
+
+// Publish implements UpdateSink.
+func (s *KafkaSink) Publish(ctx context.Context, update tg.Update) error {
+	data, err := EncodeUpdate(update)
+	if err != nil {
+		return err
+	}
+	var key []byte
+	if k := UpdateKey(update); k != "" {
+		key = []byte(k)
+	}
+	return s.Producer.Produce(ctx, s.Topic, key, data)
+}
+
+// WithPollingSink publishes every polled update to sink in the
+// background, in the order the updates arrive.
+func WithPollingSink(sink UpdateSink) PollingOption {
+	return func(c *PollingClient) {
+		c.sink = newSinkQueue(sink)
+	}
+}
+
+// WithWebhookSink publishes every received update to sink in the
+// background, in the order the updates arrive.
+func WithWebhookSink(sink UpdateSink) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.sink = newSinkQueue(sink)
+	}
+}
+
+// sinkQueue publishes updates to a sink from a worker goroutine. The
+// worker runs only while updates are waiting, so an idle queue holds no
+// goroutine and needs no Close.
+type sinkQueue struct {
+	sink UpdateSink
+
+	mu      sync.Mutex
+	pending []tg.Update
+	running bool
+}
+
+func newSinkQueue(sink UpdateSink) *sinkQueue {
+	if sink == nil {
+		return nil
+	}
+	return &sinkQueue{sink: sink}
+}
+
+// publish queues update for the sink without waiting for it. The worker
+// uses ctx's values but not its cancellation, since ctx usually ends
+// (webhook request done, polling stopped) before the sink gets to update.
+func (q *sinkQueue) publish(ctx context.Context, update tg.Update, logger *slog.Logger, onDropped func(int, string)) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	if len(q.pending) >= sinkQueueSize {
+		q.mu.Unlock()
+		logger.Warn("update sink queue full, update not published", "update_id", update.UpdateID)
+		if onDropped != nil {
+			onDropped(update.UpdateID, "sink_queue_full")
+		}
+		return
+	}
+	q.pending = append(q.pending, update)
+	if !q.running {
+		q.running = true
+		go q.drain(context.WithoutCancel(ctx), logger, onDropped)
+	}
+	q.mu.Unlock()
+}
+
+// drain publishes queued updates until the queue is empty.
+func (q *sinkQueue) drain(ctx context.Context, logger *slog.Logger, onDropped func(int, string)) {
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			q.pending = nil // release the backing array
+			q.running = false
+			q.mu.Unlock()
+			return
+		}
+		update := q.pending[0]
+		q.pending = q.pending[1:]
+		q.mu.Unlock()
+
+		pubCtx, cancel := context.WithTimeout(ctx, sinkPublishTimeout)
+		err := q.sink.Publish(pubCtx, update)
+		cancel()
+		if err != nil {
+			logger.Warn("update sink publish failed", "update_id", update.UpdateID, "error", err)
+			if onDropped != nil {
+				onDropped(update.UpdateID, "sink_publish_failed")
+			}
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package receiver_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+type fakeNATS struct {
+	subjects []string
+	data     [][]byte
+}
+
+func (f *fakeNATS) Publish(subject string, data []byte) error {
+	f.subjects = append(f.subjects, subject)
+	f.data = append(f.data, data)
+	return nil
+}
+
+type fakeKafka struct {
+	topic string
+	keys  [][]byte
+}
+
+func (f *fakeKafka) Produce(_ context.Context, topic string, key, _ []byte) error {
+	f.topic = topic
+	f.keys = append(f.keys, key)
+	return nil
+}
+
+func TestUpdateKey(t *testing.T) {
+	chat := &tg.Chat{ID: -100123}
+	assert.Equal(t, "-100123", receiver.UpdateKey(tg.Update{Message: &tg.Message{Chat: chat}}))
+	assert.Equal(t, "-100123", receiver.UpdateKey(tg.Update{CallbackQuery: &tg.CallbackQuery{Message: &tg.Message{Chat: chat}}}))
+	assert.Equal(t, "-100123", receiver.UpdateKey(tg.Update{ChatBoost: &tg.ChatBoostUpdated{Chat: *chat}}))
+	assert.Equal(t, "-100123", receiver.UpdateKey(tg.Update{MessageReactionCount: &tg.MessageReactionCountUpdated{Chat: chat}}))
+	assert.Equal(t, "", receiver.UpdateKey(tg.Update{InlineQuery: &tg.InlineQuery{ID: "q"}}))
+}
+
+func TestEncodeDecodeUpdate(t *testing.T) {
+	data, err := receiver.EncodeUpdate(tg.Update{UpdateID: 42})
+	require.NoError(t, err)
+
+	env, err := receiver.DecodeUpdate(data)
+	require.NoError(t, err)
+	assert.Equal(t, receiver.UpdateSchemaVersion, env.SchemaVersion)
+	assert.Equal(t, 42, env.Update.UpdateID)
+	assert.False(t, env.ReceivedAt.IsZero())
+
+	_, err = receiver.DecodeUpdate([]byte(`{"schema_version":99,"update":{"update_id":1}}`))
+	assert.Error(t, err)
+}
+
+func TestNATSSink_SubjectPerChat(t *testing.T) {
+	conn := &fakeNATS{}
+	sink := &receiver.NATSSink{Conn: conn}
+
+	require.NoError(t, sink.Publish(context.Background(), tg.Update{Message: &tg.Message{Chat: &tg.Chat{ID: 7}}}))
+	require.NoError(t, sink.Publish(context.Background(), tg.Update{InlineQuery: &tg.InlineQuery{ID: "q"}}))
+
+	assert.Equal(t, []string{"telegram.updates.7", "telegram.updates.none"}, conn.subjects)
+}
+
+func TestKafkaSink_KeyedByChat(t *testing.T) {
+	producer := &fakeKafka{}
+	sink := &receiver.KafkaSink{Producer: producer, Topic: "updates"}
+
+	require.NoError(t, sink.Publish(context.Background(), tg.Update{Message: &tg.Message{Chat: &tg.Chat{ID: 7}}}))
+	require.NoError(t, sink.Publish(context.Background(), tg.Update{InlineQuery: &tg.InlineQuery{ID: "q"}}))
+
+	assert.Equal(t, "updates", producer.topic)
+	assert.Equal(t, [][]byte{[]byte("7"), nil}, producer.keys)
+}
+
+func TestWebhookSink_PublishFailureStillDelivers(t *testing.T) {
+	var mu sync.Mutex
+	var reasons []string
+	cfg := testConfig()
+	cfg.OnUpdateDropped = func(_ int, reason string) {
+		mu.Lock()
+		defer mu.Unlock()
+		reasons = append(reasons, reason)
+	}
+
+	failing := receiver.UpdateSinkFunc(func(context.Context, tg.Update) error {
+		return errors.New("bus down")
+	})
+	updates := make(chan tg.Update, 1)
+	handler := receiver.NewWebhookHandler(testLogger(), updates, cfg, receiver.WithWebhookSink(failing))
+
+	body, _ := json.Marshal(tg.Update{UpdateID: 5})
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "test-secret-token")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 5, (<-updates).UpdateID)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(reasons) == 1 && reasons[0] == "sink_publish_failed"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestWebhookSink_SlowSinkDoesNotBlockDelivery(t *testing.T) {
+	release := make(chan struct{})
+	published := make(chan int, 3)
+	slow := receiver.UpdateSinkFunc(func(_ context.Context, update tg.Update) error {
+		<-release
+		published <- update.UpdateID
+		return nil
+	})
+	updates := make(chan tg.Update, 3)
+	handler := receiver.NewWebhookHandler(testLogger(), updates, testConfig(), receiver.WithWebhookSink(slow))
+
+	for _, id := range []int{1, 2, 3} {
+		body, _ := json.Marshal(tg.Update{UpdateID: id})
+		req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "test-secret-token")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, id, (<-updates).UpdateID, "delivered while the sink is stuck")
+	}
+
+	close(release)
+	for _, id := range []int{1, 2, 3} {
+		select {
+		case got := <-published:
+			assert.Equal(t, id, got, "published in arrival order")
+		case <-time.After(time.Second):
+			t.Fatal("update not published")
+		}
+	}
+}
+
+func TestWithRecordUpdates_WritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	updates := make(chan tg.Update, 2)
+	handler := receiver.NewWebhookHandler(testLogger(), updates, testConfig(), receiver.WithWebhookRecordUpdates(&buf))
+
+	for _, id := range []int{1, 2} {
+		body, _ := json.Marshal(tg.Update{UpdateID: id})
+		req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "test-secret-token")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	for i, line := range lines {
+		env, err := receiver.DecodeUpdate(line)
+		require.NoError(t, err)
+		assert.Equal(t, i+1, env.Update.UpdateID)
+	}
+}
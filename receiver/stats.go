@@ -0,0 +1,182 @@
+package receiver
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Polling Stats ==================
+//
+// PollingClient counts every successful getUpdates call and measures how
+// old each update is when it is handed to the updates channel, so lag can
+// be told apart from a quiet bot: a growing SinceLastPoll means polling is
+// stuck, growing update ages with a full channel mean the consumer is
+// slow.
+
+// updateAgeBounds are the upper bounds of the UpdateAge histogram.
+// Telegram dates have one-second resolution, so finer buckets would only
+// measure clock skew.
+var updateAgeBounds = [...]time.Duration{
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	5 * time.Minute,
+}
+
+// PollingStats is a snapshot of a PollingClient's counters.
+type PollingStats struct {
+	Polls         int64         // successful getUpdates calls
+	EmptyPolls    int64         // successful calls that returned no updates
+	Updates       int64         // updates received
+	LastBatch     int           // updates returned by the last successful call
+	LastPollAt    time.Time     // zero before the first successful call
+	SinceLastPoll time.Duration // time since LastPollAt; 0 before the first call
+	UpdateAge     Histogram     // delay from an update's date to its delivery
+	QueueLen      int           // updates buffered in the updates channel
+	QueueCap      int           // capacity of the updates channel
+}
+
+// AvgBatch returns the mean number of updates per successful poll.
+func (s PollingStats) AvgBatch() float64 {
+	if s.Polls == 0 {
+		return 0
+	}
+	return float64(s.Updates) / float64(s.Polls)
+}
+
+// QueueOccupancy returns the share of the updates channel in use (0-1).
+func (s PollingStats) QueueOccupancy() float64 {
+	if s.QueueCap == 0 {
+		return 0
+	}
+	return float64(s.QueueLen) / float64(s.QueueCap)
+}
+
+// Histogram counts durations into fixed buckets.
+type Histogram struct {
+	// Bounds are the buckets' upper bounds, ascending.
+	Bounds []time.Duration
+	// Counts[i] counts values up to Bounds[i]; the extra last entry
+	// counts values above every bound.
+	Counts []int64
+	Sum    time.Duration
+	Max    time.Duration
+}
+
+// Total returns the number of recorded values.
+func (h Histogram) Total() int64 {
+	var n int64
+	for _, c := range h.Counts {
+		n += c
+	}
+	return n
+}
+
+// Mean returns the mean recorded value.
+func (h Histogram) Mean() time.Duration {
+	n := h.Total()
+	if n == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(n)
+}
+
+// Quantile returns an upper estimate of quantile q (0-1): the bound of
+// the bucket it falls in, or Max if it is above every bound.
+func (h Histogram) Quantile(q float64) time.Duration {
+	total := h.Total()
+	if total == 0 {
+		return 0
+	}
+	rank := int64(q * float64(total))
+	var seen int64
+	for i, c := range h.Counts {
+		seen += c
+		if seen > rank && i < len(h.Bounds) {
+			return h.Bounds[i]
+		}
+	}
+	return h.Max
+}
+
+// pollingStats holds the live counters behind PollingStats.
+type pollingStats struct {
+	polls      atomic.Int64
+	emptyPolls atomic.Int64
+	updates    atomic.Int64
+	lastBatch  atomic.Int64
+	lastPoll   atomic.Int64 // unix nanos of the last successful call
+
+	ageCounts [len(updateAgeBounds) + 1]atomic.Int64
+	ageSum    atomic.Int64
+	ageMax    atomic.Int64
+}
+
+func (s *pollingStats) recordPoll(batch int, now time.Time) {
+	s.polls.Add(1)
+	if batch == 0 {
+		s.emptyPolls.Add(1)
+	}
+	s.updates.Add(int64(batch))
+	s.lastBatch.Store(int64(batch))
+	s.lastPoll.Store(now.UnixNano())
+}
+
+// recordAge records how old update is at now. Updates without a date are
+// skipped.
+func (s *pollingStats) recordAge(update *tg.Update, now time.Time) {
+	date := update.Date()
+	if date == 0 {
+		return
+	}
+	age := max(now.Sub(time.Unix(date, 0)), 0)
+	i := 0
+	for i < len(updateAgeBounds) && age > updateAgeBounds[i] {
+		i++
+	}
+	s.ageCounts[i].Add(1)
+	s.ageSum.Add(int64(age))
+	for {
+		cur := s.ageMax.Load()
+		if int64(age) <= cur || s.ageMax.CompareAndSwap(cur, int64(age)) {
+			break
+		}
+	}
+}
+
+func (s *pollingStats) snapshot(now time.Time) PollingStats {
+	stats := PollingStats{
+		Polls:      s.polls.Load(),
+		EmptyPolls: s.emptyPolls.Load(),
+		Updates:    s.updates.Load(),
+		LastBatch:  int(s.lastBatch.Load()),
+		UpdateAge: Histogram{
+			Bounds: updateAgeBounds[:],
+			Counts: make([]int64, len(s.ageCounts)),
+			Sum:    time.Duration(s.ageSum.Load()),
+			Max:    time.Duration(s.ageMax.Load()),
+		},
+	}
+	if last := s.lastPoll.Load(); last != 0 {
+		stats.LastPollAt = time.Unix(0, last)
+		stats.SinceLastPoll = now.Sub(stats.LastPollAt)
+	}
+	for i := range s.ageCounts {
+		stats.UpdateAge.Counts[i] = s.ageCounts[i].Load()
+	}
+	return stats
+}
+
+// Stats returns a snapshot of the polling counters and the occupancy of
+// the updates channel. It is safe to call while polling.
+func (c *PollingClient) Stats() PollingStats {
+	stats := c.stats.snapshot(c.clock.Now())
+	stats.QueueLen = len(c.updates)
+	stats.QueueCap = cap(c.updates)
+	return stats
+}
@@ -0,0 +1,83 @@
+package receiver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestPolling_Stats(t *testing.T) {
+	now := time.Now().Unix()
+	var requestCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result := []any{}
+		if requestCount.Add(1) == 1 {
+			chat := map[string]any{"id": 123, "type": "private"}
+			result = []any{
+				map[string]any{"update_id": 1, "message": map[string]any{"message_id": 1, "date": now - 20, "chat": chat}},
+				map[string]any{"update_id": 2, "message": map[string]any{"message_id": 2, "date": now, "chat": chat}},
+				map[string]any{"update_id": 3, "callback_query": map[string]any{"id": "c", "chat_instance": "x"}},
+			}
+		} else {
+			time.Sleep(10 * time.Millisecond)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+	}))
+	defer server.Close()
+
+	updates := make(chan tg.Update, 10)
+	cfg := pollingTestConfig()
+	cfg.BaseURL = server.URL + "/bot"
+	client := receiver.NewPollingClient(tg.SecretToken("test:token"), updates, pollingTestLogger(), cfg)
+
+	stats := client.Stats()
+	assert.Zero(t, stats.Polls)
+	assert.True(t, stats.LastPollAt.IsZero())
+	assert.Equal(t, 10, stats.QueueCap)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, client.Start(ctx))
+	defer client.Stop()
+
+	require.Eventually(t, func() bool { return client.Stats().Polls >= 2 }, time.Second, 5*time.Millisecond)
+	stats = client.Stats()
+	assert.Equal(t, int64(3), stats.Updates)
+	assert.GreaterOrEqual(t, stats.EmptyPolls, int64(1))
+	assert.Equal(t, 3, stats.QueueLen)
+	assert.InDelta(t, 0.3, stats.QueueOccupancy(), 0.001)
+	assert.False(t, stats.LastPollAt.IsZero())
+	assert.Less(t, stats.SinceLastPoll, time.Second)
+
+	// The callback query has no date and is not counted.
+	age := stats.UpdateAge
+	assert.Equal(t, int64(2), age.Total())
+	assert.Equal(t, int64(1), age.Counts[0]+age.Counts[1]) // recent message
+	assert.Equal(t, int64(1), age.Counts[4])               // 10s < age <= 30s
+	assert.GreaterOrEqual(t, age.Max, 20*time.Second)
+}
+
+func TestHistogram(t *testing.T) {
+	h := receiver.Histogram{
+		Bounds: []time.Duration{time.Second, 10 * time.Second},
+		Counts: []int64{6, 3, 1},
+		Sum:    50 * time.Second,
+		Max:    20 * time.Second,
+	}
+	assert.Equal(t, int64(10), h.Total())
+	assert.Equal(t, 5*time.Second, h.Mean())
+	assert.Equal(t, time.Second, h.Quantile(0.5))
+	assert.Equal(t, 10*time.Second, h.Quantile(0.85))
+	assert.Equal(t, 20*time.Second, h.Quantile(0.99))
+	assert.Zero(t, receiver.Histogram{}.Quantile(0.5))
+}
@@ -1,21 +1,19 @@
 package receiver
 
 import (
+	"bytes"
 	"context"
-	"crypto/subtle"
-	"encoding/json"
 	"errors"
-	"io"
 	"log/slog"
 	"net"
 	"net/http"
-	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/sony/gobreaker/v2"
 	"golang.org/x/time/rate"
 
+	"github.com/prilive-com/galigo/clock"
 	"github.com/prilive-com/galigo/tg"
 )
 
@@ -24,7 +22,7 @@ var _ http.Handler = (*WebhookHandler)(nil)
 // WebhookHandler implements http.Handler for Telegram webhook callbacks.
 type WebhookHandler struct {
 	logger        *slog.Logger
-	webhookSecret string
+	webhookSecret tg.SecretToken
 	allowedDomain string
 	updates       chan<- tg.Update
 	updatesBidi   chan tg.Update // bidirectional ref for DropOldest; may be nil
@@ -33,10 +31,27 @@ type WebhookHandler struct {
 	deliveryTimeout time.Duration
 	onUpdateDropped func(int, string)
 
+	// Per-update context derivation
+	baseCtx  atomic.Pointer[context.Context]
+	decorate ContextDecorator
+
+	// Optional copy of every update to a message bus
+	sink *sinkQueue
+
+	// Optional JSON Lines recording of every update
+	recorder *UpdateRecorder
+
 	limiter     *rate.Limiter
+	ipLimiters  *ipLimiters
+	clientIP    func(*http.Request) string
+	inflight    inflightLimit
 	breaker     *gobreaker.CircuitBreaker[any]
-	bufferPool  sync.Pool
 	maxBodySize int64
+	keepRaw     bool
+	decoder     tg.Decoder
+	clock       clock.Clock
+
+	lastUpdate atomic.Int64 // unix nanos of the last accepted update
 }
 
 // WebhookOption configures the WebhookHandler.
@@ -60,12 +75,22 @@ func WithWebhookCircuitBreaker(breaker *gobreaker.CircuitBreaker[any]) WebhookOp
 func WithWebhookMaxBodySize(size int64) WebhookOption {
 	return func(h *WebhookHandler) {
 		h.maxBodySize = size
-		h.bufferPool = sync.Pool{
-			New: func() interface{} {
-				b := make([]byte, size)
-				return &b
-			},
-		}
+	}
+}
+
+// WithWebhookClock sets the clock used for per-IP rate limits and
+// LastUpdate.
+func WithWebhookClock(clk clock.Clock) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.clock = clk
+	}
+}
+
+// WithWebhookDecoder sets how update bodies are decoded. Use
+// tg.StrictDecoder in tests to fail on fields galigo does not model.
+func WithWebhookDecoder(d tg.Decoder) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.decoder = d
 	}
 }
 
@@ -83,7 +108,7 @@ func NewWebhookHandler(
 
 	h := &WebhookHandler{
 		logger:          logger,
-		webhookSecret:   cfg.WebhookSecret,
+		webhookSecret:   tg.SecretToken(cfg.WebhookSecret),
 		allowedDomain:   cfg.AllowedDomain,
 		updates:         updates,
 		updatesBidi:     updates,
@@ -91,13 +116,12 @@ func NewWebhookHandler(
 		deliveryTimeout: cfg.UpdateDeliveryTimeout,
 		onUpdateDropped: cfg.OnUpdateDropped,
 		limiter:         rate.NewLimiter(rate.Limit(cfg.RateLimitRequests), cfg.RateLimitBurst),
+		ipLimiters:      newIPLimiters(cfg.PerIPRateLimit, cfg.PerIPBurst, 0),
+		clientIP:        remoteIP,
+		inflight:        newInflightLimit(cfg.MaxConcurrentRequests),
 		maxBodySize:     cfg.MaxBodySize,
-		bufferPool: sync.Pool{
-			New: func() interface{} {
-				b := make([]byte, cfg.MaxBodySize)
-				return &b
-			},
-		},
+		keepRaw:         cfg.KeepRawUpdates,
+		clock:           clock.Real,
 	}
 
 	// Default circuit breaker
@@ -117,12 +141,22 @@ func NewWebhookHandler(
 
 // ServeHTTP implements http.Handler.
 func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// Rate limit check (outside breaker)
+	// Rate limit checks (outside breaker): per client IP, then global
+	if !h.ipLimiters.allow(h.clientIP(r), h.clock.Now()) {
+		h.fail(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
 	if !h.limiter.Allow() {
 		h.fail(w, "rate limit exceeded", http.StatusTooManyRequests)
 		return
 	}
 
+	// Reject declared oversized bodies before reading them
+	if r.ContentLength > h.maxBodySize {
+		h.fail(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
 	// P0.6 FIX: Authentication checks OUTSIDE circuit breaker
 	// This prevents attackers from tripping the breaker with bad credentials
 
@@ -139,9 +173,8 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Secret validation (constant-time comparison)
-	if h.webhookSecret != "" {
-		secret := r.Header.Get("X-Telegram-Bot-Api-Secret-Token")
-		if subtle.ConstantTimeCompare([]byte(secret), []byte(h.webhookSecret)) != 1 {
+	if !h.webhookSecret.IsEmpty() {
+		if !h.webhookSecret.ConstantTimeEquals(r.Header.Get("X-Telegram-Bot-Api-Secret-Token")) {
 			h.fail(w, "unauthorized", http.StatusUnauthorized)
 			return
 		}
@@ -153,8 +186,16 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Bound the requests being read and delivered at once
+	if !h.inflight.acquire() {
+		w.Header().Set("Retry-After", "1")
+		h.fail(w, "too many concurrent requests", http.StatusServiceUnavailable)
+		return
+	}
+	defer h.inflight.release()
+
 	// Only downstream processing inside circuit breaker
-	_, err := h.breaker.Execute(func() (interface{}, error) {
+	_, err := h.breaker.Execute(func() (any, error) {
 		return nil, h.processUpdate(w, r)
 	})
 
@@ -170,22 +211,30 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.lastUpdate.Store(h.clock.Now().UnixNano())
 	w.WriteHeader(http.StatusOK)
 }
 
+// LastUpdate returns when the handler last accepted an update, or the zero
+// time if it has not received any.
+func (h *WebhookHandler) LastUpdate() time.Time {
+	ns := h.lastUpdate.Load()
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
 // processUpdate handles the actual update processing (inside circuit breaker)
 func (h *WebhookHandler) processUpdate(w http.ResponseWriter, r *http.Request) error {
-	// Get pooled buffer
-	bufPtr, ok := h.bufferPool.Get().(*[]byte)
-	if !ok {
-		return errors.New("invalid buffer from pool")
+	// Read body with size limit into a pooled buffer, sized by the
+	// declared length when there is one
+	buf := getBodyBuffer()
+	defer putBodyBuffer(buf)
+	if r.ContentLength > 0 {
+		buf.Grow(int(r.ContentLength) + bytes.MinRead)
 	}
-	buffer := *bufPtr
-	defer h.bufferPool.Put(bufPtr)
-
-	// Read body with size limit
-	r.Body = http.MaxBytesReader(w, r.Body, h.maxBodySize)
-	body, err := io.ReadAll(r.Body)
+	_, err := buf.ReadFrom(http.MaxBytesReader(w, r.Body, h.maxBodySize))
 	if err != nil {
 		// P0.5 FIX: Return 413 for oversized body
 		var maxBytesErr *http.MaxBytesError
@@ -196,12 +245,16 @@ func (h *WebhookHandler) processUpdate(w http.ResponseWriter, r *http.Request) e
 	}
 	defer r.Body.Close()
 
-	// Copy to buffer for potential reuse
-	n := copy(buffer, body)
-
-	// Parse update
+	// Parse update; nothing may keep a reference to the pooled buffer
+	body := buf.Bytes()
 	var update tg.Update
-	if err := json.Unmarshal(buffer[:n], &update); err != nil {
+	if h.keepRaw {
+		update, err = tg.UnmarshalUpdateWithRaw(body) // copies out of the pooled buffer
+		err = h.decoder.Wrap(body, err)
+	} else {
+		err = h.decoder.Decode(body, &update)
+	}
+	if err != nil {
 		return &WebhookError{Code: http.StatusBadRequest, Message: "invalid JSON", Err: err}
 	}
 
@@ -215,6 +268,9 @@ func (h *WebhookHandler) processUpdate(w http.ResponseWriter, r *http.Request) e
 // Always returns nil to ensure Telegram gets 200 OK (prevents retry storms).
 // Errors are only returned for actual processing failures (bad JSON, oversized body).
 func (h *WebhookHandler) deliverUpdate(ctx context.Context, update tg.Update) error {
+	recordUpdate(h.recorder, update, h.logger)
+	h.sink.publish(ctx, update, h.logger, h.onUpdateDropped)
+
 	switch h.deliveryPolicy {
 	case DeliveryPolicyBlock:
 		return h.webhookDeliverBlocking(ctx, update)
@@ -229,6 +285,14 @@ func (h *WebhookHandler) deliverUpdate(ctx context.Context, update tg.Update) er
 
 // webhookDeliverBlocking waits for channel space with optional timeout.
 func (h *WebhookHandler) webhookDeliverBlocking(ctx context.Context, update tg.Update) error {
+	// Fast path: the channel has room, no timer needed
+	select {
+	case h.updates <- update:
+		h.logger.Debug("update forwarded", "update_id", update.UpdateID)
+		return nil
+	default:
+	}
+
 	deliveryCtx := ctx
 	var cancel context.CancelFunc
 
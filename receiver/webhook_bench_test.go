@@ -0,0 +1,79 @@
+package receiver_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// benchResponseWriter is a reusable http.ResponseWriter discarding the
+// response.
+type benchResponseWriter struct {
+	header http.Header
+	code   int
+}
+
+func (w *benchResponseWriter) Header() http.Header         { return w.header }
+func (w *benchResponseWriter) Write(p []byte) (int, error) { return len(p), nil }
+func (w *benchResponseWriter) WriteHeader(code int)        { w.code = code }
+
+// rewindBody is a request body that can be read again after Reset.
+type rewindBody struct{ *bytes.Reader }
+
+func (rewindBody) Close() error { return nil }
+
+func BenchmarkWebhook_ServeHTTP(b *testing.B) {
+	body, err := json.Marshal(map[string]any{
+		"update_id": 1,
+		"message": map[string]any{
+			"message_id": 1,
+			"date":       1700000000,
+			"chat":       map[string]any{"id": 12345, "type": "private", "first_name": "Alice"},
+			"from":       map[string]any{"id": 12345, "is_bot": false, "first_name": "Alice", "language_code": "en"},
+			"text":       "/start hello there, this is a typical message",
+			"entities":   []map[string]any{{"type": "bot_command", "offset": 0, "length": 6}},
+		},
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	cfg := testConfig()
+	cfg.RateLimitRequests = 1e9
+	cfg.RateLimitBurst = 1e9
+	cfg.PerIPRateLimit = 0
+	updates := make(chan tg.Update, 1024)
+	handler := receiver.NewWebhookHandler(testLogger(), updates, cfg)
+	go func() {
+		for range updates {
+		}
+	}()
+	defer close(updates)
+
+	req, err := http.NewRequest(http.MethodPost, "https://bot.example.com/webhook", nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	req.RemoteAddr = "149.154.167.197:443"
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "test-secret-token")
+	reader := bytes.NewReader(body)
+	w := &benchResponseWriter{header: make(http.Header)}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	for b.Loop() {
+		reader.Reset(body)
+		req.Body = rewindBody{reader}
+		req.ContentLength = int64(len(body))
+		w.code = 0
+		handler.ServeHTTP(w, req)
+		if w.code != http.StatusOK {
+			b.Fatalf("status %d", w.code)
+		}
+	}
+}
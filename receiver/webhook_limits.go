@@ -0,0 +1,142 @@
+package receiver
+
+import (
+	"container/list"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ================== Webhook Self-Protection ==================
+//
+// Besides the global rate limiter and the body size cap, the webhook
+// handler can bound the requests it works on at once and rate limit each
+// client IP. Rejections use 429 or 503, which Telegram retries later, so
+// no update is lost while the handler sheds load.
+
+// DefaultMaxIPLimiters caps the per-IP limiters kept when
+// WithWebhookPerIPRateLimit is given maxIPs <= 0.
+const DefaultMaxIPLimiters = 10000
+
+// WithWebhookMaxConcurrent limits the requests processed at once (body
+// read, decoding and delivery). Requests beyond n get 503 with a
+// Retry-After header. 0 means unlimited.
+func WithWebhookMaxConcurrent(n int) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.inflight = newInflightLimit(n)
+	}
+}
+
+// WithWebhookPerIPRateLimit limits each client IP to rps requests per
+// second with the given burst, on top of the global limit. Requests over
+// the limit get 429. Limiters of up to maxIPs addresses are kept, the
+// least recently seen evicted first (maxIPs <= 0 = DefaultMaxIPLimiters).
+// rps <= 0 disables the limit.
+func WithWebhookPerIPRateLimit(rps float64, burst, maxIPs int) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.ipLimiters = newIPLimiters(rps, burst, maxIPs)
+	}
+}
+
+// WithWebhookClientIP sets how the client IP used for per-IP rate
+// limiting is found. The default is the host of r.RemoteAddr; behind a
+// reverse proxy, read the header the proxy sets instead.
+func WithWebhookClientIP(fn func(r *http.Request) string) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.clientIP = fn
+	}
+}
+
+// remoteIP returns the host part of r.RemoteAddr.
+func remoteIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// inflightLimit is a non-blocking semaphore; nil means unlimited.
+type inflightLimit chan struct{}
+
+func newInflightLimit(n int) inflightLimit {
+	if n <= 0 {
+		return nil
+	}
+	return make(inflightLimit, n)
+}
+
+func (l inflightLimit) acquire() bool {
+	if l == nil {
+		return true
+	}
+	select {
+	case l <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (l inflightLimit) release() {
+	if l != nil {
+		<-l
+	}
+}
+
+// ipLimiters holds a token bucket per client IP; nil means no limit.
+type ipLimiters struct {
+	rps   rate.Limit
+	burst int
+	max   int
+
+	mu       sync.Mutex
+	limiters map[string]*list.Element // value *ipLimiterEntry
+	lru      *list.List               // front = most recently seen
+}
+
+type ipLimiterEntry struct {
+	ip      string
+	limiter *rate.Limiter
+}
+
+func newIPLimiters(rps float64, burst, maxIPs int) *ipLimiters {
+	if rps <= 0 {
+		return nil
+	}
+	if maxIPs <= 0 {
+		maxIPs = DefaultMaxIPLimiters
+	}
+	return &ipLimiters{
+		rps:      rate.Limit(rps),
+		burst:    max(burst, 1),
+		max:      maxIPs,
+		limiters: make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// allow reports whether ip may make a request now.
+func (l *ipLimiters) allow(ip string, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elem, ok := l.limiters[ip]
+	if ok {
+		l.lru.MoveToFront(elem)
+	} else {
+		if l.lru.Len() >= l.max {
+			oldest := l.lru.Back()
+			l.lru.Remove(oldest)
+			delete(l.limiters, oldest.Value.(*ipLimiterEntry).ip)
+		}
+		elem = l.lru.PushFront(&ipLimiterEntry{ip: ip, limiter: rate.NewLimiter(l.rps, l.burst)})
+		l.limiters[ip] = elem
+	}
+	return elem.Value.(*ipLimiterEntry).limiter.AllowN(now, 1)
+}
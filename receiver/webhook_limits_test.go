@@ -0,0 +1,183 @@
+package receiver_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func webhookRequest(remoteAddr string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader([]byte(`{"update_id":1}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "test-secret-token")
+	req.RemoteAddr = remoteAddr
+	return req
+}
+
+// blockingBody signals when the handler starts reading it and then
+// blocks until release is closed.
+type blockingBody struct {
+	reading chan struct{}
+	release chan struct{}
+	r       *bytes.Reader
+}
+
+func (b *blockingBody) Read(p []byte) (int, error) {
+	select {
+	case <-b.reading:
+	default:
+		close(b.reading)
+	}
+	<-b.release
+	return b.r.Read(p)
+}
+
+func TestWebhook_MaxConcurrent_Returns503(t *testing.T) {
+	updates := make(chan tg.Update, 10)
+	handler := receiver.NewWebhookHandler(testLogger(), updates, testConfig(),
+		receiver.WithWebhookMaxConcurrent(1))
+
+	body := &blockingBody{
+		reading: make(chan struct{}),
+		release: make(chan struct{}),
+		r:       bytes.NewReader([]byte(`{"update_id":1}`)),
+	}
+	first := make(chan int)
+	go func() {
+		req := webhookRequest("149.154.160.1:1234")
+		req.Body = io.NopCloser(body)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		first <- rec.Code
+	}()
+	<-body.reading // the first request holds the only slot
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, webhookRequest("149.154.160.1:1235"))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	close(body.release)
+	assert.Equal(t, http.StatusOK, <-first)
+
+	// The slot is free again.
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, webhookRequest("149.154.160.1:1236"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, updates, 2)
+}
+
+func TestWebhook_PerIPRateLimit(t *testing.T) {
+	updates := make(chan tg.Update, 10)
+	handler := receiver.NewWebhookHandler(testLogger(), updates, testConfig(),
+		receiver.WithWebhookPerIPRateLimit(1, 1, 0))
+
+	codes := func(addr string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, webhookRequest(addr))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, codes("203.0.113.7:1000"))
+	assert.Equal(t, http.StatusTooManyRequests, codes("203.0.113.7:1001"), "same IP, new port")
+	assert.Equal(t, http.StatusOK, codes("149.154.167.99:1000"), "other IPs keep their own budget")
+}
+
+func TestWebhook_PerIPRateLimit_FromConfig(t *testing.T) {
+	updates := make(chan tg.Update, 10)
+	cfg := testConfig()
+	cfg.PerIPRateLimit = 1
+	cfg.PerIPBurst = 2
+	handler := receiver.NewWebhookHandler(testLogger(), updates, cfg)
+
+	var got []int
+	for range 3 {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, webhookRequest("203.0.113.7:1000"))
+		got = append(got, rec.Code)
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, got)
+}
+
+func TestWebhook_PerIPRateLimit_EvictsLeastRecentIP(t *testing.T) {
+	updates := make(chan tg.Update, 10)
+	handler := receiver.NewWebhookHandler(testLogger(), updates, testConfig(),
+		receiver.WithWebhookPerIPRateLimit(0.001, 1, 1))
+
+	serve := func(addr string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, webhookRequest(addr))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve("203.0.113.1:1"))
+	assert.Equal(t, http.StatusOK, serve("203.0.113.2:1")) // evicts .1
+	assert.Equal(t, http.StatusOK, serve("203.0.113.1:1"), "evicted IP starts with a fresh bucket")
+}
+
+func TestWebhook_PerIPRateLimit_EvictionFollowsRecency(t *testing.T) {
+	updates := make(chan tg.Update, 10)
+	handler := receiver.NewWebhookHandler(testLogger(), updates, testConfig(),
+		receiver.WithWebhookPerIPRateLimit(0.001, 1, 2))
+
+	serve := func(addr string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, webhookRequest(addr))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve("203.0.113.1:1"))
+	assert.Equal(t, http.StatusOK, serve("203.0.113.2:1"))
+	assert.Equal(t, http.StatusTooManyRequests, serve("203.0.113.1:1")) // .1 now most recent
+	assert.Equal(t, http.StatusOK, serve("203.0.113.3:1"))              // evicts .2
+	assert.Equal(t, http.StatusTooManyRequests, serve("203.0.113.1:1"), "recently seen IP kept")
+	assert.Equal(t, http.StatusOK, serve("203.0.113.2:1"), "least recent IP evicted")
+}
+
+func TestWebhook_ClientIP(t *testing.T) {
+	updates := make(chan tg.Update, 10)
+	handler := receiver.NewWebhookHandler(testLogger(), updates, testConfig(),
+		receiver.WithWebhookPerIPRateLimit(1, 1, 0),
+		receiver.WithWebhookClientIP(func(r *http.Request) string { return r.Header.Get("X-Real-IP") }),
+	)
+
+	serve := func(ip string) int {
+		req := webhookRequest("10.0.0.1:443") // the proxy
+		req.Header.Set("X-Real-IP", ip)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve("149.154.160.1"))
+	assert.Equal(t, http.StatusOK, serve("149.154.160.2"))
+	assert.Equal(t, http.StatusTooManyRequests, serve("149.154.160.1"))
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("body must not be read") }
+
+func TestWebhook_DeclaredOversizedBody_RejectedUnread(t *testing.T) {
+	updates := make(chan tg.Update, 10)
+	cfg := testConfig()
+	cfg.MaxBodySize = 100
+	handler := receiver.NewWebhookHandler(testLogger(), updates, cfg)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", failingReader{})
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "test-secret-token")
+	req.ContentLength = 101
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
@@ -177,6 +177,23 @@ func TestWebhook_EmptyBody_Returns400(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
 
+func TestWebhook_StrictDecoder_UnknownField_Returns400(t *testing.T) {
+	body := []byte(`{"update_id":1,"future_update":{}}`)
+	post := func(handler *receiver.WebhookHandler) int {
+		req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "test-secret-token")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	updates := make(chan tg.Update, 10)
+	assert.Equal(t, http.StatusOK, post(receiver.NewWebhookHandler(testLogger(), updates, testConfig())))
+	strict := receiver.NewWebhookHandler(testLogger(), updates, testConfig(), receiver.WithWebhookDecoder(tg.StrictDecoder()))
+	assert.Equal(t, http.StatusBadRequest, post(strict))
+}
+
 // ==================== Update Forwarding ====================
 
 func TestWebhook_ValidUpdate_ForwardsToChannel(t *testing.T) {
@@ -492,3 +509,23 @@ func TestWebhookOption_WithWebhookMaxBodySize(t *testing.T) {
 
 	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
 }
+
+func TestWebhook_KeepRawUpdates(t *testing.T) {
+	updates := make(chan tg.Update, 10)
+	cfg := testConfig()
+	cfg.KeepRawUpdates = true
+	handler := receiver.NewWebhookHandler(testLogger(), updates, cfg)
+
+	body := `{"update_id":100,"message":{"message_id":1,"date":1,"chat":{"id":123456,"type":"private"},"text":"Hello","future_field":true}}`
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "test-secret-token")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	received := <-updates
+	assert.JSONEq(t, body, string(received.Raw()))
+	assert.Contains(t, string(received.Message.Raw()), "future_field")
+}
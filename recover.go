@@ -0,0 +1,220 @@
+package galigo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prilive-com/galigo/internal/scrub"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Panic Recovery ==================
+//
+// Recover wraps an update handler so a panic in it is caught, logged with
+// its stack and the update's IDs, handed to an ErrorReporter (Sentry,
+// Rollbar, ...) and optionally summarized in an admin chat, instead of
+// crashing the process. Message bodies stay out of logs and reports
+// unless RecoverConfig.IncludeUpdate is set.
+
+// Handler processes one update. It is an alias, so handlers from other
+// packages (such as auth.Handler) can be passed without conversion.
+type Handler = func(ctx context.Context, update tg.Update) error
+
+// PanicError is returned by a recovered handler that panicked.
+type PanicError struct {
+	Value any    // the value passed to panic
+	Stack []byte // goroutine stack at the panic
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("galigo: handler panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// ErrorReport describes a failed update handler.
+type ErrorReport struct {
+	UpdateID   int
+	UpdateType string     // tg.Update.Type, e.g. "message"
+	ChatID     int64      // 0 if the update has no chat
+	UserID     int64      // 0 if the update has no user
+	Err        error      // *PanicError for panics
+	Stack      []byte     // set for panics
+	Update     *tg.Update // nil unless RecoverConfig.IncludeUpdate
+}
+
+// Panicked reports whether the handler panicked rather than returned an
+// error.
+func (r ErrorReport) Panicked() bool {
+	var pe *PanicError
+	return errors.As(r.Err, &pe)
+}
+
+// ErrorReporter receives handler failures, e.g. to forward them to an
+// error tracker. It is called synchronously, after logging.
+type ErrorReporter func(ctx context.Context, report ErrorReport)
+
+// Notifier sends the admin chat summary. *sender.Client implements it.
+type Notifier interface {
+	SendMessage(ctx context.Context, req sender.SendMessageRequest) (*tg.Message, error)
+}
+
+// RecoverConfig configures Recover.
+type RecoverConfig struct {
+	// Logger receives the panic with its stack (nil = slog.Default()).
+	Logger *slog.Logger
+
+	// Reporter, if set, receives every report.
+	Reporter ErrorReporter
+
+	// ReportErrors also reports errors returned by the handler, not only
+	// panics. Returned errors are not logged.
+	ReportErrors bool
+
+	// NotifyChatID, if set, gets a short summary of every report, sent
+	// with Notifier. The summary holds the update's IDs and the error
+	// text, truncated and with the bot token removed.
+	NotifyChatID int64
+	Notifier     Notifier
+
+	// IncludeUpdate adds the update's JSON to the log entry and report.
+	// Off by default, as updates carry message text and user data.
+	IncludeUpdate bool
+
+	// token is scrubbed from summaries; set by Bot.Recover.
+	token tg.SecretToken
+}
+
+// notifyTimeout bounds sending the admin chat summary.
+const notifyTimeout = 10 * time.Second
+
+// maxSummaryError caps the error text in admin chat summaries.
+const maxSummaryError = 300
+
+// Recover wraps next so panics are recovered and reported as described by
+// cfg. The wrapped handler returns next's error, or a *PanicError.
+func Recover(next Handler, cfg RecoverConfig) Handler {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	return func(ctx context.Context, update tg.Update) (err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				pe := &PanicError{Value: v, Stack: debug.Stack()}
+				err = pe
+				cfg.report(ctx, update, pe, pe.Stack)
+			}
+		}()
+		err = next(ctx, update)
+		if err != nil && cfg.ReportErrors {
+			cfg.report(ctx, update, err, nil)
+		}
+		return err
+	}
+}
+
+// Recover wraps next like the package-level Recover, with the bot's
+// logger and RecoverConfig set by WithRecover. Summaries are sent with
+// the bot's sender.
+func (b *Bot) Recover(next Handler) Handler {
+	cfg := b.config.recover
+	if cfg.Logger == nil {
+		cfg.Logger = b.logger
+	}
+	if cfg.Notifier == nil {
+		cfg.Notifier = b.sender
+	}
+	cfg.token = b.token
+	return Recover(next, cfg)
+}
+
+// WithRecover sets how Bot.Recover reports handler failures.
+func WithRecover(cfg RecoverConfig) Option {
+	return func(c *botConfig) {
+		c.recover = cfg
+	}
+}
+
+func (cfg *RecoverConfig) report(ctx context.Context, update tg.Update, err error, stack []byte) {
+	report := ErrorReport{
+		UpdateID:   update.UpdateID,
+		UpdateType: update.Type(),
+		Err:        err,
+		Stack:      stack,
+	}
+	if chat := update.EffectiveChat(); chat != nil {
+		report.ChatID = chat.ID
+	}
+	if user := update.EffectiveUser(); user != nil {
+		report.UserID = user.ID
+	}
+	if cfg.IncludeUpdate {
+		report.Update = &update
+	}
+
+	if stack != nil {
+		attrs := []any{
+			"update_id", report.UpdateID,
+			"update_type", report.UpdateType,
+			"chat_id", report.ChatID,
+			"user_id", report.UserID,
+			"error", scrub.TokenFromError(err, cfg.token),
+			"stack", string(stack),
+		}
+		if cfg.IncludeUpdate {
+			if data, mErr := json.Marshal(update); mErr == nil {
+				attrs = append(attrs, "update", string(data))
+			}
+		}
+		cfg.Logger.ErrorContext(ctx, "handler panic", attrs...)
+	}
+
+	if cfg.Reporter != nil {
+		cfg.Reporter(ctx, report)
+	}
+	if cfg.NotifyChatID != 0 && cfg.Notifier != nil {
+		cfg.notify(ctx, report)
+	}
+}
+
+// notify sends a summary of report to the admin chat. Failures are
+// logged, not returned: the handler's own error matters more.
+func (cfg *RecoverConfig) notify(ctx context.Context, report ErrorReport) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), notifyTimeout)
+	defer cancel()
+	_, err := cfg.Notifier.SendMessage(ctx, sender.SendMessageRequest{
+		ChatID: cfg.NotifyChatID,
+		Text:   summarizeReport(report, cfg.token),
+	})
+	if err != nil {
+		cfg.Logger.WarnContext(ctx, "handler error notification failed",
+			"chat_id", cfg.NotifyChatID,
+			"error", scrub.TokenFromError(err, cfg.token),
+		)
+	}
+}
+
+// summarizeReport renders report as plain text with IDs only.
+func summarizeReport(report ErrorReport, token tg.SecretToken) string {
+	kind := "Handler error"
+	if report.Panicked() {
+		kind = "Handler panic"
+	}
+	msg := scrub.TokenFromError(report.Err, token).Error()
+	if utf8.RuneCountInString(msg) > maxSummaryError {
+		msg = string([]rune(msg)[:maxSummaryError]) + "…"
+	}
+	return fmt.Sprintf("%s\nupdate: %d (%s)\nchat: %d\nuser: %d\nerror: %s",
+		kind, report.UpdateID, report.UpdateType, report.ChatID, report.UserID, msg)
+}
@@ -0,0 +1,155 @@
+package galigo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+type fakeNotifier struct {
+	sent []sender.SendMessageRequest
+	err  error
+}
+
+func (f *fakeNotifier) SendMessage(_ context.Context, req sender.SendMessageRequest) (*tg.Message, error) {
+	f.sent = append(f.sent, req)
+	return &tg.Message{}, f.err
+}
+
+func secretUpdate() tg.Update {
+	return tg.Update{UpdateID: 17, Message: &tg.Message{
+		MessageID: 3,
+		Chat:      &tg.Chat{ID: -100, Type: "group"},
+		From:      &tg.User{ID: 42},
+		Text:      "my password is hunter2",
+	}}
+}
+
+func TestRecover_CatchesPanic(t *testing.T) {
+	var logs bytes.Buffer
+	var reports []ErrorReport
+	notifier := &fakeNotifier{}
+
+	handler := Recover(func(context.Context, tg.Update) error {
+		panic("boom")
+	}, RecoverConfig{
+		Logger:       slog.New(slog.NewTextHandler(&logs, nil)),
+		Reporter:     func(_ context.Context, r ErrorReport) { reports = append(reports, r) },
+		NotifyChatID: 999,
+		Notifier:     notifier,
+	})
+
+	err := handler(context.Background(), secretUpdate())
+
+	var pe *PanicError
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, "boom", pe.Value)
+	assert.Contains(t, string(pe.Stack), "recover_test.go")
+
+	require.Len(t, reports, 1)
+	r := reports[0]
+	assert.True(t, r.Panicked())
+	assert.Equal(t, 17, r.UpdateID)
+	assert.Equal(t, "message", r.UpdateType)
+	assert.Equal(t, int64(-100), r.ChatID)
+	assert.Equal(t, int64(42), r.UserID)
+	assert.NotEmpty(t, r.Stack)
+	assert.Nil(t, r.Update)
+
+	assert.Contains(t, logs.String(), "handler panic")
+	assert.Contains(t, logs.String(), "update_id=17")
+	assert.NotContains(t, logs.String(), "hunter2")
+
+	require.Len(t, notifier.sent, 1)
+	assert.Equal(t, int64(999), notifier.sent[0].ChatID)
+	assert.Contains(t, notifier.sent[0].Text, "Handler panic")
+	assert.Contains(t, notifier.sent[0].Text, "chat: -100")
+	assert.NotContains(t, notifier.sent[0].Text, "hunter2")
+}
+
+func TestRecover_ReturnedErrors(t *testing.T) {
+	boom := errors.New("boom")
+	var reports []ErrorReport
+	cfg := RecoverConfig{
+		Logger:   slog.New(slog.DiscardHandler),
+		Reporter: func(_ context.Context, r ErrorReport) { reports = append(reports, r) },
+	}
+	failing := func(context.Context, tg.Update) error { return boom }
+
+	err := Recover(failing, cfg)(context.Background(), secretUpdate())
+	assert.ErrorIs(t, err, boom)
+	assert.Empty(t, reports, "returned errors are only reported with ReportErrors")
+
+	cfg.ReportErrors = true
+	err = Recover(failing, cfg)(context.Background(), secretUpdate())
+	assert.ErrorIs(t, err, boom)
+	require.Len(t, reports, 1)
+	assert.False(t, reports[0].Panicked())
+	assert.Nil(t, reports[0].Stack)
+}
+
+func TestRecover_IncludeUpdate(t *testing.T) {
+	var logs bytes.Buffer
+	var report ErrorReport
+	handler := Recover(func(context.Context, tg.Update) error {
+		panic(errors.New("nil map"))
+	}, RecoverConfig{
+		Logger:        slog.New(slog.NewTextHandler(&logs, nil)),
+		Reporter:      func(_ context.Context, r ErrorReport) { report = r },
+		IncludeUpdate: true,
+	})
+
+	_ = handler(context.Background(), secretUpdate())
+
+	require.NotNil(t, report.Update)
+	assert.Equal(t, "my password is hunter2", report.Update.Message.Text)
+	assert.Contains(t, logs.String(), "hunter2")
+}
+
+func TestRecover_SummaryIsScrubbedAndTruncated(t *testing.T) {
+	token := tg.SecretToken("123456789:ABCdefGHIjklMNOpqrSTUvwxYZ")
+	report := ErrorReport{
+		UpdateID:   1,
+		UpdateType: "message",
+		Err:        errors.New("call https://api.telegram.org/bot" + string(token) + "/x: " + strings.Repeat("x", 500)),
+	}
+
+	text := summarizeReport(report, token)
+
+	assert.True(t, strings.HasPrefix(text, "Handler error\n"))
+	assert.NotContains(t, text, string(token))
+	assert.Less(t, len(text), 400)
+}
+
+func TestBot_Recover_UsesConfig(t *testing.T) {
+	notifier := &fakeNotifier{err: errors.New("chat not found")}
+	var reported bool
+	bot, err := New("123456789:ABCdefGHIjklMNOpqrSTUvwxYZ", WithRecover(RecoverConfig{
+		Logger:       slog.New(slog.DiscardHandler),
+		Reporter:     func(context.Context, ErrorReport) { reported = true },
+		NotifyChatID: 1,
+		Notifier:     notifier,
+	}))
+	require.NoError(t, err)
+	defer bot.Close()
+
+	err = bot.Recover(func(context.Context, tg.Update) error {
+		var m map[string]int
+		m["x"]++
+		return nil
+	})(context.Background(), tg.Update{UpdateID: 5})
+
+	var pe *PanicError
+	assert.ErrorAs(t, err, &pe)
+	assert.True(t, reported)
+	assert.Len(t, notifier.sent, 1, "notification failures are logged, not returned")
+}
@@ -0,0 +1,105 @@
+package galigo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/prilive-com/galigo/receiver"
+)
+
+// ================== Update Replay ==================
+//
+// Recordings made with WithRecordUpdates (or receiver.UpdateRecorder) can
+// be fed back into a Bot to reproduce a handler regression: point the bot
+// at a test server with WithBaseURL, start the handlers as usual and call
+// Bot.Replay.
+
+// ReplaySource reads a recording of updates.
+type ReplaySource struct {
+	// Reader supplies the JSON Lines recording.
+	Reader io.Reader
+
+	// Speed scales playback: 1 keeps the original gaps between updates,
+	// 10 plays ten times faster (0 = 1).
+	Speed float64
+
+	// NoDelay delivers updates back to back, ignoring recorded timing.
+	NoDelay bool
+}
+
+// WithRecordUpdates records every received update to w as JSON lines, for
+// later playback with Bot.Replay. Writes are serialized; w need not be
+// safe for concurrent use.
+func WithRecordUpdates(w io.Writer) Option {
+	return func(c *botConfig) {
+		c.recordTo = w
+	}
+}
+
+// Replay delivers the updates of src to the updates channel, paced by
+// their recorded timestamps, and returns the number delivered. It stops
+// at the end of the recording, on a malformed entry, or when ctx is done.
+// It must not be called after Close.
+func (b *Bot) Replay(ctx context.Context, src ReplaySource) (int, error) {
+	speed := src.Speed
+	if speed <= 0 {
+		speed = 1
+	}
+
+	dec := json.NewDecoder(src.Reader)
+	var (
+		n     int
+		first time.Time
+		start = time.Now()
+	)
+	for {
+		var line json.RawMessage
+		if err := dec.Decode(&line); err != nil {
+			if errors.Is(err, io.EOF) {
+				return n, nil
+			}
+			return n, fmt.Errorf("galigo: replay entry %d: %w", n+1, err)
+		}
+		env, err := receiver.DecodeUpdate(line)
+		if err != nil {
+			return n, fmt.Errorf("galigo: replay entry %d: %w", n+1, err)
+		}
+
+		if !src.NoDelay {
+			if first.IsZero() {
+				first = env.ReceivedAt
+			}
+			offset := time.Duration(float64(env.ReceivedAt.Sub(first)) / speed)
+			if err := sleepUntil(ctx, start.Add(offset)); err != nil {
+				return n, err
+			}
+		}
+
+		select {
+		case b.updates <- env.Update:
+			n++
+		case <-ctx.Done():
+			return n, ctx.Err()
+		}
+	}
+}
+
+// sleepUntil waits until t or until ctx is done.
+func sleepUntil(ctx context.Context, t time.Time) error {
+	d := time.Until(t)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
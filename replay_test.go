@@ -0,0 +1,68 @@
+package galigo
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func recordUpdates(t *testing.T, ids ...int) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	rec := receiver.NewUpdateRecorder(&buf)
+	for _, id := range ids {
+		require.NoError(t, rec.Publish(context.Background(), tg.Update{UpdateID: id}))
+	}
+	return &buf
+}
+
+func TestReplay_DeliversRecordingInOrder(t *testing.T) {
+	bot, err := New("123456789:ABCdefGHIjklMNOpqrSTUvwxYZ", WithUpdateBufferSize(10))
+	require.NoError(t, err)
+	defer bot.Close()
+
+	n, err := bot.Replay(context.Background(), ReplaySource{Reader: recordUpdates(t, 1, 2, 3), NoDelay: true})
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	for _, want := range []int{1, 2, 3} {
+		assert.Equal(t, want, (<-bot.Updates()).UpdateID)
+	}
+}
+
+func TestReplay_HonoursRecordedTiming(t *testing.T) {
+	recording := []byte(`{"schema_version":1,"received_at":"2026-01-01T00:00:00Z","update":{"update_id":1}}
+{"schema_version":1,"received_at":"2026-01-01T00:00:01Z","update":{"update_id":2}}
+`)
+	bot, err := New("123456789:ABCdefGHIjklMNOpqrSTUvwxYZ", WithUpdateBufferSize(10))
+	require.NoError(t, err)
+	defer bot.Close()
+
+	start := time.Now()
+	n, err := bot.Replay(context.Background(), ReplaySource{Reader: bytes.NewReader(recording), Speed: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 90*time.Millisecond)
+	assert.Less(t, elapsed, 900*time.Millisecond)
+}
+
+func TestReplay_MalformedEntry(t *testing.T) {
+	bot, err := New("123456789:ABCdefGHIjklMNOpqrSTUvwxYZ", WithUpdateBufferSize(10))
+	require.NoError(t, err)
+	defer bot.Close()
+
+	recording := recordUpdates(t, 1)
+	recording.WriteString("{not json\n")
+
+	n, err := bot.Replay(context.Background(), ReplaySource{Reader: recording, NoDelay: true})
+	assert.Error(t, err)
+	assert.Equal(t, 1, n)
+}
@@ -0,0 +1,244 @@
+package sender
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// AuditRecord is a structured record of a single completed API call.
+//
+// Records never contain the bot token or raw message text. Text and captions
+// are represented only by their length and a truncated SHA-256 hash, which is
+// enough to correlate a record with a known message without storing content.
+type AuditRecord struct {
+	Time       time.Time     `json:"time"`
+	Method     string        `json:"method"`
+	ChatID     string        `json:"chat_id,omitempty"`
+	MessageID  int           `json:"message_id,omitempty"`
+	TextLength int           `json:"text_length,omitempty"`
+	TextHash   string        `json:"text_hash,omitempty"`
+	Duration   time.Duration `json:"duration"`
+	OK         bool          `json:"ok"`
+	ErrorCode  int           `json:"error_code,omitempty"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// AuditHook receives a record for every API call after it completes.
+// Implementations must be safe for concurrent use and should not block.
+type AuditHook interface {
+	Audit(ctx context.Context, rec AuditRecord)
+}
+
+// AuditHookFunc adapts a function to the AuditHook interface.
+type AuditHookFunc func(ctx context.Context, rec AuditRecord)
+
+// Audit implements AuditHook.
+func (f AuditHookFunc) Audit(ctx context.Context, rec AuditRecord) { f(ctx, rec) }
+
+// AuditRedaction controls which fields are scrubbed before a record reaches the hook.
+// The bot token is always redacted regardless of these settings.
+type AuditRedaction struct {
+	// HashChatID replaces the chat ID with a truncated SHA-256 hash.
+	HashChatID bool
+
+	// OmitText drops the text length and hash entirely.
+	OmitText bool
+
+	// OmitErrorDescription drops the error description, keeping only the code.
+	// Telegram descriptions may echo user-supplied values.
+	OmitErrorDescription bool
+}
+
+// WithAuditHook sets a hook that receives a record for every API call.
+func WithAuditHook(hook AuditHook) Option {
+	return func(c *Client) {
+		c.auditHook = hook
+	}
+}
+
+// WithAuditRedaction sets the PII redaction applied to audit records.
+func WithAuditRedaction(r AuditRedaction) Option {
+	return func(c *Client) {
+		c.auditRedaction = r
+	}
+}
+
+// audit builds an AuditRecord for a completed call and passes it to the hook.
+func (c *Client) audit(ctx context.Context, method string, payload any, chatID string, resp *apiResponse, err error, start time.Time) {
+	if c.auditHook == nil {
+		return
+	}
+
+	rec := AuditRecord{
+		Time:     start,
+		Method:   method,
+		ChatID:   chatID,
+		Duration: time.Since(start),
+		OK:       err == nil,
+	}
+
+	text := auditPayloadText(payload)
+	if text != "" && !c.auditRedaction.OmitText {
+		rec.TextLength = len([]rune(text))
+		rec.TextHash = auditHash(text)
+	}
+
+	rec.MessageID = auditPayloadMessageID(payload)
+	if resp != nil && len(resp.Result) > 0 && resp.Result[0] == '{' {
+		var result struct {
+			MessageID int `json:"message_id"`
+		}
+		if json.Unmarshal(resp.Result, &result) == nil && result.MessageID != 0 {
+			rec.MessageID = result.MessageID
+		}
+	}
+
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			rec.ErrorCode = apiErr.Code
+		}
+		if !c.auditRedaction.OmitErrorDescription {
+			rec.Error = c.scrubToken(err).Error()
+		}
+	}
+
+	if c.auditRedaction.HashChatID && rec.ChatID != "" {
+		rec.ChatID = auditHash(rec.ChatID)
+	}
+
+	c.auditHook.Audit(ctx, rec)
+}
+
+// auditHash returns the first 16 hex characters of the SHA-256 of s.
+func auditHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:8])
+}
+
+// auditPayloadText returns the Text or Caption field of a request struct, if any.
+func auditPayloadText(payload any) string {
+	rv := auditStruct(payload)
+	if !rv.IsValid() {
+		return ""
+	}
+	for _, name := range []string{"Text", "Caption"} {
+		if f := rv.FieldByName(name); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+			return f.String()
+		}
+	}
+	return ""
+}
+
+// auditPayloadMessageID returns the MessageID field of a request struct, if any.
+func auditPayloadMessageID(payload any) int {
+	rv := auditStruct(payload)
+	if !rv.IsValid() {
+		return 0
+	}
+	if f := rv.FieldByName("MessageID"); f.IsValid() && f.Kind() == reflect.Int {
+		return int(f.Int())
+	}
+	return 0
+}
+
+func auditStruct(payload any) reflect.Value {
+	rv := reflect.ValueOf(payload)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return rv
+}
+
+// ================== Built-in Sinks ==================
+
+// NewSlogAuditHook returns an AuditHook that logs each record at Info level.
+func NewSlogAuditHook(logger *slog.Logger) AuditHook {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return AuditHookFunc(func(ctx context.Context, rec AuditRecord) {
+		attrs := []slog.Attr{
+			slog.String("method", rec.Method),
+			slog.Bool("ok", rec.OK),
+			slog.Duration("duration", rec.Duration),
+		}
+		if rec.ChatID != "" {
+			attrs = append(attrs, slog.String("chat_id", rec.ChatID))
+		}
+		if rec.MessageID != 0 {
+			attrs = append(attrs, slog.Int("message_id", rec.MessageID))
+		}
+		if rec.TextHash != "" {
+			attrs = append(attrs, slog.Int("text_length", rec.TextLength), slog.String("text_hash", rec.TextHash))
+		}
+		if rec.ErrorCode != 0 {
+			attrs = append(attrs, slog.Int("error_code", rec.ErrorCode))
+		}
+		if rec.Error != "" {
+			attrs = append(attrs, slog.String("error", rec.Error))
+		}
+		logger.LogAttrs(ctx, slog.LevelInfo, "galigo audit", attrs...)
+	})
+}
+
+// JSONAuditHook writes each record as one JSON object per line.
+type JSONAuditHook struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+}
+
+var _ AuditHook = (*JSONAuditHook)(nil)
+
+// NewJSONAuditHook returns a hook writing JSON lines to w.
+func NewJSONAuditHook(w io.Writer) *JSONAuditHook {
+	return &JSONAuditHook{enc: json.NewEncoder(w)}
+}
+
+// OpenJSONAuditFile opens (or creates) path in append mode and returns a hook
+// writing JSON lines to it. The file is created with 0600 permissions.
+// Call Close to release the file.
+func OpenJSONAuditFile(path string) (*JSONAuditHook, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	h := NewJSONAuditHook(f)
+	h.closer = f
+	return h, nil
+}
+
+// Audit implements AuditHook. Write errors are ignored.
+func (h *JSONAuditHook) Audit(_ context.Context, rec AuditRecord) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_ = h.enc.Encode(rec)
+}
+
+// Close closes the underlying file if the hook was created by OpenJSONAuditFile.
+func (h *JSONAuditHook) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closer == nil {
+		return nil
+	}
+	err := h.closer.Close()
+	h.closer = nil
+	return err
+}
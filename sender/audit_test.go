@@ -0,0 +1,141 @@
+package sender_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+)
+
+type auditRecorder struct {
+	mu      sync.Mutex
+	records []sender.AuditRecord
+}
+
+func (r *auditRecorder) Audit(_ context.Context, rec sender.AuditRecord) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, rec)
+}
+
+func (r *auditRecorder) last(t *testing.T) sender.AuditRecord {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	require.NotEmpty(t, r.records)
+	return r.records[len(r.records)-1]
+}
+
+func TestAudit_SuccessRecord(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 42)
+	})
+
+	rec := &auditRecorder{}
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithAuditHook(rec))
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{
+		ChatID: testutil.TestChatID,
+		Text:   "secret text",
+	})
+	require.NoError(t, err)
+
+	got := rec.last(t)
+	assert.Equal(t, "sendMessage", got.Method)
+	assert.Equal(t, "123456789", got.ChatID)
+	assert.Equal(t, 42, got.MessageID)
+	assert.Equal(t, 11, got.TextLength)
+	assert.Len(t, got.TextHash, 16)
+	assert.True(t, got.OK)
+	assert.Zero(t, got.ErrorCode)
+}
+
+func TestAudit_ErrorRecord(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyForbidden(w, "bot was blocked by the user")
+	})
+
+	rec := &auditRecorder{}
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithAuditHook(rec))
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{
+		ChatID: testutil.TestChatID,
+		Text:   "hi",
+	})
+	require.Error(t, err)
+
+	got := rec.last(t)
+	assert.False(t, got.OK)
+	assert.Equal(t, 403, got.ErrorCode)
+	assert.Contains(t, got.Error, "blocked")
+	assert.NotContains(t, got.Error, testutil.TestToken)
+}
+
+func TestAudit_Redaction(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBadRequest(w, "can't parse entities in \"secret\"")
+	})
+
+	rec := &auditRecorder{}
+	client := testutil.NewTestClient(t, server.BaseURL(),
+		sender.WithAuditHook(rec),
+		sender.WithAuditRedaction(sender.AuditRedaction{
+			HashChatID:           true,
+			OmitText:             true,
+			OmitErrorDescription: true,
+		}),
+	)
+
+	_, _ = client.SendMessage(context.Background(), sender.SendMessageRequest{
+		ChatID: testutil.TestChatID,
+		Text:   "secret",
+	})
+
+	got := rec.last(t)
+	assert.NotEqual(t, "123456789", got.ChatID)
+	assert.Len(t, got.ChatID, 16)
+	assert.Zero(t, got.TextLength)
+	assert.Empty(t, got.TextHash)
+	assert.Empty(t, got.Error)
+	assert.Equal(t, 400, got.ErrorCode)
+}
+
+func TestAudit_JSONHook(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 7)
+	})
+
+	var buf bytes.Buffer
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithAuditHook(sender.NewJSONAuditHook(&buf)))
+
+	for range 2 {
+		_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{
+			ChatID: testutil.TestChatID,
+			Text:   "hello",
+		})
+		require.NoError(t, err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var got sender.AuditRecord
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &got))
+	assert.Equal(t, "sendMessage", got.Method)
+	assert.Equal(t, 7, got.MessageID)
+	assert.NotContains(t, buf.String(), "hello")
+	assert.NotContains(t, buf.String(), testutil.TestToken)
+}
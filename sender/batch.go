@@ -0,0 +1,215 @@
+package sender
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Batch Execution ==================
+
+// ErrBatchSkipped is the error of batch items not run because a fail-fast
+// batch stopped at an earlier error.
+var ErrBatchSkipped = errors.New("galigo: batch item skipped after earlier failure")
+
+// BatchResult is the outcome of one batch item.
+type BatchResult[T any] struct {
+	Index  int    // position in the batch
+	Method string // Bot API method, or the name given to Do
+	Value  T      // the call's result; the zero value for calls without one
+	Err    error
+}
+
+// Batch collects independent API calls whose results are of type T and
+// runs them concurrently. The calls share the client's rate limiters, so
+// a batch never exceeds the configured limits; mark ctx with
+// WithBulkPriority for broadcast-style batches. Build one with NewBatch,
+// or Client.Batch for message calls; a Batch is not safe for concurrent
+// use while being built.
+type Batch[T any] struct {
+	c           *Client
+	ctx         context.Context
+	concurrency int
+	failFast    bool
+	items       []batchItem[T]
+}
+
+type batchItem[T any] struct {
+	method string
+	fn     func(ctx context.Context) (T, error)
+}
+
+// NewBatch starts a batch on c whose calls use ctx and return T. By
+// default it runs 8 calls at a time and collects all errors.
+func NewBatch[T any](ctx context.Context, c *Client) *Batch[T] {
+	return &Batch[T]{c: c, ctx: ctx, concurrency: 8}
+}
+
+// Concurrency sets how many calls run at once (minimum 1).
+func (b *Batch[T]) Concurrency(n int) *Batch[T] {
+	b.concurrency = max(n, 1)
+	return b
+}
+
+// FailFast stops the batch at the first error: calls in flight are
+// cancelled and calls not yet started fail with ErrBatchSkipped.
+func (b *Batch[T]) FailFast() *Batch[T] {
+	b.failFast = true
+	return b
+}
+
+// Len returns the number of calls added.
+func (b *Batch[T]) Len() int {
+	return len(b.items)
+}
+
+// Do adds an arbitrary call; name labels its result.
+func (b *Batch[T]) Do(name string, fn func(ctx context.Context, c *Client) (T, error)) *Batch[T] {
+	b.items = append(b.items, batchItem[T]{method: name, fn: func(ctx context.Context) (T, error) {
+		return fn(ctx, b.c)
+	}})
+	return b
+}
+
+// Invoke adds a call of an arbitrary method whose result is decoded into
+// T; use a Batch[json.RawMessage] to keep it raw. See Client.Invoke.
+func (b *Batch[T]) Invoke(method string, payload any) *Batch[T] {
+	return b.Do(method, func(ctx context.Context, c *Client) (T, error) {
+		var v T
+		raw, err := c.Invoke(ctx, method, payload)
+		if err != nil {
+			return v, err
+		}
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return v, fmt.Errorf("galigo: decode %s result: %w", method, err)
+		}
+		return v, nil
+	})
+}
+
+// Run executes the batch and returns one result per call, in the order
+// added. The error is nil if every call succeeded; otherwise it is the
+// first failure (fail-fast) or all failures joined.
+func (b *Batch[T]) Run() ([]BatchResult[T], error) {
+	ctx, cancel := context.WithCancel(b.ctx)
+	defer cancel()
+
+	results := make([]BatchResult[T], len(b.items))
+	sem := make(chan struct{}, b.concurrency)
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for i, item := range b.items {
+		results[i] = BatchResult[T]{Index: i, Method: item.method}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			if b.failFast && b.ctx.Err() == nil {
+				results[i].Err = ErrBatchSkipped
+			} else {
+				results[i].Err = ctx.Err()
+			}
+			continue
+		}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			value, err := item.fn(ctx)
+			results[i].Value, results[i].Err = value, err
+			if err != nil && b.failFast {
+				once.Do(func() {
+					firstErr = fmt.Errorf("galigo: batch item %d (%s): %w", i, item.method, err)
+					cancel()
+				})
+			}
+		})
+	}
+	wg.Wait()
+
+	if b.failFast {
+		if firstErr == nil {
+			firstErr = b.ctx.Err()
+		}
+		return results, firstErr
+	}
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("galigo: batch item %d (%s): %w", r.Index, r.Method, r.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// MessageBatch is a batch of message calls, with builders for the common
+// ones. Start one with Client.Batch.
+type MessageBatch struct {
+	*Batch[*tg.Message]
+}
+
+// Batch starts a batch of message calls that use ctx. By default it runs 8
+// calls at a time and collects all errors.
+func (c *Client) Batch(ctx context.Context) *MessageBatch {
+	return &MessageBatch{NewBatch[*tg.Message](ctx, c)}
+}
+
+// Concurrency sets how many calls run at once (minimum 1).
+func (b *MessageBatch) Concurrency(n int) *MessageBatch {
+	b.Batch.Concurrency(n)
+	return b
+}
+
+// FailFast stops the batch at the first error: calls in flight are
+// cancelled and calls not yet started fail with ErrBatchSkipped.
+func (b *MessageBatch) FailFast() *MessageBatch {
+	b.Batch.FailFast()
+	return b
+}
+
+// Do adds an arbitrary call; name labels its result.
+func (b *MessageBatch) Do(name string, fn func(ctx context.Context, c *Client) (*tg.Message, error)) *MessageBatch {
+	b.Batch.Do(name, fn)
+	return b
+}
+
+// Invoke adds a call of an arbitrary method returning a message. See
+// Client.Invoke.
+func (b *MessageBatch) Invoke(method string, payload any) *MessageBatch {
+	b.Batch.Invoke(method, payload)
+	return b
+}
+
+// SendMessage adds a SendMessage call.
+func (b *MessageBatch) SendMessage(req SendMessageRequest) *MessageBatch {
+	return b.Do("sendMessage", func(ctx context.Context, c *Client) (*tg.Message, error) {
+		return c.SendMessage(ctx, req)
+	})
+}
+
+// EditMessageText adds an EditMessageText call.
+func (b *MessageBatch) EditMessageText(req EditMessageTextRequest) *MessageBatch {
+	return b.Do("editMessageText", func(ctx context.Context, c *Client) (*tg.Message, error) {
+		return c.EditMessageText(ctx, req)
+	})
+}
+
+// ForwardMessage adds a ForwardMessage call.
+func (b *MessageBatch) ForwardMessage(req ForwardMessageRequest) *MessageBatch {
+	return b.Do("forwardMessage", func(ctx context.Context, c *Client) (*tg.Message, error) {
+		return c.ForwardMessage(ctx, req)
+	})
+}
+
+// DeleteMessage adds a DeleteMessage call; its Value is nil.
+func (b *MessageBatch) DeleteMessage(req DeleteMessageRequest) *MessageBatch {
+	return b.Do("deleteMessage", func(ctx context.Context, c *Client) (*tg.Message, error) {
+		return nil, c.DeleteMessage(ctx, req)
+	})
+}
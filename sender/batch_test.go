@@ -0,0 +1,143 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestBatch_CollectsResultsInOrder(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		inFlight.Add(-1)
+		var body struct {
+			Text string `json:"text"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		id := 0
+		_, _ = fmt.Sscanf(body.Text, "msg %d", &id)
+		testutil.ReplyMessage(w, id)
+	})
+	server.On("/bot"+testutil.TestToken+"/deleteMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, true)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+	batch := client.Batch(context.Background()).Concurrency(3)
+	for i := range 10 {
+		batch.SendMessage(sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: fmt.Sprintf("msg %d", i)})
+	}
+	batch.DeleteMessage(sender.DeleteMessageRequest{ChatID: testutil.TestChatID, MessageID: 1})
+	require.Equal(t, 11, batch.Len())
+
+	results, err := batch.Run()
+	require.NoError(t, err)
+	require.Len(t, results, 11)
+	for i, r := range results[:10] {
+		assert.Equal(t, i, r.Index)
+		assert.Equal(t, "sendMessage", r.Method)
+		require.NotNil(t, r.Value)
+		assert.Equal(t, i, r.Value.MessageID)
+	}
+	assert.Equal(t, "deleteMessage", results[10].Method)
+	assert.NoError(t, results[10].Err)
+	assert.Nil(t, results[10].Value)
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+}
+
+func TestBatch_CollectAllErrors(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/deleteMessage", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			MessageID int `json:"message_id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.MessageID%2 == 0 {
+			testutil.ReplyBadRequest(w, "Bad Request: message to delete not found")
+			return
+		}
+		testutil.ReplyOK(w, true)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+	batch := client.Batch(context.Background())
+	for id := 1; id <= 6; id++ {
+		batch.DeleteMessage(sender.DeleteMessageRequest{ChatID: testutil.TestChatID, MessageID: id})
+	}
+
+	results, err := batch.Run()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, sender.ErrMessageNotFound)
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	assert.Equal(t, 3, failed)
+	assert.Equal(t, 6, server.CaptureCount())
+}
+
+func TestBatch_FailFast(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyForbidden(w, "Forbidden: bot was blocked by the user")
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+	batch := client.Batch(context.Background()).Concurrency(1).FailFast()
+	for range 5 {
+		batch.SendMessage(sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"})
+	}
+
+	results, err := batch.Run()
+	require.ErrorIs(t, err, sender.ErrBotBlocked)
+	assert.Equal(t, 1, server.CaptureCount())
+	for _, r := range results[1:] {
+		assert.ErrorIs(t, r.Err, sender.ErrBatchSkipped)
+	}
+}
+
+func TestBatch_InvokeAndDo(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getMyName", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, map[string]string{"name": "Test Bot"})
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+	results, err := sender.NewBatch[json.RawMessage](context.Background(), client).
+		Invoke("getMyName", nil).
+		Do("local", func(ctx context.Context, c *sender.Client) (json.RawMessage, error) {
+			return json.RawMessage(`42`), nil
+		}).
+		Run()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"Test Bot"}`, string(results[0].Value))
+	assert.Equal(t, json.RawMessage(`42`), results[1].Value)
+
+	names, err := sender.NewBatch[tg.BotName](context.Background(), client).
+		Invoke("getMyName", nil).
+		Run()
+	require.NoError(t, err)
+	assert.Equal(t, "Test Bot", names[0].Value.Name)
+}
@@ -18,6 +18,14 @@ type SavePreparedInlineMessageRequest struct {
 	AllowChannelChats bool                 `json:"allow_channel_chats,omitempty"`
 }
 
+// SetUserEmojiStatusRequest represents a setUserEmojiStatus request. An
+// empty EmojiStatusCustomEmojiID removes the status.
+type SetUserEmojiStatusRequest struct {
+	UserID                    int64  `json:"user_id"`
+	EmojiStatusCustomEmojiID  string `json:"emoji_status_custom_emoji_id,omitempty"`
+	EmojiStatusExpirationDate int64  `json:"emoji_status_expiration_date,omitempty"` // Unix time
+}
+
 // GetUserChatBoostsRequest represents a getUserChatBoosts request.
 type GetUserChatBoostsRequest struct {
 	ChatID tg.ChatID `json:"chat_id"`
@@ -63,11 +71,23 @@ func (c *Client) SavePreparedInlineMessage(ctx context.Context, req SavePrepared
 		return nil, tg.NewValidationError("result", "required")
 	}
 
-	var result tg.PreparedInlineMessage
-	if err := c.callJSON(ctx, "savePreparedInlineMessage", req, &result); err != nil {
-		return nil, err
+	return call[tg.PreparedInlineMessage](c, ctx, "savePreparedInlineMessage", req)
+}
+
+// SetUserEmojiStatus changes the emoji status of a user who allowed the bot
+// to do so through a Mini App (requestEmojiStatusAccess).
+func (c *Client) SetUserEmojiStatus(ctx context.Context, req SetUserEmojiStatusRequest) error {
+	if req.UserID <= 0 {
+		return tg.NewValidationError("user_id", "must be positive")
+	}
+	if req.EmojiStatusExpirationDate < 0 {
+		return tg.NewValidationError("emoji_status_expiration_date", "must not be negative")
 	}
-	return &result, nil
+	if req.EmojiStatusExpirationDate > 0 && req.EmojiStatusCustomEmojiID == "" {
+		return tg.NewValidationError("emoji_status_expiration_date", "requires emoji_status_custom_emoji_id")
+	}
+
+	return c.callJSON(ctx, "setUserEmojiStatus", req, nil)
 }
 
 // GetUserChatBoosts returns the list of boosts added to a chat by a user.
@@ -79,11 +99,7 @@ func (c *Client) GetUserChatBoosts(ctx context.Context, req GetUserChatBoostsReq
 		return nil, tg.NewValidationError("user_id", "must be positive")
 	}
 
-	var result tg.UserChatBoosts
-	if err := c.callJSON(ctx, "getUserChatBoosts", req, &result, extractChatID(req.ChatID)); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.UserChatBoosts](c, ctx, "getUserChatBoosts", req, extractChatID(req.ChatID))
 }
 
 // SetCustomEmojiStickerSetThumbnail sets the thumbnail of a custom emoji sticker set.
@@ -107,11 +123,7 @@ func (c *Client) CreateChatSubscriptionInviteLink(ctx context.Context, req Creat
 		return nil, tg.NewValidationError("subscription_price", "must be positive")
 	}
 
-	var result tg.ChatInviteLink
-	if err := c.callJSON(ctx, "createChatSubscriptionInviteLink", req, &result, extractChatID(req.ChatID)); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.ChatInviteLink](c, ctx, "createChatSubscriptionInviteLink", req, extractChatID(req.ChatID))
 }
 
 // EditChatSubscriptionInviteLink edits a subscription invite link created by the bot.
@@ -123,11 +135,7 @@ func (c *Client) EditChatSubscriptionInviteLink(ctx context.Context, req EditCha
 		return nil, tg.NewValidationError("invite_link", "required")
 	}
 
-	var result tg.ChatInviteLink
-	if err := c.callJSON(ctx, "editChatSubscriptionInviteLink", req, &result, extractChatID(req.ChatID)); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.ChatInviteLink](c, ctx, "editChatSubscriptionInviteLink", req, extractChatID(req.ChatID))
 }
 
 // ================== Bot API 9.5 Methods ==================
@@ -168,9 +176,5 @@ func (c *Client) GetOwnedGifts(ctx context.Context, req GetOwnedGiftsRequest) (*
 		return nil, tg.NewValidationError("user_id", "must be positive")
 	}
 
-	var result tg.OwnedGifts
-	if err := c.callJSON(ctx, "getOwnedGifts", req, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.OwnedGifts](c, ctx, "getOwnedGifts", req)
 }
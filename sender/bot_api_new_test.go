@@ -54,6 +54,49 @@ func TestSavePreparedInlineMessage_Validation(t *testing.T) {
 	}
 }
 
+// ==================== SetUserEmojiStatus ====================
+
+func TestSetUserEmojiStatus(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/setUserEmojiStatus", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, true)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+	err := client.SetUserEmojiStatus(context.Background(), sender.SetUserEmojiStatusRequest{
+		UserID:                    456,
+		EmojiStatusCustomEmojiID:  "5368324170671202286",
+		EmojiStatusExpirationDate: 1700000000,
+	})
+	require.NoError(t, err)
+
+	cap := server.LastCapture()
+	cap.AssertJSONField(t, "emoji_status_custom_emoji_id", "5368324170671202286")
+	cap.AssertJSONField(t, "emoji_status_expiration_date", float64(1700000000))
+}
+
+func TestSetUserEmojiStatus_Validation(t *testing.T) {
+	client := testutil.NewTestClient(t, "http://127.0.0.1:0")
+
+	tests := []struct {
+		name string
+		req  sender.SetUserEmojiStatusRequest
+		want string
+	}{
+		{"missing user_id", sender.SetUserEmojiStatusRequest{}, "user_id"},
+		{"negative expiration", sender.SetUserEmojiStatusRequest{UserID: 1, EmojiStatusCustomEmojiID: "e", EmojiStatusExpirationDate: -1}, "emoji_status_expiration_date"},
+		{"expiration without emoji", sender.SetUserEmojiStatusRequest{UserID: 1, EmojiStatusExpirationDate: 1700000000}, "emoji_status_custom_emoji_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.SetUserEmojiStatus(context.Background(), tt.req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
 // ==================== GetUserChatBoosts ====================
 
 func TestGetUserChatBoosts(t *testing.T) {
@@ -0,0 +1,343 @@
+package sender
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sony/gobreaker/v2"
+
+	"github.com/prilive-com/galigo/clock"
+)
+
+// ================== Circuit Breaker ==================
+//
+// By default one breaker guards every call. WithBreakerPerClass splits it
+// by MethodClass, so that e.g. a failing upload path does not block
+// sendMessage.
+
+// MethodClass groups Bot API methods for per-class circuit breakers.
+type MethodClass string
+
+const (
+	ClassMessaging MethodClass = "messaging" // everything not listed below
+	ClassMedia     MethodClass = "media"     // methods uploading files (sendPhoto, sendMediaGroup, sticker uploads, ...)
+	ClassAdmin     MethodClass = "admin"     // chat administration (banChatMember, setChatTitle, pinChatMessage, ...)
+)
+
+// MethodClasses lists the method classes in a fixed order.
+var MethodClasses = []MethodClass{ClassMessaging, ClassMedia, ClassAdmin}
+
+var mediaMethods = map[string]bool{
+	"sendPhoto":                      true,
+	"sendAudio":                      true,
+	"sendDocument":                   true,
+	"sendVideo":                      true,
+	"sendAnimation":                  true,
+	"sendVoice":                      true,
+	"sendVideoNote":                  true,
+	"sendMediaGroup":                 true,
+	"sendPaidMedia":                  true,
+	"sendSticker":                    true,
+	"editMessageMedia":               true,
+	"uploadStickerFile":              true,
+	"createNewStickerSet":            true,
+	"addStickerToSet":                true,
+	"replaceStickerInSet":            true,
+	"setStickerSetThumbnail":         true,
+	"setBusinessAccountProfilePhoto": true,
+	"postStory":                      true,
+	"editStory":                      true,
+}
+
+var adminPrefixes = []string{
+	"ban", "unban", "restrict", "promote", "approve", "decline",
+	"setChat", "deleteChat", "pin", "unpin", "leaveChat",
+	"exportChatInviteLink", "createChatInviteLink", "editChatInviteLink", "revokeChatInviteLink",
+	"createChatSubscriptionInviteLink", "editChatSubscriptionInviteLink",
+}
+
+// ClassOf returns the class of a Bot API method.
+func ClassOf(method string) MethodClass {
+	if mediaMethods[method] {
+		return ClassMedia
+	}
+	for _, prefix := range adminPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return ClassAdmin
+		}
+	}
+	return ClassMessaging
+}
+
+// BreakerState is a snapshot of a circuit breaker.
+type BreakerState struct {
+	Name   string
+	Class  MethodClass // empty for the single default breaker
+	State  gobreaker.State
+	Counts gobreaker.Counts // counts of the current generation
+	// Since is the time of the last transition, or of the breaker's
+	// creation or reset.
+	Since time.Time
+	// Tripped reports that TripBreaker holds the breaker open.
+	Tripped bool
+	// Remote reports that another replica's breaker holds it open (see
+	// WithBreakerCoordinator).
+	Remote bool
+}
+
+// BreakerHealth aggregates the state of all breakers.
+type BreakerHealth struct {
+	// Healthy reports that every breaker is closed.
+	Healthy bool
+	// Degraded lists the classes whose breaker is open or half-open; with
+	// a single breaker it holds all classes.
+	Degraded []MethodClass
+	Breakers []BreakerState
+}
+
+// WithBreakerStateChange calls fn on every circuit breaker transition,
+// including those caused by TripBreaker and ResetBreaker, e.g. to page
+// when the breaker opens. fn must not block.
+func WithBreakerStateChange(fn func(name string, from, to gobreaker.State)) Option {
+	return func(c *Client) {
+		c.onBreakerChange = fn
+	}
+}
+
+// WithBreakerPerClass gives each MethodClass its own circuit breaker.
+// Classes missing from settings, and zero fields of their settings, use
+// the client's breaker settings (WithCircuitBreakerSettings or the
+// defaults); a nil map partitions without overrides.
+func WithBreakerPerClass(settings map[MethodClass]CircuitBreakerSettings) Option {
+	return func(c *Client) {
+		c.breakerPerClass = true
+		c.classBreakerSettings = settings
+	}
+}
+
+// initBreakers creates the circuit breakers once options are applied.
+func (c *Client) initBreakers() {
+	if !c.breakerPerClass {
+		c.breakers = map[MethodClass]*breaker{
+			"": newBreaker("galigo-sender", "", c.breakerSettings, c.clock, c.logger, c.onBreakerChange),
+		}
+		c.coordinateBreakers()
+		return
+	}
+	c.breakers = make(map[MethodClass]*breaker, len(MethodClasses))
+	for _, class := range MethodClasses {
+		settings := mergeBreakerSettings(c.classBreakerSettings[class], c.breakerSettings)
+		c.breakers[class] = newBreaker("galigo-sender/"+string(class), class, settings, c.clock, c.logger, c.onBreakerChange)
+	}
+	c.coordinateBreakers()
+}
+
+// mergeBreakerSettings returns s with its zero fields taken from base.
+func mergeBreakerSettings(s, base CircuitBreakerSettings) CircuitBreakerSettings {
+	if s.MaxRequests == 0 {
+		s.MaxRequests = base.MaxRequests
+	}
+	if s.Interval == 0 {
+		s.Interval = base.Interval
+	}
+	if s.Timeout == 0 {
+		s.Timeout = base.Timeout
+	}
+	if s.ReadyToTrip == nil {
+		s.ReadyToTrip = base.ReadyToTrip
+	}
+	return s
+}
+
+// breakerFor returns the breaker guarding method.
+func (c *Client) breakerFor(method string) *breaker {
+	if b, ok := c.breakers[""]; ok {
+		return b
+	}
+	return c.breakers[ClassOf(method)]
+}
+
+// allBreakers returns the breakers in MethodClasses order.
+func (c *Client) allBreakers() []*breaker {
+	if b, ok := c.breakers[""]; ok {
+		return []*breaker{b}
+	}
+	all := make([]*breaker, 0, len(MethodClasses))
+	for _, class := range MethodClasses {
+		all = append(all, c.breakers[class])
+	}
+	return all
+}
+
+// BreakerState returns the state of the circuit breaker. With per-class
+// breakers it returns the most degraded one (open, then half-open); see
+// BreakerHealth for all of them.
+func (c *Client) BreakerState() BreakerState {
+	var worst BreakerState
+	for i, b := range c.allBreakers() {
+		st := b.snapshot()
+		if i == 0 || stateSeverity(st.State) > stateSeverity(worst.State) {
+			worst = st
+		}
+	}
+	return worst
+}
+
+// BreakerHealth returns the state of every circuit breaker.
+func (c *Client) BreakerHealth() BreakerHealth {
+	h := BreakerHealth{Healthy: true}
+	for _, b := range c.allBreakers() {
+		st := b.snapshot()
+		h.Breakers = append(h.Breakers, st)
+		if st.State == gobreaker.StateClosed {
+			continue
+		}
+		h.Healthy = false
+		if st.Class == "" {
+			h.Degraded = append(h.Degraded, MethodClasses...)
+		} else {
+			h.Degraded = append(h.Degraded, st.Class)
+		}
+	}
+	return h
+}
+
+// TripBreaker forces the circuit breakers open: requests fail with
+// ErrCircuitOpen until ResetBreaker is called. Use it to shed traffic
+// during an incident.
+func (c *Client) TripBreaker() {
+	for _, b := range c.allBreakers() {
+		b.trip()
+	}
+}
+
+// ResetBreaker closes the circuit breakers and clears their counts, also
+// releasing a TripBreaker.
+func (c *Client) ResetBreaker() {
+	for _, b := range c.allBreakers() {
+		b.reset()
+	}
+}
+
+func stateSeverity(s gobreaker.State) int {
+	switch s {
+	case gobreaker.StateOpen:
+		return 2
+	case gobreaker.StateHalfOpen:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// breaker wraps a gobreaker.CircuitBreaker with manual control and
+// transition tracking. Reset replaces the underlying breaker, as gobreaker
+// has no way to clear one.
+type breaker struct {
+	name     string
+	class    MethodClass
+	settings CircuitBreakerSettings
+	clock    clock.Clock
+	logger   *slog.Logger
+	onChange func(name string, from, to gobreaker.State)
+	coord    BreakerCoordinator // nil = not shared
+
+	pubMu      sync.Mutex
+	pubQueue   []BreakerEvent // transitions not yet published, oldest first
+	publishing bool           // a goroutine is draining pubQueue
+
+	cb          atomic.Pointer[gobreaker.CircuitBreaker[*apiResponse]]
+	tripped     atomic.Bool
+	remoteUntil atomic.Int64 // unix nanos until which a peer holds it open
+	since       atomic.Int64 // unix nanos of the last transition
+}
+
+func newBreaker(name string, class MethodClass, settings CircuitBreakerSettings, clk clock.Clock, logger *slog.Logger, onChange func(string, gobreaker.State, gobreaker.State)) *breaker {
+	b := &breaker{name: name, class: class, settings: settings, clock: clk, logger: logger, onChange: onChange}
+	b.cb.Store(b.build())
+	b.since.Store(b.clock.Now().UnixNano())
+	return b
+}
+
+func (b *breaker) build() *gobreaker.CircuitBreaker[*apiResponse] {
+	return gobreaker.NewCircuitBreaker[*apiResponse](gobreaker.Settings{
+		Name:          b.name,
+		MaxRequests:   b.settings.MaxRequests,
+		Interval:      b.settings.Interval,
+		Timeout:       b.settings.Timeout,
+		ReadyToTrip:   b.settings.ReadyToTrip,
+		IsSuccessful:  isBreakerSuccess,
+		OnStateChange: b.localChanged,
+	})
+}
+
+// localChanged handles transitions of the gobreaker, announcing opening and
+// recovery to the coordinator.
+func (b *breaker) localChanged(name string, from, to gobreaker.State) {
+	b.changed(name, from, to)
+	if b.coord != nil && to != gobreaker.StateHalfOpen {
+		b.publish(BreakerEvent{Name: name, State: to, OpenFor: b.openFor()})
+	}
+}
+
+func (b *breaker) changed(name string, from, to gobreaker.State) {
+	b.since.Store(b.clock.Now().UnixNano())
+	b.logger.Info("circuit breaker state changed",
+		"name", name,
+		"from", from.String(),
+		"to", to.String(),
+	)
+	if b.onChange != nil {
+		b.onChange(name, from, to)
+	}
+}
+
+func (b *breaker) execute(fn func() (*apiResponse, error)) (*apiResponse, error) {
+	if b.tripped.Load() || b.remoteOpen() {
+		return nil, gobreaker.ErrOpenState
+	}
+	return b.cb.Load().Execute(fn)
+}
+
+func (b *breaker) state() gobreaker.State {
+	if b.tripped.Load() || b.remoteOpen() {
+		return gobreaker.StateOpen
+	}
+	return b.cb.Load().State()
+}
+
+func (b *breaker) snapshot() BreakerState {
+	cb := b.cb.Load()
+	return BreakerState{
+		Name:    b.name,
+		Class:   b.class,
+		State:   b.state(),
+		Counts:  cb.Counts(),
+		Since:   time.Unix(0, b.since.Load()),
+		Tripped: b.tripped.Load(),
+		Remote:  b.remoteOpen(),
+	}
+}
+
+func (b *breaker) trip() {
+	from := b.state()
+	if b.tripped.Swap(true) || from == gobreaker.StateOpen {
+		return
+	}
+	b.changed(b.name, from, gobreaker.StateOpen)
+}
+
+func (b *breaker) reset() {
+	from := b.state()
+	b.cb.Store(b.build())
+	b.tripped.Store(false)
+	b.remoteUntil.Store(0)
+	if from != gobreaker.StateClosed {
+		b.changed(b.name, from, gobreaker.StateClosed)
+	} else {
+		b.since.Store(b.clock.Now().UnixNano())
+	}
+}
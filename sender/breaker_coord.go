@@ -0,0 +1,252 @@
+package sender
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sony/gobreaker/v2"
+)
+
+// ================== Shared Circuit Breakers ==================
+//
+// Each replica's breakers count their own failures, so when Telegram is
+// down every replica burns its own retries before opening. A
+// BreakerCoordinator relays transitions: when one replica's breaker opens,
+// the breaker of the same name on every other replica opens for as long,
+// and closes again when the first one recovers.
+
+// BreakerEvent is a circuit breaker transition relayed between replicas.
+type BreakerEvent struct {
+	Name  string // breaker name, e.g. "galigo-sender" or "galigo-sender/media"
+	State gobreaker.State
+	// OpenFor is how long the breaker stays open before probing
+	// (CircuitBreakerSettings.Timeout). Set for StateOpen only.
+	OpenFor time.Duration
+}
+
+// BreakerCoordinator shares circuit breaker transitions between replicas
+// of one bot. Publish must not deliver an event back to the publishing
+// client. Implementations must be safe for concurrent use.
+type BreakerCoordinator interface {
+	// Publish announces a transition of a local breaker.
+	Publish(ctx context.Context, ev BreakerEvent) error
+
+	// Subscribe calls fn for every transition published by another
+	// replica until the returned function is called.
+	Subscribe(fn func(BreakerEvent)) (unsubscribe func())
+}
+
+// WithBreakerCoordinator shares the client's circuit breaker transitions
+// through coord: an opening on any replica opens the breaker of the same
+// name on all of them. Breakers held open by a peer report Remote in their
+// BreakerState. TripBreaker stays local.
+func WithBreakerCoordinator(coord BreakerCoordinator) Option {
+	return func(c *Client) {
+		c.breakerCoord = coord
+	}
+}
+
+// coordinateBreakers connects the breakers to the coordinator.
+func (c *Client) coordinateBreakers() {
+	if c.breakerCoord == nil {
+		return
+	}
+	byName := make(map[string]*breaker, len(c.breakers))
+	for _, b := range c.breakers {
+		b.coord = c.breakerCoord
+		byName[b.name] = b
+	}
+	c.stopCoord = c.breakerCoord.Subscribe(func(ev BreakerEvent) {
+		if b, ok := byName[ev.Name]; ok {
+			b.remote(ev)
+		}
+	})
+}
+
+// breakerPublishTimeout bounds announcing a transition.
+const breakerPublishTimeout = 5 * time.Second
+
+// publish announces ev in the background, as gobreaker reports
+// transitions while holding its lock. One goroutine at a time drains the
+// queue, so peers see the transitions in the order they happened.
+func (b *breaker) publish(ev BreakerEvent) {
+	b.pubMu.Lock()
+	defer b.pubMu.Unlock()
+	b.pubQueue = append(b.pubQueue, ev)
+	if !b.publishing {
+		b.publishing = true
+		go b.drainPublish()
+	}
+}
+
+// drainPublish publishes queued transitions until the queue is empty.
+func (b *breaker) drainPublish() {
+	for {
+		b.pubMu.Lock()
+		if len(b.pubQueue) == 0 {
+			b.publishing = false
+			b.pubMu.Unlock()
+			return
+		}
+		ev := b.pubQueue[0]
+		b.pubQueue = b.pubQueue[1:]
+		b.pubMu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), breakerPublishTimeout)
+		err := b.coord.Publish(ctx, ev)
+		cancel()
+		if err != nil {
+			b.logger.Warn("circuit breaker publish failed",
+				"name", ev.Name,
+				"state", ev.State.String(),
+				"error", err,
+			)
+		}
+	}
+}
+
+// openFor returns how long the breaker stays open.
+func (b *breaker) openFor() time.Duration {
+	if b.settings.Timeout > 0 {
+		return b.settings.Timeout
+	}
+	return 60 * time.Second // gobreaker's default
+}
+
+// remoteOpen reports whether a peer holds the breaker open.
+func (b *breaker) remoteOpen() bool {
+	until := b.remoteUntil.Load()
+	return until != 0 && b.clock.Now().UnixNano() < until
+}
+
+// remote applies a transition of a peer's breaker.
+func (b *breaker) remote(ev BreakerEvent) {
+	from := b.state()
+	switch ev.State {
+	case gobreaker.StateOpen:
+		if ev.OpenFor <= 0 {
+			return
+		}
+		b.remoteUntil.Store(b.clock.Now().Add(ev.OpenFor).UnixNano())
+	case gobreaker.StateClosed:
+		b.remoteUntil.Store(0)
+	default:
+		return
+	}
+	if to := b.state(); to != from {
+		b.changed(b.name, from, to)
+	}
+}
+
+// PubSubBreakerCoordinator is a BreakerCoordinator over a publish/subscribe
+// channel such as Redis PUBLISH/SUBSCRIBE. galigo does not depend on a
+// client: publish sends a payload to the channel, and the application
+// passes every payload received from it to Deliver. With go-redis:
+//
+//	coord := sender.NewPubSubBreakerCoordinator(func(ctx context.Context, payload []byte) error {
+//	    return rdb.Publish(ctx, "galigo:breaker:mybot", payload).Err()
+//	})
+//	go func() {
+//	    for msg := range rdb.Subscribe(ctx, "galigo:breaker:mybot").Channel() {
+//	        _ = coord.Deliver([]byte(msg.Payload))
+//	    }
+//	}()
+//
+// Payloads carry a random ID of the coordinator, so its own messages are
+// ignored when the channel echoes them. Use one coordinator per Client and
+// one channel per bot.
+type PubSubBreakerCoordinator struct {
+	publish func(ctx context.Context, payload []byte) error
+	origin  string
+
+	mu   sync.Mutex
+	subs map[int]func(BreakerEvent)
+	next int
+}
+
+var _ BreakerCoordinator = (*PubSubBreakerCoordinator)(nil)
+
+// NewPubSubBreakerCoordinator returns a PubSubBreakerCoordinator sending
+// payloads with publish.
+func NewPubSubBreakerCoordinator(publish func(ctx context.Context, payload []byte) error) *PubSubBreakerCoordinator {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return &PubSubBreakerCoordinator{
+		publish: publish,
+		origin:  hex.EncodeToString(b),
+		subs:    make(map[int]func(BreakerEvent)),
+	}
+}
+
+// breakerMessage is the payload of a PubSubBreakerCoordinator.
+type breakerMessage struct {
+	Origin  string `json:"origin"`
+	Name    string `json:"name"`
+	State   string `json:"state"`
+	OpenFor int64  `json:"open_for_ms,omitempty"`
+}
+
+// Publish sends ev to the channel.
+func (c *PubSubBreakerCoordinator) Publish(ctx context.Context, ev BreakerEvent) error {
+	payload, err := json.Marshal(breakerMessage{
+		Origin:  c.origin,
+		Name:    ev.Name,
+		State:   ev.State.String(),
+		OpenFor: ev.OpenFor.Milliseconds(),
+	})
+	if err != nil {
+		return err
+	}
+	return c.publish(ctx, payload)
+}
+
+// Subscribe registers fn for events delivered from other coordinators.
+func (c *PubSubBreakerCoordinator) Subscribe(fn func(BreakerEvent)) func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id := c.next
+	c.next++
+	c.subs[id] = fn
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.subs, id)
+	}
+}
+
+// Deliver passes a payload received from the channel to the subscribers.
+// Payloads published by c itself are ignored.
+func (c *PubSubBreakerCoordinator) Deliver(payload []byte) error {
+	var msg breakerMessage
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		return fmt.Errorf("galigo: breaker event: %w", err)
+	}
+	if msg.Origin == c.origin {
+		return nil
+	}
+	ev := BreakerEvent{Name: msg.Name, OpenFor: time.Duration(msg.OpenFor) * time.Millisecond}
+	switch msg.State {
+	case gobreaker.StateOpen.String():
+		ev.State = gobreaker.StateOpen
+	case gobreaker.StateClosed.String():
+		ev.State = gobreaker.StateClosed
+	default:
+		return fmt.Errorf("galigo: breaker event: unknown state %q", msg.State)
+	}
+
+	c.mu.Lock()
+	subs := make([]func(BreakerEvent), 0, len(c.subs))
+	for _, fn := range c.subs {
+		subs = append(subs, fn)
+	}
+	c.mu.Unlock()
+	for _, fn := range subs {
+		fn(ev)
+	}
+	return nil
+}
@@ -2,11 +2,14 @@ package sender_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/sony/gobreaker/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -249,3 +252,305 @@ func TestCircuitBreaker_CustomSettings(t *testing.T) {
 	// All requests should have gone through
 	assert.Equal(t, int32(10), requestCount.Load())
 }
+
+func TestCircuitBreaker_TripAndReset(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 123)
+	})
+
+	type transition struct{ from, to gobreaker.State }
+	var changes []transition
+	client := testutil.NewBreakerTestClient(t, server.BaseURL(),
+		sender.WithBreakerStateChange(func(_ string, from, to gobreaker.State) {
+			changes = append(changes, transition{from, to})
+		}))
+	req := sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"}
+
+	client.TripBreaker()
+	state := client.BreakerState()
+	assert.Equal(t, gobreaker.StateOpen, state.State)
+	assert.True(t, state.Tripped)
+
+	_, err := client.SendMessage(context.Background(), req)
+	require.ErrorIs(t, err, sender.ErrCircuitOpen)
+	assert.Equal(t, 0, server.CaptureCount())
+
+	client.ResetBreaker()
+	state = client.BreakerState()
+	assert.Equal(t, gobreaker.StateClosed, state.State)
+	assert.False(t, state.Tripped)
+
+	_, err = client.SendMessage(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, server.CaptureCount())
+
+	assert.Equal(t, []transition{
+		{gobreaker.StateClosed, gobreaker.StateOpen},
+		{gobreaker.StateOpen, gobreaker.StateClosed},
+	}, changes)
+}
+
+func TestCircuitBreaker_StateReportsCountsAndTransitions(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyServerError(w, 500, "Internal Server Error")
+	})
+
+	var opened atomic.Int32
+	client := testutil.NewBreakerTestClient(t, server.BaseURL(),
+		sender.WithBreakerStateChange(func(_ string, _, to gobreaker.State) {
+			if to == gobreaker.StateOpen {
+				opened.Add(1)
+			}
+		}))
+	req := sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"}
+
+	before := client.BreakerState()
+	_, _ = client.SendMessage(context.Background(), req)
+	state := client.BreakerState()
+	assert.Equal(t, gobreaker.StateClosed, state.State)
+	assert.Equal(t, uint32(1), state.Counts.ConsecutiveFailures)
+	assert.Equal(t, before.Since, state.Since)
+
+	_, _ = client.SendMessage(context.Background(), req)
+	state = client.BreakerState()
+	assert.Equal(t, gobreaker.StateOpen, state.State)
+	assert.False(t, state.Tripped)
+	assert.True(t, state.Since.After(before.Since))
+	assert.Equal(t, int32(1), opened.Load())
+
+	// Reset clears the counts and closes the breaker early.
+	client.ResetBreaker()
+	state = client.BreakerState()
+	assert.Equal(t, gobreaker.StateClosed, state.State)
+	assert.Zero(t, state.Counts.Requests)
+}
+
+func TestClassOf(t *testing.T) {
+	tests := map[string]sender.MethodClass{
+		"sendMessage":          sender.ClassMessaging,
+		"editMessageText":      sender.ClassMessaging,
+		"getChat":              sender.ClassMessaging,
+		"sendPhoto":            sender.ClassMedia,
+		"sendMediaGroup":       sender.ClassMedia,
+		"uploadStickerFile":    sender.ClassMedia,
+		"banChatMember":        sender.ClassAdmin,
+		"setChatTitle":         sender.ClassAdmin,
+		"unpinAllChatMessages": sender.ClassAdmin,
+		"setWebhook":           sender.ClassMessaging,
+	}
+	for method, want := range tests {
+		assert.Equal(t, want, sender.ClassOf(method), method)
+	}
+}
+
+func TestCircuitBreaker_PerClassIsolatesFailures(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendPhoto", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyServerError(w, 500, "Internal Server Error")
+	})
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 123)
+	})
+
+	client := testutil.NewBreakerTestClient(t, server.BaseURL(), sender.WithBreakerPerClass(nil))
+	ctx := context.Background()
+	photo := sender.SendPhotoRequest{ChatID: testutil.TestChatID, Photo: sender.FromFileID("photo")}
+
+	for range 3 {
+		_, _ = client.SendPhoto(ctx, photo)
+	}
+	_, err := client.SendPhoto(ctx, photo)
+	require.ErrorIs(t, err, sender.ErrCircuitOpen)
+
+	_, err = client.SendMessage(ctx, sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"})
+	require.NoError(t, err)
+
+	health := client.BreakerHealth()
+	assert.False(t, health.Healthy)
+	assert.Equal(t, []sender.MethodClass{sender.ClassMedia}, health.Degraded)
+	require.Len(t, health.Breakers, len(sender.MethodClasses))
+	assert.Equal(t, "galigo-sender/media", client.BreakerState().Name)
+}
+
+func TestCircuitBreaker_PerClassSettings(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyServerError(w, 500, "Internal Server Error")
+	})
+
+	lenient := testutil.CircuitBreakerAggressiveTrip()
+	lenient.ReadyToTrip = func(counts gobreaker.Counts) bool { return counts.ConsecutiveFailures >= 100 }
+	client := testutil.NewBreakerTestClient(t, server.BaseURL(),
+		sender.WithBreakerPerClass(map[sender.MethodClass]sender.CircuitBreakerSettings{
+			sender.ClassMessaging: lenient,
+		}))
+
+	for range 5 {
+		_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"})
+		require.NotErrorIs(t, err, sender.ErrCircuitOpen)
+	}
+	assert.True(t, client.BreakerHealth().Healthy)
+}
+
+func TestCircuitBreaker_PerClassSettingsMergeFields(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyServerError(w, 500, "Internal Server Error")
+	})
+
+	// Only Timeout is set: ReadyToTrip still comes from the client settings.
+	client := testutil.NewBreakerTestClient(t, server.BaseURL(),
+		sender.WithBreakerPerClass(map[sender.MethodClass]sender.CircuitBreakerSettings{
+			sender.ClassMessaging: {Timeout: 50 * time.Millisecond},
+		}))
+
+	for range 2 {
+		_, _ = client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"})
+	}
+	require.Equal(t, gobreaker.StateOpen, client.BreakerState().State)
+	require.Eventually(t, func() bool {
+		return client.BreakerState().State == gobreaker.StateHalfOpen
+	}, time.Second, 5*time.Millisecond, "the class Timeout is kept")
+}
+
+// pubSubHub connects PubSubBreakerCoordinators like a Redis channel,
+// echoing every payload to all of them including the publisher.
+type pubSubHub struct {
+	coords []*sender.PubSubBreakerCoordinator
+}
+
+func (h *pubSubHub) join() *sender.PubSubBreakerCoordinator {
+	coord := sender.NewPubSubBreakerCoordinator(func(_ context.Context, payload []byte) error {
+		for _, c := range h.coords {
+			if err := c.Deliver(payload); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	h.coords = append(h.coords, coord)
+	return coord
+}
+
+func TestCircuitBreaker_Coordinator(t *testing.T) {
+	failing := testutil.NewMockServer(t)
+	failing.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyServerError(w, 500, "Internal Server Error")
+	})
+	healthy := testutil.NewMockServer(t)
+	healthy.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+
+	hub := &pubSubHub{}
+	var changes atomic.Int32
+	a := testutil.NewBreakerTestClient(t, failing.BaseURL(), sender.WithBreakerCoordinator(hub.join()))
+	b := testutil.NewBreakerTestClient(t, healthy.BaseURL(),
+		sender.WithBreakerCoordinator(hub.join()),
+		sender.WithBreakerStateChange(func(string, gobreaker.State, gobreaker.State) { changes.Add(1) }),
+	)
+	peer := hub.join()
+
+	req := sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"}
+	for range 3 {
+		_, _ = a.SendMessage(context.Background(), req)
+	}
+	require.Equal(t, gobreaker.StateOpen, a.BreakerState().State)
+	assert.False(t, a.BreakerState().Remote, "own events are not echoed back")
+
+	require.Eventually(t, func() bool {
+		return b.BreakerState().State == gobreaker.StateOpen
+	}, time.Second, 5*time.Millisecond)
+	assert.True(t, b.BreakerState().Remote)
+	assert.Equal(t, int32(1), changes.Load())
+	_, err := b.SendMessage(context.Background(), req)
+	assert.ErrorIs(t, err, sender.ErrCircuitOpen)
+	assert.Equal(t, 0, healthy.CaptureCount())
+
+	// A peer recovering closes it again.
+	require.NoError(t, peer.Publish(context.Background(), sender.BreakerEvent{Name: "galigo-sender", State: gobreaker.StateClosed}))
+	assert.Equal(t, gobreaker.StateClosed, b.BreakerState().State)
+	assert.Equal(t, int32(2), changes.Load())
+	_, err = b.SendMessage(context.Background(), req)
+	assert.NoError(t, err)
+}
+
+func TestCircuitBreaker_CoordinatorPublishesInOrder(t *testing.T) {
+	var healthy atomic.Bool
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		if healthy.Load() {
+			testutil.ReplyMessage(w, 1)
+			return
+		}
+		testutil.ReplyServerError(w, 500, "Internal Server Error")
+	})
+
+	// The first publish is held until the breaker has recovered.
+	release := make(chan struct{})
+	var calls atomic.Int32
+	var mu sync.Mutex
+	var states []string
+	coord := sender.NewPubSubBreakerCoordinator(func(_ context.Context, payload []byte) error {
+		if calls.Add(1) == 1 {
+			<-release
+		}
+		var msg struct {
+			State string `json:"state"`
+		}
+		_ = json.Unmarshal(payload, &msg)
+		mu.Lock()
+		defer mu.Unlock()
+		states = append(states, msg.State)
+		return nil
+	})
+	settings := testutil.CircuitBreakerAggressiveTrip()
+	settings.Timeout = 20 * time.Millisecond
+	client := testutil.NewBreakerTestClient(t, server.BaseURL(),
+		sender.WithCircuitBreakerSettings(settings),
+		sender.WithBreakerCoordinator(coord),
+	)
+
+	req := sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"}
+	for range 2 {
+		_, _ = client.SendMessage(context.Background(), req)
+	}
+	require.Equal(t, gobreaker.StateOpen, client.BreakerState().State)
+	require.Eventually(t, func() bool {
+		return client.BreakerState().State == gobreaker.StateHalfOpen
+	}, time.Second, 5*time.Millisecond)
+	healthy.Store(true)
+	_, err := client.SendMessage(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, gobreaker.StateClosed, client.BreakerState().State)
+	assert.Never(t, func() bool { return calls.Load() > 1 }, 50*time.Millisecond, 5*time.Millisecond,
+		"the recovery is not published before the opening")
+
+	close(release)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(states) == 2
+	}, time.Second, 5*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"open", "closed"}, states)
+}
+
+func TestPubSubBreakerCoordinator_Deliver(t *testing.T) {
+	coord := sender.NewPubSubBreakerCoordinator(func(context.Context, []byte) error { return nil })
+	var got []sender.BreakerEvent
+	unsubscribe := coord.Subscribe(func(ev sender.BreakerEvent) { got = append(got, ev) })
+
+	require.NoError(t, coord.Deliver([]byte(`{"origin":"peer","name":"galigo-sender/media","state":"open","open_for_ms":30000}`)))
+	assert.Equal(t, []sender.BreakerEvent{{Name: "galigo-sender/media", State: gobreaker.StateOpen, OpenFor: 30 * time.Second}}, got)
+
+	assert.Error(t, coord.Deliver([]byte(`{"origin":"peer","state":"half-open"}`)))
+	assert.Error(t, coord.Deliver([]byte(`not json`)))
+
+	unsubscribe()
+	require.NoError(t, coord.Deliver([]byte(`{"origin":"peer","name":"x","state":"closed"}`)))
+	assert.Len(t, got, 1)
+}
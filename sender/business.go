@@ -100,11 +100,7 @@ func (c *Client) GetBusinessConnection(ctx context.Context, businessConnectionID
 		return nil, tg.NewValidationError("business_connection_id", "required")
 	}
 
-	var result tg.BusinessConnection
-	if err := c.callJSON(ctx, "getBusinessConnection", GetBusinessConnectionRequest{BusinessConnectionID: businessConnectionID}, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.BusinessConnection](c, ctx, "getBusinessConnection", GetBusinessConnectionRequest{BusinessConnectionID: businessConnectionID})
 }
 
 // SetBusinessAccountName sets the name of a business account.
@@ -169,11 +165,7 @@ func (c *Client) PostStory(ctx context.Context, req PostStoryRequest) (*tg.Story
 		return nil, err
 	}
 
-	var result tg.Story
-	if err := c.callJSON(ctx, "postStory", payload, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.Story](c, ctx, "postStory", payload)
 }
 
 // EditStory edits a story posted by a business account.
@@ -190,11 +182,7 @@ func (c *Client) EditStory(ctx context.Context, req EditStoryRequest) (*tg.Story
 		return nil, err
 	}
 
-	var result tg.Story
-	if err := c.callJSON(ctx, "editStory", payload, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.Story](c, ctx, "editStory", payload)
 }
 
 // DeleteStory deletes a story posted by a business account.
@@ -229,11 +217,7 @@ func (c *Client) GetBusinessAccountStarBalance(ctx context.Context, businessConn
 		return nil, tg.NewValidationError("business_connection_id", "required")
 	}
 
-	var result tg.StarAmount
-	if err := c.callJSON(ctx, "getBusinessAccountStarBalance", GetBusinessAccountStarBalanceRequest{BusinessConnectionID: businessConnectionID}, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.StarAmount](c, ctx, "getBusinessAccountStarBalance", GetBusinessAccountStarBalanceRequest{BusinessConnectionID: businessConnectionID})
 }
 
 // SetBusinessAccountProfilePhoto sets the profile photo of a business account.
@@ -273,12 +257,8 @@ func resolveInputFile(file InputFile, attachName string) (string, *FilePart, err
 	case file.URL != "":
 		return file.URL, nil, nil
 	case file.Reader != nil || file.Source != nil:
-		fp := &FilePart{
-			FieldName: attachName,
-			FileName:  file.FileName,
-			Reader:    file.OpenReader(),
-		}
-		return "attach://" + attachName, fp, nil
+		fp := file.filePart(attachName)
+		return "attach://" + attachName, &fp, nil
 	default:
 		return "", nil, fmt.Errorf("InputFile must have FileID, URL, or Reader set")
 	}
@@ -0,0 +1,341 @@
+package sender
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Response Cache ==================
+//
+// Bots call GetMe, GetChat and friends far more often than the answers
+// change. WithCache serves those idempotent getters from a Cache for a TTL,
+// skipping the rate limiter and the network, and drops cached answers when
+// the client itself calls a setter that changes them (SetChatTitle
+// invalidates GetChat for that chat, SetMyCommands invalidates
+// GetMyCommands, and so on). Changes made elsewhere (another bot instance,
+// a chat admin in the Telegram app) are picked up when the TTL expires, or
+// sooner with InvalidateChat from an update handler.
+//
+// Invalidation goes through the Cache itself: each scope has a generation
+// stored next to the entries, and keys embed the current generations. A
+// shared Cache therefore invalidates for every replica, and a restarted
+// process continues from the stored generations.
+
+// Cache stores raw API results. Implementations must be safe for
+// concurrent use; a shared Cache (e.g. Redis) may serve several bots, as
+// keys include the bot ID.
+type Cache interface {
+	// Get returns the value stored under key, if present and not expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key for ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// cachedMethods maps each cacheable getter to the scope its answers
+// belong to: a chat, the bot's settings, or the bot user itself.
+var cachedMethods = map[string]cacheScope{
+	"getMe":                           scopeMe,
+	"getChat":                         scopeChat,
+	"getChatAdministrators":           scopeChat,
+	"getChatMemberCount":              scopeChat,
+	"getMyCommands":                   scopeBot,
+	"getMyName":                       scopeBot,
+	"getMyDescription":                scopeBot,
+	"getMyShortDescription":           scopeBot,
+	"getMyDefaultAdministratorRights": scopeBot,
+}
+
+// invalidatedBy maps setters to the scopes whose cached answers they change.
+var invalidatedBy = map[string][]cacheScope{
+	"setChatTitle":                    {scopeChat},
+	"setChatDescription":              {scopeChat},
+	"setChatPhoto":                    {scopeChat},
+	"deleteChatPhoto":                 {scopeChat},
+	"setChatPermissions":              {scopeChat},
+	"pinChatMessage":                  {scopeChat},
+	"unpinChatMessage":                {scopeChat},
+	"unpinAllChatMessages":            {scopeChat},
+	"promoteChatMember":               {scopeChat},
+	"restrictChatMember":              {scopeChat},
+	"banChatMember":                   {scopeChat},
+	"unbanChatMember":                 {scopeChat},
+	"banChatSenderChat":               {scopeChat},
+	"unbanChatSenderChat":             {scopeChat},
+	"setChatAdministratorCustomTitle": {scopeChat},
+	"setChatMemberTag":                {scopeChat},
+	"leaveChat":                       {scopeChat},
+	"setMyCommands":                   {scopeBot},
+	"deleteMyCommands":                {scopeBot},
+	"setMyDescription":                {scopeBot},
+	"setMyShortDescription":           {scopeBot},
+	"setMyDefaultAdministratorRights": {scopeBot},
+	"setMyName":                       {scopeBot, scopeMe},
+	"setMyProfilePhoto":               {scopeBot, scopeMe},
+}
+
+type cacheScope int
+
+const (
+	scopeChat cacheScope = iota
+	scopeBot
+	scopeMe
+)
+
+// responseCache keeps a generation per scope tag in the Cache; replacing
+// it makes every key built with the old generation unreachable, so
+// invalidation works with any Cache and needs no deletes or key scans.
+type responseCache struct {
+	cache Cache
+	ttl   time.Duration
+	botID string
+}
+
+// epochTag is the generation tag bumped by InvalidateCache; it is part of
+// every key.
+const epochTag = "*"
+
+// WithCache caches the results of idempotent getters (GetMe, GetChat,
+// GetChatAdministrators, GetChatMemberCount, GetMyCommands, GetMyName,
+// GetMyDescription, GetMyShortDescription, GetMyDefaultAdministratorRights)
+// in cache for ttl. Setters called through the client invalidate the
+// affected entries. Use NewMemoryCache for a process-local cache.
+func WithCache(cache Cache, ttl time.Duration) Option {
+	return func(c *Client) {
+		if cache == nil || ttl <= 0 {
+			c.cache = nil
+			return
+		}
+		c.cache = &responseCache{cache: cache, ttl: ttl}
+	}
+}
+
+// InvalidateChat drops cached answers about chatID (GetChat,
+// GetChatAdministrators, GetChatMemberCount). Call it from update handlers
+// when a chat changes outside the bot, e.g. on chat_member updates.
+func (c *Client) InvalidateChat(chatID tg.ChatID) {
+	if c.cache != nil {
+		c.cacheBump(context.Background(), chatTag(extractChatID(chatID)))
+	}
+}
+
+// InvalidateCache drops every cached answer.
+func (c *Client) InvalidateCache() {
+	if c.cache != nil {
+		c.cacheBump(context.Background(), epochTag)
+	}
+}
+
+// cacheBump replaces the generation of tag, logging failures: a lost bump
+// leaves stale answers until the TTL expires.
+func (c *Client) cacheBump(ctx context.Context, tag string) {
+	if err := c.cache.bump(ctx, tag); err != nil {
+		c.logger.Warn("response cache invalidation failed", "tag", tag, "error", err)
+	}
+}
+
+func chatTag(chatID string) string { return "chat:" + chatID }
+
+func (rc *responseCache) tag(scope cacheScope, chatID string) string {
+	switch scope {
+	case scopeChat:
+		return chatTag(chatID)
+	case scopeBot:
+		return "bot"
+	default:
+		return "me"
+	}
+}
+
+func (rc *responseCache) genKey(tag string) string {
+	return "galigo:" + rc.botID + ":gen:" + tag
+}
+
+// generation returns the stored generation of tag; "0" until first bumped.
+func (rc *responseCache) generation(ctx context.Context, tag string) (string, error) {
+	gen, ok, err := rc.cache.Get(ctx, rc.genKey(tag))
+	if err != nil || !ok {
+		return "0", err
+	}
+	return string(gen), nil
+}
+
+// bump stores a fresh random generation for tag. The generation lives as
+// long as the entries; when it expires the tag falls back to "0", which
+// only entries written before the first bump used, and those have expired
+// by then.
+func (rc *responseCache) bump(ctx context.Context, tag string) error {
+	return rc.cache.Set(ctx, rc.genKey(tag), []byte(rand.Text()), rc.ttl)
+}
+
+// key returns the cache key of a getter call, or "" if it is not cached.
+func (rc *responseCache) key(ctx context.Context, method string, payload any, chatID string) (string, error) {
+	scope, ok := cachedMethods[method]
+	if !ok {
+		return "", nil
+	}
+	if scope == scopeChat && chatID == "" {
+		return "", nil
+	}
+	params, err := json.Marshal(payload)
+	if err != nil {
+		return "", nil
+	}
+	tag := rc.tag(scope, chatID)
+
+	epoch, err := rc.generation(ctx, epochTag)
+	if err != nil {
+		return "", err
+	}
+	gen, err := rc.generation(ctx, tag)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("galigo:%s:%s:%s.%s:%s:%s", rc.botID, tag, epoch, gen, method, params), nil
+}
+
+// cacheLookup returns a cached result for method, and the key to store a
+// fresh result under ("" when the call is not cacheable).
+func (c *Client) cacheLookup(ctx context.Context, method string, payload any, chatID string) (*apiResponse, string) {
+	if c.cache == nil {
+		return nil, ""
+	}
+	if chatID == "" {
+		chatID = payloadChatID(payload)
+	}
+	key, err := c.cache.key(ctx, method, payload, chatID)
+	if err != nil {
+		c.logger.Debug("response cache generation lookup failed", "method", method, "error", err)
+		return nil, ""
+	}
+	if key == "" {
+		return nil, ""
+	}
+	raw, ok, err := c.cache.cache.Get(ctx, key)
+	if err != nil {
+		c.logger.Debug("response cache get failed", "method", method, "error", err)
+		return nil, key
+	}
+	if !ok {
+		return nil, key
+	}
+	return &apiResponse{OK: true, Result: raw}, key
+}
+
+// cacheStore records a getter result, or applies a setter's invalidation.
+func (c *Client) cacheStore(ctx context.Context, method string, payload any, chatID, key string, resp *apiResponse) {
+	if c.cache == nil {
+		return
+	}
+	if key != "" {
+		if err := c.cache.cache.Set(ctx, key, resp.Result, c.cache.ttl); err != nil {
+			c.logger.Debug("response cache set failed", "method", method, "error", err)
+		}
+		return
+	}
+	if _, ok := invalidatedBy[method]; ok {
+		if chatID == "" {
+			chatID = payloadChatID(payload)
+		}
+		for _, scope := range invalidatedBy[method] {
+			if scope == scopeChat && chatID == "" {
+				continue
+			}
+			c.cacheBump(context.WithoutCancel(ctx), c.cache.tag(scope, chatID))
+		}
+	}
+}
+
+// botIDFromToken returns the numeric bot ID prefix of a token.
+func botIDFromToken(token string) string {
+	id, _, _ := strings.Cut(token, ":")
+	return id
+}
+
+// ================== Memory Cache ==================
+
+// MemoryCache is an in-process Cache with per-entry expiry.
+// The zero value is ready to use.
+type MemoryCache struct {
+	// MaxEntries bounds the number of entries (0 = 10000). When full,
+	// expired entries are purged first, then arbitrary ones.
+	MaxEntries int
+
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+}
+
+type memoryCacheEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+var _ Cache = (*MemoryCache)(nil)
+
+// NewMemoryCache returns a MemoryCache holding at most maxEntries entries.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{MaxEntries: maxEntries}
+}
+
+// Get returns the value under key unless it has expired.
+func (m *MemoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if time.Now().After(e.expires) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+	return e.value, true, nil
+}
+
+// Set stores value under key for ttl.
+func (m *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.entries == nil {
+		m.entries = make(map[string]memoryCacheEntry)
+	}
+	limit := m.MaxEntries
+	if limit <= 0 {
+		limit = 10000
+	}
+	if _, exists := m.entries[key]; !exists && len(m.entries) >= limit {
+		m.evict(limit)
+	}
+	m.entries[key] = memoryCacheEntry{value: value, expires: time.Now().Add(ttl)}
+	return nil
+}
+
+// Len returns the number of stored entries, including expired ones not yet
+// purged.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+// evict makes room for one entry. Callers hold m.mu.
+func (m *MemoryCache) evict(limit int) {
+	now := time.Now()
+	for k, e := range m.entries {
+		if now.After(e.expires) {
+			delete(m.entries, k)
+		}
+	}
+	for k := range m.entries {
+		if len(m.entries) < limit {
+			break
+		}
+		delete(m.entries, k)
+	}
+}
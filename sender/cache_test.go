@@ -0,0 +1,229 @@
+package sender_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func chatHandler(title string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, map[string]any{"id": testutil.TestChatID, "type": "group", "title": title})
+	}
+}
+
+func TestCache_GetMeServedFromCache(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getMe", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, map[string]any{"id": 42, "is_bot": true, "first_name": "Bot"})
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithCache(&sender.MemoryCache{}, time.Minute))
+
+	for range 3 {
+		me, err := client.GetMe(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), me.ID)
+	}
+	assert.Equal(t, 1, server.CaptureCount())
+}
+
+func TestCache_KeyIncludesParams(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getChat", chatHandler("Group"))
+
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithCache(&sender.MemoryCache{}, time.Minute))
+
+	_, err := client.GetChat(context.Background(), int64(1))
+	require.NoError(t, err)
+	_, err = client.GetChat(context.Background(), int64(2))
+	require.NoError(t, err)
+	_, err = client.GetChat(context.Background(), int64(1))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, server.CaptureCount())
+}
+
+func TestCache_TTLExpiry(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getChat", chatHandler("Group"))
+
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithCache(&sender.MemoryCache{}, 20*time.Millisecond))
+
+	_, err := client.GetChat(context.Background(), testutil.TestChatID)
+	require.NoError(t, err)
+	time.Sleep(40 * time.Millisecond)
+	_, err = client.GetChat(context.Background(), testutil.TestChatID)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, server.CaptureCount())
+}
+
+func TestCache_SetChatTitleInvalidatesGetChat(t *testing.T) {
+	title := "Old"
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getChat", func(w http.ResponseWriter, r *http.Request) {
+		chatHandler(title)(w, r)
+	})
+	server.On("/bot"+testutil.TestToken+"/setChatTitle", func(w http.ResponseWriter, r *http.Request) {
+		title = "New"
+		testutil.ReplyOK(w, true)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithCache(&sender.MemoryCache{}, time.Minute))
+	ctx := context.Background()
+
+	chat, err := client.GetChat(ctx, testutil.TestChatID)
+	require.NoError(t, err)
+	assert.Equal(t, "Old", chat.Title)
+
+	require.NoError(t, client.SetChatTitle(ctx, testutil.TestChatID, "New"))
+
+	chat, err = client.GetChat(ctx, testutil.TestChatID)
+	require.NoError(t, err)
+	assert.Equal(t, "New", chat.Title)
+	assert.Equal(t, 3, server.CaptureCount())
+}
+
+func TestCache_InvalidationSharedAcrossReplicas(t *testing.T) {
+	title := "Old"
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getChat", func(w http.ResponseWriter, r *http.Request) {
+		chatHandler(title)(w, r)
+	})
+	server.On("/bot"+testutil.TestToken+"/setChatTitle", func(w http.ResponseWriter, r *http.Request) {
+		title = "New"
+		testutil.ReplyOK(w, true)
+	})
+
+	shared := &sender.MemoryCache{}
+	replicaA := testutil.NewTestClient(t, server.BaseURL(), sender.WithCache(shared, time.Minute))
+	replicaB := testutil.NewTestClient(t, server.BaseURL(), sender.WithCache(shared, time.Minute))
+	ctx := context.Background()
+
+	chat, err := replicaB.GetChat(ctx, testutil.TestChatID)
+	require.NoError(t, err)
+	assert.Equal(t, "Old", chat.Title)
+
+	require.NoError(t, replicaA.SetChatTitle(ctx, testutil.TestChatID, "New"))
+
+	chat, err = replicaB.GetChat(ctx, testutil.TestChatID)
+	require.NoError(t, err)
+	assert.Equal(t, "New", chat.Title, "invalidation by one replica reaches the other")
+
+	// A restarted replica reads the stored generation, not a stale entry.
+	restarted := testutil.NewTestClient(t, server.BaseURL(), sender.WithCache(shared, time.Minute))
+	replicaA.InvalidateCache()
+	title = "Newest"
+	chat, err = restarted.GetChat(ctx, testutil.TestChatID)
+	require.NoError(t, err)
+	assert.Equal(t, "Newest", chat.Title)
+}
+
+func TestCache_SetMyCommandsInvalidatesGetMyCommands(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getMyCommands", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, []tg.BotCommand{{Command: "start", Description: "Start"}})
+	})
+	server.On("/bot"+testutil.TestToken+"/setMyCommands", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, true)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithCache(&sender.MemoryCache{}, time.Minute))
+	ctx := context.Background()
+
+	_, err := client.GetMyCommands(ctx)
+	require.NoError(t, err)
+	_, err = client.GetMyCommands(ctx)
+	require.NoError(t, err)
+	require.NoError(t, client.SetMyCommands(ctx, []tg.BotCommand{{Command: "help", Description: "Help"}}))
+	_, err = client.GetMyCommands(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, server.CaptureCount())
+}
+
+func TestCache_InvalidateChat(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getChatMemberCount", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, 10)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithCache(&sender.MemoryCache{}, time.Minute))
+	ctx := context.Background()
+
+	_, err := client.GetChatMemberCount(ctx, testutil.TestChatID)
+	require.NoError(t, err)
+	client.InvalidateChat(testutil.TestChatID)
+	_, err = client.GetChatMemberCount(ctx, testutil.TestChatID)
+	require.NoError(t, err)
+	client.InvalidateCache()
+	_, err = client.GetChatMemberCount(ctx, testutil.TestChatID)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, server.CaptureCount())
+}
+
+func TestCache_ErrorsNotCached(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getChat", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBadRequest(w, "Bad Request: chat not found")
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithCache(&sender.MemoryCache{}, time.Minute))
+
+	for range 2 {
+		_, err := client.GetChat(context.Background(), testutil.TestChatID)
+		require.Error(t, err)
+	}
+	assert.Equal(t, 2, server.CaptureCount())
+}
+
+func TestCache_SharedAcrossBots(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getMe", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, map[string]any{"id": 1, "is_bot": true, "first_name": "One"})
+	})
+	const otherToken = "999999:OTHER-token"
+	server.On("/bot"+otherToken+"/getMe", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, map[string]any{"id": 2, "is_bot": true, "first_name": "Two"})
+	})
+
+	cache := &sender.MemoryCache{}
+	first := testutil.NewTestClient(t, server.BaseURL(), sender.WithCache(cache, time.Minute))
+	second, err := sender.New(otherToken, sender.WithBaseURL(server.BaseURL()), sender.WithCache(cache, time.Minute))
+	require.NoError(t, err)
+	t.Cleanup(func() { second.Close() })
+
+	me1, err := first.GetMe(context.Background())
+	require.NoError(t, err)
+	me2, err := second.GetMe(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "One", me1.FirstName)
+	assert.Equal(t, "Two", me2.FirstName)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestMemoryCache_MaxEntries(t *testing.T) {
+	cache := sender.NewMemoryCache(2)
+	ctx := context.Background()
+
+	require.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, cache.Set(ctx, "b", []byte("2"), time.Minute))
+	require.NoError(t, cache.Set(ctx, "c", []byte("3"), time.Minute))
+
+	assert.Equal(t, 2, cache.Len())
+	v, ok, err := cache.Get(ctx, "c")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("3"), v)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
 )
 
 // callJSON is the unified internal helper for all API calls.
@@ -24,8 +25,71 @@ func (c *Client) callJSON(ctx context.Context, method string, payload any, out a
 	if out == nil {
 		return nil // For methods that return bool/void
 	}
-	if err := json.Unmarshal(resp.Result, out); err != nil {
+	if err := c.decoder.Decode(resp.Result, out); err != nil {
 		return fmt.Errorf("galigo: %s: failed to parse response: %w", method, err)
 	}
 	return nil
 }
+
+// call is the generic form of callJSON for methods returning a single value.
+//
+// Usage:
+//
+//	return call[tg.Message](c, ctx, "sendDocument", req, extractChatID(req.ChatID))
+func call[T any](c *Client, ctx context.Context, method string, payload any, chatIDs ...string) (*T, error) {
+	var out T
+	if err := c.callJSON(ctx, method, payload, &out, chatIDs...); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Invoke calls an arbitrary Bot API method and returns the raw result.
+// It is an escape hatch for methods galigo does not wrap yet; the request
+// goes through the same rate limiting, circuit breaker and error mapping
+// as the typed methods. The chat_id field of payload, if present, selects
+// the per-chat rate limiter.
+func (c *Client) Invoke(ctx context.Context, method string, payload any) (json.RawMessage, error) {
+	if payload == nil {
+		payload = struct{}{}
+	}
+	resp, err := c.executeRequest(ctx, method, payload, payloadChatID(payload))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Result, nil
+}
+
+// Call invokes an arbitrary Bot API method and decodes the result into T.
+//
+// Usage:
+//
+//	msg, err := sender.Call[tg.Message](ctx, client, "sendMessage", map[string]any{
+//	    "chat_id": chatID,
+//	    "text":    "Hello",
+//	})
+func Call[T any](ctx context.Context, c *Client, method string, payload any) (*T, error) {
+	if payload == nil {
+		payload = struct{}{}
+	}
+	return call[T](c, ctx, method, payload, payloadChatID(payload))
+}
+
+// payloadChatID extracts the chat ID of a request struct or map for rate limiting.
+func payloadChatID(payload any) string {
+	if m, ok := payload.(map[string]any); ok {
+		if id, ok := m["chat_id"]; ok && id != nil {
+			return extractChatID(id)
+		}
+		return ""
+	}
+	rv := auditStruct(payload)
+	if !rv.IsValid() {
+		return ""
+	}
+	f := rv.FieldByName("ChatID")
+	if !f.IsValid() || (f.Kind() == reflect.Interface && f.IsNil()) {
+		return ""
+	}
+	return extractChatID(f.Interface())
+}
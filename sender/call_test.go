@@ -64,3 +64,65 @@ func TestCallJSON_VoidMethod(t *testing.T) {
 	})
 	assert.NoError(t, err)
 }
+
+func TestCall_Generic(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 55)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	msg, err := sender.Call[tg.Message](context.Background(), client, "sendMessage", map[string]any{
+		"chat_id": testutil.TestChatID,
+		"text":    "hello",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 55, msg.MessageID)
+
+	cap := server.LastCapture()
+	cap.AssertJSONField(t, "text", "hello")
+}
+
+func TestCall_DecodeError(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getMe", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, "not an object")
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	_, err := sender.Call[tg.User](context.Background(), client, "getMe", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "getMe: failed to parse response")
+}
+
+func TestCall_StrictDecoder(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getMe", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, map[string]any{"id": 1, "is_bot": true, "first_name": "Bot", "new_field": 1})
+	})
+
+	lenient := testutil.NewTestClient(t, server.BaseURL())
+	_, err := lenient.GetMe(context.Background())
+	require.NoError(t, err)
+
+	strict := testutil.NewTestClient(t, server.BaseURL(), sender.WithDecoder(tg.StrictDecoder()))
+	_, err = strict.GetMe(context.Background())
+	var decErr *tg.DecodeError
+	require.ErrorAs(t, err, &decErr)
+	assert.Contains(t, err.Error(), `getMe: failed to parse response: json: unknown field "new_field"`)
+}
+
+func TestInvoke_RawResult(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/someFutureMethod", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, map[string]any{"answer": 42})
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	raw, err := client.Invoke(context.Background(), "someFutureMethod", nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"answer":42}`, string(raw))
+}
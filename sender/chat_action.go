@@ -0,0 +1,92 @@
+package sender
+
+import (
+	"context"
+	"time"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Chat Action Keep-Alive ==================
+
+// Telegram shows a chat action ("typing…", "sending photo…") for about
+// five seconds, or until the bot sends a message. Slow handlers have to
+// repeat it; WithChatAction does that for the duration of a callback.
+
+// defaultChatActionRefresh repeats the action just before Telegram clears it.
+const defaultChatActionRefresh = 4 * time.Second
+
+// WithChatActionRefresh sets how often WithChatAction repeats the action
+// (default 4s, Telegram shows an action for about 5s).
+func WithChatActionRefresh(d time.Duration) Option {
+	return func(c *Client) {
+		if d > 0 {
+			c.chatActionRefresh = d
+		}
+	}
+}
+
+// WithChatAction sends action to chatID, then runs fn and repeats the
+// action until fn returns. The first action is sent before fn starts, and
+// none is sent once fn has returned: the refresh is cancelled and waited
+// for before WithChatAction returns. fn's error is returned; failures to
+// send the action are logged, not returned, since the indicator is
+// cosmetic; an unknown action is returned without running fn.
+//
+//	err := client.WithChatAction(ctx, chatID, tg.ChatActionTyping, func(ctx context.Context) error {
+//	    answer := llm.Complete(ctx, prompt) // slow
+//	    _, err := client.SendMessage(ctx, sender.SendMessageRequest{ChatID: chatID, Text: answer})
+//	    return err
+//	})
+func (c *Client) WithChatAction(ctx context.Context, chatID tg.ChatID, action tg.ChatAction, fn func(ctx context.Context) error) error {
+	if err := validateChatAction(action); err != nil {
+		return err
+	}
+	c.sendChatAction(ctx, chatID, action)
+
+	actionCtx, stop := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.keepChatAction(actionCtx, chatID, action)
+	}()
+
+	err := fn(ctx)
+	stop()
+	<-done
+	return err
+}
+
+// WithTyping is WithChatAction with the "typing" action.
+func (c *Client) WithTyping(ctx context.Context, chatID tg.ChatID, fn func(ctx context.Context) error) error {
+	return c.WithChatAction(ctx, chatID, tg.ChatActionTyping, fn)
+}
+
+// keepChatAction resends action every refresh interval until ctx is done.
+func (c *Client) keepChatAction(ctx context.Context, chatID tg.ChatID, action tg.ChatAction) {
+	interval := c.chatActionRefresh
+	if interval <= 0 {
+		interval = defaultChatActionRefresh
+	}
+	ticker := c.clock.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C():
+		case <-ctx.Done():
+			return
+		}
+		// A tick and the cancellation can be ready together.
+		if ctx.Err() != nil {
+			return
+		}
+		c.sendChatAction(ctx, chatID, action)
+	}
+}
+
+// sendChatAction sends action, logging failures unless ctx is done.
+func (c *Client) sendChatAction(ctx context.Context, chatID tg.ChatID, action tg.ChatAction) {
+	if err := c.SendChatAction(ctx, chatID, action); err != nil && ctx.Err() == nil {
+		c.logger.Debug("chat action failed", "action", action, "error", err)
+	}
+}
@@ -0,0 +1,98 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/galigotest"
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestWithChatAction_RefreshesUntilDone(t *testing.T) {
+	var mu sync.Mutex
+	var events []string
+	record := func(event string) {
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	}
+	actions := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		n := 0
+		for _, e := range events {
+			if e == "action" {
+				n++
+			}
+		}
+		return n
+	}
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendChatAction", func(w http.ResponseWriter, r *http.Request) {
+		record("action")
+		testutil.ReplyOK(w, true)
+	})
+
+	clk := galigotest.NewFakeClock(time.Now())
+	client := testutil.NewTestClient(t, server.BaseURL(),
+		sender.WithClock(clk), sender.WithChatActionRefresh(5*time.Second))
+
+	err := client.WithTyping(context.Background(), testutil.TestChatID, func(ctx context.Context) error {
+		record("fn")
+		clk.BlockUntil(2) // the client's cleanup ticker and the refresh ticker
+		for range 2 {
+			before := actions()
+			clk.Advance(5 * time.Second)
+			require.Eventually(t, func() bool { return actions() > before }, time.Second, time.Millisecond)
+		}
+		record("reply")
+		return nil
+	})
+	require.NoError(t, err)
+	server.LastCapture().AssertJSONField(t, "action", "typing")
+
+	assert.Equal(t, 1, clk.Waiters(), "the refresh ticker has stopped")
+	clk.Advance(time.Minute)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"action", "fn", "action", "action", "reply"}, events)
+}
+
+func TestWithChatAction_ReturnsFnError(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendChatAction", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBadRequest(w, "Bad Request: chat not found")
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+	boom := errors.New("boom")
+
+	err := client.WithChatAction(context.Background(), testutil.TestChatID, "upload_photo", func(ctx context.Context) error {
+		return boom
+	})
+	assert.ErrorIs(t, err, boom, "action failures are not returned")
+}
+
+func TestWithChatAction_UnknownAction(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	called := false
+	err := client.WithChatAction(context.Background(), testutil.TestChatID, "uploading", func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	var verr *tg.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.False(t, called, "fn must not run with an unknown action")
+	assert.Equal(t, 0, server.CaptureCount())
+}
@@ -29,11 +29,7 @@ func (c *Client) GetChat(ctx context.Context, chatID tg.ChatID) (*tg.ChatFullInf
 		return nil, err
 	}
 
-	var result tg.ChatFullInfo
-	if err := c.callJSON(ctx, "getChat", GetChatRequest{ChatID: chatID}, &result, extractChatID(chatID)); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.ChatFullInfo](c, ctx, "getChat", GetChatRequest{ChatID: chatID}, extractChatID(chatID))
 }
 
 // GetChatAdministrators returns a list of administrators in a chat.
@@ -49,7 +45,7 @@ func (c *Client) GetChatAdministrators(ctx context.Context, chatID tg.ChatID) ([
 
 	// Custom unmarshaling for ChatMember union type
 	var rawMembers []json.RawMessage
-	if err := json.Unmarshal(resp.Result, &rawMembers); err != nil {
+	if err := c.decoder.Decode(resp.Result, &rawMembers); err != nil {
 		return nil, fmt.Errorf("galigo: getChatAdministrators: failed to parse response: %w", err)
 	}
 
@@ -57,7 +53,7 @@ func (c *Client) GetChatAdministrators(ctx context.Context, chatID tg.ChatID) ([
 	for _, raw := range rawMembers {
 		member, err := tg.UnmarshalChatMember(raw)
 		if err != nil {
-			return nil, fmt.Errorf("galigo: getChatAdministrators: %w", err)
+			return nil, fmt.Errorf("galigo: getChatAdministrators: %w", c.decoder.Wrap(raw, err))
 		}
 		members = append(members, member)
 	}
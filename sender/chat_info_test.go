@@ -2,8 +2,11 @@ package sender_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -85,6 +88,69 @@ func TestGetChat_Error_NotFound(t *testing.T) {
 	assert.Equal(t, 400, apiErr.Code)
 }
 
+// TestGetChat_Fixtures decodes recorded getChat responses and checks that
+// re-encoding the ChatFullInfo reproduces them, so no field is dropped.
+func TestGetChat_Fixtures(t *testing.T) {
+	files, err := filepath.Glob("testdata/getchat/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			raw, err := os.ReadFile(file)
+			require.NoError(t, err)
+			var recorded struct {
+				Result json.RawMessage `json:"result"`
+			}
+			require.NoError(t, json.Unmarshal(raw, &recorded))
+
+			server := testutil.NewMockServer(t)
+			server.On("/bot"+testutil.TestToken+"/getChat", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write(raw)
+			})
+			client := testutil.NewTestClient(t, server.BaseURL())
+
+			chat, err := client.GetChat(context.Background(), int64(-1001234567890))
+			require.NoError(t, err)
+			got, err := json.Marshal(chat)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(recorded.Result), string(got))
+		})
+	}
+}
+
+func TestGetChat_FullInfoFields(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getChat", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/getchat/private.json")
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	chat, err := client.GetChat(context.Background(), testutil.TestChatID)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, chat.AccentColorID)
+	assert.Equal(t, 11, chat.MaxReactionCount)
+	assert.Equal(t, "5420225678364473113", chat.EmojiStatusCustomEmojiID)
+	require.NotNil(t, chat.Birthdate)
+	assert.Equal(t, 14, chat.Birthdate.Day)
+	require.NotNil(t, chat.Birthdate.Year)
+	assert.Equal(t, 1992, *chat.Birthdate.Year)
+	require.NotNil(t, chat.BusinessIntro)
+	assert.Equal(t, "Jane's Bakery", chat.BusinessIntro.Title)
+	require.NotNil(t, chat.BusinessLocation)
+	require.NotNil(t, chat.BusinessLocation.Location)
+	require.NotNil(t, chat.BusinessOpeningHours)
+	assert.Len(t, chat.BusinessOpeningHours.OpeningHours, 2)
+	require.NotNil(t, chat.PersonalChat)
+	assert.Equal(t, "channel", chat.PersonalChat.Type)
+	assert.True(t, chat.AcceptedGiftTypes.GiftsFromChannels)
+	require.NotNil(t, chat.Rating)
+	assert.Equal(t, 4, chat.Rating.Level)
+	assert.Equal(t, 25, chat.PaidMessageStarCount)
+}
+
 // ==================== GetChatAdministrators ====================
 
 func TestGetChatAdministrators(t *testing.T) {
@@ -0,0 +1,112 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Chunked Forward/Copy ==================
+//
+// forwardMessages and copyMessages take 1-100 message IDs in strictly
+// increasing order. The chunked variants accept any number of IDs in any
+// order, send them as consecutive valid chunks so the messages arrive in
+// the given order, and report which chunks failed.
+
+// MaxBulkMessageIDs is the number of message IDs forwardMessages,
+// copyMessages and deleteMessages accept per call.
+const MaxBulkMessageIDs = 100
+
+// ChunkMessageIDs splits ids into chunks that forwardMessages and
+// copyMessages accept: at most MaxBulkMessageIDs strictly increasing IDs
+// each. Concatenating the chunks gives ids back; a new chunk starts
+// wherever ids does not increase.
+func ChunkMessageIDs(ids []int) [][]int {
+	var chunks [][]int
+	start := 0
+	for i := 1; i <= len(ids); i++ {
+		if i == len(ids) || i-start == MaxBulkMessageIDs || ids[i] <= ids[i-1] {
+			chunks = append(chunks, ids[start:i])
+			start = i
+		}
+	}
+	return chunks
+}
+
+// MessageChunkResult is the outcome of one chunk.
+type MessageChunkResult struct {
+	SourceIDs  []int          // IDs of the chunk in the source chat
+	MessageIDs []tg.MessageID // IDs of the sent messages; Telegram skips messages it cannot send
+	Err        error          // ErrBatchSkipped for chunks not sent after a failure
+}
+
+// ChunkedMessagesResult is the result of ForwardMessagesChunked and
+// CopyMessagesChunked.
+type ChunkedMessagesResult struct {
+	MessageIDs []tg.MessageID // IDs of all sent messages, in order
+	Chunks     []MessageChunkResult
+}
+
+// FailedIDs returns the source IDs of the chunks that were not sent.
+func (r *ChunkedMessagesResult) FailedIDs() []int {
+	var ids []int
+	for _, c := range r.Chunks {
+		if c.Err != nil {
+			ids = append(ids, c.SourceIDs...)
+		}
+	}
+	return ids
+}
+
+// ForwardMessagesChunked forwards req.MessageIDs, of any length and order,
+// in chunks (see ChunkMessageIDs). Each chunk is retried like SendMessage.
+// It stops at the first chunk that fails, so later messages are never
+// delivered ahead of earlier ones; the returned error names that chunk and
+// the result tells what was sent.
+func (c *Client) ForwardMessagesChunked(ctx context.Context, req ForwardMessagesRequest) (*ChunkedMessagesResult, error) {
+	return sendChunked(c, ctx, req.ChatID, "forwardMessages", req.MessageIDs, func(ctx context.Context, ids []int) ([]tg.MessageID, error) {
+		chunk := req
+		chunk.MessageIDs = ids
+		return c.ForwardMessages(ctx, chunk)
+	})
+}
+
+// CopyMessagesChunked copies req.MessageIDs, of any length and order, in
+// chunks. See ForwardMessagesChunked.
+func (c *Client) CopyMessagesChunked(ctx context.Context, req CopyMessagesRequest) (*ChunkedMessagesResult, error) {
+	return sendChunked(c, ctx, req.ChatID, "copyMessages", req.MessageIDs, func(ctx context.Context, ids []int) ([]tg.MessageID, error) {
+		chunk := req
+		chunk.MessageIDs = ids
+		return c.CopyMessages(ctx, chunk)
+	})
+}
+
+func sendChunked(c *Client, ctx context.Context, chatID tg.ChatID, method string, ids []int, send func(context.Context, []int) ([]tg.MessageID, error)) (*ChunkedMessagesResult, error) {
+	if err := validateChatID(chatID); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, tg.NewValidationError("message_ids", "required")
+	}
+
+	res := &ChunkedMessagesResult{}
+	var firstErr error
+	for i, ids := range ChunkMessageIDs(ids) {
+		chunk := MessageChunkResult{SourceIDs: ids}
+		if firstErr != nil {
+			chunk.Err = ErrBatchSkipped
+			res.Chunks = append(res.Chunks, chunk)
+			continue
+		}
+		chunk.MessageIDs, chunk.Err = withRetry(c, ctx, chatID, func(ctx context.Context) ([]tg.MessageID, error) {
+			return send(ctx, ids)
+		})
+		if chunk.Err != nil {
+			firstErr = fmt.Errorf("galigo: %s chunk %d (messages %d-%d): %w", method, i, ids[0], ids[len(ids)-1], chunk.Err)
+		}
+		res.MessageIDs = append(res.MessageIDs, chunk.MessageIDs...)
+		res.Chunks = append(res.Chunks, chunk)
+	}
+	return res, firstErr
+}
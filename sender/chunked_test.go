@@ -0,0 +1,105 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestChunkMessageIDs(t *testing.T) {
+	assert.Nil(t, sender.ChunkMessageIDs(nil))
+	assert.Equal(t, [][]int{{1, 2, 5}, {3, 4}, {4}}, sender.ChunkMessageIDs([]int{1, 2, 5, 3, 4, 4}))
+
+	long := make([]int, 250)
+	for i := range long {
+		long[i] = i + 1
+	}
+	chunks := sender.ChunkMessageIDs(long)
+	require.Len(t, chunks, 3)
+	assert.Len(t, chunks[0], 100)
+	assert.Len(t, chunks[2], 50)
+	assert.Equal(t, 201, chunks[2][0])
+}
+
+// chunkServer answers copyMessages with one new ID per source ID, failing
+// the chunk that starts with failAt with a non-retryable error.
+func chunkServer(t *testing.T, failAt int, serverErrors int) (*testutil.MockTelegramServer, *[][]int) {
+	t.Helper()
+	var (
+		mu    sync.Mutex
+		calls [][]int
+	)
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/copyMessages", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			MessageIDs []int `json:"message_ids"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, req.MessageIDs)
+		if serverErrors > 0 {
+			serverErrors--
+			testutil.ReplyServerError(w, 502, "Bad Gateway")
+			return
+		}
+		if req.MessageIDs[0] == failAt {
+			testutil.ReplyBadRequest(w, "message to copy not found")
+			return
+		}
+		var out []map[string]any
+		for _, id := range req.MessageIDs {
+			out = append(out, map[string]any{"message_id": 1000 + id})
+		}
+		testutil.ReplyOK(w, out)
+	})
+	return server, &calls
+}
+
+func TestCopyMessagesChunked_PreservesOrderAndRetries(t *testing.T) {
+	server, calls := chunkServer(t, -1, 1)
+	client := testutil.NewRetryTestClient(t, server.BaseURL(), &testutil.FakeSleeper{})
+
+	res, err := client.CopyMessagesChunked(context.Background(), sender.CopyMessagesRequest{
+		ChatID:     testutil.TestChatID,
+		FromChatID: int64(222),
+		MessageIDs: []int{5, 9, 3, 4},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]int{{5, 9}, {5, 9}, {3, 4}}, *calls, "first chunk retried after 502")
+	assert.Equal(t, []tg.MessageID{{MessageID: 1005}, {MessageID: 1009}, {MessageID: 1003}, {MessageID: 1004}}, res.MessageIDs)
+	assert.Len(t, res.Chunks, 2)
+	assert.Empty(t, res.FailedIDs())
+}
+
+func TestCopyMessagesChunked_StopsAtFailedChunk(t *testing.T) {
+	server, calls := chunkServer(t, 3, 0)
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	res, err := client.CopyMessagesChunked(context.Background(), sender.CopyMessagesRequest{
+		ChatID:     testutil.TestChatID,
+		FromChatID: int64(222),
+		MessageIDs: []int{5, 9, 3, 4, 1},
+	})
+	require.Error(t, err)
+	assert.Len(t, *calls, 2, "chunk after the failure is not sent")
+	assert.Equal(t, []tg.MessageID{{MessageID: 1005}, {MessageID: 1009}}, res.MessageIDs)
+	assert.Equal(t, []int{3, 4, 1}, res.FailedIDs())
+	assert.ErrorIs(t, res.Chunks[2].Err, sender.ErrBatchSkipped)
+}
+
+func TestForwardMessagesChunked_Validation(t *testing.T) {
+	client := testutil.NewTestClient(t, "http://localhost:9999")
+	_, err := client.ForwardMessagesChunked(context.Background(), sender.ForwardMessagesRequest{ChatID: testutil.TestChatID})
+	var vErr *tg.ValidationError
+	assert.ErrorAs(t, err, &vErr)
+}
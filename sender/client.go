@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"math"
 	"math/big"
 	"net"
@@ -22,7 +23,9 @@ import (
 	"github.com/sony/gobreaker/v2"
 	"golang.org/x/time/rate"
 
-	"github.com/prilive-com/galigo/internal/scrub"
+	"github.com/prilive-com/galigo/clock"
+	"github.com/prilive-com/galigo/internal/proxy"
+	"github.com/prilive-com/galigo/internal/version"
 	"github.com/prilive-com/galigo/tg"
 )
 
@@ -68,45 +71,90 @@ func DefaultCircuitBreakerSettings() CircuitBreakerSettings {
 	}
 }
 
-// realSleeper uses actual time.
-type realSleeper struct{}
-
-func (realSleeper) Sleep(ctx context.Context, d time.Duration) error {
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-time.After(d):
-		return nil
-	}
-}
-
 // Client is the main sender client for Telegram Bot API.
 type Client struct {
-	config          Config
-	httpClient      *http.Client
-	logger          *slog.Logger
-	globalLimiter   *rate.Limiter
-	chatLimiters    map[string]*chatLimiterEntry // P1.2: Track last used time
-	limiterMu       sync.RWMutex
-	breaker         *gobreaker.CircuitBreaker[*apiResponse]
-	breakerSettings CircuitBreakerSettings
-	sleeper         Sleeper // For testing retry logic
+	config               Config
+	httpClient           *http.Client
+	logger               *slog.Logger
+	rateLimiter          RateLimiter              // global and per-chat limits
+	methodCosts          map[string]int           // rate limiter tokens per method, overriding requestCost
+	parseFallback        ParseFallback            // resend policy for "can't parse entities"
+	sharedLimiter        *rate.Limiter            // shared with other clients (nil = none)
+	breakers             map[MethodClass]*breaker // key "" when not partitioned
+	breakerSettings      CircuitBreakerSettings
+	breakerPerClass      bool
+	classBreakerSettings map[MethodClass]CircuitBreakerSettings
+	onBreakerChange      func(name string, from, to gobreaker.State)
+	breakerCoord         BreakerCoordinator // shares transitions (nil = local only)
+	stopCoord            func()
+	hedge                *HedgeConfig
+	retryBudget          *retryBudget
+	idempotency          *idempotencyLayer
+	sleeper              Sleeper // For testing retry logic
+	clock                clock.Clock
+
+	// Outgoing request headers ("" = version.UserAgent())
+	userAgent    string
+	extraHeaders map[string]string
+
+	// Decodes API results (zero value = lenient)
+	decoder tg.Decoder
+
+	// Bot token; swapped by SetToken, which holds tokenMu
+	tokens  atomic.Pointer[tokenPair]
+	tokenMu sync.Mutex
+
+	// Audit
+	auditHook      AuditHook
+	auditRedaction AuditRedaction
+
+	// Dry run (nil = disabled)
+	dryRun *dryRunner
+
+	// Sticker set ownership tracking (nil = disabled)
+	stickerSets StickerSetStore
+
+	// Delays requests marked with WithBulkPriority (nil = disabled)
+	bulkThrottle BulkThrottle
+
+	// Disables checkLimits (see WithLimitValidation)
+	skipLimitValidation bool
+
+	// Generates thumbnails for video/animation uploads (nil = disabled)
+	thumbnailer ThumbnailerFunc
+
+	// Proxy selection (nil = direct); proxyErr is reported by New
+	proxy    *proxy.Pool
+	proxyErr error
+
+	// Caches idempotent getters (nil = disabled)
+	cache *responseCache
+
+	// Named message templates for SendTemplate (nil = none)
+	templates *Templates
+
+	// Repeat interval of WithChatAction (0 = default)
+	chatActionRefresh time.Duration
+
+	// getMe timeout of WithStartupValidation (0 = no validation) and the
+	// bot's user from the last successful getMe
+	startupTimeout time.Duration
+	me             atomic.Pointer[tg.User]
+
+	// Scheduled messages (see Schedule); created on first use
+	schedule     ScheduleStore
+	scheduleOnce sync.Once
+	scheduleWake chan struct{}
 
 	// P1.2: Cleanup
-	cleanupTicker *time.Ticker
+	cleanupTicker clock.Ticker
 	cleanupDone   chan struct{}
 
 	// P1 FIX: Ensure Close() is idempotent
 	closeOnce sync.Once
 }
 
-// chatLimiterEntry wraps a rate limiter with last used timestamp.
-// lastUsed uses atomic.Int64 (Unix nanos) to avoid write-lock contention on the hot path.
-type chatLimiterEntry struct {
-	limiter  *rate.Limiter
-	lastUsed atomic.Int64 // UnixNano timestamp
-}
-
+// apiResponse is the envelope of every Bot API response.
 type apiResponse struct {
 	OK          bool                `json:"ok"`
 	Result      json.RawMessage     `json:"result,omitempty"`
@@ -143,7 +191,15 @@ func WithRateLimit(globalRPS float64, burst int) Option {
 	return func(c *Client) {
 		c.config.GlobalRPS = globalRPS
 		c.config.GlobalBurst = burst
-		c.globalLimiter = rate.NewLimiter(rate.Limit(globalRPS), burst)
+	}
+}
+
+// WithSharedRateLimiter adds a limiter waited on after the client's own
+// limits. Pass the same limiter to several clients to cap their combined
+// rate, e.g. for many bots behind one egress IP.
+func WithSharedRateLimiter(l *rate.Limiter) Option {
+	return func(c *Client) {
+		c.sharedLimiter = l
 	}
 }
 
@@ -168,6 +224,15 @@ func WithSleeper(s Sleeper) Option {
 	}
 }
 
+// WithClock sets the clock used for retry sleeps (unless WithSleeper is
+// given), per-chat limiter cleanup and the message scheduler. Tests pass
+// a fake clock to run these without waiting.
+func WithClock(clk clock.Clock) Option {
+	return func(c *Client) {
+		c.clock = clk
+	}
+}
+
 // WithPerChatRateLimit sets per-chat rate limiting parameters.
 func WithPerChatRateLimit(rps float64, burst int) Option {
 	return func(c *Client) {
@@ -192,25 +257,58 @@ func WithCircuitBreakerSettings(settings CircuitBreakerSettings) Option {
 	}
 }
 
+// WithUserAgent sets the User-Agent header sent with every API request.
+// The default is "galigo/<version> (Bot API <x.y>; +https://github.com/prilive-com/galigo)".
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// WithExtraHeaders adds headers to every API request, e.g. for egress
+// proxies that route by header. Headers set by galigo itself (Content-Type,
+// Accept) are not overridden. Repeated calls merge.
+func WithExtraHeaders(headers map[string]string) Option {
+	return func(c *Client) {
+		if c.extraHeaders == nil {
+			c.extraHeaders = make(map[string]string, len(headers))
+		}
+		maps.Copy(c.extraHeaders, headers)
+	}
+}
+
+// WithDecoder sets how API results are decoded. Use tg.StrictDecoder in
+// tests to fail on fields galigo does not model.
+func WithDecoder(d tg.Decoder) Option {
+	return func(c *Client) {
+		c.decoder = d
+	}
+}
+
 // P1.5 FIX: Deduplicated HTTP client creation
-func createHTTPClient(cfg Config) *http.Client {
-	return &http.Client{
-		Timeout: cfg.RequestTimeout,
-		Transport: &http.Transport{
-			DialContext: (&net.Dialer{
-				Timeout:   10 * time.Second,
-				KeepAlive: cfg.KeepAlive,
-			}).DialContext,
-			MaxIdleConns:          cfg.MaxIdleConns,
-			IdleConnTimeout:       cfg.IdleTimeout,
-			TLSHandshakeTimeout:   10 * time.Second,
-			ResponseHeaderTimeout: 10 * time.Second, // Time to receive response headers; shorter than total timeout
-			ForceAttemptHTTP2:     true,
-			TLSClientConfig: &tls.Config{
-				MinVersion: tls.VersionTLS12,
-			},
+func createHTTPClient(cfg Config, pool *proxy.Pool) *http.Client {
+	dial := (&net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: cfg.KeepAlive,
+	}).DialContext
+	transport := &http.Transport{
+		DialContext:         dial,
+		MaxIdleConns:        cfg.MaxIdleConns,
+		IdleConnTimeout:     cfg.IdleTimeout,
+		TLSHandshakeTimeout: 10 * time.Second,
+		ForceAttemptHTTP2:   true,
+		TLSClientConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
 		},
 	}
+	if pool != nil {
+		transport.Proxy = pool.Proxy
+		transport.DialContext = pool.Dialer(dial)
+	}
+	// No Client.Timeout or ResponseHeaderTimeout: doRequest gives each
+	// attempt a deadline sized for the request kind, so streamed uploads
+	// and calls with a longer WithRequestTimeout are not cut short.
+	return &http.Client{Transport: transport}
 }
 
 // New creates a new Client with the given token and options.
@@ -223,33 +321,44 @@ func New(token string, opts ...Option) (*Client, error) {
 	}
 
 	c := &Client{
-		config:       cfg,
-		chatLimiters: make(map[string]*chatLimiterEntry), // P1.2: Use entry type
+		config: cfg,
 	}
 
 	// Apply options
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.tokens.Store(&tokenPair{current: c.config.Token})
 
 	// Default logger
 	if c.logger == nil {
 		c.logger = slog.Default()
 	}
 
+	if c.proxyErr != nil {
+		return nil, c.proxyErr
+	}
+
 	// Default HTTP client (P1.5: Use helper function)
 	if c.httpClient == nil {
-		c.httpClient = createHTTPClient(c.config)
+		c.httpClient = createHTTPClient(c.config, c.proxy)
 	}
 
-	// Default global limiter
-	if c.globalLimiter == nil {
-		c.globalLimiter = rate.NewLimiter(rate.Limit(c.config.GlobalRPS), c.config.GlobalBurst)
+	// Default clock and sleeper
+	if c.clock == nil {
+		c.clock = clock.Real
 	}
-
-	// Default sleeper
 	if c.sleeper == nil {
-		c.sleeper = realSleeper{}
+		c.sleeper = c.clock
+	}
+
+	// Default rate limiter
+	if c.rateLimiter == nil {
+		c.rateLimiter = newMemoryRateLimiter(c.config, c.clock)
+	}
+
+	if c.cache != nil {
+		c.cache.botID = botIDFromToken(c.config.Token.Value())
 	}
 
 	// Default circuit breaker settings
@@ -257,26 +366,17 @@ func New(token string, opts ...Option) (*Client, error) {
 		c.breakerSettings = DefaultCircuitBreakerSettings()
 	}
 
-	// Circuit breaker
-	c.breaker = gobreaker.NewCircuitBreaker[*apiResponse](gobreaker.Settings{
-		Name:         "galigo-sender",
-		MaxRequests:  c.breakerSettings.MaxRequests,
-		Interval:     c.breakerSettings.Interval,
-		Timeout:      c.breakerSettings.Timeout,
-		ReadyToTrip:  c.breakerSettings.ReadyToTrip,
-		IsSuccessful: isBreakerSuccess,
-		OnStateChange: func(name string, from, to gobreaker.State) {
-			c.logger.Info("circuit breaker state changed",
-				"name", name,
-				"from", from.String(),
-				"to", to.String(),
-			)
-		},
-	})
+	// Circuit breakers
+	c.initBreakers()
 
 	// P1.2: Start chat limiter cleanup goroutine
 	c.startLimiterCleanup()
 
+	if err := c.validateStartup(); err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+
 	return c, nil
 }
 
@@ -287,30 +387,42 @@ func NewFromConfig(cfg Config, opts ...Option) (*Client, error) {
 	}
 
 	c := &Client{
-		config:       cfg,
-		chatLimiters: make(map[string]*chatLimiterEntry), // P1.2: Use entry type
+		config: cfg,
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.tokens.Store(&tokenPair{current: c.config.Token})
 
 	if c.logger == nil {
 		c.logger = slog.Default()
 	}
 
+	if c.proxyErr != nil {
+		return nil, c.proxyErr
+	}
+
 	// P1.5 FIX: Use helper function
 	if c.httpClient == nil {
-		c.httpClient = createHTTPClient(c.config)
+		c.httpClient = createHTTPClient(c.config, c.proxy)
 	}
 
-	if c.globalLimiter == nil {
-		c.globalLimiter = rate.NewLimiter(rate.Limit(c.config.GlobalRPS), c.config.GlobalBurst)
+	// Default clock and sleeper
+	if c.clock == nil {
+		c.clock = clock.Real
 	}
-
-	// Default sleeper
 	if c.sleeper == nil {
-		c.sleeper = realSleeper{}
+		c.sleeper = c.clock
+	}
+
+	// Default rate limiter
+	if c.rateLimiter == nil {
+		c.rateLimiter = newMemoryRateLimiter(c.config, c.clock)
+	}
+
+	if c.cache != nil {
+		c.cache.botID = botIDFromToken(c.config.Token.Value())
 	}
 
 	// Default circuit breaker settings
@@ -318,25 +430,23 @@ func NewFromConfig(cfg Config, opts ...Option) (*Client, error) {
 		c.breakerSettings = DefaultCircuitBreakerSettings()
 	}
 
-	c.breaker = gobreaker.NewCircuitBreaker[*apiResponse](gobreaker.Settings{
-		Name:         "galigo-sender",
-		MaxRequests:  c.breakerSettings.MaxRequests,
-		Interval:     c.breakerSettings.Interval,
-		Timeout:      c.breakerSettings.Timeout,
-		ReadyToTrip:  c.breakerSettings.ReadyToTrip,
-		IsSuccessful: isBreakerSuccess,
-	})
+	c.initBreakers()
 
 	// P1.2: Start chat limiter cleanup goroutine
 	c.startLimiterCleanup()
 
+	if err := c.validateStartup(); err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+
 	return c, nil
 }
 
-// Close releases resources used by the client.
-// It is safe to call Close concurrently with other methods;
-// in-flight requests will complete normally or with context errors.
-// Close is idempotent; subsequent calls are no-ops.
+// Close releases resources used by the client and drops its token. It
+// is safe to call Close concurrently with other methods;
+// in-flight requests will complete normally or with context or API
+// errors. Close is idempotent; subsequent calls are no-ops.
 func (c *Client) Close() error {
 	c.closeOnce.Do(func() {
 		// Stop limiter cleanup goroutine
@@ -345,17 +455,25 @@ func (c *Client) Close() error {
 			close(c.cleanupDone)
 		}
 
+		// Stop receiving peer breaker transitions
+		if c.stopCoord != nil {
+			c.stopCoord()
+		}
+
 		// Close idle HTTP connections
 		if t, ok := c.httpClient.Transport.(*http.Transport); ok {
 			t.CloseIdleConnections()
 		}
+
+		// Drop the tokens; requests made later fail
+		c.tokens.Store(&tokenPair{})
 	})
 	return nil
 }
 
 // P1.2: Start background goroutine to cleanup stale chat limiters
 func (c *Client) startLimiterCleanup() {
-	c.cleanupTicker = time.NewTicker(5 * time.Minute)
+	c.cleanupTicker = c.clock.NewTicker(5 * time.Minute)
 	c.cleanupDone = make(chan struct{})
 
 	go func() {
@@ -363,7 +481,7 @@ func (c *Client) startLimiterCleanup() {
 			select {
 			case <-c.cleanupDone:
 				return
-			case <-c.cleanupTicker.C:
+			case <-c.cleanupTicker.C():
 				c.cleanupStaleLimiters()
 			}
 		}
@@ -372,44 +490,51 @@ func (c *Client) startLimiterCleanup() {
 
 // cleanupStaleLimiters removes chat limiters that haven't been used in 10 minutes
 func (c *Client) cleanupStaleLimiters() {
-	c.limiterMu.Lock()
-	defer c.limiterMu.Unlock()
-
-	threshold := time.Now().Add(-10 * time.Minute).UnixNano()
-	for chatID, entry := range c.chatLimiters {
-		if entry.lastUsed.Load() < threshold {
-			delete(c.chatLimiters, chatID)
-		}
+	if l, ok := c.rateLimiter.(*MemoryRateLimiter); ok {
+		l.Sweep(chatLimiterIdle)
 	}
 }
 
-// ChatLimiterCount returns the number of active per-chat limiters.
+// ChatLimiterCount returns the number of active per-chat limiters, or 0
+// with a RateLimiter other than MemoryRateLimiter.
 // Useful for monitoring and testing.
 func (c *Client) ChatLimiterCount() int {
-	c.limiterMu.RLock()
-	defer c.limiterMu.RUnlock()
-	return len(c.chatLimiters)
+	if l, ok := c.rateLimiter.(*MemoryRateLimiter); ok {
+		return l.Len()
+	}
+	return 0
 }
 
 // SendMessage sends a text message.
 func (c *Client) SendMessage(ctx context.Context, req SendMessageRequest) (*tg.Message, error) {
-	if err := validateChatID(req.ChatID); err != nil {
-		return nil, err
-	}
-	if err := req.LinkPreviewOptions.Validate(); err != nil {
+	if err := validateSendMessage(req); err != nil {
 		return nil, err
 	}
-	return withRetry(c, ctx, req.ChatID, func() (*tg.Message, error) {
+	return withRetry(c, ctx, req.ChatID, func(ctx context.Context) (*tg.Message, error) {
 		return c.sendMessageOnce(ctx, req)
 	})
 }
 
+// validateSendMessage checks req before it is sent or scheduled.
+func validateSendMessage(req SendMessageRequest) error {
+	if err := validateChatID(req.ChatID); err != nil {
+		return err
+	}
+	if err := req.LinkPreviewOptions.Validate(); err != nil {
+		return err
+	}
+	return req.SuggestedPostParameters.Validate()
+}
+
 // SendPhoto sends a photo.
 func (c *Client) SendPhoto(ctx context.Context, req SendPhotoRequest) (*tg.Message, error) {
 	if err := validateChatID(req.ChatID); err != nil {
 		return nil, err
 	}
-	return withRetry(c, ctx, req.ChatID, func() (*tg.Message, error) {
+	if err := req.SuggestedPostParameters.Validate(); err != nil {
+		return nil, err
+	}
+	return withRetry(c, ctx, req.ChatID, func(ctx context.Context) (*tg.Message, error) {
 		return c.sendPhotoOnce(ctx, req)
 	})
 }
@@ -419,38 +544,22 @@ func (c *Client) EditMessageText(ctx context.Context, req EditMessageTextRequest
 	if err := req.LinkPreviewOptions.Validate(); err != nil {
 		return nil, err
 	}
-	resp, err := c.executeRequest(ctx, "editMessageText", req, extractChatID(req.ChatID))
-	if err != nil {
-		return nil, err
-	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "editMessageText", req, extractChatID(req.ChatID))
 }
 
 // EditMessageCaption edits message caption.
 func (c *Client) EditMessageCaption(ctx context.Context, req EditMessageCaptionRequest) (*tg.Message, error) {
-	resp, err := c.executeRequest(ctx, "editMessageCaption", req, extractChatID(req.ChatID))
-	if err != nil {
-		return nil, err
-	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "editMessageCaption", req, extractChatID(req.ChatID))
 }
 
 // EditMessageReplyMarkup edits message reply markup.
 func (c *Client) EditMessageReplyMarkup(ctx context.Context, req EditMessageReplyMarkupRequest) (*tg.Message, error) {
-	resp, err := c.executeRequest(ctx, "editMessageReplyMarkup", req, extractChatID(req.ChatID))
-	if err != nil {
-		return nil, err
-	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "editMessageReplyMarkup", req, extractChatID(req.ChatID))
 }
 
 // EditMessageMedia edits the media content of a message.
 func (c *Client) EditMessageMedia(ctx context.Context, req EditMessageMediaRequest) (*tg.Message, error) {
-	resp, err := c.executeRequest(ctx, "editMessageMedia", req, extractChatID(req.ChatID))
-	if err != nil {
-		return nil, err
-	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "editMessageMedia", req, extractChatID(req.ChatID))
 }
 
 // DeleteMessage deletes a message.
@@ -461,24 +570,18 @@ func (c *Client) DeleteMessage(ctx context.Context, req DeleteMessageRequest) er
 
 // ForwardMessage forwards a message.
 func (c *Client) ForwardMessage(ctx context.Context, req ForwardMessageRequest) (*tg.Message, error) {
-	resp, err := c.executeRequest(ctx, "forwardMessage", req, extractChatID(req.ChatID))
-	if err != nil {
+	if err := req.SuggestedPostParameters.Validate(); err != nil {
 		return nil, err
 	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "forwardMessage", req, extractChatID(req.ChatID))
 }
 
 // CopyMessage copies a message.
 func (c *Client) CopyMessage(ctx context.Context, req CopyMessageRequest) (*tg.MessageID, error) {
-	resp, err := c.executeRequest(ctx, "copyMessage", req, extractChatID(req.ChatID))
-	if err != nil {
+	if err := req.SuggestedPostParameters.Validate(); err != nil {
 		return nil, err
 	}
-	var result tg.MessageID
-	if err := json.Unmarshal(resp.Result, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse result: %w", err)
-	}
-	return &result, nil
+	return call[tg.MessageID](c, ctx, "copyMessage", req, extractChatID(req.ChatID))
 }
 
 // AnswerCallbackQuery answers a callback query.
@@ -500,9 +603,7 @@ func (c *Client) Edit(ctx context.Context, e tg.Editable, text string, opts ...E
 	} else {
 		req.InlineMessageID = msgID
 	}
-	for _, opt := range opts {
-		opt(&req)
-	}
+	req.ApplySendOptions(opts...)
 	return c.EditMessageText(ctx, req)
 }
 
@@ -531,14 +632,17 @@ func (c *Client) Forward(ctx context.Context, e tg.Editable, toChatID tg.ChatID,
 		FromChatID: chatID,
 		MessageID:  id,
 	}
-	for _, opt := range opts {
-		opt(&req)
-	}
+	req.ApplySendOptions(opts...)
 	return c.ForwardMessage(ctx, req)
 }
 
 // Copy copies a message using Editable.
+// A *tg.Message that Telegram refuses to copy (giveaways, service messages)
+// fails fast with tg.ErrMessageCantBeCopied; use Forward instead.
 func (c *Client) Copy(ctx context.Context, e tg.Editable, toChatID tg.ChatID, opts ...CopyOption) (*tg.MessageID, error) {
+	if m, ok := e.(*tg.Message); ok && m != nil && !m.CanBeCopied() {
+		return nil, tg.ErrMessageCantBeCopied
+	}
 	msgID, chatID := e.MessageSig()
 	if chatID == 0 {
 		return nil, errors.New("cannot copy inline messages")
@@ -549,12 +653,100 @@ func (c *Client) Copy(ctx context.Context, e tg.Editable, toChatID tg.ChatID, op
 		FromChatID: chatID,
 		MessageID:  id,
 	}
-	for _, opt := range opts {
-		opt(&req)
-	}
+	req.ApplySendOptions(opts...)
 	return c.CopyMessage(ctx, req)
 }
 
+// Reply sends text as a reply to msg, in the same chat, forum topic and
+// business connection. The reply is still sent if msg has been deleted.
+func (c *Client) Reply(ctx context.Context, msg *tg.Message, text string, opts ...ReplyOption) (*tg.Message, error) {
+	return c.reply(ctx, msg, &tg.ReplyParameters{MessageID: msgID(msg)}, text, opts)
+}
+
+// Quote replies to msg quoting part of it. quote must be an exact substring
+// of the message's text or caption; otherwise Quote fails with
+// tg.ErrQuoteNotFound without calling Telegram. See tg.QuoteReply.
+func (c *Client) Quote(ctx context.Context, msg *tg.Message, quote, text string, opts ...ReplyOption) (*tg.Message, error) {
+	params, err := tg.QuoteReply(msg, quote)
+	if err != nil {
+		return nil, err
+	}
+	return c.reply(ctx, msg, params, text, opts)
+}
+
+func (c *Client) reply(ctx context.Context, msg *tg.Message, params *tg.ReplyParameters, text string, opts []ReplyOption) (*tg.Message, error) {
+	if msg == nil || msg.Chat == nil {
+		return nil, errors.New("cannot reply to a message without chat")
+	}
+	params.AllowSendingWithoutReply = true
+	req := SendMessageRequest{
+		BusinessConnectionID: msg.BusinessConnectionID,
+		ChatID:               msg.Chat.ID,
+		Text:                 text,
+		ReplyParameters:      params,
+	}
+	if msg.IsTopicMessage {
+		req.MessageThreadID = msg.MessageThreadID
+	}
+	req.ApplySendOptions(opts...)
+	return c.SendMessage(ctx, req)
+}
+
+func msgID(msg *tg.Message) int {
+	if msg == nil {
+		return 0
+	}
+	return msg.MessageID
+}
+
+// React sets the bot's emoji reactions on a message using Editable, e.g.
+// client.React(ctx, msg, tg.ReactionThumbsUp). Each emoji must be one of
+// tg.ReactionEmojis. Calling it without emojis removes the bot's reactions.
+func (c *Client) React(ctx context.Context, e tg.Editable, emojis ...string) error {
+	msgID, chatID := e.MessageSig()
+	if chatID == 0 {
+		return errors.New("cannot react to inline messages")
+	}
+	id, _ := strconv.Atoi(msgID)
+	if err := validateMessageID(id); err != nil {
+		return err
+	}
+	req := SetMessageReactionRequest{
+		ChatID:    chatID,
+		MessageID: id,
+	}
+	for _, emoji := range emojis {
+		req.Reaction = append(req.Reaction, tg.EmojiReaction(emoji))
+	}
+	return c.SetMessageReaction(ctx, req)
+}
+
+// Pin pins a message using Editable.
+func (c *Client) Pin(ctx context.Context, e tg.Editable, opts ...PinOption) error {
+	msgID, chatID := e.MessageSig()
+	if chatID == 0 {
+		return errors.New("cannot pin inline messages")
+	}
+	id, _ := strconv.Atoi(msgID)
+	if err := validateMessageID(id); err != nil {
+		return err
+	}
+	return c.PinChatMessage(ctx, chatID, id, opts...)
+}
+
+// Unpin unpins a message using Editable.
+func (c *Client) Unpin(ctx context.Context, e tg.Editable) error {
+	msgID, chatID := e.MessageSig()
+	if chatID == 0 {
+		return errors.New("cannot unpin inline messages")
+	}
+	id, _ := strconv.Atoi(msgID)
+	if id <= 0 {
+		return tg.NewValidationError("message_id", "must be positive")
+	}
+	return c.UnpinChatMessage(ctx, chatID, id)
+}
+
 // Answer convenience methods
 
 // Answer answers a callback query.
@@ -578,39 +770,96 @@ func (c *Client) Acknowledge(ctx context.Context, cb *tg.CallbackQuery) error {
 func (c *Client) sendMessageOnce(ctx context.Context, req SendMessageRequest) (*tg.Message, error) {
 	resp, err := c.executeRequest(ctx, "sendMessage", req, extractChatID(req.ChatID))
 	if err != nil {
-		if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
-			return nil, fmt.Errorf("%w: %w", ErrCircuitOpen, err)
-		}
 		return nil, err
 	}
-	return parseMessage(resp)
+	return c.parseMessage(resp)
 }
 
 func (c *Client) sendPhotoOnce(ctx context.Context, req SendPhotoRequest) (*tg.Message, error) {
 	resp, err := c.executeRequest(ctx, "sendPhoto", req, extractChatID(req.ChatID))
 	if err != nil {
-		if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
-			return nil, fmt.Errorf("%w: %w", ErrCircuitOpen, err)
-		}
 		return nil, err
 	}
-	return parseMessage(resp)
+	return c.parseMessage(resp)
 }
 
 func (c *Client) executeRequest(ctx context.Context, method string, payload any, chatIDs ...string) (*apiResponse, error) {
+	var chatID string
+	if len(chatIDs) > 0 {
+		chatID = chatIDs[0]
+	}
+	resp, err := c.executeWithParseFallback(ctx, method, payload, chatID)
+	if err != nil {
+		return nil, c.requestError(method, chatID, err)
+	}
+	return resp, nil
+}
+
+// execute runs one request through the cache, idempotency, limit checks,
+// rate limiters and circuit breaker.
+func (c *Client) execute(ctx context.Context, method string, payload any, chatID string) (*apiResponse, error) {
+	start := time.Now()
+
+	cached, cacheKey := c.cacheLookup(ctx, method, payload, chatID)
+	if cached != nil {
+		return cached, nil
+	}
+
+	recorded, idemKey, release, err := c.idempotencyBegin(ctx, method, payload)
+	defer release()
+	if err != nil {
+		c.audit(ctx, method, payload, chatID, nil, err, start)
+		return nil, err
+	}
+	if recorded != nil {
+		return recorded, nil
+	}
+
+	if !c.skipLimitValidation {
+		if err := checkLimits(payload); err != nil {
+			c.audit(ctx, method, payload, chatID, nil, err, start)
+			return nil, err
+		}
+	}
+
+	if c.bulkThrottle != nil && IsBulk(ctx) {
+		if err := c.bulkThrottle.Wait(ctx); err != nil {
+			c.audit(ctx, method, payload, chatID, nil, err, start)
+			return nil, err
+		}
+	}
+
 	// Apply rate limiting if a chatID is provided
-	if len(chatIDs) > 0 && chatIDs[0] != "" {
-		if err := c.waitForRateLimit(ctx, chatIDs[0]); err != nil {
+	if chatID != "" {
+		if err := c.waitForRateLimit(ctx, chatID, c.cost(method, payload)); err != nil {
+			c.audit(ctx, method, payload, chatID, nil, err, start)
 			return nil, err
 		}
 	}
-	return c.breaker.Execute(func() (*apiResponse, error) {
-		return c.doRequest(ctx, method, payload)
-	})
+	var resp *apiResponse
+	if c.dryRun != nil {
+		resp, err = c.dryRun.respond(c, method, payload, chatID)
+	} else {
+		// Under withRetry the call was counted once for all its attempts.
+		if ctx.Value(retryLoopKey{}) == nil {
+			c.retryBudget.request(c.clock.Now())
+		}
+		c.idempotencyPending(ctx, method, idemKey)
+		resp, err = c.breakerFor(method).execute(func() (*apiResponse, error) {
+			return c.doHedged(ctx, method, payload)
+		})
+	}
+	c.audit(ctx, method, payload, chatID, resp, err, start)
+	if err == nil {
+		c.cacheStore(ctx, method, payload, chatID, cacheKey, resp)
+	}
+	c.idempotencyRecord(context.WithoutCancel(ctx), method, idemKey, resp, err)
+	return resp, err
 }
 
 func (c *Client) doRequest(ctx context.Context, method string, payload any) (*apiResponse, error) {
-	url := fmt.Sprintf("%s/bot%s/%s", c.config.BaseURL, c.config.Token.Value(), method)
+	token := c.token(ctx)
+	url := fmt.Sprintf("%s/bot%s/%s", c.config.BaseURL, token.Value(), method)
 
 	// Check if this request needs multipart encoding (has file uploads)
 	multipartReq, err := BuildMultipartRequest(payload)
@@ -618,9 +867,21 @@ func (c *Client) doRequest(ctx context.Context, method string, payload any) (*ap
 		return nil, fmt.Errorf("failed to build request: %w", err)
 	}
 
+	kind := kindSend
+	if multipartReq.HasUploads() {
+		kind = kindUpload
+	}
+	parent := ctx
+	timeout := c.requestTimeout(ctx, kind)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	var req *http.Request
 
-	if multipartReq.HasUploads() {
+	if kind == kindUpload {
 		// Use multipart/form-data for file uploads — streamed via io.Pipe
 		pr, pw := io.Pipe()
 		encoder := NewMultipartEncoder(pw)
@@ -643,7 +904,7 @@ func (c *Client) doRequest(ctx context.Context, method string, payload any) (*ap
 		req, err = http.NewRequestWithContext(ctx, http.MethodPost, url, pr)
 		if err != nil {
 			pr.Close() // Ensure pipe is cleaned up
-			return nil, fmt.Errorf("failed to create request: %w", scrub.TokenFromError(err, c.config.Token))
+			return nil, fmt.Errorf("failed to create request: %w", c.scrubToken(err, token))
 		}
 		req.Header.Set("Content-Type", contentType)
 	} else {
@@ -655,16 +916,20 @@ func (c *Client) doRequest(ctx context.Context, method string, payload any) (*ap
 
 		req, err = http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jsonData))
 		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", scrub.TokenFromError(err, c.config.Token))
+			return nil, fmt.Errorf("failed to create request: %w", c.scrubToken(err, token))
 		}
 		req.Header.Set("Content-Type", "application/json")
 	}
 
 	req.Header.Set("Accept", "application/json")
+	version.SetHeaders(req, c.userAgent, c.extraHeaders)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", scrub.TokenFromError(err, c.config.Token))
+		if attemptTimedOut(parent, ctx) {
+			return nil, fmt.Errorf("request failed: %w", &attemptTimeoutError{method: method, timeout: timeout})
+		}
+		return nil, fmt.Errorf("request failed: %w", c.scrubToken(err, token))
 	}
 	defer resp.Body.Close()
 
@@ -672,6 +937,9 @@ func (c *Client) doRequest(ctx context.Context, method string, payload any) (*ap
 	limitedReader := io.LimitReader(resp.Body, maxResponseSize+1)
 	body, err := io.ReadAll(limitedReader)
 	if err != nil {
+		if attemptTimedOut(parent, ctx) {
+			return nil, fmt.Errorf("failed to read response: %w", &attemptTimeoutError{method: method, timeout: timeout})
+		}
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
@@ -696,104 +964,65 @@ func (c *Client) doRequest(ctx context.Context, method string, payload any) (*ap
 	return &apiResp, nil
 }
 
-func (c *Client) waitForRateLimit(ctx context.Context, chatID string) error {
-	limiter := c.getChatLimiter(chatID)
-	if err := limiter.Wait(ctx); err != nil {
+func (c *Client) waitForRateLimit(ctx context.Context, chatID string, n int) error {
+	if err := waitLimiter(ctx, c.rateLimiter, chatID, n); err != nil {
 		return err
 	}
-	return c.globalLimiter.Wait(ctx)
-}
-
-func (c *Client) getChatLimiter(chatID string) *rate.Limiter {
-	now := time.Now().UnixNano()
-
-	c.limiterMu.RLock()
-	entry, exists := c.chatLimiters[chatID]
-	c.limiterMu.RUnlock()
-
-	if exists {
-		entry.lastUsed.Store(now) // Lock-free atomic update
-		return entry.limiter
-	}
-
-	c.limiterMu.Lock()
-	defer c.limiterMu.Unlock()
-
-	// Double-check after acquiring write lock
-	if entry, exists = c.chatLimiters[chatID]; exists {
-		entry.lastUsed.Store(now)
-		return entry.limiter
-	}
-
-	// Use lower rate for group chats (negative numeric IDs)
-	rps := c.config.PerChatRPS
-	burst := c.config.PerChatBurst
-	if c.config.GroupRPS > 0 {
-		if id, err := strconv.ParseInt(chatID, 10, 64); err == nil && id < 0 {
-			rps = c.config.GroupRPS
-			burst = c.config.GroupBurst
-		}
-	}
-
-	// Evict oldest if at capacity
-	maxLimiters := c.config.MaxChatLimiters
-	if maxLimiters <= 0 {
-		maxLimiters = 10000
-	}
-	if len(c.chatLimiters) >= maxLimiters {
-		var oldestKey string
-		oldestTime := now
-		for k, e := range c.chatLimiters {
-			if t := e.lastUsed.Load(); t < oldestTime {
-				oldestTime = t
-				oldestKey = k
-			}
-		}
-		if oldestKey != "" {
-			delete(c.chatLimiters, oldestKey)
-		}
-	}
-
-	// Create new entry with limiter
-	entry = &chatLimiterEntry{
-		limiter: rate.NewLimiter(rate.Limit(rps), burst),
+	if c.sharedLimiter != nil {
+		return waitTokens(ctx, c.sharedLimiter, n)
 	}
-	entry.lastUsed.Store(now)
-	c.chatLimiters[chatID] = entry
-	return entry.limiter
+	return nil
 }
 
-func withRetry[T any](c *Client, ctx context.Context, chatID tg.ChatID, fn func() (T, error)) (T, error) {
+// retryLoopKey marks a context passed to the attempts of withRetry.
+type retryLoopKey struct{}
+
+// withRetry runs fn until it succeeds, fails permanently or runs out of
+// retries. The call counts as one request for the retry budget however
+// many attempts it takes.
+func withRetry[T any](c *Client, ctx context.Context, chatID tg.ChatID, fn func(ctx context.Context) (T, error)) (T, error) {
 	var zero T
 	var lastErr error
+	var last *tg.RequestError // context of the last try, if fn returned one
+	var backoffTotal time.Duration
 
+	c.retryBudget.request(c.clock.Now())
+	attemptCtx := context.WithValue(ctx, retryLoopKey{}, true)
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
-		result, err := fn()
+		result, err := fn(attemptCtx)
 		if err == nil {
 			return result, nil
 		}
 
-		lastErr = err
+		lastErr, last = err, nil
+		if re, ok := err.(*tg.RequestError); ok {
+			lastErr, last = re.Err, re
+		}
+		tries := attempt + 1
 
 		// Non-retryable errors return immediately (not wrapped in ErrMaxRetries)
-		if !isRetryable(err) {
-			return zero, err
+		if !isRetryable(lastErr) {
+			return zero, retryError(last, lastErr, tries, backoffTotal)
 		}
 
 		// Check if we've exhausted retries
 		if attempt >= c.config.MaxRetries {
 			break
 		}
+		if !c.retryBudget.allowRetry(c.clock.Now()) {
+			return zero, retryError(last, fmt.Errorf("%w: %w", ErrRetryBudgetExhausted, lastErr), tries, backoffTotal)
+		}
 
-		backoff := calculateBackoff(c.config, attempt+1, err)
+		backoff := calculateBackoff(c.config, attempt+1, lastErr)
 
 		// Use sleeper for testable timing
 		if err := c.sleeper.Sleep(ctx, backoff); err != nil {
-			return zero, err
+			return zero, retryError(last, err, tries, backoffTotal)
 		}
+		backoffTotal += backoff
 	}
 
-	return zero, fmt.Errorf("%w: %w", ErrMaxRetries, lastErr)
+	return zero, retryError(last, fmt.Errorf("%w: %w", ErrMaxRetries, lastErr), c.config.MaxRetries+1, backoffTotal)
 }
 
 func isRetryable(err error) bool {
@@ -855,9 +1084,9 @@ func extractChatID(chatID tg.ChatID) string {
 	}
 }
 
-func parseMessage(resp *apiResponse) (*tg.Message, error) {
+func (c *Client) parseMessage(resp *apiResponse) (*tg.Message, error) {
 	var msg tg.Message
-	if err := json.Unmarshal(resp.Result, &msg); err != nil {
+	if err := c.decoder.Decode(resp.Result, &msg); err != nil {
 		return nil, fmt.Errorf("failed to parse message: %w", err)
 	}
 	return &msg, nil
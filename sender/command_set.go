@@ -0,0 +1,246 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Command Sets ==================
+
+// Telegram keeps one command list per (scope, language) pair. A CommandSet
+// describes all of them at once, typically built next to the code that
+// registers command handlers, and SyncCommands pushes the lists that
+// differ from what Telegram has and deletes the ones the set dropped.
+
+// CommandSet declares bot commands, the scopes they are shown in and
+// their localized descriptions. Methods return the set for chaining.
+// A CommandSet is not safe for concurrent modification.
+type CommandSet struct {
+	commands []commandDef
+	dropped  []CommandRoster // without commands
+}
+
+type commandDef struct {
+	command      string
+	descriptions map[string]string // language code -> description; "" is the default
+	scopes       []tg.BotCommandScope
+}
+
+// CommandRoster is the command list of one scope and language, as passed
+// to SetMyCommands.
+type CommandRoster struct {
+	Scope        tg.BotCommandScope
+	LanguageCode string // "" for users without a dedicated list
+	Commands     []tg.BotCommand
+}
+
+// NewCommandSet returns an empty CommandSet.
+func NewCommandSet() *CommandSet {
+	return &CommandSet{}
+}
+
+// Add declares command with its default description. Without scopes the
+// command goes to the default scope. Adding an existing command replaces
+// its default description and scopes and keeps its translations.
+func (s *CommandSet) Add(command, description string, scopes ...tg.BotCommandScope) *CommandSet {
+	if len(scopes) == 0 {
+		scopes = []tg.BotCommandScope{tg.BotCommandScopeDefault()}
+	}
+	if def := s.find(command); def != nil {
+		def.descriptions[""] = description
+		def.scopes = scopes
+		return s
+	}
+	s.commands = append(s.commands, commandDef{
+		command:      command,
+		descriptions: map[string]string{"": description},
+		scopes:       scopes,
+	})
+	return s
+}
+
+// Localize sets the description of command for languageCode (an IETF
+// language tag such as "de"). Users with that language see the localized
+// list; commands without a translation keep their default description.
+// Unknown commands are ignored.
+func (s *CommandSet) Localize(command, languageCode, description string) *CommandSet {
+	if def := s.find(command); def != nil && languageCode != "" {
+		def.descriptions[languageCode] = description
+	}
+	return s
+}
+
+// Drop declares that the lists of scope in languageCodes (none = the
+// default-language list) are no longer wanted, so SyncCommands deletes
+// them. SyncCommands finds dropped lists of the chat-independent scopes
+// in the languages the set uses by itself; Drop covers chat-specific
+// scopes and languages the set no longer mentions, which Telegram gives
+// no way to enumerate.
+func (s *CommandSet) Drop(scope tg.BotCommandScope, languageCodes ...string) *CommandSet {
+	if len(languageCodes) == 0 {
+		languageCodes = []string{""}
+	}
+	for _, lang := range languageCodes {
+		s.dropped = append(s.dropped, CommandRoster{Scope: scope, LanguageCode: lang})
+	}
+	return s
+}
+
+func (s *CommandSet) find(command string) *commandDef {
+	for i := range s.commands {
+		if s.commands[i].command == command {
+			return &s.commands[i]
+		}
+	}
+	return nil
+}
+
+// Rosters returns one roster per scope and language, in declaration order:
+// the default-language roster of each scope first, then one per language
+// any of its commands is localized to.
+func (s *CommandSet) Rosters() []CommandRoster {
+	var rosters []CommandRoster
+	index := make(map[string]int)
+
+	for _, def := range s.commands {
+		for _, scope := range def.scopes {
+			key := scopeKey(scope)
+			if _, ok := index[key]; !ok {
+				index[key] = len(rosters)
+				rosters = append(rosters, CommandRoster{Scope: scope})
+			}
+		}
+	}
+
+	// Languages per scope, sorted for a stable order.
+	var result []CommandRoster
+	for _, base := range rosters {
+		key := scopeKey(base.Scope)
+		var langs []string
+		for _, def := range s.commands {
+			if !def.inScope(key) {
+				continue
+			}
+			for lang := range def.descriptions {
+				if lang != "" && !slices.Contains(langs, lang) {
+					langs = append(langs, lang)
+				}
+			}
+		}
+		slices.Sort(langs)
+
+		for _, lang := range append([]string{""}, langs...) {
+			roster := CommandRoster{Scope: base.Scope, LanguageCode: lang}
+			for _, def := range s.commands {
+				if def.inScope(key) {
+					roster.Commands = append(roster.Commands, tg.BotCommand{
+						Command:     def.command,
+						Description: def.description(lang),
+					})
+				}
+			}
+			result = append(result, roster)
+		}
+	}
+	return result
+}
+
+func (d commandDef) inScope(key string) bool {
+	return slices.ContainsFunc(d.scopes, func(s tg.BotCommandScope) bool { return scopeKey(s) == key })
+}
+
+func (d commandDef) description(lang string) string {
+	if desc, ok := d.descriptions[lang]; ok {
+		return desc
+	}
+	return d.descriptions[""]
+}
+
+func scopeKey(s tg.BotCommandScope) string {
+	return fmt.Sprintf("%s|%v|%d", s.Type, s.ChatID, s.UserID)
+}
+
+func rosterKey(r CommandRoster) string {
+	return scopeKey(r.Scope) + "|" + r.LanguageCode
+}
+
+// unwanted returns the lists, without commands, that SyncCommands deletes
+// if Telegram has them: every chat-independent or used scope in every
+// language the set uses, and the dropped lists, minus the set's rosters.
+func (s *CommandSet) unwanted(rosters []CommandRoster) []CommandRoster {
+	scopes := []tg.BotCommandScope{
+		tg.BotCommandScopeDefault(),
+		tg.BotCommandScopeAllPrivateChats(),
+		tg.BotCommandScopeAllGroupChats(),
+		tg.BotCommandScopeAllChatAdministrators(),
+	}
+	langs := []string{""}
+	for _, r := range rosters {
+		if !slices.ContainsFunc(scopes, func(s tg.BotCommandScope) bool { return scopeKey(s) == scopeKey(r.Scope) }) {
+			scopes = append(scopes, r.Scope)
+		}
+		if !slices.Contains(langs, r.LanguageCode) {
+			langs = append(langs, r.LanguageCode)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, r := range rosters {
+		seen[rosterKey(r)] = true
+	}
+	var result []CommandRoster
+	add := func(r CommandRoster) {
+		if key := rosterKey(r); !seen[key] {
+			seen[key] = true
+			result = append(result, r)
+		}
+	}
+	for _, scope := range scopes {
+		for _, lang := range langs {
+			add(CommandRoster{Scope: scope, LanguageCode: lang})
+		}
+	}
+	for _, r := range s.dropped {
+		add(r)
+	}
+	return result
+}
+
+// SyncCommands makes Telegram's command lists match set. Each roster is
+// compared with GetMyCommands and only rosters that differ are sent; then
+// lists set does not declare (see CommandSet.Drop) are deleted if
+// Telegram still has them. It makes no changes when everything matches, so
+// calling it on every start is cheap. It stops at the first error.
+func (c *Client) SyncCommands(ctx context.Context, set *CommandSet) error {
+	rosters := set.Rosters()
+	for _, roster := range rosters {
+		opt := WithCommandScopeAndLanguage(roster.Scope, roster.LanguageCode)
+		current, err := c.GetMyCommands(ctx, opt)
+		if err != nil {
+			return fmt.Errorf("sync commands (%s %q): %w", roster.Scope.Type, roster.LanguageCode, err)
+		}
+		if slices.Equal(current, roster.Commands) {
+			continue
+		}
+		if err := c.SetMyCommands(ctx, roster.Commands, opt); err != nil {
+			return fmt.Errorf("sync commands (%s %q): %w", roster.Scope.Type, roster.LanguageCode, err)
+		}
+	}
+	for _, stale := range set.unwanted(rosters) {
+		opt := WithCommandScopeAndLanguage(stale.Scope, stale.LanguageCode)
+		current, err := c.GetMyCommands(ctx, opt)
+		if err != nil {
+			return fmt.Errorf("sync commands (%s %q): %w", stale.Scope.Type, stale.LanguageCode, err)
+		}
+		if len(current) == 0 {
+			continue
+		}
+		if err := c.DeleteMyCommands(ctx, opt); err != nil {
+			return fmt.Errorf("sync commands (%s %q): %w", stale.Scope.Type, stale.LanguageCode, err)
+		}
+	}
+	return nil
+}
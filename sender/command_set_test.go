@@ -0,0 +1,152 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestCommandSet_Rosters(t *testing.T) {
+	set := sender.NewCommandSet().
+		Add("start", "Start the bot").
+		Add("help", "Get help").
+		Add("ban", "Ban a user", tg.BotCommandScopeAllChatAdministrators()).
+		Localize("start", "de", "Bot starten")
+
+	rosters := set.Rosters()
+	require.Len(t, rosters, 3)
+
+	assert.Equal(t, "default", rosters[0].Scope.Type)
+	assert.Empty(t, rosters[0].LanguageCode)
+	assert.Equal(t, []tg.BotCommand{
+		{Command: "start", Description: "Start the bot"},
+		{Command: "help", Description: "Get help"},
+	}, rosters[0].Commands)
+
+	assert.Equal(t, "default", rosters[1].Scope.Type)
+	assert.Equal(t, "de", rosters[1].LanguageCode)
+	assert.Equal(t, []tg.BotCommand{
+		{Command: "start", Description: "Bot starten"},
+		{Command: "help", Description: "Get help"},
+	}, rosters[1].Commands)
+
+	assert.Equal(t, "all_chat_administrators", rosters[2].Scope.Type)
+	assert.Equal(t, []tg.BotCommand{{Command: "ban", Description: "Ban a user"}}, rosters[2].Commands)
+}
+
+func TestCommandSet_AddReplaces(t *testing.T) {
+	set := sender.NewCommandSet().
+		Add("start", "Old").
+		Localize("start", "de", "Alt").
+		Add("start", "New").
+		Localize("missing", "de", "ignored")
+
+	rosters := set.Rosters()
+	require.Len(t, rosters, 2)
+	assert.Equal(t, []tg.BotCommand{{Command: "start", Description: "New"}}, rosters[0].Commands)
+	assert.Equal(t, []tg.BotCommand{{Command: "start", Description: "Alt"}}, rosters[1].Commands)
+}
+
+type commandsRequest struct {
+	Commands     []tg.BotCommand     `json:"commands"`
+	Scope        *tg.BotCommandScope `json:"scope"`
+	LanguageCode string              `json:"language_code"`
+}
+
+// commandsServer is a mock server keeping command lists by "scope|language".
+type commandsServer struct {
+	*testutil.MockTelegramServer
+	mu      sync.Mutex
+	current map[string][]tg.BotCommand
+	sets    []commandsRequest
+	deletes []string
+}
+
+func newCommandsServer(t *testing.T, current map[string][]tg.BotCommand) *commandsServer {
+	s := &commandsServer{MockTelegramServer: testutil.NewMockServer(t), current: current}
+	decode := func(r *http.Request) (commandsRequest, string) {
+		var req commandsRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		return req, req.Scope.Type + "|" + req.LanguageCode
+	}
+	s.On("/bot"+testutil.TestToken+"/getMyCommands", func(w http.ResponseWriter, r *http.Request) {
+		_, key := decode(r)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		cmds := s.current[key]
+		if cmds == nil {
+			cmds = []tg.BotCommand{}
+		}
+		testutil.ReplyOK(w, cmds)
+	})
+	s.On("/bot"+testutil.TestToken+"/setMyCommands", func(w http.ResponseWriter, r *http.Request) {
+		req, key := decode(r)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.sets = append(s.sets, req)
+		s.current[key] = req.Commands
+		testutil.ReplyOK(w, true)
+	})
+	s.On("/bot"+testutil.TestToken+"/deleteMyCommands", func(w http.ResponseWriter, r *http.Request) {
+		_, key := decode(r)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.deletes = append(s.deletes, key)
+		delete(s.current, key)
+		testutil.ReplyOK(w, true)
+	})
+	return s
+}
+
+func TestSyncCommands_SetsOnlyChangedRosters(t *testing.T) {
+	server := newCommandsServer(t, map[string][]tg.BotCommand{
+		"default|": {{Command: "start", Description: "Start the bot"}},
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+	set := sender.NewCommandSet().
+		Add("start", "Start the bot").
+		Localize("start", "de", "Bot starten")
+
+	require.NoError(t, client.SyncCommands(context.Background(), set))
+	require.Len(t, server.sets, 1, "default roster is already up to date")
+	assert.Equal(t, "de", server.sets[0].LanguageCode)
+	assert.Equal(t, "default", server.sets[0].Scope.Type)
+
+	require.NoError(t, client.SyncCommands(context.Background(), set))
+	assert.Len(t, server.sets, 1, "second sync is a no-op")
+	assert.Empty(t, server.deletes)
+}
+
+func TestSyncCommands_DeletesDroppedLists(t *testing.T) {
+	old := []tg.BotCommand{{Command: "old", Description: "Removed command"}}
+	server := newCommandsServer(t, map[string][]tg.BotCommand{
+		"default|":           {{Command: "start", Description: "Start the bot"}},
+		"default|de":         {{Command: "start", Description: "Bot starten"}},
+		"all_group_chats|":   old,
+		"all_group_chats|de": old,
+		"default|fr":         old,
+		"chat|":              old,
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+	set := sender.NewCommandSet().
+		Add("start", "Start the bot").
+		Localize("start", "de", "Bot starten").
+		Drop(tg.BotCommandScopeDefault(), "fr").
+		Drop(tg.BotCommandScopeChat(int64(-100)))
+
+	require.NoError(t, client.SyncCommands(context.Background(), set))
+	assert.Empty(t, server.sets)
+	assert.ElementsMatch(t, []string{"all_group_chats|", "all_group_chats|de", "default|fr", "chat|"}, server.deletes)
+
+	require.NoError(t, client.SyncCommands(context.Background(), set))
+	assert.Len(t, server.deletes, 4, "second sync is a no-op")
+}
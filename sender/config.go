@@ -15,11 +15,17 @@ type Config struct {
 
 	// API settings
 	BaseURL        string
-	RequestTimeout time.Duration
+	RequestTimeout time.Duration // Default budget of a single API call attempt
 	KeepAlive      time.Duration
 	MaxIdleConns   int
 	IdleTimeout    time.Duration
 
+	// Per-request budgets; each attempt of a call gets its own deadline.
+	// 0 = RequestTimeout. WithRequestTimeout overrides them per call.
+	SendTimeout     time.Duration // JSON requests
+	UploadTimeout   time.Duration // Multipart requests with file uploads
+	DownloadTimeout time.Duration // File downloads from the Bot API file endpoint
+
 	// Rate limiting
 	GlobalRPS       float64
 	GlobalBurst     int
@@ -53,6 +59,8 @@ func DefaultConfig() Config {
 		KeepAlive:          30 * time.Second,
 		MaxIdleConns:       100,
 		IdleTimeout:        90 * time.Second,
+		UploadTimeout:      5 * time.Minute,
+		DownloadTimeout:    5 * time.Minute,
 		GlobalRPS:          30,
 		GlobalBurst:        10,
 		PerChatRPS:         1,
@@ -86,6 +94,18 @@ func LoadConfig() (*Config, error) {
 		cfg.RequestTimeout = d
 	}
 
+	if d, err := time.ParseDuration(getEnv("SEND_TIMEOUT", "0s")); err == nil {
+		cfg.SendTimeout = d
+	}
+
+	if d, err := time.ParseDuration(getEnv("UPLOAD_TIMEOUT", "5m")); err == nil {
+		cfg.UploadTimeout = d
+	}
+
+	if d, err := time.ParseDuration(getEnv("DOWNLOAD_TIMEOUT", "5m")); err == nil {
+		cfg.DownloadTimeout = d
+	}
+
 	if f, err := strconv.ParseFloat(getEnv("RATE_LIMIT_REQUESTS", "30"), 64); err == nil {
 		cfg.GlobalRPS = f
 	}
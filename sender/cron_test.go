@@ -0,0 +1,40 @@
+package sender
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron_Next(t *testing.T) {
+	base := time.Date(2026, 3, 6, 10, 30, 0, 0, time.UTC) // Friday
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 3, 6, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 3, 6, 10, 45, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 8,20 * * *", time.Date(2026, 3, 6, 20, 30, 0, 0, time.UTC)},
+		{"0 0 10 * 1", time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)}, // the 10th OR Monday
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			spec, err := parseCron(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec.next(base))
+		})
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "a * * * *", "5-2 * * * *"} {
+		_, err := parseCron(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,206 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/prilive-com/galigo/internal/version"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== File Downloads ==================
+//
+// Files are fetched from <BaseURL>/file/bot<token>/<file_path>. Right
+// after getFile that endpoint occasionally answers 404 until the file has
+// propagated, so downloads retry 404 as well as 429 and 5xx responses.
+// A local Bot API server (--local) returns absolute file paths instead;
+// those files are read from disk.
+
+// ErrFileSizeMismatch is returned when a download's length differs from
+// File.FileSize.
+var ErrFileSizeMismatch = errors.New("galigo: downloaded size does not match file size")
+
+// FileURL returns the download URL of file, as returned by GetFile. The
+// URL contains the bot token; do not log or share it. Absolute paths from
+// a local Bot API server have no URL and yield an error.
+func (c *Client) FileURL(file *tg.File) (string, error) {
+	if file == nil || file.FilePath == "" {
+		return "", errors.New("galigo: file has no file_path; call GetFile first")
+	}
+	if path.IsAbs(file.FilePath) {
+		return "", fmt.Errorf("galigo: %s is a local file path", file.FilePath)
+	}
+	if strings.Contains(file.FilePath, "..") {
+		return "", ErrPathTraversal
+	}
+	return fmt.Sprintf("%s/file/bot%s/%s", c.config.BaseURL, c.tokens.Load().current.Value(), file.FilePath), nil
+}
+
+// Download fetches the file with fileID into w and returns its info. See
+// DownloadFile.
+func (c *Client) Download(ctx context.Context, fileID string, w io.Writer) (*tg.File, error) {
+	file, err := c.GetFile(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.DownloadFile(ctx, file, w); err != nil {
+		return file, err
+	}
+	return file, nil
+}
+
+// DownloadFile copies file, as returned by GetFile, into w. Failed
+// attempts are retried with the client's retry settings as long as
+// nothing was written, or w can be rewound (like *os.File) to where it
+// stood before the first attempt; attempts cut by DownloadTimeout are
+// retried too. The length is checked against file.FileSize when known. Absolute paths from a local
+// Bot API server are read from disk.
+func (c *Client) DownloadFile(ctx context.Context, file *tg.File, w io.Writer) error {
+	if file != nil && path.IsAbs(file.FilePath) {
+		return copyLocalFile(file, w)
+	}
+	url, err := c.FileURL(file)
+	if err != nil {
+		return err
+	}
+
+	start, seekable := writerOffset(w)
+	c.retryBudget.request(c.clock.Now())
+	var lastErr error
+	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		var written int64
+		written, lastErr = c.downloadOnce(ctx, url, file.FileSize, w)
+		if lastErr == nil {
+			return nil
+		}
+		if !isDownloadRetryable(lastErr) || attempt >= c.config.MaxRetries {
+			break
+		}
+		if written > 0 && (!seekable || !rewind(w, start)) {
+			break
+		}
+		if !c.retryBudget.allowRetry(c.clock.Now()) {
+			return fmt.Errorf("%w: %w", ErrRetryBudgetExhausted, lastErr)
+		}
+		if err := c.sleeper.Sleep(ctx, calculateBackoff(c.config, attempt+1, lastErr)); err != nil {
+			return err
+		}
+	}
+	return lastErr
+}
+
+// downloadOnce performs one download attempt and returns the bytes
+// written to w.
+func (c *Client) downloadOnce(ctx context.Context, url string, size int64, w io.Writer) (int64, error) {
+	parent := ctx
+	timeout := c.requestTimeout(ctx, kindDownload)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", c.scrubToken(err))
+	}
+	version.SetHeaders(req, c.userAgent, c.extraHeaders)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if attemptTimedOut(parent, ctx) {
+			return 0, fmt.Errorf("download failed: %w", &attemptTimeoutError{method: "download", timeout: timeout})
+		}
+		return 0, fmt.Errorf("download failed: %w", c.scrubToken(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		return 0, tg.NewAPIError("download", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	if size > 0 && resp.ContentLength >= 0 && resp.ContentLength != size {
+		return 0, fmt.Errorf("%w: Content-Length %d, expected %d", ErrFileSizeMismatch, resp.ContentLength, size)
+	}
+
+	body := io.Reader(resp.Body)
+	if size > 0 {
+		body = io.LimitReader(resp.Body, size+1) // one extra byte detects overlong bodies
+	}
+	n, err := io.Copy(w, body)
+	if err != nil {
+		if attemptTimedOut(parent, ctx) {
+			return n, fmt.Errorf("download failed: %w", &attemptTimeoutError{method: "download", timeout: timeout})
+		}
+		return n, fmt.Errorf("download failed: %w", c.scrubToken(err))
+	}
+	if size > 0 && n != size {
+		return n, fmt.Errorf("%w: got %d bytes, expected %d", ErrFileSizeMismatch, n, size)
+	}
+	return n, nil
+}
+
+// isDownloadRetryable extends isRetryable with 404, which the file
+// endpoint returns transiently for freshly requested files, and with
+// transport errors, since a GET is safe to repeat.
+func isDownloadRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrFileSizeMismatch) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == http.StatusNotFound || apiErr.IsRetryable()
+	}
+	return true
+}
+
+// rewindable is a writer that can be reset for another attempt, such as
+// *os.File.
+type rewindable interface {
+	io.Seeker
+	Truncate(size int64) error
+}
+
+// writerOffset returns the position of w before the first attempt, and
+// whether w is rewindable at all.
+func writerOffset(w io.Writer) (int64, bool) {
+	f, ok := w.(rewindable)
+	if !ok {
+		return 0, false
+	}
+	offset, err := f.Seek(0, io.SeekCurrent)
+	return offset, err == nil
+}
+
+// rewind resets w to offset for another attempt, discarding what the
+// failed attempt wrote after it.
+func rewind(w io.Writer, offset int64) bool {
+	f := w.(rewindable)
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return false
+	}
+	return f.Truncate(offset) == nil
+}
+
+// copyLocalFile reads a file stored by a local Bot API server.
+func copyLocalFile(file *tg.File, w io.Writer) error {
+	f, err := os.Open(file.FilePath)
+	if err != nil {
+		return fmt.Errorf("galigo: open local file: %w", err)
+	}
+	defer f.Close()
+	n, err := io.Copy(w, f)
+	if err != nil {
+		return fmt.Errorf("galigo: read local file: %w", err)
+	}
+	if file.FileSize > 0 && n != file.FileSize {
+		return fmt.Errorf("%w: got %d bytes, expected %d", ErrFileSizeMismatch, n, file.FileSize)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package sender_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+const downloadPath = "/file/bot" + testutil.TestToken + "/photos/file_1.jpg"
+
+var downloadContent = []byte("jpeg bytes")
+
+func TestDownload_FetchesFile(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getFile", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, map[string]any{
+			"file_id": "F1", "file_unique_id": "U1",
+			"file_size": len(downloadContent), "file_path": "photos/file_1.jpg",
+		})
+	})
+	server.OnMethod(http.MethodGet, downloadPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(downloadContent)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	var buf bytes.Buffer
+	file, err := client.Download(context.Background(), "F1", &buf)
+	require.NoError(t, err)
+	assert.Equal(t, "photos/file_1.jpg", file.FilePath)
+	assert.Equal(t, downloadContent, buf.Bytes())
+}
+
+func TestDownloadFile_RetriesTransient404(t *testing.T) {
+	var attempts atomic.Int32
+	server := testutil.NewMockServer(t)
+	server.OnMethod(http.MethodGet, downloadPath, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) <= 2 {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(downloadContent)
+	})
+	sleeper := &testutil.FakeSleeper{}
+	client := testutil.NewRetryTestClient(t, server.BaseURL(), sleeper)
+
+	var buf bytes.Buffer
+	file := &tg.File{FilePath: "photos/file_1.jpg", FileSize: int64(len(downloadContent))}
+	require.NoError(t, client.DownloadFile(context.Background(), file, &buf))
+	assert.Equal(t, downloadContent, buf.Bytes())
+	assert.Equal(t, int32(3), attempts.Load())
+	assert.Equal(t, 2, sleeper.CallCount())
+}
+
+func TestDownloadFile_GivesUpAfterRetries(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.OnMethod(http.MethodGet, downloadPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	client := testutil.NewRetryTestClient(t, server.BaseURL(), &testutil.FakeSleeper{}, sender.WithRetries(2))
+
+	err := client.DownloadFile(context.Background(), &tg.File{FilePath: "photos/file_1.jpg"}, &bytes.Buffer{})
+	var apiErr *sender.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadGateway, apiErr.Code)
+	assert.Equal(t, 3, server.CaptureCount())
+}
+
+func TestDownloadFile_SizeMismatch(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.OnMethod(http.MethodGet, downloadPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(downloadContent)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	file := &tg.File{FilePath: "photos/file_1.jpg", FileSize: 999}
+	err := client.DownloadFile(context.Background(), file, &bytes.Buffer{})
+	assert.ErrorIs(t, err, sender.ErrFileSizeMismatch)
+}
+
+func TestDownloadFile_RewindsFileOnRetry(t *testing.T) {
+	var attempts atomic.Int32
+	server := testutil.NewMockServer(t)
+	server.OnMethod(http.MethodGet, downloadPath, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			// Promise more than is sent so the body fails mid-stream.
+			w.Header().Set("Content-Length", "100")
+			w.Write([]byte("partial"))
+			return
+		}
+		w.Write(downloadContent)
+	})
+	client := testutil.NewRetryTestClient(t, server.BaseURL(), &testutil.FakeSleeper{})
+
+	out, err := os.Create(filepath.Join(t.TempDir(), "out.jpg"))
+	require.NoError(t, err)
+	defer out.Close()
+
+	require.NoError(t, client.DownloadFile(context.Background(), &tg.File{FilePath: "photos/file_1.jpg"}, out))
+	got, err := os.ReadFile(out.Name())
+	require.NoError(t, err)
+	assert.Equal(t, downloadContent, got, "partial first attempt discarded")
+}
+
+func TestDownloadFile_RewindsToStartOffset(t *testing.T) {
+	var attempts atomic.Int32
+	server := testutil.NewMockServer(t)
+	server.OnMethod(http.MethodGet, downloadPath, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Content-Length", "100")
+			w.Write([]byte("partial"))
+			return
+		}
+		w.Write(downloadContent)
+	})
+	client := testutil.NewRetryTestClient(t, server.BaseURL(), &testutil.FakeSleeper{})
+
+	out, err := os.Create(filepath.Join(t.TempDir(), "out.jpg"))
+	require.NoError(t, err)
+	defer out.Close()
+	_, err = out.WriteString("header:")
+	require.NoError(t, err)
+
+	require.NoError(t, client.DownloadFile(context.Background(), &tg.File{FilePath: "photos/file_1.jpg"}, out))
+	got, err := os.ReadFile(out.Name())
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte("header:"), downloadContent...), got, "what preceded the download is kept")
+}
+
+func TestDownloadFile_RetriesAttemptTimeout(t *testing.T) {
+	var attempts atomic.Int32
+	server := testutil.NewMockServer(t)
+	server.OnMethod(http.MethodGet, downloadPath, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			// Stall in the body until the attempt deadline passes.
+			w.Header().Set("Content-Length", strconv.Itoa(len(downloadContent)))
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+			return
+		}
+		w.Write(downloadContent)
+	})
+	client := testutil.NewRetryTestClient(t, server.BaseURL(), &testutil.FakeSleeper{},
+		sender.WithDownloadTimeout(100*time.Millisecond))
+
+	var out bytes.Buffer
+	require.NoError(t, client.DownloadFile(context.Background(), &tg.File{FilePath: "photos/file_1.jpg"}, &out))
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestDownloadFile_LocalServerPath(t *testing.T) {
+	local := filepath.Join(t.TempDir(), "file_1.jpg")
+	require.NoError(t, os.WriteFile(local, downloadContent, 0o600))
+	client := testutil.NewTestClient(t, "http://unused")
+
+	var buf bytes.Buffer
+	file := &tg.File{FilePath: local, FileSize: int64(len(downloadContent))}
+	require.NoError(t, client.DownloadFile(context.Background(), file, &buf))
+	assert.Equal(t, downloadContent, buf.Bytes())
+
+	_, err := client.FileURL(file)
+	assert.Error(t, err, "local files have no URL")
+}
+
+func TestFileURL(t *testing.T) {
+	client := testutil.NewTestClient(t, "https://api.example")
+
+	url, err := client.FileURL(&tg.File{FilePath: "documents/a.pdf"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.example/file/bot"+testutil.TestToken+"/documents/a.pdf", url)
+
+	_, err = client.FileURL(&tg.File{FileID: "F1"})
+	assert.Error(t, err, "missing file_path")
+	_, err = client.FileURL(&tg.File{FilePath: "../secrets"})
+	assert.ErrorIs(t, err, sender.ErrPathTraversal)
+}
@@ -0,0 +1,328 @@
+package sender
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prilive-com/galigo/internal/fixture"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// DryRunRequest is a request that would have been sent to Telegram in dry-run mode.
+type DryRunRequest struct {
+	Method  string
+	ChatID  string
+	Payload any
+	Time    time.Time
+}
+
+// DryRunSink receives every request intercepted in dry-run mode.
+// Implementations must be safe for concurrent use.
+type DryRunSink interface {
+	Record(req DryRunRequest)
+}
+
+// DryRunCapture is an in-memory DryRunSink for assertions in staging or tests.
+type DryRunCapture struct {
+	mu       sync.Mutex
+	requests []DryRunRequest
+}
+
+var _ DryRunSink = (*DryRunCapture)(nil)
+
+// Record implements DryRunSink.
+func (c *DryRunCapture) Record(req DryRunRequest) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, req)
+}
+
+// Requests returns a copy of all recorded requests.
+func (c *DryRunCapture) Requests() []DryRunRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]DryRunRequest{}, c.requests...)
+}
+
+// Last returns the most recent recorded request, or nil if none.
+func (c *DryRunCapture) Last() *DryRunRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.requests) == 0 {
+		return nil
+	}
+	req := c.requests[len(c.requests)-1]
+	return &req
+}
+
+// Count returns the number of recorded requests.
+func (c *DryRunCapture) Count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.requests)
+}
+
+// Reset clears all recorded requests.
+func (c *DryRunCapture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = c.requests[:0]
+}
+
+// WithDryRun enables dry-run mode: requests are validated, rate limited and
+// logged as usual but never sent to Telegram. Each method returns a
+// synthesized result of its usual type: getters return the galigotest
+// fixtures, and sent messages get deterministic message IDs starting at 1.
+// If sink is non-nil, every would-be request is recorded to it.
+func WithDryRun(sink DryRunSink) Option {
+	return func(c *Client) {
+		c.dryRun = &dryRunner{sink: sink}
+	}
+}
+
+// dryRunner synthesizes API responses in dry-run mode.
+type dryRunner struct {
+	sink   DryRunSink
+	nextID atomic.Int64
+}
+
+// respond records the request and returns a synthesized successful response.
+func (d *dryRunner) respond(c *Client, method string, payload any, chatID string) (*apiResponse, error) {
+	c.logger.Info("dry run: request not sent", "method", method, "chat_id", chatID)
+
+	if d.sink != nil {
+		d.sink.Record(DryRunRequest{
+			Method:  method,
+			ChatID:  chatID,
+			Payload: payload,
+			Time:    time.Now(),
+		})
+	}
+
+	result, err := json.Marshal(d.result(c, method, payload, chatID))
+	if err != nil {
+		return nil, err
+	}
+	return &apiResponse{OK: true, Result: result}, nil
+}
+
+// result builds a plausible result value for the given method. Objects
+// come from the fixtures galigotest serves; only methods that return True
+// fall through to true.
+func (d *dryRunner) result(c *Client, method string, payload any, chatID string) any {
+	switch method {
+	case "getMe":
+		return d.user(c)
+	case "copyMessage":
+		return map[string]any{"message_id": d.nextID.Add(1)}
+	case "copyMessages", "forwardMessages":
+		n := max(payloadSliceLen(payload, "MessageIDs"), 1)
+		ids := make([]map[string]any, n)
+		for i := range ids {
+			ids[i] = map[string]any{"message_id": d.nextID.Add(1)}
+		}
+		return ids
+	case "sendMediaGroup":
+		n := max(payloadSliceLen(payload, "Media"), 1)
+		msgs := make([]map[string]any, n)
+		for i := range msgs {
+			msgs[i] = d.message(d.nextID.Add(1), chatID, "")
+		}
+		return msgs
+	case "sendChatAction", "sendMessageDraft":
+		return true
+	case "forwardMessage", "stopMessageLiveLocation":
+		return d.message(d.nextID.Add(1), chatID, auditPayloadText(payload))
+	case "setGameScore":
+		return d.editedMessage(payload, chatID)
+
+	case "getChat":
+		return fixture.ChatFullInfo(d.chat(chatID))
+	case "getChatAdministrators":
+		return []any{chatMemberJSON(fixture.ChatOwner(fixture.User()), "creator")}
+	case "getChatMember":
+		user := fixture.User()
+		if id := payloadInt(payload, "UserID"); id != 0 {
+			user.ID = id
+		}
+		return chatMemberJSON(fixture.ChatMember(user), "member")
+	case "getChatMemberCount":
+		return 1
+	case "getChatMenuButton":
+		return tg.MenuButtonDefault()
+	case "getFile", "uploadStickerFile":
+		id := payloadString(payload, "FileID")
+		if id == "" {
+			id = "dryrun_" + strconv.FormatInt(d.nextID.Add(1), 10)
+		}
+		return fixture.File(id)
+	case "getStickerSet":
+		return fixture.StickerSet(payloadString(payload, "Name"))
+	case "getCustomEmojiStickers", "getForumTopicIconStickers", "getGameHighScores", "getMyCommands":
+		return []any{}
+	case "getBusinessConnection":
+		return fixture.BusinessConnection(payloadString(payload, "BusinessConnectionID"))
+	case "getMyDefaultAdministratorRights":
+		return tg.ChatAdministratorRights{}
+	case "getMyName":
+		return tg.BotName{Name: fixture.Bot().FirstName}
+	case "getMyDescription":
+		return tg.BotDescription{}
+	case "getMyShortDescription":
+		return tg.BotShortDescription{}
+	case "getMyStarBalance", "getBusinessAccountStarBalance":
+		return tg.StarAmount{}
+	case "getStarTransactions":
+		return tg.StarTransactions{Transactions: []tg.StarTransaction{}}
+	case "getAvailableGifts":
+		return tg.Gifts{Gifts: []tg.Gift{}}
+	case "getOwnedGifts":
+		return tg.OwnedGifts{Gifts: []tg.OwnedGift{}}
+	case "getUserChatBoosts":
+		return tg.UserChatBoosts{Boosts: []tg.ChatBoost{}}
+	case "getUserProfilePhotos":
+		return tg.UserProfilePhotos{Photos: [][]tg.PhotoSize{}}
+	case "getUserProfileAudios":
+		return tg.UserProfileAudios{Audios: []tg.Audio{}}
+
+	case "exportChatInviteLink":
+		return fixture.InviteLink(fixture.Bot()).InviteLink
+	case "createChatInviteLink", "editChatInviteLink", "revokeChatInviteLink",
+		"createChatSubscriptionInviteLink", "editChatSubscriptionInviteLink":
+		return fixture.InviteLink(fixture.Bot())
+	case "createForumTopic":
+		return tg.ForumTopic{MessageThreadID: int(d.nextID.Add(1)), Name: payloadString(payload, "Name")}
+	case "createInvoiceLink":
+		return "https://t.me/$dryrun"
+	case "answerWebAppQuery":
+		return tg.SentWebAppMessage{}
+	case "savePreparedInlineMessage":
+		return tg.PreparedInlineMessage{ID: "dryrun", ExpirationDate: time.Now().Add(24 * time.Hour).Unix()}
+	case "postStory", "editStory":
+		id := payloadInt(payload, "StoryID")
+		if id == 0 {
+			id = d.nextID.Add(1)
+		}
+		return tg.Story{Chat: *d.chat(chatID), ID: int(id)}
+	case "stopPoll":
+		return tg.Poll{ID: "dryrun", Options: []tg.PollOption{}, IsClosed: true, Type: "regular"}
+	}
+
+	switch {
+	case strings.HasPrefix(method, "send"):
+		return d.message(d.nextID.Add(1), chatID, auditPayloadText(payload))
+	case strings.HasPrefix(method, "editMessage"):
+		return d.editedMessage(payload, chatID)
+	default:
+		return true
+	}
+}
+
+// editedMessage returns the message an edit produces, or true for inline
+// messages, which Telegram does not return.
+func (d *dryRunner) editedMessage(payload any, chatID string) any {
+	id := int64(auditPayloadMessageID(payload))
+	if id == 0 {
+		return true
+	}
+	return d.message(id, chatID, auditPayloadText(payload))
+}
+
+// chat returns the chat a request's chatID names: a supergroup for
+// negative IDs, a channel for @username, and otherwise a private chat,
+// the user fixture's for its own ID or no ID.
+func (d *dryRunner) chat(chatID string) *tg.Chat {
+	if chatID == "" {
+		return fixture.Chat()
+	}
+	n, err := strconv.ParseInt(chatID, 10, 64)
+	switch {
+	case err != nil:
+		return fixture.ChannelChat(0, "", strings.TrimPrefix(chatID, "@"))
+	case n == fixture.ChatID:
+		return fixture.Chat()
+	case n < 0:
+		return fixture.SuperGroupChat(n, "", "")
+	}
+	return &tg.Chat{ID: n, Type: "private"}
+}
+
+func (d *dryRunner) message(id int64, chatID, text string) map[string]any {
+	msg := map[string]any{
+		"message_id": id,
+		"date":       time.Now().Unix(),
+		"chat":       d.chat(chatID),
+	}
+	if text != "" {
+		msg["text"] = text
+	}
+	return msg
+}
+
+func (d *dryRunner) user(c *Client) map[string]any {
+	var botID int64
+	if prefix, _, ok := strings.Cut(c.config.Token.Value(), ":"); ok {
+		botID, _ = strconv.ParseInt(prefix, 10, 64)
+	}
+	return map[string]any{
+		"id":         botID,
+		"is_bot":     true,
+		"first_name": "Dry Run Bot",
+		"username":   "dryrun_bot",
+	}
+}
+
+// chatMemberJSON adds the status field ChatMember variants leave to their
+// type.
+func chatMemberJSON(m tg.ChatMember, status string) map[string]any {
+	var out map[string]any
+	if data, err := json.Marshal(m); err == nil {
+		_ = json.Unmarshal(data, &out)
+	}
+	if out == nil {
+		out = map[string]any{}
+	}
+	out["status"] = status
+	return out
+}
+
+// payloadString returns the named string field of a request struct.
+func payloadString(payload any, field string) string {
+	rv := auditStruct(payload)
+	if !rv.IsValid() {
+		return ""
+	}
+	if f := rv.FieldByName(field); f.IsValid() && f.Kind() == reflect.String {
+		return f.String()
+	}
+	return ""
+}
+
+// payloadInt returns the named integer field of a request struct.
+func payloadInt(payload any, field string) int64 {
+	rv := auditStruct(payload)
+	if !rv.IsValid() {
+		return 0
+	}
+	if f := rv.FieldByName(field); f.IsValid() && f.CanInt() {
+		return f.Int()
+	}
+	return 0
+}
+
+// payloadSliceLen returns the length of the named slice field of a request struct.
+func payloadSliceLen(payload any, field string) int {
+	rv := auditStruct(payload)
+	if !rv.IsValid() {
+		return 0
+	}
+	if f := rv.FieldByName(field); f.IsValid() && f.Kind() == reflect.Slice {
+		return f.Len()
+	}
+	return 0
+}
@@ -0,0 +1,156 @@
+package sender_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+)
+
+func TestDryRun_NoHTTPAndDeterministicIDs(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	capture := &sender.DryRunCapture{}
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithDryRun(capture))
+
+	ctx := context.Background()
+	msg1, err := client.SendMessage(ctx, sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "one"})
+	require.NoError(t, err)
+	msg2, err := client.SendMessage(ctx, sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "two"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, msg1.MessageID)
+	assert.Equal(t, 2, msg2.MessageID)
+	assert.Equal(t, testutil.TestChatID, msg2.Chat.ID)
+	assert.Equal(t, "two", msg2.Text)
+
+	assert.Equal(t, 0, server.CaptureCount(), "dry run must not hit the API")
+	require.Equal(t, 2, capture.Count())
+	last := capture.Last()
+	assert.Equal(t, "sendMessage", last.Method)
+	req, ok := last.Payload.(sender.SendMessageRequest)
+	require.True(t, ok)
+	assert.Equal(t, "two", req.Text)
+}
+
+func TestDryRun_ValidationStillApplies(t *testing.T) {
+	capture := &sender.DryRunCapture{}
+	client := testutil.NewTestClient(t, "http://unused", sender.WithDryRun(capture))
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{Text: "no chat"})
+	require.Error(t, err)
+	assert.Equal(t, 0, capture.Count())
+}
+
+func TestDryRun_SynthesizedResults(t *testing.T) {
+	client := testutil.NewTestClient(t, "http://unused", sender.WithDryRun(nil))
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, testutil.TestBotID, me.ID)
+	assert.True(t, me.IsBot)
+
+	ids, err := client.CopyMessages(ctx, sender.CopyMessagesRequest{
+		ChatID:     testutil.TestChatID,
+		FromChatID: testutil.TestChatID,
+		MessageIDs: []int{10, 11, 12},
+	})
+	require.NoError(t, err)
+	assert.Len(t, ids, 3)
+
+	err = client.DeleteMessage(ctx, sender.DeleteMessageRequest{ChatID: testutil.TestChatID, MessageID: 1})
+	require.NoError(t, err)
+
+	msg, err := client.EditMessageText(ctx, sender.EditMessageTextRequest{
+		ChatID:    testutil.TestChatID,
+		MessageID: 99,
+		Text:      "edited",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 99, msg.MessageID)
+	assert.Equal(t, "edited", msg.Text)
+}
+
+func TestDryRun_Getters(t *testing.T) {
+	client := testutil.NewTestClient(t, "http://unused", sender.WithDryRun(nil))
+	ctx := context.Background()
+
+	getters := map[string]func() error{
+		"GetAvailableGifts": func() error { _, err := client.GetAvailableGifts(ctx); return err },
+		"GetBusinessAccountStarBalance": func() error {
+			_, err := client.GetBusinessAccountStarBalance(ctx, "conn")
+			return err
+		},
+		"GetBusinessConnection": func() error { _, err := client.GetBusinessConnection(ctx, "conn"); return err },
+		"GetChat":               func() error { _, err := client.GetChat(ctx, testutil.TestChatID); return err },
+		"GetChatAdministrators": func() error { _, err := client.GetChatAdministrators(ctx, testutil.TestChatID); return err },
+		"GetChatMember": func() error {
+			_, err := client.GetChatMember(ctx, testutil.TestChatID, testutil.TestUserID)
+			return err
+		},
+		"GetChatMemberCount": func() error { _, err := client.GetChatMemberCount(ctx, testutil.TestChatID); return err },
+		"GetChatMenuButton":  func() error { _, err := client.GetChatMenuButton(ctx); return err },
+		"GetCustomEmojiStickers": func() error {
+			_, err := client.GetCustomEmojiStickers(ctx, []string{"emoji"})
+			return err
+		},
+		"GetFile":                   func() error { _, err := client.GetFile(ctx, "file"); return err },
+		"GetForumTopicIconStickers": func() error { _, err := client.GetForumTopicIconStickers(ctx); return err },
+		"GetGameHighScores": func() error {
+			_, err := client.GetGameHighScores(ctx, sender.GetGameHighScoresRequest{
+				UserID: testutil.TestUserID, ChatID: testutil.TestChatID, MessageID: 1,
+			})
+			return err
+		},
+		"GetMe":         func() error { _, err := client.GetMe(ctx); return err },
+		"GetMyCommands": func() error { _, err := client.GetMyCommands(ctx); return err },
+		"GetMyDefaultAdministratorRights": func() error {
+			_, err := client.GetMyDefaultAdministratorRights(ctx, false)
+			return err
+		},
+		"GetMyDescription":      func() error { _, err := client.GetMyDescription(ctx); return err },
+		"GetMyName":             func() error { _, err := client.GetMyName(ctx); return err },
+		"GetMyShortDescription": func() error { _, err := client.GetMyShortDescription(ctx); return err },
+		"GetMyStarBalance":      func() error { _, err := client.GetMyStarBalance(ctx); return err },
+		"GetOwnedGifts": func() error {
+			_, err := client.GetOwnedGifts(ctx, sender.GetOwnedGiftsRequest{UserID: testutil.TestUserID})
+			return err
+		},
+		"GetStarTransactions": func() error {
+			_, err := client.GetStarTransactions(ctx, sender.GetStarTransactionsRequest{})
+			return err
+		},
+		"GetStickerSet": func() error { _, err := client.GetStickerSet(ctx, "stickers"); return err },
+		"GetUserChatBoosts": func() error {
+			_, err := client.GetUserChatBoosts(ctx, sender.GetUserChatBoostsRequest{
+				ChatID: testutil.TestChatID, UserID: testutil.TestUserID,
+			})
+			return err
+		},
+		"GetUserProfileAudios": func() error { _, err := client.GetUserProfileAudios(ctx, testutil.TestUserID); return err },
+		"GetUserProfilePhotos": func() error { _, err := client.GetUserProfilePhotos(ctx, testutil.TestUserID); return err },
+	}
+
+	for name, get := range getters {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, get())
+		})
+	}
+
+	chat, err := client.GetChat(ctx, testutil.TestChatID)
+	require.NoError(t, err)
+	assert.Equal(t, testutil.TestChatID, chat.ID)
+
+	file, err := client.GetFile(ctx, "file")
+	require.NoError(t, err)
+	assert.Equal(t, "file", file.FileID)
+
+	member, err := client.GetChatMember(ctx, testutil.TestChatID, testutil.TestUserID)
+	require.NoError(t, err)
+	assert.Equal(t, "member", member.Status())
+	assert.Equal(t, testutil.TestUserID, member.GetUser().ID)
+}
@@ -148,3 +148,143 @@ func TestBuildMultipartRequest_InputMedia_URL(t *testing.T) {
 	mediaJSON := result.Params["media"]
 	assert.Contains(t, mediaJSON, `"media":"https://example.com/photo.jpg"`)
 }
+
+func TestEditMessageMedia_FileID_JSONBody(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/editMessageMedia", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 42)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	_, err := client.EditMessageMedia(context.Background(), sender.EditMessageMediaRequest{
+		ChatID:    testutil.TestChatID,
+		MessageID: 42,
+		Media: sender.NewInputMediaVideo(sender.FromFileID("BAACAgIAAxkBAAI")).
+			WithCaption("updated").
+			WithThumbnail(sender.FromURL("https://example.com/t.jpg")),
+	})
+
+	require.NoError(t, err)
+	var body struct {
+		Media map[string]any `json:"media"`
+	}
+	server.LastCapture().BodyJSON(t, &body)
+	assert.Equal(t, map[string]any{
+		"type":      "video",
+		"media":     "BAACAgIAAxkBAAI",
+		"caption":   "updated",
+		"thumbnail": "https://example.com/t.jpg",
+	}, body.Media)
+}
+
+func TestEditMessageMedia_UploadWithThumbnailAndCover(t *testing.T) {
+	var mediaParam string
+	parts := map[string]string{}
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/editMessageMedia", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		mediaParam = r.FormValue("media")
+		for name := range r.MultipartForm.File {
+			f, _, _ := r.FormFile(name)
+			b, _ := io.ReadAll(f)
+			parts[name] = string(b)
+		}
+		testutil.ReplyMessage(w, 42)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	_, err := client.EditMessageMedia(context.Background(), sender.EditMessageMediaRequest{
+		ChatID:    testutil.TestChatID,
+		MessageID: 42,
+		Media: sender.NewInputMediaVideo(sender.FromBytes([]byte("video"), "v.mp4")).
+			WithThumbnail(sender.FromBytes([]byte("thumb"), "t.jpg")).
+			WithCover(sender.FromBytes([]byte("cover"), "c.jpg")),
+	})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"video","media":"attach://file0","thumbnail":"attach://file1","cover":"attach://file2"}`, mediaParam)
+	assert.Equal(t, map[string]string{"file0": "video", "file1": "thumb", "file2": "cover"}, parts)
+}
+
+func TestSendMediaGroup_UploadsWithThumbnails(t *testing.T) {
+	var mediaParam string
+	var partNames []string
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMediaGroup", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		mediaParam = r.FormValue("media")
+		for name := range r.MultipartForm.File {
+			partNames = append(partNames, name)
+		}
+		testutil.ReplyOK(w, []map[string]any{{"message_id": 1, "date": 0}, {"message_id": 2, "date": 0}})
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	_, err := client.SendMediaGroup(context.Background(), sender.SendMediaGroupRequest{
+		ChatID: testutil.TestChatID,
+		Media: []sender.InputFile{
+			sender.FromBytes([]byte("v1"), "1.mp4").WithMediaType("video").
+				WithThumbnail(sender.FromBytes([]byte("t1"), "1.jpg")),
+			sender.FromFileID("BAACAgIAAxkBAAI").WithMediaType("video").
+				WithThumbnail(sender.FromBytes([]byte("t2"), "2.jpg")),
+		},
+	})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"type":"video","media":"attach://file0","thumbnail":"attach://file1"},
+		{"type":"video","media":"BAACAgIAAxkBAAI","thumbnail":"attach://file2"}
+	]`, mediaParam)
+	assert.ElementsMatch(t, []string{"file0", "file1", "file2"}, partNames)
+}
+
+func TestSendMediaGroup_JSONBody(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMediaGroup", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, []map[string]any{{"message_id": 1, "date": 0}, {"message_id": 2, "date": 0}})
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	_, err := client.SendMediaGroup(context.Background(), sender.SendMediaGroupRequest{
+		ChatID: testutil.TestChatID,
+		Media: []sender.InputFile{
+			sender.FromURL("https://example.com/1.jpg").WithMediaType("photo").WithCaption("one"),
+			sender.FromFileID("AgACAgIAAxkBAAI").WithMediaType("photo"),
+		},
+	})
+
+	require.NoError(t, err)
+	var body struct {
+		Media []map[string]any `json:"media"`
+	}
+	server.LastCapture().BodyJSON(t, &body)
+	require.Len(t, body.Media, 2)
+	assert.Equal(t, map[string]any{"type": "photo", "media": "https://example.com/1.jpg", "caption": "one"}, body.Media[0])
+	assert.Equal(t, map[string]any{"type": "photo", "media": "AgACAgIAAxkBAAI"}, body.Media[1])
+}
+
+func TestSendVideo_CoverUpload(t *testing.T) {
+	var coverParam string
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendVideo", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		coverParam = r.FormValue("cover")
+		testutil.ReplyMessage(w, 1)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	cover := sender.FromBytes([]byte("cover"), "c.jpg")
+	_, err := client.SendVideo(context.Background(), sender.SendVideoRequest{
+		ChatID: testutil.TestChatID,
+		Video:  sender.FromFileID("BAACAgIAAxkBAAI"),
+		Cover:  &cover,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "attach://file0", coverParam)
+}
@@ -53,11 +53,7 @@ func (c *Client) CreateForumTopic(ctx context.Context, chatID tg.ChatID, name st
 		opt(&req)
 	}
 
-	var topic tg.ForumTopic
-	if err := c.callJSON(ctx, "createForumTopic", req, &topic, extractChatID(chatID)); err != nil {
-		return nil, err
-	}
-	return &topic, nil
+	return call[tg.ForumTopic](c, ctx, "createForumTopic", req, extractChatID(chatID))
 }
 
 // EditForumTopic edits name and icon of a topic.
@@ -49,11 +49,7 @@ func (c *Client) SendGame(ctx context.Context, req SendGameRequest) (*tg.Message
 		return nil, tg.NewValidationError("game_short_name", "required")
 	}
 
-	var result tg.Message
-	if err := c.callJSON(ctx, "sendGame", req, &result, extractChatID(tg.ChatID(req.ChatID))); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.Message](c, ctx, "sendGame", req, extractChatID(tg.ChatID(req.ChatID)))
 }
 
 // SetGameScore sets the score of the specified user in a game message.
@@ -75,11 +71,7 @@ func (c *Client) SetGameScore(ctx context.Context, req SetGameScoreRequest) (*tg
 		return nil, c.callJSON(ctx, "setGameScore", req, nil)
 	}
 
-	var result tg.Message
-	if err := c.callJSON(ctx, "setGameScore", req, &result, extractChatID(tg.ChatID(req.ChatID))); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.Message](c, ctx, "setGameScore", req, extractChatID(tg.ChatID(req.ChatID)))
 }
 
 // GetGameHighScores returns data for high score tables.
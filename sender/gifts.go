@@ -2,15 +2,28 @@ package sender
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"unicode/utf8"
 
 	"github.com/prilive-com/galigo/tg"
 )
 
 // ================== Gift Request Types ==================
 
-// SendGiftRequest represents a sendGift request.
+// MaxGiftTextLength is the longest text that can accompany a gift or a
+// gifted Premium subscription, in characters.
+const MaxGiftTextLength = 128
+
+// premiumSubscriptionMonths are the Premium subscription durations
+// giftPremiumSubscription accepts.
+var premiumSubscriptionMonths = []int{3, 6, 12}
+
+// SendGiftRequest represents a sendGift request. Exactly one of UserID and
+// ChatID must be set; gifts to chats go to channels.
 type SendGiftRequest struct {
-	UserID        int64              `json:"user_id"`
+	UserID        int64              `json:"user_id,omitempty"`
+	ChatID        tg.ChatID          `json:"chat_id,omitempty"`
 	GiftID        string             `json:"gift_id"`
 	PayForUpgrade bool               `json:"pay_for_upgrade,omitempty"`
 	Text          string             `json:"text,omitempty"`
@@ -18,6 +31,18 @@ type SendGiftRequest struct {
 	TextEntities  []tg.MessageEntity `json:"text_entities,omitempty"`
 }
 
+// GiftPremiumSubscriptionRequest represents a giftPremiumSubscription
+// request. StarCount must be Telegram's current price for MonthCount;
+// Telegram rejects any other amount.
+type GiftPremiumSubscriptionRequest struct {
+	UserID        int64              `json:"user_id"`
+	MonthCount    int                `json:"month_count"`
+	StarCount     int                `json:"star_count"`
+	Text          string             `json:"text,omitempty"`
+	TextParseMode string             `json:"text_parse_mode,omitempty"`
+	TextEntities  []tg.MessageEntity `json:"text_entities,omitempty"`
+}
+
 // TransferGiftRequest represents a transferGift request.
 type TransferGiftRequest struct {
 	BusinessConnectionID string `json:"business_connection_id"`
@@ -45,24 +70,50 @@ type ConvertGiftToStarsRequest struct {
 // SendGift sends a gift to a user.
 // NO RETRY — value operation to prevent double-send.
 func (c *Client) SendGift(ctx context.Context, req SendGiftRequest) error {
-	if req.UserID <= 0 {
-		return tg.NewValidationError("user_id", "must be positive")
+	target := tg.ChatID(req.UserID)
+	switch {
+	case req.UserID != 0 && req.ChatID != nil:
+		return tg.NewValidationError("user_id", "only one of user_id and chat_id may be set")
+	case req.ChatID != nil:
+		if err := validateChatID(req.ChatID); err != nil {
+			return err
+		}
+		target = req.ChatID
+	case req.UserID <= 0:
+		return tg.NewValidationError("user_id", "must be positive, or set chat_id")
 	}
 	if req.GiftID == "" {
 		return tg.NewValidationError("gift_id", "required")
 	}
+	if err := validateGiftText(req.Text); err != nil {
+		return err
+	}
 
 	// NO RETRY — value operation
-	return c.callJSON(ctx, "sendGift", req, nil, extractChatID(tg.ChatID(req.UserID)))
+	return c.callJSON(ctx, "sendGift", req, nil, extractChatID(target))
+}
+
+// GiftPremiumSubscription gifts a Telegram Premium subscription to a user,
+// paid in Stars from the bot's balance.
+// NO RETRY — value operation to prevent double-payment.
+func (c *Client) GiftPremiumSubscription(ctx context.Context, req GiftPremiumSubscriptionRequest) error {
+	if req.UserID <= 0 {
+		return tg.NewValidationError("user_id", "must be positive")
+	}
+	if !slices.Contains(premiumSubscriptionMonths, req.MonthCount) {
+		return tg.NewValidationError("month_count", "must be 3, 6 or 12")
+	}
+	if err := validateGiftText(req.Text); err != nil {
+		return err
+	}
+
+	// NO RETRY — value operation
+	return c.callJSON(ctx, "giftPremiumSubscription", req, nil, extractChatID(tg.ChatID(req.UserID)))
 }
 
 // GetAvailableGifts returns the list of gifts that can be sent by the bot.
 func (c *Client) GetAvailableGifts(ctx context.Context) (*tg.Gifts, error) {
-	var result tg.Gifts
-	if err := c.callJSON(ctx, "getAvailableGifts", struct{}{}, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.Gifts](c, ctx, "getAvailableGifts", struct{}{})
 }
 
 // TransferGift transfers an owned gift to another user.
@@ -109,3 +160,10 @@ func (c *Client) ConvertGiftToStars(ctx context.Context, req ConvertGiftToStarsR
 	// NO RETRY — value operation
 	return c.callJSON(ctx, "convertGiftToStars", req, nil)
 }
+
+func validateGiftText(text string) error {
+	if n := utf8.RuneCountInString(text); n > MaxGiftTextLength {
+		return tg.NewValidationError("text", fmt.Sprintf("must be at most %d characters, got %d", MaxGiftTextLength, n))
+	}
+	return nil
+}
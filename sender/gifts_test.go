@@ -3,6 +3,7 @@ package sender_test
 import (
 	"context"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -38,7 +39,9 @@ func TestSendGift_Validation(t *testing.T) {
 		want string
 	}{
 		{"missing user_id", sender.SendGiftRequest{GiftID: "g"}, "user_id"},
+		{"user_id and chat_id", sender.SendGiftRequest{UserID: 1, ChatID: int64(-100), GiftID: "g"}, "only one"},
 		{"missing gift_id", sender.SendGiftRequest{UserID: 1}, "gift_id"},
+		{"text too long", sender.SendGiftRequest{UserID: 1, GiftID: "g", Text: strings.Repeat("a", sender.MaxGiftTextLength+1)}, "text"},
 	}
 
 	for _, tt := range tests {
@@ -50,6 +53,58 @@ func TestSendGift_Validation(t *testing.T) {
 	}
 }
 
+func TestSendGift_ToChat(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendGift", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, true)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+	err := client.SendGift(context.Background(), sender.SendGiftRequest{ChatID: "@channel", GiftID: "gift_abc"})
+	require.NoError(t, err)
+
+	cap := server.LastCapture()
+	cap.AssertJSONField(t, "chat_id", "@channel")
+	cap.AssertJSONFieldAbsent(t, "user_id")
+}
+
+// ==================== GiftPremiumSubscription ====================
+
+func TestGiftPremiumSubscription(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/giftPremiumSubscription", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, true)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+	err := client.GiftPremiumSubscription(context.Background(), sender.GiftPremiumSubscriptionRequest{
+		UserID: 456, MonthCount: 6, StarCount: 1500,
+	})
+	require.NoError(t, err)
+	server.LastCapture().AssertJSONField(t, "month_count", float64(6))
+}
+
+func TestGiftPremiumSubscription_Validation(t *testing.T) {
+	client := testutil.NewTestClient(t, "http://127.0.0.1:0")
+
+	tests := []struct {
+		name string
+		req  sender.GiftPremiumSubscriptionRequest
+		want string
+	}{
+		{"missing user_id", sender.GiftPremiumSubscriptionRequest{MonthCount: 3, StarCount: 1000}, "user_id"},
+		{"bad duration", sender.GiftPremiumSubscriptionRequest{UserID: 1, MonthCount: 1, StarCount: 1000}, "month_count"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.GiftPremiumSubscription(context.Background(), tt.req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
 // ==================== GetAvailableGifts ====================
 
 func TestGetAvailableGifts(t *testing.T) {
@@ -0,0 +1,179 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ================== Hedging & Retry Budget ==================
+//
+// A hedged request sends a second attempt when the first has not answered
+// within HedgeConfig.Delay and takes whichever succeeds first. A retry
+// budget caps retries and hedges at a share of recent requests, so an
+// outage does not multiply the load on Telegram.
+
+// ErrRetryBudgetExhausted is returned, wrapping the last error, when a
+// retry was denied by the retry budget.
+var ErrRetryBudgetExhausted = errors.New("galigo: retry budget exhausted")
+
+// HedgeConfig enables hedged requests.
+type HedgeConfig struct {
+	// Delay is how long the first attempt may take before a second one is
+	// sent, typically the p95 latency. Zero disables hedging.
+	//
+	// Only idempotent methods (get*, edit*, delete*, set*, answer*) are
+	// hedged. send* methods are not, as both attempts could deliver, and
+	// uploads are not, as their readers cannot be replayed.
+	Delay time.Duration
+}
+
+// RetryBudget limits retries, including hedges, to a share of requests.
+type RetryBudget struct {
+	// Ratio is the maximum number of retries per request in a window,
+	// e.g. 0.1 for 10%.
+	Ratio float64
+	// MinRetries are always allowed per window, so that retries work at
+	// low traffic (default 10).
+	MinRetries int
+	// Window is the accounting period (default 1 minute).
+	Window time.Duration
+}
+
+// WithHedging enables hedged requests. See HedgeConfig.
+func WithHedging(cfg HedgeConfig) Option {
+	return func(c *Client) {
+		if cfg.Delay > 0 {
+			c.hedge = &cfg
+		}
+	}
+}
+
+// WithRetryBudget caps retries and hedges at budget.Ratio of the requests
+// in each window; denied retries fail with ErrRetryBudgetExhausted.
+func WithRetryBudget(budget RetryBudget) Option {
+	return func(c *Client) {
+		if budget.MinRetries <= 0 {
+			budget.MinRetries = 10
+		}
+		if budget.Window <= 0 {
+			budget.Window = time.Minute
+		}
+		c.retryBudget = &retryBudget{cfg: budget}
+	}
+}
+
+// retryBudget counts requests and retries in fixed windows.
+type retryBudget struct {
+	cfg RetryBudget
+
+	mu       sync.Mutex
+	start    time.Time
+	requests int
+	retries  int
+}
+
+func (b *retryBudget) roll(now time.Time) {
+	if now.Sub(b.start) >= b.cfg.Window {
+		b.start = now
+		b.requests = 0
+		b.retries = 0
+	}
+}
+
+// request records a request made at now.
+func (b *retryBudget) request(now time.Time) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.roll(now)
+	b.requests++
+}
+
+// allowRetry records and reports whether a retry at now is within budget.
+func (b *retryBudget) allowRetry(now time.Time) bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.roll(now)
+	if b.retries >= b.cfg.MinRetries && float64(b.retries+1) > b.cfg.Ratio*float64(b.requests) {
+		return false
+	}
+	b.retries++
+	return true
+}
+
+// hedges reports whether method may be hedged.
+func (c *Client) hedges(method string) bool {
+	if c.hedge == nil {
+		return false
+	}
+	// Uploads cannot be replayed; polling and webhook calls are left alone
+	if ClassOf(method) == ClassMedia || method == "getUpdates" || method == "getWebhookInfo" ||
+		strings.HasSuffix(method, "Webhook") {
+		return false
+	}
+	if method == "sendChatAction" {
+		return true
+	}
+	for _, prefix := range []string{"get", "edit", "delete", "set", "answer"} {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+type hedgeResult struct {
+	resp *apiResponse
+	err  error
+}
+
+// doHedged performs doRequest, hedged when the method allows it. The first
+// success wins and cancels the other attempt; an error is returned only
+// when every attempt failed.
+func (c *Client) doHedged(ctx context.Context, method string, payload any) (*apiResponse, error) {
+	if !c.hedges(method) {
+		return c.doRequest(ctx, method, payload)
+	}
+
+	attemptCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgeResult, 2)
+	run := func() {
+		resp, err := c.doRequest(attemptCtx, method, payload)
+		results <- hedgeResult{resp, err}
+	}
+	go run()
+
+	timer := c.clock.NewTimer(c.hedge.Delay)
+	defer timer.Stop()
+
+	pending := 1
+	var firstErr error
+	for pending > 0 {
+		select {
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				return r.resp, nil
+			}
+			if firstErr == nil {
+				firstErr = r.err
+			}
+		case <-timer.C():
+			if c.retryBudget.allowRetry(c.clock.Now()) {
+				pending++
+				go run()
+			}
+		}
+	}
+	return nil, firstErr
+}
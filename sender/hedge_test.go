@@ -0,0 +1,120 @@
+package sender_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+)
+
+// slowFirst delays the first request by delay, or until it is cancelled.
+func slowFirst(delay time.Duration, reply func(w http.ResponseWriter, n int32)) http.HandlerFunc {
+	var calls atomic.Int32
+	return func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		if n == 1 {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		reply(w, n)
+	}
+}
+
+func TestHedging_IdempotentMethod(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getMe", slowFirst(5*time.Second, func(w http.ResponseWriter, _ int32) {
+		testutil.ReplyUser(w)
+	}))
+
+	client := testutil.NewTestClient(t, server.BaseURL(),
+		sender.WithHedging(sender.HedgeConfig{Delay: 20 * time.Millisecond}))
+
+	start := time.Now()
+	_, err := client.GetMe(context.Background())
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.Equal(t, 2, server.CaptureCount())
+}
+
+func TestHedging_SendsNotHedged(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", slowFirst(100*time.Millisecond, func(w http.ResponseWriter, n int32) {
+		testutil.ReplyMessage(w, int(n))
+	}))
+
+	client := testutil.NewTestClient(t, server.BaseURL(),
+		sender.WithHedging(sender.HedgeConfig{Delay: 10 * time.Millisecond}))
+
+	msg, err := client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, msg.MessageID)
+	assert.Equal(t, 1, server.CaptureCount())
+}
+
+func TestRetryBudget_DeniesRetries(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyServerError(w, 502, "Bad Gateway")
+	})
+
+	sleeper := &testutil.FakeSleeper{}
+	client := testutil.NewRetryTestClient(t, server.BaseURL(), sleeper,
+		sender.WithRetries(3),
+		sender.WithRetryBudget(sender.RetryBudget{Ratio: 0, MinRetries: 1}))
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"})
+	require.ErrorIs(t, err, sender.ErrRetryBudgetExhausted)
+	assert.Equal(t, 2, server.CaptureCount(), "one retry within the minimum")
+	assert.Equal(t, 1, sleeper.CallCount())
+
+	server.ResetCaptures()
+	_, err = client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"})
+	require.ErrorIs(t, err, sender.ErrRetryBudgetExhausted)
+	assert.Equal(t, 1, server.CaptureCount(), "budget spent for this window")
+}
+
+func TestRetryBudget_AllowsRatio(t *testing.T) {
+	var calls atomic.Int32
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1)%2 == 1 {
+			testutil.ReplyServerError(w, 502, "Bad Gateway")
+			return
+		}
+		testutil.ReplyMessage(w, 1)
+	})
+
+	client := testutil.NewRetryTestClient(t, server.BaseURL(), &testutil.FakeSleeper{},
+		sender.WithRetries(1),
+		sender.WithRetryBudget(sender.RetryBudget{Ratio: 1, MinRetries: 1}))
+
+	for range 5 {
+		_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"})
+		require.NoError(t, err)
+	}
+}
+
+func TestRetryBudget_CountsCallOnce(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyServerError(w, 502, "Bad Gateway")
+	})
+
+	client := testutil.NewRetryTestClient(t, server.BaseURL(), &testutil.FakeSleeper{},
+		sender.WithRetries(3),
+		sender.WithRetryBudget(sender.RetryBudget{Ratio: 1, MinRetries: 1}))
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"})
+	require.ErrorIs(t, err, sender.ErrRetryBudgetExhausted)
+	assert.Equal(t, 2, server.CaptureCount(), "retries do not earn budget for further retries")
+}
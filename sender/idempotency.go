@@ -0,0 +1,202 @@
+package sender
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ================== Idempotent Sends ==================
+//
+// Telegram has no idempotency keys: a send repeated after a lost response
+// delivers the message twice. With WithIdempotency the client records the
+// result of each successful send under a key, and a later send with the
+// same key returns the recorded message instead of sending again. That
+// covers re-runs of a job, redelivered webhook updates and callers retrying
+// a failed SendMessage.
+//
+// Before sending, the key is marked pending. A send whose response never
+// arrives (a timeout, a dropped connection) may or may not have been
+// delivered, so its key stays pending and later sends with it fail with
+// ErrIdempotencyPending until the record expires, rather than risk a
+// duplicate. Sends Telegram rejected, or that never left, clear the mark.
+
+// ErrIdempotencyPending is returned for a send whose idempotency key
+// belongs to an earlier send with an unknown outcome.
+var ErrIdempotencyPending = errors.New("galigo: earlier send with this idempotency key has an unknown outcome")
+
+// Markers stored in place of a result. Results are JSON, which never
+// starts with a NUL byte.
+const (
+	idempotencyPendingMark = "\x00pending"
+	idempotencyFailedMark  = "\x00failed"
+)
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey makes the send made with ctx idempotent under key:
+// while the record lives, another send of the same method with the same
+// key returns the first result. Keys are scoped to the bot and the method.
+// It has no effect unless the client uses WithIdempotency.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// IdempotencyConfig configures idempotent sends.
+type IdempotencyConfig struct {
+	// Store keeps the recorded results, e.g. NewMemoryCache, or a shared
+	// store for several instances of a bot.
+	Store Cache
+	// TTL is how long results are remembered (default 24h).
+	TTL time.Duration
+	// DeriveKeys gives sends without WithIdempotencyKey a key derived from
+	// the method and request, so that identical sends within TTL are sent
+	// only once. Leave it off if the bot legitimately repeats messages.
+	DeriveKeys bool
+}
+
+// WithIdempotency enables idempotent sends. See IdempotencyConfig.
+func WithIdempotency(cfg IdempotencyConfig) Option {
+	return func(c *Client) {
+		if cfg.Store == nil {
+			c.idempotency = nil
+			return
+		}
+		if cfg.TTL <= 0 {
+			cfg.TTL = 24 * time.Hour
+		}
+		c.idempotency = &idempotencyLayer{cfg: cfg, inflight: make(map[string]chan struct{})}
+	}
+}
+
+type idempotencyLayer struct {
+	cfg IdempotencyConfig
+
+	mu       sync.Mutex
+	inflight map[string]chan struct{}
+}
+
+// isSendMethod reports whether method delivers a message and so should
+// not be repeated.
+func isSendMethod(method string) bool {
+	if method == "sendChatAction" {
+		return false
+	}
+	return strings.HasPrefix(method, "send") ||
+		strings.HasPrefix(method, "forward") ||
+		strings.HasPrefix(method, "copy")
+}
+
+// idempotencyBegin returns the recorded result of an idempotent send, or
+// the store key to record a fresh result under, and a release function the
+// caller must call when done. Concurrent sends with the same key wait for
+// the first one. It fails with ErrIdempotencyPending if an earlier send
+// with the key has an unknown outcome, or with ctx's error if ctx ends
+// while waiting.
+func (c *Client) idempotencyBegin(ctx context.Context, method string, payload any) (*apiResponse, string, func(), error) {
+	noop := func() {}
+	if c.idempotency == nil || c.dryRun != nil || !isSendMethod(method) {
+		return nil, "", noop, nil
+	}
+	key, _ := ctx.Value(idempotencyKey{}).(string)
+	if key == "" {
+		if !c.idempotency.cfg.DeriveKeys {
+			return nil, "", noop, nil
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return nil, "", noop, nil
+		}
+		sum := sha256.Sum256(body)
+		key = "derived:" + hex.EncodeToString(sum[:])
+	}
+	storeKey := "galigo:idem:" + botIDFromToken(c.config.Token.Value()) + ":" + method + ":" + key
+
+	l := c.idempotency
+	for {
+		l.mu.Lock()
+		wait, busy := l.inflight[storeKey]
+		if !busy {
+			done := make(chan struct{})
+			l.inflight[storeKey] = done
+			l.mu.Unlock()
+			release := func() {
+				l.mu.Lock()
+				delete(l.inflight, storeKey)
+				l.mu.Unlock()
+				close(done)
+			}
+			raw, ok, err := l.cfg.Store.Get(ctx, storeKey)
+			if err != nil {
+				c.logger.Debug("idempotency store get failed", "method", method, "error", err)
+			}
+			switch {
+			case !ok || string(raw) == idempotencyFailedMark:
+				return nil, storeKey, release, nil
+			case string(raw) == idempotencyPendingMark:
+				release()
+				return nil, "", noop, ErrIdempotencyPending
+			}
+			release()
+			return &apiResponse{OK: true, Result: raw}, "", noop, nil
+		}
+		l.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return nil, "", noop, ctx.Err()
+		}
+	}
+}
+
+// idempotencyPending marks storeKey as being sent.
+func (c *Client) idempotencyPending(ctx context.Context, method, storeKey string) {
+	if storeKey == "" {
+		return
+	}
+	c.idempotencySet(ctx, method, storeKey, []byte(idempotencyPendingMark))
+}
+
+// idempotencyRecord stores the outcome of a send: its result, a cleared
+// mark if it was certainly not delivered, or nothing (leaving the pending
+// mark) if that is unknown.
+func (c *Client) idempotencyRecord(ctx context.Context, method, storeKey string, resp *apiResponse, err error) {
+	if storeKey == "" {
+		return
+	}
+	switch {
+	case err == nil:
+		c.idempotencySet(ctx, method, storeKey, resp.Result)
+	case !sendOutcomeUnknown(err):
+		c.idempotencySet(ctx, method, storeKey, []byte(idempotencyFailedMark))
+	default:
+		c.logger.Warn("send outcome unknown, keeping its idempotency key pending", "method", method, "error", err)
+	}
+}
+
+func (c *Client) idempotencySet(ctx context.Context, method, storeKey string, value []byte) {
+	if err := c.idempotency.cfg.Store.Set(ctx, storeKey, value, c.idempotency.cfg.TTL); err != nil {
+		c.logger.Warn("idempotency store set failed", "method", method, "error", err)
+	}
+}
+
+// sendOutcomeUnknown reports whether a send that failed with err may
+// still have been delivered: it is false when Telegram answered, or the
+// request never left (open breaker, failed dial).
+func sendOutcomeUnknown(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return false
+	}
+	return true
+}
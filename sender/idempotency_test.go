@@ -0,0 +1,162 @@
+package sender_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+)
+
+func newIdempotencyServer(t *testing.T, delay time.Duration) *testutil.MockTelegramServer {
+	t.Helper()
+	var ids atomic.Int32
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		testutil.ReplyMessage(w, int(ids.Add(1)))
+	})
+	return server
+}
+
+func TestIdempotency_SameKeySendsOnce(t *testing.T) {
+	server := newIdempotencyServer(t, 0)
+	client := testutil.NewTestClient(t, server.BaseURL(),
+		sender.WithIdempotency(sender.IdempotencyConfig{Store: sender.NewMemoryCache(0)}))
+	req := sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Order #42 confirmed"}
+
+	ctx := sender.WithIdempotencyKey(context.Background(), "order-42")
+	first, err := client.SendMessage(ctx, req)
+	require.NoError(t, err)
+	second, err := client.SendMessage(ctx, req)
+	require.NoError(t, err)
+
+	assert.Equal(t, first.MessageID, second.MessageID)
+	assert.Equal(t, 1, server.CaptureCount())
+
+	_, err = client.SendMessage(sender.WithIdempotencyKey(context.Background(), "order-43"), req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.CaptureCount())
+}
+
+func TestIdempotency_NoKeyWithoutDerivation(t *testing.T) {
+	server := newIdempotencyServer(t, 0)
+	client := testutil.NewTestClient(t, server.BaseURL(),
+		sender.WithIdempotency(sender.IdempotencyConfig{Store: sender.NewMemoryCache(0)}))
+	req := sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"}
+
+	for range 2 {
+		_, err := client.SendMessage(context.Background(), req)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, server.CaptureCount())
+}
+
+func TestIdempotency_DerivedKeys(t *testing.T) {
+	server := newIdempotencyServer(t, 0)
+	client := testutil.NewTestClient(t, server.BaseURL(),
+		sender.WithIdempotency(sender.IdempotencyConfig{Store: sender.NewMemoryCache(0), DeriveKeys: true}))
+
+	for range 2 {
+		_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, server.CaptureCount())
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Bye"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.CaptureCount())
+}
+
+func TestIdempotency_ConcurrentSendsWait(t *testing.T) {
+	server := newIdempotencyServer(t, 50*time.Millisecond)
+	client := testutil.NewTestClient(t, server.BaseURL(),
+		sender.WithIdempotency(sender.IdempotencyConfig{Store: sender.NewMemoryCache(0)}))
+	ctx := sender.WithIdempotencyKey(context.Background(), "once")
+
+	var wg sync.WaitGroup
+	ids := make([]int, 5)
+	for i := range ids {
+		wg.Go(func() {
+			msg, err := client.SendMessage(ctx, sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"})
+			if assert.NoError(t, err) {
+				ids[i] = msg.MessageID
+			}
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, server.CaptureCount())
+	for _, id := range ids {
+		assert.Equal(t, 1, id)
+	}
+}
+
+func TestIdempotency_FailedSendNotRecorded(t *testing.T) {
+	var calls atomic.Int32
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			testutil.ReplyServerError(w, 502, "Bad Gateway")
+			return
+		}
+		testutil.ReplyMessage(w, 7)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL(),
+		sender.WithIdempotency(sender.IdempotencyConfig{Store: sender.NewMemoryCache(0)}))
+	ctx := sender.WithIdempotencyKey(context.Background(), "k")
+	req := sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"}
+
+	_, err := client.SendMessage(ctx, req)
+	require.Error(t, err)
+	msg, err := client.SendMessage(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 7, msg.MessageID)
+	assert.Equal(t, 2, server.CaptureCount())
+}
+
+func TestIdempotency_WaitEndsWithContext(t *testing.T) {
+	server := newIdempotencyServer(t, 200*time.Millisecond)
+	client := testutil.NewTestClient(t, server.BaseURL(),
+		sender.WithIdempotency(sender.IdempotencyConfig{Store: sender.NewMemoryCache(0)}))
+	ctx := sender.WithIdempotencyKey(context.Background(), "slow")
+	req := sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := client.SendMessage(ctx, req)
+		assert.NoError(t, err)
+	}()
+	require.Eventually(t, func() bool { return server.CaptureCount() == 1 }, time.Second, time.Millisecond)
+
+	waitCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err := client.SendMessage(waitCtx, req)
+	require.ErrorIs(t, err, context.Canceled)
+	<-done
+}
+
+func TestIdempotency_LostResponseStaysPending(t *testing.T) {
+	server := newIdempotencyServer(t, 200*time.Millisecond)
+	client := testutil.NewTestClient(t, server.BaseURL(),
+		sender.WithRetries(0),
+		sender.WithIdempotency(sender.IdempotencyConfig{Store: sender.NewMemoryCache(0)}))
+	ctx := sender.WithIdempotencyKey(context.Background(), "lost")
+	req := sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "Hello"}
+
+	_, err := client.SendMessage(sender.WithRequestTimeout(ctx, 20*time.Millisecond), req)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, sender.ErrIdempotencyPending)
+
+	_, err = client.SendMessage(ctx, req)
+	require.ErrorIs(t, err, sender.ErrIdempotencyPending)
+	assert.Equal(t, 1, server.CaptureCount(), "the unknown send is not repeated")
+}
@@ -2,7 +2,10 @@ package sender
 
 import (
 	"context"
+	"fmt"
 	"regexp"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/prilive-com/galigo/tg"
 )
@@ -85,6 +88,17 @@ type GetMyDefaultAdministratorRightsRequest struct {
 	ForChannels bool `json:"for_channels,omitempty"`
 }
 
+// SetChatMenuButtonRequest represents a setChatMenuButton request.
+type SetChatMenuButtonRequest struct {
+	ChatID     int64          `json:"chat_id,omitempty"`
+	MenuButton *tg.MenuButton `json:"menu_button,omitempty"`
+}
+
+// GetChatMenuButtonRequest represents a getChatMenuButton request.
+type GetChatMenuButtonRequest struct {
+	ChatID int64 `json:"chat_id,omitempty"`
+}
+
 // ================== Bot Commands ==================
 
 var commandRegex = regexp.MustCompile(`^[a-z0-9_]+$`)
@@ -144,7 +158,7 @@ func (c *Client) DeleteMyCommands(ctx context.Context, opts ...BotCommandOption)
 // SetMyName sets the bot's name for the specified language.
 // Pass empty string to remove the dedicated name for that language.
 func (c *Client) SetMyName(ctx context.Context, name string, opts ...LanguageOption) error {
-	if len(name) > 64 {
+	if utf8.RuneCountInString(name) > 64 {
 		return tg.NewValidationError("name", "must be at most 64 characters")
 	}
 
@@ -163,16 +177,12 @@ func (c *Client) GetMyName(ctx context.Context, opts ...LanguageOption) (*tg.Bot
 		opt.applyLanguage(&req.LanguageCode)
 	}
 
-	var result tg.BotName
-	if err := c.callJSON(ctx, "getMyName", req, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.BotName](c, ctx, "getMyName", req)
 }
 
 // SetMyDescription sets the bot's description (shown in empty chat).
 func (c *Client) SetMyDescription(ctx context.Context, description string, opts ...LanguageOption) error {
-	if len(description) > 512 {
+	if utf8.RuneCountInString(description) > 512 {
 		return tg.NewValidationError("description", "must be at most 512 characters")
 	}
 
@@ -191,16 +201,12 @@ func (c *Client) GetMyDescription(ctx context.Context, opts ...LanguageOption) (
 		opt.applyLanguage(&req.LanguageCode)
 	}
 
-	var result tg.BotDescription
-	if err := c.callJSON(ctx, "getMyDescription", req, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.BotDescription](c, ctx, "getMyDescription", req)
 }
 
 // SetMyShortDescription sets the bot's short description (shown in profile/search).
 func (c *Client) SetMyShortDescription(ctx context.Context, shortDescription string, opts ...LanguageOption) error {
-	if len(shortDescription) > 120 {
+	if utf8.RuneCountInString(shortDescription) > 120 {
 		return tg.NewValidationError("short_description", "must be at most 120 characters")
 	}
 
@@ -219,11 +225,7 @@ func (c *Client) GetMyShortDescription(ctx context.Context, opts ...LanguageOpti
 		opt.applyLanguage(&req.LanguageCode)
 	}
 
-	var result tg.BotShortDescription
-	if err := c.callJSON(ctx, "getMyShortDescription", req, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.BotShortDescription](c, ctx, "getMyShortDescription", req)
 }
 
 // SetMyProfilePhoto sets the bot's profile photo.
@@ -262,11 +264,53 @@ func (c *Client) SetMyDefaultAdministratorRights(ctx context.Context, opts ...Ad
 func (c *Client) GetMyDefaultAdministratorRights(ctx context.Context, forChannels bool) (*tg.ChatAdministratorRights, error) {
 	req := GetMyDefaultAdministratorRightsRequest{ForChannels: forChannels}
 
-	var result tg.ChatAdministratorRights
-	if err := c.callJSON(ctx, "getMyDefaultAdministratorRights", req, &result); err != nil {
-		return nil, err
+	return call[tg.ChatAdministratorRights](c, ctx, "getMyDefaultAdministratorRights", req)
+}
+
+// ================== Menu Button ==================
+
+// SetChatMenuButton sets the bot's menu button, by default for all private
+// chats; MenuButtonForChat limits it to one chat. Pass
+// tg.MenuButtonDefault() to reset.
+func (c *Client) SetChatMenuButton(ctx context.Context, button tg.MenuButton, opts ...MenuButtonOption) error {
+	if err := validateMenuButton(button); err != nil {
+		return err
 	}
-	return &result, nil
+	req := SetChatMenuButtonRequest{MenuButton: &button}
+	for _, opt := range opts {
+		opt.applyMenuButtonChat(&req.ChatID)
+	}
+
+	return c.callJSON(ctx, "setChatMenuButton", req, nil)
+}
+
+// GetChatMenuButton returns the bot's default menu button, or with
+// MenuButtonForChat the one of a private chat.
+func (c *Client) GetChatMenuButton(ctx context.Context, opts ...MenuButtonOption) (*tg.MenuButton, error) {
+	req := GetChatMenuButtonRequest{}
+	for _, opt := range opts {
+		opt.applyMenuButtonChat(&req.ChatID)
+	}
+
+	return call[tg.MenuButton](c, ctx, "getChatMenuButton", req)
+}
+
+func validateMenuButton(b tg.MenuButton) error {
+	switch b.Type {
+	case tg.MenuButtonTypeDefault, tg.MenuButtonTypeCommands:
+		return nil
+	case tg.MenuButtonTypeWebApp:
+		if b.Text == "" {
+			return tg.NewValidationError("menu_button.text", "required for web_app")
+		}
+		if b.WebApp == nil || !strings.HasPrefix(b.WebApp.URL, "https://") {
+			return tg.NewValidationError("menu_button.web_app.url", "must be an HTTPS URL")
+		}
+		return nil
+	case "":
+		return tg.NewValidationError("menu_button.type", "required")
+	}
+	return tg.NewValidationError("menu_button.type", fmt.Sprintf("unknown type %q", b.Type))
 }
 
 // ================== Options ==================
@@ -283,19 +327,27 @@ type botCommandOption struct {
 	languageCode string
 }
 
+// apply sets only the fields o carries, so WithCommandScope and
+// WithCommandLanguage can be combined.
+func (o botCommandOption) apply(scope **tg.BotCommandScope, languageCode *string) {
+	if o.scope != nil {
+		*scope = o.scope
+	}
+	if o.languageCode != "" {
+		*languageCode = o.languageCode
+	}
+}
+
 func (o botCommandOption) applyToSetMyCommands(r *SetMyCommandsRequest) {
-	r.Scope = o.scope
-	r.LanguageCode = o.languageCode
+	o.apply(&r.Scope, &r.LanguageCode)
 }
 
 func (o botCommandOption) applyToGetMyCommands(r *GetMyCommandsRequest) {
-	r.Scope = o.scope
-	r.LanguageCode = o.languageCode
+	o.apply(&r.Scope, &r.LanguageCode)
 }
 
 func (o botCommandOption) applyToDeleteMyCommands(r *DeleteMyCommandsRequest) {
-	r.Scope = o.scope
-	r.LanguageCode = o.languageCode
+	o.apply(&r.Scope, &r.LanguageCode)
 }
 
 // WithCommandScope sets the scope for bot commands.
@@ -357,6 +409,21 @@ func WithAdminRightsForChannels(rights tg.ChatAdministratorRights) AdminRightsOp
 	return adminRightsOption{rights: &rights, forChannels: true}
 }
 
+// MenuButtonOption configures menu button methods.
+type MenuButtonOption interface {
+	applyMenuButtonChat(*int64)
+}
+
+type menuButtonOption int64
+
+func (o menuButtonOption) applyMenuButtonChat(id *int64) { *id = int64(o) }
+
+// MenuButtonForChat targets the menu button of one private chat instead of
+// the default.
+func MenuButtonForChat(chatID int64) MenuButtonOption {
+	return menuButtonOption(chatID)
+}
+
 // ProfilePhotoOption configures profile photo methods.
 type ProfilePhotoOption interface {
 	applyToSetProfilePhoto(*SetMyProfilePhotoRequest)
@@ -378,3 +378,82 @@ func TestRemoveMyProfilePhoto_Personal(t *testing.T) {
 	cap := server.LastCapture()
 	cap.AssertJSONField(t, "is_personal", true)
 }
+
+// ==================== Menu Button ====================
+
+func TestSetChatMenuButton(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/setChatMenuButton", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, true)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+	err := client.SetChatMenuButton(context.Background(), tg.MenuButtonWebApp("Shop", "https://example.com/app"), sender.MenuButtonForChat(42))
+	require.NoError(t, err)
+
+	cap := server.LastCapture()
+	var req sender.SetChatMenuButtonRequest
+	cap.BodyJSON(t, &req)
+	assert.Equal(t, int64(42), req.ChatID)
+	assert.Equal(t, tg.MenuButtonWebApp("Shop", "https://example.com/app"), *req.MenuButton)
+
+	require.NoError(t, client.SetChatMenuButton(context.Background(), tg.MenuButtonDefault()))
+	server.LastCapture().AssertJSONFieldAbsent(t, "chat_id")
+}
+
+func TestSetChatMenuButton_Validation(t *testing.T) {
+	client := testutil.NewTestClient(t, "http://127.0.0.1:0")
+
+	tests := []struct {
+		name   string
+		button tg.MenuButton
+		field  string
+	}{
+		{"missing type", tg.MenuButton{}, "menu_button.type"},
+		{"unknown type", tg.MenuButton{Type: "inline"}, "menu_button.type"},
+		{"web app without text", tg.MenuButtonWebApp("", "https://example.com"), "menu_button.text"},
+		{"web app over http", tg.MenuButtonWebApp("App", "http://example.com"), "menu_button.web_app.url"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.SetChatMenuButton(context.Background(), tt.button)
+			var vErr *tg.ValidationError
+			require.ErrorAs(t, err, &vErr)
+			assert.Equal(t, tt.field, vErr.Field)
+		})
+	}
+}
+
+func TestGetChatMenuButton(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getChatMenuButton", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, map[string]any{"type": "web_app", "text": "Shop", "web_app": map[string]any{"url": "https://example.com/app"}})
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+	button, err := client.GetChatMenuButton(context.Background(), sender.MenuButtonForChat(42))
+	require.NoError(t, err)
+	assert.Equal(t, tg.MenuButtonWebApp("Shop", "https://example.com/app"), *button)
+	server.LastCapture().AssertJSONField(t, "chat_id", float64(42))
+}
+
+func TestBotCommandOptions_Combine(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/setMyCommands", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, true)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+	err := client.SetMyCommands(context.Background(), []tg.BotCommand{
+		{Command: "start", Description: "Start the bot"},
+	}, sender.WithCommandScope(tg.BotCommandScopeAllPrivateChats()), sender.WithCommandLanguage("de"))
+	require.NoError(t, err)
+
+	var body struct {
+		Scope        tg.BotCommandScope `json:"scope"`
+		LanguageCode string             `json:"language_code"`
+	}
+	server.LastCapture().BodyJSON(t, &body)
+	assert.Equal(t, "all_private_chats", body.Scope.Type)
+	assert.Equal(t, "de", body.LanguageCode)
+}
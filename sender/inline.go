@@ -64,11 +64,7 @@ func (c *Client) AnswerWebAppQuery(ctx context.Context, req AnswerWebAppQueryReq
 		return nil, tg.NewValidationError("result", "required")
 	}
 
-	var result tg.SentWebAppMessage
-	if err := c.callJSON(ctx, "answerWebAppQuery", req, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.SentWebAppMessage](c, ctx, "answerWebAppQuery", req)
 }
 
 // SendChecklist sends a checklist message.
@@ -83,11 +79,7 @@ func (c *Client) SendChecklist(ctx context.Context, req SendChecklistRequest) (*
 		return nil, tg.NewValidationError("checklist.tasks", "at least one task required")
 	}
 
-	var result tg.Message
-	if err := c.callJSON(ctx, "sendChecklist", req, &result, extractChatID(req.ChatID)); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.Message](c, ctx, "sendChecklist", req, extractChatID(req.ChatID))
 }
 
 // EditMessageChecklist edits a checklist message.
@@ -99,9 +91,5 @@ func (c *Client) EditMessageChecklist(ctx context.Context, req EditMessageCheckl
 		return nil, tg.NewValidationError("message_id", "must be positive")
 	}
 
-	var result tg.Message
-	if err := c.callJSON(ctx, "editMessageChecklist", req, &result, extractChatID(req.ChatID)); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.Message](c, ctx, "editMessageChecklist", req, extractChatID(req.ChatID))
 }
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"os"
+	"path/filepath"
+	"sync"
 )
 
 const (
@@ -16,7 +19,12 @@ const (
 )
 
 // InputFile represents a file to upload or reference.
-// Use one of the constructors: FromReader, FromFileID, FromURL, FromBytes.
+// Use one of the constructors: FromFile, FromBytes, FromReaderAt,
+// FromReader, FromFileID, FromURL.
+//
+// Uploads built by FromFile, FromBytes and FromReaderAt (or with Source
+// set) are retry-safe: every attempt of the request gets a fresh reader.
+// FromReader is single-use unless made Retryable.
 type InputFile struct {
 	// FileID references an existing file on Telegram servers.
 	FileID string
@@ -28,17 +36,25 @@ type InputFile struct {
 	// Content is streamed directly - not buffered in memory.
 	// WARNING: io.Reader can only be consumed once. If the request is retried
 	// (e.g., on 429/5xx), the retry will send an empty file. Prefer FromBytes
-	// for retry-safe uploads, or set Source instead.
+	// for retry-safe uploads, set Source instead, or call Retryable.
 	Reader io.Reader
 
 	// Source is a factory that returns a fresh io.Reader for each attempt.
 	// When set, this takes priority over Reader for multipart uploads,
-	// making the request retry-safe.
+	// making the request retry-safe. If the returned reader implements
+	// io.Closer, it is closed once the attempt has streamed it.
 	Source func() io.Reader
 
 	// FileName is required when Reader or Source is set.
 	FileName string
 
+	// Size is the content length in bytes, if known (0 = unknown). It is
+	// reported as the total to Progress.
+	Size int64
+
+	// Progress, if set, is called as the content is streamed to Telegram.
+	Progress ProgressFunc
+
 	// MediaType is used for media groups (e.g., "photo", "video").
 	MediaType string
 
@@ -47,16 +63,30 @@ type InputFile struct {
 
 	// ParseMode for caption (HTML, Markdown, MarkdownV2).
 	ParseMode string
+
+	// Thumbnail and Cover for media group items (video, audio, document).
+	// Uploads are sent as attach:// parts.
+	Thumbnail *InputFile
+	Cover     *InputFile
 }
 
+// ProgressFunc reports upload progress: sent bytes of total so far. total is
+// -1 when the size is unknown. It is called from the goroutine streaming the
+// request body, after every chunk; a retried request reports from zero again.
+// To cancel an upload, cancel the request's context.
+type ProgressFunc func(sent, total int64)
+
 // FromReader creates an InputFile from an io.Reader.
 // The reader is streamed directly - not buffered in memory.
+// Size is filled in when r reports it (*bytes.Reader, *strings.Reader,
+// *bytes.Buffer, *os.File).
 // WARNING: Not retry-safe. If the request is retried, the reader will be at EOF.
-// Use FromBytes for retry-safe uploads from in-memory data.
+// Use FromBytes for retry-safe uploads from in-memory data, or Retryable.
 func FromReader(r io.Reader, filename string) InputFile {
 	return InputFile{
 		Reader:   r,
 		FileName: filename,
+		Size:     readerSize(r),
 	}
 }
 
@@ -68,6 +98,38 @@ func FromBytes(data []byte, filename string) InputFile {
 			return bytes.NewReader(data)
 		},
 		FileName: filename,
+		Size:     int64(len(data)),
+	}
+}
+
+// FromFile creates a retry-safe InputFile that streams the file at path.
+// The file is opened when an attempt starts sending it and closed when the
+// attempt ends, so each retry reads it from the beginning and nothing is
+// held in memory. Open errors surface from the send call. The file name
+// sent to Telegram is the base name of path.
+func FromFile(path string) InputFile {
+	f := InputFile{
+		Source: func() io.Reader {
+			return &fileReader{path: path}
+		},
+		FileName: filepath.Base(path),
+	}
+	if fi, err := os.Stat(path); err == nil && fi.Mode().IsRegular() {
+		f.Size = fi.Size()
+	}
+	return f
+}
+
+// FromReaderAt creates a retry-safe InputFile that streams size bytes of r.
+// Each attempt reads r from offset 0 through its own section reader, so
+// retries resend the full content without buffering it.
+func FromReaderAt(r io.ReaderAt, filename string, size int64) InputFile {
+	return InputFile{
+		Source: func() io.Reader {
+			return io.NewSectionReader(r, 0, size)
+		},
+		FileName: filename,
+		Size:     size,
 	}
 }
 
@@ -131,6 +193,150 @@ func (f InputFile) WithMediaType(mediaType string) InputFile {
 	return f
 }
 
+// WithThumbnail returns a copy with the media group item's thumbnail set.
+func (f InputFile) WithThumbnail(thumb InputFile) InputFile {
+	f.Thumbnail = &thumb
+	return f
+}
+
+// WithCover returns a copy with the media group item's video cover set.
+func (f InputFile) WithCover(cover InputFile) InputFile {
+	f.Cover = &cover
+	return f
+}
+
+// WithProgress returns a copy that reports upload progress to fn.
+func (f InputFile) WithProgress(fn ProgressFunc) InputFile {
+	f.Progress = fn
+	return f
+}
+
+// WithSize returns a copy with the content size set, for readers whose
+// length FromReader cannot detect.
+func (f InputFile) WithSize(size int64) InputFile {
+	f.Size = size
+	return f
+}
+
+// Retryable returns a copy whose Reader is safe to send more than once.
+// Content is copied into memory as it is streamed, and a retried request
+// replays the copied bytes before reading on from Reader. Memory use grows
+// to the file size, so prefer Source for large files that can be reopened.
+// Files that already have Source, or no Reader, are returned unchanged.
+func (f InputFile) Retryable() InputFile {
+	if f.Source != nil || f.Reader == nil {
+		return f
+	}
+	t := &teeSource{r: f.Reader}
+	f.Source = t.open
+	f.Reader = nil
+	return f
+}
+
+// filePart returns the multipart part uploading f as fieldName.
+func (f InputFile) filePart(fieldName string) FilePart {
+	return FilePart{
+		FieldName: fieldName,
+		FileName:  f.FileName,
+		Reader:    f.OpenReader(),
+		Size:      f.Size,
+		Progress:  f.Progress,
+		owned:     f.Source != nil,
+	}
+}
+
+// fileReader opens path on first Read and closes it at EOF or on error.
+type fileReader struct {
+	path string
+	f    *os.File
+	done bool
+}
+
+func (r *fileReader) Read(p []byte) (int, error) {
+	if r.done {
+		return 0, io.EOF
+	}
+	if r.f == nil {
+		f, err := os.Open(r.path)
+		if err != nil {
+			r.done = true
+			return 0, err
+		}
+		r.f = f
+	}
+	n, err := r.f.Read(p)
+	if err != nil {
+		r.Close()
+	}
+	return n, err
+}
+
+// Close closes the file if it is open.
+func (r *fileReader) Close() error {
+	r.done = true
+	if r.f == nil {
+		return nil
+	}
+	err := r.f.Close()
+	r.f = nil
+	return err
+}
+
+// readerSize returns the remaining length of r, or 0 if it is unknown.
+func readerSize(r io.Reader) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len())
+	case *os.File:
+		if fi, err := v.Stat(); err == nil && fi.Mode().IsRegular() {
+			if off, err := v.Seek(0, io.SeekCurrent); err == nil {
+				return fi.Size() - off
+			}
+		}
+	}
+	return 0
+}
+
+// teeSource backs Retryable: every reader it opens replays the bytes read
+// so far, then continues from the shared underlying reader.
+type teeSource struct {
+	mu  sync.Mutex
+	r   io.Reader
+	buf []byte
+	err error
+}
+
+func (t *teeSource) open() io.Reader {
+	return &replayReader{t: t}
+}
+
+type replayReader struct {
+	t   *teeSource
+	off int
+}
+
+func (rr *replayReader) Read(p []byte) (int, error) {
+	t := rr.t
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if rr.off < len(t.buf) {
+		n := copy(p, t.buf[rr.off:])
+		rr.off += n
+		return n, nil
+	}
+	if t.err != nil {
+		return 0, t.err
+	}
+	n, err := t.r.Read(p)
+	t.buf = append(t.buf, p[:n]...)
+	rr.off += n
+	if err != nil {
+		t.err = err
+	}
+	return n, err
+}
+
 // MarshalJSON returns the string value (URL or FileID) for JSON encoding.
 // For uploads (Reader-based), this returns empty string as those use multipart.
 func (f InputFile) MarshalJSON() ([]byte, error) {
@@ -1,10 +1,15 @@
 package sender_test
 
 import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/prilive-com/galigo/sender"
 )
@@ -77,3 +82,85 @@ func TestConstants(t *testing.T) {
 	assert.Equal(t, int64(50*1024*1024), int64(sender.MaxUploadSize))
 	assert.Equal(t, int64(10*1024*1024), int64(sender.MaxPhotoSize))
 }
+
+func TestInputFile_Size(t *testing.T) {
+	assert.Equal(t, int64(5), sender.FromBytes([]byte("hello"), "a.txt").Size)
+	assert.Equal(t, int64(12), sender.FromReader(strings.NewReader("file content"), "a.txt").Size)
+	assert.Equal(t, int64(3), sender.FromReader(bytes.NewBufferString("abc"), "a.txt").Size)
+
+	// Unknown length
+	r := io.MultiReader(strings.NewReader("abc"))
+	assert.Zero(t, sender.FromReader(r, "a.txt").Size)
+	assert.Equal(t, int64(3), sender.FromReader(r, "a.txt").WithSize(3).Size)
+}
+
+func TestInputFile_Retryable(t *testing.T) {
+	file := sender.FromReader(io.MultiReader(strings.NewReader("file content")), "a.txt").Retryable()
+	require.NotNil(t, file.Source)
+	assert.Nil(t, file.Reader)
+
+	// First attempt fails after a partial read
+	first := file.OpenReader()
+	buf := make([]byte, 4)
+	n, err := first.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "file", string(buf[:n]))
+
+	// Retry sees the whole content
+	data, err := io.ReadAll(file.OpenReader())
+	require.NoError(t, err)
+	assert.Equal(t, "file content", string(data))
+
+	// And so does a third attempt
+	data, err = io.ReadAll(file.OpenReader())
+	require.NoError(t, err)
+	assert.Equal(t, "file content", string(data))
+}
+
+func TestInputFile_Retryable_NoOpForSource(t *testing.T) {
+	file := sender.FromBytes([]byte("x"), "a.txt")
+	assert.Equal(t, "x", readAll(t, file.Retryable()))
+
+	id := sender.FromFileID("abc").Retryable()
+	assert.Nil(t, id.Source)
+}
+
+func readAll(t *testing.T, f sender.InputFile) string {
+	t.Helper()
+	data, err := io.ReadAll(f.OpenReader())
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestInputFile_FromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	require.NoError(t, os.WriteFile(path, []byte("pdf content"), 0o600))
+
+	file := sender.FromFile(path)
+
+	assert.True(t, file.IsUpload())
+	assert.Equal(t, "report.pdf", file.FileName)
+	assert.Equal(t, int64(11), file.Size)
+
+	// Every attempt reads the file from the start
+	assert.Equal(t, "pdf content", readAll(t, file))
+	assert.Equal(t, "pdf content", readAll(t, file))
+}
+
+func TestInputFile_FromFile_Missing(t *testing.T) {
+	file := sender.FromFile(filepath.Join(t.TempDir(), "missing.pdf"))
+
+	assert.True(t, file.IsUpload())
+	assert.Zero(t, file.Size)
+	_, err := io.ReadAll(file.OpenReader())
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestInputFile_FromReaderAt(t *testing.T) {
+	file := sender.FromReaderAt(strings.NewReader("section data"), "a.bin", 7)
+
+	assert.True(t, file.IsUpload())
+	assert.Equal(t, int64(7), file.Size)
+	assert.Equal(t, "section", readAll(t, file))
+	assert.Equal(t, "section", readAll(t, file))
+}
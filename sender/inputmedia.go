@@ -1,5 +1,10 @@
 package sender
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 // InputMedia represents media content for editMessageMedia.
 // Use one of the constructors: InputMediaPhoto, InputMediaDocument, etc.
 type InputMedia struct {
@@ -14,6 +19,12 @@ type InputMedia struct {
 
 	// ParseMode for caption (optional): HTML, Markdown, MarkdownV2.
 	ParseMode string
+
+	// Thumbnail for video, animation, audio and document media (optional).
+	Thumbnail *InputFile
+
+	// Cover for video media (optional).
+	Cover *InputFile
 }
 
 // NewInputMediaPhoto creates an InputMedia of type "photo".
@@ -52,3 +63,45 @@ func (m InputMedia) WithParseMode(mode string) InputMedia {
 	m.ParseMode = mode
 	return m
 }
+
+// WithThumbnail returns a copy with the thumbnail set.
+func (m InputMedia) WithThumbnail(thumb InputFile) InputMedia {
+	m.Thumbnail = &thumb
+	return m
+}
+
+// WithCover returns a copy with the video cover set.
+func (m InputMedia) WithCover(cover InputFile) InputMedia {
+	m.Cover = &cover
+	return m
+}
+
+// MarshalJSON encodes m as a Telegram InputMedia object. It is used for
+// requests without uploads; uploads go through BuildMultipartRequest.
+func (m InputMedia) MarshalJSON() ([]byte, error) {
+	var idx int
+	item, err := mediaItem(nil, "InputMedia.Media", m.Type, m.Media, m.Caption, m.ParseMode, m.Thumbnail, m.Cover, &idx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(item)
+}
+
+// MarshalJSON encodes Media as InputMedia objects for requests without
+// uploads; uploads go through BuildMultipartRequest.
+func (r SendMediaGroupRequest) MarshalJSON() ([]byte, error) {
+	var idx int
+	media := make([]map[string]any, len(r.Media))
+	for i, f := range r.Media {
+		item, err := mediaItem(nil, "InputFile", f.MediaType, f, f.Caption, f.ParseMode, f.Thumbnail, f.Cover, &idx)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		media[i] = item
+	}
+	type alias SendMediaGroupRequest
+	return json.Marshal(struct {
+		alias
+		Media []map[string]any `json:"media"`
+	}{alias(r), media})
+}
@@ -0,0 +1,219 @@
+package sender
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// Telegram Bot API limits checked before a request is sent.
+// Text lengths are in characters after entity parsing, so they are only
+// checked for plain text (no parse mode); markup is stripped by Telegram.
+const (
+	MaxMessageTextLength     = 4096
+	MaxCaptionLength         = 1024
+	MaxCallbackDataBytes     = 64
+	MaxCopyTextLength        = 256
+	MaxCallbackAnswerLength  = 200
+	MinPollOptions           = 2
+	MaxPollOptions           = 10
+	MaxPollQuestionLength    = 300
+	MaxPollOptionLength      = 100
+	MaxPollExplanationLength = 200
+	MinMediaGroupSize        = 2
+	MaxMediaGroupSize        = 10
+	MaxStickerEmojis         = 20
+	MaxStickerKeywords       = 20
+)
+
+// WithLimitValidation enables or disables client-side checks of Telegram's
+// documented limits (text and caption length, inline button actions and
+// callback_data size, poll option counts, coordinates, sticker emoji
+// lists). Violations fail with a
+// *tg.ValidationError before any network call. Enabled by default; disable
+// it if Telegram raises a limit before galigo is updated.
+func WithLimitValidation(enabled bool) Option {
+	return func(c *Client) {
+		c.skipLimitValidation = !enabled
+	}
+}
+
+// checkLimits validates payload against Telegram's limits.
+func checkLimits(payload any) error {
+	if err := checkPayloadLimits(payload); err != nil {
+		return err
+	}
+
+	// Fields shared by most send/edit requests.
+	rv := auditStruct(payload)
+	if !rv.IsValid() {
+		return nil
+	}
+	if f := rv.FieldByName("Caption"); f.IsValid() && f.Kind() == reflect.String {
+		if err := checkLength("caption", f.String(), MaxCaptionLength, plainText(rv, "ParseMode")); err != nil {
+			return err
+		}
+	}
+	if f := rv.FieldByName("ReplyMarkup"); f.IsValid() && f.CanInterface() {
+		return checkReplyMarkup(f.Interface())
+	}
+	return nil
+}
+
+func checkPayloadLimits(payload any) error {
+	switch req := payload.(type) {
+	case SendMessageRequest:
+		return checkLength("text", req.Text, MaxMessageTextLength, req.ParseMode == "")
+	case EditMessageTextRequest:
+		return checkLength("text", req.Text, MaxMessageTextLength, req.ParseMode == "")
+	case AnswerCallbackQueryRequest:
+		return checkLength("text", req.Text, MaxCallbackAnswerLength, true)
+	case SendPollRequest:
+		return checkPoll(req)
+	case SendLocationRequest:
+		return checkCoordinates(req.Latitude, req.Longitude)
+	case SendVenueRequest:
+		return checkCoordinates(req.Latitude, req.Longitude)
+	case SendMediaGroupRequest:
+		if n := len(req.Media); n < MinMediaGroupSize || n > MaxMediaGroupSize {
+			return tg.NewValidationError("media", fmt.Sprintf("must contain %d-%d items, got %d", MinMediaGroupSize, MaxMediaGroupSize, n))
+		}
+	case SetStickerEmojiListRequest:
+		return checkEmojiList("emoji_list", req.EmojiList)
+	case SetStickerKeywordsRequest:
+		if len(req.Keywords) > MaxStickerKeywords {
+			return tg.NewValidationError("keywords", fmt.Sprintf("cannot exceed %d keywords, got %d", MaxStickerKeywords, len(req.Keywords)))
+		}
+	case CreateNewStickerSetRequest:
+		for i, s := range req.Stickers {
+			if err := checkInputSticker(fmt.Sprintf("stickers[%d]", i), s); err != nil {
+				return err
+			}
+		}
+	case AddStickerToSetRequest:
+		return checkInputSticker("sticker", req.Sticker)
+	case ReplaceStickerInSetRequest:
+		return checkInputSticker("sticker", req.Sticker)
+	}
+	return nil
+}
+
+func checkPoll(req SendPollRequest) error {
+	if err := checkLength("question", req.Question, MaxPollQuestionLength, req.QuestionParseMode == ""); err != nil {
+		return err
+	}
+	if n := len(req.Options); n < MinPollOptions || n > MaxPollOptions {
+		return tg.NewValidationError("options", fmt.Sprintf("must contain %d-%d options, got %d", MinPollOptions, MaxPollOptions, n))
+	}
+	for i, opt := range req.Options {
+		field := fmt.Sprintf("options[%d]", i)
+		if opt.Text == "" {
+			return tg.NewValidationError(field, "text required")
+		}
+		if err := checkLength(field, opt.Text, MaxPollOptionLength, opt.TextParseMode == ""); err != nil {
+			return err
+		}
+	}
+	return checkLength("explanation", req.Explanation, MaxPollExplanationLength, req.ExplanationParseMode == "")
+}
+
+func checkCoordinates(lat, lon float64) error {
+	if lat < -90 || lat > 90 {
+		return tg.NewValidationError("latitude", fmt.Sprintf("must be between -90 and 90, got %g", lat))
+	}
+	if lon < -180 || lon > 180 {
+		return tg.NewValidationError("longitude", fmt.Sprintf("must be between -180 and 180, got %g", lon))
+	}
+	return nil
+}
+
+func checkInputSticker(field string, s InputSticker) error {
+	if err := checkEmojiList(field+".emoji_list", s.EmojiList); err != nil {
+		return err
+	}
+	if len(s.Keywords) > MaxStickerKeywords {
+		return tg.NewValidationError(field+".keywords", fmt.Sprintf("cannot exceed %d keywords, got %d", MaxStickerKeywords, len(s.Keywords)))
+	}
+	return nil
+}
+
+func checkEmojiList(field string, emojis []string) error {
+	if len(emojis) > MaxStickerEmojis {
+		return tg.NewValidationError(field, fmt.Sprintf("cannot exceed %d emojis, got %d", MaxStickerEmojis, len(emojis)))
+	}
+	return nil
+}
+
+// checkReplyMarkup checks the buttons of inline keyboards: each has
+// exactly one action, callback_data and copy_text fit their limits, and
+// pay and callback_game buttons come first in the first row.
+func checkReplyMarkup(markup any) error {
+	var rows [][]tg.InlineKeyboardButton
+	switch m := markup.(type) {
+	case *tg.InlineKeyboardMarkup:
+		if m == nil {
+			return nil
+		}
+		rows = m.InlineKeyboard
+	case tg.InlineKeyboardMarkup:
+		rows = m.InlineKeyboard
+	case *tg.Keyboard:
+		if m == nil {
+			return nil
+		}
+		rows = m.Build().InlineKeyboard
+	default:
+		return nil
+	}
+	for i, row := range rows {
+		for j, btn := range row {
+			if err := checkInlineButton(fmt.Sprintf("reply_markup.inline_keyboard[%d][%d]", i, j), btn, i == 0 && j == 0); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkInlineButton(field string, btn tg.InlineKeyboardButton, first bool) error {
+	switch actions := btn.Actions(); len(actions) {
+	case 0:
+		return tg.NewValidationError(field, "needs an action such as callback_data or url")
+	case 1:
+	default:
+		return tg.NewValidationError(field, fmt.Sprintf("must have exactly one action, got %s", strings.Join(actions, ", ")))
+	}
+	if n := len(btn.CallbackData); n > MaxCallbackDataBytes {
+		return tg.NewValidationError(field+".callback_data", fmt.Sprintf("must be at most %d bytes, got %d", MaxCallbackDataBytes, n))
+	}
+	if btn.CopyText != nil {
+		if n := utf8.RuneCountInString(btn.CopyText.Text); n < 1 || n > MaxCopyTextLength {
+			return tg.NewValidationError(field+".copy_text.text", fmt.Sprintf("must be 1-%d characters, got %d", MaxCopyTextLength, n))
+		}
+	}
+	if (btn.Pay || btn.CallbackGame != nil) && !first {
+		return tg.NewValidationError(field, fmt.Sprintf("%s button must be the first button in the first row", btn.Action()))
+	}
+	return nil
+}
+
+// checkLength rejects s if it is longer than limit characters. Only plain
+// text is checked; formatted text is measured by Telegram after parsing.
+func checkLength(field, s string, limit int, plain bool) error {
+	if !plain || len(s) <= limit {
+		return nil
+	}
+	if n := utf8.RuneCountInString(s); n > limit {
+		return tg.NewValidationError(field, fmt.Sprintf("must be at most %d characters, got %d", limit, n))
+	}
+	return nil
+}
+
+// plainText reports whether the struct's parse mode field is unset.
+func plainText(rv reflect.Value, field string) bool {
+	f := rv.FieldByName(field)
+	return !f.IsValid() || f.Kind() != reflect.String || f.String() == ""
+}
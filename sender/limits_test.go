@@ -0,0 +1,150 @@
+package sender_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestLimits_RejectedBeforeNetwork(t *testing.T) {
+	ctx := context.Background()
+	longKeyboard := tg.NewKeyboard().Row(tg.Btn("Go", strings.Repeat("x", 65)))
+
+	tests := []struct {
+		name  string
+		field string
+		call  func(c *sender.Client) error
+	}{
+		{"text", "text", func(c *sender.Client) error {
+			_, err := c.SendMessage(ctx, sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: strings.Repeat("a", 4097)})
+			return err
+		}},
+		{"caption", "caption", func(c *sender.Client) error {
+			_, err := c.SendPhoto(ctx, sender.SendPhotoRequest{ChatID: testutil.TestChatID, Photo: sender.FromFileID("p"), Caption: strings.Repeat("a", 1025)})
+			return err
+		}},
+		{"callback_data", "reply_markup.inline_keyboard[0][0].callback_data", func(c *sender.Client) error {
+			_, err := c.SendMessage(ctx, sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "hi", ReplyMarkup: longKeyboard.Build()})
+			return err
+		}},
+		{"button_without_action", "reply_markup.inline_keyboard[0][0]", func(c *sender.Client) error {
+			kb := tg.InlineKeyboard(tg.Row(tg.InlineKeyboardButton{Text: "Go"}))
+			_, err := c.SendMessage(ctx, sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "hi", ReplyMarkup: kb})
+			return err
+		}},
+		{"button_two_actions", "reply_markup.inline_keyboard[0][0]", func(c *sender.Client) error {
+			kb := tg.InlineKeyboard(tg.Row(tg.InlineKeyboardButton{Text: "Go", URL: "https://example.com", CallbackData: "go"}))
+			_, err := c.SendMessage(ctx, sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "hi", ReplyMarkup: kb})
+			return err
+		}},
+		{"copy_text", "reply_markup.inline_keyboard[0][0].copy_text.text", func(c *sender.Client) error {
+			kb := tg.InlineKeyboard(tg.Row(tg.BtnCopy("Copy", strings.Repeat("c", 257))))
+			_, err := c.SendMessage(ctx, sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "hi", ReplyMarkup: kb})
+			return err
+		}},
+		{"pay_not_first", "reply_markup.inline_keyboard[0][1]", func(c *sender.Client) error {
+			kb := tg.InlineKeyboard(tg.Row(tg.BtnURL("Terms", "https://example.com"), tg.BtnPay("Pay")))
+			_, err := c.SendMessage(ctx, sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "hi", ReplyMarkup: kb})
+			return err
+		}},
+		{"poll_options", "options", func(c *sender.Client) error {
+			_, err := c.SendPoll(ctx, sender.SendPollRequest{ChatID: testutil.TestChatID, Question: "Q?", Options: []sender.InputPollOption{{Text: "only"}}})
+			return err
+		}},
+		{"poll_option_length", "options[1]", func(c *sender.Client) error {
+			_, err := c.SendPoll(ctx, sender.SendPollRequest{ChatID: testutil.TestChatID, Question: "Q?", Options: []sender.InputPollOption{{Text: "a"}, {Text: strings.Repeat("b", 101)}}})
+			return err
+		}},
+		{"latitude", "latitude", func(c *sender.Client) error {
+			_, err := c.SendLocation(ctx, sender.SendLocationRequest{ChatID: testutil.TestChatID, Latitude: 91, Longitude: 0})
+			return err
+		}},
+		{"longitude", "longitude", func(c *sender.Client) error {
+			_, err := c.SendVenue(ctx, sender.SendVenueRequest{ChatID: testutil.TestChatID, Latitude: 0, Longitude: -181, Title: "T", Address: "A"})
+			return err
+		}},
+		{"emoji_list", "emoji_list", func(c *sender.Client) error {
+			return c.SetStickerEmojiList(ctx, sender.SetStickerEmojiListRequest{Sticker: "s", EmojiList: make([]string, 21)})
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := testutil.NewMockServer(t)
+			client := testutil.NewTestClient(t, server.BaseURL())
+
+			err := tc.call(client)
+
+			var vErr *tg.ValidationError
+			require.ErrorAs(t, err, &vErr)
+			assert.Equal(t, tc.field, vErr.Field)
+			assert.Equal(t, 0, server.CaptureCount())
+		})
+	}
+}
+
+func TestLimits_EmptySwitchQueryAllowed(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	kb := tg.InlineKeyboard(tg.Row(tg.BtnSwitch("Share", "")))
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "hi", ReplyMarkup: kb})
+	require.NoError(t, err)
+	assert.Contains(t, string(server.LastCapture().Body), `"switch_inline_query":""`)
+}
+
+func TestLimits_FormattedTextNotMeasured(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	// Markup is stripped by Telegram, so the raw length may exceed the limit.
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{
+		ChatID:    testutil.TestChatID,
+		Text:      "<b>" + strings.Repeat("a", 4096) + "</b>",
+		ParseMode: tg.ParseModeHTML,
+	})
+	require.NoError(t, err)
+}
+
+func TestLimits_CountsCharactersNotBytes(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{
+		ChatID: testutil.TestChatID,
+		Text:   strings.Repeat("я", 4096),
+	})
+	require.NoError(t, err)
+}
+
+func TestWithLimitValidation_Disabled(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithLimitValidation(false))
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{
+		ChatID: testutil.TestChatID,
+		Text:   strings.Repeat("a", 5000),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, server.CaptureCount())
+}
@@ -2,24 +2,20 @@ package sender
 
 import (
 	"context"
-	"encoding/json"
 
 	"github.com/prilive-com/galigo/tg"
 )
 
 // ================== Bot Identity Methods ==================
 
-// GetMe returns basic information about the bot.
+// GetMe returns basic information about the bot and keeps it for Me.
 func (c *Client) GetMe(ctx context.Context) (*tg.User, error) {
-	resp, err := c.executeRequest(ctx, "getMe", struct{}{})
-	if err != nil {
-		return nil, err
-	}
-	var user tg.User
-	if err := json.Unmarshal(resp.Result, &user); err != nil {
-		return nil, err
+	me, err := call[tg.User](c, ctx, "getMe", struct{}{})
+	if err == nil {
+		self := *me
+		c.me.Store(&self)
 	}
-	return &user, nil
+	return me, err
 }
 
 // LogOut logs out from the cloud Bot API server.
@@ -41,97 +37,68 @@ func (c *Client) CloseBot(ctx context.Context) error {
 
 // SendDocument sends a document.
 func (c *Client) SendDocument(ctx context.Context, req SendDocumentRequest) (*tg.Message, error) {
-	resp, err := c.executeRequest(ctx, "sendDocument", req, extractChatID(req.ChatID))
-	if err != nil {
-		return nil, err
-	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "sendDocument", req, extractChatID(req.ChatID))
 }
 
 // SendVideo sends a video.
 func (c *Client) SendVideo(ctx context.Context, req SendVideoRequest) (*tg.Message, error) {
-	resp, err := c.executeRequest(ctx, "sendVideo", req, extractChatID(req.ChatID))
-	if err != nil {
+	if err := c.autoThumbnail(ctx, req.Video, &req.Thumbnail); err != nil {
 		return nil, err
 	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "sendVideo", req, extractChatID(req.ChatID))
 }
 
 // SendAudio sends an audio file.
 func (c *Client) SendAudio(ctx context.Context, req SendAudioRequest) (*tg.Message, error) {
-	resp, err := c.executeRequest(ctx, "sendAudio", req, extractChatID(req.ChatID))
-	if err != nil {
-		return nil, err
-	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "sendAudio", req, extractChatID(req.ChatID))
 }
 
 // SendVoice sends a voice message.
 func (c *Client) SendVoice(ctx context.Context, req SendVoiceRequest) (*tg.Message, error) {
-	resp, err := c.executeRequest(ctx, "sendVoice", req, extractChatID(req.ChatID))
-	if err != nil {
-		return nil, err
-	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "sendVoice", req, extractChatID(req.ChatID))
 }
 
 // SendAnimation sends an animation (GIF or H.264/MPEG-4 AVC video without sound).
 func (c *Client) SendAnimation(ctx context.Context, req SendAnimationRequest) (*tg.Message, error) {
-	resp, err := c.executeRequest(ctx, "sendAnimation", req, extractChatID(req.ChatID))
-	if err != nil {
+	if err := c.autoThumbnail(ctx, req.Animation, &req.Thumbnail); err != nil {
 		return nil, err
 	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "sendAnimation", req, extractChatID(req.ChatID))
 }
 
 // SendVideoNote sends a video note (round video up to 1 minute).
 func (c *Client) SendVideoNote(ctx context.Context, req SendVideoNoteRequest) (*tg.Message, error) {
-	resp, err := c.executeRequest(ctx, "sendVideoNote", req, extractChatID(req.ChatID))
-	if err != nil {
-		return nil, err
-	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "sendVideoNote", req, extractChatID(req.ChatID))
 }
 
 // SendSticker sends a sticker.
 func (c *Client) SendSticker(ctx context.Context, req SendStickerRequest) (*tg.Message, error) {
-	resp, err := c.executeRequest(ctx, "sendSticker", req, extractChatID(req.ChatID))
-	if err != nil {
-		return nil, err
-	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "sendSticker", req, extractChatID(req.ChatID))
 }
 
 // SendMediaGroup sends a group of photos, videos, documents or audios as an album.
 func (c *Client) SendMediaGroup(ctx context.Context, req SendMediaGroupRequest) ([]*tg.Message, error) {
-	resp, err := c.executeRequest(ctx, "sendMediaGroup", req, extractChatID(req.ChatID))
+	messages, err := call[[]*tg.Message](c, ctx, "sendMediaGroup", req, extractChatID(req.ChatID))
 	if err != nil {
 		return nil, err
 	}
-	var messages []*tg.Message
-	if err := json.Unmarshal(resp.Result, &messages); err != nil {
-		return nil, err
-	}
-	return messages, nil
+	return *messages, nil
 }
 
 // ================== Utility Methods ==================
 
 // GetFile returns basic info about a file and prepares it for downloading.
 func (c *Client) GetFile(ctx context.Context, fileID string) (*tg.File, error) {
-	resp, err := c.executeRequest(ctx, "getFile", GetFileRequest{FileID: fileID})
-	if err != nil {
-		return nil, err
-	}
-	var file tg.File
-	if err := json.Unmarshal(resp.Result, &file); err != nil {
-		return nil, err
-	}
-	return &file, nil
+	return call[tg.File](c, ctx, "getFile", GetFileRequest{FileID: fileID})
 }
 
 // SendChatAction sends a chat action (typing, upload_photo, etc.).
-func (c *Client) SendChatAction(ctx context.Context, chatID tg.ChatID, action string) error {
+// Telegram ignores unknown actions, so they are rejected with a
+// *tg.ValidationError before the request is sent.
+func (c *Client) SendChatAction(ctx context.Context, chatID tg.ChatID, action tg.ChatAction) error {
+	if err := validateChatAction(action); err != nil {
+		return err
+	}
 	_, err := c.executeRequest(ctx, "sendChatAction", SendChatActionRequest{
 		ChatID: chatID,
 		Action: action,
@@ -145,15 +112,7 @@ func (c *Client) GetUserProfilePhotos(ctx context.Context, userID int64, opts ..
 	for _, opt := range opts {
 		opt(&req)
 	}
-	resp, err := c.executeRequest(ctx, "getUserProfilePhotos", req)
-	if err != nil {
-		return nil, err
-	}
-	var photos tg.UserProfilePhotos
-	if err := json.Unmarshal(resp.Result, &photos); err != nil {
-		return nil, err
-	}
-	return &photos, nil
+	return call[tg.UserProfilePhotos](c, ctx, "getUserProfilePhotos", req)
 }
 
 // GetUserProfileAudios returns a user's profile audios.
@@ -163,53 +122,29 @@ func (c *Client) GetUserProfileAudios(ctx context.Context, userID int64, opts ..
 	for _, opt := range opts {
 		opt(&req)
 	}
-	resp, err := c.executeRequest(ctx, "getUserProfileAudios", req)
-	if err != nil {
-		return nil, err
-	}
-	var audios tg.UserProfileAudios
-	if err := json.Unmarshal(resp.Result, &audios); err != nil {
-		return nil, err
-	}
-	return &audios, nil
+	return call[tg.UserProfileAudios](c, ctx, "getUserProfileAudios", req)
 }
 
 // ================== Location/Contact Methods ==================
 
 // SendLocation sends a location.
 func (c *Client) SendLocation(ctx context.Context, req SendLocationRequest) (*tg.Message, error) {
-	resp, err := c.executeRequest(ctx, "sendLocation", req, extractChatID(req.ChatID))
-	if err != nil {
-		return nil, err
-	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "sendLocation", req, extractChatID(req.ChatID))
 }
 
 // SendVenue sends a venue.
 func (c *Client) SendVenue(ctx context.Context, req SendVenueRequest) (*tg.Message, error) {
-	resp, err := c.executeRequest(ctx, "sendVenue", req, extractChatID(req.ChatID))
-	if err != nil {
-		return nil, err
-	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "sendVenue", req, extractChatID(req.ChatID))
 }
 
 // SendContact sends a phone contact.
 func (c *Client) SendContact(ctx context.Context, req SendContactRequest) (*tg.Message, error) {
-	resp, err := c.executeRequest(ctx, "sendContact", req, extractChatID(req.ChatID))
-	if err != nil {
-		return nil, err
-	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "sendContact", req, extractChatID(req.ChatID))
 }
 
 // SendPoll sends a native poll.
 func (c *Client) SendPoll(ctx context.Context, req SendPollRequest) (*tg.Message, error) {
-	resp, err := c.executeRequest(ctx, "sendPoll", req, extractChatID(req.ChatID))
-	if err != nil {
-		return nil, err
-	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "sendPoll", req, extractChatID(req.ChatID))
 }
 
 // SendDice sends an animated emoji that displays a random value.
@@ -218,39 +153,27 @@ func (c *Client) SendDice(ctx context.Context, chatID tg.ChatID, opts ...SendDic
 	for _, opt := range opts {
 		opt(&req)
 	}
-	resp, err := c.executeRequest(ctx, "sendDice", req, extractChatID(chatID))
-	if err != nil {
-		return nil, err
-	}
-	return parseMessage(resp)
+	return call[tg.Message](c, ctx, "sendDice", req, extractChatID(chatID))
 }
 
 // ================== Bulk Operations ==================
 
 // ForwardMessages forwards multiple messages at once.
 func (c *Client) ForwardMessages(ctx context.Context, req ForwardMessagesRequest) ([]tg.MessageID, error) {
-	resp, err := c.executeRequest(ctx, "forwardMessages", req, extractChatID(req.ChatID))
+	ids, err := call[[]tg.MessageID](c, ctx, "forwardMessages", req, extractChatID(req.ChatID))
 	if err != nil {
 		return nil, err
 	}
-	var ids []tg.MessageID
-	if err := json.Unmarshal(resp.Result, &ids); err != nil {
-		return nil, err
-	}
-	return ids, nil
+	return *ids, nil
 }
 
 // CopyMessages copies multiple messages at once.
 func (c *Client) CopyMessages(ctx context.Context, req CopyMessagesRequest) ([]tg.MessageID, error) {
-	resp, err := c.executeRequest(ctx, "copyMessages", req, extractChatID(req.ChatID))
+	ids, err := call[[]tg.MessageID](c, ctx, "copyMessages", req, extractChatID(req.ChatID))
 	if err != nil {
 		return nil, err
 	}
-	var ids []tg.MessageID
-	if err := json.Unmarshal(resp.Result, &ids); err != nil {
-		return nil, err
-	}
-	return ids, nil
+	return *ids, nil
 }
 
 // DeleteMessages deletes multiple messages at once.
@@ -262,8 +185,21 @@ func (c *Client) DeleteMessages(ctx context.Context, chatID tg.ChatID, messageID
 	return err
 }
 
-// SetMessageReaction sets a reaction on a message.
+// SetMessageReaction sets a reaction on a message. An empty Reaction list
+// removes the bot's reactions. Build entries with tg.EmojiReaction or
+// tg.CustomEmojiReaction; bots cannot set the paid reaction.
 func (c *Client) SetMessageReaction(ctx context.Context, req SetMessageReactionRequest) error {
+	if err := validateChatID(req.ChatID); err != nil {
+		return err
+	}
+	if err := validateMessageID(req.MessageID); err != nil {
+		return err
+	}
+	for _, r := range req.Reaction {
+		if err := validateReaction(r); err != nil {
+			return err
+		}
+	}
 	_, err := c.executeRequest(ctx, "setMessageReaction", req, extractChatID(req.ChatID))
 	return err
 }
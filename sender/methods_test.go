@@ -11,6 +11,7 @@ import (
 	"github.com/prilive-com/galigo/internal/testutil"
 	"github.com/prilive-com/galigo/sender"
 	"github.com/prilive-com/galigo/tg"
+	"github.com/prilive-com/galigo/tg/sendopt"
 )
 
 func TestSendPhoto_Success(t *testing.T) {
@@ -231,6 +232,216 @@ func TestCopy_InlineMessage_Error(t *testing.T) {
 	assert.Contains(t, err.Error(), "cannot copy inline messages")
 }
 
+func TestCopy_GiveawayMessage_Error(t *testing.T) {
+	client := testutil.NewTestClient(t, "http://localhost:9999")
+
+	msg := &tg.Message{
+		MessageID: 5,
+		Chat:      &tg.Chat{ID: -100111},
+		Giveaway:  &tg.Giveaway{WinnerCount: 3},
+	}
+	_, err := client.Copy(context.Background(), msg, int64(123))
+
+	require.ErrorIs(t, err, tg.ErrMessageCantBeCopied)
+}
+
+func TestForwardMessage_Story(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/forwardMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, map[string]any{
+			"message_id": 301,
+			"date":       1700000000,
+			"chat":       map[string]any{"id": testutil.TestChatID, "type": "private"},
+			"forward_origin": map[string]any{
+				"type": "channel", "date": 1699990000, "message_id": 0,
+				"chat": map[string]any{"id": int64(-1001), "type": "channel", "title": "C"},
+			},
+			"story": map[string]any{
+				"chat": map[string]any{"id": int64(-1001), "type": "channel", "title": "C"},
+				"id":   17,
+			},
+		})
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+	msg, err := client.ForwardMessage(context.Background(), sender.ForwardMessageRequest{
+		ChatID:     testutil.TestChatID,
+		FromChatID: int64(-1001),
+		MessageID:  5,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, msg.Story)
+	assert.Equal(t, 17, msg.Story.ID)
+	assert.Equal(t, int64(-1001), msg.Story.Chat.ID)
+	assert.True(t, msg.IsForwarded())
+}
+
+func TestCopy_StoryMessage(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/copyMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessageID(w, 401)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	msg := &tg.Message{
+		MessageID: 5,
+		Chat:      &tg.Chat{ID: -100111},
+		Story:     &tg.Story{Chat: tg.Chat{ID: -1001, Type: "channel"}, ID: 17},
+	}
+	require.True(t, msg.CanBeCopied())
+	msgID, err := client.Copy(context.Background(), msg, int64(123))
+
+	require.NoError(t, err)
+	assert.Equal(t, 401, msgID.MessageID)
+	server.LastCapture().AssertJSONField(t, "message_id", float64(5))
+}
+
+func TestReply_TopicMessage(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 2)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	msg := &tg.Message{
+		MessageID:       7,
+		MessageThreadID: 3,
+		IsTopicMessage:  true,
+		Chat:            &tg.Chat{ID: -100111},
+	}
+	_, err := client.Reply(context.Background(), msg, "pong", sender.WithReplyParseMode(tg.ParseModeHTML))
+	require.NoError(t, err)
+
+	cap := server.LastCapture()
+	cap.AssertJSONField(t, "chat_id", float64(-100111))
+	cap.AssertJSONField(t, "message_thread_id", float64(3))
+	cap.AssertJSONField(t, "text", "pong")
+	cap.AssertJSONField(t, "parse_mode", "HTML")
+	body := cap.BodyMap(t)
+	assert.Equal(t, map[string]any{"message_id": float64(7), "allow_sending_without_reply": true}, body["reply_parameters"])
+}
+
+func TestReply_BusinessMessage(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 2)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	msg := &tg.Message{MessageID: 7, Chat: &tg.Chat{ID: 42}, BusinessConnectionID: "bc1"}
+	_, err := client.Reply(context.Background(), msg, "hi")
+	require.NoError(t, err)
+
+	cap := server.LastCapture()
+	cap.AssertJSONField(t, "business_connection_id", "bc1")
+	cap.AssertJSONFieldAbsent(t, "message_thread_id")
+}
+
+func TestQuote(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 2)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	msg := &tg.Message{MessageID: 7, Chat: &tg.Chat{ID: 42}, Text: "the quick brown fox"}
+	_, err := client.Quote(context.Background(), msg, "brown fox", "which fox?")
+	require.NoError(t, err)
+
+	params := server.LastCapture().BodyMap(t)["reply_parameters"].(map[string]any)
+	assert.Equal(t, "brown fox", params["quote"])
+	assert.Equal(t, float64(10), params["quote_position"])
+	assert.Equal(t, float64(7), params["message_id"])
+
+	_, err = client.Quote(context.Background(), msg, "", "x")
+	var vErr *tg.ValidationError
+	require.ErrorAs(t, err, &vErr)
+	assert.Equal(t, "quote", vErr.Field)
+
+	calls := server.CaptureCount()
+	_, err = client.Quote(context.Background(), msg, "lazy dog", "x")
+	assert.ErrorIs(t, err, tg.ErrQuoteNotFound)
+	assert.Equal(t, calls, server.CaptureCount(), "no request for a quote that is not in the message")
+}
+
+func TestReply_NoChat_Error(t *testing.T) {
+	client := testutil.NewTestClient(t, "http://localhost:9999")
+
+	_, err := client.Reply(context.Background(), &tg.Message{MessageID: 1}, "x")
+	assert.Error(t, err)
+	_, err = client.Reply(context.Background(), nil, "x")
+	assert.Error(t, err)
+}
+
+func TestReact(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/setMessageReaction", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBool(w, true)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	msg := &tg.Message{MessageID: 9, Chat: &tg.Chat{ID: 42}}
+	require.NoError(t, client.React(context.Background(), msg, "👍"))
+
+	cap := server.LastCapture()
+	cap.AssertJSONField(t, "message_id", float64(9))
+	assert.Equal(t, []any{map[string]any{"type": "emoji", "emoji": "👍"}}, cap.BodyMap(t)["reaction"])
+
+	// Variation selectors are dropped.
+	require.NoError(t, client.React(context.Background(), msg, "❤\uFE0F"))
+	assert.Equal(t, []any{map[string]any{"type": "emoji", "emoji": tg.ReactionHeart}}, server.LastCapture().BodyMap(t)["reaction"])
+
+	// No emojis clears the bot's reactions.
+	require.NoError(t, client.React(context.Background(), msg))
+	server.LastCapture().AssertJSONFieldAbsent(t, "reaction")
+}
+
+func TestPinUnpin(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/pinChatMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBool(w, true)
+	})
+	server.On("/bot"+testutil.TestToken+"/unpinChatMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBool(w, true)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	msg := &tg.Message{MessageID: 9, Chat: &tg.Chat{ID: 42}}
+	require.NoError(t, client.Pin(context.Background(), msg, sender.WithSilentPin()))
+	cap := server.LastCapture()
+	cap.AssertPath(t, "/bot"+testutil.TestToken+"/pinChatMessage")
+	cap.AssertJSONField(t, "message_id", float64(9))
+	cap.AssertJSONField(t, "disable_notification", true)
+
+	require.NoError(t, client.Unpin(context.Background(), msg))
+	server.LastCapture().AssertPath(t, "/bot"+testutil.TestToken+"/unpinChatMessage")
+}
+
+func TestPin_InlineMessage_Error(t *testing.T) {
+	client := testutil.NewTestClient(t, "http://localhost:9999")
+
+	inline := tg.InlineMessage{InlineMessageID: "inline_123"}
+	assert.Error(t, client.Pin(context.Background(), inline))
+	assert.Error(t, client.React(context.Background(), inline, "👍"))
+}
+
+func TestPin_InvalidMessageID(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	msg := &tg.Message{Chat: &tg.Chat{ID: 42}}
+	assert.ErrorContains(t, client.Pin(context.Background(), msg), "message_id must be positive")
+	assert.ErrorContains(t, client.React(context.Background(), msg, "👍"), "message_id must be positive")
+	assert.Zero(t, server.CaptureCount())
+}
+
 func TestAnswer_CallbackQuery(t *testing.T) {
 	server := testutil.NewMockServer(t)
 	server.On("/bot"+testutil.TestToken+"/answerCallbackQuery", func(w http.ResponseWriter, r *http.Request) {
@@ -822,6 +1033,19 @@ func TestSendChatAction_Success(t *testing.T) {
 	cap.AssertJSONField(t, "action", "typing")
 }
 
+func TestSendChatAction_UnknownAction(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	for _, action := range []tg.ChatAction{"", "typeing", "upload_audio"} {
+		err := client.SendChatAction(context.Background(), testutil.TestChatID, action)
+		var verr *tg.ValidationError
+		require.ErrorAs(t, err, &verr, "action %q", action)
+		assert.Equal(t, "action", verr.Field)
+	}
+	assert.Equal(t, 0, server.CaptureCount(), "validation should fail before HTTP call")
+}
+
 func TestGetUserProfilePhotos_Success(t *testing.T) {
 	server := testutil.NewMockServer(t)
 	server.On("/bot"+testutil.TestToken+"/getUserProfilePhotos", func(w http.ResponseWriter, r *http.Request) {
@@ -1126,6 +1350,53 @@ func TestSetMessageReaction_Success(t *testing.T) {
 	cap.AssertJSONField(t, "message_id", float64(123))
 }
 
+func TestSetMessageReaction_CustomEmoji(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/setMessageReaction", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBool(w, true)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	err := client.SetMessageReaction(context.Background(), sender.SetMessageReactionRequest{
+		ChatID:    testutil.TestChatID,
+		MessageID: 123,
+		Reaction:  []tg.ReactionType{tg.CustomEmojiReaction("5368324170671202286")},
+	})
+	require.NoError(t, err)
+
+	var body struct {
+		Reaction []tg.ReactionType `json:"reaction"`
+	}
+	server.LastCapture().BodyJSON(t, &body)
+	assert.Equal(t, []tg.ReactionType{tg.CustomEmojiReaction("5368324170671202286")}, body.Reaction)
+}
+
+func TestSetMessageReaction_Validation(t *testing.T) {
+	client := testutil.NewTestClient(t, "http://unused")
+
+	tests := []struct {
+		name     string
+		reaction tg.ReactionType
+	}{
+		{"paid", tg.PaidReaction()},
+		{"empty emoji", tg.ReactionType{Type: tg.ReactionEmoji}},
+		{"empty custom emoji", tg.ReactionType{Type: tg.ReactionCustomEmoji}},
+		{"not a reaction emoji", tg.ReactionType{Type: tg.ReactionEmoji, Emoji: "🦀"}},
+		{"variation selector", tg.ReactionType{Type: tg.ReactionEmoji, Emoji: "❤\uFE0F"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.SetMessageReaction(context.Background(), sender.SetMessageReactionRequest{
+				ChatID:    testutil.TestChatID,
+				MessageID: 1,
+				Reaction:  []tg.ReactionType{tt.reaction},
+			})
+			require.Error(t, err)
+		})
+	}
+}
+
 // ================== LinkPreviewOptions Tests ==================
 
 func TestSendMessage_WithLinkPreviewOptions(t *testing.T) {
@@ -1209,3 +1480,74 @@ func TestEditMessageText_LinkPreviewOptions_MutuallyExclusiveError(t *testing.T)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "mutually exclusive")
 }
+
+// Test shared sendopt options
+
+func TestSendopt_Edit(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/editMessageText", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	editable := mockEditable{msgID: "1", chatID: testutil.TestChatID}
+	_, err := client.Edit(context.Background(), editable, "<b>Bold</b>",
+		sendopt.ParseMode(tg.ParseModeHTML),
+		sendopt.NoLinkPreview(),
+		sendopt.Silent(), // not supported by editMessageText: ignored
+	)
+
+	require.NoError(t, err)
+
+	cap := server.LastCapture()
+	cap.AssertJSONField(t, "parse_mode", "HTML")
+	cap.AssertJSONFieldExists(t, "link_preview_options")
+	cap.AssertJSONFieldAbsent(t, "disable_notification")
+}
+
+func TestSendopt_Copy(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/copyMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessageID(w, 1)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	editable := mockEditable{msgID: "1", chatID: int64(111)}
+	_, err := client.Copy(context.Background(), editable, int64(222),
+		sendopt.Caption("New caption"),
+		sendopt.Protect(),
+		sendopt.Thread(7),
+		sender.CopySilent(), // legacy options mix with sendopt options
+	)
+
+	require.NoError(t, err)
+
+	cap := server.LastCapture()
+	cap.AssertJSONField(t, "caption", "New caption")
+	cap.AssertJSONField(t, "protect_content", true)
+	cap.AssertJSONField(t, "message_thread_id", float64(7))
+	cap.AssertJSONField(t, "disable_notification", true)
+}
+
+func TestSendopt_Forward(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/forwardMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	editable := mockEditable{msgID: "1", chatID: int64(111)}
+	_, err := client.Forward(context.Background(), editable, int64(222),
+		sendopt.Silent(),
+		sendopt.Caption("ignored"),
+	)
+
+	require.NoError(t, err)
+
+	cap := server.LastCapture()
+	cap.AssertJSONField(t, "disable_notification", true)
+	cap.AssertJSONFieldAbsent(t, "caption")
+}
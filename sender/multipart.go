@@ -2,6 +2,7 @@ package sender
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -12,9 +13,13 @@ import (
 
 // FilePart represents a file to be uploaded via multipart.
 type FilePart struct {
-	FieldName string    // e.g., "photo", "document", "thumbnail"
-	FileName  string    // e.g., "photo.jpg"
-	Reader    io.Reader // File content
+	FieldName string       // e.g., "photo", "document", "thumbnail"
+	FileName  string       // e.g., "photo.jpg"
+	Reader    io.Reader    // File content
+	Size      int64        // Content length if known (0 = unknown)
+	Progress  ProgressFunc // Optional upload progress callback
+
+	owned bool // Reader came from InputFile.Source; close it after encoding
 }
 
 // MultipartRequest represents a request with files and parameters.
@@ -45,8 +50,11 @@ func (e *MultipartEncoder) Close() error {
 	return e.w.Close()
 }
 
-// Encode writes the multipart request.
+// Encode writes the multipart request. Readers opened from InputFile.Source
+// are closed when Encode returns, whether or not it succeeds.
 func (e *MultipartEncoder) Encode(req MultipartRequest) error {
+	defer closeOwned(req.Files)
+
 	// 1. Write all file parts (explicit, type-safe)
 	for _, file := range req.Files {
 		if err := e.writeFile(file); err != nil {
@@ -71,10 +79,44 @@ func (e *MultipartEncoder) writeFile(file FilePart) error {
 	}
 
 	// Stream directly - no buffering
-	_, err = io.Copy(part, file.Reader)
+	r := file.Reader
+	if file.Progress != nil {
+		total := file.Size
+		if total <= 0 {
+			total = -1
+		}
+		r = &progressReader{r: r, total: total, fn: file.Progress}
+	}
+	_, err = io.Copy(part, r)
 	return err
 }
 
+// closeOwned closes the readers of files that Encode owns.
+func closeOwned(files []FilePart) {
+	for _, f := range files {
+		if c, ok := f.Reader.(io.Closer); ok && f.owned {
+			_ = c.Close()
+		}
+	}
+}
+
+// progressReader reports the bytes read through it to fn.
+type progressReader struct {
+	r     io.Reader
+	sent  int64
+	total int64
+	fn    ProgressFunc
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.fn(p.sent, p.total)
+	}
+	return n, err
+}
+
 // BuildMultipartRequest creates a MultipartRequest from a typed request struct.
 // Uses reflection for field iteration, but explicit handling for known types.
 func BuildMultipartRequest(req any) (MultipartRequest, error) {
@@ -88,6 +130,11 @@ func BuildMultipartRequest(req any) (MultipartRequest, error) {
 		rv = rv.Elem()
 	}
 
+	// Maps and other non-struct payloads (e.g. via Invoke) are always sent as JSON
+	if rv.Kind() != reflect.Struct {
+		return result, nil
+	}
+
 	rt := rv.Type()
 	attachIdx := 0
 
@@ -177,46 +224,87 @@ func BuildMultipartRequest(req any) (MultipartRequest, error) {
 }
 
 func handleInputMedia(req *MultipartRequest, fieldName string, media InputMedia, attachIdx *int) error {
-	item := map[string]any{
-		"type": media.Type,
+	item, err := mediaItem(req, "InputMedia.Media", media.Type, media.Media, media.Caption, media.ParseMode, media.Thumbnail, media.Cover, attachIdx)
+	if err != nil {
+		return err
 	}
 
-	switch {
-	case media.Media.FileID != "":
-		item["media"] = media.Media.FileID
+	data, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("JSON marshal InputMedia: %w", err)
+	}
+	req.Params[fieldName] = string(data)
 
-	case media.Media.URL != "":
-		item["media"] = media.Media.URL
+	return nil
+}
 
-	case media.Media.Reader != nil || media.Media.Source != nil:
-		attachName := fmt.Sprintf("file%d", *attachIdx)
-		*attachIdx++
+// mediaItem builds the JSON object of one InputMedia entry. Uploaded files
+// (media, thumbnail, cover, in that order) are added to req as parts named
+// file0, file1, ... by attachIdx and referenced as attach://fileN, so part
+// names are deterministic for a given request. With req nil (plain JSON
+// encoding) uploads are an error. name prefixes error messages.
+func mediaItem(req *MultipartRequest, name, mediaType string, media InputFile, caption, parseMode string, thumb, cover *InputFile, attachIdx *int) (map[string]any, error) {
+	item := map[string]any{
+		"type": mediaType,
+	}
 
-		item["media"] = "attach://" + attachName
-		req.Files = append(req.Files, FilePart{
-			FieldName: attachName,
-			FileName:  media.Media.FileName,
-			Reader:    media.Media.OpenReader(),
-		})
+	ref, err := attachRef(req, media, attachIdx)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", name, err)
+	}
+	item["media"] = ref
 
-	default:
-		return fmt.Errorf("InputMedia.Media must have FileID, URL, or Reader set")
+	for _, sec := range []struct {
+		key  string
+		file *InputFile
+	}{{"thumbnail", thumb}, {"cover", cover}} {
+		if sec.file == nil || sec.file.IsEmpty() {
+			continue
+		}
+		ref, err := attachRef(req, *sec.file, attachIdx)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", name, sec.key, err)
+		}
+		item[sec.key] = ref
 	}
 
-	if media.Caption != "" {
-		item["caption"] = media.Caption
+	if caption != "" {
+		item["caption"] = caption
 	}
-	if media.ParseMode != "" {
-		item["parse_mode"] = media.ParseMode
+	if parseMode != "" {
+		item["parse_mode"] = parseMode
 	}
+	return item, nil
+}
 
-	data, err := json.Marshal(item)
-	if err != nil {
-		return fmt.Errorf("JSON marshal InputMedia: %w", err)
+var errNoFileSource = errors.New("must have FileID, URL, or Reader set")
+
+// attachRef returns the value referencing file: its FileID or URL, or
+// attach://fileN after adding the upload to req as part fileN.
+func attachRef(req *MultipartRequest, file InputFile, attachIdx *int) (string, error) {
+	switch {
+	case file.FileID != "":
+		return file.FileID, nil
+	case file.URL != "":
+		return file.URL, nil
+	case file.IsUpload():
+		if req == nil {
+			return "", fmt.Errorf("upload requires multipart encoding")
+		}
+		attachName := fmt.Sprintf("file%d", *attachIdx)
+		*attachIdx++
+		req.Files = append(req.Files, file.filePart(attachName))
+		return "attach://" + attachName, nil
+	default:
+		return "", errNoFileSource
 	}
-	req.Params[fieldName] = string(data)
+}
 
-	return nil
+// attachFields are secondary file fields that Telegram only accepts as
+// attach://<name> references to a separately named part.
+var attachFields = map[string]bool{
+	"thumbnail": true,
+	"cover":     true,
 }
 
 func handleInputFile(req *MultipartRequest, fieldName string, file InputFile, attachIdx *int) error {
@@ -227,19 +315,23 @@ func handleInputFile(req *MultipartRequest, fieldName string, file InputFile, at
 	case file.URL != "":
 		req.Params[fieldName] = file.URL
 
+	case file.IsUpload() && attachFields[fieldName]:
+		ref, err := attachRef(req, file, attachIdx)
+		if err != nil {
+			return err
+		}
+		req.Params[fieldName] = ref
+
 	case file.Reader != nil || file.Source != nil:
 		// For single file uploads (sendDocument, sendPhoto, etc.),
 		// put file directly in field with the correct name.
 		// The attach:// syntax is only for sendMediaGroup.
-		req.Files = append(req.Files, FilePart{
-			FieldName: fieldName, // Use actual field name: "document", "photo", etc.
-			FileName:  file.FileName,
-			Reader:    file.OpenReader(),
-		})
+		// Use actual field name: "document", "photo", etc.
+		req.Files = append(req.Files, file.filePart(fieldName))
 		// Don't add to Params - the file IS the value
 
 	default:
-		return fmt.Errorf("InputFile must have FileID, URL, or Reader set")
+		return fmt.Errorf("InputFile %w", errNoFileSource)
 	}
 
 	return nil
@@ -250,39 +342,10 @@ func handleInputFileSlice(req *MultipartRequest, fieldName string, files []Input
 	mediaItems := make([]map[string]any, 0, len(files))
 
 	for i, file := range files {
-		item := map[string]any{
-			"type": file.MediaType,
+		item, err := mediaItem(req, "InputFile", file.MediaType, file, file.Caption, file.ParseMode, file.Thumbnail, file.Cover, attachIdx)
+		if err != nil {
+			return fmt.Errorf("item %d: %w", i, err)
 		}
-
-		switch {
-		case file.FileID != "":
-			item["media"] = file.FileID
-
-		case file.URL != "":
-			item["media"] = file.URL
-
-		case file.Reader != nil || file.Source != nil:
-			attachName := fmt.Sprintf("file%d", *attachIdx)
-			*attachIdx++
-
-			item["media"] = "attach://" + attachName
-			req.Files = append(req.Files, FilePart{
-				FieldName: attachName,
-				FileName:  file.FileName,
-				Reader:    file.OpenReader(),
-			})
-
-		default:
-			return fmt.Errorf("item %d: InputFile must have FileID, URL, or Reader set", i)
-		}
-
-		if file.Caption != "" {
-			item["caption"] = file.Caption
-		}
-		if file.ParseMode != "" {
-			item["parse_mode"] = file.ParseMode
-		}
-
 		mediaItems = append(mediaItems, item)
 	}
 
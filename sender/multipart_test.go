@@ -2,13 +2,18 @@ package sender_test
 
 import (
 	"bytes"
+	"context"
 	"io"
+	"net/http"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/prilive-com/galigo/internal/testutil"
 	"github.com/prilive-com/galigo/sender"
 	"github.com/prilive-com/galigo/tg"
 )
@@ -65,6 +70,92 @@ func TestMultipartEncoder_WithFile(t *testing.T) {
 	assert.Contains(t, body, "document")
 }
 
+func TestMultipartEncoder_Progress(t *testing.T) {
+	var buf bytes.Buffer
+	enc := sender.NewMultipartEncoder(&buf)
+
+	var sent, total []int64
+	req := sender.MultipartRequest{
+		Files: []sender.FilePart{
+			{
+				FieldName: "document",
+				FileName:  "test.txt",
+				Reader:    strings.NewReader("test file content"),
+				Size:      17,
+				Progress: func(s, t int64) {
+					sent = append(sent, s)
+					total = append(total, t)
+				},
+			},
+		},
+	}
+
+	require.NoError(t, enc.Encode(req))
+	require.NoError(t, enc.Close())
+
+	require.NotEmpty(t, sent)
+	assert.Equal(t, int64(17), sent[len(sent)-1])
+	assert.Equal(t, int64(17), total[0])
+}
+
+func TestMultipartEncoder_Progress_UnknownSize(t *testing.T) {
+	var buf bytes.Buffer
+	enc := sender.NewMultipartEncoder(&buf)
+
+	var lastTotal int64
+	req := sender.MultipartRequest{
+		Files: []sender.FilePart{
+			{
+				FieldName: "document",
+				FileName:  "test.txt",
+				Reader:    strings.NewReader("abc"),
+				Progress:  func(_, t int64) { lastTotal = t },
+			},
+		},
+	}
+
+	require.NoError(t, enc.Encode(req))
+	assert.Equal(t, int64(-1), lastTotal)
+}
+
+func TestSendDocument_ReportsUploadProgress(t *testing.T) {
+	data := bytes.Repeat([]byte("galigo"), 50_000)
+	var received []byte
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendDocument", func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("document")
+		if err == nil {
+			received, _ = io.ReadAll(file)
+			file.Close()
+		}
+		testutil.ReplyMessage(w, 1)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	var mu sync.Mutex
+	var sent, totals []int64
+	_, err := client.SendDocument(context.Background(), sender.SendDocumentRequest{
+		ChatID: testutil.TestChatID,
+		Document: sender.FromBytes(data, "a.txt").WithProgress(func(n, total int64) {
+			mu.Lock()
+			sent = append(sent, n)
+			totals = append(totals, total)
+			mu.Unlock()
+		}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, data, received)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Greater(t, len(sent), 1, "progress is reported per chunk")
+	assert.True(t, slices.IsSorted(sent))
+	assert.Equal(t, int64(len(data)), sent[len(sent)-1])
+	for _, total := range totals {
+		assert.Equal(t, int64(len(data)), total)
+	}
+}
+
 func TestBuildMultipartRequest_FileID(t *testing.T) {
 	type TestRequest struct {
 		ChatID   int64            `json:"chat_id"`
@@ -146,12 +237,13 @@ func TestBuildMultipartRequest_MultipleUploads(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.True(t, result.HasUploads())
-	// For single file uploads, files go directly in their fields (no attach://)
+	// The primary file goes directly in its field (no attach://)
 	assert.NotContains(t, result.Params, "photo")
-	assert.NotContains(t, result.Params, "thumbnail")
+	// Thumbnails are only accepted as attach:// references
+	assert.Equal(t, "attach://file0", result.Params["thumbnail"])
 	require.Len(t, result.Files, 2)
 	assert.Equal(t, "photo", result.Files[0].FieldName)
-	assert.Equal(t, "thumbnail", result.Files[1].FieldName)
+	assert.Equal(t, "file0", result.Files[1].FieldName)
 }
 
 func TestBuildMultipartRequest_InputFileSlice(t *testing.T) {
@@ -365,3 +457,42 @@ func TestBuildMultipartRequest_ChatType(t *testing.T) {
 	assert.Equal(t, "supergroup", result.Params["chat_type"])
 	assert.NotContains(t, result.Params["chat_type"], "\"")
 }
+
+type closeTracker struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestMultipartEncoder_ClosesSourceReaders(t *testing.T) {
+	tracker := &closeTracker{Reader: strings.NewReader("abc")}
+	file := sender.InputFile{
+		Source:   func() io.Reader { return tracker },
+		FileName: "a.txt",
+	}
+
+	mr, err := sender.BuildMultipartRequest(sender.SendDocumentRequest{ChatID: int64(1), Document: file})
+	require.NoError(t, err)
+
+	enc := sender.NewMultipartEncoder(io.Discard)
+	require.NoError(t, enc.Encode(mr))
+	assert.True(t, tracker.closed)
+}
+
+func TestMultipartEncoder_LeavesCallerReadersOpen(t *testing.T) {
+	tracker := &closeTracker{Reader: strings.NewReader("abc")}
+
+	mr, err := sender.BuildMultipartRequest(sender.SendDocumentRequest{
+		ChatID:   int64(1),
+		Document: sender.FromReader(tracker, "a.txt"),
+	})
+	require.NoError(t, err)
+
+	enc := sender.NewMultipartEncoder(io.Discard)
+	require.NoError(t, enc.Encode(mr))
+	assert.False(t, tracker.closed, "FromReader readers belong to the caller")
+}
@@ -1,90 +1,140 @@
 package sender
 
-import "github.com/prilive-com/galigo/tg"
-
-// EditOption configures edit requests.
-type EditOption func(*EditMessageTextRequest)
+import (
+	"github.com/prilive-com/galigo/tg"
+	"github.com/prilive-com/galigo/tg/sendopt"
+)
+
+// EditOption, ReplyOption, ForwardOption and CopyOption are all
+// sendopt.Option: any option from package sendopt (or the galigo facade)
+// can be passed to Edit, Reply, Quote, Forward and Copy.
+type (
+	// EditOption configures edit requests.
+	EditOption = sendopt.Option
+	// ReplyOption configures Reply and Quote.
+	ReplyOption = sendopt.Option
+	// ForwardOption configures forward requests.
+	ForwardOption = sendopt.Option
+	// CopyOption configures copy requests.
+	CopyOption = sendopt.Option
+)
 
 // WithEditParseMode sets the parse mode for editing.
-func WithEditParseMode(mode tg.ParseMode) EditOption {
-	return func(r *EditMessageTextRequest) {
-		r.ParseMode = mode
-	}
-}
+func WithEditParseMode(mode tg.ParseMode) EditOption { return sendopt.ParseMode(mode) }
 
 // WithEditKeyboard sets the reply markup for editing.
-func WithEditKeyboard(kb *tg.InlineKeyboardMarkup) EditOption {
-	return func(r *EditMessageTextRequest) {
-		r.ReplyMarkup = kb
-	}
-}
+func WithEditKeyboard(kb *tg.InlineKeyboardMarkup) EditOption { return sendopt.Keyboard(kb) }
 
 // WithDisableWebPreview disables web page preview.
+//
+// Deprecated: Use sendopt.NoLinkPreview instead.
 func WithDisableWebPreview(disable bool) EditOption {
-	return func(r *EditMessageTextRequest) {
-		r.DisableWebPagePreview = disable
+	return func(o *sendopt.Options) {
+		o.DisableWebPagePreview = disable
 	}
 }
 
-// ForwardOption configures forward requests.
-type ForwardOption func(*ForwardMessageRequest)
+// WithReplyParseMode sets the parse mode of the reply text.
+func WithReplyParseMode(mode tg.ParseMode) ReplyOption { return sendopt.ParseMode(mode) }
+
+// WithReplyKeyboard attaches an inline keyboard to the reply.
+func WithReplyKeyboard(kb *tg.InlineKeyboardMarkup) ReplyOption { return sendopt.Keyboard(kb) }
+
+// WithSilentReply sends the reply without notification.
+func WithSilentReply() ReplyOption { return sendopt.Silent() }
 
 // Silent disables notification for forwarding.
-func Silent() ForwardOption {
-	return func(r *ForwardMessageRequest) {
-		r.DisableNotification = true
-	}
-}
+func Silent() ForwardOption { return sendopt.Silent() }
 
 // Protected protects content from forwarding.
-func Protected() ForwardOption {
-	return func(r *ForwardMessageRequest) {
-		r.ProtectContent = true
-	}
-}
-
-// CopyOption configures copy requests.
-type CopyOption func(*CopyMessageRequest)
+func Protected() ForwardOption { return sendopt.Protect() }
 
 // WithCopyCaption sets a new caption when copying.
-func WithCopyCaption(caption string) CopyOption {
-	return func(r *CopyMessageRequest) {
-		r.Caption = caption
-	}
-}
+func WithCopyCaption(caption string) CopyOption { return sendopt.Caption(caption) }
 
 // WithCopyParseMode sets parse mode for copied caption.
-func WithCopyParseMode(mode tg.ParseMode) CopyOption {
-	return func(r *CopyMessageRequest) {
-		r.ParseMode = mode
-	}
-}
+func WithCopyParseMode(mode tg.ParseMode) CopyOption { return sendopt.ParseMode(mode) }
 
 // CopySilent disables notification when copying.
-func CopySilent() CopyOption {
-	return func(r *CopyMessageRequest) {
-		r.DisableNotification = true
-	}
-}
+func CopySilent() CopyOption { return sendopt.Silent() }
 
 // CopyProtected protects copied content.
-func CopyProtected() CopyOption {
-	return func(r *CopyMessageRequest) {
-		r.ProtectContent = true
-	}
-}
+func CopyProtected() CopyOption { return sendopt.Protect() }
 
 // WithCopyReply sets reply-to message ID when copying.
-func WithCopyReply(messageID int) CopyOption {
-	return func(r *CopyMessageRequest) {
-		r.ReplyToMessageID = messageID
-	}
-}
+func WithCopyReply(messageID int) CopyOption { return sendopt.ReplyTo(messageID) }
 
 // WithCopyKeyboard sets keyboard when copying.
-func WithCopyKeyboard(kb *tg.InlineKeyboardMarkup) CopyOption {
-	return func(r *CopyMessageRequest) {
-		r.ReplyMarkup = kb
+func WithCopyKeyboard(kb *tg.InlineKeyboardMarkup) CopyOption { return sendopt.Keyboard(kb) }
+
+// ApplySendOptions applies the settings in opts that sendMessage supports.
+func (r *SendMessageRequest) ApplySendOptions(opts ...sendopt.Option) {
+	o := sendopt.Apply(opts...)
+	setIf(&r.ParseMode, o.ParseMode)
+	setIf(&r.ReplyMarkup, o.ReplyMarkup)
+	setIf(&r.LinkPreviewOptions, o.LinkPreview)
+	setIf(&r.DisableWebPagePreview, o.DisableWebPagePreview)
+	setIf(&r.DisableNotification, o.DisableNotification)
+	setIf(&r.ProtectContent, o.ProtectContent)
+	setIf(&r.ReplyToMessageID, o.ReplyToMessageID)
+	setIf(&r.MessageThreadID, o.MessageThreadID)
+	setIf(&r.DirectMessagesTopicID, o.DirectMessagesTopicID)
+	setIf(&r.SuggestedPostParameters, o.SuggestedPost)
+}
+
+// ApplySendOptions applies the settings in opts that sendPhoto supports.
+func (r *SendPhotoRequest) ApplySendOptions(opts ...sendopt.Option) {
+	o := sendopt.Apply(opts...)
+	setIf(&r.Caption, o.Caption)
+	setIf(&r.ParseMode, o.ParseMode)
+	setIf(&r.ReplyMarkup, o.ReplyMarkup)
+	setIf(&r.DisableNotification, o.DisableNotification)
+	setIf(&r.ProtectContent, o.ProtectContent)
+	setIf(&r.ReplyToMessageID, o.ReplyToMessageID)
+	setIf(&r.MessageThreadID, o.MessageThreadID)
+	setIf(&r.DirectMessagesTopicID, o.DirectMessagesTopicID)
+	setIf(&r.SuggestedPostParameters, o.SuggestedPost)
+}
+
+// ApplySendOptions applies the settings in opts that editMessageText supports.
+func (r *EditMessageTextRequest) ApplySendOptions(opts ...sendopt.Option) {
+	o := sendopt.Apply(opts...)
+	setIf(&r.ParseMode, o.ParseMode)
+	setIf(&r.ReplyMarkup, o.ReplyMarkup)
+	setIf(&r.LinkPreviewOptions, o.LinkPreview)
+	setIf(&r.DisableWebPagePreview, o.DisableWebPagePreview)
+}
+
+// ApplySendOptions applies the settings in opts that forwardMessage supports.
+func (r *ForwardMessageRequest) ApplySendOptions(opts ...sendopt.Option) {
+	o := sendopt.Apply(opts...)
+	setIf(&r.DisableNotification, o.DisableNotification)
+	setIf(&r.ProtectContent, o.ProtectContent)
+	setIf(&r.MessageThreadID, o.MessageThreadID)
+	setIf(&r.DirectMessagesTopicID, o.DirectMessagesTopicID)
+	setIf(&r.SuggestedPostParameters, o.SuggestedPost)
+}
+
+// ApplySendOptions applies the settings in opts that copyMessage supports.
+func (r *CopyMessageRequest) ApplySendOptions(opts ...sendopt.Option) {
+	o := sendopt.Apply(opts...)
+	setIf(&r.Caption, o.Caption)
+	setIf(&r.ParseMode, o.ParseMode)
+	setIf(&r.ReplyMarkup, o.ReplyMarkup)
+	setIf(&r.DisableNotification, o.DisableNotification)
+	setIf(&r.ProtectContent, o.ProtectContent)
+	setIf(&r.ReplyToMessageID, o.ReplyToMessageID)
+	setIf(&r.MessageThreadID, o.MessageThreadID)
+	setIf(&r.DirectMessagesTopicID, o.DirectMessagesTopicID)
+	setIf(&r.SuggestedPostParameters, o.SuggestedPost)
+}
+
+// setIf overwrites *dst with v unless v is the zero value, so options only
+// override what they set.
+func setIf[T comparable](dst *T, v T) {
+	var zero T
+	if v != zero {
+		*dst = v
 	}
 }
 
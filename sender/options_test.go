@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -315,3 +316,48 @@ func TestClient_Close(t *testing.T) {
 	err = client.Close()
 	assert.NoError(t, err)
 }
+
+func TestOption_DefaultUserAgent(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{
+		ChatID: testutil.TestChatID,
+		Text:   "Hello",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(server.LastCapture().Headers.Get("User-Agent"), "galigo/"))
+}
+
+func TestOption_WithUserAgentAndExtraHeaders(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL(),
+		sender.WithUserAgent("acme-bot/2.0"),
+		sender.WithExtraHeaders(map[string]string{"X-Egress-Route": "telegram"}),
+		sender.WithExtraHeaders(map[string]string{
+			"X-Team":       "payments",
+			"Content-Type": "text/plain", // must not override the JSON body type
+		}),
+	)
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{
+		ChatID: testutil.TestChatID,
+		Text:   "Hello",
+	})
+	require.NoError(t, err)
+
+	cap := server.LastCapture()
+	cap.AssertHeader(t, "User-Agent", "acme-bot/2.0")
+	cap.AssertHeader(t, "X-Egress-Route", "telegram")
+	cap.AssertHeader(t, "X-Team", "payments")
+	cap.AssertHeader(t, "Content-Type", "application/json")
+}
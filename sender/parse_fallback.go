@@ -0,0 +1,107 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Parse Mode Fallback ==================
+//
+// Telegram rejects a whole message with 400 "can't parse entities" when
+// its text or caption is not valid for its parse_mode, typically because
+// user input was interpolated without escaping. With a ParseFallback the
+// client resends such a request once in a form Telegram accepts, so the
+// recipient gets the message with its markup shown literally instead of
+// no message at all.
+
+// ParseFallback selects how WithParseFallback resends a request whose
+// entities Telegram could not parse.
+type ParseFallback int
+
+const (
+	// ParseFallbackNone returns the error (the default).
+	ParseFallbackNone ParseFallback = iota
+	// ParseFallbackPlain resends without parse_mode.
+	ParseFallbackPlain
+	// ParseFallbackEscape resends with the text and caption escaped for
+	// their parse_mode (see tg.EscapeText).
+	ParseFallbackEscape
+)
+
+// String returns the policy name.
+func (p ParseFallback) String() string {
+	switch p {
+	case ParseFallbackPlain:
+		return "plain"
+	case ParseFallbackEscape:
+		return "escape"
+	default:
+		return "none"
+	}
+}
+
+// WithParseFallback resends a request that failed with
+// tg.ErrCantParseEntities once, downgraded by policy, and logs a warning.
+// It applies to requests with a top-level ParseMode, such as sendMessage,
+// the media sends, copyMessage and the edit methods; parse modes inside
+// media groups and input media are left alone. If the resend fails too,
+// its error is returned.
+func WithParseFallback(policy ParseFallback) Option {
+	return func(c *Client) {
+		c.parseFallback = policy
+	}
+}
+
+// executeWithParseFallback runs execute and, if Telegram could not parse
+// the entities, resends the downgraded payload once.
+func (c *Client) executeWithParseFallback(ctx context.Context, method string, payload any, chatID string) (*apiResponse, error) {
+	resp, err := c.execute(ctx, method, payload, chatID)
+	if err == nil || c.parseFallback == ParseFallbackNone || !errors.Is(err, tg.ErrCantParseEntities) {
+		return resp, err
+	}
+	downgraded, ok := downgradeParseMode(payload, c.parseFallback)
+	if !ok {
+		return resp, err
+	}
+	c.logger.Warn("can't parse entities, resending with parse mode fallback",
+		"method", method, "fallback", c.parseFallback.String(), "error", err)
+	return c.execute(ctx, method, downgraded, chatID)
+}
+
+// downgradeParseMode returns a copy of payload changed by policy, or false
+// if payload has no parse mode to downgrade.
+func downgradeParseMode(payload any, policy ParseFallback) (any, bool) {
+	rv := auditStruct(payload)
+	if !rv.IsValid() {
+		return nil, false
+	}
+	pm := rv.FieldByName("ParseMode")
+	if !pm.IsValid() || pm.Kind() != reflect.String || pm.String() == "" {
+		return nil, false
+	}
+
+	cp := reflect.New(rv.Type())
+	cp.Elem().Set(rv)
+	out := cp.Elem()
+	switch policy {
+	case ParseFallbackPlain:
+		out.FieldByName("ParseMode").SetString("")
+	case ParseFallbackEscape:
+		mode := tg.ParseMode(pm.String())
+		for _, name := range []string{"Text", "Caption"} {
+			if f := out.FieldByName(name); f.IsValid() && f.Kind() == reflect.String && f.CanSet() {
+				f.SetString(tg.EscapeText(mode, f.String()))
+			}
+		}
+	default:
+		return nil, false
+	}
+
+	if reflect.ValueOf(payload).Kind() == reflect.Ptr {
+		return cp.Interface(), true
+	}
+	return out.Interface(), true
+}
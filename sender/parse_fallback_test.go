@@ -0,0 +1,102 @@
+package sender_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// rejectEntitiesOnce fails the first request with "can't parse entities".
+func rejectEntitiesOnce(server *testutil.MockTelegramServer, method string) {
+	var calls atomic.Int32
+	server.On("/bot"+testutil.TestToken+"/"+method, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			testutil.ReplyBadRequest(w, `can't parse entities: Unsupported start tag "name" at byte offset 6`)
+			return
+		}
+		testutil.ReplyMessage(w, 1)
+	})
+}
+
+func TestParseFallback_Disabled(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	rejectEntitiesOnce(server, "sendMessage")
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{
+		ChatID: testutil.TestChatID, Text: "Hello <name>", ParseMode: tg.ParseModeHTML,
+	})
+	assert.ErrorIs(t, err, tg.ErrCantParseEntities)
+	assert.Equal(t, 1, server.CaptureCount())
+}
+
+func TestParseFallback_Plain(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	rejectEntitiesOnce(server, "sendMessage")
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithParseFallback(sender.ParseFallbackPlain))
+
+	msg, err := client.SendMessage(context.Background(), sender.SendMessageRequest{
+		ChatID: testutil.TestChatID, Text: "Hello <name>", ParseMode: tg.ParseModeHTML,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, msg.MessageID)
+
+	require.Equal(t, 2, server.CaptureCount())
+	cap := server.LastCapture()
+	cap.AssertJSONFieldAbsent(t, "parse_mode")
+	cap.AssertJSONField(t, "text", "Hello <name>")
+}
+
+func TestParseFallback_Escape(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	rejectEntitiesOnce(server, "sendPhoto")
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithParseFallback(sender.ParseFallbackEscape))
+
+	_, err := client.SendPhoto(context.Background(), sender.SendPhotoRequest{
+		ChatID:    testutil.TestChatID,
+		Photo:     sender.FromFileID("photo-id"),
+		Caption:   "Hello <name>",
+		ParseMode: tg.ParseModeHTML,
+	})
+	require.NoError(t, err)
+
+	cap := server.LastCapture()
+	cap.AssertJSONField(t, "parse_mode", "HTML")
+	cap.AssertJSONField(t, "caption", "Hello &lt;name&gt;")
+}
+
+func TestParseFallback_OnlyOnce(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBadRequest(w, "can't parse entities: Character '.' is reserved")
+	})
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithParseFallback(sender.ParseFallbackEscape))
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{
+		ChatID: testutil.TestChatID, Text: "v1.2", ParseMode: tg.ParseModeMarkdownV2,
+	})
+	assert.ErrorIs(t, err, tg.ErrCantParseEntities)
+	assert.Equal(t, 2, server.CaptureCount())
+	server.LastCapture().AssertJSONField(t, "text", `v1\.2`)
+}
+
+func TestParseFallback_NoParseMode(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	rejectEntitiesOnce(server, "sendMessage")
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithParseFallback(sender.ParseFallbackPlain))
+
+	// Without a parse mode there is nothing to downgrade.
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{
+		ChatID: testutil.TestChatID, Text: "hi",
+	})
+	assert.ErrorIs(t, err, tg.ErrCantParseEntities)
+	assert.Equal(t, 1, server.CaptureCount())
+}
@@ -10,33 +10,35 @@ import (
 
 // SendInvoiceRequest represents a sendInvoice request.
 type SendInvoiceRequest struct {
-	ChatID                    any                      `json:"chat_id"`
-	MessageThreadID           int                      `json:"message_thread_id,omitempty"`
-	Title                     string                   `json:"title"`
-	Description               string                   `json:"description"`
-	Payload                   string                   `json:"payload"`
-	ProviderToken             string                   `json:"provider_token,omitempty"`
-	Currency                  string                   `json:"currency"`
-	Prices                    []tg.LabeledPrice        `json:"prices"`
-	MaxTipAmount              int                      `json:"max_tip_amount,omitempty"`
-	SuggestedTipAmounts       []int                    `json:"suggested_tip_amounts,omitempty"`
-	StartParameter            string                   `json:"start_parameter,omitempty"`
-	ProviderData              string                   `json:"provider_data,omitempty"`
-	PhotoURL                  string                   `json:"photo_url,omitempty"`
-	PhotoSize                 int                      `json:"photo_size,omitempty"`
-	PhotoWidth                int                      `json:"photo_width,omitempty"`
-	PhotoHeight               int                      `json:"photo_height,omitempty"`
-	NeedName                  bool                     `json:"need_name,omitempty"`
-	NeedPhoneNumber           bool                     `json:"need_phone_number,omitempty"`
-	NeedEmail                 bool                     `json:"need_email,omitempty"`
-	NeedShippingAddress       bool                     `json:"need_shipping_address,omitempty"`
-	SendPhoneNumberToProvider bool                     `json:"send_phone_number_to_provider,omitempty"`
-	SendEmailToProvider       bool                     `json:"send_email_to_provider,omitempty"`
-	IsFlexible                bool                     `json:"is_flexible,omitempty"`
-	DisableNotification       bool                     `json:"disable_notification,omitempty"`
-	ProtectContent            bool                     `json:"protect_content,omitempty"`
-	ReplyParameters           *tg.ReplyParameters      `json:"reply_parameters,omitempty"`
-	ReplyMarkup               *tg.InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	ChatID                    any                         `json:"chat_id"`
+	MessageThreadID           int                         `json:"message_thread_id,omitempty"`
+	DirectMessagesTopicID     int64                       `json:"direct_messages_topic_id,omitempty"` // 9.2
+	Title                     string                      `json:"title"`
+	Description               string                      `json:"description"`
+	Payload                   string                      `json:"payload"`
+	ProviderToken             string                      `json:"provider_token,omitempty"`
+	Currency                  string                      `json:"currency"`
+	Prices                    []tg.LabeledPrice           `json:"prices"`
+	MaxTipAmount              int                         `json:"max_tip_amount,omitempty"`
+	SuggestedTipAmounts       []int                       `json:"suggested_tip_amounts,omitempty"`
+	StartParameter            string                      `json:"start_parameter,omitempty"`
+	ProviderData              string                      `json:"provider_data,omitempty"`
+	PhotoURL                  string                      `json:"photo_url,omitempty"`
+	PhotoSize                 int                         `json:"photo_size,omitempty"`
+	PhotoWidth                int                         `json:"photo_width,omitempty"`
+	PhotoHeight               int                         `json:"photo_height,omitempty"`
+	NeedName                  bool                        `json:"need_name,omitempty"`
+	NeedPhoneNumber           bool                        `json:"need_phone_number,omitempty"`
+	NeedEmail                 bool                        `json:"need_email,omitempty"`
+	NeedShippingAddress       bool                        `json:"need_shipping_address,omitempty"`
+	SendPhoneNumberToProvider bool                        `json:"send_phone_number_to_provider,omitempty"`
+	SendEmailToProvider       bool                        `json:"send_email_to_provider,omitempty"`
+	IsFlexible                bool                        `json:"is_flexible,omitempty"`
+	DisableNotification       bool                        `json:"disable_notification,omitempty"`
+	ProtectContent            bool                        `json:"protect_content,omitempty"`
+	SuggestedPostParameters   *tg.SuggestedPostParameters `json:"suggested_post_parameters,omitempty"` // 9.2
+	ReplyParameters           *tg.ReplyParameters         `json:"reply_parameters,omitempty"`
+	ReplyMarkup               *tg.InlineKeyboardMarkup    `json:"reply_markup,omitempty"`
 }
 
 // CreateInvoiceLinkRequest represents a createInvoiceLink request.
@@ -121,11 +123,7 @@ func (c *Client) SendInvoice(ctx context.Context, req SendInvoiceRequest) (*tg.M
 		return nil, tg.NewValidationError("prices", "at least one price required")
 	}
 
-	var result tg.Message
-	if err := c.callJSON(ctx, "sendInvoice", req, &result, extractChatID(req.ChatID)); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.Message](c, ctx, "sendInvoice", req, extractChatID(req.ChatID))
 }
 
 // CreateInvoiceLink creates a link for an invoice.
@@ -202,18 +200,10 @@ func (c *Client) GetStarTransactions(ctx context.Context, req GetStarTransaction
 		return nil, tg.NewValidationError("limit", "must be 1-100")
 	}
 
-	var result tg.StarTransactions
-	if err := c.callJSON(ctx, "getStarTransactions", req, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.StarTransactions](c, ctx, "getStarTransactions", req)
 }
 
 // GetMyStarBalance returns the bot's current Star balance.
 func (c *Client) GetMyStarBalance(ctx context.Context) (*tg.StarAmount, error) {
-	var result tg.StarAmount
-	if err := c.callJSON(ctx, "getMyStarBalance", struct{}{}, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.StarAmount](c, ctx, "getMyStarBalance", struct{}{})
 }
@@ -2,6 +2,8 @@ package sender
 
 import (
 	"context"
+	"slices"
+	"time"
 
 	"github.com/prilive-com/galigo/tg"
 )
@@ -10,22 +12,27 @@ import (
 
 // SendPollRequest represents a sendPoll request (enhanced version).
 type SendPollRequest struct {
-	ChatID                tg.ChatID         `json:"chat_id"`
-	Question              string            `json:"question"`
-	Options               []InputPollOption `json:"options"`
-	IsAnonymous           *bool             `json:"is_anonymous,omitempty"`
-	Type                  string            `json:"type,omitempty"` // "regular" or "quiz"
-	AllowsMultipleAnswers bool              `json:"allows_multiple_answers,omitempty"`
-	CorrectOptionID       *int              `json:"correct_option_id,omitempty"` // For quiz
-	Explanation           string            `json:"explanation,omitempty"`
-	ExplanationParseMode  tg.ParseMode      `json:"explanation_parse_mode,omitempty"`
-	OpenPeriod            int               `json:"open_period,omitempty"` // 5-600 seconds
-	CloseDate             int64             `json:"close_date,omitempty"`
-	IsClosed              bool              `json:"is_closed,omitempty"`
-	DisableNotification   bool              `json:"disable_notification,omitempty"`
-	ProtectContent        bool              `json:"protect_content,omitempty"`
-	ReplyToMessageID      int               `json:"reply_to_message_id,omitempty"`
-	ReplyMarkup           any               `json:"reply_markup,omitempty"`
+	BusinessConnectionID  string             `json:"business_connection_id,omitempty"`
+	ChatID                tg.ChatID          `json:"chat_id"`
+	MessageThreadID       int                `json:"message_thread_id,omitempty"`
+	Question              string             `json:"question"`
+	QuestionParseMode     tg.ParseMode       `json:"question_parse_mode,omitempty"`
+	QuestionEntities      []tg.MessageEntity `json:"question_entities,omitempty"`
+	Options               []InputPollOption  `json:"options"`
+	IsAnonymous           *bool              `json:"is_anonymous,omitempty"`
+	Type                  string             `json:"type,omitempty"` // tg.PollTypeRegular or tg.PollTypeQuiz
+	AllowsMultipleAnswers bool               `json:"allows_multiple_answers,omitempty"`
+	CorrectOptionID       *int               `json:"correct_option_id,omitempty"` // For quiz
+	Explanation           string             `json:"explanation,omitempty"`
+	ExplanationParseMode  tg.ParseMode       `json:"explanation_parse_mode,omitempty"`
+	ExplanationEntities   []tg.MessageEntity `json:"explanation_entities,omitempty"`
+	OpenPeriod            int                `json:"open_period,omitempty"` // 5-600 seconds
+	CloseDate             int64              `json:"close_date,omitempty"`
+	IsClosed              bool               `json:"is_closed,omitempty"`
+	DisableNotification   bool               `json:"disable_notification,omitempty"`
+	ProtectContent        bool               `json:"protect_content,omitempty"`
+	ReplyToMessageID      int                `json:"reply_to_message_id,omitempty"`
+	ReplyMarkup           any                `json:"reply_markup,omitempty"`
 }
 
 // InputPollOption represents a poll option.
@@ -73,11 +80,7 @@ func (c *Client) SendPollSimple(ctx context.Context, chatID tg.ChatID, question
 		opt(&req)
 	}
 
-	var msg tg.Message
-	if err := c.callJSON(ctx, "sendPoll", req, &msg, extractChatID(chatID)); err != nil {
-		return nil, err
-	}
-	return &msg, nil
+	return call[tg.Message](c, ctx, "sendPoll", req, extractChatID(chatID))
 }
 
 // SendQuiz sends a quiz poll with a correct answer.
@@ -98,18 +101,14 @@ func (c *Client) SendQuiz(ctx context.Context, chatID tg.ChatID, question string
 		ChatID:          chatID,
 		Question:        question,
 		Options:         inputOptions,
-		Type:            "quiz",
+		Type:            tg.PollTypeQuiz,
 		CorrectOptionID: &correctOptionIndex,
 	}
 	for _, opt := range opts {
 		opt(&req)
 	}
 
-	var msg tg.Message
-	if err := c.callJSON(ctx, "sendPoll", req, &msg, extractChatID(chatID)); err != nil {
-		return nil, err
-	}
-	return &msg, nil
+	return call[tg.Message](c, ctx, "sendPoll", req, extractChatID(chatID))
 }
 
 // StopPoll stops a poll and returns the final results.
@@ -129,11 +128,7 @@ func (c *Client) StopPoll(ctx context.Context, chatID tg.ChatID, messageID int,
 		opt(&req)
 	}
 
-	var poll tg.Poll
-	if err := c.callJSON(ctx, "stopPoll", req, &poll, extractChatID(chatID)); err != nil {
-		return nil, err
-	}
-	return &poll, nil
+	return call[tg.Poll](c, ctx, "stopPoll", req, extractChatID(chatID))
 }
 
 // ================== Options ==================
@@ -170,6 +165,49 @@ func WithPollOpenPeriod(seconds int) PollOption {
 	}
 }
 
+// WithPollQuestionParseMode sets the parse mode for the question.
+func WithPollQuestionParseMode(mode tg.ParseMode) PollOption {
+	return func(r *SendPollRequest) {
+		r.QuestionParseMode = mode
+	}
+}
+
+// WithPollQuestionEntities sets explicit entities for the question.
+func WithPollQuestionEntities(entities []tg.MessageEntity) PollOption {
+	return func(r *SendPollRequest) {
+		r.QuestionEntities = entities
+	}
+}
+
+// WithQuizExplanationEntities sets explicit entities for the quiz explanation.
+func WithQuizExplanationEntities(entities []tg.MessageEntity) PollOption {
+	return func(r *SendPollRequest) {
+		r.ExplanationEntities = entities
+	}
+}
+
+// WithPollCloseDate sets when the poll closes (5-600 seconds in the future).
+// Cannot be combined with WithPollOpenPeriod.
+func WithPollCloseDate(t time.Time) PollOption {
+	return func(r *SendPollRequest) {
+		r.CloseDate = t.Unix()
+	}
+}
+
+// WithPollClosed sends the poll already closed, e.g. to preview it.
+func WithPollClosed() PollOption {
+	return func(r *SendPollRequest) {
+		r.IsClosed = true
+	}
+}
+
+// WithPollThread sends the poll to a forum topic.
+func WithPollThread(threadID int) PollOption {
+	return func(r *SendPollRequest) {
+		r.MessageThreadID = threadID
+	}
+}
+
 // StopPollOption configures StopPoll.
 type StopPollOption func(*StopPollRequest)
 
@@ -179,3 +217,93 @@ func WithStopPollReplyMarkup(markup any) StopPollOption {
 		r.ReplyMarkup = markup
 	}
 }
+
+// ================== Quiz Builder ==================
+
+// Quiz builds a quiz SendPollRequest fluently:
+//
+//	req, err := sender.NewQuiz(chatID, "2 + 2 = ?").
+//		Option("3").
+//		Correct("4").
+//		Explanation("Basic arithmetic", tg.ParseModeHTML).
+//		With(sender.WithPollOpenPeriod(60)).
+//		Build()
+//	if err != nil {
+//		return err
+//	}
+//	msg, err := client.SendPoll(ctx, req)
+type Quiz struct {
+	req     SendPollRequest
+	correct []int
+}
+
+// NewQuiz starts a quiz for chatID.
+func NewQuiz(chatID tg.ChatID, question string) *Quiz {
+	return &Quiz{req: SendPollRequest{
+		ChatID:   chatID,
+		Question: question,
+		Type:     tg.PollTypeQuiz,
+	}}
+}
+
+// Option adds a wrong answer.
+func (q *Quiz) Option(text string) *Quiz {
+	return q.Add(InputPollOption{Text: text}, false)
+}
+
+// Correct adds the correct answer.
+func (q *Quiz) Correct(text string) *Quiz {
+	return q.Add(InputPollOption{Text: text}, true)
+}
+
+// Add adds an answer with formatting, marking it correct if requested.
+func (q *Quiz) Add(opt InputPollOption, correct bool) *Quiz {
+	if correct {
+		q.correct = append(q.correct, len(q.req.Options))
+	}
+	q.req.Options = append(q.req.Options, opt)
+	return q
+}
+
+// Explanation sets the text shown after a wrong answer.
+func (q *Quiz) Explanation(text string, parseMode tg.ParseMode) *Quiz {
+	q.req.Explanation = text
+	q.req.ExplanationParseMode = parseMode
+	return q
+}
+
+// With applies PollOptions (anonymity, open period, close date, ...).
+func (q *Quiz) With(opts ...PollOption) *Quiz {
+	for _, opt := range opts {
+		opt(&q.req)
+	}
+	return q
+}
+
+// Build validates the quiz and returns the request.
+func (q *Quiz) Build() (SendPollRequest, error) {
+	if err := validateChatID(q.req.ChatID); err != nil {
+		return SendPollRequest{}, err
+	}
+	if q.req.Question == "" {
+		return SendPollRequest{}, tg.NewValidationError("question", "cannot be empty")
+	}
+	if len(q.req.Options) < 2 {
+		return SendPollRequest{}, tg.NewValidationError("options", "must have at least 2 options")
+	}
+	if len(q.req.Options) > 10 {
+		return SendPollRequest{}, tg.NewValidationError("options", "cannot exceed 10 options")
+	}
+	if len(q.correct) != 1 {
+		return SendPollRequest{}, tg.NewValidationError("correct_option_id", "quiz must have exactly one correct option")
+	}
+	if q.req.OpenPeriod != 0 && q.req.CloseDate != 0 {
+		return SendPollRequest{}, tg.NewValidationError("open_period", "cannot be combined with close_date")
+	}
+
+	req := q.req
+	req.Options = slices.Clone(q.req.Options)
+	correct := q.correct[0]
+	req.CorrectOptionID = &correct
+	return req, nil
+}
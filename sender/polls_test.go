@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -163,3 +164,77 @@ func TestPollOptions(t *testing.T) {
 	}
 	assert.Len(t, opts, 4)
 }
+
+func TestSendPollSimple_FullOptions(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendPoll", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+	closeAt := time.Unix(1700000000, 0)
+
+	_, err := client.SendPollSimple(context.Background(), int64(-100123), "<b>Lunch?</b>",
+		[]string{"Pizza", "Sushi"},
+		sender.WithPollQuestionParseMode(tg.ParseModeHTML),
+		sender.WithPollAnonymous(false),
+		sender.WithMultipleAnswers(),
+		sender.WithPollCloseDate(closeAt),
+		sender.WithPollThread(7),
+	)
+	require.NoError(t, err)
+
+	cap := server.LastCapture()
+	cap.AssertJSONField(t, "question_parse_mode", "HTML")
+	cap.AssertJSONField(t, "is_anonymous", false)
+	cap.AssertJSONField(t, "allows_multiple_answers", true)
+	cap.AssertJSONField(t, "close_date", float64(1700000000))
+	cap.AssertJSONField(t, "message_thread_id", float64(7))
+}
+
+// ==================== Quiz Builder ====================
+
+func TestQuiz_Build(t *testing.T) {
+	req, err := sender.NewQuiz(int64(-100123), "2 + 2 = ?").
+		Option("3").
+		Correct("4").
+		Add(sender.InputPollOption{Text: "<i>5</i>", TextParseMode: tg.ParseModeHTML}, false).
+		Explanation("Basic arithmetic", tg.ParseModeHTML).
+		With(sender.WithPollAnonymous(false), sender.WithPollOpenPeriod(30)).
+		Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, tg.PollTypeQuiz, req.Type)
+	require.NotNil(t, req.CorrectOptionID)
+	assert.Equal(t, 1, *req.CorrectOptionID)
+	assert.Len(t, req.Options, 3)
+	assert.Equal(t, tg.ParseModeHTML, req.Options[2].TextParseMode)
+	assert.Equal(t, "Basic arithmetic", req.Explanation)
+	assert.Equal(t, 30, req.OpenPeriod)
+	require.NotNil(t, req.IsAnonymous)
+	assert.False(t, *req.IsAnonymous)
+}
+
+func TestQuiz_Build_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		quiz  *sender.Quiz
+		field string
+	}{
+		{"empty question", sender.NewQuiz(int64(1), "").Option("a").Correct("b"), "question"},
+		{"too few options", sender.NewQuiz(int64(1), "Q?").Correct("a"), "options"},
+		{"no correct option", sender.NewQuiz(int64(1), "Q?").Option("a").Option("b"), "correct_option_id"},
+		{"two correct options", sender.NewQuiz(int64(1), "Q?").Correct("a").Correct("b"), "correct_option_id"},
+		{"open period and close date", sender.NewQuiz(int64(1), "Q?").Option("a").Correct("b").
+			With(sender.WithPollOpenPeriod(30), sender.WithPollCloseDate(time.Now().Add(time.Minute))), "open_period"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.quiz.Build()
+			var vErr *tg.ValidationError
+			require.ErrorAs(t, err, &vErr)
+			assert.Equal(t, tt.field, vErr.Field)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package sender
+
+import "context"
+
+// ================== Bulk Traffic ==================
+
+type bulkKey struct{}
+
+// WithBulkPriority marks requests made with ctx as bulk traffic
+// (broadcasts, mass notifications). Bulk requests pass through the
+// BulkThrottle configured with WithBulkThrottle, so interactive replies
+// keep flowing when the process is under load.
+func WithBulkPriority(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bulkKey{}, true)
+}
+
+// IsBulk reports whether ctx was marked with WithBulkPriority.
+func IsBulk(ctx context.Context) bool {
+	bulk, _ := ctx.Value(bulkKey{}).(bool)
+	return bulk
+}
+
+// BulkThrottle delays bulk requests. Wait is called before each bulk
+// request; it returns when the request may proceed, or ctx's error.
+type BulkThrottle interface {
+	Wait(ctx context.Context) error
+}
+
+// WithBulkThrottle sets the throttle applied to requests marked with
+// WithBulkPriority. galigo.WithCoupledBackpressure installs one driven by
+// receiver pressure.
+func WithBulkThrottle(t BulkThrottle) Option {
+	return func(c *Client) {
+		c.bulkThrottle = t
+	}
+}
@@ -0,0 +1,59 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+)
+
+type countingThrottle struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (t *countingThrottle) Wait(context.Context) error {
+	t.calls.Add(1)
+	return t.err
+}
+
+func TestBulkThrottle_OnlyBulkRequests(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+	throttle := &countingThrottle{}
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithBulkThrottle(throttle))
+
+	req := sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "hi"}
+	_, err := client.SendMessage(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), throttle.calls.Load())
+
+	_, err = client.SendMessage(sender.WithBulkPriority(context.Background()), req)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), throttle.calls.Load())
+}
+
+func TestBulkThrottle_ErrorAbortsRequest(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	throttle := &countingThrottle{err: errors.New("shed")}
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithBulkThrottle(throttle), sender.WithRetries(0))
+
+	_, err := client.SendMessage(sender.WithBulkPriority(context.Background()),
+		sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "hi"})
+	require.Error(t, err)
+	assert.Equal(t, 0, server.CaptureCount())
+}
+
+func TestIsBulk(t *testing.T) {
+	assert.False(t, sender.IsBulk(context.Background()))
+	assert.True(t, sender.IsBulk(sender.WithBulkPriority(context.Background())))
+}
@@ -0,0 +1,147 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Bot Profiles ==================
+
+// Telegram keeps the bot's name, description and short description per
+// language. SyncProfiles takes the profile of every language at once and
+// sets only the fields that differ from what Telegram has.
+
+// Profile fields, as reported in ProfileChange.Field.
+const (
+	ProfileFieldName             = "name"
+	ProfileFieldDescription      = "description"
+	ProfileFieldShortDescription = "short_description"
+)
+
+// BotProfile is the bot's name, description and short description in one
+// language. An empty field removes the dedicated value, so users of that
+// language see the default one.
+type BotProfile struct {
+	Name             string
+	Description      string
+	ShortDescription string
+}
+
+// ProfileChange is a field set by SyncProfiles.
+type ProfileChange struct {
+	LanguageCode string // "" for the default profile
+	Field        string // one of the ProfileField constants
+	Old          string
+	New          string
+}
+
+// SyncProfiles makes the bot's profiles match profiles, keyed by language
+// code with "" for the default. Languages not in the map are left alone.
+//
+// All profiles are validated and the current values read with the Get
+// methods before anything is set, so a bad profile or a failed read
+// changes nothing. Changes are then applied in language order; if one
+// fails, the fields already set are restored on a best-effort basis and
+// the error is returned together with the changes that could not be
+// rolled back. On success it returns the applied changes.
+func (c *Client) SyncProfiles(ctx context.Context, profiles map[string]BotProfile) ([]ProfileChange, error) {
+	langs := slices.Sorted(maps.Keys(profiles))
+
+	for _, lang := range langs {
+		if err := validateBotProfile(profiles[lang]); err != nil {
+			return nil, fmt.Errorf("sync profiles (%q): %w", lang, err)
+		}
+	}
+
+	var changes []ProfileChange
+	for _, lang := range langs {
+		current, err := c.botProfile(ctx, lang)
+		if err != nil {
+			return nil, fmt.Errorf("sync profiles (%q): %w", lang, err)
+		}
+		want := profiles[lang]
+		for _, f := range []struct{ field, old, new string }{
+			{ProfileFieldName, current.Name, want.Name},
+			{ProfileFieldDescription, current.Description, want.Description},
+			{ProfileFieldShortDescription, current.ShortDescription, want.ShortDescription},
+		} {
+			if f.old != f.new {
+				changes = append(changes, ProfileChange{LanguageCode: lang, Field: f.field, Old: f.old, New: f.new})
+			}
+		}
+	}
+
+	for i, ch := range changes {
+		if err := c.setProfileField(ctx, ch.LanguageCode, ch.Field, ch.New); err != nil {
+			err = fmt.Errorf("sync profiles (%q %s): %w", ch.LanguageCode, ch.Field, err)
+			return c.rollbackProfiles(ctx, changes[:i], err)
+		}
+	}
+	return changes, nil
+}
+
+// rollbackProfiles restores the Old value of applied in reverse order. It
+// returns the changes it could not undo and cause joined with their errors.
+func (c *Client) rollbackProfiles(ctx context.Context, applied []ProfileChange, cause error) ([]ProfileChange, error) {
+	var kept []ProfileChange
+	errs := []error{cause}
+	for _, ch := range slices.Backward(applied) {
+		if err := c.setProfileField(ctx, ch.LanguageCode, ch.Field, ch.Old); err != nil {
+			kept = append(kept, ch)
+			errs = append(errs, fmt.Errorf("rollback (%q %s): %w", ch.LanguageCode, ch.Field, err))
+		}
+	}
+	slices.Reverse(kept)
+	return kept, errors.Join(errs...)
+}
+
+func (c *Client) botProfile(ctx context.Context, lang string) (BotProfile, error) {
+	opt := WithLanguage(lang)
+	name, err := c.GetMyName(ctx, opt)
+	if err != nil {
+		return BotProfile{}, err
+	}
+	desc, err := c.GetMyDescription(ctx, opt)
+	if err != nil {
+		return BotProfile{}, err
+	}
+	short, err := c.GetMyShortDescription(ctx, opt)
+	if err != nil {
+		return BotProfile{}, err
+	}
+	return BotProfile{
+		Name:             name.Name,
+		Description:      desc.Description,
+		ShortDescription: short.ShortDescription,
+	}, nil
+}
+
+func (c *Client) setProfileField(ctx context.Context, lang, field, value string) error {
+	opt := WithLanguage(lang)
+	switch field {
+	case ProfileFieldName:
+		return c.SetMyName(ctx, value, opt)
+	case ProfileFieldDescription:
+		return c.SetMyDescription(ctx, value, opt)
+	default:
+		return c.SetMyShortDescription(ctx, value, opt)
+	}
+}
+
+func validateBotProfile(p BotProfile) error {
+	switch {
+	case utf8.RuneCountInString(p.Name) > 64:
+		return tg.NewValidationError("name", "must be at most 64 characters")
+	case utf8.RuneCountInString(p.Description) > 512:
+		return tg.NewValidationError("description", "must be at most 512 characters")
+	case utf8.RuneCountInString(p.ShortDescription) > 120:
+		return tg.NewValidationError("short_description", "must be at most 120 characters")
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// profileServer keeps bot profile fields per language, like Telegram.
+type profileServer struct {
+	mu     sync.Mutex
+	fields map[string]string // "field|lang" -> value
+	sets   []string
+	fail   string // "field|lang" whose set fails
+}
+
+func newProfileServer(t *testing.T, initial map[string]string) (*profileServer, *testutil.MockTelegramServer) {
+	t.Helper()
+	ps := &profileServer{fields: initial}
+	server := testutil.NewMockServer(t)
+	for _, field := range []string{"name", "description", "short_description"} {
+		method := map[string]string{"name": "Name", "description": "Description", "short_description": "ShortDescription"}[field]
+		server.On("/bot"+testutil.TestToken+"/getMy"+method, func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				LanguageCode string `json:"language_code"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			ps.mu.Lock()
+			defer ps.mu.Unlock()
+			testutil.ReplyOK(w, map[string]string{field: ps.fields[field+"|"+req.LanguageCode]})
+		})
+		server.On("/bot"+testutil.TestToken+"/setMy"+method, func(w http.ResponseWriter, r *http.Request) {
+			var req map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			key := field + "|" + req["language_code"]
+			ps.mu.Lock()
+			defer ps.mu.Unlock()
+			if key == ps.fail {
+				testutil.ReplyBadRequest(w, "Bad Request: failed")
+				return
+			}
+			ps.sets = append(ps.sets, key)
+			ps.fields[key] = req[field]
+			testutil.ReplyOK(w, true)
+		})
+	}
+	return ps, server
+}
+
+func TestSyncProfiles(t *testing.T) {
+	ps, server := newProfileServer(t, map[string]string{
+		"name|":                "Shop Bot",
+		"description|":         "Old description",
+		"short_description|":   "Buy things",
+		"name|de":              "Laden Bot",
+		"short_description|de": "Dinge kaufen",
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	changes, err := client.SyncProfiles(context.Background(), map[string]sender.BotProfile{
+		"":   {Name: "Shop Bot", Description: "New description", ShortDescription: "Buy things"},
+		"de": {Name: "Laden Bot", Description: "Neue Beschreibung"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []sender.ProfileChange{
+		{LanguageCode: "", Field: sender.ProfileFieldDescription, Old: "Old description", New: "New description"},
+		{LanguageCode: "de", Field: sender.ProfileFieldDescription, Old: "", New: "Neue Beschreibung"},
+		{LanguageCode: "de", Field: sender.ProfileFieldShortDescription, Old: "Dinge kaufen", New: ""},
+	}, changes)
+	assert.Equal(t, "", ps.fields["short_description|de"])
+
+	// A second run has nothing to do.
+	ps.sets = nil
+	changes, err = client.SyncProfiles(context.Background(), map[string]sender.BotProfile{
+		"":   {Name: "Shop Bot", Description: "New description", ShortDescription: "Buy things"},
+		"de": {Name: "Laden Bot", Description: "Neue Beschreibung"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Empty(t, ps.sets)
+}
+
+func TestSyncProfiles_RollsBack(t *testing.T) {
+	ps, server := newProfileServer(t, map[string]string{"name|": "Old", "name|de": "Alt"})
+	ps.fail = "name|de"
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	changes, err := client.SyncProfiles(context.Background(), map[string]sender.BotProfile{
+		"":   {Name: "New"},
+		"de": {Name: "Neu"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `sync profiles ("de" name)`)
+	assert.Empty(t, changes)
+	assert.Equal(t, []string{"name|", "name|"}, ps.sets)
+	assert.Equal(t, "Old", ps.fields["name|"])
+}
+
+func TestSyncProfiles_ValidatesFirst(t *testing.T) {
+	ps, server := newProfileServer(t, map[string]string{})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	_, err := client.SyncProfiles(context.Background(), map[string]sender.BotProfile{
+		"":   {Name: "Fine"},
+		"de": {ShortDescription: string(make([]byte, 121))},
+	})
+	var vErr *tg.ValidationError
+	require.ErrorAs(t, err, &vErr)
+	assert.Equal(t, "short_description", vErr.Field)
+	assert.Empty(t, ps.sets)
+	assert.Equal(t, 0, server.CaptureCount())
+
+	// Limits count characters, not bytes.
+	_, err = client.SyncProfiles(context.Background(), map[string]sender.BotProfile{
+		"ru": {Name: strings.Repeat("б", 64), ShortDescription: strings.Repeat("я", 120)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("б", 64), ps.fields["name|ru"])
+}
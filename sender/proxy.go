@@ -0,0 +1,29 @@
+package sender
+
+import (
+	"context"
+
+	"github.com/prilive-com/galigo/internal/proxy"
+)
+
+// ================== Proxy ==================
+
+// WithProxy sends requests through the first reachable of the given proxy
+// URLs (http://, https:// or socks5://, with optional user:pass@). When a
+// proxy cannot be reached the next one takes over, and retries use it.
+// Invalid URLs make New fail. It has no effect with WithHTTPClient.
+func WithProxy(urls ...string) Option {
+	return func(c *Client) {
+		c.proxy, c.proxyErr = proxy.Parse(urls...)
+	}
+}
+
+// CheckProxy connects to the configured proxies, starting with the
+// current one, and switches to the first reachable one. It returns an
+// error if none can be reached, and nil without WithProxy.
+func (c *Client) CheckProxy(ctx context.Context) error {
+	if c.proxy == nil {
+		return nil
+	}
+	return c.proxy.Check(ctx)
+}
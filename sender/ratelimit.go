@@ -0,0 +1,201 @@
+package sender
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/prilive-com/galigo/clock"
+)
+
+// RateLimiter paces outgoing requests. Wait blocks until a request to
+// chatID ("" for requests without a chat) may be sent, or returns an
+// error once ctx is done. Implementations must be safe for concurrent use.
+//
+// A Client uses a MemoryRateLimiter built from its Config unless
+// WithRateLimiter is given; RedisRateLimiter shares the limits between
+// replicas.
+type RateLimiter interface {
+	Wait(ctx context.Context, chatID string) error
+}
+
+// WeightedRateLimiter is a RateLimiter that can take several tokens at
+// once, for requests costing more than one (see WithMethodCosts). The
+// client calls Wait n times on limiters that do not implement it.
+type WeightedRateLimiter interface {
+	RateLimiter
+	// WaitN blocks until n tokens for chatID are available. n may exceed
+	// the burst; the tokens are then taken in several steps.
+	WaitN(ctx context.Context, chatID string, n int) error
+}
+
+// WithRateLimiter replaces the client's built-in global and per-chat
+// limits with l. WithSharedRateLimiter still applies on top.
+func WithRateLimiter(l RateLimiter) Option {
+	return func(c *Client) {
+		c.rateLimiter = l
+	}
+}
+
+// chatLimiterIdle is how long a chat's limiter is kept unused.
+const chatLimiterIdle = 10 * time.Minute
+
+// MemoryRateLimiter is the in-process RateLimiter: one token bucket for
+// all requests plus one per chat, with the lower group rate for negative
+// chat IDs. Per-chat buckets are capped at MaxChatLimiters, evicting the
+// least recently used.
+type MemoryRateLimiter struct {
+	global       *rate.Limiter
+	perChatRPS   float64
+	perChatBurst int
+	groupRPS     float64
+	groupBurst   int
+	maxChats     int
+	clock        clock.Clock
+
+	mu    sync.RWMutex
+	chats map[string]*chatLimiterEntry
+}
+
+// chatLimiterEntry wraps a rate limiter with last used timestamp.
+// lastUsed uses atomic.Int64 (Unix nanos) to avoid write-lock contention on the hot path.
+type chatLimiterEntry struct {
+	limiter  *rate.Limiter
+	lastUsed atomic.Int64 // UnixNano timestamp
+}
+
+var _ WeightedRateLimiter = (*MemoryRateLimiter)(nil)
+
+// NewMemoryRateLimiter returns a MemoryRateLimiter with the GlobalRPS,
+// GlobalBurst, PerChatRPS, PerChatBurst, GroupRPS, GroupBurst and
+// MaxChatLimiters of cfg.
+func NewMemoryRateLimiter(cfg Config) *MemoryRateLimiter {
+	return newMemoryRateLimiter(cfg, clock.Real)
+}
+
+func newMemoryRateLimiter(cfg Config, clk clock.Clock) *MemoryRateLimiter {
+	maxChats := cfg.MaxChatLimiters
+	if maxChats <= 0 {
+		maxChats = 10000
+	}
+	return &MemoryRateLimiter{
+		global:       rate.NewLimiter(rate.Limit(cfg.GlobalRPS), cfg.GlobalBurst),
+		perChatRPS:   cfg.PerChatRPS,
+		perChatBurst: cfg.PerChatBurst,
+		groupRPS:     cfg.GroupRPS,
+		groupBurst:   cfg.GroupBurst,
+		maxChats:     maxChats,
+		clock:        clk,
+		chats:        make(map[string]*chatLimiterEntry),
+	}
+}
+
+// Wait waits for chatID's bucket, then for the global one.
+func (l *MemoryRateLimiter) Wait(ctx context.Context, chatID string) error {
+	return l.WaitN(ctx, chatID, 1)
+}
+
+// WaitN waits for n tokens from chatID's bucket, then from the global one.
+func (l *MemoryRateLimiter) WaitN(ctx context.Context, chatID string, n int) error {
+	if err := l.waitChat(ctx, chatID, n); err != nil {
+		return err
+	}
+	return l.waitGlobal(ctx, n)
+}
+
+// waitChat waits for n tokens from chatID's bucket only.
+func (l *MemoryRateLimiter) waitChat(ctx context.Context, chatID string, n int) error {
+	return waitTokens(ctx, l.chatLimiter(chatID), n)
+}
+
+// waitGlobal waits for n tokens from the global bucket only.
+func (l *MemoryRateLimiter) waitGlobal(ctx context.Context, n int) error {
+	return waitTokens(ctx, l.global, n)
+}
+
+// waitTokens takes n tokens from lim, at most a burst at a time, since
+// rate.Limiter rejects larger requests.
+func waitTokens(ctx context.Context, lim *rate.Limiter, n int) error {
+	for n > 0 {
+		k := min(n, max(lim.Burst(), 1))
+		if err := lim.WaitN(ctx, k); err != nil {
+			return err
+		}
+		n -= k
+	}
+	return nil
+}
+
+// Sweep drops the buckets of chats idle for longer than idle.
+func (l *MemoryRateLimiter) Sweep(idle time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	threshold := l.clock.Now().Add(-idle).UnixNano()
+	for chatID, entry := range l.chats {
+		if entry.lastUsed.Load() < threshold {
+			delete(l.chats, chatID)
+		}
+	}
+}
+
+// Len returns the number of per-chat buckets.
+func (l *MemoryRateLimiter) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.chats)
+}
+
+func (l *MemoryRateLimiter) chatLimiter(chatID string) *rate.Limiter {
+	now := l.clock.Now().UnixNano()
+
+	l.mu.RLock()
+	entry, exists := l.chats[chatID]
+	l.mu.RUnlock()
+
+	if exists {
+		entry.lastUsed.Store(now) // Lock-free atomic update
+		return entry.limiter
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Double-check after acquiring write lock
+	if entry, exists = l.chats[chatID]; exists {
+		entry.lastUsed.Store(now)
+		return entry.limiter
+	}
+
+	// Use lower rate for group chats (negative numeric IDs)
+	rps, burst := l.perChatRPS, l.perChatBurst
+	if l.groupRPS > 0 {
+		if id, err := strconv.ParseInt(chatID, 10, 64); err == nil && id < 0 {
+			rps, burst = l.groupRPS, l.groupBurst
+		}
+	}
+
+	// Evict oldest if at capacity
+	if len(l.chats) >= l.maxChats {
+		var oldestKey string
+		oldestTime := now
+		for k, e := range l.chats {
+			if t := e.lastUsed.Load(); t < oldestTime {
+				oldestTime = t
+				oldestKey = k
+			}
+		}
+		if oldestKey != "" {
+			delete(l.chats, oldestKey)
+		}
+	}
+
+	entry = &chatLimiterEntry{limiter: rate.NewLimiter(rate.Limit(rps), burst)}
+	entry.lastUsed.Store(now)
+	l.chats[chatID] = entry
+	return entry.limiter
+}
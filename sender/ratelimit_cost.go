@@ -0,0 +1,57 @@
+package sender
+
+import "context"
+
+// ================== Request Cost ==================
+//
+// Telegram's limits count messages, not requests: a media group of ten
+// items counts as ten messages, and so does copyMessages with ten IDs.
+// Each request therefore takes as many rate limiter tokens as messages it
+// sends, and chat actions, which send none, take none.
+
+// requestCost returns the default number of rate limiter tokens a request
+// takes.
+func requestCost(method string, payload any) int {
+	switch method {
+	case "sendMediaGroup":
+		return max(payloadSliceLen(payload, "Media"), 1)
+	case "copyMessages", "forwardMessages":
+		return max(payloadSliceLen(payload, "MessageIDs"), 1)
+	case "sendChatAction":
+		return 0
+	}
+	return 1
+}
+
+// WithMethodCosts sets how many rate limiter tokens each request to a
+// method takes, overriding the defaults: one per message sent (so
+// sendMediaGroup takes one per item and copyMessages one per ID), none
+// for sendChatAction, and one for everything else. A cost of 0 exempts a
+// method from rate limiting. Methods missing from costs keep the default.
+func WithMethodCosts(costs map[string]int) Option {
+	return func(c *Client) {
+		c.methodCosts = costs
+	}
+}
+
+// cost returns the number of tokens a request takes on this client.
+func (c *Client) cost(method string, payload any) int {
+	if n, ok := c.methodCosts[method]; ok {
+		return max(n, 0)
+	}
+	return requestCost(method, payload)
+}
+
+// waitLimiter takes n tokens from l, calling Wait n times if l is not a
+// WeightedRateLimiter.
+func waitLimiter(ctx context.Context, l RateLimiter, chatID string, n int) error {
+	if w, ok := l.(WeightedRateLimiter); ok {
+		return w.WaitN(ctx, chatID, n)
+	}
+	for range n {
+		if err := l.Wait(ctx, chatID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/prilive-com/galigo/clock"
+)
+
+// ================== Distributed Rate Limiting ==================
+//
+// Telegram's limits apply per bot, not per process: three replicas with
+// their own 30 msg/s buckets send up to 90 msg/s. RedisRateLimiter keeps
+// the buckets in Redis and updates them atomically with a Lua script, so
+// all replicas draw from the same ones. galigo does not depend on a Redis
+// client; RedisEval adapts whichever one the application uses.
+
+// RedisEval runs a Lua script on Redis and returns its reply. With
+// go-redis:
+//
+//	eval := func(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+//	    return rdb.Eval(ctx, script, keys, args...).Result()
+//	}
+type RedisEval func(ctx context.Context, script string, keys []string, args ...any) (any, error)
+
+// DefaultRedisRateLimitPrefix prefixes the bucket keys.
+const DefaultRedisRateLimitPrefix = "galigo:ratelimit:"
+
+// redisTokenBucket takes ARGV[3] tokens (default 1) from the bucket
+// KEYS[1], refilled at ARGV[1] tokens per second up to ARGV[2], and
+// returns 0, or the milliseconds until they are available without taking
+// any. Redis's own clock is used, so replicas need not agree on the time.
+const redisTokenBucket = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local n = tonumber(ARGV[3]) or 1
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) / 1000000 * rate)
+local wait = 0
+if tokens >= n then
+  tokens = tokens - n
+else
+  wait = math.ceil((n - tokens) / rate * 1000)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return wait
+`
+
+// RedisRateLimiter is a RateLimiter whose global and per-chat token
+// buckets live in Redis, shared by every replica using the same prefix.
+// The rates come from Config as for MemoryRateLimiter.
+type RedisRateLimiter struct {
+	eval     RedisEval
+	prefix   string
+	cfg      Config
+	fallback RateLimiter
+	clock    clock.Clock
+}
+
+var _ WeightedRateLimiter = (*RedisRateLimiter)(nil)
+
+// RedisRateLimiterOption configures a RedisRateLimiter.
+type RedisRateLimiterOption func(*RedisRateLimiter)
+
+// WithRedisPrefix sets the bucket key prefix (default
+// DefaultRedisRateLimitPrefix). Bots sharing a Redis need distinct
+// prefixes, e.g. including the bot ID.
+func WithRedisPrefix(prefix string) RedisRateLimiterOption {
+	return func(l *RedisRateLimiter) {
+		l.prefix = prefix
+	}
+}
+
+// WithRedisFallback makes Wait use fallback (typically a
+// MemoryRateLimiter) while Redis fails, instead of returning the error.
+// Tokens already taken from Redis are not taken again: a MemoryRateLimiter
+// fallback is charged only for the buckets, and tokens, still owed; any
+// other limiter for the rest of the request.
+func WithRedisFallback(fallback RateLimiter) RedisRateLimiterOption {
+	return func(l *RedisRateLimiter) {
+		l.fallback = fallback
+	}
+}
+
+// WithRedisClock sets the clock used to wait for tokens.
+func WithRedisClock(clk clock.Clock) RedisRateLimiterOption {
+	return func(l *RedisRateLimiter) {
+		l.clock = clk
+	}
+}
+
+// NewRedisRateLimiter returns a RedisRateLimiter running its script with
+// eval and the GlobalRPS, GlobalBurst, PerChatRPS, PerChatBurst, GroupRPS
+// and GroupBurst of cfg.
+func NewRedisRateLimiter(eval RedisEval, cfg Config, opts ...RedisRateLimiterOption) *RedisRateLimiter {
+	l := &RedisRateLimiter{
+		eval:   eval,
+		prefix: DefaultRedisRateLimitPrefix,
+		cfg:    cfg,
+		clock:  clock.Real,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Wait takes a token from chatID's bucket, then from the global one,
+// waiting as long as Redis says each is empty.
+func (l *RedisRateLimiter) Wait(ctx context.Context, chatID string) error {
+	return l.WaitN(ctx, chatID, 1)
+}
+
+// WaitN takes n tokens from chatID's bucket, then from the global one.
+func (l *RedisRateLimiter) WaitN(ctx context.Context, chatID string, n int) error {
+	rps, burst := l.cfg.PerChatRPS, l.cfg.PerChatBurst
+	if l.cfg.GroupRPS > 0 {
+		if id, err := strconv.ParseInt(chatID, 10, 64); err == nil && id < 0 {
+			rps, burst = l.cfg.GroupRPS, l.cfg.GroupBurst
+		}
+	}
+	if taken, err := l.take(ctx, l.prefix+"chat:"+chatID, rps, burst, n); err != nil {
+		return l.fallBack(ctx, chatID, n-taken, n, err)
+	}
+	if taken, err := l.take(ctx, l.prefix+"global", l.cfg.GlobalRPS, l.cfg.GlobalBurst, n); err != nil {
+		return l.fallBack(ctx, chatID, 0, n-taken, err)
+	}
+	return nil
+}
+
+// take waits until the bucket at key yields n tokens, at most a burst at a
+// time, and returns how many it took. rps <= 0 means no limit.
+func (l *RedisRateLimiter) take(ctx context.Context, key string, rps float64, burst, n int) (int, error) {
+	if rps <= 0 {
+		return n, nil
+	}
+	burst = max(burst, 1)
+	taken := 0
+	for taken < n {
+		k := min(n-taken, burst)
+		if err := l.takeOnce(ctx, key, rps, burst, k); err != nil {
+			return taken, err
+		}
+		taken += k
+	}
+	return taken, nil
+}
+
+func (l *RedisRateLimiter) takeOnce(ctx context.Context, key string, rps float64, burst, n int) error {
+	args := []any{rps, burst}
+	if n != 1 {
+		args = append(args, n)
+	}
+	for {
+		reply, err := l.eval(ctx, redisTokenBucket, []string{key}, args...)
+		if err != nil {
+			return &redisError{err: err}
+		}
+		wait, err := redisMillis(reply)
+		if err != nil {
+			return &redisError{err: err}
+		}
+		if wait <= 0 {
+			return nil
+		}
+		if err := l.clock.Sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// bucketLimiter is a fallback whose chat and global buckets can be charged
+// separately, like MemoryRateLimiter.
+type bucketLimiter interface {
+	waitChat(ctx context.Context, chatID string, n int) error
+	waitGlobal(ctx context.Context, n int) error
+}
+
+// fallBack returns err, or, if Redis failed and a fallback is set, takes
+// the chatN and globalN tokens Redis did not take from it.
+func (l *RedisRateLimiter) fallBack(ctx context.Context, chatID string, chatN, globalN int, err error) error {
+	var rErr *redisError
+	if l.fallback == nil || !errors.As(err, &rErr) {
+		return err
+	}
+	b, ok := l.fallback.(bucketLimiter)
+	if !ok {
+		return waitLimiter(ctx, l.fallback, chatID, max(chatN, globalN))
+	}
+	if err := b.waitChat(ctx, chatID, chatN); err != nil {
+		return err
+	}
+	return b.waitGlobal(ctx, globalN)
+}
+
+type redisError struct{ err error }
+
+func (e *redisError) Error() string { return "galigo: redis rate limiter: " + e.err.Error() }
+func (e *redisError) Unwrap() error { return e.err }
+
+// redisMillis converts the script's reply to a duration.
+func redisMillis(reply any) (time.Duration, error) {
+	switch v := reply.(type) {
+	case int64:
+		return time.Duration(v) * time.Millisecond, nil
+	case int:
+		return time.Duration(v) * time.Millisecond, nil
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("unexpected reply %q", v)
+		}
+		return time.Duration(n) * time.Millisecond, nil
+	}
+	return 0, fmt.Errorf("unexpected reply %T", reply)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/prilive-com/galigo/clock"
 	"github.com/prilive-com/galigo/internal/testutil"
 	"github.com/prilive-com/galigo/sender"
 )
@@ -218,3 +219,209 @@ func TestRateLimit_ConcurrentRequests(t *testing.T) {
 
 	assert.Equal(t, int32(10), requestCount.Load())
 }
+
+type recordingLimiter struct {
+	mu    sync.Mutex
+	chats []string
+	err   error
+}
+
+func (l *recordingLimiter) Wait(_ context.Context, chatID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.chats = append(l.chats, chatID)
+	return l.err
+}
+
+func TestRateLimit_WithRateLimiter(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+	limiter := &recordingLimiter{}
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithRateLimiter(limiter))
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: int64(-100), Text: "hi"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-100"}, limiter.chats)
+	assert.Equal(t, 0, client.ChatLimiterCount(), "built-in limiter is not used")
+
+	limiter.err = context.DeadlineExceeded
+	_, err = client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: int64(-100), Text: "hi"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, server.CaptureCount(), "nothing sent when the limiter refuses")
+}
+
+func TestRateLimit_MethodCosts(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMediaGroup", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, []map[string]any{})
+	})
+	photos := []sender.InputFile{
+		sender.FromURL("https://example.com/1.jpg").WithMediaType("photo"),
+		sender.FromURL("https://example.com/2.jpg").WithMediaType("photo"),
+		sender.FromURL("https://example.com/3.jpg").WithMediaType("photo"),
+	}
+	ctx := context.Background()
+
+	limiter := &recordingLimiter{}
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithRateLimiter(limiter))
+
+	_, err := client.SendMediaGroup(ctx, sender.SendMediaGroupRequest{ChatID: int64(7), Media: photos})
+	require.NoError(t, err)
+	assert.Len(t, limiter.chats, 3, "one token per media group item")
+
+	require.NoError(t, client.SendChatAction(ctx, int64(7), "typing"))
+	assert.Len(t, limiter.chats, 3, "chat actions are free")
+
+	limiter = &recordingLimiter{}
+	client = testutil.NewTestClient(t, server.BaseURL(), sender.WithRateLimiter(limiter),
+		sender.WithMethodCosts(map[string]int{"sendMediaGroup": 1, "sendChatAction": 2}))
+
+	_, err = client.SendMediaGroup(ctx, sender.SendMediaGroupRequest{ChatID: int64(7), Media: photos})
+	require.NoError(t, err)
+	require.NoError(t, client.SendChatAction(ctx, int64(7), "typing"))
+	assert.Len(t, limiter.chats, 3)
+}
+
+func TestMemoryRateLimiter_WaitN(t *testing.T) {
+	cfg := sender.DefaultConfig()
+	cfg.GlobalRPS, cfg.GlobalBurst = 1000, 2
+	cfg.PerChatRPS, cfg.PerChatBurst = 1000, 1
+	l := sender.NewMemoryRateLimiter(cfg)
+
+	require.NoError(t, l.WaitN(context.Background(), "1", 5), "more than a burst is taken in steps")
+	require.NoError(t, l.WaitN(context.Background(), "1", 0))
+}
+
+func TestMemoryRateLimiter(t *testing.T) {
+	cfg := sender.DefaultConfig()
+	cfg.MaxChatLimiters = 2
+	l := sender.NewMemoryRateLimiter(cfg)
+	ctx := context.Background()
+
+	for _, chat := range []string{"1", "2", "3"} {
+		require.NoError(t, l.Wait(ctx, chat))
+	}
+	assert.Equal(t, 2, l.Len(), "least recently used chat evicted")
+
+	l.Sweep(-time.Second) // everything counts as idle
+	assert.Equal(t, 0, l.Len())
+}
+
+// fakeRedis answers the token bucket script from a queue of waits (in
+// milliseconds) and records the keys and arguments. With err set, it fails
+// every call after the first healthy ones.
+type fakeRedis struct {
+	mu      sync.Mutex
+	waits   []any
+	keys    []string
+	args    [][]any
+	err     error
+	healthy int
+}
+
+func (f *fakeRedis) eval(_ context.Context, script string, keys []string, args ...any) (any, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil && f.healthy == 0 {
+		return nil, f.err
+	}
+	f.healthy--
+	f.keys = append(f.keys, keys...)
+	f.args = append(f.args, args)
+	if len(f.waits) == 0 {
+		return int64(0), nil
+	}
+	wait := f.waits[0]
+	f.waits = f.waits[1:]
+	return wait, nil
+}
+
+func TestRedisRateLimiter(t *testing.T) {
+	cfg := sender.DefaultConfig()
+	redis := &fakeRedis{waits: []any{int64(0), int64(250), "0"}}
+	sleeper := &testutil.FakeSleeper{}
+	clk := &sleeperClock{FakeSleeper: sleeper}
+	l := sender.NewRedisRateLimiter(redis.eval, cfg, sender.WithRedisPrefix("bot42:"), sender.WithRedisClock(clk))
+
+	require.NoError(t, l.Wait(context.Background(), "-100"))
+
+	assert.Equal(t, []string{"bot42:chat:-100", "bot42:global", "bot42:global"}, redis.keys)
+	assert.Equal(t, []any{cfg.GroupRPS, cfg.GroupBurst}, redis.args[0], "group rate for negative chat IDs")
+	assert.Equal(t, []any{cfg.GlobalRPS, cfg.GlobalBurst}, redis.args[1])
+	assert.Equal(t, []time.Duration{250 * time.Millisecond}, sleeper.Calls())
+}
+
+func TestRedisRateLimiter_WaitN(t *testing.T) {
+	cfg := sender.DefaultConfig()
+	cfg.PerChatRPS, cfg.PerChatBurst = 1, 3
+	redis := &fakeRedis{}
+	l := sender.NewRedisRateLimiter(redis.eval, cfg)
+
+	require.NoError(t, l.WaitN(context.Background(), "1", 5))
+
+	assert.Equal(t, []string{"galigo:ratelimit:chat:1", "galigo:ratelimit:chat:1", "galigo:ratelimit:global"}, redis.keys)
+	assert.Equal(t, []any{cfg.PerChatRPS, 3, 3}, redis.args[0], "a burst at a time")
+	assert.Equal(t, []any{cfg.PerChatRPS, 3, 2}, redis.args[1])
+	assert.Equal(t, []any{cfg.GlobalRPS, cfg.GlobalBurst, 5}, redis.args[2])
+}
+
+func TestRedisRateLimiter_Errors(t *testing.T) {
+	down := errors.New("connection refused")
+	redis := &fakeRedis{err: down}
+
+	l := sender.NewRedisRateLimiter(redis.eval, sender.DefaultConfig())
+	err := l.Wait(context.Background(), "1")
+	assert.ErrorIs(t, err, down)
+	assert.True(t, strings.HasPrefix(err.Error(), "galigo: redis rate limiter:"))
+
+	fallback := &recordingLimiter{}
+	l = sender.NewRedisRateLimiter(redis.eval, sender.DefaultConfig(), sender.WithRedisFallback(fallback))
+	require.NoError(t, l.Wait(context.Background(), "1"))
+	assert.Equal(t, []string{"1"}, fallback.chats)
+
+	redis = &fakeRedis{waits: []any{[]byte("?")}}
+	l = sender.NewRedisRateLimiter(redis.eval, sender.DefaultConfig())
+	assert.ErrorContains(t, l.Wait(context.Background(), "1"), "unexpected reply")
+}
+
+func TestRedisRateLimiter_FallbackChargesOnlyWhatRedisDidNot(t *testing.T) {
+	down := errors.New("connection refused")
+	cfg := sender.DefaultConfig()
+	cfg.PerChatRPS, cfg.PerChatBurst = 0.001, 2
+	cfg.GlobalRPS, cfg.GlobalBurst = 0.001, 3
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// Redis takes the chat tokens, then fails on the global bucket.
+	fallback := sender.NewMemoryRateLimiter(cfg)
+	l := sender.NewRedisRateLimiter((&fakeRedis{err: down, healthy: 1}).eval, cfg, sender.WithRedisFallback(fallback))
+	require.NoError(t, l.WaitN(ctx, "1", 2))
+	assert.NoError(t, fallback.Wait(ctx, "1"), "chat bucket not charged")
+	assert.Error(t, fallback.Wait(ctx, "2"), "global bucket charged")
+
+	// Redis takes the first burst of 3 chat tokens, then fails.
+	cfg.GlobalBurst = 10
+	fallback = sender.NewMemoryRateLimiter(cfg)
+	l = sender.NewRedisRateLimiter((&fakeRedis{err: down, healthy: 1}).eval, cfg, sender.WithRedisFallback(fallback))
+	require.NoError(t, l.WaitN(ctx, "1", 3))
+	assert.NoError(t, fallback.Wait(ctx, "1"), "1 chat token charged")
+	assert.Error(t, fallback.Wait(ctx, "1"))
+
+	// Other fallbacks are charged for the rest of the request.
+	recorder := &recordingLimiter{}
+	l = sender.NewRedisRateLimiter((&fakeRedis{err: down, healthy: 1}).eval, cfg, sender.WithRedisFallback(recorder))
+	require.NoError(t, l.WaitN(ctx, "1", 3))
+	assert.Equal(t, []string{"1", "1", "1"}, recorder.chats, "the global bucket is still owed 3")
+}
+
+// sleeperClock is a clock.Clock that records sleeps.
+type sleeperClock struct {
+	*testutil.FakeSleeper
+}
+
+func (sleeperClock) Now() time.Time                         { return time.Now() }
+func (sleeperClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (sleeperClock) NewTicker(d time.Duration) clock.Ticker { return clock.Real.NewTicker(d) }
+func (sleeperClock) NewTimer(d time.Duration) clock.Timer   { return clock.Real.NewTimer(d) }
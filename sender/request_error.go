@@ -0,0 +1,40 @@
+package sender
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sony/gobreaker/v2"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// requestError wraps the error of one try of method in a *tg.RequestError.
+// Errors of the breaker itself also match ErrCircuitOpen.
+func (c *Client) requestError(method, chatID string, err error) error {
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		err = fmt.Errorf("%w: %w", ErrCircuitOpen, err)
+	}
+	return &tg.RequestError{
+		Method:   method,
+		ChatID:   chatID,
+		Attempts: 1,
+		Breaker:  c.breakerFor(method).state().String(),
+		Err:      err,
+	}
+}
+
+// retryError returns err, the final error of withRetry, with the context
+// of the last try and the totals of the loop. Without a last try context
+// err is returned as is.
+func retryError(last *tg.RequestError, err error, attempts int, backoff time.Duration) error {
+	if last == nil {
+		return err
+	}
+	re := *last
+	re.Err = err
+	re.Attempts = attempts
+	re.Backoff = backoff
+	return &re
+}
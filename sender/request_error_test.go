@@ -0,0 +1,95 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestRequestError_SingleAttempt(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBadRequest(w, "Bad Request: chat not found")
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "hi"})
+
+	var reqErr *tg.RequestError
+	require.ErrorAs(t, err, &reqErr)
+	assert.Equal(t, "sendMessage", reqErr.Method)
+	assert.Equal(t, "123456789", reqErr.ChatID)
+	assert.Equal(t, 1, reqErr.Attempts)
+	assert.Zero(t, reqErr.Backoff)
+	assert.Equal(t, "closed", reqErr.Breaker)
+
+	var apiErr *tg.APIError
+	require.ErrorAs(t, err, &apiErr, "the cause stays reachable")
+	assert.ErrorIs(t, err, tg.ErrChatNotFound)
+	assert.Equal(t, apiErr.Error(), err.Error(), "the message is the cause's")
+}
+
+func TestRequestError_RetryTrace(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyServerError(w, 502, "Bad Gateway")
+	})
+	sleeper := &testutil.FakeSleeper{}
+	client := testutil.NewRetryTestClient(t, server.BaseURL(), sleeper, sender.WithRetries(2))
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "hi"})
+
+	var reqErr *tg.RequestError
+	require.ErrorAs(t, err, &reqErr)
+	assert.Equal(t, 3, reqErr.Attempts)
+	assert.Equal(t, sleeper.TotalDuration(), reqErr.Backoff)
+	assert.Positive(t, reqErr.Backoff)
+	assert.ErrorIs(t, err, sender.ErrMaxRetries)
+
+	var nested *tg.RequestError
+	assert.False(t, errors.As(reqErr.Err, &nested), "one RequestError, not one per try")
+}
+
+func TestRequestError_BreakerOpen(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	client := testutil.NewTestClient(t, server.BaseURL())
+	client.TripBreaker()
+
+	_, err := client.GetChat(context.Background(), testutil.TestChatID)
+
+	var reqErr *tg.RequestError
+	require.ErrorAs(t, err, &reqErr)
+	assert.Equal(t, "getChat", reqErr.Method)
+	assert.Equal(t, "open", reqErr.Breaker)
+	assert.ErrorIs(t, err, sender.ErrCircuitOpen)
+	assert.Zero(t, server.CaptureCount())
+}
+
+func TestRequestError_LogValue(t *testing.T) {
+	err := &tg.RequestError{
+		Method:   "sendMessage",
+		ChatID:   "-100123",
+		Attempts: 3,
+		Backoff:  1500 * time.Millisecond,
+		Breaker:  "closed",
+		Err:      tg.NewAPIError("sendMessage", 502, "Bad Gateway"),
+	}
+	var buf strings.Builder
+	slog.New(slog.NewTextHandler(&buf, nil)).Error("send failed", "error", err)
+
+	out := buf.String()
+	for _, want := range []string{"error.method=sendMessage", "error.chat_id=-100123", "error.attempts=3", "error.backoff=1.5s", "error.breaker=closed", "Bad Gateway"} {
+		assert.Contains(t, out, want)
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -532,6 +535,50 @@ func TestRetry_FileUpload_FromBytes_RetrySafe(t *testing.T) {
 		"retry must send full file content, not empty (consumed reader)")
 }
 
+func TestRetry_FileUpload_FromFile_RetrySafe(t *testing.T) {
+	// FromFile reopens the file for every attempt, so a retry after a
+	// transient error streams the full content again.
+	var attempts atomic.Int32
+	var secondAttemptData []byte
+
+	photoData := []byte("fake-photo-data-for-retry-test")
+	path := filepath.Join(t.TempDir(), "test.jpg")
+	require.NoError(t, os.WriteFile(path, photoData, 0o600))
+
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendPhoto", func(w http.ResponseWriter, r *http.Request) {
+		attempt := attempts.Add(1)
+
+		file, header, err := r.FormFile("photo")
+		if err == nil {
+			data, _ := io.ReadAll(file)
+			if attempt == 2 {
+				secondAttemptData = data
+				assert.Equal(t, "test.jpg", header.Filename)
+			}
+			file.Close()
+		}
+
+		if attempt == 1 {
+			testutil.ReplyError(w, 502, "Bad Gateway", nil)
+			return
+		}
+		testutil.ReplyMessage(w, 123)
+	})
+
+	sleeper := &testutil.FakeSleeper{}
+	client := testutil.NewRetryTestClient(t, server.BaseURL(), sleeper, sender.WithRetries(3))
+
+	_, err := client.SendPhoto(context.Background(), sender.SendPhotoRequest{
+		ChatID: testutil.TestChatID,
+		Photo:  sender.FromFile(path),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), attempts.Load())
+	assert.Equal(t, photoData, secondAttemptData)
+}
+
 func TestRetry_FileUpload_FromReader_EmptyOnRetry(t *testing.T) {
 	// Documents the known limitation: FromReader is NOT retry-safe.
 	// On retry, the reader is at EOF and sends 0 bytes.
@@ -576,3 +623,58 @@ func TestRetry_FileUpload_FromReader_EmptyOnRetry(t *testing.T) {
 		"FromReader sends empty file on retry (known limitation, use FromBytes)")
 	_ = msg
 }
+
+func TestRetry_FileUpload_Retryable_FullContentOnRetry(t *testing.T) {
+	// Retryable tees the reader into memory, so the second attempt resends
+	// the whole file; progress restarts from zero for each attempt.
+	var attempts atomic.Int32
+	var secondAttemptData []byte
+
+	photoData := []byte("fake-photo-data-for-retry-test")
+
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendPhoto", func(w http.ResponseWriter, r *http.Request) {
+		attempt := attempts.Add(1)
+
+		file, _, err := r.FormFile("photo")
+		if err == nil {
+			data, _ := io.ReadAll(file)
+			if attempt == 2 {
+				secondAttemptData = data
+			}
+			file.Close()
+		}
+
+		if attempt == 1 {
+			testutil.ReplyError(w, 502, "Bad Gateway", nil)
+			return
+		}
+		testutil.ReplyMessage(w, 123)
+	})
+
+	var mu sync.Mutex
+	var progress []int64
+	sleeper := &testutil.FakeSleeper{}
+	client := testutil.NewRetryTestClient(t, server.BaseURL(), sleeper, sender.WithRetries(3))
+
+	photo := sender.FromReader(io.MultiReader(bytes.NewReader(photoData)), "test.jpg").
+		Retryable().
+		WithProgress(func(sent, total int64) {
+			mu.Lock()
+			progress = append(progress, sent)
+			mu.Unlock()
+		})
+	_, err := client.SendPhoto(context.Background(), sender.SendPhotoRequest{
+		ChatID: testutil.TestChatID,
+		Photo:  photo,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), attempts.Load())
+	assert.Equal(t, photoData, secondAttemptData)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, progress)
+	assert.Equal(t, int64(len(photoData)), progress[len(progress)-1])
+}
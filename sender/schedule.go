@@ -0,0 +1,391 @@
+package sender
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Scheduled Messages ==================
+//
+// Schedule stores a message to be sent later; RunScheduler sends it when
+// it is due. Jobs live in a ScheduleStore (in memory unless
+// WithScheduleStore is used), so a persistent store lets them survive
+// restarts. Failed sends are retried a few times with backoff; recurring
+// jobs then move on to their next occurrence.
+
+// ErrScheduleNotFound is returned by CancelScheduled for unknown IDs.
+var ErrScheduleNotFound = errors.New("scheduled message not found")
+
+// ScheduledMessage is a stored job.
+type ScheduledMessage struct {
+	ID      string             `json:"id"`
+	At      time.Time          `json:"at"` // next fire time
+	Request SendMessageRequest `json:"request"`
+	// Occurrence is the scheduled time of the occurrence being sent. It
+	// differs from At while a failed send is retried, and anchors the
+	// next occurrence of recurring jobs (zero = At).
+	Occurrence time.Time `json:"occurrence,omitzero"`
+	// Every repeats the message at this interval (0 = once).
+	Every time.Duration `json:"every,omitempty"`
+	// Cron repeats the message on a five-field cron schedule
+	// ("0 9 * * 1-5" = weekdays at 09:00), evaluated in TimeZone.
+	Cron     string `json:"cron,omitempty"`
+	TimeZone string `json:"time_zone,omitempty"` // IANA name, "" = UTC
+	// Attempts counts failed sends of the current occurrence.
+	Attempts int `json:"attempts,omitempty"`
+}
+
+// Recurring reports whether the job repeats.
+func (m ScheduledMessage) Recurring() bool {
+	return m.Every > 0 || m.Cron != ""
+}
+
+// occurrence returns the scheduled time of the current occurrence.
+func (m ScheduledMessage) occurrence() time.Time {
+	if m.Occurrence.IsZero() {
+		return m.At
+	}
+	return m.Occurrence
+}
+
+// next returns the occurrence after the current one, or the zero time for
+// one-off jobs. Retries of the current occurrence do not shift it.
+func (m ScheduledMessage) next(now time.Time) (time.Time, error) {
+	switch {
+	case m.Every > 0:
+		next := m.occurrence().Add(m.Every)
+		for !next.After(now) { // skip occurrences missed while not running
+			next = next.Add(m.Every)
+		}
+		return next, nil
+	case m.Cron != "":
+		spec, err := parseCron(m.Cron)
+		if err != nil {
+			return time.Time{}, err
+		}
+		loc, err := m.location()
+		if err != nil {
+			return time.Time{}, err
+		}
+		base := m.occurrence()
+		if now.After(base) {
+			base = now
+		}
+		return spec.next(base.In(loc)), nil
+	}
+	return time.Time{}, nil
+}
+
+// location returns the time zone cron schedules are evaluated in.
+func (m ScheduledMessage) location() (*time.Location, error) {
+	if m.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(m.TimeZone)
+}
+
+// ScheduleStore persists scheduled messages.
+// Implementations must be safe for concurrent use.
+type ScheduleStore interface {
+	Save(ctx context.Context, msg ScheduledMessage) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]ScheduledMessage, error)
+}
+
+// MemoryScheduleStore is an in-memory ScheduleStore.
+// The zero value is ready to use.
+type MemoryScheduleStore struct {
+	mu   sync.Mutex
+	jobs map[string]ScheduledMessage
+}
+
+var _ ScheduleStore = (*MemoryScheduleStore)(nil)
+
+// Save stores msg, replacing a job with the same ID.
+func (s *MemoryScheduleStore) Save(_ context.Context, msg ScheduledMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.jobs == nil {
+		s.jobs = make(map[string]ScheduledMessage)
+	}
+	s.jobs[msg.ID] = msg
+	return nil
+}
+
+// Delete removes the job with id.
+func (s *MemoryScheduleStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.jobs, id)
+	return nil
+}
+
+// List returns all jobs ordered by fire time.
+func (s *MemoryScheduleStore) List(_ context.Context) ([]ScheduledMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs := make([]ScheduledMessage, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, j)
+	}
+	slices.SortFunc(jobs, func(a, b ScheduledMessage) int { return a.At.Compare(b.At) })
+	return jobs, nil
+}
+
+// WithScheduleStore keeps scheduled messages in store instead of memory.
+func WithScheduleStore(store ScheduleStore) Option {
+	return func(c *Client) {
+		c.schedule = store
+	}
+}
+
+// ScheduleOption configures a scheduled message.
+type ScheduleOption func(*ScheduledMessage)
+
+// ScheduleEvery repeats the message every d after the first send.
+func ScheduleEvery(d time.Duration) ScheduleOption {
+	return func(m *ScheduledMessage) {
+		m.Every = d
+	}
+}
+
+// ScheduleCron repeats the message on a five-field cron schedule
+// evaluated in loc (nil = UTC). The first send is at the time passed to
+// Schedule; pass the zero time to start at the first cron occurrence.
+func ScheduleCron(spec string, loc *time.Location) ScheduleOption {
+	return func(m *ScheduledMessage) {
+		m.Cron = spec
+		if loc != nil && loc != time.UTC {
+			m.TimeZone = loc.String()
+		}
+	}
+}
+
+// ScheduleID sets the job ID instead of a random one. Scheduling an
+// existing ID replaces that job.
+func ScheduleID(id string) ScheduleOption {
+	return func(m *ScheduledMessage) {
+		m.ID = id
+	}
+}
+
+// scheduleRetries is how often a failed occurrence is retried.
+const scheduleRetries = 3
+
+// scheduleRetryDelay is the first retry delay; it doubles per attempt.
+const scheduleRetryDelay = 30 * time.Second
+
+// Schedule stores req to be sent at at and returns the job ID. Messages
+// are sent by RunScheduler, which must be running (in this or, with a
+// shared store, another process) when they fall due.
+func (c *Client) Schedule(ctx context.Context, at time.Time, req SendMessageRequest, opts ...ScheduleOption) (string, error) {
+	if err := validateSendMessage(req); err != nil {
+		return "", err
+	}
+	msg := ScheduledMessage{At: at, Request: req}
+	for _, opt := range opts {
+		opt(&msg)
+	}
+	if msg.Every < 0 {
+		return "", tg.NewValidationError("every", "must not be negative")
+	}
+	if msg.Cron != "" {
+		if _, err := parseCron(msg.Cron); err != nil {
+			return "", err
+		}
+		if _, err := msg.location(); err != nil {
+			return "", tg.NewValidationError("time_zone", err.Error())
+		}
+		if msg.At.IsZero() {
+			next, err := msg.next(c.clock.Now())
+			if err != nil {
+				return "", err
+			}
+			msg.At = next
+		}
+	}
+	if msg.ID == "" {
+		msg.ID = newScheduleID()
+	}
+
+	if err := c.scheduleStore().Save(ctx, msg); err != nil {
+		return "", fmt.Errorf("schedule: %w", err)
+	}
+	c.wakeScheduler()
+	return msg.ID, nil
+}
+
+// CancelScheduled removes a scheduled message.
+func (c *Client) CancelScheduled(ctx context.Context, id string) error {
+	store := c.scheduleStore()
+	jobs, err := store.List(ctx)
+	if err != nil {
+		return fmt.Errorf("schedule: %w", err)
+	}
+	if !slices.ContainsFunc(jobs, func(m ScheduledMessage) bool { return m.ID == id }) {
+		return ErrScheduleNotFound
+	}
+	if err := store.Delete(ctx, id); err != nil {
+		return fmt.Errorf("schedule: %w", err)
+	}
+	c.wakeScheduler()
+	return nil
+}
+
+// ScheduledMessages returns all pending scheduled messages.
+func (c *Client) ScheduledMessages(ctx context.Context) ([]ScheduledMessage, error) {
+	return c.scheduleStore().List(ctx)
+}
+
+// RunScheduler sends scheduled messages as they fall due, until ctx is
+// done. Messages that fell due while no scheduler ran are sent on start.
+// Run at most one scheduler per store.
+//
+// When the store fails to take a job's new state after a send, the
+// scheduler keeps that state in memory and writes it again on every
+// pass, so the job is not sent twice.
+func (c *Client) RunScheduler(ctx context.Context) error {
+	store := c.scheduleStore()
+	unsynced := make(map[string]*ScheduledMessage) // nil = to delete
+	for {
+		c.syncScheduled(ctx, store, unsynced)
+		jobs, err := store.List(ctx)
+		if err != nil {
+			c.logger.Warn("scheduler: list failed", "error", err)
+		}
+
+		now := c.clock.Now()
+		wait := time.Minute // re-list periodically for jobs added by other processes
+		fired := false
+		for _, job := range jobs {
+			if state, ok := unsynced[job.ID]; ok {
+				if state == nil {
+					continue // sent; only the delete is missing
+				}
+				job = *state
+			}
+			if job.At.After(now) {
+				wait = min(wait, job.At.Sub(now))
+				continue
+			}
+			follow, sent := c.fireScheduled(ctx, job)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if sent {
+				c.storeScheduled(ctx, store, job.ID, follow, unsynced)
+			}
+			fired = true
+		}
+		if fired {
+			continue // fired jobs may have been rescheduled
+		}
+
+		timer := c.clock.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-c.scheduleWake:
+			timer.Stop()
+		case <-timer.C():
+		}
+	}
+}
+
+// fireScheduled sends a due job and returns its follow-up state: nil when
+// done, or the job retried with backoff or moved to its next occurrence.
+// It reports false when the send was interrupted by ctx, leaving the job
+// due.
+func (c *Client) fireScheduled(ctx context.Context, job ScheduledMessage) (*ScheduledMessage, bool) {
+	// Stores that round-trip through JSON decode numeric chat IDs as float64.
+	if id, ok := job.Request.ChatID.(float64); ok {
+		job.Request.ChatID = int64(id)
+	}
+	_, err := c.SendMessage(ctx, job.Request)
+	if err != nil && ctx.Err() != nil {
+		return nil, false // shutting down; the job stays due
+	}
+
+	now := c.clock.Now()
+	if err != nil {
+		job.Attempts++
+		if job.Attempts <= scheduleRetries {
+			c.logger.Warn("scheduler: send failed, will retry",
+				"id", job.ID, "attempt", job.Attempts, "error", err)
+			job.Occurrence = job.occurrence()
+			job.At = now.Add(scheduleRetryDelay << (job.Attempts - 1))
+			return &job, true
+		}
+		c.logger.Error("scheduler: send failed, giving up on occurrence", "id", job.ID, "error", err)
+	}
+
+	if !job.Recurring() {
+		return nil, true
+	}
+	next, nextErr := job.next(now)
+	if nextErr != nil || next.IsZero() {
+		c.logger.Error("scheduler: no next occurrence, removing", "id", job.ID, "error", nextErr)
+		return nil, true
+	}
+	job.At, job.Occurrence, job.Attempts = next, time.Time{}, 0
+	return &job, true
+}
+
+// storeScheduled saves job's follow-up state, or deletes the job when
+// state is nil. A failed write is kept in unsynced for syncScheduled.
+func (c *Client) storeScheduled(ctx context.Context, store ScheduleStore, id string, state *ScheduledMessage, unsynced map[string]*ScheduledMessage) {
+	var err error
+	if state == nil {
+		err = store.Delete(ctx, id)
+	} else {
+		err = store.Save(ctx, *state)
+	}
+	if err != nil {
+		c.logger.Warn("scheduler: store failed, keeping the job's state in memory", "id", id, "error", err)
+		unsynced[id] = state
+		return
+	}
+	delete(unsynced, id)
+}
+
+// syncScheduled retries the writes in unsynced.
+func (c *Client) syncScheduled(ctx context.Context, store ScheduleStore, unsynced map[string]*ScheduledMessage) {
+	for id, state := range unsynced {
+		c.storeScheduled(ctx, store, id, state, unsynced)
+	}
+}
+
+// scheduleStore returns the configured store, creating the in-memory
+// default on first use.
+func (c *Client) scheduleStore() ScheduleStore {
+	c.scheduleOnce.Do(func() {
+		if c.schedule == nil {
+			c.schedule = &MemoryScheduleStore{}
+		}
+		c.scheduleWake = make(chan struct{}, 1)
+	})
+	return c.schedule
+}
+
+// wakeScheduler makes a running RunScheduler re-read the store.
+func (c *Client) wakeScheduler() {
+	select {
+	case c.scheduleWake <- struct{}{}:
+	default:
+	}
+}
+
+func newScheduleID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,253 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/galigotest"
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// runScheduler starts RunScheduler and stops it when the test ends.
+func runScheduler(t *testing.T, client *sender.Client) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = client.RunScheduler(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func TestSchedule_SendsOnceWhenDue(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+	runScheduler(t, client)
+
+	id, err := client.Schedule(context.Background(), time.Now().Add(50*time.Millisecond),
+		sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "later"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, id)
+	assert.Equal(t, 0, server.CaptureCount(), "not sent before due")
+
+	require.Eventually(t, func() bool { return server.CaptureCount() == 1 }, 2*time.Second, 10*time.Millisecond)
+	server.LastCapture().AssertJSONField(t, "text", "later")
+
+	jobs, err := client.ScheduledMessages(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, jobs, "one-off job removed after sending")
+}
+
+func TestSchedule_FakeClock(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+	clk := galigotest.NewFakeClock(time.Now())
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithClock(clk))
+	runScheduler(t, client)
+
+	_, err := client.Schedule(context.Background(), clk.Now().Add(time.Hour),
+		sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "in an hour"})
+	require.NoError(t, err)
+
+	// An hour of fake time passes in milliseconds.
+	due := clk.Now().Add(time.Hour)
+	require.Eventually(t, func() bool {
+		if server.CaptureCount() > 0 {
+			return true
+		}
+		clk.Advance(time.Minute)
+		return false
+	}, 5*time.Second, time.Millisecond)
+	assert.False(t, clk.Now().Before(due), "not sent before due")
+}
+
+func TestSchedule_Every(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+	runScheduler(t, client)
+
+	_, err := client.Schedule(context.Background(), time.Now(),
+		sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "tick"},
+		sender.ScheduleEvery(30*time.Millisecond), sender.ScheduleID("ticker"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return server.CaptureCount() >= 3 }, 2*time.Second, 10*time.Millisecond)
+
+	jobs, err := client.ScheduledMessages(context.Background())
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "ticker", jobs[0].ID)
+	assert.True(t, jobs[0].Recurring())
+}
+
+func TestSchedule_RetriesFailedSend(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBadRequest(w, "Bad Request: chat not found")
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+	runScheduler(t, client)
+
+	start := time.Now()
+	_, err := client.Schedule(context.Background(), start,
+		sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "x"})
+	require.NoError(t, err)
+
+	var job sender.ScheduledMessage
+	require.Eventually(t, func() bool {
+		jobs, _ := client.ScheduledMessages(context.Background())
+		if len(jobs) != 1 || jobs[0].Attempts == 0 {
+			return false
+		}
+		job = jobs[0]
+		return true
+	}, 2*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, 1, job.Attempts)
+	assert.True(t, job.At.After(start.Add(20*time.Second)), "retry is backed off")
+}
+
+func TestSchedule_Cron(t *testing.T) {
+	client := testutil.NewTestClient(t, "http://unused")
+
+	_, err := client.Schedule(context.Background(), time.Time{},
+		sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "x"},
+		sender.ScheduleCron("not a cron", nil))
+	assert.Error(t, err)
+
+	before := time.Now()
+	_, err = client.Schedule(context.Background(), time.Time{},
+		sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "daily"},
+		sender.ScheduleCron("0 9 * * *", time.UTC))
+	require.NoError(t, err)
+
+	jobs, err := client.ScheduledMessages(context.Background())
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	at := jobs[0].At.UTC()
+	assert.True(t, at.After(before))
+	assert.Equal(t, 9, at.Hour())
+	assert.Equal(t, 0, at.Minute())
+}
+
+func TestCancelScheduled(t *testing.T) {
+	client := testutil.NewTestClient(t, "http://unused")
+
+	id, err := client.Schedule(context.Background(), time.Now().Add(time.Hour),
+		sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "x"})
+	require.NoError(t, err)
+
+	require.NoError(t, client.CancelScheduled(context.Background(), id))
+	assert.ErrorIs(t, client.CancelScheduled(context.Background(), id), sender.ErrScheduleNotFound)
+
+	jobs, err := client.ScheduledMessages(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestSchedule_ValidatesChatID(t *testing.T) {
+	client := testutil.NewTestClient(t, "http://unused")
+	_, err := client.Schedule(context.Background(), time.Now(), sender.SendMessageRequest{Text: "x"})
+	assert.Error(t, err)
+}
+
+// deleteFailingStore is a schedule store whose deletes always fail.
+type deleteFailingStore struct {
+	sender.MemoryScheduleStore
+	deletes atomic.Int32
+}
+
+func (s *deleteFailingStore) Delete(context.Context, string) error {
+	s.deletes.Add(1)
+	return errors.New("store unavailable")
+}
+
+func TestSchedule_FailedStoreWriteDoesNotResend(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+	store := &deleteFailingStore{}
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithScheduleStore(store))
+	runScheduler(t, client)
+
+	_, err := client.Schedule(context.Background(), time.Now(),
+		sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "once"})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return store.deletes.Load() >= 2 }, 2*time.Second, 10*time.Millisecond,
+		"failed delete is retried")
+	assert.Equal(t, 1, server.CaptureCount(), "job not sent again while its delete is pending")
+}
+
+func TestSchedule_RecurrenceAnchoredAfterRetry(t *testing.T) {
+	var calls atomic.Int32
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			testutil.ReplyBadRequest(w, "Bad Request: chat not found")
+			return
+		}
+		testutil.ReplyMessage(w, 1)
+	})
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	clk := galigotest.NewFakeClock(start)
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithClock(clk))
+	runScheduler(t, client)
+
+	_, err := client.Schedule(context.Background(), start,
+		sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "hourly"},
+		sender.ScheduleEvery(time.Hour))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		if server.CaptureCount() >= 2 {
+			return true
+		}
+		clk.Advance(time.Second)
+		return false
+	}, 5*time.Second, time.Millisecond)
+
+	// The scheduler records the outcome after the send returns.
+	var jobs []sender.ScheduledMessage
+	require.Eventually(t, func() bool {
+		jobs, err = client.ScheduledMessages(context.Background())
+		return err == nil && len(jobs) == 1 && jobs[0].Attempts == 0
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, start.Add(time.Hour), jobs[0].At.UTC(), "next occurrence ignores the retry delay")
+}
+
+func TestSchedule_ValidatesRequest(t *testing.T) {
+	client := testutil.NewTestClient(t, "http://unused")
+	_, err := client.Schedule(context.Background(), time.Now(), sender.SendMessageRequest{
+		ChatID: testutil.TestChatID, Text: "x",
+		LinkPreviewOptions: &tg.LinkPreviewOptions{PreferSmallMedia: true, PreferLargeMedia: true},
+	})
+	assert.Error(t, err)
+
+	_, err = client.Schedule(context.Background(), time.Now(),
+		sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "x"},
+		sender.ScheduleEvery(-time.Minute))
+	assert.Error(t, err)
+}
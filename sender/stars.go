@@ -0,0 +1,127 @@
+package sender
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"time"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Stars ==================
+
+// maxStarTransactionsPage is the largest limit getStarTransactions accepts.
+const maxStarTransactionsPage = 100
+
+// defaultStarBalanceInterval is the WatchStarBalance poll interval when
+// none is given.
+const defaultStarBalanceInterval = time.Minute
+
+// StarTransactionFilter selects transactions in StarTransactions.
+type StarTransactionFilter func(*tg.StarTransaction) bool
+
+// IncomingStarTransactions selects transactions that credited the bot.
+func IncomingStarTransactions() StarTransactionFilter {
+	return func(t *tg.StarTransaction) bool { return t.Incoming() }
+}
+
+// OutgoingStarTransactions selects refunds, withdrawals and other
+// transactions that debited the bot.
+func OutgoingStarTransactions() StarTransactionFilter {
+	return func(t *tg.StarTransaction) bool { return !t.Incoming() }
+}
+
+// StarTransactionsWithPartner selects transactions whose partner has one
+// of the given types, e.g. tg.TransactionPartnerTypeUser.
+func StarTransactionsWithPartner(types ...string) StarTransactionFilter {
+	return func(t *tg.StarTransaction) bool {
+		return slices.Contains(types, tg.TransactionPartnerType(t.Partner()))
+	}
+}
+
+// StarTransactions returns an iterator over all of the bot's Star
+// transactions, newest first, fetched with GetStarTransactions in pages of
+// pageSize (1-100, default 100) with the offset advanced automatically.
+// Only transactions matching every filter are yielded. An error is yielded
+// once and ends the iteration.
+func (c *Client) StarTransactions(ctx context.Context, pageSize int, filters ...StarTransactionFilter) iter.Seq2[tg.StarTransaction, error] {
+	if pageSize <= 0 || pageSize > maxStarTransactionsPage {
+		pageSize = maxStarTransactionsPage
+	}
+	return func(yield func(tg.StarTransaction, error) bool) {
+		for offset := 0; ; {
+			page, err := c.GetStarTransactions(ctx, GetStarTransactionsRequest{Offset: offset, Limit: pageSize})
+			if err != nil {
+				yield(tg.StarTransaction{}, err)
+				return
+			}
+		next:
+			for i := range page.Transactions {
+				t := &page.Transactions[i]
+				for _, keep := range filters {
+					if !keep(t) {
+						continue next
+					}
+				}
+				if !yield(*t, nil) {
+					return
+				}
+			}
+			if len(page.Transactions) < pageSize {
+				return
+			}
+			offset += len(page.Transactions)
+		}
+	}
+}
+
+// StarBalanceChange is emitted by WatchStarBalance.
+type StarBalanceChange struct {
+	Old tg.StarAmount
+	New tg.StarAmount
+	At  time.Time
+}
+
+// WatchStarBalance polls GetMyStarBalance every interval (default one
+// minute) and emits a change whenever the balance differs from the
+// previous poll. The first successful poll sets the baseline and emits
+// nothing. Poll errors are logged and skipped. The returned channel is
+// closed when ctx is done.
+func (c *Client) WatchStarBalance(ctx context.Context, interval time.Duration) <-chan StarBalanceChange {
+	if interval <= 0 {
+		interval = defaultStarBalanceInterval
+	}
+	out := make(chan StarBalanceChange, 1)
+	go func() {
+		defer close(out)
+		ticker := c.clock.NewTicker(interval)
+		defer ticker.Stop()
+
+		var last *tg.StarAmount
+		for {
+			balance, err := c.GetMyStarBalance(ctx)
+			switch {
+			case err != nil:
+				if ctx.Err() == nil {
+					c.logger.Warn("star balance poll failed", "error", err)
+				}
+			case last != nil && *balance != *last:
+				select {
+				case out <- StarBalanceChange{Old: *last, New: *balance, At: c.clock.Now()}:
+				case <-ctx.Done():
+					return
+				}
+				last = balance
+			default:
+				last = balance
+			}
+			select {
+			case <-ticker.C():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,105 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestStarTransactions_Pages(t *testing.T) {
+	// 5 transactions: even IDs come from users, odd ones go to Fragment.
+	var offsets []int
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getStarTransactions", func(w http.ResponseWriter, r *http.Request) {
+		var req sender.GetStarTransactionsRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		offsets = append(offsets, req.Offset)
+		var txs []map[string]any
+		for i := req.Offset; i < min(req.Offset+req.Limit, 5); i++ {
+			tx := map[string]any{"id": fmt.Sprint(i), "amount": 10, "date": 1}
+			if i%2 == 0 {
+				tx["source"] = map[string]any{"type": "user", "user": map[string]any{"id": 1}}
+			} else {
+				tx["receiver"] = map[string]any{"type": "fragment"}
+			}
+			txs = append(txs, tx)
+		}
+		testutil.ReplyOK(w, map[string]any{"transactions": txs})
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	var ids []string
+	for tx, err := range client.StarTransactions(context.Background(), 2) {
+		require.NoError(t, err)
+		ids = append(ids, tx.ID)
+	}
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, ids)
+	assert.Equal(t, []int{0, 2, 4}, offsets)
+
+	ids = nil
+	for tx, err := range client.StarTransactions(context.Background(), 2, sender.OutgoingStarTransactions()) {
+		require.NoError(t, err)
+		ids = append(ids, tx.ID)
+	}
+	assert.Equal(t, []string{"1", "3"}, ids)
+
+	ids = nil
+	filter := sender.StarTransactionsWithPartner(tg.TransactionPartnerTypeUser)
+	for tx, err := range client.StarTransactions(context.Background(), 100, filter) {
+		require.NoError(t, err)
+		ids = append(ids, tx.ID)
+	}
+	assert.Equal(t, []string{"0", "2", "4"}, ids)
+}
+
+func TestStarTransactions_Error(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getStarTransactions", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBadRequest(w, "something went wrong")
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	n := 0
+	for _, err := range client.StarTransactions(context.Background(), 10) {
+		assert.Error(t, err)
+		n++
+	}
+	assert.Equal(t, 1, n)
+}
+
+func TestWatchStarBalance(t *testing.T) {
+	balances := []int{10, 10, 15, 15, 7}
+	var polls atomic.Int32
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getMyStarBalance", func(w http.ResponseWriter, r *http.Request) {
+		i := int(polls.Add(1)) - 1
+		testutil.ReplyOK(w, tg.StarAmount{Amount: balances[min(i, len(balances)-1)]})
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := client.WatchStarBalance(ctx, time.Millisecond)
+
+	first := <-changes
+	assert.Equal(t, 10, first.Old.Amount)
+	assert.Equal(t, 15, first.New.Amount)
+	second := <-changes
+	assert.Equal(t, 15, second.Old.Amount)
+	assert.Equal(t, 7, second.New.Amount)
+
+	cancel()
+	for range changes {
+	}
+}
@@ -0,0 +1,70 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Startup Validation ==================
+
+// DefaultStartupTimeout bounds the getMe call of WithStartupValidation
+// when no timeout is given.
+const DefaultStartupTimeout = 10 * time.Second
+
+// WithStartupValidation makes New call getMe, within timeout
+// (DefaultStartupTimeout if <= 0), before returning the client. An invalid
+// or revoked token then fails construction with tg.ErrUnauthorized rather
+// than the first send, the connection to the API is already open for it,
+// and the bot's user is available from Me. With WithDryRun the dry-run
+// bot user is used.
+func WithStartupValidation(timeout time.Duration) Option {
+	return func(c *Client) {
+		if timeout <= 0 {
+			timeout = DefaultStartupTimeout
+		}
+		c.startupTimeout = timeout
+	}
+}
+
+// Me returns the bot's user from the last successful GetMe, including the
+// one of WithStartupValidation, or nil if there was none.
+func (c *Client) Me() *tg.User {
+	me := c.me.Load()
+	if me == nil {
+		return nil
+	}
+	self := *me
+	return &self
+}
+
+// validateStartup runs the getMe of WithStartupValidation, if enabled.
+// Like SetToken it calls the API directly: a failure must not count
+// against the circuit breaker, retry budget or rate limits of a client
+// that is not in use yet.
+func (c *Client) validateStartup() error {
+	if c.startupTimeout <= 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.startupTimeout)
+	defer cancel()
+
+	var resp *apiResponse
+	var err error
+	if c.dryRun != nil {
+		resp, err = c.dryRun.respond(c, "getMe", struct{}{}, "")
+	} else {
+		resp, err = c.doRequest(ctx, "getMe", struct{}{})
+	}
+	if err != nil {
+		return fmt.Errorf("galigo: startup validation: %w", err)
+	}
+	var me tg.User
+	if err := c.decoder.Decode(resp.Result, &me); err != nil {
+		return fmt.Errorf("galigo: startup validation: failed to parse response: %w", err)
+	}
+	c.me.Store(&me)
+	return nil
+}
@@ -0,0 +1,80 @@
+package sender_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestStartupValidation(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getMe", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyUser(w)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithStartupValidation(time.Second))
+
+	assert.Equal(t, 1, server.CaptureCount())
+	me := client.Me()
+	require.NotNil(t, me)
+	assert.Equal(t, testutil.TestBotID, me.ID)
+	assert.Equal(t, testutil.TestBotUsername, me.Username)
+
+	me.Username = "changed"
+	assert.Equal(t, testutil.TestBotUsername, client.Me().Username, "Me returns a copy")
+}
+
+func TestStartupValidation_InvalidToken(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getMe", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyError(w, 401, "Unauthorized", nil)
+	})
+
+	client, err := sender.New(testutil.TestToken,
+		sender.WithBaseURL(server.BaseURL()),
+		sender.WithStartupValidation(time.Second),
+	)
+	require.ErrorIs(t, err, tg.ErrUnauthorized)
+	assert.Nil(t, client)
+	assert.NotContains(t, err.Error(), testutil.TestToken)
+}
+
+func TestStartupValidation_Timeout(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getMe", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	start := time.Now()
+	_, err := sender.New(testutil.TestToken,
+		sender.WithBaseURL(server.BaseURL()),
+		sender.WithRetries(0),
+		sender.WithStartupValidation(50*time.Millisecond),
+	)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestMe_SetByGetMe(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getMe", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyUser(w)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	assert.Nil(t, client.Me())
+	assert.Zero(t, server.CaptureCount(), "no getMe without WithStartupValidation")
+
+	_, err := client.GetMe(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, client.Me())
+	assert.Equal(t, testutil.TestBotUsername, client.Me().Username)
+}
@@ -0,0 +1,236 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Sticker Set Ownership ==================
+
+// Telegram has no method to list the sticker sets a bot created, so
+// ownership has to be tracked by the application. A StickerSetStore
+// records set names; with WithStickerSetStore the client keeps it up to
+// date on CreateNewStickerSet and DeleteStickerSet.
+
+// StickerSetStore persists the names of sticker sets created by the bot.
+// Implementations must be safe for concurrent use.
+type StickerSetStore interface {
+	Add(ctx context.Context, name string) error
+	Remove(ctx context.Context, name string) error
+	List(ctx context.Context) ([]string, error)
+}
+
+// MemoryStickerSetStore is an in-memory StickerSetStore.
+// The zero value is ready to use.
+type MemoryStickerSetStore struct {
+	mu    sync.Mutex
+	names map[string]struct{}
+}
+
+var _ StickerSetStore = (*MemoryStickerSetStore)(nil)
+
+// Add records name.
+func (s *MemoryStickerSetStore) Add(_ context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.names == nil {
+		s.names = make(map[string]struct{})
+	}
+	s.names[name] = struct{}{}
+	return nil
+}
+
+// Remove forgets name.
+func (s *MemoryStickerSetStore) Remove(_ context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.names, name)
+	return nil
+}
+
+// List returns all recorded names in sorted order.
+func (s *MemoryStickerSetStore) List(_ context.Context) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.names))
+	for name := range s.names {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// WithStickerSetStore records sticker sets created with CreateNewStickerSet
+// in store and forgets them on DeleteStickerSet.
+func WithStickerSetStore(store StickerSetStore) Option {
+	return func(c *Client) {
+		c.stickerSets = store
+	}
+}
+
+// trackStickerSet records a newly created set. Store failures are logged,
+// not returned: the set exists on Telegram either way.
+func (c *Client) trackStickerSet(ctx context.Context, name string) {
+	if c.stickerSets == nil {
+		return
+	}
+	if err := c.stickerSets.Add(ctx, name); err != nil {
+		c.logger.Warn("failed to record sticker set", "name", name, "error", err)
+	}
+}
+
+// untrackStickerSet forgets a deleted set.
+func (c *Client) untrackStickerSet(ctx context.Context, name string) {
+	if c.stickerSets == nil {
+		return
+	}
+	if err := c.stickerSets.Remove(ctx, name); err != nil {
+		c.logger.Warn("failed to forget sticker set", "name", name, "error", err)
+	}
+}
+
+// StickerSetPages returns an iterator over the sticker sets recorded in
+// store, fetched with GetStickerSet in pages of up to pageSize sets.
+// Names that no longer exist on Telegram are skipped. Any other error is
+// yielded once and ends the iteration.
+func (c *Client) StickerSetPages(ctx context.Context, store StickerSetStore, pageSize int) iter.Seq2[[]*tg.StickerSet, error] {
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	return func(yield func([]*tg.StickerSet, error) bool) {
+		names, err := store.List(ctx)
+		if err != nil {
+			yield(nil, fmt.Errorf("galigo: list sticker sets: %w", err))
+			return
+		}
+
+		page := make([]*tg.StickerSet, 0, pageSize)
+		for _, name := range names {
+			set, err := c.GetStickerSet(ctx, name)
+			if errors.Is(err, tg.ErrStickerSetInvalid) {
+				continue
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			page = append(page, set)
+			if len(page) == pageSize {
+				if !yield(page, nil) {
+					return
+				}
+				page = make([]*tg.StickerSet, 0, pageSize)
+			}
+		}
+		if len(page) > 0 {
+			yield(page, nil)
+		}
+	}
+}
+
+// StickerSetAudit is the result of AuditStickerSets.
+type StickerSetAudit struct {
+	Live    []*tg.StickerSet // Recorded and present on Telegram
+	Missing []string         // Recorded but gone from Telegram (STICKERSET_INVALID)
+	Orphans []string         // Live sets the predicate marked as orphaned
+	Deleted []string         // Orphans deleted (only when deleting)
+	Errors  map[string]error // Per-set lookup or delete failures
+}
+
+// AuditStickerSetsOption configures AuditStickerSets.
+type AuditStickerSetsOption func(*auditStickerSetsConfig)
+
+type auditStickerSetsConfig struct {
+	isOrphan      func(*tg.StickerSet) bool
+	deleteOrphans bool
+	pruneMissing  bool
+}
+
+// WithOrphanPredicate marks live sets for which fn returns true as orphans,
+// e.g. sets no longer referenced by the application.
+func WithOrphanPredicate(fn func(*tg.StickerSet) bool) AuditStickerSetsOption {
+	return func(c *auditStickerSetsConfig) {
+		c.isOrphan = fn
+	}
+}
+
+// WithDeleteOrphans deletes orphaned sets via DeleteStickerSet and removes
+// them from the store.
+func WithDeleteOrphans() AuditStickerSetsOption {
+	return func(c *auditStickerSetsConfig) {
+		c.deleteOrphans = true
+	}
+}
+
+// WithPruneMissing removes names that no longer exist on Telegram from the store.
+func WithPruneMissing() AuditStickerSetsOption {
+	return func(c *auditStickerSetsConfig) {
+		c.pruneMissing = true
+	}
+}
+
+// AuditStickerSets verifies every sticker set recorded in store via
+// GetStickerSet and classifies it as live or missing. With
+// WithOrphanPredicate and WithDeleteOrphans it bulk-deletes orphans.
+// Per-set failures are collected in StickerSetAudit.Errors; the returned
+// error is only set when the store itself fails or ctx is done.
+func (c *Client) AuditStickerSets(ctx context.Context, store StickerSetStore, opts ...AuditStickerSetsOption) (*StickerSetAudit, error) {
+	var cfg auditStickerSetsConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	names, err := store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("galigo: list sticker sets: %w", err)
+	}
+
+	audit := &StickerSetAudit{Errors: make(map[string]error)}
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return audit, err
+		}
+
+		set, err := c.GetStickerSet(ctx, name)
+		switch {
+		case errors.Is(err, tg.ErrStickerSetInvalid):
+			audit.Missing = append(audit.Missing, name)
+			if cfg.pruneMissing {
+				if err := store.Remove(ctx, name); err != nil {
+					audit.Errors[name] = err
+				}
+			}
+			continue
+		case err != nil:
+			audit.Errors[name] = err
+			continue
+		}
+
+		audit.Live = append(audit.Live, set)
+		if cfg.isOrphan == nil || !cfg.isOrphan(set) {
+			continue
+		}
+		audit.Orphans = append(audit.Orphans, name)
+		if !cfg.deleteOrphans {
+			continue
+		}
+		if err := c.DeleteStickerSet(ctx, name); err != nil {
+			audit.Errors[name] = err
+			continue
+		}
+		// DeleteStickerSet only updates the client's own store, which
+		// may differ from the audited one; Remove is idempotent.
+		if err := store.Remove(ctx, name); err != nil {
+			audit.Errors[name] = err
+		}
+		audit.Deleted = append(audit.Deleted, name)
+	}
+
+	return audit, nil
+}
@@ -0,0 +1,165 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// stickerSetServer serves getStickerSet for the given live names and
+// STICKERSET_INVALID for everything else.
+func stickerSetServer(t *testing.T, live ...string) *testutil.MockTelegramServer {
+	t.Helper()
+	exists := make(map[string]bool)
+	for _, name := range live {
+		exists[name] = true
+	}
+
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/getStickerSet", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Name string `json:"name"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if !exists[req.Name] {
+			testutil.ReplyBadRequest(w, "Bad Request: STICKERSET_INVALID")
+			return
+		}
+		testutil.ReplyOK(w, map[string]any{
+			"name":         req.Name,
+			"title":        "Title " + req.Name,
+			"sticker_type": "regular",
+			"stickers":     []map[string]any{},
+		})
+	})
+	server.On("/bot"+testutil.TestToken+"/deleteStickerSet", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBool(w, true)
+	})
+	return server
+}
+
+func TestGetStickerSet_Invalid_Sentinel(t *testing.T) {
+	server := stickerSetServer(t)
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	_, err := client.GetStickerSet(context.Background(), "gone")
+	assert.ErrorIs(t, err, tg.ErrStickerSetInvalid)
+}
+
+func TestStickerSetStore_TrackedByClient(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/createNewStickerSet", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBool(w, true)
+	})
+	server.On("/bot"+testutil.TestToken+"/deleteStickerSet", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBool(w, true)
+	})
+
+	store := &sender.MemoryStickerSetStore{}
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithStickerSetStore(store))
+	ctx := context.Background()
+
+	err := client.CreateNewStickerSet(ctx, sender.CreateNewStickerSetRequest{
+		UserID: 1,
+		Name:   "pack_by_testbot",
+		Title:  "Pack",
+		Stickers: []sender.InputSticker{{
+			Sticker:   sender.FromFileID("file1"),
+			Format:    "static",
+			EmojiList: []string{"😀"},
+		}},
+	})
+	require.NoError(t, err)
+
+	names, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pack_by_testbot"}, names)
+
+	require.NoError(t, client.DeleteStickerSet(ctx, "pack_by_testbot"))
+	names, err = store.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
+
+func TestStickerSetPages(t *testing.T) {
+	server := stickerSetServer(t, "a", "b", "d")
+	client := testutil.NewTestClient(t, server.BaseURL())
+	ctx := context.Background()
+
+	store := &sender.MemoryStickerSetStore{}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, store.Add(ctx, name))
+	}
+
+	var pages [][]string
+	for page, err := range client.StickerSetPages(ctx, store, 2) {
+		require.NoError(t, err)
+		var names []string
+		for _, set := range page {
+			names = append(names, set.Name)
+		}
+		pages = append(pages, names)
+	}
+
+	assert.Equal(t, [][]string{{"a", "b"}, {"d"}}, pages)
+}
+
+func TestAuditStickerSets_DeletesOrphansAndPrunesMissing(t *testing.T) {
+	server := stickerSetServer(t, "keep", "orphan")
+	client := testutil.NewTestClient(t, server.BaseURL())
+	ctx := context.Background()
+
+	store := &sender.MemoryStickerSetStore{}
+	for _, name := range []string{"keep", "orphan", "gone"} {
+		require.NoError(t, store.Add(ctx, name))
+	}
+
+	audit, err := client.AuditStickerSets(ctx, store,
+		sender.WithOrphanPredicate(func(set *tg.StickerSet) bool { return set.Name == "orphan" }),
+		sender.WithDeleteOrphans(),
+		sender.WithPruneMissing(),
+	)
+	require.NoError(t, err)
+
+	require.Len(t, audit.Live, 2)
+	assert.Equal(t, []string{"gone"}, audit.Missing)
+	assert.Equal(t, []string{"orphan"}, audit.Orphans)
+	assert.Equal(t, []string{"orphan"}, audit.Deleted)
+	assert.Empty(t, audit.Errors)
+
+	names, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"keep"}, names)
+
+	cap := server.LastCapture()
+	cap.AssertJSONField(t, "name", "orphan")
+}
+
+func TestAuditStickerSets_ReportOnly(t *testing.T) {
+	server := stickerSetServer(t, "orphan")
+	client := testutil.NewTestClient(t, server.BaseURL())
+	ctx := context.Background()
+
+	store := &sender.MemoryStickerSetStore{}
+	require.NoError(t, store.Add(ctx, "orphan"))
+	require.NoError(t, store.Add(ctx, "gone"))
+
+	audit, err := client.AuditStickerSets(ctx, store,
+		sender.WithOrphanPredicate(func(*tg.StickerSet) bool { return true }),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orphan"}, audit.Orphans)
+	assert.Empty(t, audit.Deleted)
+
+	names, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gone", "orphan"}, names)
+}
@@ -0,0 +1,323 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Sticker Set Sync ==================
+//
+// SyncStickerSet makes a live sticker set match a desired state, as
+// SyncCommands does for command lists. Telegram does not expose the files
+// behind a set, so the application identifies stickers it already
+// uploaded by their file_unique_id, typically saved from the Set returned
+// by a previous sync.
+
+// MaxStickersPerCreate is the number of stickers createNewStickerSet
+// accepts; larger sets are created and then extended with addStickerToSet.
+const MaxStickersPerCreate = 50
+
+// StickerSetSpec is the desired state of a sticker set.
+type StickerSetSpec struct {
+	UserID      int64  // Owner of the set
+	Name        string // Set name, ending in "_by_<bot username>"
+	Title       string
+	StickerType string // For creation: "regular" (default), "mask" or "custom_emoji"
+	Stickers    []StickerSpec
+}
+
+// StickerSpec is one sticker of a StickerSetSpec, in the desired order.
+type StickerSpec struct {
+	// FileUniqueID is the live sticker this entry stands for; empty for a
+	// sticker that still has to be uploaded. Live stickers no entry refers
+	// to are deleted.
+	FileUniqueID string
+
+	// Sticker is uploaded for new entries and, with Replace, in place of
+	// the live sticker. Its EmojiList is applied to live stickers whose
+	// list differs. Telegram only reports a sticker's first emoji, so a
+	// list of several is applied on every sync.
+	Sticker InputSticker
+
+	// Replace uploads Sticker in place of the live sticker, keeping its
+	// position.
+	Replace bool
+}
+
+// StickerSetOpKind is the kind of a StickerSetOp.
+type StickerSetOpKind string
+
+// Operations planned by SyncStickerSet.
+const (
+	StickerSetOpCreate   StickerSetOpKind = "create"
+	StickerSetOpSetTitle StickerSetOpKind = "set_title"
+	StickerSetOpDelete   StickerSetOpKind = "delete"
+	StickerSetOpReplace  StickerSetOpKind = "replace"
+	StickerSetOpSetEmoji StickerSetOpKind = "set_emoji"
+	StickerSetOpAdd      StickerSetOpKind = "add"
+	StickerSetOpMove     StickerSetOpKind = "move"
+)
+
+// StickerSetOp is one step of a sticker set sync.
+type StickerSetOp struct {
+	Kind StickerSetOpKind
+
+	// Index is the StickerSpec the step concerns, or -1 for set-level
+	// steps and deletions.
+	Index int
+
+	// FileUniqueID is the live sticker affected, when it exists before
+	// the sync (delete, replace, set_emoji, and move of kept stickers).
+	FileUniqueID string
+
+	// Position is the target position of a move.
+	Position int
+
+	fileID string // live sticker to pass to the API
+}
+
+// String describes the step, e.g. for dry-run output.
+func (op StickerSetOp) String() string {
+	switch op.Kind {
+	case StickerSetOpCreate:
+		return "create set"
+	case StickerSetOpSetTitle:
+		return "set title"
+	case StickerSetOpDelete:
+		return fmt.Sprintf("delete %s", op.FileUniqueID)
+	case StickerSetOpMove:
+		return fmt.Sprintf("move sticker %d to position %d", op.Index, op.Position)
+	default:
+		if op.FileUniqueID != "" {
+			return fmt.Sprintf("%s sticker %d (%s)", op.Kind, op.Index, op.FileUniqueID)
+		}
+		return fmt.Sprintf("%s sticker %d", op.Kind, op.Index)
+	}
+}
+
+// StickerSetSyncResult is the result of SyncStickerSet.
+type StickerSetSyncResult struct {
+	Ops     []StickerSetOp // Planned steps, in order
+	Applied int            // Steps performed (0 in a dry run)
+
+	// Set is the set after the sync, with Set.Stickers[i] corresponding
+	// to StickerSpec i; nil in a dry run or after a failure.
+	Set *tg.StickerSet
+}
+
+// SyncStickerSetOption configures SyncStickerSet.
+type SyncStickerSetOption func(*syncStickerSetConfig)
+
+type syncStickerSetConfig struct {
+	dryRun bool
+}
+
+// WithSyncDryRun only plans the sync: StickerSetSyncResult.Ops lists the
+// steps and nothing is changed.
+func WithSyncDryRun() SyncStickerSetOption {
+	return func(c *syncStickerSetConfig) {
+		c.dryRun = true
+	}
+}
+
+// SyncStickerSet makes the sticker set spec.Name match spec, creating it
+// if it does not exist. Steps are applied in the order of
+// StickerSetSyncResult.Ops: title, deletions, replacements, emoji
+// updates, additions, then moves. It stops at the first failed step.
+func (c *Client) SyncStickerSet(ctx context.Context, spec StickerSetSpec, opts ...SyncStickerSetOption) (*StickerSetSyncResult, error) {
+	var cfg syncStickerSetConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if len(spec.Stickers) == 0 {
+		return nil, tg.NewValidationError("stickers", "at least one sticker required")
+	}
+
+	live, err := c.GetStickerSet(ctx, spec.Name)
+	if err != nil && !errors.Is(err, tg.ErrStickerSetInvalid) {
+		return nil, fmt.Errorf("galigo: sync sticker set %q: %w", spec.Name, err)
+	}
+
+	var (
+		ops   []StickerSetOp
+		order []int // spec index at each position once deletions and additions are done
+	)
+	if live == nil {
+		ops = planStickerSetCreate(spec)
+	} else if ops, order, err = planStickerSetUpdate(spec, live); err != nil {
+		return nil, err
+	}
+
+	result := &StickerSetSyncResult{Ops: ops}
+	if cfg.dryRun {
+		return result, nil
+	}
+
+	for i, op := range ops {
+		if op.Kind == StickerSetOpMove && (i == 0 || ops[i-1].Kind != StickerSetOpMove) {
+			// Added and replaced stickers have no file_id until uploaded.
+			if err := c.resolveStickerMoves(ctx, spec.Name, ops[i:], order); err != nil {
+				return result, err
+			}
+			op = ops[i]
+		}
+		if err := c.applyStickerSetOp(ctx, spec, op); err != nil {
+			return result, fmt.Errorf("galigo: sync sticker set %q: %s: %w", spec.Name, op, err)
+		}
+		result.Applied++
+	}
+
+	if result.Set, err = c.GetStickerSet(ctx, spec.Name); err != nil {
+		return result, fmt.Errorf("galigo: sync sticker set %q: %w", spec.Name, err)
+	}
+	return result, nil
+}
+
+func planStickerSetCreate(spec StickerSetSpec) []StickerSetOp {
+	ops := []StickerSetOp{{Kind: StickerSetOpCreate, Index: -1}}
+	for i := MaxStickersPerCreate; i < len(spec.Stickers); i++ {
+		ops = append(ops, StickerSetOp{Kind: StickerSetOpAdd, Index: i})
+	}
+	return ops
+}
+
+// liveEmojiList returns the emoji list of st as far as Telegram reports
+// it: only the first emoji.
+func liveEmojiList(st tg.Sticker) []string {
+	if st.Emoji == "" {
+		return nil
+	}
+	return []string{st.Emoji}
+}
+
+func planStickerSetUpdate(spec StickerSetSpec, live *tg.StickerSet) ([]StickerSetOp, []int, error) {
+	var ops []StickerSetOp
+	if spec.Title != "" && spec.Title != live.Title {
+		ops = append(ops, StickerSetOp{Kind: StickerSetOpSetTitle, Index: -1})
+	}
+
+	wanted := make(map[string]int) // file_unique_id -> spec index
+	for i, s := range spec.Stickers {
+		if s.FileUniqueID == "" {
+			continue
+		}
+		if _, dup := wanted[s.FileUniqueID]; dup {
+			return nil, nil, tg.NewValidationError(fmt.Sprintf("stickers[%d].file_unique_id", i), "duplicate")
+		}
+		wanted[s.FileUniqueID] = i
+	}
+
+	var order []int
+	kept := make(map[int]tg.Sticker)
+	for _, st := range live.Stickers {
+		i, ok := wanted[st.FileUniqueID]
+		if !ok {
+			ops = append(ops, StickerSetOp{Kind: StickerSetOpDelete, Index: -1, FileUniqueID: st.FileUniqueID, fileID: st.FileID})
+			continue
+		}
+		order = append(order, i)
+		kept[i] = st
+	}
+
+	var updates, adds []StickerSetOp
+	for i, s := range spec.Stickers {
+		st, ok := kept[i]
+		switch {
+		case !ok:
+			adds = append(adds, StickerSetOp{Kind: StickerSetOpAdd, Index: i})
+			order = append(order, i)
+		case s.Replace:
+			updates = append(updates, StickerSetOp{Kind: StickerSetOpReplace, Index: i, FileUniqueID: st.FileUniqueID, fileID: st.FileID})
+		case len(s.Sticker.EmojiList) > 0 && !slices.Equal(s.Sticker.EmojiList, liveEmojiList(st)):
+			updates = append(updates, StickerSetOp{Kind: StickerSetOpSetEmoji, Index: i, FileUniqueID: st.FileUniqueID, fileID: st.FileID})
+		}
+	}
+	ops = append(ops, updates...)
+	ops = append(ops, adds...)
+
+	// Selection into the desired order; each move takes the sticker that
+	// belongs at position p from where it is now.
+	sim := slices.Clone(order)
+	for p := range sim {
+		if sim[p] == p {
+			continue
+		}
+		q := slices.Index(sim, p)
+		op := StickerSetOp{Kind: StickerSetOpMove, Index: p, Position: p}
+		if st, ok := kept[p]; ok && !spec.Stickers[p].Replace {
+			op.FileUniqueID = st.FileUniqueID
+		}
+		ops = append(ops, op)
+		sim = slices.Insert(slices.Delete(sim, q, q+1), p, p)
+	}
+	return ops, order, nil
+}
+
+// resolveStickerMoves fills in the file_id of each move from the set as it
+// is after all other steps, whose positions follow order.
+func (c *Client) resolveStickerMoves(ctx context.Context, name string, moves []StickerSetOp, order []int) error {
+	set, err := c.GetStickerSet(ctx, name)
+	if err != nil {
+		return fmt.Errorf("galigo: sync sticker set %q: %w", name, err)
+	}
+	if len(set.Stickers) != len(order) {
+		return fmt.Errorf("galigo: sync sticker set %q: set has %d stickers, expected %d; was it changed concurrently?", name, len(set.Stickers), len(order))
+	}
+	fileIDs := make(map[int]string, len(order))
+	for pos, i := range order {
+		fileIDs[i] = set.Stickers[pos].FileID
+	}
+	for j := range moves {
+		moves[j].fileID = fileIDs[moves[j].Index]
+	}
+	return nil
+}
+
+func (c *Client) applyStickerSetOp(ctx context.Context, spec StickerSetSpec, op StickerSetOp) error {
+	switch op.Kind {
+	case StickerSetOpCreate:
+		var stickers []InputSticker
+		for _, s := range spec.Stickers[:min(len(spec.Stickers), MaxStickersPerCreate)] {
+			stickers = append(stickers, s.Sticker)
+		}
+		return c.CreateNewStickerSet(ctx, CreateNewStickerSetRequest{
+			UserID:      spec.UserID,
+			Name:        spec.Name,
+			Title:       spec.Title,
+			Stickers:    stickers,
+			StickerType: spec.StickerType,
+		})
+	case StickerSetOpSetTitle:
+		return c.SetStickerSetTitle(ctx, spec.Name, spec.Title)
+	case StickerSetOpDelete:
+		return c.DeleteStickerFromSet(ctx, op.fileID)
+	case StickerSetOpReplace:
+		return c.ReplaceStickerInSet(ctx, ReplaceStickerInSetRequest{
+			UserID:     spec.UserID,
+			Name:       spec.Name,
+			OldSticker: op.fileID,
+			Sticker:    spec.Stickers[op.Index].Sticker,
+		})
+	case StickerSetOpSetEmoji:
+		return c.SetStickerEmojiList(ctx, SetStickerEmojiListRequest{
+			Sticker:   op.fileID,
+			EmojiList: spec.Stickers[op.Index].Sticker.EmojiList,
+		})
+	case StickerSetOpAdd:
+		return c.AddStickerToSet(ctx, AddStickerToSetRequest{
+			UserID:  spec.UserID,
+			Name:    spec.Name,
+			Sticker: spec.Stickers[op.Index].Sticker,
+		})
+	case StickerSetOpMove:
+		return c.SetStickerPositionInSet(ctx, SetStickerPositionInSetRequest{
+			Sticker:  op.fileID,
+			Position: op.Position,
+		})
+	}
+	return fmt.Errorf("unknown operation %q", op.Kind)
+}
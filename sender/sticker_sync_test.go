@@ -0,0 +1,215 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// fakeStickerSet serves one sticker set and applies the sticker methods to
+// it. A sticker uploaded as FileID "X" becomes file_unique_id "X".
+type fakeStickerSet struct {
+	mu       sync.Mutex
+	exists   bool
+	title    string
+	stickers []tg.Sticker
+	calls    []string
+}
+
+func (f *fakeStickerSet) indexOf(fileID string) int {
+	return slices.IndexFunc(f.stickers, func(s tg.Sticker) bool { return s.FileID == fileID })
+}
+
+func newFakeStickerSet(t *testing.T, set *fakeStickerSet) *sender.Client {
+	t.Helper()
+	uploaded := func(raw any) tg.Sticker {
+		var in struct {
+			Sticker   string   `json:"sticker"`
+			EmojiList []string `json:"emoji_list"`
+		}
+		_ = json.Unmarshal([]byte(raw.(string)), &in)
+		return tg.Sticker{FileID: "f-" + in.Sticker, FileUniqueID: in.Sticker, Emoji: in.EmojiList[0]}
+	}
+
+	server := testutil.NewMockServer(t)
+	handle := func(method string, fn func(req map[string]any)) {
+		server.On("/bot"+testutil.TestToken+"/"+method, func(w http.ResponseWriter, r *http.Request) {
+			var req map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			set.mu.Lock()
+			defer set.mu.Unlock()
+			if method == "getStickerSet" {
+				if !set.exists {
+					testutil.ReplyBadRequest(w, "Bad Request: STICKERSET_INVALID")
+					return
+				}
+				testutil.ReplyOK(w, tg.StickerSet{Name: "brand_by_bot", Title: set.title, Stickers: set.stickers})
+				return
+			}
+			set.calls = append(set.calls, method)
+			fn(req)
+			testutil.ReplyBool(w, true)
+		})
+	}
+	handle("getStickerSet", nil)
+	handle("createNewStickerSet", func(req map[string]any) {
+		var stickers []json.RawMessage
+		_ = json.Unmarshal([]byte(req["stickers"].(string)), &stickers)
+		set.exists, set.title = true, req["title"].(string)
+		for _, s := range stickers {
+			set.stickers = append(set.stickers, uploaded(string(s)))
+		}
+	})
+	handle("setStickerSetTitle", func(req map[string]any) { set.title = req["title"].(string) })
+	handle("deleteStickerFromSet", func(req map[string]any) {
+		i := set.indexOf(req["sticker"].(string))
+		set.stickers = slices.Delete(set.stickers, i, i+1)
+	})
+	handle("addStickerToSet", func(req map[string]any) { set.stickers = append(set.stickers, uploaded(req["sticker"])) })
+	handle("replaceStickerInSet", func(req map[string]any) {
+		set.stickers[set.indexOf(req["old_sticker"].(string))] = uploaded(req["sticker"])
+	})
+	handle("setStickerEmojiList", func(req map[string]any) {
+		set.stickers[set.indexOf(req["sticker"].(string))].Emoji = req["emoji_list"].([]any)[0].(string)
+	})
+	handle("setStickerPositionInSet", func(req map[string]any) {
+		i := set.indexOf(req["sticker"].(string))
+		st := set.stickers[i]
+		set.stickers = slices.Insert(slices.Delete(set.stickers, i, i+1), int(req["position"].(float64)), st)
+	})
+	return testutil.NewTestClient(t, server.BaseURL())
+}
+
+func specSticker(uniqueID, emoji string) sender.StickerSpec {
+	return sender.StickerSpec{
+		FileUniqueID: uniqueID,
+		Sticker: sender.InputSticker{
+			Sticker:   sender.InputFile{FileID: uniqueID},
+			Format:    "static",
+			EmojiList: []string{emoji},
+		},
+	}
+}
+
+func newSticker(fileID, emoji string) sender.StickerSpec {
+	s := specSticker(fileID, emoji)
+	s.FileUniqueID = ""
+	return s
+}
+
+func uniqueIDs(set *tg.StickerSet) []string {
+	var ids []string
+	for _, s := range set.Stickers {
+		ids = append(ids, s.FileUniqueID)
+	}
+	return ids
+}
+
+func TestSyncStickerSet_CreatesMissingSet(t *testing.T) {
+	fake := &fakeStickerSet{}
+	client := newFakeStickerSet(t, fake)
+
+	res, err := client.SyncStickerSet(context.Background(), sender.StickerSetSpec{
+		UserID: 1, Name: "brand_by_bot", Title: "Brand",
+		Stickers: []sender.StickerSpec{newSticker("A", "😀"), newSticker("B", "😎")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"createNewStickerSet"}, fake.calls)
+	assert.Equal(t, []string{"A", "B"}, uniqueIDs(res.Set))
+}
+
+func TestSyncStickerSet_AppliesDiff(t *testing.T) {
+	fake := &fakeStickerSet{exists: true, title: "Old", stickers: []tg.Sticker{
+		{FileID: "f-A", FileUniqueID: "A", Emoji: "😀"},
+		{FileID: "f-B", FileUniqueID: "B", Emoji: "😎"},
+		{FileID: "f-C", FileUniqueID: "C", Emoji: "🙂"},
+		{FileID: "f-D", FileUniqueID: "D", Emoji: "🤖"},
+	}}
+	client := newFakeStickerSet(t, fake)
+
+	replaced := newSticker("B2", "😎")
+	replaced.FileUniqueID, replaced.Replace = "B", true
+	spec := sender.StickerSetSpec{
+		UserID: 1, Name: "brand_by_bot", Title: "Brand",
+		Stickers: []sender.StickerSpec{
+			newSticker("E", "🎉"), // new, first
+			specSticker("C", "🙂"),
+			replaced,              // B replaced in place
+			specSticker("A", "😺"), // emoji changed
+			// D deleted
+		},
+	}
+
+	plan, err := client.SyncStickerSet(context.Background(), spec, sender.WithSyncDryRun())
+	require.NoError(t, err)
+	assert.Zero(t, plan.Applied)
+	assert.Nil(t, plan.Set)
+	assert.Empty(t, fake.calls, "dry run changes nothing")
+
+	var kinds []sender.StickerSetOpKind
+	for _, op := range plan.Ops {
+		kinds = append(kinds, op.Kind)
+	}
+	assert.Equal(t, []sender.StickerSetOpKind{
+		sender.StickerSetOpSetTitle,
+		sender.StickerSetOpDelete,
+		sender.StickerSetOpReplace,
+		sender.StickerSetOpSetEmoji,
+		sender.StickerSetOpAdd,
+	}, kinds[:5])
+	assert.Equal(t, "delete D", plan.Ops[1].String())
+
+	res, err := client.SyncStickerSet(context.Background(), spec)
+	require.NoError(t, err)
+	assert.Equal(t, len(res.Ops), res.Applied)
+	assert.Equal(t, "Brand", res.Set.Title)
+	assert.Equal(t, []string{"E", "C", "B2", "A"}, uniqueIDs(res.Set))
+	assert.Equal(t, "😺", res.Set.Stickers[3].Emoji)
+
+	// In sync now: nothing left to do.
+	next := sender.StickerSetSpec{UserID: 1, Name: "brand_by_bot", Title: "Brand"}
+	for _, s := range res.Set.Stickers {
+		next.Stickers = append(next.Stickers, specSticker(s.FileUniqueID, s.Emoji))
+	}
+	again, err := client.SyncStickerSet(context.Background(), next, sender.WithSyncDryRun())
+	require.NoError(t, err)
+	assert.Empty(t, again.Ops)
+}
+
+func TestSyncStickerSet_ComparesFullEmojiList(t *testing.T) {
+	fake := &fakeStickerSet{exists: true, title: "Brand", stickers: []tg.Sticker{{FileID: "f-A", FileUniqueID: "A", Emoji: "😀"}}}
+	client := newFakeStickerSet(t, fake)
+
+	spec := sender.StickerSetSpec{UserID: 1, Name: "brand_by_bot", Stickers: []sender.StickerSpec{specSticker("A", "😀")}}
+	plan, err := client.SyncStickerSet(context.Background(), spec, sender.WithSyncDryRun())
+	require.NoError(t, err)
+	assert.Empty(t, plan.Ops)
+
+	spec.Stickers[0].Sticker.EmojiList = []string{"😀", "🔥"}
+	plan, err = client.SyncStickerSet(context.Background(), spec, sender.WithSyncDryRun())
+	require.NoError(t, err)
+	require.Len(t, plan.Ops, 1, "same first emoji, longer list")
+	assert.Equal(t, sender.StickerSetOpSetEmoji, plan.Ops[0].Kind)
+}
+
+func TestSyncStickerSet_DuplicateFileUniqueID(t *testing.T) {
+	fake := &fakeStickerSet{exists: true, title: "Brand", stickers: []tg.Sticker{{FileID: "f-A", FileUniqueID: "A", Emoji: "😀"}}}
+	client := newFakeStickerSet(t, fake)
+
+	_, err := client.SyncStickerSet(context.Background(), sender.StickerSetSpec{
+		UserID: 1, Name: "brand_by_bot",
+		Stickers: []sender.StickerSpec{specSticker("A", "😀"), specSticker("A", "😀")},
+	})
+	var vErr *tg.ValidationError
+	assert.ErrorAs(t, err, &vErr)
+}
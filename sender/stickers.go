@@ -109,11 +109,7 @@ func (c *Client) GetStickerSet(ctx context.Context, name string) (*tg.StickerSet
 		return nil, tg.NewValidationError("name", "required")
 	}
 
-	var result tg.StickerSet
-	if err := c.callJSON(ctx, "getStickerSet", GetStickerSetRequest{Name: name}, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.StickerSet](c, ctx, "getStickerSet", GetStickerSetRequest{Name: name})
 }
 
 // GetCustomEmojiStickers returns information about custom emoji stickers by their identifiers.
@@ -144,11 +140,7 @@ func (c *Client) UploadStickerFile(ctx context.Context, req UploadStickerFileReq
 		return nil, tg.NewValidationError("sticker_format", "required")
 	}
 
-	var result tg.File
-	if err := c.callJSON(ctx, "uploadStickerFile", req, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return call[tg.File](c, ctx, "uploadStickerFile", req)
 }
 
 // CreateNewStickerSet creates a new sticker set owned by a user.
@@ -174,7 +166,11 @@ func (c *Client) CreateNewStickerSet(ctx context.Context, req CreateNewStickerSe
 		return err
 	}
 
-	return c.callJSON(ctx, "createNewStickerSet", payload, nil)
+	if err := c.callJSON(ctx, "createNewStickerSet", payload, nil); err != nil {
+		return err
+	}
+	c.trackStickerSet(ctx, req.Name)
+	return nil
 }
 
 // AddStickerToSet adds a new sticker to an existing sticker set.
@@ -230,7 +226,11 @@ func (c *Client) DeleteStickerSet(ctx context.Context, name string) error {
 		return tg.NewValidationError("name", "required")
 	}
 
-	return c.callJSON(ctx, "deleteStickerSet", DeleteStickerSetRequest{Name: name}, nil)
+	if err := c.callJSON(ctx, "deleteStickerSet", DeleteStickerSetRequest{Name: name}, nil); err != nil {
+		return err
+	}
+	c.untrackStickerSet(ctx, name)
+	return nil
 }
 
 // SetStickerSetThumbnail sets the thumbnail of a sticker set.
@@ -329,12 +329,8 @@ func resolveInputSticker(s InputSticker, attachName string) (inputStickerJSON, *
 		return sj, nil, nil
 	case s.Sticker.Reader != nil || s.Sticker.Source != nil:
 		sj.Sticker = "attach://" + attachName
-		fp := &FilePart{
-			FieldName: attachName,
-			FileName:  s.Sticker.FileName,
-			Reader:    s.Sticker.OpenReader(),
-		}
-		return sj, fp, nil
+		fp := s.Sticker.filePart(attachName)
+		return sj, &fp, nil
 	default:
 		return sj, nil, fmt.Errorf("InputFile must have FileID, URL, or Reader set")
 	}
@@ -0,0 +1,75 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// MaxDeclineCommentLength is the longest comment DeclineSuggestedPost
+// accepts, in characters.
+const MaxDeclineCommentLength = 128
+
+// ================== Suggested Post Requests ==================
+
+// ApproveSuggestedPostRequest represents an approveSuggestedPost request.
+type ApproveSuggestedPostRequest struct {
+	ChatID    int64 `json:"chat_id"` // the direct messages chat
+	MessageID int   `json:"message_id"`
+	SendDate  int64 `json:"send_date,omitempty"` // Unix time; 0 = as suggested or now
+}
+
+// DeclineSuggestedPostRequest represents a declineSuggestedPost request.
+type DeclineSuggestedPostRequest struct {
+	ChatID    int64  `json:"chat_id"` // the direct messages chat
+	MessageID int    `json:"message_id"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+// ================== Suggested Post Methods ==================
+
+// ApproveSuggestedPost approves a suggested post in a channel direct
+// messages chat. sendDate overrides the publication time, and must be set
+// if the post has none; pass 0 otherwise. The bot must have the
+// can_post_messages right in the channel.
+func (c *Client) ApproveSuggestedPost(ctx context.Context, chatID int64, messageID int, sendDate int64) error {
+	if err := validateChatID(chatID); err != nil {
+		return err
+	}
+	if err := validateMessageID(messageID); err != nil {
+		return err
+	}
+	if sendDate < 0 {
+		return tg.NewValidationError("send_date", "must not be negative")
+	}
+
+	return c.callJSON(ctx, "approveSuggestedPost", ApproveSuggestedPostRequest{
+		ChatID:    chatID,
+		MessageID: messageID,
+		SendDate:  sendDate,
+	}, nil, extractChatID(chatID))
+}
+
+// DeclineSuggestedPost declines a suggested post in a channel direct
+// messages chat, with an optional comment for its author of at most
+// MaxDeclineCommentLength characters. The bot must have the
+// can_manage_direct_messages right in the channel.
+func (c *Client) DeclineSuggestedPost(ctx context.Context, chatID int64, messageID int, comment string) error {
+	if err := validateChatID(chatID); err != nil {
+		return err
+	}
+	if err := validateMessageID(messageID); err != nil {
+		return err
+	}
+	if n := utf8.RuneCountInString(comment); n > MaxDeclineCommentLength {
+		return tg.NewValidationError("comment", fmt.Sprintf("must be at most %d characters, got %d", MaxDeclineCommentLength, n))
+	}
+
+	return c.callJSON(ctx, "declineSuggestedPost", DeclineSuggestedPostRequest{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Comment:   comment,
+	}, nil, extractChatID(chatID))
+}
@@ -0,0 +1,129 @@
+package sender_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+	"github.com/prilive-com/galigo/tg/sendopt"
+)
+
+func TestApproveSuggestedPost(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/approveSuggestedPost", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBool(w, true)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	err := client.ApproveSuggestedPost(context.Background(), -100123, 42, 1800000000)
+	require.NoError(t, err)
+
+	cap := server.LastCapture()
+	cap.AssertJSONField(t, "chat_id", float64(-100123))
+	cap.AssertJSONField(t, "message_id", float64(42))
+	cap.AssertJSONField(t, "send_date", float64(1800000000))
+}
+
+func TestApproveSuggestedPost_NoSendDate(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/approveSuggestedPost", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBool(w, true)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	require.NoError(t, client.ApproveSuggestedPost(context.Background(), -100123, 42, 0))
+	server.LastCapture().AssertJSONFieldAbsent(t, "send_date")
+}
+
+func TestDeclineSuggestedPost(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/declineSuggestedPost", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyBool(w, true)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	err := client.DeclineSuggestedPost(context.Background(), -100123, 42, "off topic")
+	require.NoError(t, err)
+
+	cap := server.LastCapture()
+	cap.AssertJSONField(t, "message_id", float64(42))
+	cap.AssertJSONField(t, "comment", "off topic")
+}
+
+func TestSuggestedPost_Validation(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	client := testutil.NewTestClient(t, server.BaseURL())
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		call  func() error
+		field string
+	}{
+		{"approve zero chat", func() error { return client.ApproveSuggestedPost(ctx, 0, 42, 0) }, "chat_id"},
+		{"approve zero message", func() error { return client.ApproveSuggestedPost(ctx, -100123, 0, 0) }, "message_id"},
+		{"approve negative date", func() error { return client.ApproveSuggestedPost(ctx, -100123, 42, -1) }, "send_date"},
+		{"decline zero message", func() error { return client.DeclineSuggestedPost(ctx, -100123, 0, "") }, "message_id"},
+		{"decline long comment", func() error {
+			return client.DeclineSuggestedPost(ctx, -100123, 42, strings.Repeat("x", sender.MaxDeclineCommentLength+1))
+		}, "comment"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.call()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.field)
+		})
+	}
+	assert.Equal(t, 0, server.CaptureCount(), "validation should fail before HTTP call")
+}
+
+func TestSendMessage_SuggestedPost(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	req := sender.SendMessageRequest{ChatID: int64(-100123), Text: "post me"}
+	req.ApplySendOptions(
+		sendopt.DirectMessagesTopic(77),
+		sendopt.SuggestPost(&tg.SuggestedPostParameters{
+			Price:    &tg.SuggestedPostPrice{Currency: tg.SuggestedPostCurrencyStars, Amount: 50},
+			SendDate: 1800000000,
+		}),
+	)
+	_, err := client.SendMessage(context.Background(), req)
+	require.NoError(t, err)
+
+	cap := server.LastCapture()
+	cap.AssertJSONField(t, "direct_messages_topic_id", float64(77))
+	cap.AssertJSONField(t, "suggested_post_parameters", map[string]any{
+		"price":     map[string]any{"currency": "XTR", "amount": float64(50)},
+		"send_date": float64(1800000000),
+	})
+}
+
+func TestSendMessage_SuggestedPostInvalidPrice(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{
+		ChatID: int64(-100123),
+		Text:   "post me",
+		SuggestedPostParameters: &tg.SuggestedPostParameters{
+			Price: &tg.SuggestedPostPrice{Currency: tg.SuggestedPostCurrencyStars, Amount: 1},
+		},
+	})
+	var verr *tg.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "price.amount", verr.Field)
+	assert.Equal(t, 0, server.CaptureCount())
+}
@@ -0,0 +1,288 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/prilive-com/galigo/tg"
+	"github.com/prilive-com/galigo/tg/sendopt"
+)
+
+// ================== Message Templates ==================
+//
+// Templates keep message wording, formatting and keyboards in one place
+// instead of scattered through business code. Each MessageTemplate is a
+// text/template text plus a parse mode and an inline keyboard whose
+// labels, URLs and callback data are templates too. Definitions are
+// checked when added, so a broken template fails at startup rather than
+// on the first send.
+//
+// Values are not escaped automatically. Use the escape function, which
+// escapes for the template's parse mode:
+//
+//	welcome:
+//	  text: "<b>Hello, {{escape .Name}}!</b>"
+//	  parse_mode: HTML
+//	  keyboard:
+//	    - [{text: "Open profile", url: "https://example.com/u/{{.ID}}"}]
+
+// MessageTemplate defines a message. Text, and the Text, URL and
+// CallbackData of buttons, are text/template templates.
+type MessageTemplate struct {
+	Text      string             `json:"text" yaml:"text"`
+	ParseMode tg.ParseMode       `json:"parse_mode,omitempty" yaml:"parse_mode,omitempty"`
+	Keyboard  [][]TemplateButton `json:"keyboard,omitempty" yaml:"keyboard,omitempty"`
+}
+
+// TemplateButton is an inline keyboard button of a MessageTemplate.
+// Exactly one of URL and CallbackData must be set.
+type TemplateButton struct {
+	Text         string `json:"text" yaml:"text"`
+	URL          string `json:"url,omitempty" yaml:"url,omitempty"`
+	CallbackData string `json:"callback_data,omitempty" yaml:"callback_data,omitempty"`
+}
+
+// RenderedMessage is a template rendered with data, as it would be sent.
+type RenderedMessage struct {
+	Text        string
+	ParseMode   tg.ParseMode
+	ReplyMarkup *tg.InlineKeyboardMarkup // nil without keyboard
+}
+
+// Templates is a set of named message templates. It is safe for
+// concurrent use; add templates at startup, before sending.
+type Templates struct {
+	mu        sync.RWMutex
+	templates map[string]*compiledTemplate
+}
+
+type compiledTemplate struct {
+	parseMode tg.ParseMode
+	text      *template.Template
+	buttons   [][]compiledButton
+}
+
+type compiledButton struct {
+	text, url, callbackData *template.Template
+}
+
+// NewTemplates returns an empty template set.
+func NewTemplates() *Templates {
+	return &Templates{templates: make(map[string]*compiledTemplate)}
+}
+
+// Add parses and validates def and stores it as name, replacing an
+// existing template of that name.
+func (t *Templates) Add(name string, def MessageTemplate) error {
+	compiled, err := compileTemplate(name, def)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.templates[name] = compiled
+	return nil
+}
+
+// Load adds the templates of a document mapping names to MessageTemplate
+// definitions, decoded with unmarshal (json.Unmarshal, yaml.Unmarshal, ...).
+// Nothing is added if any definition is invalid.
+func (t *Templates) Load(data []byte, unmarshal func([]byte, any) error) error {
+	var defs map[string]MessageTemplate
+	if err := unmarshal(data, &defs); err != nil {
+		return fmt.Errorf("galigo: load templates: %w", err)
+	}
+	compiled := make(map[string]*compiledTemplate, len(defs))
+	for _, name := range slices.Sorted(maps.Keys(defs)) {
+		c, err := compileTemplate(name, defs[name])
+		if err != nil {
+			return err
+		}
+		compiled[name] = c
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for name, c := range compiled {
+		t.templates[name] = c
+	}
+	return nil
+}
+
+// Names returns the names of all templates, sorted.
+func (t *Templates) Names() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return slices.Sorted(maps.Keys(t.templates))
+}
+
+// Render executes template name with data, e.g. to preview a message
+// before it is sent. The rendered text and callback data are checked
+// against Telegram's limits.
+func (t *Templates) Render(name string, data any) (*RenderedMessage, error) {
+	t.mu.RLock()
+	c, ok := t.templates[name]
+	t.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("galigo: template %q not found", name)
+	}
+
+	text, err := execute(c.text, data)
+	if err != nil {
+		return nil, fmt.Errorf("galigo: template %q: %w", name, err)
+	}
+	msg := &RenderedMessage{Text: text, ParseMode: c.parseMode}
+	if err := checkLength("text", text, MaxMessageTextLength, c.parseMode == ""); err != nil {
+		return nil, fmt.Errorf("galigo: template %q: %w", name, err)
+	}
+
+	if len(c.buttons) > 0 {
+		markup := &tg.InlineKeyboardMarkup{}
+		for _, row := range c.buttons {
+			var buttons []tg.InlineKeyboardButton
+			for _, b := range row {
+				var btn tg.InlineKeyboardButton
+				for _, f := range []struct {
+					tmpl *template.Template
+					dst  *string
+				}{{b.text, &btn.Text}, {b.url, &btn.URL}, {b.callbackData, &btn.CallbackData}} {
+					if f.tmpl == nil {
+						continue
+					}
+					if *f.dst, err = execute(f.tmpl, data); err != nil {
+						return nil, fmt.Errorf("galigo: template %q: %w", name, err)
+					}
+				}
+				buttons = append(buttons, btn)
+			}
+			markup.InlineKeyboard = append(markup.InlineKeyboard, buttons)
+		}
+		if err := checkReplyMarkup(markup); err != nil {
+			return nil, fmt.Errorf("galigo: template %q: %w", name, err)
+		}
+		msg.ReplyMarkup = markup
+	}
+	return msg, nil
+}
+
+// WithTemplates sets the templates used by SendTemplate.
+func WithTemplates(t *Templates) Option {
+	return func(c *Client) {
+		c.templates = t
+	}
+}
+
+// SendTemplate renders template name with data and sends it to chatID.
+// opts are applied after the template, so they can override its parse
+// mode or keyboard.
+func (c *Client) SendTemplate(ctx context.Context, chatID tg.ChatID, name string, data any, opts ...sendopt.Option) (*tg.Message, error) {
+	if c.templates == nil {
+		return nil, errors.New("galigo: SendTemplate: no templates configured (see WithTemplates)")
+	}
+	msg, err := c.templates.Render(name, data)
+	if err != nil {
+		return nil, err
+	}
+	req := SendMessageRequest{
+		ChatID:    chatID,
+		Text:      msg.Text,
+		ParseMode: msg.ParseMode,
+	}
+	if msg.ReplyMarkup != nil {
+		req.ReplyMarkup = msg.ReplyMarkup
+	}
+	req.ApplySendOptions(opts...)
+	return c.SendMessage(ctx, req)
+}
+
+func compileTemplate(name string, def MessageTemplate) (*compiledTemplate, error) {
+	fail := func(field, msg string) error {
+		return fmt.Errorf("galigo: template %q: %w", name, tg.NewValidationError(field, msg))
+	}
+	switch def.ParseMode {
+	case "", tg.ParseModeHTML, tg.ParseModeMarkdownV2, tg.ParseModeMarkdown:
+	default:
+		return nil, fail("parse_mode", fmt.Sprintf("unsupported parse mode %q", def.ParseMode))
+	}
+	if strings.TrimSpace(def.Text) == "" {
+		return nil, fail("text", "required")
+	}
+
+	c := &compiledTemplate{parseMode: def.ParseMode}
+	var err error
+	if c.text, err = parseTemplate(name, def.Text, def.ParseMode); err != nil {
+		return nil, fail("text", err.Error())
+	}
+	if isStatic(c.text) {
+		if err := checkLength("text", def.Text, MaxMessageTextLength, def.ParseMode == ""); err != nil {
+			return nil, fmt.Errorf("galigo: template %q: %w", name, err)
+		}
+	}
+
+	for i, row := range def.Keyboard {
+		var buttons []compiledButton
+		for j, b := range row {
+			field := fmt.Sprintf("keyboard[%d][%d]", i, j)
+			if b.Text == "" {
+				return nil, fail(field+".text", "required")
+			}
+			if (b.URL == "") == (b.CallbackData == "") {
+				return nil, fail(field, "exactly one of url and callback_data must be set")
+			}
+			var cb compiledButton
+			for _, f := range []struct {
+				name, src string
+				dst       **template.Template
+			}{{"text", b.Text, &cb.text}, {"url", b.URL, &cb.url}, {"callback_data", b.CallbackData, &cb.callbackData}} {
+				if f.src == "" {
+					continue
+				}
+				if *f.dst, err = parseTemplate(name, f.src, ""); err != nil {
+					return nil, fail(field+"."+f.name, err.Error())
+				}
+			}
+			if cb.callbackData != nil && isStatic(cb.callbackData) && len(b.CallbackData) > MaxCallbackDataBytes {
+				return nil, fail(field+".callback_data", fmt.Sprintf("must be at most %d bytes, got %d", MaxCallbackDataBytes, len(b.CallbackData)))
+			}
+			buttons = append(buttons, cb)
+		}
+		c.buttons = append(c.buttons, buttons)
+	}
+	return c, nil
+}
+
+// parseTemplate parses src with an escape function for mode.
+func parseTemplate(name, src string, mode tg.ParseMode) (*template.Template, error) {
+	return template.New(name).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"escape": escaper(mode)}).
+		Parse(src)
+}
+
+// escaper returns the function that makes a value safe to embed in text of
+// the given parse mode.
+func escaper(mode tg.ParseMode) func(any) string {
+	return func(v any) string {
+		return tg.EscapeText(mode, fmt.Sprint(v))
+	}
+}
+
+// isStatic reports whether t is plain text without actions.
+func isStatic(t *template.Template) bool {
+	nodes := t.Tree.Root.Nodes
+	return len(nodes) == 0 || (len(nodes) == 1 && nodes[0].Type() == parse.NodeText)
+}
+
+func execute(t *template.Template, data any) (string, error) {
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
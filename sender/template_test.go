@@ -0,0 +1,113 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+const templatesJSON = `{
+  "welcome": {
+    "text": "<b>Hello, {{escape .Name}}!</b>",
+    "parse_mode": "HTML",
+    "keyboard": [[{"text": "Profile", "url": "https://example.com/u/{{.ID}}"}, {"text": "Help", "callback_data": "help"}]]
+  },
+  "bye": {"text": "Bye, {{escape .Name}}.", "parse_mode": "MarkdownV2"}
+}`
+
+func TestTemplates_Render(t *testing.T) {
+	tmpl := sender.NewTemplates()
+	require.NoError(t, tmpl.Load([]byte(templatesJSON), json.Unmarshal))
+	assert.Equal(t, []string{"bye", "welcome"}, tmpl.Names())
+
+	msg, err := tmpl.Render("welcome", map[string]any{"Name": "<Ann>", "ID": 7})
+	require.NoError(t, err)
+	assert.Equal(t, "<b>Hello, &lt;Ann&gt;!</b>", msg.Text)
+	assert.Equal(t, tg.ParseModeHTML, msg.ParseMode)
+	require.NotNil(t, msg.ReplyMarkup)
+	assert.Equal(t, [][]tg.InlineKeyboardButton{{
+		{Text: "Profile", URL: "https://example.com/u/7"},
+		{Text: "Help", CallbackData: "help"},
+	}}, msg.ReplyMarkup.InlineKeyboard)
+
+	msg, err = tmpl.Render("bye", map[string]any{"Name": "a.b"})
+	require.NoError(t, err)
+	assert.Equal(t, `Bye, a\.b.`, msg.Text)
+	assert.Nil(t, msg.ReplyMarkup)
+}
+
+func TestTemplates_ValidatedAtLoad(t *testing.T) {
+	tests := []struct {
+		name string
+		def  sender.MessageTemplate
+	}{
+		{"empty text", sender.MessageTemplate{}},
+		{"bad syntax", sender.MessageTemplate{Text: "Hi {{.Name"}},
+		{"bad parse mode", sender.MessageTemplate{Text: "Hi", ParseMode: "BBCode"}},
+		{"too long", sender.MessageTemplate{Text: strings.Repeat("a", sender.MaxMessageTextLength+1)}},
+		{"button without action", sender.MessageTemplate{Text: "Hi", Keyboard: [][]sender.TemplateButton{{{Text: "x"}}}}},
+		{"callback data too long", sender.MessageTemplate{Text: "Hi", Keyboard: [][]sender.TemplateButton{{
+			{Text: "x", CallbackData: strings.Repeat("d", sender.MaxCallbackDataBytes+1)},
+		}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, sender.NewTemplates().Add("t", tt.def))
+		})
+	}
+
+	// A bad definition leaves the set unchanged.
+	tmpl := sender.NewTemplates()
+	err := tmpl.Load([]byte(`{"ok": {"text": "hi"}, "bad": {"text": "{{"}}`), json.Unmarshal)
+	require.Error(t, err)
+	assert.Empty(t, tmpl.Names())
+}
+
+func TestTemplates_RenderErrors(t *testing.T) {
+	tmpl := sender.NewTemplates()
+	require.NoError(t, tmpl.Add("hi", sender.MessageTemplate{Text: "Hi {{.Name}}"}))
+
+	_, err := tmpl.Render("missing", nil)
+	assert.Error(t, err)
+
+	_, err = tmpl.Render("hi", map[string]any{})
+	assert.Error(t, err, "missing keys fail instead of rendering <no value>")
+
+	var vErr *tg.ValidationError
+	_, err = tmpl.Render("hi", map[string]any{"Name": strings.Repeat("a", sender.MaxMessageTextLength)})
+	assert.ErrorAs(t, err, &vErr)
+}
+
+func TestSendTemplate(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+
+	tmpl := sender.NewTemplates()
+	require.NoError(t, tmpl.Load([]byte(templatesJSON), json.Unmarshal))
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithTemplates(tmpl))
+
+	_, err := client.SendTemplate(context.Background(), int64(42), "welcome", map[string]any{"Name": "Ann", "ID": 7})
+	require.NoError(t, err)
+
+	cap := server.LastCapture()
+	cap.AssertJSONField(t, "text", "<b>Hello, Ann!</b>")
+	cap.AssertJSONField(t, "parse_mode", "HTML")
+	cap.AssertJSONFieldExists(t, "reply_markup")
+}
+
+func TestSendTemplate_WithoutTemplates(t *testing.T) {
+	client := testutil.NewTestClient(t, "http://127.0.0.1:0")
+	_, err := client.SendTemplate(context.Background(), int64(42), "welcome", nil)
+	assert.Error(t, err)
+}
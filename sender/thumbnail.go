@@ -0,0 +1,36 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+)
+
+// ThumbnailerFunc generates a thumbnail for an uploaded video or animation,
+// typically by wrapping ffmpeg. media.OpenReader returns a fresh reader of
+// the upload. Telegram expects a JPEG under 200 kB and at most 320 px on
+// each side. Return nil to send without a thumbnail.
+type ThumbnailerFunc func(ctx context.Context, media InputFile) (*InputFile, error)
+
+// WithThumbnailer sets a hook that attaches a generated thumbnail to
+// SendVideo and SendAnimation requests that upload a file and have no
+// Thumbnail. It is only called for re-readable uploads (FromFile,
+// FromBytes, FromReaderAt or Source set), so the video itself is still
+// sent in full; thumbnails for FileID and URL media are Telegram's job.
+func WithThumbnailer(fn ThumbnailerFunc) Option {
+	return func(c *Client) {
+		c.thumbnailer = fn
+	}
+}
+
+// autoThumbnail fills *thumb from the thumbnailer when media qualifies.
+func (c *Client) autoThumbnail(ctx context.Context, media InputFile, thumb **InputFile) error {
+	if c.thumbnailer == nil || *thumb != nil || media.Source == nil {
+		return nil
+	}
+	t, err := c.thumbnailer(ctx, media)
+	if err != nil {
+		return fmt.Errorf("thumbnail: %w", err)
+	}
+	*thumb = t
+	return nil
+}
@@ -0,0 +1,120 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+)
+
+func staticThumbnailer(calls *int) sender.ThumbnailerFunc {
+	return func(ctx context.Context, media sender.InputFile) (*sender.InputFile, error) {
+		*calls++
+		data, err := io.ReadAll(media.OpenReader())
+		if err != nil {
+			return nil, err
+		}
+		thumb := sender.FromBytes([]byte("thumb of "+string(data)), "thumb.jpg")
+		return &thumb, nil
+	}
+}
+
+func TestThumbnailer_AttachesThumbnail(t *testing.T) {
+	var thumbParam, thumbData, videoData string
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendVideo", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		thumbParam = r.FormValue("thumbnail")
+		if f, _, err := r.FormFile("file0"); err == nil {
+			b, _ := io.ReadAll(f)
+			thumbData = string(b)
+		}
+		if f, _, err := r.FormFile("video"); err == nil {
+			b, _ := io.ReadAll(f)
+			videoData = string(b)
+		}
+		testutil.ReplyMessage(w, 1)
+	})
+
+	var calls int
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithThumbnailer(staticThumbnailer(&calls)))
+
+	_, err := client.SendVideo(context.Background(), sender.SendVideoRequest{
+		ChatID: testutil.TestChatID,
+		Video:  sender.FromBytes([]byte("video"), "clip.mp4"),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "attach://file0", thumbParam)
+	assert.Equal(t, "thumb of video", thumbData)
+	assert.Equal(t, "video", videoData, "thumbnailer must not consume the upload")
+}
+
+func TestThumbnailer_Animation(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendAnimation", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+
+	var calls int
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithThumbnailer(staticThumbnailer(&calls)))
+
+	_, err := client.SendAnimation(context.Background(), sender.SendAnimationRequest{
+		ChatID:    testutil.TestChatID,
+		Animation: sender.FromBytes([]byte("gif"), "a.gif"),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestThumbnailer_Skipped(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendVideo", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyMessage(w, 1)
+	})
+
+	var calls int
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithThumbnailer(staticThumbnailer(&calls)))
+
+	own := sender.FromBytes([]byte("mine"), "thumb.jpg")
+	for name, video := range map[string]sender.SendVideoRequest{
+		"file id":       {Video: sender.FromFileID("BAACAgIAAxkBAAI")},
+		"url":           {Video: sender.FromURL("https://example.com/v.mp4")},
+		"single-use":    {Video: sender.FromReader(strings.NewReader("video"), "v.mp4")},
+		"has thumbnail": {Video: sender.FromBytes([]byte("video"), "v.mp4"), Thumbnail: &own},
+	} {
+		video.ChatID = testutil.TestChatID
+		_, err := client.SendVideo(context.Background(), video)
+		require.NoError(t, err, name)
+	}
+
+	assert.Zero(t, calls)
+}
+
+func TestThumbnailer_Error(t *testing.T) {
+	server := testutil.NewMockServer(t)
+
+	failing := func(ctx context.Context, media sender.InputFile) (*sender.InputFile, error) {
+		return nil, errors.New("ffmpeg not found")
+	}
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithThumbnailer(failing))
+
+	_, err := client.SendVideo(context.Background(), sender.SendVideoRequest{
+		ChatID: testutil.TestChatID,
+		Video:  sender.FromBytes([]byte("video"), "clip.mp4"),
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "thumbnail: ffmpeg not found")
+	assert.Zero(t, server.CaptureCount())
+}
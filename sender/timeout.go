@@ -0,0 +1,93 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ================== Request Timeouts ==================
+
+type timeoutKey struct{}
+
+// WithRequestTimeout overrides the client's per-attempt timeout for calls
+// made with ctx, e.g. a longer budget for one large upload or a tighter one
+// for a latency-sensitive reply. Each retry attempt gets d afresh; ctx's own
+// deadline, if any, still bounds the call as a whole.
+func WithRequestTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutKey{}, d)
+}
+
+// WithSendTimeout sets the per-attempt timeout of JSON requests.
+func WithSendTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.config.SendTimeout = d
+	}
+}
+
+// WithUploadTimeout sets the per-attempt timeout of multipart requests
+// that upload files. The whole body is streamed within this budget.
+func WithUploadTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.config.UploadTimeout = d
+	}
+}
+
+// WithDownloadTimeout sets the timeout of file downloads.
+func WithDownloadTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.config.DownloadTimeout = d
+	}
+}
+
+// requestKind selects which configured budget applies to a request.
+type requestKind int
+
+const (
+	kindSend requestKind = iota
+	kindUpload
+	kindDownload
+)
+
+// requestTimeout returns the per-attempt budget for a request made with
+// ctx; 0 means no limit beyond ctx.
+func (c *Client) requestTimeout(ctx context.Context, kind requestKind) time.Duration {
+	if d, ok := ctx.Value(timeoutKey{}).(time.Duration); ok {
+		return d
+	}
+	var d time.Duration
+	switch kind {
+	case kindSend:
+		d = c.config.SendTimeout
+	case kindUpload:
+		d = c.config.UploadTimeout
+	case kindDownload:
+		d = c.config.DownloadTimeout
+	}
+	if d == 0 {
+		d = c.config.RequestTimeout
+	}
+	return d
+}
+
+// attemptTimeoutError reports that one attempt ran out of its per-request
+// budget while the caller's context was still live. It is a net.Error
+// timeout, so withRetry retries it like any other network timeout.
+type attemptTimeoutError struct {
+	method  string
+	timeout time.Duration
+}
+
+func (e *attemptTimeoutError) Error() string {
+	return fmt.Sprintf("%s: request timed out after %s", e.method, e.timeout)
+}
+
+func (e *attemptTimeoutError) Timeout() bool   { return true }
+func (e *attemptTimeoutError) Temporary() bool { return true }
+
+// attemptTimedOut reports whether attempt expired on its own deadline
+// rather than because parent was canceled or expired.
+func attemptTimedOut(parent, attempt context.Context) bool {
+	return parent.Err() == nil && errors.Is(attempt.Err(), context.DeadlineExceeded)
+}
@@ -0,0 +1,134 @@
+package sender_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+)
+
+func slowHandler(delay time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		testutil.ReplyMessage(w, 1)
+	}
+}
+
+func TestSendTimeout_AttemptTimesOut(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", slowHandler(time.Second))
+
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithSendTimeout(50*time.Millisecond))
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{
+		ChatID: testutil.TestChatID,
+		Text:   "Hello",
+	})
+
+	require.Error(t, err)
+	var netErr net.Error
+	require.True(t, errors.As(err, &netErr))
+	assert.True(t, netErr.Timeout())
+	assert.Contains(t, err.Error(), "sendMessage: request timed out after 50ms")
+}
+
+func TestSendTimeout_RetriesTimedOutAttempt(t *testing.T) {
+	var calls atomic.Int32
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			slowHandler(time.Second)(w, r)
+			return
+		}
+		testutil.ReplyMessage(w, 1)
+	})
+
+	sleeper := &testutil.FakeSleeper{}
+	client := testutil.NewRetryTestClient(t, server.BaseURL(), sleeper,
+		sender.WithRetries(1),
+		sender.WithSendTimeout(50*time.Millisecond),
+	)
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{
+		ChatID: testutil.TestChatID,
+		Text:   "Hello",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestSendTimeout_CallerDeadlineNotRetried(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", slowHandler(time.Second))
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.SendMessage(ctx, sender.SendMessageRequest{
+		ChatID: testutil.TestChatID,
+		Text:   "Hello",
+	})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWithRequestTimeout_OverridesConfig(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", slowHandler(100*time.Millisecond))
+
+	client := testutil.NewTestClient(t, server.BaseURL(), sender.WithSendTimeout(20*time.Millisecond))
+
+	ctx := sender.WithRequestTimeout(context.Background(), 5*time.Second)
+	_, err := client.SendMessage(ctx, sender.SendMessageRequest{
+		ChatID: testutil.TestChatID,
+		Text:   "Hello",
+	})
+
+	require.NoError(t, err)
+}
+
+func TestUploadTimeout_AppliesToMultipart(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendPhoto", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		slowHandler(100*time.Millisecond)(w, r)
+	})
+
+	// The send budget would kill this request; uploads get their own.
+	client := testutil.NewTestClient(t, server.BaseURL(),
+		sender.WithSendTimeout(20*time.Millisecond),
+		sender.WithUploadTimeout(5*time.Second),
+	)
+
+	_, err := client.SendPhoto(context.Background(), sender.SendPhotoRequest{
+		ChatID: testutil.TestChatID,
+		Photo:  sender.FromBytes([]byte("fake image data"), "photo.jpg"),
+	})
+
+	require.NoError(t, err)
+}
+
+func TestDefaultConfig_Timeouts(t *testing.T) {
+	cfg := sender.DefaultConfig()
+
+	assert.Zero(t, cfg.SendTimeout)
+	assert.Equal(t, 5*time.Minute, cfg.UploadTimeout)
+	assert.Equal(t, 5*time.Minute, cfg.DownloadTimeout)
+}
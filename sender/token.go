@@ -0,0 +1,69 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prilive-com/galigo/internal/scrub"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Token Rotation ==================
+
+// tokenPair is the current token and the one it replaced. Errors are
+// scrubbed of both, as requests still in flight use the old one.
+type tokenPair struct {
+	current  tg.SecretToken
+	previous tg.SecretToken
+}
+
+// tokenOverrideKey carries the token SetToken validates into doRequest.
+type tokenOverrideKey struct{}
+
+// token returns the token for a request with ctx.
+func (c *Client) token(ctx context.Context) tg.SecretToken {
+	if t, ok := ctx.Value(tokenOverrideKey{}).(tg.SecretToken); ok {
+		return t
+	}
+	return c.tokens.Load().current
+}
+
+// scrubToken removes the current, the previous and any extra tokens from
+// err's message.
+func (c *Client) scrubToken(err error, extra ...tg.SecretToken) error {
+	pair := c.tokens.Load()
+	err = scrub.TokenFromError(err, pair.current)
+	err = scrub.TokenFromError(err, pair.previous)
+	for _, t := range extra {
+		err = scrub.TokenFromError(err, t)
+	}
+	return err
+}
+
+// SetToken switches the client to token, e.g. after the old one was
+// revoked in @BotFather. The token is first checked with getMe, bypassing
+// the rate limiter and breaker; if that fails, or the token belongs to
+// another bot (tg.ErrTokenBotMismatch), the client keeps its current
+// token. Requests already in flight finish with the old token. With
+// WithDryRun the getMe check is skipped.
+func (c *Client) SetToken(ctx context.Context, token tg.SecretToken) error {
+	if token.IsEmpty() {
+		return ErrInvalidToken
+	}
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	old := c.tokens.Load().current
+	if botIDFromToken(token.Value()) != botIDFromToken(old.Value()) {
+		return tg.ErrTokenBotMismatch
+	}
+	if c.dryRun == nil {
+		ctx = context.WithValue(ctx, tokenOverrideKey{}, token)
+		if _, err := c.doRequest(ctx, "getMe", struct{}{}); err != nil {
+			return fmt.Errorf("galigo: validate token: %w", err)
+		}
+	}
+	c.tokens.Store(&tokenPair{current: token, previous: old})
+	c.logger.Info("bot token rotated")
+	return nil
+}
@@ -0,0 +1,89 @@
+package sender_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/internal/testutil"
+	"github.com/prilive-com/galigo/sender"
+	"github.com/prilive-com/galigo/tg"
+)
+
+const rotatedToken = "123456789:NewSecretAfterRotation"
+
+func TestSetToken(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	require.NoError(t, client.SetToken(context.Background(), rotatedToken))
+	assert.Equal(t, "/bot"+rotatedToken+"/getMe", server.LastCapture().Path)
+
+	_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "hi"})
+	require.NoError(t, err)
+	assert.Equal(t, "/bot"+rotatedToken+"/sendMessage", server.LastCapture().Path)
+}
+
+func TestSetToken_InvalidKeepsOldToken(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+rotatedToken+"/getMe", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyError(w, 401, "Unauthorized", nil)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	err := client.SetToken(context.Background(), rotatedToken)
+	require.ErrorIs(t, err, tg.ErrUnauthorized)
+	assert.NotContains(t, err.Error(), "NewSecret")
+
+	_, err = client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "hi"})
+	require.NoError(t, err)
+	assert.Equal(t, "/bot"+testutil.TestToken+"/sendMessage", server.LastCapture().Path)
+}
+
+func TestSetToken_OtherBot(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	assert.ErrorIs(t, client.SetToken(context.Background(), "987654321:Other"), tg.ErrTokenBotMismatch)
+	assert.ErrorIs(t, client.SetToken(context.Background(), ""), tg.ErrInvalidToken)
+	assert.Zero(t, server.CaptureCount())
+}
+
+func TestSetToken_InFlightFinishesOnOldToken(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		testutil.ReplyMessage(w, 1)
+	})
+	client := testutil.NewTestClient(t, server.BaseURL())
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.SendMessage(context.Background(), sender.SendMessageRequest{ChatID: testutil.TestChatID, Text: "slow"})
+		done <- err
+	}()
+	<-started
+	require.NoError(t, client.SetToken(context.Background(), rotatedToken))
+	close(release)
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("in-flight request did not finish")
+	}
+
+	var paths []string
+	for _, c := range server.Captures() {
+		paths = append(paths, strings.TrimPrefix(c.Path, "/bot"))
+	}
+	assert.Equal(t, []string{testutil.TestToken + "/sendMessage", rotatedToken + "/getMe"}, paths)
+}
@@ -72,3 +72,34 @@ func validateMessageIDs(ids []int) error {
 	}
 	return nil
 }
+
+// validateChatAction validates an action for sendChatAction.
+func validateChatAction(action tg.ChatAction) error {
+	if action == "" {
+		return tg.NewValidationError("action", "required")
+	}
+	if !action.IsValid() {
+		return tg.NewValidationError("action", fmt.Sprintf("unknown chat action %q (see tg.ChatAction)", action))
+	}
+	return nil
+}
+
+// validateReaction validates a reaction for setMessageReaction.
+func validateReaction(r tg.ReactionType) error {
+	switch r.Type {
+	case tg.ReactionEmoji:
+		if r.Emoji == "" {
+			return fmt.Errorf("galigo: emoji reaction requires emoji")
+		}
+		if !tg.IsReactionEmoji(r.Emoji) {
+			return fmt.Errorf("galigo: %q is not a reaction emoji (see tg.ReactionEmojis)", r.Emoji)
+		}
+	case tg.ReactionCustomEmoji:
+		if r.CustomEmoji == "" {
+			return fmt.Errorf("galigo: custom_emoji reaction requires custom_emoji_id")
+		}
+	case tg.ReactionPaid:
+		return fmt.Errorf("galigo: bots cannot set paid reactions")
+	}
+	return nil
+}
@@ -2,12 +2,19 @@ package sender
 
 import (
 	"context"
+	"fmt"
+	"unicode/utf8"
 
 	"github.com/prilive-com/galigo/tg"
 )
 
 // ================== Verification Request Types ==================
 
+// MaxVerificationDescriptionLength is the longest custom verification
+// description, in characters. Organizations without the right to provide
+// one must leave it empty.
+const MaxVerificationDescriptionLength = 70
+
 // SetPassportDataErrorsRequest represents a setPassportDataErrors request.
 type SetPassportDataErrorsRequest struct {
 	UserID int64                     `json:"user_id"`
@@ -55,6 +62,9 @@ func (c *Client) VerifyUser(ctx context.Context, req VerifyUserRequest) error {
 	if req.UserID <= 0 {
 		return tg.NewValidationError("user_id", "must be positive")
 	}
+	if err := validateVerificationDescription(req.CustomDescription); err != nil {
+		return err
+	}
 
 	return c.callJSON(ctx, "verifyUser", req, nil)
 }
@@ -64,6 +74,9 @@ func (c *Client) VerifyChat(ctx context.Context, req VerifyChatRequest) error {
 	if err := validateChatID(req.ChatID); err != nil {
 		return err
 	}
+	if err := validateVerificationDescription(req.CustomDescription); err != nil {
+		return err
+	}
 
 	return c.callJSON(ctx, "verifyChat", req, nil, extractChatID(req.ChatID))
 }
@@ -85,3 +98,10 @@ func (c *Client) RemoveChatVerification(ctx context.Context, chatID tg.ChatID) e
 
 	return c.callJSON(ctx, "removeChatVerification", RemoveChatVerificationRequest{ChatID: chatID}, nil, extractChatID(chatID))
 }
+
+func validateVerificationDescription(desc string) error {
+	if n := utf8.RuneCountInString(desc); n > MaxVerificationDescriptionLength {
+		return tg.NewValidationError("custom_description", fmt.Sprintf("must be at most %d characters, got %d", MaxVerificationDescriptionLength, n))
+	}
+	return nil
+}
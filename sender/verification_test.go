@@ -3,6 +3,7 @@ package sender_test
 import (
 	"context"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -83,6 +84,13 @@ func TestVerifyUser_Validation(t *testing.T) {
 	err := client.VerifyUser(context.Background(), sender.VerifyUserRequest{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "user_id")
+
+	err = client.VerifyUser(context.Background(), sender.VerifyUserRequest{
+		UserID:            456,
+		CustomDescription: strings.Repeat("a", sender.MaxVerificationDescriptionLength+1),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "custom_description")
 }
 
 // ==================== VerifyChat ====================
@@ -100,6 +108,21 @@ func TestVerifyChat(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestVerifyChat_DescriptionCountsCharacters(t *testing.T) {
+	server := testutil.NewMockServer(t)
+	server.On("/bot"+testutil.TestToken+"/verifyChat", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ReplyOK(w, true)
+	})
+
+	client := testutil.NewTestClient(t, server.BaseURL())
+	err := client.VerifyChat(context.Background(), sender.VerifyChatRequest{
+		ChatID:            int64(123),
+		CustomDescription: strings.Repeat("é", sender.MaxVerificationDescriptionLength),
+	})
+	require.NoError(t, err)
+	server.LastCapture().AssertJSONFieldExists(t, "custom_description")
+}
+
 func TestVerifyChat_Validation(t *testing.T) {
 	server := testutil.NewMockServer(t)
 	client := testutil.NewTestClient(t, server.BaseURL())
@@ -107,6 +130,13 @@ func TestVerifyChat_Validation(t *testing.T) {
 	err := client.VerifyChat(context.Background(), sender.VerifyChatRequest{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "chat_id")
+
+	err = client.VerifyChat(context.Background(), sender.VerifyChatRequest{
+		ChatID:            int64(123),
+		CustomDescription: strings.Repeat("a", sender.MaxVerificationDescriptionLength+1),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "custom_description")
 }
 
 // ==================== RemoveUserVerification ====================
@@ -0,0 +1,107 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-process Store. Expired sessions are dropped when
+// read and by Sweep. The zero value is ready to use.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	value   []byte
+	expires time.Time // zero = never
+}
+
+var _ CASStore = (*MemoryStore)(nil)
+
+// Get returns the value under key unless it has expired.
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.get(key)
+	return value, ok, nil
+}
+
+// Set stores value under key for ttl (0 = no expiry).
+func (s *MemoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set(key, value, ttl)
+	return nil
+}
+
+// CompareAndSwap stores value under key if key holds old (nil = absent).
+func (s *MemoryStore) CompareAndSwap(_ context.Context, key string, old, value []byte, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cur, ok := s.get(key)
+	if ok != (old != nil) || !bytes.Equal(cur, old) {
+		return false, nil
+	}
+	s.set(key, value, ttl)
+	return true, nil
+}
+
+// get returns the value under key, dropping it if expired. s.mu is held.
+func (s *MemoryStore) get(key string) ([]byte, bool) {
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		delete(s.entries, key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+// set stores value under key. s.mu is held.
+func (s *MemoryStore) set(key string, value []byte, ttl time.Duration) {
+	if s.entries == nil {
+		s.entries = make(map[string]memoryEntry)
+	}
+	e := memoryEntry{value: value}
+	if ttl > 0 {
+		e.expires = time.Now().Add(ttl)
+	}
+	s.entries[key] = e
+}
+
+// Delete removes key.
+func (s *MemoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// Sweep drops expired sessions and returns how many were removed. Call it
+// periodically in long-running bots with many short-lived sessions.
+func (s *MemoryStore) Sweep() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	removed := 0
+	for k, e := range s.entries {
+		if !e.expires.IsZero() && now.After(e.expires) {
+			delete(s.entries, k)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Len returns the number of stored sessions, including expired ones not
+// yet swept.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
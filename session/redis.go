@@ -0,0 +1,137 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// RedisEval runs a Lua script on Redis and returns its reply. With
+// go-redis:
+//
+//	eval := func(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+//	    return rdb.Eval(ctx, script, keys, args...).Result()
+//	}
+type RedisEval func(ctx context.Context, script string, keys []string, args ...any) (any, error)
+
+// redisGet returns {value}, or {} when KEYS[1] is absent; an empty array
+// rather than nil, which some clients report as an error.
+const redisGet = `
+local v = redis.call('GET', KEYS[1])
+if v then
+  return {v}
+end
+return {}
+`
+
+// redisSet sets KEYS[1] to ARGV[1], expiring after ARGV[2] ms unless 0.
+const redisSet = `
+if tonumber(ARGV[2]) > 0 then
+  redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+else
+  redis.call('SET', KEYS[1], ARGV[1])
+end
+return 1
+`
+
+// redisCompareAndSwap sets KEYS[1] to ARGV[3] like redisSet with the TTL
+// in ARGV[4], if it holds ARGV[2] (ARGV[1] = "1") or is absent (ARGV[1] =
+// "0"), returning 1 if it did.
+const redisCompareAndSwap = `
+local cur = redis.call('GET', KEYS[1])
+if ARGV[1] == '1' then
+  if cur ~= ARGV[2] then
+    return 0
+  end
+elseif cur then
+  return 0
+end
+if tonumber(ARGV[4]) > 0 then
+  redis.call('SET', KEYS[1], ARGV[3], 'PX', ARGV[4])
+else
+  redis.call('SET', KEYS[1], ARGV[3])
+end
+return 1
+`
+
+// redisDelete deletes KEYS[1].
+const redisDelete = `return redis.call('DEL', KEYS[1])`
+
+// RedisStore is a CASStore in Redis, shared by every process using the
+// same keys; set WithPrefix per bot when bots share a Redis. Expiry uses
+// Redis's own TTLs.
+type RedisStore struct {
+	eval RedisEval
+}
+
+var _ CASStore = (*RedisStore)(nil)
+
+// NewRedisStore returns a RedisStore running its scripts with eval.
+func NewRedisStore(eval RedisEval) *RedisStore {
+	return &RedisStore{eval: eval}
+}
+
+// Get returns the value under key.
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := s.eval(ctx, redisGet, []string{key})
+	if err != nil {
+		return nil, false, fmt.Errorf("session: redis get: %w", err)
+	}
+	items, ok := reply.([]any)
+	if !ok {
+		return nil, false, fmt.Errorf("session: redis get: unexpected reply %T", reply)
+	}
+	if len(items) == 0 {
+		return nil, false, nil
+	}
+	switch v := items[0].(type) {
+	case string:
+		return []byte(v), true, nil
+	case []byte:
+		return v, true, nil
+	}
+	return nil, false, fmt.Errorf("session: redis get: unexpected value %T", items[0])
+}
+
+// Set stores value under key for ttl (0 = no expiry).
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if _, err := s.eval(ctx, redisSet, []string{key}, string(value), ttlMillis(ttl)); err != nil {
+		return fmt.Errorf("session: redis set: %w", err)
+	}
+	return nil
+}
+
+// CompareAndSwap stores value under key if key holds old (nil = absent).
+func (s *RedisStore) CompareAndSwap(ctx context.Context, key string, old, value []byte, ttl time.Duration) (bool, error) {
+	present := "0"
+	if old != nil {
+		present = "1"
+	}
+	reply, err := s.eval(ctx, redisCompareAndSwap, []string{key}, present, string(old), string(value), ttlMillis(ttl))
+	if err != nil {
+		return false, fmt.Errorf("session: redis compare-and-swap: %w", err)
+	}
+	n, ok := reply.(int64)
+	if !ok {
+		return false, fmt.Errorf("session: redis compare-and-swap: unexpected reply %T", reply)
+	}
+	return n == 1, nil
+}
+
+// Delete removes key.
+func (s *RedisStore) Delete(ctx context.Context, key string) error {
+	if _, err := s.eval(ctx, redisDelete, []string{key}); err != nil {
+		return fmt.Errorf("session: redis delete: %w", err)
+	}
+	return nil
+}
+
+// ttlMillis formats ttl as whole milliseconds, rounding up so a short TTL
+// does not become "no expiry".
+func ttlMillis(ttl time.Duration) string {
+	if ttl <= 0 {
+		return "0"
+	}
+	return strconv.FormatInt(int64((ttl+time.Millisecond-1)/time.Millisecond), 10)
+}
@@ -0,0 +1,385 @@
+// Package session attaches per-chat or per-user state to update contexts.
+//
+// A Manager loads a typed value for the chat (or user) an update belongs
+// to, and handlers read and write it through the update context:
+//
+//	type state struct {
+//	    Step  string
+//	    Items []string
+//	}
+//
+//	sessions := session.NewManager[state](&session.MemoryStore{}, session.WithTTL(24*time.Hour))
+//	bot, _ := galigo.New(token, galigo.WithContextDecorator(sessions.Decorator()))
+//	...
+//	ctx := bot.UpdateContext(update)
+//	sess, _ := session.From[state](ctx)
+//	st, err := sess.Get(ctx)
+//	st.Step = "confirm"
+//	err = sess.Set(ctx, st)
+//
+// Values are serialized with JSON by default (see WithCodec) and kept in a
+// Store: MemoryStore, RedisStore or SQLStore, or any other implementation
+// of the three-method Store interface.
+//
+// Session.Update is safe against concurrent updates of the same session:
+// with a CASStore (all built-in stores) it retries on conflicting writes,
+// across processes; with a plain Store it serializes updates within the
+// process.
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prilive-com/galigo/receiver"
+	"github.com/prilive-com/galigo/tg"
+)
+
+// Store persists serialized sessions. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Get returns the value under key, if present and not expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key; ttl 0 means no expiry.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// CASStore is a Store that can replace a value only if it is unchanged,
+// which Session.Update uses to detect concurrent writes.
+type CASStore interface {
+	Store
+	// CompareAndSwap stores value under key if key holds old, or is absent
+	// (or expired) when old is nil, and reports whether it did.
+	CompareAndSwap(ctx context.Context, key string, old, value []byte, ttl time.Duration) (bool, error)
+}
+
+// Codec serializes session values.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// ErrConflict is returned by Session.Update when other writers changed the
+// session on every attempt.
+var ErrConflict = errors.New("session: too many concurrent updates")
+
+// maxUpdateAttempts bounds the compare-and-swap attempts of Session.Update.
+const maxUpdateAttempts = 10
+
+// ErrNoKey is returned by Session methods when the update has no chat or
+// user to key the session by (e.g. an inline query with PerChat).
+var ErrNoKey = errors.New("session: update has no session key")
+
+// KeyFunc derives the session key of an update. It returns false when the
+// update does not belong to a session.
+type KeyFunc func(update tg.Update) (string, bool)
+
+// PerChat keys sessions by chat: everyone in a group shares one session.
+// This is the default.
+func PerChat(update tg.Update) (string, bool) {
+	if chat := update.EffectiveChat(); chat != nil {
+		return "chat:" + strconv.FormatInt(chat.ID, 10), true
+	}
+	return "", false
+}
+
+// PerUser keys sessions by user, across all chats.
+func PerUser(update tg.Update) (string, bool) {
+	if user := update.EffectiveUser(); user != nil {
+		return "user:" + strconv.FormatInt(user.ID, 10), true
+	}
+	return "", false
+}
+
+// PerChatUser keys sessions by user within a chat.
+func PerChatUser(update tg.Update) (string, bool) {
+	chat, user := update.EffectiveChat(), update.EffectiveUser()
+	if chat == nil || user == nil {
+		return "", false
+	}
+	return "chat:" + strconv.FormatInt(chat.ID, 10) + ":user:" + strconv.FormatInt(user.ID, 10), true
+}
+
+type config struct {
+	ttl    time.Duration
+	key    KeyFunc
+	prefix string
+	codec  Codec
+}
+
+// Option configures a Manager.
+type Option func(*config)
+
+// WithTTL expires sessions ttl after their last write (default: never).
+func WithTTL(ttl time.Duration) Option {
+	return func(c *config) {
+		c.ttl = ttl
+	}
+}
+
+// WithKey sets how updates map to sessions (default PerChat).
+func WithKey(fn KeyFunc) Option {
+	return func(c *config) {
+		c.key = fn
+	}
+}
+
+// WithPrefix namespaces store keys, so several managers or bots can share
+// one Store (default "session:").
+func WithPrefix(prefix string) Option {
+	return func(c *config) {
+		c.prefix = prefix
+	}
+}
+
+// WithCodec sets the serialization of session values (default JSON).
+func WithCodec(codec Codec) Option {
+	return func(c *config) {
+		c.codec = codec
+	}
+}
+
+// Manager loads and saves sessions of type T.
+type Manager[T any] struct {
+	store Store
+	cfg   config
+	locks keyLocks // serializes Update for stores without CompareAndSwap
+}
+
+// NewManager returns a Manager keeping sessions of type T in store.
+func NewManager[T any](store Store, opts ...Option) *Manager[T] {
+	cfg := config{key: PerChat, prefix: "session:", codec: jsonCodec{}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Manager[T]{store: store, cfg: cfg}
+}
+
+// ForUpdate returns the session of update. Nothing is loaded until the
+// first Get.
+func (m *Manager[T]) ForUpdate(update tg.Update) *Session[T] {
+	key, ok := m.cfg.key(update)
+	if !ok {
+		return &Session[T]{m: m}
+	}
+	return m.ForKey(key)
+}
+
+// ForKey returns the session stored under key, e.g. to reach a user's
+// session from a background job.
+func (m *Manager[T]) ForKey(key string) *Session[T] {
+	return &Session[T]{m: m, key: m.cfg.prefix + key}
+}
+
+// Decorator returns a receiver.ContextDecorator that attaches the update's
+// session to its context, for use with galigo.WithContextDecorator.
+// Retrieve it with From.
+func (m *Manager[T]) Decorator() receiver.ContextDecorator {
+	return func(ctx context.Context, update tg.Update) context.Context {
+		return NewContext(ctx, m.ForUpdate(update))
+	}
+}
+
+// Session is the state of one chat or user. Loaded values are cached for
+// the lifetime of the Session, which is normally one update; it is safe
+// for concurrent use.
+type Session[T any] struct {
+	m   *Manager[T]
+	key string
+
+	mu     sync.Mutex
+	loaded bool
+	value  T
+	raw    []byte // stored form of value; nil when absent
+}
+
+// Key returns the store key of the session, or "" if the update had none.
+func (s *Session[T]) Key() string {
+	return s.key
+}
+
+// Get returns the session value, or T's zero value for a new session.
+func (s *Session[T]) Get(ctx context.Context) (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(ctx); err != nil {
+		var zero T
+		return zero, err
+	}
+	return s.value, nil
+}
+
+// Set saves v as the session value.
+func (s *Session[T]) Set(ctx context.Context, v T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.save(ctx, v)
+}
+
+// Update loads the session value, applies fn and saves the result. A
+// concurrent write to the session between the load and the save is not
+// lost: with a CASStore, the value is reloaded and fn applied again (so fn
+// may run more than once, and Update fails with ErrConflict after
+// repeated conflicts); with a plain Store, updates through the same
+// Manager run one at a time.
+func (s *Session[T]) Update(ctx context.Context, fn func(*T)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.key == "" {
+		return ErrNoKey
+	}
+	cas, ok := s.m.store.(CASStore)
+	if !ok {
+		defer s.m.locks.lock(s.key)()
+		s.loaded = false // another Session may have written since the load
+		if err := s.load(ctx); err != nil {
+			return err
+		}
+		v := s.value
+		fn(&v)
+		return s.save(ctx, v)
+	}
+
+	for range maxUpdateAttempts {
+		if err := s.load(ctx); err != nil {
+			return err
+		}
+		v := s.value
+		fn(&v)
+		data, err := s.m.cfg.codec.Marshal(v)
+		if err != nil {
+			return err
+		}
+		swapped, err := cas.CompareAndSwap(ctx, s.key, s.raw, data, s.m.cfg.ttl)
+		if err != nil {
+			return err
+		}
+		if swapped {
+			s.value, s.raw = v, data
+			return nil
+		}
+		s.loaded = false
+	}
+	return ErrConflict
+}
+
+// Delete removes the session; the next Get returns the zero value.
+func (s *Session[T]) Delete(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.key == "" {
+		return ErrNoKey
+	}
+	if err := s.m.store.Delete(ctx, s.key); err != nil {
+		return err
+	}
+	var zero T
+	s.value, s.raw, s.loaded = zero, nil, true
+	return nil
+}
+
+// load reads the value once. Callers hold s.mu.
+func (s *Session[T]) load(ctx context.Context) error {
+	if s.loaded {
+		return nil
+	}
+	if s.key == "" {
+		return ErrNoKey
+	}
+	data, ok, err := s.m.store.Get(ctx, s.key)
+	if err != nil {
+		return err
+	}
+	var v T
+	if ok {
+		if err := s.m.cfg.codec.Unmarshal(data, &v); err != nil {
+			return err
+		}
+	} else {
+		data = nil
+	}
+	s.value, s.raw, s.loaded = v, data, true
+	return nil
+}
+
+// save writes v. Callers hold s.mu.
+func (s *Session[T]) save(ctx context.Context, v T) error {
+	if s.key == "" {
+		return ErrNoKey
+	}
+	data, err := s.m.cfg.codec.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := s.m.store.Set(ctx, s.key, data, s.m.cfg.ttl); err != nil {
+		return err
+	}
+	s.value, s.raw, s.loaded = v, data, true
+	return nil
+}
+
+// keyLocks is a mutex per key, held only while in use. The zero value is
+// ready to use.
+type keyLocks struct {
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lock locks key and returns its unlock function.
+func (l *keyLocks) lock(key string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*keyLock)
+	}
+	kl := l.locks[key]
+	if kl == nil {
+		kl = &keyLock{}
+		l.locks[key] = kl
+	}
+	kl.refs++
+	l.mu.Unlock()
+
+	kl.mu.Lock()
+	return func() {
+		kl.mu.Unlock()
+		l.mu.Lock()
+		if kl.refs--; kl.refs == 0 {
+			delete(l.locks, key)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// ================== Context ==================
+
+// contextKey is distinct per session type, so managers of different types
+// can decorate the same context.
+type contextKey[T any] struct{}
+
+// NewContext returns ctx carrying s.
+func NewContext[T any](ctx context.Context, s *Session[T]) context.Context {
+	return context.WithValue(ctx, contextKey[T]{}, s)
+}
+
+// From returns the session of type T attached to ctx by a Manager's
+// Decorator.
+func From[T any](ctx context.Context) (*Session[T], bool) {
+	s, ok := ctx.Value(contextKey[T]{}).(*Session[T])
+	return s, ok
+}
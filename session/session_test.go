@@ -0,0 +1,196 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/session"
+	"github.com/prilive-com/galigo/tg"
+)
+
+type cart struct {
+	Step  string   `json:"step"`
+	Items []string `json:"items"`
+}
+
+func message(chatID, userID int64) tg.Update {
+	return tg.Update{Message: &tg.Message{
+		Chat: &tg.Chat{ID: chatID, Type: "group"},
+		From: &tg.User{ID: userID},
+	}}
+}
+
+func TestSession_GetSetAcrossUpdates(t *testing.T) {
+	ctx := context.Background()
+	m := session.NewManager[cart](&session.MemoryStore{})
+
+	s := m.ForUpdate(message(-100, 1))
+	v, err := s.Get(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, v)
+
+	require.NoError(t, s.Set(ctx, cart{Step: "pick", Items: []string{"apple"}}))
+
+	// Another member of the same chat sees the same session (PerChat).
+	v, err = m.ForUpdate(message(-100, 2)).Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, cart{Step: "pick", Items: []string{"apple"}}, v)
+
+	// A different chat starts empty.
+	v, err = m.ForUpdate(message(-200, 1)).Get(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, v)
+}
+
+func TestSession_KeyFuncs(t *testing.T) {
+	u := message(-100, 7)
+
+	key, ok := session.PerChat(u)
+	assert.True(t, ok)
+	assert.Equal(t, "chat:-100", key)
+
+	key, ok = session.PerUser(u)
+	assert.True(t, ok)
+	assert.Equal(t, "user:7", key)
+
+	key, ok = session.PerChatUser(u)
+	assert.True(t, ok)
+	assert.Equal(t, "chat:-100:user:7", key)
+
+	_, ok = session.PerChat(tg.Update{InlineQuery: &tg.InlineQuery{From: &tg.User{ID: 7}}})
+	assert.False(t, ok)
+}
+
+func TestSession_NoKey(t *testing.T) {
+	m := session.NewManager[cart](&session.MemoryStore{})
+	s := m.ForUpdate(tg.Update{InlineQuery: &tg.InlineQuery{From: &tg.User{ID: 7}}})
+
+	_, err := s.Get(context.Background())
+	assert.ErrorIs(t, err, session.ErrNoKey)
+	assert.ErrorIs(t, s.Set(context.Background(), cart{}), session.ErrNoKey)
+}
+
+func TestSession_UpdateAndDelete(t *testing.T) {
+	ctx := context.Background()
+	store := &session.MemoryStore{}
+	m := session.NewManager[cart](store, session.WithKey(session.PerUser), session.WithPrefix("shop:"))
+	s := m.ForUpdate(message(-100, 7))
+	assert.Equal(t, "shop:user:7", s.Key())
+
+	require.NoError(t, s.Update(ctx, func(c *cart) { c.Items = append(c.Items, "pear") }))
+	require.NoError(t, s.Update(ctx, func(c *cart) { c.Items = append(c.Items, "plum") }))
+
+	v, err := m.ForKey("user:7").Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pear", "plum"}, v.Items)
+
+	require.NoError(t, s.Delete(ctx))
+	assert.Equal(t, 0, store.Len())
+	v, err = s.Get(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, v)
+}
+
+func TestSession_TTL(t *testing.T) {
+	ctx := context.Background()
+	store := &session.MemoryStore{}
+	m := session.NewManager[cart](store, session.WithTTL(20*time.Millisecond))
+
+	require.NoError(t, m.ForKey("k").Set(ctx, cart{Step: "x"}))
+	time.Sleep(40 * time.Millisecond)
+
+	v, err := m.ForKey("k").Get(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, v)
+
+	require.NoError(t, m.ForKey("k").Set(ctx, cart{Step: "y"}))
+	time.Sleep(40 * time.Millisecond)
+	assert.Equal(t, 1, store.Sweep())
+}
+
+func TestSession_Decorator(t *testing.T) {
+	type profile struct{ Name string }
+	carts := session.NewManager[cart](&session.MemoryStore{})
+	profiles := session.NewManager[profile](&session.MemoryStore{}, session.WithKey(session.PerUser))
+
+	u := message(-100, 7)
+	ctx := carts.Decorator()(context.Background(), u)
+	ctx = profiles.Decorator()(ctx, u)
+
+	c, ok := session.From[cart](ctx)
+	require.True(t, ok)
+	assert.Equal(t, "session:chat:-100", c.Key())
+
+	p, ok := session.From[profile](ctx)
+	require.True(t, ok)
+	assert.Equal(t, "session:user:7", p.Key())
+
+	_, ok = session.From[int](ctx)
+	assert.False(t, ok)
+}
+
+type failingStore struct{ session.MemoryStore }
+
+func (*failingStore) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, errors.New("backend down")
+}
+
+func TestSession_StoreError(t *testing.T) {
+	m := session.NewManager[cart](&failingStore{})
+	_, err := m.ForKey("k").Get(context.Background())
+	assert.EqualError(t, err, "backend down")
+}
+
+// plainStore hides MemoryStore's CompareAndSwap.
+type plainStore struct{ session.Store }
+
+func TestSession_ConcurrentUpdates(t *testing.T) {
+	for name, store := range map[string]session.Store{
+		"cas":   &session.MemoryStore{},
+		"plain": plainStore{&session.MemoryStore{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			m := session.NewManager[cart](store)
+
+			var wg sync.WaitGroup
+			for range 20 {
+				wg.Go(func() {
+					// One Session per update, as the Decorator creates them.
+					err := m.ForKey("k").Update(ctx, func(c *cart) { c.Items = append(c.Items, "x") })
+					assert.NoError(t, err)
+				})
+			}
+			wg.Wait()
+
+			v, err := m.ForKey("k").Get(ctx)
+			require.NoError(t, err)
+			assert.Len(t, v.Items, 20, "no update lost")
+		})
+	}
+}
+
+// conflictingStore writes a new value before every CompareAndSwap.
+type conflictingStore struct {
+	session.MemoryStore
+	writes int
+}
+
+func (s *conflictingStore) CompareAndSwap(ctx context.Context, key string, old, value []byte, ttl time.Duration) (bool, error) {
+	s.writes++
+	_ = s.Set(ctx, key, []byte(`{"step":"other`+strconv.Itoa(s.writes)+`"}`), ttl)
+	return s.MemoryStore.CompareAndSwap(ctx, key, old, value, ttl)
+}
+
+func TestSession_UpdateConflict(t *testing.T) {
+	m := session.NewManager[cart](&conflictingStore{})
+	err := m.ForKey("k").Update(context.Background(), func(c *cart) { c.Step = "mine" })
+	assert.ErrorIs(t, err, session.ErrConflict)
+}
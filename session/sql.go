@@ -0,0 +1,171 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SQLDialect selects the SQL syntax of a SQLStore.
+type SQLDialect int
+
+const (
+	// Postgres uses $1-style placeholders and BYTEA values.
+	Postgres SQLDialect = iota
+	// SQLite uses ? placeholders and BLOB values.
+	SQLite
+)
+
+// DefaultSQLTable is the table a SQLStore uses unless told otherwise.
+const DefaultSQLTable = "galigo_sessions"
+
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SQLStore is a CASStore in a SQL table, shared by every process using
+// the same database. Expired rows are ignored when read and removed by
+// Sweep. The table holds session_key, data and expires_at (Unix
+// milliseconds, NULL = never); CreateTable creates it.
+type SQLStore struct {
+	db      *sql.DB
+	dialect SQLDialect
+	table   string
+}
+
+var _ CASStore = (*SQLStore)(nil)
+
+// NewSQLStore returns a SQLStore on db's table ("" = DefaultSQLTable). It
+// fails if table is not a plain identifier.
+func NewSQLStore(db *sql.DB, dialect SQLDialect, table string) (*SQLStore, error) {
+	if table == "" {
+		table = DefaultSQLTable
+	}
+	if !sqlIdentifier.MatchString(table) {
+		return nil, fmt.Errorf("session: invalid table name %q", table)
+	}
+	if dialect != Postgres && dialect != SQLite {
+		return nil, fmt.Errorf("session: unknown SQL dialect %d", dialect)
+	}
+	return &SQLStore{db: db, dialect: dialect, table: table}, nil
+}
+
+// query expands {t} to the table name and each ? to the dialect's
+// placeholder.
+func (s *SQLStore) query(q string) string {
+	q = strings.ReplaceAll(q, "{t}", s.table)
+	if s.dialect != Postgres {
+		return q
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range q {
+		if r == '?' {
+			n++
+			fmt.Fprintf(&b, "$%d", n)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// CreateTable creates the session table if it does not exist.
+func (s *SQLStore) CreateTable(ctx context.Context) error {
+	blob := "BYTEA"
+	if s.dialect == SQLite {
+		blob = "BLOB"
+	}
+	_, err := s.db.ExecContext(ctx, s.query(
+		`CREATE TABLE IF NOT EXISTS {t} (session_key TEXT PRIMARY KEY, data `+blob+` NOT NULL, expires_at BIGINT)`))
+	if err != nil {
+		return fmt.Errorf("session: create table: %w", err)
+	}
+	return nil
+}
+
+// Get returns the value under key unless it has expired.
+func (s *SQLStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	var data []byte
+	err := s.db.QueryRowContext(ctx, s.query(
+		`SELECT data FROM {t} WHERE session_key = ? AND (expires_at IS NULL OR expires_at > ?)`),
+		key, nowMillis()).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("session: sql get: %w", err)
+	}
+	return data, true, nil
+}
+
+// Set stores value under key for ttl (0 = no expiry).
+func (s *SQLStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := s.db.ExecContext(ctx, s.query(
+		`INSERT INTO {t} (session_key, data, expires_at) VALUES (?, ?, ?) `+
+			`ON CONFLICT (session_key) DO UPDATE SET data = excluded.data, expires_at = excluded.expires_at`),
+		key, value, expiresAt(ttl))
+	if err != nil {
+		return fmt.Errorf("session: sql set: %w", err)
+	}
+	return nil
+}
+
+// CompareAndSwap stores value under key if key holds old (nil = absent).
+func (s *SQLStore) CompareAndSwap(ctx context.Context, key string, old, value []byte, ttl time.Duration) (bool, error) {
+	now := nowMillis()
+	var res sql.Result
+	var err error
+	if old != nil {
+		res, err = s.db.ExecContext(ctx, s.query(
+			`UPDATE {t} SET data = ?, expires_at = ? `+
+				`WHERE session_key = ? AND data = ? AND (expires_at IS NULL OR expires_at > ?)`),
+			value, expiresAt(ttl), key, old, now)
+	} else {
+		// Insert, or take over a row that has expired but not been swept.
+		res, err = s.db.ExecContext(ctx, s.query(
+			`INSERT INTO {t} (session_key, data, expires_at) VALUES (?, ?, ?) `+
+				`ON CONFLICT (session_key) DO UPDATE SET data = excluded.data, expires_at = excluded.expires_at `+
+				`WHERE {t}.expires_at IS NOT NULL AND {t}.expires_at <= ?`),
+			key, value, expiresAt(ttl), now)
+	}
+	if err != nil {
+		return false, fmt.Errorf("session: sql compare-and-swap: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("session: sql compare-and-swap: %w", err)
+	}
+	return n == 1, nil
+}
+
+// Delete removes key.
+func (s *SQLStore) Delete(ctx context.Context, key string) error {
+	if _, err := s.db.ExecContext(ctx, s.query(`DELETE FROM {t} WHERE session_key = ?`), key); err != nil {
+		return fmt.Errorf("session: sql delete: %w", err)
+	}
+	return nil
+}
+
+// Sweep deletes expired sessions and returns how many were removed. Call
+// it periodically, e.g. from a cron job.
+func (s *SQLStore) Sweep(ctx context.Context) (int64, error) {
+	res, err := s.db.ExecContext(ctx, s.query(
+		`DELETE FROM {t} WHERE expires_at IS NOT NULL AND expires_at <= ?`), nowMillis())
+	if err != nil {
+		return 0, fmt.Errorf("session: sql sweep: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+func nowMillis() int64 { return time.Now().UnixMilli() }
+
+// expiresAt returns the expires_at of a row written now with ttl.
+func expiresAt(ttl time.Duration) any {
+	if ttl <= 0 {
+		return nil
+	}
+	return time.Now().Add(ttl).UnixMilli()
+}
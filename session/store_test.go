@@ -0,0 +1,242 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCASStore checks the Store and CASStore contract on store.
+func testCASStore(t *testing.T, store CASStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	_, ok, err := store.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	swapped, err := store.CompareAndSwap(ctx, "k", nil, []byte("v1"), 0)
+	require.NoError(t, err)
+	assert.True(t, swapped, "absent key created")
+	swapped, err = store.CompareAndSwap(ctx, "k", nil, []byte("other"), 0)
+	require.NoError(t, err)
+	assert.False(t, swapped, "key no longer absent")
+
+	swapped, err = store.CompareAndSwap(ctx, "k", []byte("stale"), []byte("other"), 0)
+	require.NoError(t, err)
+	assert.False(t, swapped)
+	swapped, err = store.CompareAndSwap(ctx, "k", []byte("v1"), []byte("v2"), 0)
+	require.NoError(t, err)
+	assert.True(t, swapped)
+
+	v, ok, err := store.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("v2"), v)
+
+	require.NoError(t, store.Set(ctx, "k", []byte("v3"), 0))
+	v, _, _ = store.Get(ctx, "k")
+	assert.Equal(t, []byte("v3"), v)
+
+	require.NoError(t, store.Delete(ctx, "k"))
+	_, ok, _ = store.Get(ctx, "k")
+	assert.False(t, ok)
+	require.NoError(t, store.Delete(ctx, "k"), "deleting a missing key")
+
+	require.NoError(t, store.Set(ctx, "short", []byte("x"), 20*time.Millisecond))
+	time.Sleep(40 * time.Millisecond)
+	_, ok, _ = store.Get(ctx, "short")
+	assert.False(t, ok, "expired")
+	swapped, err = store.CompareAndSwap(ctx, "short", nil, []byte("y"), 0)
+	require.NoError(t, err)
+	assert.True(t, swapped, "an expired key counts as absent")
+}
+
+func TestMemoryStore_CAS(t *testing.T) {
+	testCASStore(t, &MemoryStore{})
+}
+
+// fakeRedis emulates the session scripts.
+type fakeRedis struct {
+	mu      sync.Mutex
+	values  map[string]string
+	expires map[string]time.Time
+}
+
+func (r *fakeRedis) eval(_ context.Context, script string, keys []string, args ...any) (any, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.values == nil {
+		r.values, r.expires = map[string]string{}, map[string]time.Time{}
+	}
+	key := keys[0]
+	if exp, ok := r.expires[key]; ok && time.Now().After(exp) {
+		delete(r.values, key)
+		delete(r.expires, key)
+	}
+	set := func(value, ttl string) {
+		r.values[key] = value
+		delete(r.expires, key)
+		if d, _ := time.ParseDuration(ttl + "ms"); d > 0 {
+			r.expires[key] = time.Now().Add(d)
+		}
+	}
+	cur, present := r.values[key]
+	switch script {
+	case redisGet:
+		if !present {
+			return []any{}, nil
+		}
+		return []any{cur}, nil
+	case redisSet:
+		set(args[0].(string), args[1].(string))
+		return int64(1), nil
+	case redisCompareAndSwap:
+		if (args[0] == "1") != present || (present && cur != args[1]) {
+			return int64(0), nil
+		}
+		set(args[2].(string), args[3].(string))
+		return int64(1), nil
+	case redisDelete:
+		delete(r.values, key)
+		return int64(1), nil
+	}
+	return nil, errors.New("unexpected script")
+}
+
+func TestRedisStore(t *testing.T) {
+	testCASStore(t, NewRedisStore((&fakeRedis{}).eval))
+
+	failing := NewRedisStore(func(context.Context, string, []string, ...any) (any, error) {
+		return nil, errors.New("connection refused")
+	})
+	_, _, err := failing.Get(context.Background(), "k")
+	assert.EqualError(t, err, "session: redis get: connection refused")
+}
+
+func TestSQLStore(t *testing.T) {
+	db := sql.OpenDB(&fakeSQL{rows: map[string]fakeRow{}})
+	t.Cleanup(func() { db.Close() })
+
+	store, err := NewSQLStore(db, SQLite, "")
+	require.NoError(t, err)
+	require.NoError(t, store.CreateTable(context.Background()))
+	testCASStore(t, store)
+
+	require.NoError(t, store.Set(context.Background(), "gone", []byte("x"), time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	n, err := store.Sweep(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	_, err = NewSQLStore(db, Postgres, "sessions; DROP TABLE users")
+	assert.Error(t, err)
+}
+
+func TestSQLStore_PostgresPlaceholders(t *testing.T) {
+	store, err := NewSQLStore(nil, Postgres, "s")
+	require.NoError(t, err)
+	assert.Equal(t, "UPDATE s SET data = $1 WHERE session_key = $2", store.query("UPDATE {t} SET data = ? WHERE session_key = ?"))
+}
+
+// fakeSQL is a database/sql driver emulating the SQLStore statements on a
+// map. It tells them apart by their leading keywords.
+type fakeSQL struct {
+	mu   sync.Mutex
+	rows map[string]fakeRow
+}
+
+type fakeRow struct {
+	data    []byte
+	expires any // int64 Unix millis or nil
+}
+
+func (r fakeRow) live(now int64) bool {
+	exp, ok := r.expires.(int64)
+	return !ok || exp > now
+}
+
+func (f *fakeSQL) Connect(context.Context) (driver.Conn, error) { return fakeConn{f}, nil }
+func (f *fakeSQL) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ db *fakeSQL }
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	f := c.db
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !strings.HasPrefix(query, "SELECT data") {
+		return nil, errors.New("unexpected query " + query)
+	}
+	row, ok := f.rows[args[0].Value.(string)]
+	if !ok || !row.live(args[1].Value.(int64)) {
+		return &fakeRows{}, nil
+	}
+	return &fakeRows{data: [][]byte{row.data}}, nil
+}
+
+func (c fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	f := c.db
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	arg := func(i int) any { return args[i].Value }
+	var n int64
+	switch {
+	case strings.HasPrefix(query, "CREATE TABLE"):
+	case strings.HasPrefix(query, "INSERT") && strings.Contains(query, " WHERE "):
+		key := arg(0).(string)
+		if row, ok := f.rows[key]; !ok || !row.live(arg(3).(int64)) {
+			f.rows[key] = fakeRow{arg(1).([]byte), arg(2)}
+			n = 1
+		}
+	case strings.HasPrefix(query, "INSERT"):
+		f.rows[arg(0).(string)] = fakeRow{arg(1).([]byte), arg(2)}
+		n = 1
+	case strings.HasPrefix(query, "UPDATE"):
+		key := arg(2).(string)
+		if row, ok := f.rows[key]; ok && row.live(arg(4).(int64)) && string(row.data) == string(arg(3).([]byte)) {
+			f.rows[key] = fakeRow{arg(0).([]byte), arg(1)}
+			n = 1
+		}
+	case strings.HasPrefix(query, "DELETE") && strings.Contains(query, "expires_at"):
+		for key, row := range f.rows {
+			if !row.live(arg(0).(int64)) {
+				delete(f.rows, key)
+				n++
+			}
+		}
+	case strings.HasPrefix(query, "DELETE"):
+		if _, ok := f.rows[arg(0).(string)]; ok {
+			delete(f.rows, arg(0).(string))
+			n = 1
+		}
+	default:
+		return nil, errors.New("unexpected statement " + query)
+	}
+	return driver.RowsAffected(n), nil
+}
+
+type fakeRows struct{ data [][]byte }
+
+func (*fakeRows) Columns() []string { return []string{"data"} }
+func (*fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.data) == 0 {
+		return io.EOF
+	}
+	dest[0], r.data = r.data[0], r.data[1:]
+	return nil
+}
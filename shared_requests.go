@@ -0,0 +1,82 @@
+package galigo
+
+import (
+	"context"
+	"sync"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+// ================== Shared Users and Chats ==================
+//
+// Reply keyboard buttons built with tg.RequestUsersButton and
+// tg.RequestChatButton make Telegram send a users_shared or chat_shared
+// service message carrying the button's request ID. SharedRequests routes
+// those messages back to the flow that showed the button.
+
+// UsersSharedHandler handles a users_shared service message.
+type UsersSharedHandler func(ctx context.Context, msg *tg.Message, shared *tg.UsersShared) error
+
+// ChatSharedHandler handles a chat_shared service message.
+type ChatSharedHandler func(ctx context.Context, msg *tg.Message, shared *tg.ChatShared) error
+
+// SharedRequests maps request IDs to handlers. It is safe for concurrent
+// use.
+type SharedRequests struct {
+	mu    sync.RWMutex
+	users map[int]UsersSharedHandler
+	chats map[int]ChatSharedHandler
+}
+
+// NewSharedRequests creates an empty SharedRequests.
+func NewSharedRequests() *SharedRequests {
+	return &SharedRequests{
+		users: make(map[int]UsersSharedHandler),
+		chats: make(map[int]ChatSharedHandler),
+	}
+}
+
+// OnUsers registers h for users shared through the button with requestID,
+// replacing any previous handler for it.
+func (r *SharedRequests) OnUsers(requestID int, h UsersSharedHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[requestID] = h
+}
+
+// OnChat registers h for chats shared through the button with requestID,
+// replacing any previous handler for it.
+func (r *SharedRequests) OnChat(requestID int, h ChatSharedHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.chats[requestID] = h
+}
+
+// Observe calls the handler registered for the request ID of a
+// users_shared or chat_shared message in update. It reports whether a
+// handler ran and returns its error; other updates and unknown request
+// IDs are left to the caller.
+func (r *SharedRequests) Observe(ctx context.Context, update tg.Update) (bool, error) {
+	msg := update.Message
+	if msg == nil {
+		return false, nil
+	}
+	r.mu.RLock()
+	var users UsersSharedHandler
+	var chat ChatSharedHandler
+	switch {
+	case msg.UsersShared != nil:
+		users = r.users[msg.UsersShared.RequestID]
+	case msg.ChatShared != nil:
+		chat = r.chats[msg.ChatShared.RequestID]
+	}
+	r.mu.RUnlock()
+
+	switch {
+	case users != nil:
+		return true, users(ctx, msg, msg.UsersShared)
+	case chat != nil:
+		return true, chat(ctx, msg, msg.ChatShared)
+	}
+	return false, nil
+}
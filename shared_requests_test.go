@@ -0,0 +1,50 @@
+package galigo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestSharedRequests_Observe(t *testing.T) {
+	r := NewSharedRequests()
+	var gotUsers []int64
+	r.OnUsers(1, func(ctx context.Context, msg *tg.Message, shared *tg.UsersShared) error {
+		for _, u := range shared.Users {
+			gotUsers = append(gotUsers, u.UserID)
+		}
+		return nil
+	})
+	errChat := errors.New("boom")
+	r.OnChat(2, func(ctx context.Context, msg *tg.Message, shared *tg.ChatShared) error {
+		assert.Equal(t, int64(-100), shared.ChatID)
+		return errChat
+	})
+
+	var users, chat, unknown tg.Update
+	require.NoError(t, json.Unmarshal([]byte(`{"update_id":1,"message":{"message_id":1,"date":1,"chat":{"id":5,"type":"private"},"users_shared":{"request_id":1,"users":[{"user_id":7},{"user_id":8}]}}}`), &users))
+	require.NoError(t, json.Unmarshal([]byte(`{"update_id":2,"message":{"message_id":2,"date":1,"chat":{"id":5,"type":"private"},"chat_shared":{"request_id":2,"chat_id":-100}}}`), &chat))
+	require.NoError(t, json.Unmarshal([]byte(`{"update_id":3,"message":{"message_id":3,"date":1,"chat":{"id":5,"type":"private"},"chat_shared":{"request_id":9,"chat_id":-100}}}`), &unknown))
+
+	handled, err := r.Observe(context.Background(), users)
+	assert.True(t, handled)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{7, 8}, gotUsers)
+
+	handled, err = r.Observe(context.Background(), chat)
+	assert.True(t, handled)
+	assert.ErrorIs(t, err, errChat)
+
+	handled, err = r.Observe(context.Background(), unknown)
+	assert.False(t, handled)
+	assert.NoError(t, err)
+
+	handled, _ = r.Observe(context.Background(), tg.Update{UpdateID: 4})
+	assert.False(t, handled)
+}
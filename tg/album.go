@@ -0,0 +1,75 @@
+package tg
+
+// Album is a media group: photos, videos, documents or audio files sent
+// together, which Telegram delivers as separate messages sharing a
+// MediaGroupID. receiver.CollectAlbums merges them back into one update.
+type Album struct {
+	MediaGroupID string
+	// Messages are the album's parts in message_id order.
+	Messages []*Message
+}
+
+// Chat returns the chat the album was sent to.
+func (a *Album) Chat() *Chat {
+	if a == nil || len(a.Messages) == 0 {
+		return nil
+	}
+	return a.Messages[0].Chat
+}
+
+// Caption returns the album's caption. Clients attach it to one part,
+// usually the first.
+func (a *Album) Caption() string {
+	if m := a.captioned(); m != nil {
+		return m.Caption
+	}
+	return ""
+}
+
+// CaptionEntities returns the entities of Caption.
+func (a *Album) CaptionEntities() []MessageEntity {
+	if m := a.captioned(); m != nil {
+		return m.CaptionEntities
+	}
+	return nil
+}
+
+func (a *Album) captioned() *Message {
+	if a == nil {
+		return nil
+	}
+	for _, m := range a.Messages {
+		if m.Caption != "" {
+			return m
+		}
+	}
+	return nil
+}
+
+// Photos returns the largest size of every photo in the album.
+func (a *Album) Photos() []PhotoSize {
+	if a == nil {
+		return nil
+	}
+	var photos []PhotoSize
+	for _, m := range a.Messages {
+		if n := len(m.Photo); n > 0 {
+			photos = append(photos, m.Photo[n-1])
+		}
+	}
+	return photos
+}
+
+// Videos returns every video in the album.
+func (a *Album) Videos() []*Video {
+	if a == nil {
+		return nil
+	}
+	var videos []*Video
+	for _, m := range a.Messages {
+		if m.Video != nil {
+			videos = append(videos, m.Video)
+		}
+	}
+	return videos
+}
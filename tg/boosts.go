@@ -82,6 +82,16 @@ type ChatBoostSourceUnknown struct {
 func (ChatBoostSourceUnknown) chatBoostSourceTag() {}
 func (s ChatBoostSourceUnknown) GetSource() string { return s.Source }
 
+// MarshalJSON emits the original payload so unknown sources survive a
+// round-trip.
+func (s ChatBoostSourceUnknown) MarshalJSON() ([]byte, error) {
+	if len(s.Raw) > 0 {
+		return s.Raw, nil
+	}
+	type alias ChatBoostSourceUnknown
+	return json.Marshal(alias(s))
+}
+
 // unmarshalChatBoostSource decodes a ChatBoostSource from JSON.
 // Returns ChatBoostSourceUnknown on any error.
 func unmarshalChatBoostSource(data json.RawMessage) ChatBoostSource {
@@ -115,3 +125,37 @@ func unmarshalChatBoostSource(data json.RawMessage) ChatBoostSource {
 		return ChatBoostSourceUnknown{Source: probe.Source, Raw: data}
 	}
 }
+
+// ChatBoostUpdated represents a boost added to a chat or changed.
+type ChatBoostUpdated struct {
+	Chat  Chat      `json:"chat"`
+	Boost ChatBoost `json:"boost"`
+}
+
+// ChatBoostRemoved represents a boost removed from a chat.
+type ChatBoostRemoved struct {
+	Chat       Chat            `json:"chat"`
+	BoostID    string          `json:"boost_id"`
+	RemoveDate int64           `json:"remove_date"`
+	Source     ChatBoostSource `json:"source"`
+}
+
+// UnmarshalJSON handles the polymorphic Source field.
+func (r *ChatBoostRemoved) UnmarshalJSON(data []byte) error {
+	type Alias ChatBoostRemoved
+	aux := &struct {
+		Source json.RawMessage `json:"source"`
+		*Alias
+	}{Alias: (*Alias)(r)}
+
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+
+	if len(aux.Source) == 0 || string(aux.Source) == "null" {
+		r.Source = ChatBoostSourceUnknown{Raw: aux.Source}
+	} else {
+		r.Source = unmarshalChatBoostSource(aux.Source)
+	}
+	return nil
+}
@@ -27,9 +27,18 @@ type BusinessBotRights struct {
 	CanManageStories           bool `json:"can_manage_stories"`
 }
 
-// Story represents a story posted to a chat.
+// Story represents a story posted to a chat. A message forwarding a story
+// carries it in Message.Story, a reply to one in Message.ReplyToStory.
 type Story struct {
-	ID   int   `json:"id"`
 	Chat Chat  `json:"chat"`
-	Date int64 `json:"date"`
+	ID   int   `json:"id"`
+	Date int64 `json:"date,omitempty"` // Deprecated: never sent by Telegram.
+}
+
+// BusinessMessagesDeleted is received when messages are deleted from a
+// connected business account.
+type BusinessMessagesDeleted struct {
+	BusinessConnectionID string `json:"business_connection_id"`
+	Chat                 Chat   `json:"chat"`
+	MessageIDs           []int  `json:"message_ids"`
 }
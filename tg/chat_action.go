@@ -0,0 +1,37 @@
+package tg
+
+// ChatAction is the action shown by sendChatAction, e.g. "typing…".
+type ChatAction string
+
+// Supported chat actions, named after the message the bot is preparing.
+const (
+	ChatActionTyping          ChatAction = "typing"
+	ChatActionUploadPhoto     ChatAction = "upload_photo"
+	ChatActionRecordVideo     ChatAction = "record_video"
+	ChatActionUploadVideo     ChatAction = "upload_video"
+	ChatActionRecordVoice     ChatAction = "record_voice"
+	ChatActionUploadVoice     ChatAction = "upload_voice"
+	ChatActionUploadDocument  ChatAction = "upload_document"
+	ChatActionChooseSticker   ChatAction = "choose_sticker"
+	ChatActionFindLocation    ChatAction = "find_location"
+	ChatActionRecordVideoNote ChatAction = "record_video_note"
+	ChatActionUploadVideoNote ChatAction = "upload_video_note"
+)
+
+// String returns the chat action string value.
+func (a ChatAction) String() string {
+	return string(a)
+}
+
+// IsValid returns true if the chat action is supported by Telegram.
+// Telegram accepts an unknown action without showing anything.
+func (a ChatAction) IsValid() bool {
+	switch a {
+	case ChatActionTyping, ChatActionUploadPhoto, ChatActionRecordVideo, ChatActionUploadVideo,
+		ChatActionRecordVoice, ChatActionUploadVoice, ChatActionUploadDocument, ChatActionChooseSticker,
+		ChatActionFindLocation, ChatActionRecordVideoNote, ChatActionUploadVideoNote:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,32 @@
+package tg_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestChatAction_IsValid(t *testing.T) {
+	tests := []struct {
+		action tg.ChatAction
+		valid  bool
+	}{
+		{tg.ChatActionTyping, true},
+		{tg.ChatActionUploadPhoto, true},
+		{tg.ChatActionRecordVideo, true},
+		{tg.ChatActionUploadDocument, true},
+		{tg.ChatActionChooseSticker, true},
+		{tg.ChatActionUploadVideoNote, true},
+		{tg.ChatAction(""), false},
+		{tg.ChatAction("upload_audio"), false}, // removed from the Bot API
+		{tg.ChatAction("Typing"), false},       // case-sensitive
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.action), func(t *testing.T) {
+			assert.Equal(t, tt.valid, tt.action.IsValid())
+		})
+	}
+}
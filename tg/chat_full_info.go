@@ -1,7 +1,8 @@
 package tg
 
-// ChatFullInfo contains full information about a chat.
-// Returned by the getChat method.
+// ChatFullInfo contains full information about a chat, as of Bot API 9.4.
+// Returned by the getChat method; messages and updates carry the shorter
+// Chat.
 type ChatFullInfo struct {
 	// Basic info (always present)
 	ID        int64  `json:"id"`
@@ -13,6 +14,7 @@ type ChatFullInfo struct {
 
 	// Optional fields
 	IsForum                            bool              `json:"is_forum,omitempty"`
+	IsDirectMessages                   bool              `json:"is_direct_messages,omitempty"` // 9.2
 	AccentColorID                      int               `json:"accent_color_id,omitempty"`
 	MaxReactionCount                   int               `json:"max_reaction_count,omitempty"`
 	Photo                              *ChatPhoto        `json:"photo,omitempty"`
@@ -23,6 +25,7 @@ type ChatFullInfo struct {
 	BusinessLocation                   *BusinessLocation `json:"business_location,omitempty"`
 	BusinessOpeningHours               *BusinessHours    `json:"business_opening_hours,omitempty"`
 	PersonalChat                       *Chat             `json:"personal_chat,omitempty"`
+	ParentChat                         *Chat             `json:"parent_chat,omitempty"` // 9.2, for direct messages chats
 	AvailableReactions                 []ReactionType    `json:"available_reactions,omitempty"`
 	BackgroundCustomEmojiID            string            `json:"background_custom_emoji_id,omitempty"`
 	ProfileAccentColorID               *int              `json:"profile_accent_color_id,omitempty"`
@@ -38,6 +41,7 @@ type ChatFullInfo struct {
 	InviteLink                         string            `json:"invite_link,omitempty"`
 	PinnedMessage                      *Message          `json:"pinned_message,omitempty"`
 	Permissions                        *ChatPermissions  `json:"permissions,omitempty"`
+	AcceptedGiftTypes                  AcceptedGiftTypes `json:"accepted_gift_types"` // 9.0
 	CanSendPaidMedia                   bool              `json:"can_send_paid_media,omitempty"`
 	SlowModeDelay                      int               `json:"slow_mode_delay,omitempty"`
 	UnrestrictBoostCount               int               `json:"unrestrict_boost_count,omitempty"`
@@ -51,6 +55,7 @@ type ChatFullInfo struct {
 	CustomEmojiStickerSetName          string            `json:"custom_emoji_sticker_set_name,omitempty"`
 	LinkedChatID                       int64             `json:"linked_chat_id,omitempty"`
 	Location                           *ChatLocation     `json:"location,omitempty"`
+	Rating                             *UserRating       `json:"rating,omitempty"`                  // 9.3
 	UniqueGiftColors                   *UniqueGiftColors `json:"unique_gift_colors,omitempty"`      // 9.3
 	PaidMessageStarCount               int               `json:"paid_message_star_count,omitempty"` // 9.3
 }
@@ -61,6 +66,15 @@ type ChatLocation struct {
 	Address  string    `json:"address"`
 }
 
+// UserRating describes the rating of a user based on their Telegram Star
+// spendings. Added in Bot API 9.3.
+type UserRating struct {
+	Level              int `json:"level"`
+	Rating             int `json:"rating"`
+	CurrentLevelRating int `json:"current_level_rating"`
+	NextLevelRating    int `json:"next_level_rating,omitempty"` // 0 at the maximum level
+}
+
 // Birthdate represents a user's birthdate.
 type Birthdate struct {
 	Day   int  `json:"day"`
@@ -92,10 +106,3 @@ type BusinessOpeningHoursInterval struct {
 	OpeningMinute int `json:"opening_minute"`
 	ClosingMinute int `json:"closing_minute"`
 }
-
-// ReactionType represents a reaction type.
-type ReactionType struct {
-	Type        string `json:"type"` // "emoji" or "custom_emoji"
-	Emoji       string `json:"emoji,omitempty"`
-	CustomEmoji string `json:"custom_emoji_id,omitempty"`
-}
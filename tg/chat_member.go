@@ -168,6 +168,30 @@ func UnmarshalChatMember(data []byte) (ChatMember, error) {
 	return result, nil
 }
 
+// MarshalChatMember serializes a ChatMember, including the "status"
+// discriminator that the concrete types do not carry themselves.
+func MarshalChatMember(m ChatMember) ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+	body, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	status, err := json.Marshal(m.Status())
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(body)+len(status)+11)
+	out = append(out, `{"status":`...)
+	out = append(out, status...)
+	if len(body) > 2 {
+		out = append(out, ',')
+	}
+	return append(out, body[1:]...), nil
+}
+
 // IsOwner returns true if the member is the chat owner.
 func IsOwner(m ChatMember) bool {
 	_, ok := m.(ChatMemberOwner)
@@ -207,3 +231,122 @@ func HasLeft(m ChatMember) bool {
 	_, ok := m.(ChatMemberLeft)
 	return ok
 }
+
+// IsInChat returns true if the member is currently part of the chat:
+// owner, administrator, member, or restricted with is_member set.
+func IsInChat(m ChatMember) bool {
+	switch v := m.(type) {
+	case ChatMemberOwner, ChatMemberAdministrator, ChatMemberMember:
+		return true
+	case ChatMemberRestricted:
+		return v.IsMember
+	default:
+		return false
+	}
+}
+
+// ================== ChatMemberUpdated transitions ==================
+
+// StatusChange returns the old and new member statuses ("" if absent).
+func (u *ChatMemberUpdated) StatusChange() (old, new string) {
+	if u.OldChatMember != nil {
+		old = u.OldChatMember.Status()
+	}
+	if u.NewChatMember != nil {
+		new = u.NewChatMember.Status()
+	}
+	return old, new
+}
+
+// JoinedChat reports whether the user became part of the chat
+// (e.g. left→member, kicked→member, restricted non-member→restricted member).
+func (u *ChatMemberUpdated) JoinedChat() bool {
+	return !IsInChat(u.OldChatMember) && IsInChat(u.NewChatMember)
+}
+
+// LeftChat reports whether the user stopped being part of the chat,
+// either voluntarily or by being banned.
+func (u *ChatMemberUpdated) LeftChat() bool {
+	return IsInChat(u.OldChatMember) && !IsInChat(u.NewChatMember)
+}
+
+// WasPromoted reports whether the user became an administrator.
+func (u *ChatMemberUpdated) WasPromoted() bool {
+	return !IsAdmin(u.OldChatMember) && IsAdmin(u.NewChatMember)
+}
+
+// WasDemoted reports whether the user lost administrator status.
+func (u *ChatMemberUpdated) WasDemoted() bool {
+	return IsAdmin(u.OldChatMember) && !IsAdmin(u.NewChatMember)
+}
+
+// WasRestricted reports whether restrictions were applied to the user.
+func (u *ChatMemberUpdated) WasRestricted() bool {
+	return !IsRestricted(u.OldChatMember) && IsRestricted(u.NewChatMember)
+}
+
+// WasUnrestricted reports whether the user's restrictions were lifted.
+// A restricted user that is banned counts as WasBanned, not WasUnrestricted.
+func (u *ChatMemberUpdated) WasUnrestricted() bool {
+	return IsRestricted(u.OldChatMember) && !IsRestricted(u.NewChatMember) && !IsBanned(u.NewChatMember)
+}
+
+// WasBanned reports whether the user was banned from the chat.
+func (u *ChatMemberUpdated) WasBanned() bool {
+	return !IsBanned(u.OldChatMember) && IsBanned(u.NewChatMember)
+}
+
+// WasUnbanned reports whether the user's ban was lifted.
+func (u *ChatMemberUpdated) WasUnbanned() bool {
+	return IsBanned(u.OldChatMember) && !IsBanned(u.NewChatMember)
+}
+
+// ================== Membership updates ==================
+
+// MemberUpdate returns the update's my_chat_member or chat_member payload,
+// or nil.
+func (u *Update) MemberUpdate() *ChatMemberUpdated {
+	if u.MyChatMember != nil {
+		return u.MyChatMember
+	}
+	return u.ChatMember
+}
+
+// OldNewStatus returns the old and new statuses of a my_chat_member or
+// chat_member update ("" if the update is neither).
+func (u *Update) OldNewStatus() (old, new string) {
+	if m := u.MemberUpdate(); m != nil {
+		return m.StatusChange()
+	}
+	return "", ""
+}
+
+// BotWasAdded reports whether the bot was added to a group or channel, or
+// unblocked in a private chat.
+func (u *Update) BotWasAdded() bool {
+	return u.MyChatMember != nil && u.MyChatMember.JoinedChat()
+}
+
+// BotWasRemoved reports whether the bot was removed or banned from a group
+// or channel, or blocked in a private chat.
+func (u *Update) BotWasRemoved() bool {
+	return u.MyChatMember != nil && u.MyChatMember.LeftChat()
+}
+
+// BotWasBlocked reports whether a user blocked the bot in a private chat.
+func (u *Update) BotWasBlocked() bool {
+	return u.BotWasRemoved() && u.MyChatMember.Chat != nil && u.MyChatMember.Chat.Type == "private"
+}
+
+// UserJoined reports whether a chat_member update shows a user joining.
+// The bot must be an administrator and request "chat_member" in
+// allowed_updates.
+func (u *Update) UserJoined() bool {
+	return u.ChatMember != nil && u.ChatMember.JoinedChat()
+}
+
+// UserLeft reports whether a chat_member update shows a user leaving or
+// being banned.
+func (u *Update) UserLeft() bool {
+	return u.ChatMember != nil && u.ChatMember.LeftChat()
+}
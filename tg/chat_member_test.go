@@ -214,3 +214,107 @@ func TestChatMemberUpdated_UnmarshalJSON(t *testing.T) {
 	assert.True(t, IsMember(updated.OldChatMember))
 	assert.True(t, IsBanned(updated.NewChatMember))
 }
+
+func TestChatMemberUpdated_Transitions(t *testing.T) {
+	user := chatMemberBase{User: &User{ID: 999, FirstName: "Bob"}}
+	owner := ChatMemberOwner{chatMemberBase: user}
+	admin := ChatMemberAdministrator{chatMemberBase: user}
+	member := ChatMemberMember{chatMemberBase: user}
+	restrictedIn := ChatMemberRestricted{chatMemberBase: user, IsMember: true}
+	restrictedOut := ChatMemberRestricted{chatMemberBase: user, IsMember: false}
+	left := ChatMemberLeft{chatMemberBase: user}
+	banned := ChatMemberBanned{chatMemberBase: user}
+
+	type want struct {
+		joined, left, promoted, demoted, restricted, unrestricted, banned, unbanned bool
+	}
+	tests := []struct {
+		name     string
+		old, new ChatMember
+		want     want
+	}{
+		{"left→member", left, member, want{joined: true}},
+		{"member→left", member, left, want{left: true}},
+		{"member→administrator", member, admin, want{promoted: true}},
+		{"administrator→member", admin, member, want{demoted: true}},
+		{"member→owner", member, owner, want{promoted: true}},
+		{"member→restricted", member, restrictedIn, want{restricted: true}},
+		{"restricted→member", restrictedIn, member, want{unrestricted: true}},
+		{"restricted→banned", restrictedIn, banned, want{left: true, banned: true}},
+		{"member→banned", member, banned, want{left: true, banned: true}},
+		{"banned→left", banned, left, want{unbanned: true}},
+		{"banned→member", banned, member, want{joined: true, unbanned: true}},
+		{"left→restricted member", left, restrictedIn, want{joined: true, restricted: true}},
+		{"restricted non-member→restricted member", restrictedOut, restrictedIn, want{joined: true}},
+		{"administrator→banned", admin, banned, want{left: true, demoted: true, banned: true}},
+		{"missing old member", nil, member, want{joined: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &ChatMemberUpdated{OldChatMember: tt.old, NewChatMember: tt.new}
+			got := want{
+				joined:       u.JoinedChat(),
+				left:         u.LeftChat(),
+				promoted:     u.WasPromoted(),
+				demoted:      u.WasDemoted(),
+				restricted:   u.WasRestricted(),
+				unrestricted: u.WasUnrestricted(),
+				banned:       u.WasBanned(),
+				unbanned:     u.WasUnbanned(),
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChatMemberUpdated_StatusChange(t *testing.T) {
+	u := &ChatMemberUpdated{NewChatMember: ChatMemberMember{}}
+	old, new := u.StatusChange()
+	assert.Equal(t, "", old)
+	assert.Equal(t, "member", new)
+}
+
+func TestUpdate_MembershipHelpers(t *testing.T) {
+	memberUpdate := func(field, chatType, oldStatus, newStatus string) Update {
+		raw := `{"update_id": 1, "` + field + `": {
+			"chat": {"id": -100, "type": "` + chatType + `"},
+			"from": {"id": 1, "is_bot": false, "first_name": "A"},
+			"date": 1700000000,
+			"old_chat_member": {"status": "` + oldStatus + `", "user": {"id": 7, "is_bot": true, "first_name": "Bot"}},
+			"new_chat_member": {"status": "` + newStatus + `", "user": {"id": 7, "is_bot": true, "first_name": "Bot"}}
+		}}`
+		var u Update
+		require.NoError(t, json.Unmarshal([]byte(raw), &u))
+		return u
+	}
+
+	added := memberUpdate("my_chat_member", "supergroup", "left", "member")
+	assert.True(t, added.BotWasAdded())
+	assert.False(t, added.BotWasRemoved())
+	old, new := added.OldNewStatus()
+	assert.Equal(t, "left", old)
+	assert.Equal(t, "member", new)
+
+	kicked := memberUpdate("my_chat_member", "supergroup", "administrator", "kicked")
+	assert.True(t, kicked.BotWasRemoved())
+	assert.False(t, kicked.BotWasBlocked())
+
+	blocked := memberUpdate("my_chat_member", "private", "member", "kicked")
+	assert.True(t, blocked.BotWasBlocked())
+
+	joined := memberUpdate("chat_member", "supergroup", "left", "member")
+	assert.True(t, joined.UserJoined())
+	assert.False(t, joined.BotWasAdded())
+	assert.Same(t, joined.ChatMember, joined.MemberUpdate())
+
+	left := memberUpdate("chat_member", "supergroup", "member", "left")
+	assert.True(t, left.UserLeft())
+
+	var empty Update
+	assert.Nil(t, empty.MemberUpdate())
+	old, new = empty.OldNewStatus()
+	assert.Empty(t, old)
+	assert.Empty(t, new)
+	assert.False(t, empty.BotWasAdded())
+}
@@ -29,9 +29,93 @@ type Checklist struct {
 
 // ChecklistTask represents a task in a received checklist.
 type ChecklistTask struct {
-	ID            int             `json:"id"`
-	Text          string          `json:"text"`
-	TextEntities  []MessageEntity `json:"text_entities,omitempty"`
-	IsDone        bool            `json:"is_done"`
-	CompletedByID int64           `json:"completed_by_id,omitempty"`
+	ID              int             `json:"id"`
+	Text            string          `json:"text"`
+	TextEntities    []MessageEntity `json:"text_entities,omitempty"`
+	CompletedByUser *User           `json:"completed_by_user,omitempty"`
+	CompletedByChat *Chat           `json:"completed_by_chat,omitempty"`
+	CompletionDate  int64           `json:"completion_date,omitempty"`
+	IsDone          bool            `json:"is_done"`
+	CompletedByID   int64           `json:"completed_by_id,omitempty"`
+}
+
+// Done reports whether the task is marked as done.
+func (t ChecklistTask) Done() bool {
+	return t.IsDone || t.CompletionDate != 0
+}
+
+// Task returns the task with the given ID, or nil.
+func (c *Checklist) Task(id int) *ChecklistTask {
+	for i := range c.Tasks {
+		if c.Tasks[i].ID == id {
+			return &c.Tasks[i]
+		}
+	}
+	return nil
+}
+
+// DoneTasks returns the tasks marked as done.
+func (c *Checklist) DoneTasks() []ChecklistTask {
+	var out []ChecklistTask
+	for _, t := range c.Tasks {
+		if t.Done() {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// PendingTasks returns the tasks not yet done.
+func (c *Checklist) PendingTasks() []ChecklistTask {
+	var out []ChecklistTask
+	for _, t := range c.Tasks {
+		if !t.Done() {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Progress returns the number of done tasks and the total.
+func (c *Checklist) Progress() (done, total int) {
+	return len(c.DoneTasks()), len(c.Tasks)
+}
+
+// ChecklistTasksDone is a service message about checklist tasks marked as
+// done or not done.
+type ChecklistTasksDone struct {
+	ChecklistMessage       *Message `json:"checklist_message,omitempty"` // May lack the checklist if it was deleted
+	MarkedAsDoneTaskIDs    []int    `json:"marked_as_done_task_ids,omitempty"`
+	MarkedAsNotDoneTaskIDs []int    `json:"marked_as_not_done_task_ids,omitempty"`
+}
+
+// MarkedAsDone returns the tasks marked as done, resolved against the
+// checklist in ChecklistMessage. IDs missing from the checklist are skipped.
+func (d *ChecklistTasksDone) MarkedAsDone() []ChecklistTask {
+	return d.resolve(d.MarkedAsDoneTaskIDs)
+}
+
+// MarkedAsNotDone returns the tasks marked as not done, resolved against
+// the checklist in ChecklistMessage.
+func (d *ChecklistTasksDone) MarkedAsNotDone() []ChecklistTask {
+	return d.resolve(d.MarkedAsNotDoneTaskIDs)
+}
+
+func (d *ChecklistTasksDone) resolve(ids []int) []ChecklistTask {
+	if d.ChecklistMessage == nil || d.ChecklistMessage.Checklist == nil {
+		return nil
+	}
+	var out []ChecklistTask
+	for _, id := range ids {
+		if t := d.ChecklistMessage.Checklist.Task(id); t != nil {
+			out = append(out, *t)
+		}
+	}
+	return out
+}
+
+// ChecklistTasksAdded is a service message about tasks added to a checklist.
+type ChecklistTasksAdded struct {
+	ChecklistMessage *Message        `json:"checklist_message,omitempty"`
+	Tasks            []ChecklistTask `json:"tasks"`
 }
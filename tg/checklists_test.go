@@ -0,0 +1,82 @@
+package tg_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+const checklistMessageJSON = `{
+	"message_id": 10,
+	"date": 1700000000,
+	"chat": {"id": 42, "type": "private"},
+	"checklist": {
+		"title": "Release",
+		"tasks": [
+			{"id": 1, "text": "Tag"},
+			{"id": 2, "text": "Changelog", "completed_by_user": {"id": 7, "is_bot": false, "first_name": "A"}, "completion_date": 1700000100},
+			{"id": 3, "text": "Announce"}
+		],
+		"others_can_mark_tasks_as_done": true
+	}
+}`
+
+func TestMessage_Checklist(t *testing.T) {
+	var msg tg.Message
+	require.NoError(t, json.Unmarshal([]byte(checklistMessageJSON), &msg))
+	require.NotNil(t, msg.Checklist)
+
+	c := msg.Checklist
+	done, total := c.Progress()
+	assert.Equal(t, 1, done)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, "Changelog", c.DoneTasks()[0].Text)
+	assert.Equal(t, int64(7), c.DoneTasks()[0].CompletedByUser.ID)
+	assert.Len(t, c.PendingTasks(), 2)
+	assert.Equal(t, "Announce", c.Task(3).Text)
+	assert.Nil(t, c.Task(99))
+}
+
+func TestMessage_ChecklistTasksDone(t *testing.T) {
+	raw := `{
+		"message_id": 11,
+		"date": 1700000200,
+		"chat": {"id": 42, "type": "private"},
+		"checklist_tasks_done": {
+			"checklist_message": ` + checklistMessageJSON + `,
+			"marked_as_done_task_ids": [1, 99],
+			"marked_as_not_done_task_ids": [2]
+		}
+	}`
+	var msg tg.Message
+	require.NoError(t, json.Unmarshal([]byte(raw), &msg))
+	require.NotNil(t, msg.ChecklistTasksDone)
+
+	d := msg.ChecklistTasksDone
+	assert.Equal(t, []int{1, 99}, d.MarkedAsDoneTaskIDs)
+	require.Len(t, d.MarkedAsDone(), 1) // 99 is not in the checklist
+	assert.Equal(t, "Tag", d.MarkedAsDone()[0].Text)
+	assert.Equal(t, "Changelog", d.MarkedAsNotDone()[0].Text)
+
+	assert.Nil(t, (&tg.ChecklistTasksDone{MarkedAsDoneTaskIDs: []int{1}}).MarkedAsDone())
+}
+
+func TestMessage_ChecklistTasksAdded(t *testing.T) {
+	raw := `{
+		"message_id": 12,
+		"date": 1700000300,
+		"chat": {"id": 42, "type": "private"},
+		"checklist_tasks_added": {
+			"tasks": [{"id": 4, "text": "Deploy"}]
+		}
+	}`
+	var msg tg.Message
+	require.NoError(t, json.Unmarshal([]byte(raw), &msg))
+	require.NotNil(t, msg.ChecklistTasksAdded)
+	assert.Nil(t, msg.ChecklistTasksAdded.ChecklistMessage)
+	assert.Equal(t, "Deploy", msg.ChecklistTasksAdded.Tasks[0].Text)
+}
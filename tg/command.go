@@ -0,0 +1,141 @@
+package tg
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+)
+
+// Command is a bot command parsed from a message, e.g.
+// "/ban@MyBot 42 spam" has Name "ban", Mention "MyBot", Args ["42", "spam"]
+// and Payload "42 spam".
+type Command struct {
+	Name    string   // without the leading slash, lowercased
+	Mention string   // bot username after "@", or ""
+	Args    []string // Payload split on whitespace
+	Payload string   // everything after the command, trimmed
+}
+
+// ParseCommand parses the command at the start of msg's text (or caption,
+// for media). It reports false when the message does not start with a
+// command.
+func ParseCommand(msg *Message) (Command, bool) {
+	if msg == nil {
+		return Command{}, false
+	}
+	text := msg.Text
+	if text == "" {
+		text = msg.Caption
+	}
+	return ParseCommandText(text)
+}
+
+// ParseCommandText parses a command from text; see ParseCommand.
+func ParseCommandText(text string) (Command, bool) {
+	if !strings.HasPrefix(text, "/") {
+		return Command{}, false
+	}
+	head, rest, _ := strings.Cut(text[1:], " ")
+	if i := strings.IndexAny(head, "\n\t"); i >= 0 {
+		rest = head[i+1:] + " " + rest
+		head = head[:i]
+	}
+	name, mention, _ := strings.Cut(head, "@")
+	if name == "" {
+		return Command{}, false
+	}
+	payload := strings.TrimSpace(rest)
+	return Command{
+		Name:    strings.ToLower(name),
+		Mention: mention,
+		Args:    strings.Fields(payload),
+		Payload: payload,
+	}, true
+}
+
+// IsFor reports whether the command is addressed to the bot with the
+// given username: it has no mention, or mentions that bot. In groups with
+// several bots, commands mentioning another bot should be ignored.
+func (c Command) IsFor(botUsername string) bool {
+	return c.Mention == "" || strings.EqualFold(c.Mention, strings.TrimPrefix(botUsername, "@"))
+}
+
+// StartPayload returns the deep-link payload of a /start command: decoded
+// if StartLink encoded it, as is for a plain parameter ("/start ref42"
+// from a hand-written link). It returns "" if the command is not /start or
+// has no valid parameter.
+func (c Command) StartPayload() string {
+	if c.Name != "start" || len(c.Payload) > maxStartParam || !startParamRegex.MatchString(c.Payload) {
+		return ""
+	}
+	if !strings.HasPrefix(c.Payload, startPayloadMarker) {
+		return c.Payload
+	}
+	payload, err := DecodeStartPayload(c.Payload)
+	if err != nil {
+		return ""
+	}
+	return payload
+}
+
+// ================== Deep Links ==================
+
+// maxStartParam is Telegram's limit on start/startgroup parameters.
+const maxStartParam = 64
+
+var startParamRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// startPayloadMarker prefixes encoded payloads, telling them apart from
+// plain parameters.
+const startPayloadMarker = "b64-"
+
+// StartLink returns a t.me link that opens a private chat with the bot and
+// sends "/start <param>". payload may be any string; it is encoded with
+// EncodeStartPayload to fit Telegram's parameter alphabet, so payloads up
+// to 45 bytes fit the 64-character limit. Decode it with
+// Command.StartPayload.
+func StartLink(botUsername, payload string) (string, error) {
+	return deepLink(botUsername, "start", payload)
+}
+
+// StartGroupLink is like StartLink but lets the user pick a group to add
+// the bot to.
+func StartGroupLink(botUsername, payload string) (string, error) {
+	return deepLink(botUsername, "startgroup", payload)
+}
+
+func deepLink(botUsername, param, payload string) (string, error) {
+	botUsername = strings.TrimPrefix(botUsername, "@")
+	if botUsername == "" {
+		return "", NewValidationError("bot_username", "must not be empty")
+	}
+	link := "https://t.me/" + botUsername + "?" + param
+	if payload == "" {
+		return link, nil
+	}
+	encoded := EncodeStartPayload(payload)
+	if len(encoded) > maxStartParam {
+		return "", NewValidationError("payload", "must encode to at most 64 characters (45 bytes)")
+	}
+	return link + "=" + encoded, nil
+}
+
+// EncodeStartPayload encodes payload with unpadded base64url, the alphabet
+// Telegram allows in start parameters, behind a "b64-" marker.
+func EncodeStartPayload(payload string) string {
+	return startPayloadMarker + base64.RawURLEncoding.EncodeToString([]byte(payload))
+}
+
+// DecodeStartPayload reverses EncodeStartPayload. It fails for parameters
+// without the marker.
+func DecodeStartPayload(param string) (string, error) {
+	encoded, ok := strings.CutPrefix(param, startPayloadMarker)
+	if !ok || len(param) > maxStartParam || !startParamRegex.MatchString(param) {
+		return "", NewValidationError("start", "invalid start parameter")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", NewValidationError("start", "invalid start parameter")
+	}
+	return string(data), nil
+}
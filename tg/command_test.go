@@ -0,0 +1,112 @@
+package tg_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		text string
+		want tg.Command
+		ok   bool
+	}{
+		{"/start", tg.Command{Name: "start"}, true},
+		{"/Ban@MyBot 42  spam", tg.Command{Name: "ban", Mention: "MyBot", Args: []string{"42", "spam"}, Payload: "42  spam"}, true},
+		{"/note\nfirst line", tg.Command{Name: "note", Args: []string{"first", "line"}, Payload: "first line"}, true},
+		{"hello /start", tg.Command{}, false},
+		{"/", tg.Command{}, false},
+		{"/@MyBot", tg.Command{}, false},
+		{"", tg.Command{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := tg.ParseCommand(&tg.Message{Text: tt.text})
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				if tt.want.Args == nil {
+					tt.want.Args = []string{}
+				}
+				if len(got.Args) == 0 {
+					got.Args = []string{}
+				}
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseCommand_Caption(t *testing.T) {
+	cmd, ok := tg.ParseCommand(&tg.Message{Caption: "/resize 100"})
+	require.True(t, ok)
+	assert.Equal(t, "resize", cmd.Name)
+	assert.Equal(t, []string{"100"}, cmd.Args)
+
+	_, ok = tg.ParseCommand(nil)
+	assert.False(t, ok)
+}
+
+func TestCommand_IsFor(t *testing.T) {
+	cmd, _ := tg.ParseCommandText("/help@MyBot")
+	assert.True(t, cmd.IsFor("mybot"))
+	assert.True(t, cmd.IsFor("@MyBot"))
+	assert.False(t, cmd.IsFor("OtherBot"))
+
+	cmd, _ = tg.ParseCommandText("/help")
+	assert.True(t, cmd.IsFor("OtherBot"))
+}
+
+func TestStartLink_RoundTrip(t *testing.T) {
+	link, err := tg.StartLink("@MyBot", "ref=42&src=ad")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(link, "https://t.me/MyBot?start="))
+
+	param := strings.TrimPrefix(link, "https://t.me/MyBot?start=")
+	cmd, ok := tg.ParseCommandText("/start " + param)
+	require.True(t, ok)
+	assert.Equal(t, "ref=42&src=ad", cmd.StartPayload())
+}
+
+func TestStartLink_Limits(t *testing.T) {
+	link, err := tg.StartGroupLink("MyBot", "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://t.me/MyBot?startgroup", link)
+
+	_, err = tg.StartLink("MyBot", strings.Repeat("x", 45))
+	require.NoError(t, err)
+	_, err = tg.StartLink("MyBot", strings.Repeat("x", 46))
+	require.Error(t, err)
+	_, err = tg.StartLink("", "x")
+	require.Error(t, err)
+}
+
+func TestDecodeStartPayload_Invalid(t *testing.T) {
+	_, err := tg.DecodeStartPayload("not valid!")
+	require.Error(t, err)
+	_, err = tg.DecodeStartPayload("cmVmNDI")
+	require.Error(t, err, "no marker")
+	_, err = tg.DecodeStartPayload("b64-!")
+	require.Error(t, err)
+
+	cmd, _ := tg.ParseCommandText("/start plain words")
+	assert.Empty(t, cmd.StartPayload())
+	cmd, _ = tg.ParseCommandText("/help abc")
+	assert.Empty(t, cmd.StartPayload())
+	cmd, _ = tg.ParseCommandText("/start b64-!!")
+	assert.Empty(t, cmd.StartPayload())
+}
+
+func TestStartPayload_Plain(t *testing.T) {
+	// A hand-written link's parameter is not base64 and comes back as is,
+	// even when it happens to be valid base64.
+	for _, param := range []string{"ref42", "abcd", "promo_2024-q1"} {
+		cmd, ok := tg.ParseCommandText("/start " + param)
+		require.True(t, ok)
+		assert.Equal(t, param, cmd.StartPayload())
+	}
+}
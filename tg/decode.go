@@ -0,0 +1,189 @@
+package tg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ================== JSON Decoding ==================
+
+// DefaultSnippetSize is the length of the payload excerpt in a DecodeError
+// when Decoder.SnippetSize is 0.
+const DefaultSnippetSize = 256
+
+// Decoder decodes Bot API payloads. The zero value is the lenient
+// production decoder: fields galigo does not model are ignored, numbers
+// in untyped values (any, map[string]any) decode to float64, and errors
+// carry a redacted excerpt of the payload.
+type Decoder struct {
+	// Strict rejects fields the target type does not model, to catch API
+	// drift in tests. Types with their own UnmarshalJSON, such as the
+	// ChatMember and TransactionPartner unions, still decode their
+	// contents leniently.
+	Strict bool
+	// UseNumber decodes numbers in untyped values as json.Number instead
+	// of float64, keeping IDs above 2^53 exact.
+	UseNumber bool
+	// SnippetSize caps the payload excerpt in errors (0 =
+	// DefaultSnippetSize, negative = no excerpt).
+	SnippetSize int
+}
+
+// StrictDecoder returns the decoder for tests: unknown fields are errors
+// and untyped numbers keep their precision.
+func StrictDecoder() Decoder {
+	return Decoder{Strict: true, UseNumber: true}
+}
+
+// DecodeError reports a payload that could not be decoded.
+type DecodeError struct {
+	Offset  int64  // byte offset of the error in the payload, -1 if unknown
+	Snippet string // excerpt around Offset with string values redacted
+	Err     error
+}
+
+func (e *DecodeError) Error() string {
+	switch {
+	case e.Snippet == "":
+		return e.Err.Error()
+	case e.Offset < 0:
+		return fmt.Sprintf("%v (payload: %s)", e.Err, e.Snippet)
+	}
+	return fmt.Sprintf("%v (at byte %d: %s)", e.Err, e.Offset, e.Snippet)
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// Decode decodes the JSON value in data into v. Like json.Unmarshal, it
+// fails if data holds anything after the value. Errors are *DecodeError.
+func (d Decoder) Decode(data []byte, v any) error {
+	// json.Unmarshal decodes in place, where a json.Decoder first copies
+	// data into its own buffer. Errors are rare, so on failure the value
+	// is decoded again below for the usual error.
+	if !d.Strict && !d.UseNumber && json.Unmarshal(data, v) == nil {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if d.Strict {
+		dec.DisallowUnknownFields()
+	}
+	if d.UseNumber {
+		dec.UseNumber()
+	}
+	err := dec.Decode(v)
+	if err == nil {
+		if len(bytes.TrimSpace(data[dec.InputOffset():])) == 0 {
+			return nil
+		}
+		err = errors.New("json: unexpected data after top-level value")
+	}
+	return d.wrap(data, err)
+}
+
+// Wrap turns err, returned while decoding data by other means (such as
+// UnmarshalUpdateWithRaw), into a *DecodeError with this decoder's
+// excerpt settings.
+func (d Decoder) Wrap(data []byte, err error) error {
+	if err == nil {
+		return nil
+	}
+	return d.wrap(data, err)
+}
+
+func (d Decoder) wrap(data []byte, err error) error {
+	offset := int64(-1)
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	}
+
+	size := d.SnippetSize
+	if size == 0 {
+		size = DefaultSnippetSize
+	}
+	var snippet string
+	if size > 0 {
+		snippet = redactedSnippet(data, offset, size)
+	}
+	return &DecodeError{Offset: offset, Snippet: snippet, Err: err}
+}
+
+// snippetKeepKeys are keys whose string values are enum-like and kept in
+// snippets, as they explain most union decoding errors.
+var snippetKeepKeys = map[string]bool{"type": true, "status": true, "source": true}
+
+// redactedSnippet returns about size bytes of data around offset (from the
+// start if offset is unknown) with string values replaced by "***". Object
+// keys and values of snippetKeepKeys are kept.
+func redactedSnippet(data []byte, offset int64, size int) string {
+	start := 0
+	if offset > 0 {
+		start = max(0, int(offset)-size/2)
+	}
+	end := min(len(data), start+size)
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	var key string
+	for i := 0; i < end; {
+		if data[i] != '"' {
+			if i >= start {
+				b.WriteByte(data[i])
+			}
+			i++
+			continue
+		}
+		j := stringEnd(data, i)
+		tok := data[i:j]
+		isKey := nextNonSpace(data, j) == ':'
+		switch {
+		case isKey:
+			key = strings.Trim(string(tok), `"`)
+		case !snippetKeepKeys[key]:
+			tok = []byte(`"***"`)
+		}
+		if j > start {
+			b.Write(tok)
+		}
+		i = j
+	}
+	if end < len(data) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+// stringEnd returns the index after the closing quote of the JSON string
+// starting at data[i], or len(data) if it is unterminated.
+func stringEnd(data []byte, i int) int {
+	for j := i + 1; j < len(data); j++ {
+		switch data[j] {
+		case '\\':
+			j++
+		case '"':
+			return j + 1
+		}
+	}
+	return len(data)
+}
+
+func nextNonSpace(data []byte, i int) byte {
+	for ; i < len(data); i++ {
+		switch data[i] {
+		case ' ', '\t', '\n', '\r':
+		default:
+			return data[i]
+		}
+	}
+	return 0
+}
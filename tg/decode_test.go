@@ -0,0 +1,98 @@
+package tg_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestDecoder_Lenient(t *testing.T) {
+	var u tg.User
+	require.NoError(t, tg.Decoder{}.Decode([]byte(`{"id":1,"first_name":"A","future_field":true}`), &u))
+	assert.Equal(t, int64(1), u.ID)
+
+	var m map[string]any
+	require.NoError(t, tg.Decoder{}.Decode([]byte(`{"id":9007199254740993}`), &m))
+	assert.IsType(t, float64(0), m["id"])
+}
+
+func TestDecoder_Strict(t *testing.T) {
+	var u tg.User
+	err := tg.StrictDecoder().Decode([]byte(`{"id":1,"first_name":"A","future_field":true}`), &u)
+	var decErr *tg.DecodeError
+	require.ErrorAs(t, err, &decErr)
+	assert.Contains(t, err.Error(), `unknown field "future_field"`)
+	assert.Equal(t, int64(-1), decErr.Offset)
+
+	var m map[string]any
+	require.NoError(t, tg.StrictDecoder().Decode([]byte(`{"id":9007199254740993}`), &m))
+	assert.Equal(t, json.Number("9007199254740993"), m["id"])
+}
+
+func TestDecoder_TrailingData(t *testing.T) {
+	var u tg.User
+	err := tg.Decoder{}.Decode([]byte(`{"id":1} {"id":2}`), &u)
+	assert.ErrorContains(t, err, "unexpected data after top-level value")
+	assert.NoError(t, tg.Decoder{}.Decode([]byte(" {\"id\":1}\n"), &u))
+}
+
+func TestDecoder_RedactedSnippet(t *testing.T) {
+	payload := `{"message_id":"oops","chat":{"id":5,"type":"private"},"text":"secret \"quoted\" text"}`
+	var msg tg.Message
+	err := tg.Decoder{}.Decode([]byte(payload), &msg)
+
+	var decErr *tg.DecodeError
+	require.ErrorAs(t, err, &decErr)
+	assert.Positive(t, decErr.Offset)
+	assert.Contains(t, decErr.Snippet, `"message_id":"***"`)
+	assert.Contains(t, decErr.Snippet, `"type":"private"`)
+	assert.Contains(t, decErr.Snippet, `"text":"***"`)
+	assert.NotContains(t, err.Error(), "secret")
+	assert.Contains(t, err.Error(), "at byte")
+}
+
+func TestDecoder_SnippetSize(t *testing.T) {
+	payload := `{"id":1,"first_name":"A","pad":[` + strings.Repeat("0,", 200) + `0],"is_bot":"no"}`
+	var u tg.User
+
+	err := tg.Decoder{SnippetSize: 40}.Decode([]byte(payload), &u)
+	var decErr *tg.DecodeError
+	require.ErrorAs(t, err, &decErr)
+	assert.True(t, strings.HasPrefix(decErr.Snippet, "…"))
+	assert.Contains(t, decErr.Snippet, `"is_bot"`)
+	assert.LessOrEqual(t, len(decErr.Snippet), 40+2*len("…"))
+
+	err = tg.Decoder{SnippetSize: -1}.Decode([]byte(payload), &u)
+	require.ErrorAs(t, err, &decErr)
+	assert.Empty(t, decErr.Snippet)
+	assert.NotContains(t, err.Error(), "at byte")
+}
+
+func BenchmarkDecoder(b *testing.B) {
+	payload := []byte(`{"message_id":1,"date":1700000000,"chat":{"id":5,"type":"private","first_name":"A"},` +
+		`"from":{"id":5,"is_bot":false,"first_name":"A"},"text":"/start hello",` +
+		`"entities":[{"type":"bot_command","offset":0,"length":6}]}`)
+	for _, bc := range []struct {
+		name string
+		dec  tg.Decoder
+	}{
+		{"Lenient", tg.Decoder{}},
+		{"Strict", tg.StrictDecoder()},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(payload)))
+			for b.Loop() {
+				var msg tg.Message
+				if err := bc.dec.Decode(payload, &msg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
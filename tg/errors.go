@@ -3,6 +3,7 @@ package tg
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
 	"time"
 )
@@ -20,7 +21,10 @@ var (
 	ErrMessageNotModified   = errors.New("galigo: message not modified")
 	ErrMessageCantBeEdited  = errors.New("galigo: message can't be edited")
 	ErrMessageCantBeDeleted = errors.New("galigo: message can't be deleted")
+	ErrMessageCantBeCopied  = errors.New("galigo: message can't be copied")
 	ErrMessageTooOld        = errors.New("galigo: message too old")
+	ErrQuoteNotFound        = errors.New("galigo: quote not found in message")
+	ErrCantParseEntities    = errors.New("galigo: can't parse entities")
 
 	// Chat/User errors
 	ErrBotBlocked      = errors.New("galigo: bot blocked by user")
@@ -29,6 +33,9 @@ var (
 	ErrUserDeactivated = errors.New("galigo: user deactivated")
 	ErrNoRights        = errors.New("galigo: not enough rights")
 
+	// Sticker errors
+	ErrStickerSetInvalid = errors.New("galigo: sticker set not found")
+
 	// Callback errors
 	ErrCallbackExpired     = errors.New("galigo: callback query expired")
 	ErrInvalidCallbackData = errors.New("galigo: invalid callback data")
@@ -40,9 +47,10 @@ var (
 	ErrResponseTooLarge = errors.New("galigo: response too large")
 
 	// Validation errors
-	ErrInvalidToken  = errors.New("galigo: invalid bot token format")
-	ErrPathTraversal = errors.New("galigo: path traversal attempt")
-	ErrInvalidConfig = errors.New("galigo: invalid configuration")
+	ErrInvalidToken     = errors.New("galigo: invalid bot token format")
+	ErrTokenBotMismatch = errors.New("galigo: token belongs to a different bot")
+	ErrPathTraversal    = errors.New("galigo: path traversal attempt")
+	ErrInvalidConfig    = errors.New("galigo: invalid configuration")
 )
 
 // ResponseParameters contains information about why a request was unsuccessful.
@@ -124,8 +132,12 @@ func DetectSentinel(code int, desc string) error {
 		return ErrMessageCantBeEdited
 	case strings.Contains(descLower, "message can't be deleted"):
 		return ErrMessageCantBeDeleted
+	case strings.Contains(descLower, "message can't be copied"):
+		return ErrMessageCantBeCopied
 	case strings.Contains(descLower, "message is too old"):
 		return ErrMessageTooOld
+	case strings.Contains(descLower, "can't parse entities"):
+		return ErrCantParseEntities
 	case strings.Contains(descLower, "bot was blocked"):
 		return ErrBotBlocked
 	case strings.Contains(descLower, "bot was kicked"):
@@ -136,6 +148,8 @@ func DetectSentinel(code int, desc string) error {
 		return ErrUserDeactivated
 	case strings.Contains(descLower, "not enough rights"):
 		return ErrNoRights
+	case strings.Contains(descLower, "stickerset_invalid"):
+		return ErrStickerSetInvalid
 	case strings.Contains(descLower, "query is too old"):
 		return ErrCallbackExpired
 	case strings.Contains(descLower, "button_data_invalid"):
@@ -157,6 +171,42 @@ func DetectSentinel(code int, desc string) error {
 	return nil
 }
 
+// RequestError is the final error of a failed API call, with the context
+// logs and alerts need without parsing messages: the method, the target
+// chat, how often the call was tried, the time spent backing off between
+// tries and the state of its circuit breaker afterwards. Err is the cause
+// (an *APIError, ErrCircuitOpen, ErrMaxRetries, a network or context
+// error, ...); Error returns its message, and errors.Is and errors.As see
+// through to it. Logged with slog, it expands into these fields.
+type RequestError struct {
+	Method   string
+	ChatID   string        // "" for requests without a chat
+	Attempts int           // tries, including retries
+	Backoff  time.Duration // total wait between tries
+	Breaker  string        // "closed", "half-open" or "open"
+	Err      error
+}
+
+func (e *RequestError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the cause.
+func (e *RequestError) Unwrap() error { return e.Err }
+
+// LogValue returns the context and the cause as a group (slog.LogValuer).
+func (e *RequestError) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.String("method", e.Method)}
+	if e.ChatID != "" {
+		attrs = append(attrs, slog.String("chat_id", e.ChatID))
+	}
+	attrs = append(attrs,
+		slog.Int("attempts", e.Attempts),
+		slog.Duration("backoff", e.Backoff),
+		slog.String("breaker", e.Breaker),
+		slog.String("error", e.Err.Error()),
+	)
+	return slog.GroupValue(attrs...)
+}
+
 // ValidationError represents a request validation error.
 type ValidationError struct {
 	Field   string
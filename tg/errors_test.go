@@ -110,7 +110,9 @@ func TestDetectSentinel(t *testing.T) {
 		{"message not found generic", 400, "Bad Request: message not found", tg.ErrMessageNotFound},
 		{"message can't be edited", 400, "Bad Request: message can't be edited", tg.ErrMessageCantBeEdited},
 		{"message can't be deleted", 400, "Bad Request: message can't be deleted", tg.ErrMessageCantBeDeleted},
+		{"message can't be copied", 400, "Bad Request: message can't be copied", tg.ErrMessageCantBeCopied},
 		{"message too old", 400, "Bad Request: message is too old", tg.ErrMessageTooOld},
+		{"can't parse entities", 400, "Bad Request: can't parse entities: Unsupported start tag \"foo\" at byte offset 0", tg.ErrCantParseEntities},
 		{"bot blocked", 403, "Forbidden: bot was blocked by the user", tg.ErrBotBlocked},
 		{"bot kicked", 403, "Forbidden: bot was kicked from the chat", tg.ErrBotKicked},
 		{"chat not found", 400, "Bad Request: chat not found", tg.ErrChatNotFound},
@@ -118,6 +120,7 @@ func TestDetectSentinel(t *testing.T) {
 		{"not enough rights", 400, "Bad Request: not enough rights to send messages", tg.ErrNoRights},
 		{"callback expired", 400, "Bad Request: query is too old and response timeout expired", tg.ErrCallbackExpired},
 		{"invalid callback data", 400, "Bad Request: BUTTON_DATA_INVALID", tg.ErrInvalidCallbackData},
+		{"sticker set invalid", 400, "Bad Request: STICKERSET_INVALID", tg.ErrStickerSetInvalid},
 
 		// HTTP status code fallbacks
 		{"401 unauthorized", 401, "Unauthorized", tg.ErrUnauthorized},
@@ -173,7 +176,9 @@ func TestSentinelErrors_AreDistinct(t *testing.T) {
 		tg.ErrMessageNotModified,
 		tg.ErrMessageCantBeEdited,
 		tg.ErrMessageCantBeDeleted,
+		tg.ErrMessageCantBeCopied,
 		tg.ErrMessageTooOld,
+		tg.ErrCantParseEntities,
 		tg.ErrBotBlocked,
 		tg.ErrBotKicked,
 		tg.ErrChatNotFound,
@@ -181,6 +186,7 @@ func TestSentinelErrors_AreDistinct(t *testing.T) {
 		tg.ErrNoRights,
 		tg.ErrCallbackExpired,
 		tg.ErrInvalidCallbackData,
+		tg.ErrStickerSetInvalid,
 		tg.ErrRateLimited,
 		tg.ErrCircuitOpen,
 		tg.ErrMaxRetries,
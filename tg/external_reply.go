@@ -0,0 +1,112 @@
+package tg
+
+// ================== Replies ==================
+//
+// A reply to a message in the same chat carries it in reply_to_message.
+// A reply to a message in another chat or forum topic carries
+// external_reply instead, with the replied message's origin and content
+// but not the Message itself. Either may come with quote, the part of
+// the replied message the user selected. A reply to a story carries
+// reply_to_story. The Replied* accessors read whichever is set.
+
+// ExternalReplyInfo describes a message replied to from another chat or
+// forum topic. At most one of the content fields is set. Paid media is
+// not modeled yet.
+type ExternalReplyInfo struct {
+	Origin             MessageOrigin       `json:"origin"`
+	Chat               *Chat               `json:"chat,omitempty"`       // only for supergroups and channels
+	MessageID          int                 `json:"message_id,omitempty"` // only if Chat is set
+	LinkPreviewOptions *LinkPreviewOptions `json:"link_preview_options,omitempty"`
+	Animation          *Animation          `json:"animation,omitempty"`
+	Audio              *Audio              `json:"audio,omitempty"`
+	Document           *Document           `json:"document,omitempty"`
+	Photo              []PhotoSize         `json:"photo,omitempty"`
+	Sticker            *Sticker            `json:"sticker,omitempty"`
+	Story              *Story              `json:"story,omitempty"`
+	Video              *Video              `json:"video,omitempty"`
+	VideoNote          *VideoNote          `json:"video_note,omitempty"`
+	Voice              *Voice              `json:"voice,omitempty"`
+	HasMediaSpoiler    bool                `json:"has_media_spoiler,omitempty"`
+	Checklist          *Checklist          `json:"checklist,omitempty"`
+	Contact            *Contact            `json:"contact,omitempty"`
+	Dice               *Dice               `json:"dice,omitempty"`
+	Game               *Game               `json:"game,omitempty"`
+	Giveaway           *Giveaway           `json:"giveaway,omitempty"`
+	GiveawayWinners    *GiveawayWinners    `json:"giveaway_winners,omitempty"`
+	Invoice            *Invoice            `json:"invoice,omitempty"`
+	Location           *Location           `json:"location,omitempty"`
+	Poll               *Poll               `json:"poll,omitempty"`
+	Venue              *Venue              `json:"venue,omitempty"`
+}
+
+// TextQuote is the part of a replied message quoted by a reply.
+type TextQuote struct {
+	Text string `json:"text"`
+	// Entities are the bold, italic, underline, strikethrough, spoiler and
+	// custom_emoji entities of the quoted part, relative to Text.
+	Entities []MessageEntity `json:"entities,omitempty"`
+	Position int             `json:"position"`            // in UTF-16 code units of the original text
+	IsManual bool            `json:"is_manual,omitempty"` // selected by the sender rather than added automatically
+}
+
+// EntityText returns the part of the quote covered by e, one of its
+// Entities, or "" if e lies outside the quote.
+func (q *TextQuote) EntityText(e MessageEntity) string {
+	if q == nil {
+		return ""
+	}
+	s, _ := utf16Slice(q.Text, e.Offset, e.Length)
+	return s
+}
+
+// IsReply reports whether m replies to a message, in the same chat or in
+// another one, or to a story.
+func (m *Message) IsReply() bool {
+	return m != nil && (m.ReplyToMessage != nil || m.ExternalReply != nil || m.ReplyToStory != nil)
+}
+
+// RepliedChat returns the chat of the message or story m replies to: the
+// chat of reply_to_message, of external_reply, which is nil unless the
+// replied message is in a supergroup or channel, or of reply_to_story.
+func (m *Message) RepliedChat() *Chat {
+	switch {
+	case m == nil:
+		return nil
+	case m.ReplyToMessage != nil:
+		return m.ReplyToMessage.Chat
+	case m.ExternalReply != nil:
+		return m.ExternalReply.Chat
+	case m.ReplyToStory != nil:
+		return &m.ReplyToStory.Chat
+	}
+	return nil
+}
+
+// RepliedMessageID returns the ID of the message m replies to, 0 if m is
+// not a reply to a message or the replied message's chat is unknown.
+func (m *Message) RepliedMessageID() int {
+	switch {
+	case m == nil:
+		return 0
+	case m.ReplyToMessage != nil:
+		return m.ReplyToMessage.MessageID
+	case m.ExternalReply != nil:
+		return m.ExternalReply.MessageID
+	}
+	return 0
+}
+
+// RepliedText returns the text m replies to: the quote if there is one,
+// otherwise the text or caption of reply_to_message. An external reply
+// carries no text beyond its quote.
+func (m *Message) RepliedText() (string, []MessageEntity) {
+	switch {
+	case m == nil:
+		return "", nil
+	case m.Quote != nil:
+		return m.Quote.Text, m.Quote.Entities
+	case m.ReplyToMessage != nil:
+		return quotableText(m.ReplyToMessage)
+	}
+	return "", nil
+}
@@ -0,0 +1,92 @@
+package tg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_ExternalReply(t *testing.T) {
+	data := `{"message_id":3,"date":1700000000,"chat":{"id":7,"type":"private"},"text":"agreed",
+		"external_reply":{"origin":{"type":"channel","date":1699990000,"chat":{"id":-1001,"type":"channel","title":"C"},"message_id":42},
+			"chat":{"id":-1001,"type":"channel","title":"C"},"message_id":42,"photo":[{"file_id":"f","file_unique_id":"u","width":90,"height":90}]},
+		"quote":{"text":"👍 big news","entities":[{"type":"bold","offset":3,"length":3}],"position":5,"is_manual":true}}`
+	var m Message
+	require.NoError(t, json.Unmarshal([]byte(data), &m))
+
+	require.NotNil(t, m.ExternalReply)
+	assert.Equal(t, MessageOriginTypeChannel, m.ExternalReply.Origin.Type())
+	assert.Len(t, m.ExternalReply.Photo, 1)
+	assert.True(t, m.IsReply())
+	assert.Equal(t, int64(-1001), m.RepliedChat().ID)
+	assert.Equal(t, 42, m.RepliedMessageID())
+
+	require.NotNil(t, m.Quote)
+	assert.True(t, m.Quote.IsManual)
+	assert.Equal(t, 5, m.Quote.Position)
+	assert.Equal(t, "big", m.Quote.EntityText(m.Quote.Entities[0]))
+	text, entities := m.RepliedText()
+	assert.Equal(t, "👍 big news", text)
+	assert.Len(t, entities, 1)
+}
+
+func TestMessage_ReplyToMessage(t *testing.T) {
+	m := &Message{
+		MessageID: 3,
+		ReplyToMessage: &Message{
+			MessageID: 2,
+			Chat:      &Chat{ID: 7, Type: "private"},
+			Caption:   "photo caption",
+		},
+	}
+	assert.True(t, m.IsReply())
+	assert.Equal(t, int64(7), m.RepliedChat().ID)
+	assert.Equal(t, 2, m.RepliedMessageID())
+	text, _ := m.RepliedText()
+	assert.Equal(t, "photo caption", text)
+
+	m.Quote = &TextQuote{Text: "caption"}
+	text, _ = m.RepliedText()
+	assert.Equal(t, "caption", text)
+}
+
+func TestMessage_NotReply(t *testing.T) {
+	for _, m := range []*Message{nil, {MessageID: 1, Text: "hi"}} {
+		assert.False(t, m.IsReply())
+		assert.Nil(t, m.RepliedChat())
+		assert.Zero(t, m.RepliedMessageID())
+		text, entities := m.RepliedText()
+		assert.Empty(t, text)
+		assert.Nil(t, entities)
+	}
+}
+
+func TestMessage_ExternalReplyFromPrivateChat(t *testing.T) {
+	var m Message
+	require.NoError(t, json.Unmarshal([]byte(`{"message_id":3,"date":1,"chat":{"id":7,"type":"private"},
+		"external_reply":{"origin":{"type":"hidden_user","date":1,"sender_user_name":"Anon"},"dice":{"emoji":"🎲","value":4}}}`), &m))
+	assert.True(t, m.IsReply())
+	assert.Nil(t, m.RepliedChat())
+	assert.Zero(t, m.RepliedMessageID())
+	require.NotNil(t, m.ExternalReply.Dice)
+	assert.Equal(t, 4, m.ExternalReply.Dice.Value)
+}
+
+func TestMessage_ReplyToStory(t *testing.T) {
+	var m Message
+	require.NoError(t, json.Unmarshal([]byte(`{"message_id":3,"date":1,"chat":{"id":7,"type":"private"},"text":"nice",
+		"reply_to_story":{"chat":{"id":8,"type":"private","first_name":"B"},"id":12}}`), &m))
+	require.NotNil(t, m.ReplyToStory)
+	assert.Equal(t, 12, m.ReplyToStory.ID)
+	assert.True(t, m.IsReply())
+	assert.Equal(t, int64(8), m.RepliedChat().ID)
+	assert.Zero(t, m.RepliedMessageID())
+}
+
+func TestTextQuote_EntityTextOutOfRange(t *testing.T) {
+	q := &TextQuote{Text: "short"}
+	assert.Empty(t, q.EntityText(MessageEntity{Type: "bold", Offset: 3, Length: 10}))
+	assert.Empty(t, (*TextQuote)(nil).EntityText(MessageEntity{}))
+}
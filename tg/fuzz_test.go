@@ -85,3 +85,54 @@ func FuzzStarTransactionUnmarshalJSON(f *testing.F) {
 		_ = json.Unmarshal(data, &tx)
 	})
 }
+
+// FuzzUpdateRoundTrip checks that any Update galigo accepts survives
+// marshal→unmarshal without losing fields: re-encoding the decoded value
+// must reproduce the same JSON.
+func FuzzUpdateRoundTrip(f *testing.F) {
+	for _, tc := range roundTripUpdates {
+		f.Add([]byte(tc.json))
+	}
+	f.Add([]byte(`{}`))
+	f.Add([]byte(`null`))
+	f.Add([]byte(`{invalid`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var u Update
+		if err := json.Unmarshal(data, &u); err != nil {
+			return
+		}
+		first, err := json.Marshal(u)
+		if err != nil {
+			t.Fatalf("marshal after successful unmarshal: %v", err)
+		}
+
+		var again Update
+		if err := json.Unmarshal(first, &again); err != nil {
+			t.Fatalf("unmarshal of re-encoded update: %v\n%s", err, first)
+		}
+		second, err := json.Marshal(again)
+		if err != nil {
+			t.Fatalf("second marshal: %v", err)
+		}
+		if string(first) != string(second) {
+			t.Fatalf("round-trip changed update:\nfirst:  %s\nsecond: %s", first, second)
+		}
+	})
+}
+
+// FuzzConvertMarkdownToV2 checks that converted legacy Markdown always
+// passes LintMarkdownV2.
+func FuzzConvertMarkdownToV2(f *testing.F) {
+	f.Add("*bold* _italic_ `code` [link](https://example.com)")
+	f.Add("```go\nx := `a`\n```")
+	f.Add(`a\_b \* c\ d`)
+	f.Add("_a__b_ *_ [x](y")
+	f.Add("Done. (1-2) #tag!")
+
+	f.Fuzz(func(t *testing.T, text string) {
+		if issues := LintMarkdownV2(ConvertMarkdownToV2(text)); len(issues) > 0 {
+			t.Fatalf("ConvertMarkdownToV2(%q): %v", text, issues)
+		}
+	})
+}
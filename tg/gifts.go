@@ -1,5 +1,7 @@
 package tg
 
+import "encoding/json"
+
 // Gifts represents a list of available gifts.
 type Gifts struct {
 	Gifts []Gift `json:"gifts"`
@@ -22,29 +24,80 @@ type OwnedGifts struct {
 	NextOffset string      `json:"next_offset,omitempty"`
 }
 
-// OwnedGift represents a gift owned by a user.
+// OwnedGift types.
+const (
+	OwnedGiftTypeRegular = "regular"
+	OwnedGiftTypeUnique  = "unique"
+)
+
+// OwnedGift represents a gift owned by a user or chat. Telegram sends the
+// gift itself as a Gift for regular gifts and as a UniqueGift for upgraded
+// ones; exactly one of Gift and UniqueGift is set, according to Type.
 type OwnedGift struct {
-	Type        string `json:"type"` // "regular" or "unique"
-	Gift        *Gift  `json:"gift,omitempty"`
-	OwnedGiftID string `json:"owned_gift_id,omitempty"`
+	Type        string      `json:"type"` // OwnedGiftTypeRegular or OwnedGiftTypeUnique
+	Gift        *Gift       `json:"-"`
+	UniqueGift  *UniqueGift `json:"-"`
+	OwnedGiftID string      `json:"owned_gift_id,omitempty"`
 
 	// Sender info
 	SenderUser *User `json:"sender_user,omitempty"`
 	SendDate   int64 `json:"send_date"`
 
-	// Message
+	// Message (regular gifts)
 	Text         string          `json:"text,omitempty"`
-	TextEntities []MessageEntity `json:"text_entities,omitempty"`
+	TextEntities []MessageEntity `json:"entities,omitempty"`
 
 	// State flags
-	IsSaved       bool `json:"is_saved,omitempty"`
-	CanBeUpgraded bool `json:"can_be_upgraded,omitempty"`
-	WasRefunded   bool `json:"was_refunded,omitempty"`
+	IsPrivate        bool `json:"is_private,omitempty"`
+	IsSaved          bool `json:"is_saved,omitempty"`
+	CanBeUpgraded    bool `json:"can_be_upgraded,omitempty"`
+	WasRefunded      bool `json:"was_refunded,omitempty"`
+	CanBeTransferred bool `json:"can_be_transferred,omitempty"`
 
 	// Star values
 	ConvertStarCount        int `json:"convert_star_count,omitempty"`
 	PrepaidUpgradeStarCount int `json:"prepaid_upgrade_star_count,omitempty"`
 	TransferStarCount       int `json:"transfer_star_count,omitempty"`
+
+	NextTransferDate int64 `json:"next_transfer_date,omitempty"` // Unique gifts
+}
+
+// UnmarshalJSON decodes the gift field into Gift or UniqueGift by Type.
+func (g *OwnedGift) UnmarshalJSON(data []byte) error {
+	type Alias OwnedGift
+	aux := &struct {
+		Gift json.RawMessage `json:"gift,omitempty"`
+		*Alias
+	}{Alias: (*Alias)(g)}
+
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	if len(aux.Gift) == 0 || string(aux.Gift) == "null" {
+		return nil
+	}
+	if g.Type == OwnedGiftTypeUnique {
+		g.UniqueGift = new(UniqueGift)
+		return json.Unmarshal(aux.Gift, g.UniqueGift)
+	}
+	g.Gift = new(Gift)
+	return json.Unmarshal(aux.Gift, g.Gift)
+}
+
+// MarshalJSON encodes Gift or UniqueGift as the gift field.
+func (g OwnedGift) MarshalJSON() ([]byte, error) {
+	type Alias OwnedGift
+	var gift any
+	switch {
+	case g.UniqueGift != nil:
+		gift = g.UniqueGift
+	case g.Gift != nil:
+		gift = g.Gift
+	}
+	return json.Marshal(struct {
+		Gift any `json:"gift,omitempty"`
+		Alias
+	}{Gift: gift, Alias: Alias(g)})
 }
 
 // AcceptedGiftTypes describes which gift types are accepted.
@@ -53,6 +106,7 @@ type AcceptedGiftTypes struct {
 	LimitedGifts        bool `json:"limited_gifts,omitempty"`
 	UniqueGifts         bool `json:"unique_gifts,omitempty"`
 	PremiumSubscription bool `json:"premium_subscription,omitempty"`
+	GiftsFromChannels   bool `json:"gifts_from_channels,omitempty"` // 9.3
 }
 
 // UniqueGiftModel describes the model of a unique gift.
@@ -32,6 +32,29 @@ func TestOwnedGift_Unmarshal(t *testing.T) {
 	assert.Equal(t, 25, g.ConvertStarCount)
 }
 
+func TestOwnedGift_GiftByType(t *testing.T) {
+	var regular, unique tg.OwnedGift
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"regular","gift":{"id":"g1","star_count":50},"text":"hi","entities":[{"type":"bold","offset":0,"length":2}]}`), &regular))
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"unique","gift":{"base_name":"Cap","name":"Cap-7","number":7},"can_be_transferred":true,"next_transfer_date":1700000000}`), &unique))
+
+	require.NotNil(t, regular.Gift)
+	assert.Nil(t, regular.UniqueGift)
+	assert.Equal(t, "g1", regular.Gift.ID)
+	assert.Len(t, regular.TextEntities, 1)
+
+	require.NotNil(t, unique.UniqueGift)
+	assert.Nil(t, unique.Gift)
+	assert.Equal(t, 7, unique.UniqueGift.Number)
+	assert.True(t, unique.CanBeTransferred)
+
+	// Round trip keeps the gift.
+	data, err := json.Marshal(unique)
+	require.NoError(t, err)
+	var again tg.OwnedGift
+	require.NoError(t, json.Unmarshal(data, &again))
+	assert.Equal(t, unique, again)
+}
+
 func TestAcceptedGiftTypes_Unmarshal(t *testing.T) {
 	data := `{"unlimited_gifts":true,"unique_gifts":true}`
 
@@ -0,0 +1,62 @@
+package tg
+
+// Giveaway represents a scheduled giveaway message.
+type Giveaway struct {
+	Chats                         []Chat   `json:"chats"`
+	WinnersSelectionDate          int64    `json:"winners_selection_date"`
+	WinnerCount                   int      `json:"winner_count"`
+	OnlyNewMembers                bool     `json:"only_new_members,omitempty"`
+	HasPublicWinners              bool     `json:"has_public_winners,omitempty"`
+	PrizeDescription              string   `json:"prize_description,omitempty"`
+	CountryCodes                  []string `json:"country_codes,omitempty"`
+	PrizeStarCount                int      `json:"prize_star_count,omitempty"`
+	PremiumSubscriptionMonthCount int      `json:"premium_subscription_month_count,omitempty"`
+}
+
+// IsStarGiveaway reports whether the prize is Telegram Stars rather than
+// Premium subscriptions.
+func (g *Giveaway) IsStarGiveaway() bool {
+	return g.PrizeStarCount > 0
+}
+
+// GiveawayCreated is a service message about the creation of a scheduled
+// giveaway.
+type GiveawayCreated struct {
+	PrizeStarCount int `json:"prize_star_count,omitempty"` // Stars giveaways only
+}
+
+// GiveawayWinners is a message about the completion of a giveaway with
+// public winners.
+type GiveawayWinners struct {
+	Chat                          Chat   `json:"chat"`
+	GiveawayMessageID             int    `json:"giveaway_message_id"`
+	WinnersSelectionDate          int64  `json:"winners_selection_date"`
+	WinnerCount                   int    `json:"winner_count"`
+	Winners                       []User `json:"winners"`
+	AdditionalChatCount           int    `json:"additional_chat_count,omitempty"`
+	PrizeStarCount                int    `json:"prize_star_count,omitempty"`
+	PremiumSubscriptionMonthCount int    `json:"premium_subscription_month_count,omitempty"`
+	UnclaimedPrizeCount           int    `json:"unclaimed_prize_count,omitempty"`
+	OnlyNewMembers                bool   `json:"only_new_members,omitempty"`
+	WasRefunded                   bool   `json:"was_refunded,omitempty"`
+	PrizeDescription              string `json:"prize_description,omitempty"`
+}
+
+// GiveawayCompleted is a service message about the completion of a
+// giveaway without public winners.
+type GiveawayCompleted struct {
+	WinnerCount         int      `json:"winner_count"`
+	UnclaimedPrizeCount int      `json:"unclaimed_prize_count,omitempty"`
+	GiveawayMessage     *Message `json:"giveaway_message,omitempty"`
+	IsStarGiveaway      bool     `json:"is_star_giveaway,omitempty"`
+}
+
+// CanBeCopied reports whether Telegram accepts the message in copyMessage.
+// Giveaway, giveaway winners and service messages (those galigo models)
+// can only be forwarded.
+func (m *Message) CanBeCopied() bool {
+	if m == nil {
+		return false
+	}
+	return m.Giveaway == nil && m.GiveawayWinners == nil && !m.IsService()
+}
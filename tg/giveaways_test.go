@@ -0,0 +1,82 @@
+package tg_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestMessage_Giveaway(t *testing.T) {
+	raw := `{
+		"message_id": 1,
+		"date": 1700000000,
+		"chat": {"id": -1001, "type": "channel"},
+		"giveaway": {
+			"chats": [{"id": -1001, "type": "channel"}],
+			"winners_selection_date": 1700086400,
+			"winner_count": 5,
+			"only_new_members": true,
+			"country_codes": ["DE", "FR"],
+			"prize_star_count": 500
+		}
+	}`
+	var msg tg.Message
+	require.NoError(t, json.Unmarshal([]byte(raw), &msg))
+	require.NotNil(t, msg.Giveaway)
+	assert.Equal(t, 5, msg.Giveaway.WinnerCount)
+	assert.Equal(t, []string{"DE", "FR"}, msg.Giveaway.CountryCodes)
+	assert.True(t, msg.Giveaway.IsStarGiveaway())
+	assert.False(t, msg.CanBeCopied())
+}
+
+func TestMessage_GiveawayServiceMessages(t *testing.T) {
+	raw := `{
+		"message_id": 2,
+		"date": 1700086400,
+		"chat": {"id": -1001, "type": "channel"},
+		"giveaway_winners": {
+			"chat": {"id": -1001, "type": "channel"},
+			"giveaway_message_id": 1,
+			"winners_selection_date": 1700086400,
+			"winner_count": 2,
+			"winners": [{"id": 7, "is_bot": false, "first_name": "A"}, {"id": 8, "is_bot": false, "first_name": "B"}],
+			"premium_subscription_month_count": 3,
+			"unclaimed_prize_count": 1
+		}
+	}`
+	var msg tg.Message
+	require.NoError(t, json.Unmarshal([]byte(raw), &msg))
+	require.NotNil(t, msg.GiveawayWinners)
+	assert.Len(t, msg.GiveawayWinners.Winners, 2)
+	assert.Equal(t, 1, msg.GiveawayWinners.GiveawayMessageID)
+	assert.Equal(t, 3, msg.GiveawayWinners.PremiumSubscriptionMonthCount)
+
+	raw = `{
+		"message_id": 3,
+		"date": 1700086400,
+		"chat": {"id": -1001, "type": "channel"},
+		"giveaway_completed": {"winner_count": 2, "is_star_giveaway": true, "giveaway_message": {"message_id": 1, "date": 0, "chat": {"id": -1001, "type": "channel"}}}
+	}`
+	msg = tg.Message{}
+	require.NoError(t, json.Unmarshal([]byte(raw), &msg))
+	require.NotNil(t, msg.GiveawayCompleted)
+	assert.True(t, msg.GiveawayCompleted.IsStarGiveaway)
+	assert.Equal(t, 1, msg.GiveawayCompleted.GiveawayMessage.MessageID)
+
+	raw = `{"message_id": 4, "date": 0, "chat": {"id": -1001, "type": "channel"}, "giveaway_created": {"prize_star_count": 100}}`
+	msg = tg.Message{}
+	require.NoError(t, json.Unmarshal([]byte(raw), &msg))
+	require.NotNil(t, msg.GiveawayCreated)
+	assert.Equal(t, 100, msg.GiveawayCreated.PrizeStarCount)
+}
+
+func TestMessage_CanBeCopied(t *testing.T) {
+	assert.True(t, (&tg.Message{Text: "hi"}).CanBeCopied())
+	assert.False(t, (&tg.Message{NewChatTitle: "New"}).CanBeCopied())
+	assert.False(t, (&tg.Message{GiveawayCreated: &tg.GiveawayCreated{}}).CanBeCopied())
+	assert.False(t, (*tg.Message)(nil).CanBeCopied())
+}
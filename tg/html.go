@@ -0,0 +1,271 @@
+package tg
+
+import (
+	"html"
+	"slices"
+	"strings"
+)
+
+// ================== HTML Sanitizing ==================
+//
+// Telegram's HTML parse mode accepts a small set of tags and rejects the
+// whole message (400 "can't parse entities") on anything else: unknown
+// tags, unbalanced tags, or a bare "<" or "&". SanitizeHTML keeps the
+// supported subset and escapes the rest, so user text can be echoed back
+// inside HTML-formatted replies.
+
+// htmlTags lists the tags Telegram supports and the attributes kept on
+// each; other attributes are dropped.
+var htmlTags = map[string][]string{
+	"b": nil, "strong": nil, "i": nil, "em": nil, "u": nil, "ins": nil,
+	"s": nil, "strike": nil, "del": nil, "tg-spoiler": nil,
+	"span":       {"class"}, // only class="tg-spoiler"
+	"a":          {"href"},
+	"tg-emoji":   {"emoji-id"},
+	"tg-time":    {"unix", "format"},
+	"code":       {"class"}, // language-* inside pre
+	"pre":        nil,
+	"blockquote": {"expandable"},
+}
+
+// SanitizeHTML returns s with the HTML tags Telegram supports kept and
+// everything else escaped, so it can be sent with ParseModeHTML without
+// being rejected. Supported tags lose unsupported attributes, closing
+// tags that do not match the innermost open tag are escaped, tags left
+// open are closed at the end, and tags inside code and pre (other than
+// pre's code) are escaped. Entities other than &lt; &gt; &amp; &quot; and
+// numeric ones are escaped too.
+func SanitizeHTML(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + len(s)/8)
+	var open []string
+
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '<':
+			tag, n, ok := parseHTMLTag(s[i:])
+			if ok && sanitizeTag(&b, &open, tag) {
+				i += n
+				continue
+			}
+			b.WriteString("&lt;")
+		case '>':
+			b.WriteString("&gt;")
+		case '"':
+			b.WriteString("&quot;")
+		case '&':
+			if n := htmlEntityLen(s[i:]); n > 0 {
+				b.WriteString(s[i : i+n])
+				i += n
+				continue
+			}
+			b.WriteString("&amp;")
+		default:
+			b.WriteByte(s[i])
+		}
+		i++
+	}
+	for j := len(open) - 1; j >= 0; j-- {
+		b.WriteString("</" + open[j] + ">")
+	}
+	return b.String()
+}
+
+// SanitizeHTMLStrict strips all tags from s and escapes the remaining
+// text, for user input that must show up as plain text inside an HTML
+// message. Entities in s are decoded first, so "&lt;b&gt;" stays visible
+// as "<b>".
+func SanitizeHTMLStrict(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] == '<' {
+			if _, n, ok := parseHTMLTag(s[i:]); ok {
+				i += n
+				continue
+			}
+		}
+		next := strings.IndexByte(s[i+1:], '<')
+		if next < 0 {
+			next = len(s)
+		} else {
+			next += i + 1
+		}
+		b.WriteString(html.EscapeString(html.UnescapeString(s[i:next])))
+		i = next
+	}
+	return b.String()
+}
+
+type htmlTag struct {
+	name        string
+	closing     bool
+	selfClosing bool
+	attrs       [][2]string
+}
+
+// sanitizeTag writes tag if Telegram supports it where it appears and
+// updates the open tag stack. It reports false if tag must be escaped.
+func sanitizeTag(b *strings.Builder, open *[]string, tag htmlTag) bool {
+	allowed, ok := htmlTags[tag.name]
+	if !ok || tag.selfClosing {
+		return false
+	}
+	inner := ""
+	if len(*open) > 0 {
+		inner = (*open)[len(*open)-1]
+	}
+
+	if tag.closing {
+		if inner != tag.name {
+			return false
+		}
+		*open = (*open)[:len(*open)-1]
+		b.WriteString("</" + tag.name + ">")
+		return true
+	}
+
+	// Nothing nests in code; only code nests in pre; links, quotes and
+	// the like do not nest in themselves.
+	if inner == "code" || (inner == "pre" && tag.name != "code") {
+		return false
+	}
+	switch tag.name {
+	case "a", "blockquote", "pre", "tg-emoji", "tg-time":
+		if slices.Contains(*open, tag.name) {
+			return false
+		}
+	}
+	var kept [][2]string
+	for _, attr := range tag.attrs {
+		for _, name := range allowed {
+			if attr[0] == name {
+				kept = append(kept, attr)
+			}
+		}
+	}
+	switch tag.name {
+	case "span":
+		if len(kept) != 1 || kept[0][1] != "tg-spoiler" {
+			return false
+		}
+	case "code":
+		if inner != "pre" || (len(kept) == 1 && !strings.HasPrefix(kept[0][1], "language-")) {
+			kept = nil
+		}
+	case "a", "tg-emoji", "tg-time":
+		if len(kept) == 0 {
+			return false
+		}
+	}
+
+	*open = append(*open, tag.name)
+	b.WriteString("<" + tag.name)
+	for _, attr := range kept {
+		b.WriteString(" " + attr[0])
+		if attr[1] != "" || attr[0] != "expandable" {
+			b.WriteString(`="` + html.EscapeString(attr[1]) + `"`)
+		}
+	}
+	b.WriteByte('>')
+	return true
+}
+
+// parseHTMLTag parses a start or end tag at the start of s and returns
+// its length in bytes. Names are lowercased.
+func parseHTMLTag(s string) (htmlTag, int, bool) {
+	var tag htmlTag
+	i := 1
+	if i < len(s) && s[i] == '/' {
+		tag.closing = true
+		i++
+	}
+	start := i
+	for i < len(s) && isTagNameByte(s[i]) {
+		i++
+	}
+	if i == start || !isASCIILetter(s[start]) {
+		return tag, 0, false
+	}
+	tag.name = strings.ToLower(s[start:i])
+
+	for {
+		for i < len(s) && isHTMLSpace(s[i]) {
+			i++
+		}
+		if i >= len(s) {
+			return tag, 0, false
+		}
+		if s[i] == '>' {
+			return tag, i + 1, true
+		}
+		if strings.HasPrefix(s[i:], "/>") {
+			tag.selfClosing = true
+			return tag, i + 2, true
+		}
+		if tag.closing {
+			return tag, 0, false
+		}
+
+		start = i
+		for i < len(s) && !isHTMLSpace(s[i]) && strings.IndexByte("=>/\"'<", s[i]) < 0 {
+			i++
+		}
+		if i == start {
+			return tag, 0, false
+		}
+		name := strings.ToLower(s[start:i])
+		value := ""
+		if i < len(s) && s[i] == '=' {
+			i++
+			if i >= len(s) {
+				return tag, 0, false
+			}
+			if q := s[i]; q == '"' || q == '\'' {
+				end := strings.IndexByte(s[i+1:], q)
+				if end < 0 {
+					return tag, 0, false
+				}
+				value = s[i+1 : i+1+end]
+				i += end + 2
+			} else {
+				start = i
+				for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				value = s[start:i]
+			}
+		}
+		tag.attrs = append(tag.attrs, [2]string{name, html.UnescapeString(value)})
+	}
+}
+
+// htmlEntityLen returns the length of the entity Telegram accepts at the
+// start of s, or 0.
+func htmlEntityLen(s string) int {
+	for _, name := range []string{"&lt;", "&gt;", "&amp;", "&quot;"} {
+		if strings.HasPrefix(s, name) {
+			return len(name)
+		}
+	}
+	if !strings.HasPrefix(s, "&#") {
+		return 0
+	}
+	i, hex := 2, false
+	if i < len(s) && (s[i] == 'x' || s[i] == 'X') {
+		i, hex = 3, true
+	}
+	start := i
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9' || hex && strings.IndexByte("abcdefABCDEF", s[i]) >= 0) {
+		i++
+	}
+	if i == start || i >= len(s) || s[i] != ';' {
+		return 0
+	}
+	return i + 1
+}
+
+func isASCIILetter(c byte) bool { return c|0x20 >= 'a' && c|0x20 <= 'z' }
+
+func isTagNameByte(c byte) bool { return isASCIILetter(c) || c >= '0' && c <= '9' || c == '-' }
+
+func isHTMLSpace(c byte) bool { return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' }
@@ -0,0 +1,58 @@
+package tg_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain text", "1 < 2 & 3 > 2", "1 &lt; 2 &amp; 3 &gt; 2"},
+		{"supported tags", "<b>bold</b> <i>it</i> <tg-spoiler>s</tg-spoiler>", "<b>bold</b> <i>it</i> <tg-spoiler>s</tg-spoiler>"},
+		{"tag case", "<B>x</B>", "<b>x</b>"},
+		{"unsupported tag", "<script>alert(1)</script>", "&lt;script&gt;alert(1)&lt;/script&gt;"},
+		{"link keeps href only", `<a href="https://example.com/?a=1&amp;b=2" onclick="x">l</a>`, `<a href="https://example.com/?a=1&amp;b=2">l</a>`},
+		{"link without href", "<a name=x>l</a>", "&lt;a name=x&gt;l&lt;/a&gt;"},
+		{"spoiler span", `<span class="tg-spoiler">s</span>`, `<span class="tg-spoiler">s</span>`},
+		{"other span", `<span class="big">s</span>`, `&lt;span class=&quot;big&quot;&gt;s&lt;/span&gt;`},
+		{"pre with language", `<pre><code class="language-go">x := 1</code></pre>`, `<pre><code class="language-go">x := 1</code></pre>`},
+		{"tags inside code", "<code><b>x</b></code>", "<code>&lt;b&gt;x&lt;/b&gt;</code>"},
+		{"unclosed tag", "<b>bold", "<b>bold</b>"},
+		{"stray closing tag", "x</b>", "x&lt;/b&gt;"},
+		{"overlapping tags", "<b><i>x</b></i>", "<b><i>x&lt;/b&gt;</i></b>"},
+		{"nested links", `<a href="u"><a href="v">x</a></a>`, `<a href="u">&lt;a href=&quot;v&quot;&gt;x</a>&lt;/a&gt;`},
+		{"expandable quote", "<blockquote expandable>q</blockquote>", "<blockquote expandable>q</blockquote>"},
+		{"entities", "&lt; &#169; &#x1F600; &nbsp; &copy", "&lt; &#169; &#x1F600; &amp;nbsp; &amp;copy"},
+		{"self-closing", "a<br/>b", "a&lt;br/&gt;b"},
+		{"malformed tag", "<b", "&lt;b"},
+		{"not a tag", "<3 you", "&lt;3 you"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tg.SanitizeHTML(tt.in))
+		})
+	}
+}
+
+func TestSanitizeHTMLStrict(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"<b>hi</b> & <script>x</script>", "hi &amp; x"},
+		{"&lt;b&gt; stays visible", "&lt;b&gt; stays visible"},
+		{"1 < 2", "1 &lt; 2"},
+		{`say "hi"`, "say &#34;hi&#34;"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tg.SanitizeHTMLStrict(tt.in), tt.in)
+	}
+}
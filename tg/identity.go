@@ -65,3 +65,38 @@ type BotDescription struct {
 type BotShortDescription struct {
 	ShortDescription string `json:"short_description"` // 0-120 chars
 }
+
+// Menu button types.
+const (
+	MenuButtonTypeCommands = "commands"
+	MenuButtonTypeWebApp   = "web_app"
+	MenuButtonTypeDefault  = "default"
+)
+
+// MenuButton describes the bot's menu button in a private chat. Type
+// selects the variant:
+//   - MenuButtonTypeCommands: opens the bot's list of commands
+//   - MenuButtonTypeWebApp: Text is the button label and WebApp the Mini App it opens
+//   - MenuButtonTypeDefault: no specific value; the default applies
+type MenuButton struct {
+	Type   string      `json:"type"`
+	Text   string      `json:"text,omitempty"`
+	WebApp *WebAppInfo `json:"web_app,omitempty"`
+}
+
+// MenuButtonDefault returns the menu button that resets a chat to the
+// bot's default.
+func MenuButtonDefault() MenuButton {
+	return MenuButton{Type: MenuButtonTypeDefault}
+}
+
+// MenuButtonCommands returns the menu button that opens the command list.
+func MenuButtonCommands() MenuButton {
+	return MenuButton{Type: MenuButtonTypeCommands}
+}
+
+// MenuButtonWebApp returns a menu button labeled text that opens the Mini
+// App at url.
+func MenuButtonWebApp(text, url string) MenuButton {
+	return MenuButton{Type: MenuButtonTypeWebApp, Text: text, WebApp: &WebAppInfo{URL: url}}
+}
@@ -23,7 +23,91 @@ type InlineKeyboardButton struct {
 	SwitchInlineQuery            string                       `json:"switch_inline_query,omitempty"`
 	SwitchInlineQueryCurrentChat string                       `json:"switch_inline_query_current_chat,omitempty"`
 	SwitchInlineQueryChosenChat  *SwitchInlineQueryChosenChat `json:"switch_inline_query_chosen_chat,omitempty"`
+	CopyText                     *CopyTextButton              `json:"copy_text,omitempty"`
+	CallbackGame                 *CallbackGame                `json:"callback_game,omitempty"`
 	Pay                          bool                         `json:"pay,omitempty"`
+
+	// emptySwitch and emptySwitchCurrent mark a switch_inline_query or
+	// switch_inline_query_current_chat action with an empty query, which
+	// Telegram allows: it inserts just the bot's username.
+	emptySwitch        bool
+	emptySwitchCurrent bool
+}
+
+// MarshalJSON encodes the button, keeping an empty inline query switch.
+func (b InlineKeyboardButton) MarshalJSON() ([]byte, error) {
+	type Alias InlineKeyboardButton
+	aux := struct {
+		SwitchInlineQuery            *string `json:"switch_inline_query,omitempty"`
+		SwitchInlineQueryCurrentChat *string `json:"switch_inline_query_current_chat,omitempty"`
+		Alias
+	}{Alias: Alias(b)}
+	if b.SwitchInlineQuery != "" || b.emptySwitch {
+		aux.SwitchInlineQuery = &b.SwitchInlineQuery
+	}
+	if b.SwitchInlineQueryCurrentChat != "" || b.emptySwitchCurrent {
+		aux.SwitchInlineQueryCurrentChat = &b.SwitchInlineQueryCurrentChat
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON decodes the button, keeping an empty inline query switch.
+func (b *InlineKeyboardButton) UnmarshalJSON(data []byte) error {
+	type Alias InlineKeyboardButton
+	aux := &struct {
+		SwitchInlineQuery            *string `json:"switch_inline_query,omitempty"`
+		SwitchInlineQueryCurrentChat *string `json:"switch_inline_query_current_chat,omitempty"`
+		*Alias
+	}{Alias: (*Alias)(b)}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	b.SwitchInlineQuery, b.emptySwitch = "", false
+	if aux.SwitchInlineQuery != nil {
+		b.SwitchInlineQuery, b.emptySwitch = *aux.SwitchInlineQuery, *aux.SwitchInlineQuery == ""
+	}
+	b.SwitchInlineQueryCurrentChat, b.emptySwitchCurrent = "", false
+	if aux.SwitchInlineQueryCurrentChat != nil {
+		b.SwitchInlineQueryCurrentChat, b.emptySwitchCurrent = *aux.SwitchInlineQueryCurrentChat, *aux.SwitchInlineQueryCurrentChat == ""
+	}
+	return nil
+}
+
+// CopyTextButton is the action of a button that copies Text to the
+// clipboard.
+type CopyTextButton struct {
+	Text string `json:"text"` // 1-256 characters
+}
+
+// Action returns the name of the button's action field, e.g.
+// "callback_data", or "" if none is set. Every button needs exactly one;
+// Actions lists all that are set.
+func (b InlineKeyboardButton) Action() string {
+	if a := b.Actions(); len(a) > 0 {
+		return a[0]
+	}
+	return ""
+}
+
+// Actions returns the names of all action fields set on the button.
+func (b InlineKeyboardButton) Actions() []string {
+	var actions []string
+	add := func(set bool, name string) {
+		if set {
+			actions = append(actions, name)
+		}
+	}
+	add(b.URL != "", "url")
+	add(b.CallbackData != "", "callback_data")
+	add(b.WebApp != nil, "web_app")
+	add(b.LoginURL != nil, "login_url")
+	add(b.SwitchInlineQuery != "" || b.emptySwitch, "switch_inline_query")
+	add(b.SwitchInlineQueryCurrentChat != "" || b.emptySwitchCurrent, "switch_inline_query_current_chat")
+	add(b.SwitchInlineQueryChosenChat != nil, "switch_inline_query_chosen_chat")
+	add(b.CopyText != nil, "copy_text")
+	add(b.CallbackGame != nil, "callback_game")
+	add(b.Pay, "pay")
+	return actions
 }
 
 // Button style constants for InlineKeyboardButton and KeyboardButton.
@@ -87,14 +171,16 @@ func BtnWebApp(text, url string) InlineKeyboardButton {
 	return InlineKeyboardButton{Text: text, WebApp: &WebAppInfo{URL: url}}
 }
 
-// BtnSwitch creates an inline query switch button.
+// BtnSwitch creates an inline query switch button. An empty query inserts
+// just the bot's username.
 func BtnSwitch(text, query string) InlineKeyboardButton {
-	return InlineKeyboardButton{Text: text, SwitchInlineQuery: query}
+	return InlineKeyboardButton{Text: text, SwitchInlineQuery: query, emptySwitch: query == ""}
 }
 
 // BtnSwitchCurrent creates an inline query switch button for current chat.
+// An empty query inserts just the bot's username.
 func BtnSwitchCurrent(text, query string) InlineKeyboardButton {
-	return InlineKeyboardButton{Text: text, SwitchInlineQueryCurrentChat: query}
+	return InlineKeyboardButton{Text: text, SwitchInlineQueryCurrentChat: query, emptySwitchCurrent: query == ""}
 }
 
 // BtnLogin creates a login URL button.
@@ -102,6 +188,23 @@ func BtnLogin(text string, loginURL LoginURL) InlineKeyboardButton {
 	return InlineKeyboardButton{Text: text, LoginURL: &loginURL}
 }
 
+// BtnSwitchChosen creates a button that lets the user pick a chat of the
+// allowed types and inserts the bot's username and query there.
+func BtnSwitchChosen(text string, chosen SwitchInlineQueryChosenChat) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, SwitchInlineQueryChosenChat: &chosen}
+}
+
+// BtnCopy creates a button that copies copyText to the clipboard.
+func BtnCopy(text, copyText string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, CopyText: &CopyTextButton{Text: copyText}}
+}
+
+// BtnGame creates a button that launches the game of a sendGame message
+// (must be first in first row).
+func BtnGame(text string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, CallbackGame: &CallbackGame{}}
+}
+
 // BtnPay creates a Pay button (must be first in first row).
 func BtnPay(text string) InlineKeyboardButton {
 	return InlineKeyboardButton{Text: text, Pay: true}
@@ -266,10 +369,13 @@ type KeyboardButton struct {
 
 // KeyboardButtonRequestUsers defines criteria for requesting users.
 type KeyboardButtonRequestUsers struct {
-	RequestID     int   `json:"request_id"`
-	UserIsBot     *bool `json:"user_is_bot,omitempty"`
-	UserIsPremium *bool `json:"user_is_premium,omitempty"`
-	MaxQuantity   int   `json:"max_quantity,omitempty"`
+	RequestID       int   `json:"request_id"`
+	UserIsBot       *bool `json:"user_is_bot,omitempty"`
+	UserIsPremium   *bool `json:"user_is_premium,omitempty"`
+	MaxQuantity     int   `json:"max_quantity,omitempty"` // 1-10, default 1
+	RequestName     bool  `json:"request_name,omitempty"`
+	RequestUsername bool  `json:"request_username,omitempty"`
+	RequestPhoto    bool  `json:"request_photo,omitempty"`
 }
 
 // KeyboardButtonRequestChat defines criteria for requesting a chat.
@@ -282,6 +388,9 @@ type KeyboardButtonRequestChat struct {
 	UserAdministratorRights *ChatAdministratorRights `json:"user_administrator_rights,omitempty"`
 	BotAdministratorRights  *ChatAdministratorRights `json:"bot_administrator_rights,omitempty"`
 	BotIsMember             bool                     `json:"bot_is_member,omitempty"`
+	RequestTitle            bool                     `json:"request_title,omitempty"`
+	RequestUsername         bool                     `json:"request_username,omitempty"`
+	RequestPhoto            bool                     `json:"request_photo,omitempty"`
 }
 
 // KeyboardButtonPollType limits polls to a specific type.
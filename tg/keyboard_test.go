@@ -376,3 +376,66 @@ func TestButtonStyleConstants(t *testing.T) {
 	assert.Equal(t, "success", tg.ButtonStyleSuccess)
 	assert.Equal(t, "primary", tg.ButtonStylePrimary)
 }
+
+func TestRequestUsersButton(t *testing.T) {
+	btn := tg.RequestUsersButton("Pick", tg.KeyboardButtonRequestUsers{RequestID: 3, MaxQuantity: 2})
+	data, err := json.Marshal(btn)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text":"Pick","request_users":{"request_id":3,"max_quantity":2}}`, string(data))
+
+	chat := tg.RequestChatButton("Channel", tg.KeyboardButtonRequestChat{RequestID: 4, ChatIsChannel: true})
+	require.NotNil(t, chat.RequestChat)
+	assert.Equal(t, 4, chat.RequestChat.RequestID)
+}
+
+func TestMessage_SharedRequestID(t *testing.T) {
+	var msg tg.Message
+	require.NoError(t, json.Unmarshal([]byte(`{"message_id":1,"date":1,"users_shared":{"request_id":3,"users":[{"user_id":7,"first_name":"A"}]}}`), &msg))
+	id, ok := msg.SharedRequestID()
+	assert.True(t, ok)
+	assert.Equal(t, 3, id)
+	assert.Equal(t, "A", msg.UsersShared.Users[0].FirstName)
+
+	_, ok = (&tg.Message{Text: "hi"}).SharedRequestID()
+	assert.False(t, ok)
+}
+
+func TestInlineButtonActions(t *testing.T) {
+	copyBtn := tg.BtnCopy("Copy", "PROMO10")
+	data, err := json.Marshal(copyBtn)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text":"Copy","copy_text":{"text":"PROMO10"}}`, string(data))
+	assert.Equal(t, "copy_text", copyBtn.Action())
+
+	game, err := json.Marshal(tg.BtnGame("Play"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text":"Play","callback_game":{}}`, string(game))
+
+	chosen := tg.BtnSwitchChosen("Share", tg.SwitchInlineQueryChosenChat{Query: "q", AllowGroupChats: true})
+	assert.Equal(t, "switch_inline_query_chosen_chat", chosen.Action())
+
+	assert.Empty(t, tg.InlineKeyboardButton{Text: "x"}.Action())
+	assert.Equal(t, []string{"url", "pay"}, tg.InlineKeyboardButton{Text: "x", URL: "u", Pay: true}.Actions())
+}
+
+func TestBtnSwitch_EmptyQuery(t *testing.T) {
+	btn := tg.BtnSwitch("Share", "")
+	assert.Equal(t, "switch_inline_query", btn.Action())
+	data, err := json.Marshal(btn)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text":"Share","switch_inline_query":""}`, string(data))
+
+	var decoded tg.InlineKeyboardButton
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, btn, decoded)
+
+	current := tg.BtnSwitchCurrent("Search", "")
+	assert.Equal(t, "switch_inline_query_current_chat", current.Action())
+	data, err = json.Marshal(current)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text":"Search","switch_inline_query_current_chat":""}`, string(data))
+
+	data, err = json.Marshal(tg.BtnSwitch("Share", "q"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text":"Share","switch_inline_query":"q"}`, string(data))
+}
@@ -0,0 +1,269 @@
+package tg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ================== Legacy Markdown Conversion ==================
+
+// markdownV2Special are the characters MarkdownV2 reserves outside code.
+const markdownV2Special = "_*[]()~`>#+-=|{}.!\\"
+
+// ConvertMarkdownToV2 rewrites text written for the legacy Markdown parse
+// mode as MarkdownV2 that renders the same way: *bold*, _italic_, `code`,
+// ```pre``` and [links](url) are kept, and everything else is escaped, so
+// stray dots, dashes and parentheses no longer fail with "can't parse
+// entities". Legacy escapes (\_ \* \` \[) become literal characters, and
+// markers without a closing partner are kept as literal text.
+func ConvertMarkdownToV2(text string) string {
+	var b strings.Builder
+	b.Grow(len(text) + len(text)/8)
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch c {
+		case '\\':
+			if i+1 < len(text) && strings.IndexByte("_*`[", text[i+1]) >= 0 {
+				b.WriteByte('\\')
+				b.WriteByte(text[i+1])
+				i += 2
+				continue
+			}
+		case '*', '_':
+			if end := strings.IndexByte(text[i+1:], c); end == 0 {
+				i += 2 // empty entity: renders nothing, and "__" would be underline
+				continue
+			} else if end > 0 {
+				inner := text[i+1 : i+1+end]
+				// An italic right after another one would read as "__"
+				// (underline); "\r" separates them and is ignored.
+				if c == '_' && strings.HasSuffix(b.String(), "_") {
+					b.WriteByte('\r')
+				}
+				b.WriteByte(c)
+				b.WriteString(EscapeText(ParseModeMarkdownV2, inner))
+				b.WriteByte(c)
+				i += end + 2
+				continue
+			}
+		case '`':
+			if strings.HasPrefix(text[i:], "```") {
+				if end := strings.Index(text[i+3:], "```"); end >= 0 {
+					b.WriteString("```")
+					b.WriteString(escapeWith(text[i+3:i+3+end], "`\\"))
+					b.WriteString("```")
+					i += end + 6
+					continue
+				}
+			} else if end := strings.IndexByte(text[i+1:], '`'); end >= 0 {
+				b.WriteByte('`')
+				b.WriteString(escapeWith(text[i+1:i+1+end], "`\\"))
+				b.WriteByte('`')
+				i += end + 2
+				continue
+			}
+		case '[':
+			if label, url, n, ok := legacyLink(text[i:]); ok {
+				b.WriteByte('[')
+				b.WriteString(EscapeText(ParseModeMarkdownV2, label))
+				b.WriteString("](")
+				b.WriteString(escapeWith(url, ")\\"))
+				b.WriteByte(')')
+				i += n
+				continue
+			}
+		}
+		if strings.IndexByte(markdownV2Special, c) >= 0 {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+		i++
+	}
+	return b.String()
+}
+
+// legacyLink parses "[label](url)" at the start of s and returns its
+// length in bytes.
+func legacyLink(s string) (label, url string, n int, ok bool) {
+	closeLabel := strings.IndexByte(s, ']')
+	if closeLabel < 0 || !strings.HasPrefix(s[closeLabel+1:], "(") {
+		return "", "", 0, false
+	}
+	rest := s[closeLabel+2:]
+	closeURL := strings.IndexByte(rest, ')')
+	if closeURL < 0 {
+		return "", "", 0, false
+	}
+	return s[1:closeLabel], rest[:closeURL], closeLabel + 2 + closeURL + 1, true
+}
+
+// ================== MarkdownV2 Lint ==================
+
+// MarkdownIssue is a problem LintMarkdownV2 found that would make
+// Telegram reject the text.
+type MarkdownIssue struct {
+	Offset  int // byte offset in the text, as in Telegram's errors
+	Message string
+}
+
+func (i MarkdownIssue) String() string {
+	return fmt.Sprintf("byte offset %d: %s", i.Offset, i.Message)
+}
+
+// LintMarkdownV2 reports what would make Telegram reject text sent with
+// ParseModeMarkdownV2: reserved characters that are not escaped, entities
+// that are never closed, and entities that overlap instead of nesting
+// (such as "*bold _both* italic_"). It returns nil for valid text.
+func LintMarkdownV2(text string) []MarkdownIssue {
+	l := markdownLinter{text: text}
+	l.run()
+	return l.issues
+}
+
+type markdownEntity struct {
+	name   string
+	offset int
+}
+
+type markdownLinter struct {
+	text   string
+	open   []markdownEntity
+	issues []MarkdownIssue
+}
+
+func (l *markdownLinter) report(offset int, format string, args ...any) {
+	l.issues = append(l.issues, MarkdownIssue{Offset: offset, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *markdownLinter) run() {
+	text := l.text
+	lineStart := true
+	for i := 0; i < len(text); {
+		c := text[i]
+		atLineStart := lineStart
+		lineStart = c == '\n'
+
+		switch {
+		case c == '\\':
+			if i+1 >= len(text) {
+				l.report(i, `trailing "\"`)
+				return
+			}
+			i += 2
+			continue
+		case strings.HasPrefix(text[i:], "```"):
+			i = l.skipCode(i, "```", "pre")
+			continue
+		case c == '`':
+			i = l.skipCode(i, "`", "code")
+			continue
+		case c == '*':
+			l.toggle("bold", i)
+		case strings.HasPrefix(text[i:], "__"):
+			l.toggle("underline", i)
+			i += 2
+			continue
+		case c == '_':
+			l.toggle("italic", i)
+		case c == '~':
+			l.toggle("strikethrough", i)
+		case strings.HasPrefix(text[i:], "||"):
+			l.toggle("spoiler", i)
+			i += 2
+			continue
+		case c == '[' || strings.HasPrefix(text[i:], "!["):
+			if l.isOpen("link") {
+				l.report(i, "link inside a link")
+			}
+			l.open = append(l.open, markdownEntity{name: "link", offset: i})
+			if c == '!' {
+				i++
+			}
+		case c == ']':
+			i = l.closeLink(i)
+			continue
+		case c == '>' && atLineStart:
+			// blockquote
+		case strings.IndexByte(markdownV2Special, c) >= 0:
+			l.report(i, "character %q is reserved and must be escaped with \"\\\"", c)
+		}
+		i++
+	}
+	for _, e := range l.open {
+		l.report(e.offset, "%s is not closed", e.name)
+	}
+}
+
+func (l *markdownLinter) isOpen(name string) bool {
+	for _, e := range l.open {
+		if e.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// toggle closes entity name if it is open, or opens it. Closing an entity
+// that is not the innermost one is an overlap.
+func (l *markdownLinter) toggle(name string, offset int) {
+	for j := len(l.open) - 1; j >= 0; j-- {
+		if l.open[j].name != name {
+			continue
+		}
+		if j != len(l.open)-1 {
+			inner := l.open[len(l.open)-1]
+			l.report(offset, "%s closed before %s opened at byte offset %d; entities must nest", name, inner.name, inner.offset)
+		}
+		l.open = l.open[:j]
+		return
+	}
+	l.open = append(l.open, markdownEntity{name: name, offset: offset})
+}
+
+// skipCode skips a code or pre entity starting at i, in which only "`"
+// and "\" are special, and returns the offset after it.
+func (l *markdownLinter) skipCode(i int, delim, name string) int {
+	for j := i + len(delim); j < len(l.text); j++ {
+		switch {
+		case l.text[j] == '\\':
+			j++
+		case strings.HasPrefix(l.text[j:], delim):
+			return j + len(delim)
+		case l.text[j] == '`':
+			l.report(j, "character '`' must be escaped inside %s", name)
+		}
+	}
+	l.report(i, "%s is not closed", name)
+	return len(l.text)
+}
+
+// closeLink handles "]" at i: it must end an open link label and be
+// followed by "(url)". It returns the offset after the link.
+func (l *markdownLinter) closeLink(i int) int {
+	text := l.text
+	if len(l.open) == 0 || l.open[len(l.open)-1].name != "link" {
+		if l.isOpen("link") {
+			l.toggle("link", i) // reports the overlap
+		} else {
+			l.report(i, "character ']' is reserved and must be escaped with \"\\\"")
+			return i + 1
+		}
+	} else {
+		l.open = l.open[:len(l.open)-1]
+	}
+	if !strings.HasPrefix(text[i+1:], "(") {
+		l.report(i, `link label must be followed by "(url)"`)
+		return i + 1
+	}
+	for j := i + 2; j < len(text); j++ {
+		switch text[j] {
+		case '\\':
+			j++
+		case ')':
+			return j + 1
+		}
+	}
+	l.report(i+1, "link URL is not closed")
+	return len(text)
+}
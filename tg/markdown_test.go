@@ -0,0 +1,88 @@
+package tg_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestConvertMarkdownToV2(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain punctuation", "Price: 9.99 (incl. tax) - 50% off!", `Price: 9\.99 \(incl\. tax\) \- 50% off\!`},
+		{"bold", "*Order #12* shipped", `*Order \#12* shipped`},
+		{"italic", "_v1.2_", `_v1\.2_`},
+		{"adjacent italics", "_a__b_", "_a_\r_b_"},
+		{"code", "run `go test ./...`", "run `go test ./...`"},
+		{"code with backslash", "`C:\\dir`", "`C:\\\\dir`"},
+		{"pre", "```go\nfmt.Println(\"hi\")\n```", "```go\nfmt.Println(\"hi\")\n```"},
+		{"link", "[docs (v2)](https://example.com/a_b?x=1)", `[docs \(v2\)](https://example.com/a_b?x=1)`},
+		{"link ends at first paren", "[w](https://en.wikipedia.org/wiki/Go_(game))", `[w](https://en.wikipedia.org/wiki/Go_(game)\)`},
+		{"legacy escapes", `snake\_case \*not bold\*`, `snake\_case \*not bold\*`},
+		{"unclosed markers", "2 * 3 = 6 and a_b", `2 \* 3 \= 6 and a\_b`},
+		{"empty entities", "a__b**c", "abc"},
+		{"other backslash", `a\b`, `a\\b`},
+		{"markers inside bold", "*a_b [c]*", `*a\_b \[c\]*`},
+		{"unicode", "*Привет.* ✓", `*Привет\.* ✓`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tg.ConvertMarkdownToV2(tt.in)
+			assert.Equal(t, tt.want, got)
+			assert.Empty(t, tg.LintMarkdownV2(got), "converted text must lint clean")
+		})
+	}
+}
+
+func TestLintMarkdownV2_Valid(t *testing.T) {
+	valid := []string{
+		"",
+		"plain text",
+		`*bold \*text*`,
+		"*bold _italic bold ~strike~ __underline italic bold___ bold*",
+		"||spoiler||",
+		`[inline URL](http://www.example.com/)`,
+		`[*bold link*](tg://user?id=123)`,
+		`![👍](tg://emoji?id=5368324170671202286)`,
+		"`inline code with \\` escaped`",
+		"```python\nprint(\"a.b\")\n```",
+		">quoted line\n>another",
+		"_italic_\r_italic_",
+	}
+	for _, text := range valid {
+		assert.Empty(t, tg.LintMarkdownV2(text), "%q", text)
+	}
+}
+
+func TestLintMarkdownV2_Issues(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		offset  int
+		message string
+	}{
+		{"reserved dot", "Done.", 4, `character '.' is reserved`},
+		{"unclosed bold", "*bold", 0, "bold is not closed"},
+		{"overlap", "*a _b* c_", 5, "bold closed before italic"},
+		{"unclosed code", "`code", 0, "code is not closed"},
+		{"link without url", "[label] x", 6, `followed by "(url)"`},
+		{"nested links", "[a [b](u)](v)", 3, "link inside a link"},
+		{"trailing backslash", `a\`, 1, `trailing "\"`},
+		{"stray bracket", "a]", 1, `character ']' is reserved`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := tg.LintMarkdownV2(tt.in)
+			require.NotEmpty(t, issues)
+			assert.Equal(t, tt.offset, issues[0].Offset)
+			assert.Contains(t, issues[0].Message, tt.message)
+			assert.Contains(t, issues[0].String(), "byte offset")
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package tg
+
+import "encoding/json"
+
+// ================== Message Origin ==================
+//
+// Since Bot API 7.0 a forwarded message carries forward_origin, a union
+// discriminated by "type", instead of forward_from, forward_from_chat and
+// forward_date. MessageOrigin holds the decoded variant, so Message keeps
+// its plain struct decoding.
+
+// Message origin types.
+const (
+	MessageOriginTypeUser       = "user"
+	MessageOriginTypeHiddenUser = "hidden_user"
+	MessageOriginTypeChat       = "chat"
+	MessageOriginTypeChannel    = "channel"
+)
+
+// MessageOrigin describes where a forwarded message came from. Value is
+// one of MessageOriginUser, MessageOriginHiddenUser, MessageOriginChat,
+// MessageOriginChannel or, for types galigo does not know yet,
+// MessageOriginUnknown:
+//
+//	switch o := msg.ForwardOrigin.Value.(type) {
+//	case tg.MessageOriginUser:
+//		log.Println("forwarded from", o.SenderUser.FirstName)
+//	case tg.MessageOriginChannel:
+//		log.Println("forwarded from", o.Chat.Title, o.MessageID)
+//	}
+type MessageOrigin struct {
+	Value MessageOriginValue
+}
+
+// MessageOriginValue is implemented by the MessageOrigin variants.
+type MessageOriginValue interface {
+	messageOriginTag()
+	GetType() string
+	GetDate() int64
+}
+
+// Type returns the origin type, "" if o or its value is nil.
+func (o *MessageOrigin) Type() string {
+	if o == nil || o.Value == nil {
+		return ""
+	}
+	return o.Value.GetType()
+}
+
+// Date returns the Unix time the original message was sent, 0 if o or
+// its value is nil.
+func (o *MessageOrigin) Date() int64 {
+	if o == nil || o.Value == nil {
+		return 0
+	}
+	return o.Value.GetDate()
+}
+
+// UnmarshalJSON decodes the variant selected by "type".
+func (o *MessageOrigin) UnmarshalJSON(data []byte) error {
+	o.Value = unmarshalMessageOrigin(data)
+	return nil
+}
+
+// MarshalJSON encodes the variant with its type.
+func (o MessageOrigin) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.Value)
+}
+
+// MessageOriginUser is a message originally sent by a known user.
+type MessageOriginUser struct {
+	Type       string `json:"type"` // Always "user"
+	Date       int64  `json:"date"`
+	SenderUser User   `json:"sender_user"`
+}
+
+func (MessageOriginUser) messageOriginTag() {}
+func (MessageOriginUser) GetType() string   { return MessageOriginTypeUser }
+func (o MessageOriginUser) GetDate() int64  { return o.Date }
+
+// MessageOriginHiddenUser is a message originally sent by a user who hides
+// their account in forwards; only their name is known.
+type MessageOriginHiddenUser struct {
+	Type           string `json:"type"` // Always "hidden_user"
+	Date           int64  `json:"date"`
+	SenderUserName string `json:"sender_user_name"`
+}
+
+func (MessageOriginHiddenUser) messageOriginTag() {}
+func (MessageOriginHiddenUser) GetType() string   { return MessageOriginTypeHiddenUser }
+func (o MessageOriginHiddenUser) GetDate() int64  { return o.Date }
+
+// MessageOriginChat is a message originally sent on behalf of a chat to a
+// group chat, e.g. by an anonymous administrator.
+type MessageOriginChat struct {
+	Type            string `json:"type"` // Always "chat"
+	Date            int64  `json:"date"`
+	SenderChat      Chat   `json:"sender_chat"`
+	AuthorSignature string `json:"author_signature,omitempty"`
+}
+
+func (MessageOriginChat) messageOriginTag() {}
+func (MessageOriginChat) GetType() string   { return MessageOriginTypeChat }
+func (o MessageOriginChat) GetDate() int64  { return o.Date }
+
+// MessageOriginChannel is a message originally sent to a channel.
+type MessageOriginChannel struct {
+	Type            string `json:"type"` // Always "channel"
+	Date            int64  `json:"date"`
+	Chat            Chat   `json:"chat"`
+	MessageID       int    `json:"message_id"`
+	AuthorSignature string `json:"author_signature,omitempty"`
+}
+
+func (MessageOriginChannel) messageOriginTag() {}
+func (MessageOriginChannel) GetType() string   { return MessageOriginTypeChannel }
+func (o MessageOriginChannel) GetDate() int64  { return o.Date }
+
+// MessageOriginUnknown is a fallback for future origin types.
+type MessageOriginUnknown struct {
+	Type string          `json:"type"`
+	Date int64           `json:"date"`
+	Raw  json.RawMessage `json:"-"`
+}
+
+func (MessageOriginUnknown) messageOriginTag() {}
+func (o MessageOriginUnknown) GetType() string { return o.Type }
+func (o MessageOriginUnknown) GetDate() int64  { return o.Date }
+
+// MarshalJSON emits the original payload so unknown origins survive a
+// round-trip.
+func (o MessageOriginUnknown) MarshalJSON() ([]byte, error) {
+	if len(o.Raw) > 0 {
+		return o.Raw, nil
+	}
+	type alias MessageOriginUnknown
+	return json.Marshal(alias(o))
+}
+
+// unmarshalMessageOrigin decodes a MessageOrigin variant from JSON.
+// Returns MessageOriginUnknown on any error.
+func unmarshalMessageOrigin(data []byte) MessageOriginValue {
+	raw := json.RawMessage(append([]byte(nil), data...))
+	var probe struct {
+		Type string `json:"type"`
+		Date int64  `json:"date"`
+	}
+	if err := json.Unmarshal(raw, &probe); err != nil {
+		return MessageOriginUnknown{Raw: raw}
+	}
+	unknown := MessageOriginUnknown{Type: probe.Type, Date: probe.Date, Raw: raw}
+
+	switch probe.Type {
+	case MessageOriginTypeUser:
+		var o MessageOriginUser
+		if err := json.Unmarshal(raw, &o); err != nil {
+			return unknown
+		}
+		return o
+	case MessageOriginTypeHiddenUser:
+		var o MessageOriginHiddenUser
+		if err := json.Unmarshal(raw, &o); err != nil {
+			return unknown
+		}
+		return o
+	case MessageOriginTypeChat:
+		var o MessageOriginChat
+		if err := json.Unmarshal(raw, &o); err != nil {
+			return unknown
+		}
+		return o
+	case MessageOriginTypeChannel:
+		var o MessageOriginChannel
+		if err := json.Unmarshal(raw, &o); err != nil {
+			return unknown
+		}
+		return o
+	default:
+		return unknown
+	}
+}
+
+// IsForwarded reports whether m was forwarded from another message.
+func (m *Message) IsForwarded() bool {
+	return m != nil && (m.ForwardOrigin != nil || m.ForwardDate != 0)
+}
+
+// ForwardedFrom returns the user a forwarded message was originally sent
+// by, from forward_origin or the pre-7.0 forward_from. It is nil for
+// hidden users, chats and channels, and for messages that are not
+// forwards.
+func (m *Message) ForwardedFrom() *User {
+	if m == nil {
+		return nil
+	}
+	if o, ok := m.ForwardOrigin.value().(MessageOriginUser); ok {
+		return &o.SenderUser
+	}
+	return m.ForwardFrom
+}
+
+// ForwardedFromChat returns the chat or channel a forwarded message was
+// originally sent on behalf of, from forward_origin or the pre-7.0
+// forward_from_chat.
+func (m *Message) ForwardedFromChat() *Chat {
+	if m == nil {
+		return nil
+	}
+	switch o := m.ForwardOrigin.value().(type) {
+	case MessageOriginChat:
+		return &o.SenderChat
+	case MessageOriginChannel:
+		return &o.Chat
+	}
+	return m.ForwardFromChat
+}
+
+// ForwardedSenderName returns the name of the hidden user a forwarded
+// message was originally sent by, "" otherwise.
+func (m *Message) ForwardedSenderName() string {
+	if m == nil {
+		return ""
+	}
+	if o, ok := m.ForwardOrigin.value().(MessageOriginHiddenUser); ok {
+		return o.SenderUserName
+	}
+	return ""
+}
+
+// ForwardedDate returns the Unix time the original of a forwarded message
+// was sent, 0 for messages that are not forwards.
+func (m *Message) ForwardedDate() int64 {
+	if m == nil {
+		return 0
+	}
+	if date := m.ForwardOrigin.Date(); date != 0 {
+		return date
+	}
+	return m.ForwardDate
+}
+
+func (o *MessageOrigin) value() MessageOriginValue {
+	if o == nil {
+		return nil
+	}
+	return o.Value
+}
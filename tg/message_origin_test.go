@@ -0,0 +1,126 @@
+package tg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageOrigin_Variants(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want MessageOriginValue
+	}{
+		{
+			"user",
+			`{"type":"user","date":1700000000,"sender_user":{"id":7,"is_bot":false,"first_name":"A"}}`,
+			MessageOriginUser{Type: "user", Date: 1700000000, SenderUser: User{ID: 7, FirstName: "A"}},
+		},
+		{
+			"hidden_user",
+			`{"type":"hidden_user","date":1700000000,"sender_user_name":"Anon"}`,
+			MessageOriginHiddenUser{Type: "hidden_user", Date: 1700000000, SenderUserName: "Anon"},
+		},
+		{
+			"chat",
+			`{"type":"chat","date":1700000000,"sender_chat":{"id":-100,"type":"supergroup","title":"G"},"author_signature":"admin"}`,
+			MessageOriginChat{Type: "chat", Date: 1700000000, SenderChat: Chat{ID: -100, Type: "supergroup", Title: "G"}, AuthorSignature: "admin"},
+		},
+		{
+			"channel",
+			`{"type":"channel","date":1700000000,"chat":{"id":-1001,"type":"channel","title":"C"},"message_id":42}`,
+			MessageOriginChannel{Type: "channel", Date: 1700000000, Chat: Chat{ID: -1001, Type: "channel", Title: "C"}, MessageID: 42},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var o MessageOrigin
+			require.NoError(t, json.Unmarshal([]byte(tc.json), &o))
+			assert.Equal(t, tc.want, o.Value)
+			assert.Equal(t, tc.name, o.Type())
+			assert.Equal(t, int64(1700000000), o.Date())
+
+			data, err := json.Marshal(o)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.json, string(data))
+		})
+	}
+}
+
+func TestMessageOrigin_Unknown(t *testing.T) {
+	data := `{"type":"future","date":5,"extra":true}`
+	var o MessageOrigin
+	require.NoError(t, json.Unmarshal([]byte(data), &o))
+
+	u, ok := o.Value.(MessageOriginUnknown)
+	require.True(t, ok)
+	assert.Equal(t, "future", o.Type())
+	assert.Equal(t, int64(5), o.Date())
+
+	out, err := json.Marshal(u)
+	require.NoError(t, err)
+	assert.JSONEq(t, data, string(out))
+}
+
+func TestMessageOrigin_MalformedKnown(t *testing.T) {
+	var o MessageOrigin
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"user","date":1,"sender_user":"bad"}`), &o))
+	assert.IsType(t, MessageOriginUnknown{}, o.Value)
+	assert.Equal(t, MessageOriginTypeUser, o.Type())
+}
+
+func TestMessage_ForwardAccessors(t *testing.T) {
+	decode := func(t *testing.T, s string) *Message {
+		var m Message
+		require.NoError(t, json.Unmarshal([]byte(s), &m))
+		return &m
+	}
+
+	t.Run("user origin", func(t *testing.T) {
+		m := decode(t, `{"message_id":1,"date":2,"chat":{"id":7,"type":"private"},"forward_origin":{"type":"user","date":10,"sender_user":{"id":8,"is_bot":false,"first_name":"B"}}}`)
+		assert.True(t, m.IsForwarded())
+		require.NotNil(t, m.ForwardedFrom())
+		assert.Equal(t, int64(8), m.ForwardedFrom().ID)
+		assert.Nil(t, m.ForwardedFromChat())
+		assert.Equal(t, int64(10), m.ForwardedDate())
+	})
+
+	t.Run("hidden user origin", func(t *testing.T) {
+		m := decode(t, `{"message_id":1,"date":2,"chat":{"id":7,"type":"private"},"forward_origin":{"type":"hidden_user","date":10,"sender_user_name":"Anon"}}`)
+		assert.Nil(t, m.ForwardedFrom())
+		assert.Equal(t, "Anon", m.ForwardedSenderName())
+	})
+
+	t.Run("channel origin", func(t *testing.T) {
+		m := decode(t, `{"message_id":1,"date":2,"chat":{"id":7,"type":"private"},"forward_origin":{"type":"channel","date":10,"chat":{"id":-1001,"type":"channel","title":"C"},"message_id":5}}`)
+		assert.Nil(t, m.ForwardedFrom())
+		require.NotNil(t, m.ForwardedFromChat())
+		assert.Equal(t, int64(-1001), m.ForwardedFromChat().ID)
+	})
+
+	t.Run("legacy fields", func(t *testing.T) {
+		m := decode(t, `{"message_id":1,"date":2,"chat":{"id":7,"type":"private"},"forward_from":{"id":8,"is_bot":false,"first_name":"B"},"forward_date":10}`)
+		assert.True(t, m.IsForwarded())
+		require.NotNil(t, m.ForwardedFrom())
+		assert.Equal(t, int64(8), m.ForwardedFrom().ID)
+		assert.Equal(t, int64(10), m.ForwardedDate())
+	})
+
+	t.Run("not forwarded", func(t *testing.T) {
+		m := decode(t, `{"message_id":1,"date":2,"chat":{"id":7,"type":"private"},"text":"hi"}`)
+		assert.False(t, m.IsForwarded())
+		assert.Nil(t, m.ForwardedFrom())
+		assert.Nil(t, m.ForwardedFromChat())
+		assert.Empty(t, m.ForwardedSenderName())
+		assert.Zero(t, m.ForwardedDate())
+	})
+
+	t.Run("nil message", func(t *testing.T) {
+		var m *Message
+		assert.False(t, m.IsForwarded())
+		assert.Nil(t, m.ForwardedFrom())
+	})
+}
@@ -1,5 +1,10 @@
 package tg
 
+import (
+	"html"
+	"strings"
+)
+
 // ParseMode defines the text formatting mode for messages.
 type ParseMode string
 
@@ -25,6 +30,32 @@ func (p ParseMode) IsValid() bool {
 	}
 }
 
+// EscapeText escapes s so that Telegram shows it literally in text sent
+// with mode. Plain text (mode "") is returned unchanged.
+func EscapeText(mode ParseMode, s string) string {
+	switch mode {
+	case ParseModeHTML:
+		return html.EscapeString(s)
+	case ParseModeMarkdownV2:
+		return escapeWith(s, "_*[]()~`>#+-=|{}.!\\")
+	case ParseModeMarkdown:
+		return escapeWith(s, "_*`[")
+	default:
+		return s
+	}
+}
+
+func escapeWith(s, special string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(special, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 // ChatType represents the type of a Telegram chat.
 type ChatType string
 
@@ -15,6 +15,15 @@ type ShippingOption struct {
 	Prices []LabeledPrice `json:"prices"`
 }
 
+// Invoice contains basic information about an invoice.
+type Invoice struct {
+	Title          string `json:"title"`
+	Description    string `json:"description"`
+	StartParameter string `json:"start_parameter"`
+	Currency       string `json:"currency"`
+	TotalAmount    int    `json:"total_amount"` // Smallest currency unit
+}
+
 // SuccessfulPayment contains information about a successful payment.
 type SuccessfulPayment struct {
 	Currency                   string     `json:"currency"`
@@ -40,6 +49,12 @@ type StarTransactions struct {
 	Transactions []StarTransaction `json:"transactions"`
 }
 
+// PaidMediaPurchased contains information about a paid media purchase.
+type PaidMediaPurchased struct {
+	From             User   `json:"from"`
+	PaidMediaPayload string `json:"paid_media_payload"`
+}
+
 // StarTransaction describes a Telegram Star transaction.
 type StarTransaction struct {
 	ID             string             `json:"id"`
@@ -79,18 +94,110 @@ type TransactionPartner interface {
 	transactionPartnerTag()
 }
 
+// Transaction partner types, as returned by TransactionPartnerType.
+const (
+	TransactionPartnerTypeUser             = "user"
+	TransactionPartnerTypeChat             = "chat"
+	TransactionPartnerTypeAffiliateProgram = "affiliate_program"
+	TransactionPartnerTypeFragment         = "fragment"
+	TransactionPartnerTypeTelegramAds      = "telegram_ads"
+	TransactionPartnerTypeTelegramAPI      = "telegram_api"
+	TransactionPartnerTypeOther            = "other"
+)
+
+// Kinds of TransactionPartnerUser.TransactionType.
+const (
+	UserTransactionInvoicePayment          = "invoice_payment"
+	UserTransactionPaidMediaPayment        = "paid_media_payment"
+	UserTransactionGiftPurchase            = "gift_purchase"
+	UserTransactionPremiumPurchase         = "premium_purchase"
+	UserTransactionBusinessAccountTransfer = "business_account_transfer"
+)
+
+// TransactionPartnerType returns the type of p, e.g.
+// TransactionPartnerTypeUser, or "" for nil.
+func TransactionPartnerType(p TransactionPartner) string {
+	switch p := p.(type) {
+	case TransactionPartnerUser:
+		return TransactionPartnerTypeUser
+	case TransactionPartnerChat:
+		return TransactionPartnerTypeChat
+	case TransactionPartnerAffiliateProgram:
+		return TransactionPartnerTypeAffiliateProgram
+	case TransactionPartnerFragment:
+		return TransactionPartnerTypeFragment
+	case TransactionPartnerTelegramAds:
+		return TransactionPartnerTypeTelegramAds
+	case TransactionPartnerTelegramAPI:
+		return TransactionPartnerTypeTelegramAPI
+	case TransactionPartnerOther:
+		return TransactionPartnerTypeOther
+	case TransactionPartnerUnknown:
+		return p.Type
+	}
+	return ""
+}
+
+// Incoming reports whether the transaction credited the bot: incoming
+// transactions have a Source, outgoing ones a Receiver.
+func (s *StarTransaction) Incoming() bool {
+	return s.Source != nil
+}
+
+// Partner returns the other side of the transaction: Source for incoming
+// transactions, Receiver for outgoing ones.
+func (s *StarTransaction) Partner() TransactionPartner {
+	if s.Source != nil {
+		return s.Source
+	}
+	return s.Receiver
+}
+
+// AffiliateInfo describes the affiliate that received a commission from a
+// transaction.
+type AffiliateInfo struct {
+	AffiliateUser      *User `json:"affiliate_user,omitempty"`
+	AffiliateChat      *Chat `json:"affiliate_chat,omitempty"`
+	CommissionPerMille int   `json:"commission_per_mille"`
+	Amount             int   `json:"amount"`
+	NanostarAmount     int   `json:"nanostar_amount,omitempty"`
+}
+
 // TransactionPartnerUser represents a transaction with a user.
 type TransactionPartnerUser struct {
-	Type               string `json:"type"` // Always "user"
-	User               User   `json:"user"`
-	InvoicePayload     string `json:"invoice_payload,omitempty"`
-	PaidMediaPayload   string `json:"paid_media_payload,omitempty"`
-	SubscriptionPeriod int    `json:"subscription_period,omitempty"`
-	Gift               *Gift  `json:"gift,omitempty"`
+	Type                        string            `json:"type"`             // Always "user"
+	TransactionType             string            `json:"transaction_type"` // UserTransaction* constants
+	User                        User              `json:"user"`
+	Affiliate                   *AffiliateInfo    `json:"affiliate,omitempty"`
+	InvoicePayload              string            `json:"invoice_payload,omitempty"`
+	SubscriptionPeriod          int               `json:"subscription_period,omitempty"`
+	PaidMedia                   []json.RawMessage `json:"paid_media,omitempty"`
+	PaidMediaPayload            string            `json:"paid_media_payload,omitempty"`
+	Gift                        *Gift             `json:"gift,omitempty"`
+	PremiumSubscriptionDuration int               `json:"premium_subscription_duration,omitempty"` // months
 }
 
 func (TransactionPartnerUser) transactionPartnerTag() {}
 
+// TransactionPartnerChat represents a transaction with a chat.
+type TransactionPartnerChat struct {
+	Type string `json:"type"` // Always "chat"
+	Chat Chat   `json:"chat"`
+	Gift *Gift  `json:"gift,omitempty"`
+}
+
+func (TransactionPartnerChat) transactionPartnerTag() {}
+
+// TransactionPartnerAffiliateProgram represents a commission received
+// through an affiliate program.
+type TransactionPartnerAffiliateProgram struct {
+	Type               string `json:"type"` // Always "affiliate_program"
+	SponsorUser        *User  `json:"sponsor_user,omitempty"`
+	CommissionPerMille int    `json:"commission_per_mille"`
+}
+
+func (TransactionPartnerAffiliateProgram) transactionPartnerTag() {}
+
 // TransactionPartnerFragment represents a withdrawal to Fragment.
 type TransactionPartnerFragment struct {
 	Type            string                 `json:"type"` // Always "fragment"
@@ -147,6 +254,16 @@ type TransactionPartnerUnknown struct {
 
 func (TransactionPartnerUnknown) transactionPartnerTag() {}
 
+// MarshalJSON emits the original payload so unknown types survive a
+// round-trip.
+func (u TransactionPartnerUnknown) MarshalJSON() ([]byte, error) {
+	if len(u.Raw) > 0 {
+		return u.Raw, nil
+	}
+	type alias TransactionPartnerUnknown
+	return json.Marshal(alias(u))
+}
+
 // unmarshalTransactionPartner decodes a TransactionPartner from JSON.
 // Returns TransactionPartnerUnknown on any error (including malformed known types).
 func unmarshalTransactionPartner(data json.RawMessage) TransactionPartner {
@@ -164,6 +281,18 @@ func unmarshalTransactionPartner(data json.RawMessage) TransactionPartner {
 			return TransactionPartnerUnknown{Type: probe.Type, Raw: data}
 		}
 		return p
+	case "chat":
+		var p TransactionPartnerChat
+		if err := json.Unmarshal(data, &p); err != nil {
+			return TransactionPartnerUnknown{Type: probe.Type, Raw: data}
+		}
+		return p
+	case "affiliate_program":
+		var p TransactionPartnerAffiliateProgram
+		if err := json.Unmarshal(data, &p); err != nil {
+			return TransactionPartnerUnknown{Type: probe.Type, Raw: data}
+		}
+		return p
 	case "fragment":
 		var p TransactionPartnerFragment
 		if err := json.Unmarshal(data, &p); err != nil {
@@ -231,6 +360,16 @@ type RevenueWithdrawalStateUnknown struct {
 
 func (RevenueWithdrawalStateUnknown) revenueWithdrawalStateTag() {}
 
+// MarshalJSON emits the original payload so unknown types survive a
+// round-trip.
+func (u RevenueWithdrawalStateUnknown) MarshalJSON() ([]byte, error) {
+	if len(u.Raw) > 0 {
+		return u.Raw, nil
+	}
+	type alias RevenueWithdrawalStateUnknown
+	return json.Marshal(alias(u))
+}
+
 // unmarshalRevenueWithdrawalState decodes a RevenueWithdrawalState from JSON.
 // Returns RevenueWithdrawalStateUnknown on any error.
 func unmarshalRevenueWithdrawalState(data json.RawMessage) RevenueWithdrawalState {
@@ -19,6 +19,50 @@ func TestUnmarshalTransactionPartner_User(t *testing.T) {
 	assert.Equal(t, "Alice", p.User.FirstName)
 }
 
+func TestUnmarshalTransactionPartner_UserAffiliate(t *testing.T) {
+	data := `{"type":"user","transaction_type":"invoice_payment","user":{"id":1,"first_name":"A"},` +
+		`"affiliate":{"affiliate_user":{"id":2,"first_name":"B"},"commission_per_mille":100,"amount":5}}`
+	p, ok := unmarshalTransactionPartner(json.RawMessage(data)).(TransactionPartnerUser)
+	require.True(t, ok)
+	assert.Equal(t, UserTransactionInvoicePayment, p.TransactionType)
+	require.NotNil(t, p.Affiliate)
+	assert.Equal(t, int64(2), p.Affiliate.AffiliateUser.ID)
+	assert.Equal(t, 5, p.Affiliate.Amount)
+}
+
+func TestUnmarshalTransactionPartner_Chat(t *testing.T) {
+	data := `{"type":"chat","chat":{"id":-100,"type":"channel"}}`
+	p, ok := unmarshalTransactionPartner(json.RawMessage(data)).(TransactionPartnerChat)
+	require.True(t, ok)
+	assert.Equal(t, int64(-100), p.Chat.ID)
+}
+
+func TestUnmarshalTransactionPartner_AffiliateProgram(t *testing.T) {
+	data := `{"type":"affiliate_program","sponsor_user":{"id":3,"is_bot":true,"first_name":"S"},"commission_per_mille":200}`
+	p, ok := unmarshalTransactionPartner(json.RawMessage(data)).(TransactionPartnerAffiliateProgram)
+	require.True(t, ok)
+	assert.Equal(t, 200, p.CommissionPerMille)
+	assert.Equal(t, int64(3), p.SponsorUser.ID)
+}
+
+func TestTransactionPartnerType(t *testing.T) {
+	assert.Equal(t, TransactionPartnerTypeUser, TransactionPartnerType(TransactionPartnerUser{}))
+	assert.Equal(t, TransactionPartnerTypeFragment, TransactionPartnerType(TransactionPartnerFragment{}))
+	assert.Equal(t, "future", TransactionPartnerType(TransactionPartnerUnknown{Type: "future"}))
+	assert.Equal(t, "", TransactionPartnerType(nil))
+}
+
+func TestStarTransaction_Partner(t *testing.T) {
+	var in, out StarTransaction
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"1","amount":5,"date":1,"source":{"type":"user","user":{"id":1}}}`), &in))
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"2","amount":5,"date":1,"receiver":{"type":"fragment"}}`), &out))
+
+	assert.True(t, in.Incoming())
+	assert.IsType(t, TransactionPartnerUser{}, in.Partner())
+	assert.False(t, out.Incoming())
+	assert.IsType(t, TransactionPartnerFragment{}, out.Partner())
+}
+
 func TestUnmarshalTransactionPartner_Fragment(t *testing.T) {
 	data := `{"type":"fragment","withdrawal_state":{"type":"succeeded","date":1700000000,"url":"https://example.com"}}`
 	result := unmarshalTransactionPartner(json.RawMessage(data))
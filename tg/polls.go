@@ -0,0 +1,133 @@
+package tg
+
+import (
+	"slices"
+	"sync"
+)
+
+// Poll types.
+const (
+	PollTypeRegular = "regular"
+	PollTypeQuiz    = "quiz"
+)
+
+// IsQuiz reports whether the poll is a quiz.
+func (p *Poll) IsQuiz() bool {
+	return p != nil && p.Type == PollTypeQuiz
+}
+
+// VoterID returns the ID of the user or chat that answered.
+func (a *PollAnswer) VoterID() int64 {
+	switch {
+	case a == nil:
+		return 0
+	case a.User != nil:
+		return a.User.ID
+	case a.VoterChat != nil:
+		return a.VoterChat.ID
+	}
+	return 0
+}
+
+// IsRetracted reports whether the voter retracted their vote.
+func (a *PollAnswer) IsRetracted() bool {
+	return a != nil && len(a.OptionIDs) == 0
+}
+
+// IsCorrect reports whether the answer picks the correct option of quiz p.
+// It returns false for regular polls and for polls whose correct option is
+// unknown to the bot (Telegram only reveals it to the poll's sender).
+func (a *PollAnswer) IsCorrect(p *Poll) bool {
+	if a == nil || !p.IsQuiz() || len(a.OptionIDs) != 1 {
+		return false
+	}
+	return a.OptionIDs[0] == p.CorrectOptionID
+}
+
+// PollTally counts votes from poll_answer updates. Each voter's latest
+// answer replaces the previous one, and retractions remove it. Telegram
+// only sends poll_answer for non-anonymous polls.
+//
+// PollTally is safe for concurrent use. The zero value is ready to use.
+type PollTally struct {
+	mu      sync.Mutex
+	answers map[string]map[int64][]int // poll ID → voter ID → option IDs
+}
+
+// Record applies a poll_answer update.
+func (t *PollTally) Record(a *PollAnswer) {
+	voter := a.VoterID()
+	if voter == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if a.IsRetracted() {
+		delete(t.answers[a.PollID], voter)
+		return
+	}
+	if t.answers == nil {
+		t.answers = make(map[string]map[int64][]int)
+	}
+	if t.answers[a.PollID] == nil {
+		t.answers[a.PollID] = make(map[int64][]int)
+	}
+	t.answers[a.PollID][voter] = slices.Clone(a.OptionIDs)
+}
+
+// Counts returns votes per option ID for the poll.
+func (t *PollTally) Counts(pollID string) map[int]int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	counts := make(map[int]int)
+	for _, options := range t.answers[pollID] {
+		for _, id := range options {
+			counts[id]++
+		}
+	}
+	return counts
+}
+
+// Voters returns the number of voters with a current answer.
+func (t *PollTally) Voters(pollID string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.answers[pollID])
+}
+
+// Answer returns the voter's current option IDs, or nil.
+func (t *PollTally) Answer(pollID string, voterID int64) []int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return slices.Clone(t.answers[pollID][voterID])
+}
+
+// CorrectVoters returns the IDs of voters whose current answer to quiz p is
+// correct, in ascending order.
+func (t *PollTally) CorrectVoters(p *Poll) []int64 {
+	if !p.IsQuiz() {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var ids []int64
+	for voter, options := range t.answers[p.ID] {
+		if len(options) == 1 && options[0] == p.CorrectOptionID {
+			ids = append(ids, voter)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// Forget drops all answers for the poll, e.g. once it is closed and scored.
+func (t *PollTally) Forget(pollID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.answers, pollID)
+}
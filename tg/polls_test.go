@@ -0,0 +1,87 @@
+package tg_test
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestPoll_Unmarshal_Entities(t *testing.T) {
+	raw := `{
+		"id": "p1",
+		"question": "Best language?",
+		"question_entities": [{"type": "bold", "offset": 0, "length": 4}],
+		"options": [{"text": "Go", "text_entities": [{"type": "italic", "offset": 0, "length": 2}], "voter_count": 3}],
+		"total_voter_count": 3,
+		"type": "regular"
+	}`
+	var p tg.Poll
+	require.NoError(t, json.Unmarshal([]byte(raw), &p))
+	require.Len(t, p.QuestionEntities, 1)
+	require.Len(t, p.Options[0].TextEntities, 1)
+	assert.Equal(t, "italic", p.Options[0].TextEntities[0].Type)
+	assert.False(t, p.IsQuiz())
+}
+
+func TestPollAnswer_Helpers(t *testing.T) {
+	quiz := &tg.Poll{ID: "q", Type: tg.PollTypeQuiz, CorrectOptionID: 2}
+
+	byUser := &tg.PollAnswer{PollID: "q", User: &tg.User{ID: 7}, OptionIDs: []int{2}}
+	assert.Equal(t, int64(7), byUser.VoterID())
+	assert.True(t, byUser.IsCorrect(quiz))
+	assert.False(t, byUser.IsCorrect(&tg.Poll{Type: tg.PollTypeRegular}))
+
+	byChat := &tg.PollAnswer{PollID: "q", VoterChat: &tg.Chat{ID: -100}, OptionIDs: []int{0}}
+	assert.Equal(t, int64(-100), byChat.VoterID())
+	assert.False(t, byChat.IsCorrect(quiz))
+
+	retracted := &tg.PollAnswer{PollID: "q", User: &tg.User{ID: 7}}
+	assert.True(t, retracted.IsRetracted())
+
+	var nilAnswer *tg.PollAnswer
+	assert.Zero(t, nilAnswer.VoterID())
+	assert.False(t, nilAnswer.IsRetracted())
+}
+
+func TestPollTally(t *testing.T) {
+	quiz := &tg.Poll{ID: "q", Type: tg.PollTypeQuiz, CorrectOptionID: 1}
+	var tally tg.PollTally
+
+	tally.Record(&tg.PollAnswer{PollID: "q", User: &tg.User{ID: 1}, OptionIDs: []int{1}})
+	tally.Record(&tg.PollAnswer{PollID: "q", User: &tg.User{ID: 2}, OptionIDs: []int{0}})
+	tally.Record(&tg.PollAnswer{PollID: "q", User: &tg.User{ID: 3}, OptionIDs: []int{1}})
+	tally.Record(&tg.PollAnswer{PollID: "other", User: &tg.User{ID: 1}, OptionIDs: []int{0}})
+
+	assert.Equal(t, map[int]int{0: 1, 1: 2}, tally.Counts("q"))
+	assert.Equal(t, 3, tally.Voters("q"))
+	assert.Equal(t, []int64{1, 3}, tally.CorrectVoters(quiz))
+
+	// Changed vote replaces the previous one; retraction removes it.
+	tally.Record(&tg.PollAnswer{PollID: "q", User: &tg.User{ID: 2}, OptionIDs: []int{1}})
+	tally.Record(&tg.PollAnswer{PollID: "q", User: &tg.User{ID: 3}})
+	assert.Equal(t, map[int]int{1: 2}, tally.Counts("q"))
+	assert.Equal(t, []int{1}, tally.Answer("q", 2))
+	assert.Nil(t, tally.Answer("q", 3))
+	assert.Equal(t, []int64{1, 2}, tally.CorrectVoters(quiz))
+
+	tally.Forget("q")
+	assert.Zero(t, tally.Voters("q"))
+	assert.Equal(t, 1, tally.Voters("other"))
+}
+
+func TestPollTally_Concurrent(t *testing.T) {
+	var tally tg.PollTally
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Go(func() {
+			tally.Record(&tg.PollAnswer{PollID: "p", User: &tg.User{ID: int64(i + 1)}, OptionIDs: []int{i % 2}})
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, map[int]int{0: 25, 1: 25}, tally.Counts("p"))
+}
@@ -0,0 +1,132 @@
+package tg
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// MaxQuoteLength is the longest quote Telegram accepts, in characters.
+const MaxQuoteLength = 1024
+
+// quoteEntityTypes are the entity types Telegram keeps in quotes.
+var quoteEntityTypes = map[string]bool{
+	"bold":          true,
+	"italic":        true,
+	"underline":     true,
+	"strikethrough": true,
+	"spoiler":       true,
+	"custom_emoji":  true,
+}
+
+// QuoteReply returns reply parameters quoting the first occurrence of quote
+// in msg's text or caption. The quote position is set in UTF-16 code units,
+// as Telegram counts them, and the formatting of the quoted part is carried
+// over as QuoteEntities. It fails with ErrQuoteNotFound if quote is not part
+// of the message, which Telegram would reject with a 400.
+func QuoteReply(msg *Message, quote string) (*ReplyParameters, error) {
+	if quote == "" {
+		return nil, NewValidationError("quote", "required")
+	}
+	text, _ := quotableText(msg)
+	i := strings.Index(text, quote)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %q", ErrQuoteNotFound, quote)
+	}
+	return QuoteReplyAt(msg, UTF16Len(text[:i]), UTF16Len(quote))
+}
+
+// QuoteReplyAt returns reply parameters quoting length UTF-16 code units of
+// msg's text or caption starting at offset, e.g. the span of a
+// MessageEntity. See QuoteReply.
+func QuoteReplyAt(msg *Message, offset, length int) (*ReplyParameters, error) {
+	if msg == nil {
+		return nil, NewValidationError("message", "required")
+	}
+	text, entities := quotableText(msg)
+	quote, ok := utf16Slice(text, offset, length)
+	if !ok || length <= 0 {
+		return nil, fmt.Errorf("%w: range %d+%d outside the message text", ErrQuoteNotFound, offset, length)
+	}
+	if n := utf8.RuneCountInString(quote); n > MaxQuoteLength {
+		return nil, NewValidationError("quote", fmt.Sprintf("must be at most %d characters, got %d", MaxQuoteLength, n))
+	}
+
+	p := &ReplyParameters{
+		MessageID:     msg.MessageID,
+		Quote:         quote,
+		QuotePosition: offset,
+	}
+	end := offset + length
+	for _, e := range entities {
+		if !quoteEntityTypes[e.Type] || e.Offset >= end || e.Offset+e.Length <= offset {
+			continue
+		}
+		start, stop := max(e.Offset, offset), min(e.Offset+e.Length, end)
+		e.Offset, e.Length = start-offset, stop-start
+		p.QuoteEntities = append(p.QuoteEntities, e)
+	}
+	return p, nil
+}
+
+// WithQuoteParseMode makes Telegram parse Quote in mode: the quote is
+// escaped so that it still matches the message text, and QuoteEntities,
+// which cannot be combined with a parse mode, are dropped. Use it when
+// adding markup around the escaped quote by hand.
+func (p *ReplyParameters) WithQuoteParseMode(mode ParseMode) *ReplyParameters {
+	p.Quote = EscapeText(mode, p.Quote)
+	p.QuoteParseMode = string(mode)
+	p.QuoteEntities = nil
+	return p
+}
+
+// UTF16Len returns the length of s in UTF-16 code units, the unit of
+// Telegram's entity offsets and quote positions.
+func UTF16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// utf16Slice returns the part of s at UTF-16 offset with the given length.
+// ok is false if the range is out of bounds or splits a surrogate pair.
+func utf16Slice(s string, offset, length int) (string, bool) {
+	if offset < 0 || length < 0 {
+		return "", false
+	}
+	start, end := -1, -1
+	pos := 0
+	for i, r := range s {
+		if pos == offset {
+			start = i
+		}
+		if pos == offset+length {
+			end = i
+			break
+		}
+		pos += utf16.RuneLen(r)
+	}
+	if pos == offset && start < 0 {
+		start = len(s)
+	}
+	if end < 0 && pos == offset+length {
+		end = len(s)
+	}
+	if start < 0 || end < 0 {
+		return "", false
+	}
+	return s[start:end], true
+}
+
+func quotableText(msg *Message) (string, []MessageEntity) {
+	if msg == nil {
+		return "", nil
+	}
+	if msg.Text != "" {
+		return msg.Text, msg.Entities
+	}
+	return msg.Caption, msg.CaptionEntities
+}
@@ -0,0 +1,90 @@
+package tg_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestQuoteReply_UTF16Position(t *testing.T) {
+	// "😀" is two UTF-16 code units, "é" one.
+	msg := &tg.Message{MessageID: 9, Text: "😀 café au lait"}
+
+	p, err := tg.QuoteReply(msg, "au lait")
+	require.NoError(t, err)
+	assert.Equal(t, 9, p.MessageID)
+	assert.Equal(t, "au lait", p.Quote)
+	assert.Equal(t, 8, p.QuotePosition)
+}
+
+func TestQuoteReply_CarriesFormatting(t *testing.T) {
+	msg := &tg.Message{
+		MessageID: 1,
+		Text:      "hello bold world",
+		Entities: []tg.MessageEntity{
+			{Type: "bold", Offset: 6, Length: 4},
+			{Type: "url", Offset: 0, Length: 5}, // not allowed in quotes
+			{Type: "italic", Offset: 8, Length: 8},
+		},
+	}
+
+	p, err := tg.QuoteReply(msg, "bold wor")
+	require.NoError(t, err)
+	assert.Equal(t, []tg.MessageEntity{
+		{Type: "bold", Offset: 0, Length: 4},
+		{Type: "italic", Offset: 2, Length: 6},
+	}, p.QuoteEntities)
+}
+
+func TestQuoteReply_Caption(t *testing.T) {
+	p, err := tg.QuoteReply(&tg.Message{Caption: "a photo of a cat"}, "cat")
+	require.NoError(t, err)
+	assert.Equal(t, 13, p.QuotePosition)
+}
+
+func TestQuoteReply_NotFound(t *testing.T) {
+	msg := &tg.Message{Text: "the quick brown fox"}
+
+	_, err := tg.QuoteReply(msg, "lazy dog")
+	assert.ErrorIs(t, err, tg.ErrQuoteNotFound)
+
+	_, err = tg.QuoteReplyAt(msg, 15, 10)
+	assert.ErrorIs(t, err, tg.ErrQuoteNotFound)
+
+	_, err = tg.QuoteReplyAt(&tg.Message{Text: "😀x"}, 1, 2) // splits the surrogate pair
+	assert.ErrorIs(t, err, tg.ErrQuoteNotFound)
+}
+
+func TestQuoteReplyAt_EndOfText(t *testing.T) {
+	p, err := tg.QuoteReplyAt(&tg.Message{Text: "ab😀"}, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "😀", p.Quote)
+}
+
+func TestWithQuoteParseMode(t *testing.T) {
+	msg := &tg.Message{Text: "price: 5.00 (approx)", Entities: []tg.MessageEntity{{Type: "bold", Offset: 7, Length: 4}}}
+
+	p, err := tg.QuoteReply(msg, "5.00 (approx)")
+	require.NoError(t, err)
+	p.WithQuoteParseMode(tg.ParseModeMarkdownV2)
+
+	assert.Equal(t, `5\.00 \(approx\)`, p.Quote)
+	assert.Equal(t, "MarkdownV2", p.QuoteParseMode)
+	assert.Nil(t, p.QuoteEntities)
+}
+
+func TestEscapeText(t *testing.T) {
+	assert.Equal(t, "a &lt;b&gt; &amp; c", tg.EscapeText(tg.ParseModeHTML, "a <b> & c"))
+	assert.Equal(t, `1\+1\=2\!`, tg.EscapeText(tg.ParseModeMarkdownV2, "1+1=2!"))
+	assert.Equal(t, `\_x\_`, tg.EscapeText(tg.ParseModeMarkdown, "_x_"))
+	assert.Equal(t, "<b>", tg.EscapeText("", "<b>"))
+}
+
+func TestUTF16Len(t *testing.T) {
+	assert.Equal(t, 0, tg.UTF16Len(""))
+	assert.Equal(t, 3, tg.UTF16Len("abc"))
+	assert.Equal(t, 2, tg.UTF16Len("😀"))
+}
@@ -0,0 +1,105 @@
+package tg
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// UnmarshalUpdateWithRaw decodes an update and keeps the JSON it was decoded
+// from, available through Update.Raw. Messages carried by the update keep
+// their own JSON too (Message.Raw), so fields galigo does not model yet can
+// still be read:
+//
+//	var extra struct {
+//	    NewField string `json:"new_field"`
+//	}
+//	_ = json.Unmarshal(update.Message.Raw(), &extra)
+//
+// data is copied; the caller may reuse it.
+func UnmarshalUpdateWithRaw(data []byte) (Update, error) {
+	raw := json.RawMessage(bytes.Clone(data))
+
+	// One decoding pass: the message fields are shadowed by rawMessage,
+	// which keeps each message's slice of raw.
+	type plain Update
+	var u Update
+	aux := struct {
+		*plain
+		Message               *rawMessage `json:"message,omitempty"`
+		EditedMessage         *rawMessage `json:"edited_message,omitempty"`
+		ChannelPost           *rawMessage `json:"channel_post,omitempty"`
+		EditedChannelPost     *rawMessage `json:"edited_channel_post,omitempty"`
+		BusinessMessage       *rawMessage `json:"business_message,omitempty"`
+		EditedBusinessMessage *rawMessage `json:"edited_business_message,omitempty"`
+	}{plain: (*plain)(&u)}
+	if err := json.Unmarshal(raw, &aux); err != nil {
+		return Update{}, err
+	}
+	u.Message = aux.Message.message()
+	u.EditedMessage = aux.EditedMessage.message()
+	u.ChannelPost = aux.ChannelPost.message()
+	u.EditedChannelPost = aux.EditedChannelPost.message()
+	u.BusinessMessage = aux.BusinessMessage.message()
+	u.EditedBusinessMessage = aux.EditedBusinessMessage.message()
+	u.raw = &raw
+	return u, nil
+}
+
+// rawMessage decodes a Message and keeps its JSON. The JSON is a slice of
+// the input, which UnmarshalUpdateWithRaw owns, so it is not copied.
+type rawMessage struct{ m *Message }
+
+func (r *rawMessage) UnmarshalJSON(data []byte) error {
+	r.m = new(Message)
+	if err := json.Unmarshal(data, r.m); err != nil {
+		return err
+	}
+	r.m.raw = data
+	return nil
+}
+
+func (r *rawMessage) message() *Message {
+	if r == nil {
+		return nil
+	}
+	return r.m
+}
+
+// Raw returns the JSON the update was decoded from, or nil unless it was
+// decoded by UnmarshalUpdateWithRaw (receiver.Config.KeepRawUpdates).
+// The returned slice must not be modified.
+func (u *Update) Raw() json.RawMessage {
+	if u.raw == nil {
+		return nil
+	}
+	return *u.raw
+}
+
+// Raw returns the JSON the message was decoded from, or nil unless its
+// update was decoded by UnmarshalUpdateWithRaw.
+// The returned slice must not be modified.
+func (m *Message) Raw() json.RawMessage {
+	if m == nil {
+		return nil
+	}
+	return m.raw
+}
+
+// message returns the update's Message-typed payload, if any.
+func (u *Update) message() *Message {
+	switch {
+	case u.Message != nil:
+		return u.Message
+	case u.EditedMessage != nil:
+		return u.EditedMessage
+	case u.ChannelPost != nil:
+		return u.ChannelPost
+	case u.EditedChannelPost != nil:
+		return u.EditedChannelPost
+	case u.BusinessMessage != nil:
+		return u.BusinessMessage
+	case u.EditedBusinessMessage != nil:
+		return u.EditedBusinessMessage
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package tg_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestUnmarshalUpdateWithRaw_KeepsUnknownFields(t *testing.T) {
+	data := []byte(`{"update_id":7,"future_update":{"x":1},"message":{"message_id":3,"date":1,"chat":{"id":5,"type":"private"},"text":"hi","future_field":"v"}}`)
+
+	u, err := tg.UnmarshalUpdateWithRaw(data)
+	require.NoError(t, err)
+	assert.Equal(t, 7, u.UpdateID)
+	assert.JSONEq(t, string(data), string(u.Raw()))
+
+	require.NotNil(t, u.Message)
+	var extra struct {
+		FutureField string `json:"future_field"`
+	}
+	require.NoError(t, json.Unmarshal(u.Message.Raw(), &extra))
+	assert.Equal(t, "v", extra.FutureField)
+
+	// The input may be reused by the caller.
+	msgRaw := string(u.Message.Raw())
+	clear(data)
+	assert.True(t, json.Valid(u.Raw()))
+	assert.Equal(t, msgRaw, string(u.Message.Raw()))
+}
+
+func TestUnmarshalUpdateWithRaw_MessageRawIsItsJSON(t *testing.T) {
+	const msg = `{"message_id":3,"date":1,"chat":{"id":5,"type":"channel"}}`
+	u, err := tg.UnmarshalUpdateWithRaw([]byte(`{"update_id":7,"edited_channel_post":` + msg + `}`))
+	require.NoError(t, err)
+	require.NotNil(t, u.EditedChannelPost)
+	assert.Equal(t, msg, string(u.EditedChannelPost.Raw()))
+	assert.Equal(t, int64(5), u.EditedChannelPost.Chat.ID)
+}
+
+func TestUnmarshalUpdateWithRaw_UpdateComparable(t *testing.T) {
+	u, err := tg.UnmarshalUpdateWithRaw([]byte(`{"update_id":7}`))
+	require.NoError(t, err)
+	seen := map[tg.Update]bool{u: true} // Update must stay usable as a map key
+	assert.True(t, seen[u])
+}
+
+func TestUnmarshalUpdateWithRaw_NonMessageUpdate(t *testing.T) {
+	u, err := tg.UnmarshalUpdateWithRaw([]byte(`{"update_id":1,"poll_answer":{"poll_id":"p","option_ids":[0]}}`))
+	require.NoError(t, err)
+	assert.NotNil(t, u.Raw())
+	assert.NotNil(t, u.PollAnswer)
+}
+
+func TestUnmarshalUpdateWithRaw_Invalid(t *testing.T) {
+	_, err := tg.UnmarshalUpdateWithRaw([]byte(`{invalid`))
+	assert.Error(t, err)
+}
+
+func TestRaw_NilByDefault(t *testing.T) {
+	var u tg.Update
+	require.NoError(t, json.Unmarshal([]byte(`{"update_id":1,"message":{"message_id":1,"date":1,"chat":{"id":1,"type":"private"}}}`), &u))
+	assert.Nil(t, u.Raw())
+	assert.Nil(t, u.Message.Raw())
+
+	var m *tg.Message
+	assert.Nil(t, m.Raw())
+}
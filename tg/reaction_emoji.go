@@ -0,0 +1,126 @@
+package tg
+
+import "strings"
+
+// Reaction emoji that bots and users without Telegram Premium can set, as
+// listed for ReactionTypeEmoji. Telegram spells them without the U+FE0F
+// variation selector that keyboards often append ("❤" rather than "❤️").
+const (
+	ReactionHeart          = "❤"
+	ReactionThumbsUp       = "👍"
+	ReactionThumbsDown     = "👎"
+	ReactionFire           = "🔥"
+	ReactionSmilingHearts  = "🥰"
+	ReactionClap           = "👏"
+	ReactionGrin           = "😁"
+	ReactionThinking       = "🤔"
+	ReactionMindBlown      = "🤯"
+	ReactionScream         = "😱"
+	ReactionCursing        = "🤬"
+	ReactionCry            = "😢"
+	ReactionParty          = "🎉"
+	ReactionStarStruck     = "🤩"
+	ReactionVomit          = "🤮"
+	ReactionPoo            = "💩"
+	ReactionPray           = "🙏"
+	ReactionOK             = "👌"
+	ReactionDove           = "🕊"
+	ReactionClown          = "🤡"
+	ReactionYawn           = "🥱"
+	ReactionWoozy          = "🥴"
+	ReactionHeartEyes      = "😍"
+	ReactionWhale          = "🐳"
+	ReactionHeartOnFire    = "❤\u200d🔥"
+	ReactionNewMoonFace    = "🌚"
+	ReactionHotDog         = "🌭"
+	ReactionHundred        = "💯"
+	ReactionROFL           = "🤣"
+	ReactionLightning      = "⚡"
+	ReactionBanana         = "🍌"
+	ReactionTrophy         = "🏆"
+	ReactionBrokenHeart    = "💔"
+	ReactionRaisedEyebrow  = "🤨"
+	ReactionNeutral        = "😐"
+	ReactionStrawberry     = "🍓"
+	ReactionChampagne      = "🍾"
+	ReactionKiss           = "💋"
+	ReactionMiddleFinger   = "🖕"
+	ReactionDevil          = "😈"
+	ReactionSleeping       = "😴"
+	ReactionSob            = "😭"
+	ReactionNerd           = "🤓"
+	ReactionGhost          = "👻"
+	ReactionTechnologist   = "👨\u200d💻"
+	ReactionEyes           = "👀"
+	ReactionPumpkin        = "🎃"
+	ReactionSeeNoEvil      = "🙈"
+	ReactionHalo           = "😇"
+	ReactionFearful        = "😨"
+	ReactionHandshake      = "🤝"
+	ReactionWriting        = "✍"
+	ReactionHug            = "🤗"
+	ReactionSalute         = "🫡"
+	ReactionSanta          = "🎅"
+	ReactionChristmasTree  = "🎄"
+	ReactionSnowman        = "☃"
+	ReactionNailPolish     = "💅"
+	ReactionZany           = "🤪"
+	ReactionMoai           = "🗿"
+	ReactionCool           = "🆒"
+	ReactionCupid          = "💘"
+	ReactionHearNoEvil     = "🙉"
+	ReactionUnicorn        = "🦄"
+	ReactionBlowKiss       = "😘"
+	ReactionPill           = "💊"
+	ReactionSpeakNoEvil    = "🙊"
+	ReactionSunglasses     = "😎"
+	ReactionAlien          = "👾"
+	ReactionManShrugging   = "🤷\u200d♂"
+	ReactionShrugging      = "🤷"
+	ReactionWomanShrugging = "🤷\u200d♀"
+	ReactionAngry          = "😡"
+)
+
+// ReactionEmojis lists every standard reaction emoji, in Telegram's order.
+var ReactionEmojis = []string{
+	ReactionHeart, ReactionThumbsUp, ReactionThumbsDown, ReactionFire,
+	ReactionSmilingHearts, ReactionClap, ReactionGrin, ReactionThinking,
+	ReactionMindBlown, ReactionScream, ReactionCursing, ReactionCry,
+	ReactionParty, ReactionStarStruck, ReactionVomit, ReactionPoo, ReactionPray,
+	ReactionOK, ReactionDove, ReactionClown, ReactionYawn, ReactionWoozy,
+	ReactionHeartEyes, ReactionWhale, ReactionHeartOnFire, ReactionNewMoonFace,
+	ReactionHotDog, ReactionHundred, ReactionROFL, ReactionLightning,
+	ReactionBanana, ReactionTrophy, ReactionBrokenHeart, ReactionRaisedEyebrow,
+	ReactionNeutral, ReactionStrawberry, ReactionChampagne, ReactionKiss,
+	ReactionMiddleFinger, ReactionDevil, ReactionSleeping, ReactionSob,
+	ReactionNerd, ReactionGhost, ReactionTechnologist, ReactionEyes,
+	ReactionPumpkin, ReactionSeeNoEvil, ReactionHalo, ReactionFearful,
+	ReactionHandshake, ReactionWriting, ReactionHug, ReactionSalute,
+	ReactionSanta, ReactionChristmasTree, ReactionSnowman, ReactionNailPolish,
+	ReactionZany, ReactionMoai, ReactionCool, ReactionCupid, ReactionHearNoEvil,
+	ReactionUnicorn, ReactionBlowKiss, ReactionPill, ReactionSpeakNoEvil,
+	ReactionSunglasses, ReactionAlien, ReactionManShrugging, ReactionShrugging,
+	ReactionWomanShrugging, ReactionAngry,
+}
+
+var reactionEmojiSet = func() map[string]struct{} {
+	set := make(map[string]struct{}, len(ReactionEmojis))
+	for _, e := range ReactionEmojis {
+		set[e] = struct{}{}
+	}
+	return set
+}()
+
+// IsReactionEmoji reports whether emoji is one of ReactionEmojis, spelled
+// as Telegram spells it; see NormalizeReactionEmoji.
+func IsReactionEmoji(emoji string) bool {
+	_, ok := reactionEmojiSet[emoji]
+	return ok
+}
+
+// NormalizeReactionEmoji removes U+FE0F variation selectors, turning
+// emoji as typed on most keyboards into Telegram's spelling. EmojiReaction
+// applies it.
+func NormalizeReactionEmoji(emoji string) string {
+	return strings.ReplaceAll(emoji, "\uFE0F", "")
+}
@@ -0,0 +1,24 @@
+package tg_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestReactionEmojis(t *testing.T) {
+	assert.Len(t, tg.ReactionEmojis, 73)
+	assert.True(t, tg.IsReactionEmoji(tg.ReactionThumbsUp))
+	assert.True(t, tg.IsReactionEmoji("❤‍🔥"))
+	assert.False(t, tg.IsReactionEmoji("🦀"))
+	assert.False(t, tg.IsReactionEmoji("❤️"), "Telegram's spelling only")
+	assert.False(t, tg.IsReactionEmoji(""))
+}
+
+func TestNormalizeReactionEmoji(t *testing.T) {
+	assert.Equal(t, tg.ReactionHeart, tg.NormalizeReactionEmoji("❤️"))
+	assert.Equal(t, tg.ReactionHeart, tg.EmojiReaction("❤️").Emoji)
+	assert.Equal(t, tg.ReactionThumbsUp, tg.NormalizeReactionEmoji(tg.ReactionThumbsUp))
+}
@@ -0,0 +1,108 @@
+package tg
+
+import "slices"
+
+// Reaction types.
+const (
+	ReactionEmoji       = "emoji"
+	ReactionCustomEmoji = "custom_emoji"
+	ReactionPaid        = "paid"
+)
+
+// ReactionType describes a reaction. Type selects the variant:
+//   - ReactionEmoji: Emoji is set
+//   - ReactionCustomEmoji: CustomEmoji holds the custom emoji ID
+//   - ReactionPaid: no further fields
+//
+// Unknown future types are preserved in Type.
+type ReactionType struct {
+	Type        string `json:"type"`
+	Emoji       string `json:"emoji,omitempty"`
+	CustomEmoji string `json:"custom_emoji_id,omitempty"`
+}
+
+// EmojiReaction returns a reaction with a standard emoji, one of
+// ReactionEmojis. Variation selectors are removed, so "❤️" becomes
+// ReactionHeart.
+func EmojiReaction(emoji string) ReactionType {
+	return ReactionType{Type: ReactionEmoji, Emoji: NormalizeReactionEmoji(emoji)}
+}
+
+// CustomEmojiReaction returns a reaction with a custom emoji.
+func CustomEmojiReaction(customEmojiID string) ReactionType {
+	return ReactionType{Type: ReactionCustomEmoji, CustomEmoji: customEmojiID}
+}
+
+// PaidReaction returns the paid (Telegram Star) reaction.
+func PaidReaction() ReactionType {
+	return ReactionType{Type: ReactionPaid}
+}
+
+// IsEmoji reports whether r is a standard emoji reaction.
+func (r ReactionType) IsEmoji() bool { return r.Type == ReactionEmoji }
+
+// IsCustomEmoji reports whether r is a custom emoji reaction.
+func (r ReactionType) IsCustomEmoji() bool { return r.Type == ReactionCustomEmoji }
+
+// IsPaid reports whether r is the paid reaction.
+func (r ReactionType) IsPaid() bool { return r.Type == ReactionPaid }
+
+// ReactionCount represents a reaction added to a message along with the
+// number of times it was added.
+type ReactionCount struct {
+	Type       ReactionType `json:"type"`
+	TotalCount int          `json:"total_count"`
+}
+
+// MessageReactionUpdated represents a change of a reaction on a message
+// performed by a user. The bot must be an administrator in the chat and
+// request "message_reaction" in allowed_updates.
+type MessageReactionUpdated struct {
+	Chat        *Chat          `json:"chat"`
+	MessageID   int            `json:"message_id"`
+	User        *User          `json:"user,omitempty"`
+	ActorChat   *Chat          `json:"actor_chat,omitempty"` // Set for anonymous admins
+	Date        int64          `json:"date"`
+	OldReaction []ReactionType `json:"old_reaction"`
+	NewReaction []ReactionType `json:"new_reaction"`
+}
+
+// Added returns reactions present in NewReaction but not in OldReaction.
+func (u *MessageReactionUpdated) Added() []ReactionType {
+	return reactionDiff(u.NewReaction, u.OldReaction)
+}
+
+// Removed returns reactions present in OldReaction but not in NewReaction.
+func (u *MessageReactionUpdated) Removed() []ReactionType {
+	return reactionDiff(u.OldReaction, u.NewReaction)
+}
+
+// MessageReactionCountUpdated represents changes of anonymous reactions on a
+// message. Updates are grouped and can be sent with a delay of up to a few
+// minutes. The bot must request "message_reaction_count" in allowed_updates.
+type MessageReactionCountUpdated struct {
+	Chat      *Chat           `json:"chat"`
+	MessageID int             `json:"message_id"`
+	Date      int64           `json:"date"`
+	Reactions []ReactionCount `json:"reactions"`
+}
+
+// Count returns the total count for reaction r, or 0.
+func (u *MessageReactionCountUpdated) Count(r ReactionType) int {
+	for _, rc := range u.Reactions {
+		if rc.Type == r {
+			return rc.TotalCount
+		}
+	}
+	return 0
+}
+
+func reactionDiff(a, b []ReactionType) []ReactionType {
+	var out []ReactionType
+	for _, r := range a {
+		if !slices.Contains(b, r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
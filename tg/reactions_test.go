@@ -0,0 +1,79 @@
+package tg_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestReactionType_Constructors(t *testing.T) {
+	assert.True(t, tg.EmojiReaction("👍").IsEmoji())
+	assert.True(t, tg.CustomEmojiReaction("5368324170671202286").IsCustomEmoji())
+	assert.True(t, tg.PaidReaction().IsPaid())
+
+	data, err := json.Marshal(tg.PaidReaction())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"paid"}`, string(data))
+
+	data, err = json.Marshal(tg.CustomEmojiReaction("123"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"custom_emoji","custom_emoji_id":"123"}`, string(data))
+}
+
+func TestUpdate_MessageReaction(t *testing.T) {
+	raw := `{
+		"update_id": 10,
+		"message_reaction": {
+			"chat": {"id": -1001, "type": "supergroup"},
+			"message_id": 5,
+			"user": {"id": 42, "is_bot": false, "first_name": "A"},
+			"date": 1700000000,
+			"old_reaction": [{"type": "emoji", "emoji": "👍"}, {"type": "paid"}],
+			"new_reaction": [{"type": "paid"}, {"type": "custom_emoji", "custom_emoji_id": "99"}]
+		}
+	}`
+	var u tg.Update
+	require.NoError(t, json.Unmarshal([]byte(raw), &u))
+	assert.Equal(t, tg.UpdateTypeMessageReaction, u.Type())
+
+	r := u.MessageReaction
+	require.NotNil(t, r)
+	assert.Equal(t, int64(42), r.User.ID)
+	assert.Equal(t, []tg.ReactionType{tg.CustomEmojiReaction("99")}, r.Added())
+	assert.Equal(t, []tg.ReactionType{tg.EmojiReaction("👍")}, r.Removed())
+}
+
+func TestUpdate_MessageReactionCount(t *testing.T) {
+	raw := `{
+		"update_id": 11,
+		"message_reaction_count": {
+			"chat": {"id": -1001, "type": "channel"},
+			"message_id": 5,
+			"date": 1700000000,
+			"reactions": [
+				{"type": {"type": "emoji", "emoji": "🔥"}, "total_count": 7},
+				{"type": {"type": "paid"}, "total_count": 2}
+			]
+		}
+	}`
+	var u tg.Update
+	require.NoError(t, json.Unmarshal([]byte(raw), &u))
+	assert.Equal(t, tg.UpdateTypeMessageReactionCount, u.Type())
+
+	c := u.MessageReactionCount
+	require.NotNil(t, c)
+	assert.Equal(t, 7, c.Count(tg.EmojiReaction("🔥")))
+	assert.Equal(t, 2, c.Count(tg.PaidReaction()))
+	assert.Zero(t, c.Count(tg.EmojiReaction("👍")))
+}
+
+func TestAllUpdateTypes_IncludesOptIn(t *testing.T) {
+	all := tg.AllUpdateTypes()
+	assert.Contains(t, all, tg.UpdateTypeMessageReaction)
+	assert.Contains(t, all, tg.UpdateTypeMessageReactionCount)
+	assert.Contains(t, all, tg.UpdateTypeChatMember)
+}
@@ -0,0 +1,294 @@
+package tg
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripRoots are the structs from which TestRoundTrip_AllTypes reaches
+// every exported tg struct through fields: Update, union variants (only
+// reachable through an interface) and method results no update carries.
+var roundTripRoots = []reflect.Type{
+	reflect.TypeFor[Update](),
+	reflect.TypeFor[BackgroundFillFreeformGradient](),
+	reflect.TypeFor[BackgroundFillGradient](),
+	reflect.TypeFor[BackgroundFillSolid](),
+	reflect.TypeFor[BackgroundFillUnknown](),
+	reflect.TypeFor[BackgroundTypeChatTheme](),
+	reflect.TypeFor[BackgroundTypeFill](),
+	reflect.TypeFor[BackgroundTypePattern](),
+	reflect.TypeFor[BackgroundTypeUnknown](),
+	reflect.TypeFor[BackgroundTypeWallpaper](),
+	reflect.TypeFor[BotCommand](),
+	reflect.TypeFor[BotCommandScope](),
+	reflect.TypeFor[BotDescription](),
+	reflect.TypeFor[BotName](),
+	reflect.TypeFor[BotShortDescription](),
+	reflect.TypeFor[ChatBoostSourceGiftCode](),
+	reflect.TypeFor[ChatBoostSourceGiveaway](),
+	reflect.TypeFor[ChatBoostSourcePremium](),
+	reflect.TypeFor[ChatBoostSourceUnknown](),
+	reflect.TypeFor[ChatFullInfo](),
+	reflect.TypeFor[ChatMemberAdministrator](),
+	reflect.TypeFor[ChatMemberBanned](),
+	reflect.TypeFor[ChatMemberLeft](),
+	reflect.TypeFor[ChatMemberMember](),
+	reflect.TypeFor[ChatMemberOwner](),
+	reflect.TypeFor[ChatMemberRestricted](),
+	reflect.TypeFor[ForumTopic](),
+	reflect.TypeFor[ForumTopicClosed](),
+	reflect.TypeFor[ForumTopicCreated](),
+	reflect.TypeFor[ForumTopicEdited](),
+	reflect.TypeFor[ForumTopicReopened](),
+	reflect.TypeFor[GameHighScore](),
+	reflect.TypeFor[GeneralForumTopicHidden](),
+	reflect.TypeFor[GeneralForumTopicUnhidden](),
+	reflect.TypeFor[Gifts](),
+	reflect.TypeFor[InlineQueryResultArticle](),
+	reflect.TypeFor[InlineQueryResultDocument](),
+	reflect.TypeFor[InlineQueryResultPhoto](),
+	reflect.TypeFor[InlineQueryResultUnknown](),
+	reflect.TypeFor[InlineQueryResultsButton](),
+	reflect.TypeFor[InputChecklist](),
+	reflect.TypeFor[InputContactMessageContent](),
+	reflect.TypeFor[InputInvoiceMessageContent](),
+	reflect.TypeFor[InputLocationMessageContent](),
+	reflect.TypeFor[InputTextMessageContent](),
+	reflect.TypeFor[InputVenueMessageContent](),
+	reflect.TypeFor[MenuButton](),
+	reflect.TypeFor[MessageID](),
+	reflect.TypeFor[MessageOriginChannel](),
+	reflect.TypeFor[MessageOriginChat](),
+	reflect.TypeFor[MessageOriginHiddenUser](),
+	reflect.TypeFor[MessageOriginUnknown](),
+	reflect.TypeFor[MessageOriginUser](),
+	reflect.TypeFor[OwnedGifts](),
+	reflect.TypeFor[PassportElementErrorDataField](),
+	reflect.TypeFor[PassportElementErrorFile](),
+	reflect.TypeFor[PassportElementErrorFiles](),
+	reflect.TypeFor[PassportElementErrorFrontSide](),
+	reflect.TypeFor[PassportElementErrorReverseSide](),
+	reflect.TypeFor[PassportElementErrorSelfie](),
+	reflect.TypeFor[PassportElementErrorTranslationFile](),
+	reflect.TypeFor[PassportElementErrorTranslationFiles](),
+	reflect.TypeFor[PassportElementErrorUnspecified](),
+	reflect.TypeFor[PreparedInlineMessage](),
+	reflect.TypeFor[ReplyKeyboardMarkup](),
+	reflect.TypeFor[ReplyKeyboardRemove](),
+	reflect.TypeFor[ReplyParameters](),
+	reflect.TypeFor[ResponseParameters](),
+	reflect.TypeFor[RevenueWithdrawalStateFailed](),
+	reflect.TypeFor[RevenueWithdrawalStatePending](),
+	reflect.TypeFor[RevenueWithdrawalStateSucceeded](),
+	reflect.TypeFor[RevenueWithdrawalStateUnknown](),
+	reflect.TypeFor[SentWebAppMessage](),
+	reflect.TypeFor[ShippingOption](),
+	reflect.TypeFor[StarTransactions](),
+	reflect.TypeFor[StickerSet](),
+	reflect.TypeFor[StoredMessage](),
+	reflect.TypeFor[SuccessfulPayment](),
+	reflect.TypeFor[SuggestedPostParameters](),
+	reflect.TypeFor[TransactionPartnerAffiliateProgram](),
+	reflect.TypeFor[TransactionPartnerChat](),
+	reflect.TypeFor[TransactionPartnerFragment](),
+	reflect.TypeFor[TransactionPartnerOther](),
+	reflect.TypeFor[TransactionPartnerTelegramAPI](),
+	reflect.TypeFor[TransactionPartnerTelegramAds](),
+	reflect.TypeFor[TransactionPartnerUnknown](),
+	reflect.TypeFor[TransactionPartnerUser](),
+	reflect.TypeFor[UniqueGift](),
+	reflect.TypeFor[UserChatBoosts](),
+	reflect.TypeFor[UserProfileAudios](),
+	reflect.TypeFor[UserProfilePhotos](),
+}
+
+// roundTripUnions gives each decodable union interface a variant to fill
+// it with, discriminator set.
+var roundTripUnions = map[reflect.Type]any{
+	reflect.TypeFor[MessageOriginValue]():     MessageOriginUser{Type: MessageOriginTypeUser},
+	reflect.TypeFor[ChatBoostSource]():        ChatBoostSourcePremium{Source: "premium"},
+	reflect.TypeFor[ChatMember]():             ChatMemberMember{},
+	reflect.TypeFor[TransactionPartner]():     TransactionPartnerUser{Type: "user"},
+	reflect.TypeFor[RevenueWithdrawalState](): RevenueWithdrawalStateSucceeded{Type: "succeeded"},
+	reflect.TypeFor[BackgroundType]():         BackgroundTypeFill{Type: "fill"},
+	reflect.TypeFor[BackgroundFill]():         BackgroundFillSolid{Type: "solid"},
+}
+
+// roundTripSkip lists exported structs that are not Bot API objects.
+var roundTripSkip = map[string]bool{
+	"Album":           true,
+	"APIError":        true,
+	"Command":         true,
+	"Config":          true,
+	"ConfigError":     true,
+	"DecodeError":     true,
+	"Decoder":         true,
+	"InlineMessage":   true,
+	"Keyboard":        true,
+	"MarkdownIssue":   true,
+	"PollTally":       true,
+	"ReplyKeyboard":   true,
+	"RequestError":    true,
+	"ValidationError": true,
+}
+
+// TestRoundTrip_AllTypes fills every field of every exported tg struct,
+// then checks that a marshal/unmarshal round-trip keeps the value, so a
+// field with a missing or mistyped JSON tag is caught as soon as it is
+// added.
+func TestRoundTrip_AllTypes(t *testing.T) {
+	reached := make(map[string]reflect.Type)
+	for _, root := range roundTripRoots {
+		collectStructs(root, reached)
+	}
+	for _, name := range exportedStructs(t) {
+		if _, ok := reached[name]; !ok && !roundTripSkip[name] {
+			t.Errorf("%s is not reachable from roundTripRoots; add it there", name)
+		}
+	}
+
+	for name, typ := range reached {
+		if !token.IsExported(name) {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			if !reflect.PointerTo(typ).Implements(reflect.TypeFor[json.Marshaler]()) {
+				for i := range typ.NumField() {
+					if f := typ.Field(i); f.IsExported() && !f.Anonymous {
+						assert.NotEmpty(t, f.Tag.Get("json"), "%s has no json tag", f.Name)
+					}
+				}
+			}
+			first := reflect.New(typ)
+			fillValue(first.Elem(), map[reflect.Type]bool{})
+
+			data, err := json.Marshal(first.Interface())
+			require.NoError(t, err)
+			second := reflect.New(typ)
+			require.NoError(t, json.Unmarshal(data, second.Interface()))
+			assert.Equal(t, first.Interface(), second.Interface(), "JSON: %s", data)
+		})
+	}
+}
+
+var tgPkgPath = reflect.TypeFor[Update]().PkgPath()
+
+// collectStructs records the tg structs reachable from t by name.
+func collectStructs(t reflect.Type, seen map[string]reflect.Type) {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		collectStructs(t.Elem(), seen)
+	case reflect.Struct:
+		if t.PkgPath() != tgPkgPath || t.Name() == "" {
+			return
+		}
+		if _, ok := seen[t.Name()]; ok {
+			return
+		}
+		seen[t.Name()] = t
+		for i := range t.NumField() {
+			if f := t.Field(i); f.Tag.Get("json") != "-" {
+				collectStructs(f.Type, seen)
+			}
+		}
+	}
+}
+
+// fillValue sets every zero JSON-visible field of v to a non-zero value.
+// Interfaces get their roundTripUnions variant or stay nil, and a struct
+// already being filled further up (Message.ReplyToMessage) is left zero to
+// end the recursion.
+func fillValue(v reflect.Value, filling map[reflect.Type]bool) {
+	if v.Kind() != reflect.Struct && !v.IsZero() {
+		return
+	}
+	if v.Type() == reflect.TypeFor[json.RawMessage]() {
+		v.SetBytes([]byte(`{"x":7}`))
+		return
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("x")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(7)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(7)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	case reflect.Pointer:
+		if filling[v.Type().Elem()] {
+			return
+		}
+		p := reflect.New(v.Type().Elem())
+		fillValue(p.Elem(), filling)
+		v.Set(p)
+	case reflect.Slice:
+		if filling[v.Type().Elem()] {
+			return
+		}
+		s := reflect.MakeSlice(v.Type(), 1, 1)
+		fillValue(s.Index(0), filling)
+		v.Set(s)
+	case reflect.Interface:
+		sample, ok := roundTripUnions[v.Type()]
+		if !ok {
+			return
+		}
+		variant := reflect.New(reflect.TypeOf(sample)).Elem()
+		variant.Set(reflect.ValueOf(sample))
+		fillValue(variant, filling)
+		v.Set(variant)
+	case reflect.Struct:
+		typ := v.Type()
+		filling[typ] = true
+		defer delete(filling, typ)
+		for i := range typ.NumField() {
+			f := typ.Field(i)
+			if tag := f.Tag.Get("json"); tag == "-" || !f.IsExported() && !f.Anonymous {
+				continue
+			}
+			fillValue(v.Field(i), filling)
+		}
+	}
+}
+
+// exportedStructs lists the exported, non-generic struct types declared
+// in the package's non-test files.
+func exportedStructs(t *testing.T) []string {
+	t.Helper()
+	files, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+	fset := token.NewFileSet()
+	var names []string
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		require.NoError(t, err)
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if _, ok := ts.Type.(*ast.StructType); ok && ts.Name.IsExported() && ts.TypeParams == nil {
+					names = append(names, ts.Name.Name)
+				}
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,226 @@
+package tg
+
+// Reply button constructors
+
+// ReplyBtn creates a plain reply keyboard button; pressing it sends text.
+func ReplyBtn(text string) KeyboardButton {
+	return KeyboardButton{Text: text}
+}
+
+// ReplyBtnContact creates a button that shares the user's phone number
+// (private chats only).
+func ReplyBtnContact(text string) KeyboardButton {
+	return KeyboardButton{Text: text, RequestContact: true}
+}
+
+// ReplyBtnLocation creates a button that shares the user's location
+// (private chats only).
+func ReplyBtnLocation(text string) KeyboardButton {
+	return KeyboardButton{Text: text, RequestLocation: true}
+}
+
+// ReplyBtnPoll creates a button that asks the user to create a poll of
+// pollType ("quiz", "regular", or "" for any) and send it to the bot
+// (private chats only).
+func ReplyBtnPoll(text, pollType string) KeyboardButton {
+	return KeyboardButton{Text: text, RequestPoll: &KeyboardButtonPollType{Type: pollType}}
+}
+
+// ReplyBtnWebApp creates a button that opens a Web App (private chats
+// only).
+func ReplyBtnWebApp(text, url string) KeyboardButton {
+	return KeyboardButton{Text: text, WebApp: &WebAppInfo{URL: url}}
+}
+
+// WithStyle returns a copy of the button with the given style.
+func (b KeyboardButton) WithStyle(style string) KeyboardButton {
+	b.Style = style
+	return b
+}
+
+// WithIcon returns a copy of the button with a custom emoji icon.
+func (b KeyboardButton) WithIcon(customEmojiID string) KeyboardButton {
+	b.IconCustomEmojiID = customEmojiID
+	return b
+}
+
+// Request criteria builders
+
+// UsersRequest starts the criteria of a request_users button. Refine it
+// with the chained methods and pass it to RequestUsersButton.
+func UsersRequest(requestID int) KeyboardButtonRequestUsers {
+	return KeyboardButtonRequestUsers{RequestID: requestID}
+}
+
+// Bots restricts the request to bots (true) or to regular users (false).
+func (r KeyboardButtonRequestUsers) Bots(isBot bool) KeyboardButtonRequestUsers {
+	r.UserIsBot = &isBot
+	return r
+}
+
+// Premium restricts the request to users with (true) or without (false)
+// Telegram Premium.
+func (r KeyboardButtonRequestUsers) Premium(isPremium bool) KeyboardButtonRequestUsers {
+	r.UserIsPremium = &isPremium
+	return r
+}
+
+// Max lets the user pick up to n users (1-10).
+func (r KeyboardButtonRequestUsers) Max(n int) KeyboardButtonRequestUsers {
+	r.MaxQuantity = n
+	return r
+}
+
+// WithName requests the users' first and last names.
+func (r KeyboardButtonRequestUsers) WithName() KeyboardButtonRequestUsers {
+	r.RequestName = true
+	return r
+}
+
+// WithUsername requests the users' usernames.
+func (r KeyboardButtonRequestUsers) WithUsername() KeyboardButtonRequestUsers {
+	r.RequestUsername = true
+	return r
+}
+
+// WithPhoto requests the users' photos.
+func (r KeyboardButtonRequestUsers) WithPhoto() KeyboardButtonRequestUsers {
+	r.RequestPhoto = true
+	return r
+}
+
+// ChatRequest starts the criteria of a request_chat button for a channel
+// (true) or a group (false). Refine it with the chained methods and pass
+// it to RequestChatButton.
+func ChatRequest(requestID int, channel bool) KeyboardButtonRequestChat {
+	return KeyboardButtonRequestChat{RequestID: requestID, ChatIsChannel: channel}
+}
+
+// Forum restricts the request to forum (true) or non-forum (false)
+// supergroups.
+func (r KeyboardButtonRequestChat) Forum(isForum bool) KeyboardButtonRequestChat {
+	r.ChatIsForum = &isForum
+	return r
+}
+
+// Public restricts the request to chats with (true) or without (false) a
+// username.
+func (r KeyboardButtonRequestChat) Public(hasUsername bool) KeyboardButtonRequestChat {
+	r.ChatHasUsername = &hasUsername
+	return r
+}
+
+// OwnedByUser restricts the request to chats created by the user.
+func (r KeyboardButtonRequestChat) OwnedByUser() KeyboardButtonRequestChat {
+	r.ChatIsCreated = true
+	return r
+}
+
+// UserRights restricts the request to chats where the user has at least
+// rights.
+func (r KeyboardButtonRequestChat) UserRights(rights ChatAdministratorRights) KeyboardButtonRequestChat {
+	r.UserAdministratorRights = &rights
+	return r
+}
+
+// BotRights restricts the request to chats where the bot has, or will be
+// granted, at least rights.
+func (r KeyboardButtonRequestChat) BotRights(rights ChatAdministratorRights) KeyboardButtonRequestChat {
+	r.BotAdministratorRights = &rights
+	return r
+}
+
+// BotMember restricts the request to chats the bot is a member of.
+func (r KeyboardButtonRequestChat) BotMember() KeyboardButtonRequestChat {
+	r.BotIsMember = true
+	return r
+}
+
+// WithTitle requests the chat's title.
+func (r KeyboardButtonRequestChat) WithTitle() KeyboardButtonRequestChat {
+	r.RequestTitle = true
+	return r
+}
+
+// WithUsername requests the chat's username.
+func (r KeyboardButtonRequestChat) WithUsername() KeyboardButtonRequestChat {
+	r.RequestUsername = true
+	return r
+}
+
+// WithPhoto requests the chat's photo.
+func (r KeyboardButtonRequestChat) WithPhoto() KeyboardButtonRequestChat {
+	r.RequestPhoto = true
+	return r
+}
+
+// ReplyKeyboard builds reply keyboards fluently.
+type ReplyKeyboard struct {
+	markup ReplyKeyboardMarkup
+}
+
+// NewReplyKeyboard creates a new reply keyboard builder. Keyboards are
+// resized to fit their buttons; call FullSize to keep the default height.
+func NewReplyKeyboard() *ReplyKeyboard {
+	return &ReplyKeyboard{markup: ReplyKeyboardMarkup{
+		Keyboard:       make([][]KeyboardButton, 0, 4),
+		ResizeKeyboard: true,
+	}}
+}
+
+// Row adds a row of buttons.
+func (k *ReplyKeyboard) Row(buttons ...KeyboardButton) *ReplyKeyboard {
+	if len(buttons) > 0 {
+		k.markup.Keyboard = append(k.markup.Keyboard, buttons)
+	}
+	return k
+}
+
+// Add appends buttons to the last row, or creates a new row if empty.
+func (k *ReplyKeyboard) Add(buttons ...KeyboardButton) *ReplyKeyboard {
+	rows := k.markup.Keyboard
+	if len(rows) == 0 {
+		k.markup.Keyboard = append(rows, buttons)
+	} else {
+		rows[len(rows)-1] = append(rows[len(rows)-1], buttons...)
+	}
+	return k
+}
+
+// Persistent keeps the keyboard shown when the regular keyboard is hidden.
+func (k *ReplyKeyboard) Persistent() *ReplyKeyboard {
+	k.markup.IsPersistent = true
+	return k
+}
+
+// FullSize keeps the keyboard at the app's default height.
+func (k *ReplyKeyboard) FullSize() *ReplyKeyboard {
+	k.markup.ResizeKeyboard = false
+	return k
+}
+
+// OneTime hides the keyboard once a button is pressed.
+func (k *ReplyKeyboard) OneTime() *ReplyKeyboard {
+	k.markup.OneTimeKeyboard = true
+	return k
+}
+
+// Placeholder sets the text shown in the input field while the keyboard
+// is active (1-64 characters).
+func (k *ReplyKeyboard) Placeholder(text string) *ReplyKeyboard {
+	k.markup.InputFieldPlaceholder = text
+	return k
+}
+
+// Selective shows the keyboard only to users mentioned in the message
+// text and to the sender of the message replied to.
+func (k *ReplyKeyboard) Selective() *ReplyKeyboard {
+	k.markup.Selective = true
+	return k
+}
+
+// Build returns the completed ReplyKeyboardMarkup.
+func (k *ReplyKeyboard) Build() *ReplyKeyboardMarkup {
+	markup := k.markup
+	return &markup
+}
@@ -0,0 +1,59 @@
+package tg_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestReplyKeyboard_Build(t *testing.T) {
+	kb := tg.NewReplyKeyboard().
+		Row(tg.ReplyBtnContact("Phone"), tg.ReplyBtnLocation("Location")).
+		Row(tg.ReplyBtn("Cancel").WithStyle(tg.ButtonStyleDanger)).
+		Add(tg.ReplyBtnPoll("Quiz", "quiz")).
+		OneTime().
+		Persistent().
+		Placeholder("Choose").
+		Selective().
+		Build()
+
+	data, err := json.Marshal(kb)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"keyboard": [
+			[{"text":"Phone","request_contact":true},{"text":"Location","request_location":true}],
+			[{"text":"Cancel","style":"danger"},{"text":"Quiz","request_poll":{"type":"quiz"}}]
+		],
+		"is_persistent": true,
+		"resize_keyboard": true,
+		"one_time_keyboard": true,
+		"input_field_placeholder": "Choose",
+		"selective": true
+	}`, string(data))
+
+	assert.False(t, tg.NewReplyKeyboard().Row(tg.ReplyBtn("x")).FullSize().Build().ResizeKeyboard)
+}
+
+func TestRequestCriteriaBuilders(t *testing.T) {
+	users := tg.UsersRequest(1).Bots(false).Premium(true).Max(3).WithName().WithUsername().WithPhoto()
+	data, err := json.Marshal(tg.RequestUsersButton("Pick", users))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text":"Pick","request_users":{"request_id":1,"user_is_bot":false,"user_is_premium":true,
+		"max_quantity":3,"request_name":true,"request_username":true,"request_photo":true}}`, string(data))
+
+	chat := tg.ChatRequest(2, true).Public(true).OwnedByUser().BotMember().
+		BotRights(tg.FullAdminRights()).WithTitle()
+	assert.True(t, chat.ChatIsChannel)
+	assert.True(t, *chat.ChatHasUsername)
+	assert.True(t, chat.ChatIsCreated)
+	assert.True(t, chat.BotIsMember)
+	assert.True(t, *chat.BotAdministratorRights.CanPostMessages)
+	assert.True(t, chat.RequestTitle)
+	assert.Nil(t, chat.ChatIsForum)
+
+	assert.Equal(t, "https://example.com/app", tg.ReplyBtnWebApp("App", "https://example.com/app").WebApp.URL)
+}
@@ -0,0 +1,111 @@
+package tg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripUpdates covers every Update payload. The JSON is compact and
+// uses Telegram's field order so a lossless round-trip decodes to the same
+// value. It also seeds FuzzUpdateRoundTrip.
+var roundTripUpdates = []struct {
+	name string
+	json string
+	typ  string
+}{
+	{"message", `{"update_id":1,"message":{"message_id":10,"from":{"id":7,"is_bot":false,"first_name":"A"},"date":1700000000,"chat":{"id":7,"type":"private"},"text":"hi","entities":[{"type":"bold","offset":0,"length":2}]}}`, UpdateTypeMessage},
+	{"message_forwarded", `{"update_id":25,"message":{"message_id":11,"date":1700000000,"chat":{"id":7,"type":"private"},"forward_origin":{"type":"channel","date":1699990000,"chat":{"id":-1001,"type":"channel","title":"C"},"message_id":5},"text":"news"}}`, UpdateTypeMessage},
+	{"message_external_reply", `{"update_id":26,"message":{"message_id":12,"date":1700000000,"chat":{"id":7,"type":"private"},"text":"yes","external_reply":{"origin":{"type":"user","date":1699990000,"sender_user":{"id":8,"is_bot":false,"first_name":"B"}},"chat":{"id":-100,"type":"supergroup","title":"G"},"message_id":9},"quote":{"text":"q","position":0,"is_manual":true}}}`, UpdateTypeMessage},
+	{"message_story", `{"update_id":27,"message":{"message_id":13,"date":1700000000,"chat":{"id":7,"type":"private"},"forward_origin":{"type":"channel","date":1699990000,"chat":{"id":-1001,"type":"channel","title":"C"},"message_id":0},"story":{"chat":{"id":-1001,"type":"channel","title":"C"},"id":17}}}`, UpdateTypeMessage},
+	{"message_reply_to_story", `{"update_id":28,"message":{"message_id":14,"date":1700000000,"chat":{"id":7,"type":"private"},"reply_to_story":{"chat":{"id":7,"type":"private"},"id":3},"text":"wow"}}`, UpdateTypeMessage},
+	{"message_suggested_post", `{"update_id":29,"message":{"message_id":15,"direct_messages_topic":{"topic_id":77,"user":{"id":8,"is_bot":false,"first_name":"B"}},"from":{"id":8,"is_bot":false,"first_name":"B"},"date":1700000000,"chat":{"id":-1002,"type":"supergroup","title":"C","is_direct_messages":true},"suggested_post_info":{"state":"pending","price":{"currency":"XTR","amount":50},"send_date":1800000000},"text":"post me"}}`, UpdateTypeMessage},
+	{"message_suggested_post_approved", `{"update_id":30,"message":{"message_id":16,"direct_messages_topic":{"topic_id":77},"date":1700000000,"chat":{"id":-1002,"type":"supergroup","title":"C","is_direct_messages":true},"suggested_post_approved":{"suggested_post_message":{"message_id":15,"date":1700000000,"chat":{"id":-1002,"type":"supergroup","title":"C","is_direct_messages":true},"text":"post me"},"price":{"currency":"XTR","amount":50},"send_date":1800000000}}}`, UpdateTypeMessage},
+	{"edited_message", `{"update_id":2,"edited_message":{"message_id":10,"date":1700000000,"chat":{"id":7,"type":"private"},"edit_date":1700000100,"text":"hi!"}}`, UpdateTypeEditedMessage},
+	{"channel_post", `{"update_id":3,"channel_post":{"message_id":5,"date":1700000000,"chat":{"id":-1001,"type":"channel","title":"C"},"text":"news"}}`, UpdateTypeChannelPost},
+	{"edited_channel_post", `{"update_id":4,"edited_channel_post":{"message_id":5,"date":1700000000,"chat":{"id":-1001,"type":"channel","title":"C"},"text":"news!"}}`, UpdateTypeEditedChannelPost},
+	{"business_connection", `{"update_id":5,"business_connection":{"id":"bc1","user":{"id":7,"is_bot":false,"first_name":"A"},"user_chat_id":7,"date":1700000000,"is_enabled":true}}`, UpdateTypeBusinessConnection},
+	{"business_message", `{"update_id":6,"business_message":{"message_id":11,"date":1700000000,"chat":{"id":8,"type":"private"},"business_connection_id":"bc1","text":"hello"}}`, UpdateTypeBusinessMessage},
+	{"edited_business_message", `{"update_id":7,"edited_business_message":{"message_id":11,"date":1700000000,"chat":{"id":8,"type":"private"},"business_connection_id":"bc1","text":"hello!"}}`, UpdateTypeEditedBusinessMessage},
+	{"deleted_business_messages", `{"update_id":8,"deleted_business_messages":{"business_connection_id":"bc1","chat":{"id":8,"type":"private"},"message_ids":[11,12]}}`, UpdateTypeDeletedBusinessMessages},
+	{"message_reaction", `{"update_id":9,"message_reaction":{"chat":{"id":-100,"type":"supergroup"},"message_id":3,"user":{"id":7,"is_bot":false,"first_name":"A"},"date":1700000000,"old_reaction":[],"new_reaction":[{"type":"emoji","emoji":"👍"}]}}`, UpdateTypeMessageReaction},
+	{"message_reaction_count", `{"update_id":10,"message_reaction_count":{"chat":{"id":-100,"type":"supergroup"},"message_id":3,"date":1700000000,"reactions":[{"type":{"type":"emoji","emoji":"👍"},"total_count":2}]}}`, UpdateTypeMessageReactionCount},
+	{"inline_query", `{"update_id":11,"inline_query":{"id":"q1","from":{"id":7,"is_bot":false,"first_name":"A"},"query":"cats","offset":""}}`, UpdateTypeInlineQuery},
+	{"chosen_inline_result", `{"update_id":12,"chosen_inline_result":{"result_id":"r1","from":{"id":7,"is_bot":false,"first_name":"A"},"query":"cats"}}`, UpdateTypeChosenInlineResult},
+	{"callback_query", `{"update_id":13,"callback_query":{"id":"cb1","from":{"id":7,"is_bot":false,"first_name":"A"},"chat_instance":"ci","data":"yes"}}`, UpdateTypeCallbackQuery},
+	{"shipping_query", `{"update_id":14,"shipping_query":{"id":"sq1","from":{"id":7,"is_bot":false,"first_name":"A"},"invoice_payload":"p","shipping_address":{"country_code":"DE","state":"","city":"Berlin","street_line1":"A 1","street_line2":"","post_code":"10115"}}}`, UpdateTypeShippingQuery},
+	{"pre_checkout_query", `{"update_id":15,"pre_checkout_query":{"id":"pq1","from":{"id":7,"is_bot":false,"first_name":"A"},"currency":"XTR","total_amount":50,"invoice_payload":"p"}}`, UpdateTypePreCheckoutQuery},
+	{"purchased_paid_media", `{"update_id":16,"purchased_paid_media":{"from":{"id":7,"is_bot":false,"first_name":"A"},"paid_media_payload":"pm"}}`, UpdateTypePurchasedPaidMedia},
+	{"poll", `{"update_id":17,"poll":{"id":"p1","question":"Q?","options":[{"text":"a","voter_count":1},{"text":"b","voter_count":0}],"total_voter_count":1,"is_closed":false,"is_anonymous":false,"type":"quiz","allows_multiple_answers":false,"correct_option_id":0}}`, UpdateTypePoll},
+	{"poll_answer", `{"update_id":18,"poll_answer":{"poll_id":"p1","user":{"id":7,"is_bot":false,"first_name":"A"},"option_ids":[0]}}`, UpdateTypePollAnswer},
+	{"my_chat_member", `{"update_id":19,"my_chat_member":{"chat":{"id":-100,"type":"supergroup"},"from":{"id":7,"is_bot":false,"first_name":"A"},"date":1700000000,"old_chat_member":{"status":"left","user":{"id":99,"is_bot":true,"first_name":"Bot"}},"new_chat_member":{"status":"administrator","user":{"id":99,"is_bot":true,"first_name":"Bot"},"can_be_edited":false,"is_anonymous":false,"can_manage_chat":true,"can_delete_messages":true,"can_manage_video_chats":false,"can_restrict_members":true,"can_promote_members":false,"can_change_info":false,"can_invite_users":true}}}`, UpdateTypeMyChatMember},
+	{"chat_member", `{"update_id":20,"chat_member":{"chat":{"id":-100,"type":"supergroup"},"from":{"id":7,"is_bot":false,"first_name":"A"},"date":1700000000,"old_chat_member":{"status":"member","user":{"id":8,"is_bot":false,"first_name":"B"}},"new_chat_member":{"status":"kicked","user":{"id":8,"is_bot":false,"first_name":"B"},"until_date":0}}}`, UpdateTypeChatMember},
+	{"chat_join_request", `{"update_id":21,"chat_join_request":{"chat":{"id":-100,"type":"supergroup"},"from":{"id":8,"is_bot":false,"first_name":"B"},"user_chat_id":8,"date":1700000000}}`, UpdateTypeChatJoinRequest},
+	{"chat_boost", `{"update_id":22,"chat_boost":{"chat":{"id":-1001,"type":"channel","title":"C"},"boost":{"boost_id":"b1","add_date":1700000000,"expiration_date":1702592000,"source":{"source":"premium","user":{"id":7,"is_bot":false,"first_name":"A"}}}}}`, UpdateTypeChatBoost},
+	{"removed_chat_boost", `{"update_id":23,"removed_chat_boost":{"chat":{"id":-1001,"type":"channel","title":"C"},"boost_id":"b1","remove_date":1700000500,"source":{"source":"gift_code","user":{"id":7,"is_bot":false,"first_name":"A"}}}}`, UpdateTypeRemovedChatBoost},
+	{"chat_boost_unknown_source", `{"update_id":24,"chat_boost":{"chat":{"id":-1001,"type":"channel","title":"C"},"boost":{"boost_id":"b2","add_date":1,"expiration_date":2,"source":{"source":"future","extra":1}}}}`, UpdateTypeChatBoost},
+}
+
+func TestUpdate_RoundTrip(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, tc := range roundTripUpdates {
+		t.Run(tc.name, func(t *testing.T) {
+			var first Update
+			require.NoError(t, json.Unmarshal([]byte(tc.json), &first))
+			assert.Equal(t, tc.typ, first.Type())
+
+			data, err := json.Marshal(first)
+			require.NoError(t, err)
+
+			var second Update
+			require.NoError(t, json.Unmarshal(data, &second))
+			assert.Equal(t, first, second)
+		})
+		seen[tc.typ] = true
+	}
+
+	for _, typ := range AllUpdateTypes() {
+		assert.True(t, seen[typ], "no round-trip case for %s", typ)
+	}
+}
+
+func TestRoundTrip_PolymorphicTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		new  func() any
+	}{
+		{"star_transaction_user", `{"id":"tx1","amount":100,"date":1700000000,"source":{"type":"user","user":{"id":7,"is_bot":false,"first_name":"A"}}}`, func() any { return new(StarTransaction) }},
+		{"star_transaction_fragment", `{"id":"tx2","amount":5,"date":1700000000,"receiver":{"type":"fragment","withdrawal_state":{"type":"succeeded","date":1700000000,"url":"https://fragment.com/tx"}}}`, func() any { return new(StarTransaction) }},
+		{"star_transaction_unknown_partner", `{"id":"tx3","amount":1,"date":1700000000,"source":{"type":"future_partner","x":1}}`, func() any { return new(StarTransaction) }},
+		{"star_transaction_unknown_state", `{"id":"tx4","amount":1,"date":1700000000,"receiver":{"type":"fragment","withdrawal_state":{"type":"future_state"}}}`, func() any { return new(StarTransaction) }},
+		{"chat_boost_giveaway", `{"boost_id":"b3","add_date":1,"expiration_date":2,"source":{"source":"giveaway","giveaway_message_id":9,"prize_star_count":500,"is_unclaimed":true}}`, func() any { return new(ChatBoost) }},
+		{"chat_member_restricted", `{"chat":{"id":-100,"type":"supergroup"},"from":{"id":7,"is_bot":false,"first_name":"A"},"date":1700000000,"old_chat_member":{"status":"member","user":{"id":8,"is_bot":false,"first_name":"B"}},"new_chat_member":{"status":"restricted","user":{"id":8,"is_bot":false,"first_name":"B"},"is_member":true,"can_send_messages":false,"until_date":1700086400,"can_edit_tag":false}}`, func() any { return new(ChatMemberUpdated) }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			first := tc.new()
+			require.NoError(t, json.Unmarshal([]byte(tc.json), first))
+
+			data, err := json.Marshal(first)
+			require.NoError(t, err)
+
+			second := tc.new()
+			require.NoError(t, json.Unmarshal(data, second))
+			assert.Equal(t, first, second)
+		})
+	}
+}
+
+func TestMarshalChatMember_IncludesStatus(t *testing.T) {
+	data, err := MarshalChatMember(ChatMemberLeft{chatMemberBase{User: &User{ID: 8, FirstName: "B"}}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status":"left","user":{"id":8,"is_bot":false,"first_name":"B"}}`, string(data))
+
+	data, err = MarshalChatMember(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+}
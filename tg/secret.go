@@ -1,9 +1,14 @@
 package tg
 
-import "log/slog"
+import (
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+)
 
 // SecretToken wraps a bot token to prevent accidental logging.
-// Implements fmt.Stringer, fmt.GoStringer, slog.LogValuer, and encoding.TextMarshaler.
+// Implements fmt.Stringer, fmt.GoStringer, fmt.Formatter, slog.LogValuer,
+// and encoding.TextMarshaler.
 type SecretToken string
 
 // Value returns the actual token value.
@@ -16,6 +21,26 @@ func (s SecretToken) String() string { return "[REDACTED]" }
 // GoString returns redacted for %#v (fmt.GoStringer).
 func (s SecretToken) GoString() string { return `tg.SecretToken("[REDACTED]")` }
 
+// Format writes a redacted placeholder for %s, %q, %x and every other
+// verb but %v, which would otherwise format String's result
+// (fmt.Formatter). %#v writes GoString. %v panics instead: a token
+// formatted into a request ("%v" instead of Value) would silently send
+// the placeholder. fmt recovers the panic and writes
+// "%!v(PANIC=Format method: ...)" in place of the token, so the request or
+// log line fails visibly without leaking it. Only %p bypasses Format: fmt
+// reports it as a bad verb with the raw value before consulting any
+// method, so never format a token with %p.
+func (s SecretToken) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = f.Write([]byte(s.GoString()))
+		return
+	}
+	if verb == 'v' {
+		panic("galigo: SecretToken formatted with %v; use Value()")
+	}
+	_, _ = f.Write([]byte("[REDACTED]"))
+}
+
 // LogValue returns a redacted value for slog (slog.LogValuer).
 // This ensures the token is never logged even with %+v.
 func (s SecretToken) LogValue() slog.Value {
@@ -32,3 +57,25 @@ func (s SecretToken) MarshalText() ([]byte, error) {
 func (s SecretToken) IsEmpty() bool {
 	return s == ""
 }
+
+// ConstantTimeEquals reports whether other equals the token, taking time
+// independent of their contents, e.g. to check a webhook secret header.
+// Only the length can leak.
+func (s SecretToken) ConstantTimeEquals(other string) bool {
+	if len(other) != len(s) {
+		return false
+	}
+	var v byte
+	for i := range len(s) {
+		v |= s[i] ^ other[i]
+	}
+	return subtle.ConstantTimeByteEq(v, 0) == 1
+}
+
+// Zero drops the token held in s. Go strings are immutable and may be
+// shared with other strings (the bot ID is a substring of the token), so
+// the bytes are not overwritten; once every holder has called Zero the
+// garbage collector reclaims them.
+func (s *SecretToken) Zero() {
+	*s = ""
+}
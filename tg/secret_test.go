@@ -64,9 +64,8 @@ func TestSecretToken_NotLeakedInFmt(t *testing.T) {
 	// Test various fmt formats don't leak the token
 	formats := []string{
 		token.String(),
-		fmt.Sprintf("%v", token),
+		fmt.Sprintf("%s", token),
 		fmt.Sprintf("%#v", token),
-		fmt.Sprintf("%+v", token),
 	}
 
 	for _, formatted := range formats {
@@ -100,3 +99,51 @@ func TestSecretToken_NotLeakedInSlog(t *testing.T) {
 	assert.NotContains(t, output, "SECRET")
 	assert.Contains(t, output, "REDACTED")
 }
+
+func TestSecretToken_NotLeakedInAnyVerb(t *testing.T) {
+	token := tg.SecretToken("123456:ABC-DEF-SECRET")
+	type wrapped struct{ Token tg.SecretToken }
+
+	for _, format := range []string{"%s", "%q", "%x", "%X", "%d", "%10.3s", "%v", "%+v"} {
+		for _, formatted := range []string{fmt.Sprintf(format, token), fmt.Sprintf(format, wrapped{token})} {
+			assert.NotContains(t, formatted, "123456", format)
+			assert.NotContains(t, formatted, "SECRET", format)
+			assert.NotContains(t, formatted, "313233", format) // hex of "123"
+		}
+	}
+	assert.Equal(t, "[REDACTED]", fmt.Sprintf("%x", token))
+	assert.Equal(t, `tg.SecretToken("[REDACTED]")`, fmt.Sprintf("%#v", token))
+}
+
+func TestSecretToken_PanicsOnV(t *testing.T) {
+	token := tg.SecretToken("123456:SECRET")
+	url := fmt.Sprintf("https://api.telegram.org/bot%v/getMe", token)
+	assert.Contains(t, url, "PANIC=Format method: galigo: SecretToken formatted with %v; use Value()")
+	assert.NotContains(t, url, "SECRET")
+
+	type wrapped struct{ Token tg.SecretToken }
+	assert.NotContains(t, fmt.Sprintf("%+v", wrapped{token}), "SECRET")
+}
+
+func TestSecretToken_ConstantTimeEquals(t *testing.T) {
+	token := tg.SecretToken("s3cret-token")
+
+	assert.True(t, token.ConstantTimeEquals("s3cret-token"))
+	assert.False(t, token.ConstantTimeEquals("s3cret-tokeN"))
+	assert.False(t, token.ConstantTimeEquals("s3cret"))
+	assert.False(t, token.ConstantTimeEquals(""))
+	assert.True(t, tg.SecretToken("").ConstantTimeEquals(""))
+}
+
+func TestSecretToken_Zero(t *testing.T) {
+	token := tg.SecretToken("123456:ABC-DEF-SECRET")
+	botID, _, _ := strings.Cut(token.Value(), ":")
+
+	token.Zero()
+	assert.True(t, token.IsEmpty())
+	assert.Equal(t, "123456", botID, "substrings are left intact")
+
+	var empty tg.SecretToken
+	empty.Zero()
+	assert.True(t, empty.IsEmpty())
+}
@@ -0,0 +1,134 @@
+// Package sendopt provides the functional options shared by galigo's send,
+// edit, copy, forward and reply helpers.
+//
+// The same options work with the galigo.Bot facade and with sender.Client:
+//
+//	bot.SendMessage(ctx, chatID, "<b>hi</b>", sendopt.ParseMode(tg.ParseModeHTML))
+//	client.Edit(ctx, msg, "<b>bye</b>", sendopt.ParseMode(tg.ParseModeHTML), sendopt.Keyboard(kb))
+//
+// Each request applies the settings it supports and ignores the rest; a
+// keyboard is meaningless for forwardMessage, for example.
+package sendopt
+
+import "github.com/prilive-com/galigo/tg"
+
+// Options holds the settings collected from a list of Option.
+type Options struct {
+	ParseMode           tg.ParseMode
+	ReplyMarkup         any
+	Caption             string
+	LinkPreview         *tg.LinkPreviewOptions
+	DisableNotification bool
+	ProtectContent      bool
+	ReplyToMessageID    int
+	MessageThreadID     int
+
+	DirectMessagesTopicID int64
+	SuggestedPost         *tg.SuggestedPostParameters
+
+	// Deprecated: Use LinkPreview instead.
+	DisableWebPagePreview bool
+}
+
+// Option configures a send, edit, copy, forward or reply request.
+type Option func(*Options)
+
+// Apply collects opts into Options.
+func Apply(opts ...Option) Options {
+	var o Options
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// ParseMode sets the parse mode of the text or caption.
+func ParseMode(mode tg.ParseMode) Option {
+	return func(o *Options) {
+		o.ParseMode = mode
+	}
+}
+
+// Keyboard attaches an inline keyboard. A nil keyboard is ignored.
+func Keyboard(kb *tg.InlineKeyboardMarkup) Option {
+	return func(o *Options) {
+		if kb != nil {
+			o.ReplyMarkup = kb
+		}
+	}
+}
+
+// ReplyMarkup attaches any reply markup (inline keyboard, reply keyboard,
+// keyboard removal or force reply).
+func ReplyMarkup(markup any) Option {
+	return func(o *Options) {
+		o.ReplyMarkup = markup
+	}
+}
+
+// Caption sets the caption of a media message, or replaces it when copying.
+func Caption(caption string) Option {
+	return func(o *Options) {
+		o.Caption = caption
+	}
+}
+
+// LinkPreview sets the link preview options of a text message.
+func LinkPreview(lp *tg.LinkPreviewOptions) Option {
+	return func(o *Options) {
+		o.LinkPreview = lp
+	}
+}
+
+// NoLinkPreview disables the link preview of a text message.
+func NoLinkPreview() Option {
+	return LinkPreview(&tg.LinkPreviewOptions{IsDisabled: true})
+}
+
+// Silent sends the message without notification.
+func Silent() Option {
+	return func(o *Options) {
+		o.DisableNotification = true
+	}
+}
+
+// Protect protects the message from forwarding and saving.
+func Protect() Option {
+	return func(o *Options) {
+		o.ProtectContent = true
+	}
+}
+
+// ReplyTo makes the message a reply to messageID.
+func ReplyTo(messageID int) Option {
+	return func(o *Options) {
+		o.ReplyToMessageID = messageID
+	}
+}
+
+// Thread sends the message to a forum topic.
+func Thread(messageThreadID int) Option {
+	return func(o *Options) {
+		o.MessageThreadID = messageThreadID
+	}
+}
+
+// DirectMessagesTopic sends the message to a topic of a channel direct
+// messages chat.
+func DirectMessagesTopic(topicID int64) Option {
+	return func(o *Options) {
+		o.DirectMessagesTopicID = topicID
+	}
+}
+
+// SuggestPost sends the message as a suggested post for the channel of a
+// direct messages chat. A nil p is ignored.
+func SuggestPost(p *tg.SuggestedPostParameters) Option {
+	return func(o *Options) {
+		if p != nil {
+			o.SuggestedPost = p
+		}
+	}
+}
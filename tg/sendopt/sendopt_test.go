@@ -0,0 +1,52 @@
+package sendopt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/prilive-com/galigo/tg"
+	"github.com/prilive-com/galigo/tg/sendopt"
+)
+
+func TestApply(t *testing.T) {
+	kb := tg.NewKeyboard().Row(tg.Btn("OK", "ok")).Build()
+
+	o := sendopt.Apply(
+		sendopt.ParseMode(tg.ParseModeHTML),
+		sendopt.Keyboard(kb),
+		sendopt.Caption("caption"),
+		sendopt.NoLinkPreview(),
+		sendopt.Silent(),
+		sendopt.Protect(),
+		sendopt.ReplyTo(5),
+		sendopt.Thread(9),
+	)
+
+	assert.Equal(t, tg.ParseModeHTML, o.ParseMode)
+	assert.Same(t, kb, o.ReplyMarkup)
+	assert.Equal(t, "caption", o.Caption)
+	assert.True(t, o.LinkPreview.IsDisabled)
+	assert.True(t, o.DisableNotification)
+	assert.True(t, o.ProtectContent)
+	assert.Equal(t, 5, o.ReplyToMessageID)
+	assert.Equal(t, 9, o.MessageThreadID)
+}
+
+func TestApply_LastWins(t *testing.T) {
+	o := sendopt.Apply(
+		sendopt.ParseMode(tg.ParseModeHTML),
+		sendopt.ParseMode(tg.ParseModeMarkdownV2),
+	)
+	assert.Equal(t, tg.ParseModeMarkdownV2, o.ParseMode)
+}
+
+func TestApply_Empty(t *testing.T) {
+	assert.Equal(t, sendopt.Options{}, sendopt.Apply())
+	assert.Equal(t, sendopt.Options{}, sendopt.Apply(nil))
+}
+
+func TestKeyboard_NilIgnored(t *testing.T) {
+	o := sendopt.Apply(sendopt.Keyboard(nil))
+	assert.Nil(t, o.ReplyMarkup)
+}
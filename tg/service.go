@@ -0,0 +1,424 @@
+package tg
+
+import "encoding/json"
+
+// ================== Service Messages ==================
+//
+// A service message reports a chat event (members joining, a title
+// change, a video chat starting, ...) in one of Message's service fields
+// instead of content. Message.ServiceKind names the field that is set, so
+// handlers can route on it with a switch.
+
+// Service message kinds, as returned by Message.ServiceKind. Each is the
+// JSON name of the Message field that carries the event.
+const (
+	ServiceKindNewChatMembers                = "new_chat_members"
+	ServiceKindLeftChatMember                = "left_chat_member"
+	ServiceKindNewChatTitle                  = "new_chat_title"
+	ServiceKindNewChatPhoto                  = "new_chat_photo"
+	ServiceKindDeleteChatPhoto               = "delete_chat_photo"
+	ServiceKindGroupChatCreated              = "group_chat_created"
+	ServiceKindSupergroupChatCreated         = "supergroup_chat_created"
+	ServiceKindChannelChatCreated            = "channel_chat_created"
+	ServiceKindMessageAutoDeleteTimerChanged = "message_auto_delete_timer_changed"
+	ServiceKindUsersShared                   = "users_shared"
+	ServiceKindChatShared                    = "chat_shared"
+	ServiceKindWriteAccessAllowed            = "write_access_allowed"
+	ServiceKindProximityAlertTriggered       = "proximity_alert_triggered"
+	ServiceKindBoostAdded                    = "boost_added"
+	ServiceKindChatBackgroundSet             = "chat_background_set"
+	ServiceKindChecklistTasksDone            = "checklist_tasks_done"
+	ServiceKindChecklistTasksAdded           = "checklist_tasks_added"
+	ServiceKindGiveawayCreated               = "giveaway_created"
+	ServiceKindGiveawayCompleted             = "giveaway_completed"
+	ServiceKindVideoChatScheduled            = "video_chat_scheduled"
+	ServiceKindVideoChatStarted              = "video_chat_started"
+	ServiceKindVideoChatEnded                = "video_chat_ended"
+	ServiceKindVideoChatParticipantsInvited  = "video_chat_participants_invited"
+	ServiceKindWebAppData                    = "web_app_data"
+	ServiceKindSuggestedPostApproved         = "suggested_post_approved"
+	ServiceKindSuggestedPostApprovalFailed   = "suggested_post_approval_failed"
+	ServiceKindSuggestedPostDeclined         = "suggested_post_declined"
+	ServiceKindSuggestedPostPaid             = "suggested_post_paid"
+	ServiceKindSuggestedPostRefunded         = "suggested_post_refunded"
+	ServiceKindChatOwnerLeft                 = "chat_owner_left"
+	ServiceKindChatOwnerChanged              = "chat_owner_changed"
+)
+
+// ServiceKind returns the kind of service message m is, one of the
+// ServiceKind constants, or "" for a regular message.
+func (m *Message) ServiceKind() string {
+	switch {
+	case m == nil:
+		return ""
+	case len(m.NewChatMembers) > 0:
+		return ServiceKindNewChatMembers
+	case m.LeftChatMember != nil:
+		return ServiceKindLeftChatMember
+	case m.NewChatTitle != "":
+		return ServiceKindNewChatTitle
+	case len(m.NewChatPhoto) > 0:
+		return ServiceKindNewChatPhoto
+	case m.DeleteChatPhoto:
+		return ServiceKindDeleteChatPhoto
+	case m.GroupChatCreated:
+		return ServiceKindGroupChatCreated
+	case m.SupergroupChatCreated:
+		return ServiceKindSupergroupChatCreated
+	case m.ChannelChatCreated:
+		return ServiceKindChannelChatCreated
+	case m.MessageAutoDeleteTimerChanged != nil:
+		return ServiceKindMessageAutoDeleteTimerChanged
+	case m.UsersShared != nil:
+		return ServiceKindUsersShared
+	case m.ChatShared != nil:
+		return ServiceKindChatShared
+	case m.WriteAccessAllowed != nil:
+		return ServiceKindWriteAccessAllowed
+	case m.ProximityAlertTriggered != nil:
+		return ServiceKindProximityAlertTriggered
+	case m.BoostAdded != nil:
+		return ServiceKindBoostAdded
+	case m.ChatBackgroundSet != nil:
+		return ServiceKindChatBackgroundSet
+	case m.ChecklistTasksDone != nil:
+		return ServiceKindChecklistTasksDone
+	case m.ChecklistTasksAdded != nil:
+		return ServiceKindChecklistTasksAdded
+	case m.GiveawayCreated != nil:
+		return ServiceKindGiveawayCreated
+	case m.GiveawayCompleted != nil:
+		return ServiceKindGiveawayCompleted
+	case m.VideoChatScheduled != nil:
+		return ServiceKindVideoChatScheduled
+	case m.VideoChatStarted != nil:
+		return ServiceKindVideoChatStarted
+	case m.VideoChatEnded != nil:
+		return ServiceKindVideoChatEnded
+	case m.VideoChatParticipantsInvited != nil:
+		return ServiceKindVideoChatParticipantsInvited
+	case m.WebAppData != nil:
+		return ServiceKindWebAppData
+	case m.SuggestedPostApproved != nil:
+		return ServiceKindSuggestedPostApproved
+	case m.SuggestedPostApprovalFailed != nil:
+		return ServiceKindSuggestedPostApprovalFailed
+	case m.SuggestedPostDeclined != nil:
+		return ServiceKindSuggestedPostDeclined
+	case m.SuggestedPostPaid != nil:
+		return ServiceKindSuggestedPostPaid
+	case m.SuggestedPostRefunded != nil:
+		return ServiceKindSuggestedPostRefunded
+	case m.ChatOwnerLeft != nil:
+		return ServiceKindChatOwnerLeft
+	case m.ChatOwnerChanged != nil:
+		return ServiceKindChatOwnerChanged
+	}
+	return ""
+}
+
+// IsService reports whether m is a service message.
+func (m *Message) IsService() bool {
+	return m.ServiceKind() != ""
+}
+
+// MessageAutoDeleteTimerChanged is a service message: the auto-delete
+// timer of the chat changed.
+type MessageAutoDeleteTimerChanged struct {
+	MessageAutoDeleteTime int `json:"message_auto_delete_time"` // seconds, 0 = disabled
+}
+
+// WriteAccessAllowed is a service message: the user allowed the bot to
+// write messages, after adding it to the attachment or side menu,
+// launching a Web App from a link, or accepting a requestWriteAccess
+// request from a Web App.
+type WriteAccessAllowed struct {
+	FromRequest        bool   `json:"from_request,omitempty"`
+	WebAppName         string `json:"web_app_name,omitempty"`
+	FromAttachmentMenu bool   `json:"from_attachment_menu,omitempty"`
+}
+
+// ProximityAlertTriggered is a service message: a user sharing live
+// location came within Distance meters of another.
+type ProximityAlertTriggered struct {
+	Traveler User `json:"traveler"`
+	Watcher  User `json:"watcher"`
+	Distance int  `json:"distance"`
+}
+
+// ChatBoostAdded is a service message: a user boosted the chat.
+type ChatBoostAdded struct {
+	BoostCount int `json:"boost_count"`
+}
+
+// VideoChatScheduled is a service message: a video chat was scheduled.
+type VideoChatScheduled struct {
+	StartDate int64 `json:"start_date"`
+}
+
+// VideoChatStarted is a service message: a video chat started.
+type VideoChatStarted struct{}
+
+// VideoChatEnded is a service message: a video chat ended.
+type VideoChatEnded struct {
+	Duration int `json:"duration"` // seconds
+}
+
+// VideoChatParticipantsInvited is a service message: users were invited
+// to a video chat.
+type VideoChatParticipantsInvited struct {
+	Users []User `json:"users"`
+}
+
+// WebAppData is a service message carrying data sent by a Web App with
+// Telegram.WebApp.sendData.
+type WebAppData struct {
+	Data       string `json:"data"`
+	ButtonText string `json:"button_text"` // text of the keyboard button that opened the Web App
+}
+
+// ================== Chat Background ==================
+
+// ChatBackground is a service message: the chat background was set.
+type ChatBackground struct {
+	Type BackgroundType `json:"type"`
+}
+
+// UnmarshalJSON handles the polymorphic Type field.
+func (b *ChatBackground) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type json.RawMessage `json:"type"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if len(aux.Type) > 0 && string(aux.Type) != "null" {
+		b.Type = unmarshalBackgroundType(aux.Type)
+	}
+	return nil
+}
+
+// --- BackgroundType Union ---
+
+// BackgroundType describes the type of a chat background.
+type BackgroundType interface {
+	backgroundTypeTag()
+}
+
+// BackgroundTypeFill is a background filled with a color or gradient.
+type BackgroundTypeFill struct {
+	Type             string         `json:"type"` // Always "fill"
+	Fill             BackgroundFill `json:"fill"`
+	DarkThemeDimming int            `json:"dark_theme_dimming"` // percent, 0-100
+}
+
+func (BackgroundTypeFill) backgroundTypeTag() {}
+
+// UnmarshalJSON handles the nested polymorphic Fill.
+func (t *BackgroundTypeFill) UnmarshalJSON(data []byte) error {
+	type Alias BackgroundTypeFill
+	aux := &struct {
+		Fill json.RawMessage `json:"fill"`
+		*Alias
+	}{Alias: (*Alias)(t)}
+
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	if len(aux.Fill) > 0 && string(aux.Fill) != "null" {
+		t.Fill = unmarshalBackgroundFill(aux.Fill)
+	}
+	return nil
+}
+
+// BackgroundTypeWallpaper is a background made of a wallpaper image.
+type BackgroundTypeWallpaper struct {
+	Type             string   `json:"type"` // Always "wallpaper"
+	Document         Document `json:"document"`
+	DarkThemeDimming int      `json:"dark_theme_dimming"` // percent, 0-100
+	IsBlurred        bool     `json:"is_blurred,omitempty"`
+	IsMoving         bool     `json:"is_moving,omitempty"`
+}
+
+func (BackgroundTypeWallpaper) backgroundTypeTag() {}
+
+// BackgroundTypePattern is a PNG or TGV pattern combined with a fill.
+type BackgroundTypePattern struct {
+	Type       string         `json:"type"` // Always "pattern"
+	Document   Document       `json:"document"`
+	Fill       BackgroundFill `json:"fill"`
+	Intensity  int            `json:"intensity"` // percent, 0-100
+	IsInverted bool           `json:"is_inverted,omitempty"`
+	IsMoving   bool           `json:"is_moving,omitempty"`
+}
+
+func (BackgroundTypePattern) backgroundTypeTag() {}
+
+// UnmarshalJSON handles the nested polymorphic Fill.
+func (t *BackgroundTypePattern) UnmarshalJSON(data []byte) error {
+	type Alias BackgroundTypePattern
+	aux := &struct {
+		Fill json.RawMessage `json:"fill"`
+		*Alias
+	}{Alias: (*Alias)(t)}
+
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	if len(aux.Fill) > 0 && string(aux.Fill) != "null" {
+		t.Fill = unmarshalBackgroundFill(aux.Fill)
+	}
+	return nil
+}
+
+// BackgroundTypeChatTheme is a background taken from a chat theme.
+type BackgroundTypeChatTheme struct {
+	Type      string `json:"type"` // Always "chat_theme"
+	ThemeName string `json:"theme_name"`
+}
+
+func (BackgroundTypeChatTheme) backgroundTypeTag() {}
+
+// BackgroundTypeUnknown is a fallback for future background types.
+type BackgroundTypeUnknown struct {
+	Type string          `json:"type"`
+	Raw  json.RawMessage `json:"-"`
+}
+
+func (BackgroundTypeUnknown) backgroundTypeTag() {}
+
+// MarshalJSON emits the original payload so unknown types survive a
+// round-trip.
+func (u BackgroundTypeUnknown) MarshalJSON() ([]byte, error) {
+	if len(u.Raw) > 0 {
+		return u.Raw, nil
+	}
+	type alias BackgroundTypeUnknown
+	return json.Marshal(alias(u))
+}
+
+// unmarshalBackgroundType decodes a BackgroundType from JSON.
+// Returns BackgroundTypeUnknown on any error.
+func unmarshalBackgroundType(data json.RawMessage) BackgroundType {
+	var probe struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return BackgroundTypeUnknown{Raw: data}
+	}
+
+	switch probe.Type {
+	case "fill":
+		var t BackgroundTypeFill
+		if err := json.Unmarshal(data, &t); err != nil {
+			return BackgroundTypeUnknown{Type: probe.Type, Raw: data}
+		}
+		return t
+	case "wallpaper":
+		var t BackgroundTypeWallpaper
+		if err := json.Unmarshal(data, &t); err != nil {
+			return BackgroundTypeUnknown{Type: probe.Type, Raw: data}
+		}
+		return t
+	case "pattern":
+		var t BackgroundTypePattern
+		if err := json.Unmarshal(data, &t); err != nil {
+			return BackgroundTypeUnknown{Type: probe.Type, Raw: data}
+		}
+		return t
+	case "chat_theme":
+		var t BackgroundTypeChatTheme
+		if err := json.Unmarshal(data, &t); err != nil {
+			return BackgroundTypeUnknown{Type: probe.Type, Raw: data}
+		}
+		return t
+	default:
+		return BackgroundTypeUnknown{Type: probe.Type, Raw: data}
+	}
+}
+
+// --- BackgroundFill Union ---
+
+// BackgroundFill describes how a background is filled. Colors are RGB24.
+type BackgroundFill interface {
+	backgroundFillTag()
+}
+
+// BackgroundFillSolid fills the background with one color.
+type BackgroundFillSolid struct {
+	Type  string `json:"type"` // Always "solid"
+	Color int    `json:"color"`
+}
+
+func (BackgroundFillSolid) backgroundFillTag() {}
+
+// BackgroundFillGradient fills the background with a two-color gradient.
+type BackgroundFillGradient struct {
+	Type          string `json:"type"` // Always "gradient"
+	TopColor      int    `json:"top_color"`
+	BottomColor   int    `json:"bottom_color"`
+	RotationAngle int    `json:"rotation_angle"` // clockwise degrees, 0-359
+}
+
+func (BackgroundFillGradient) backgroundFillTag() {}
+
+// BackgroundFillFreeformGradient fills the background with a freeform
+// gradient that moves after every message.
+type BackgroundFillFreeformGradient struct {
+	Type   string `json:"type"`   // Always "freeform_gradient"
+	Colors []int  `json:"colors"` // 3 or 4 colors
+}
+
+func (BackgroundFillFreeformGradient) backgroundFillTag() {}
+
+// BackgroundFillUnknown is a fallback for future fill types.
+type BackgroundFillUnknown struct {
+	Type string          `json:"type"`
+	Raw  json.RawMessage `json:"-"`
+}
+
+func (BackgroundFillUnknown) backgroundFillTag() {}
+
+// MarshalJSON emits the original payload so unknown types survive a
+// round-trip.
+func (u BackgroundFillUnknown) MarshalJSON() ([]byte, error) {
+	if len(u.Raw) > 0 {
+		return u.Raw, nil
+	}
+	type alias BackgroundFillUnknown
+	return json.Marshal(alias(u))
+}
+
+// unmarshalBackgroundFill decodes a BackgroundFill from JSON.
+// Returns BackgroundFillUnknown on any error.
+func unmarshalBackgroundFill(data json.RawMessage) BackgroundFill {
+	var probe struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return BackgroundFillUnknown{Raw: data}
+	}
+
+	switch probe.Type {
+	case "solid":
+		var f BackgroundFillSolid
+		if err := json.Unmarshal(data, &f); err != nil {
+			return BackgroundFillUnknown{Type: probe.Type, Raw: data}
+		}
+		return f
+	case "gradient":
+		var f BackgroundFillGradient
+		if err := json.Unmarshal(data, &f); err != nil {
+			return BackgroundFillUnknown{Type: probe.Type, Raw: data}
+		}
+		return f
+	case "freeform_gradient":
+		var f BackgroundFillFreeformGradient
+		if err := json.Unmarshal(data, &f); err != nil {
+			return BackgroundFillUnknown{Type: probe.Type, Raw: data}
+		}
+		return f
+	default:
+		return BackgroundFillUnknown{Type: probe.Type, Raw: data}
+	}
+}
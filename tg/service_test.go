@@ -0,0 +1,134 @@
+package tg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_ServiceKind(t *testing.T) {
+	tests := []struct {
+		field string
+		json  string
+	}{
+		{ServiceKindNewChatMembers, `[{"id":8,"is_bot":false,"first_name":"B"}]`},
+		{ServiceKindNewChatTitle, `"New title"`},
+		{ServiceKindDeleteChatPhoto, `true`},
+		{ServiceKindMessageAutoDeleteTimerChanged, `{"message_auto_delete_time":86400}`},
+		{ServiceKindWriteAccessAllowed, `{"web_app_name":"shop"}`},
+		{ServiceKindProximityAlertTriggered, `{"traveler":{"id":1,"is_bot":false,"first_name":"T"},"watcher":{"id":2,"is_bot":false,"first_name":"W"},"distance":50}`},
+		{ServiceKindBoostAdded, `{"boost_count":4}`},
+		{ServiceKindChatBackgroundSet, `{"type":{"type":"chat_theme","theme_name":"🎄"}}`},
+		{ServiceKindVideoChatScheduled, `{"start_date":1700003600}`},
+		{ServiceKindVideoChatStarted, `{}`},
+		{ServiceKindVideoChatEnded, `{"duration":3600}`},
+		{ServiceKindVideoChatParticipantsInvited, `{"users":[{"id":8,"is_bot":false,"first_name":"B"}]}`},
+		{ServiceKindWebAppData, `{"data":"{\"order\":1}","button_text":"Order"}`},
+		{ServiceKindChatOwnerChanged, `{"new_owner":{"id":8,"is_bot":false,"first_name":"B"}}`},
+		{ServiceKindSuggestedPostApproved, `{"price":{"currency":"XTR","amount":50},"send_date":1800000000}`},
+		{ServiceKindSuggestedPostApprovalFailed, `{"price":{"currency":"TON","amount":10000000}}`},
+		{ServiceKindSuggestedPostDeclined, `{"comment":"off topic"}`},
+		{ServiceKindSuggestedPostPaid, `{"currency":"XTR","star_amount":{"amount":45}}`},
+		{ServiceKindSuggestedPostRefunded, `{"reason":"post_deleted"}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.field, func(t *testing.T) {
+			data := `{"message_id":1,"date":1700000000,"chat":{"id":-100,"type":"supergroup"},"` + tc.field + `":` + tc.json + `}`
+			var m Message
+			require.NoError(t, StrictDecoder().Decode([]byte(data), &m))
+			assert.Equal(t, tc.field, m.ServiceKind())
+			assert.True(t, m.IsService())
+
+			out, err := json.Marshal(&m)
+			require.NoError(t, err)
+			assert.JSONEq(t, data, string(out))
+		})
+	}
+}
+
+func TestMessage_ServiceKind_Regular(t *testing.T) {
+	assert.Empty(t, (&Message{Text: "hi"}).ServiceKind())
+	assert.False(t, (&Message{Text: "hi"}).IsService())
+	assert.Empty(t, (*Message)(nil).ServiceKind())
+}
+
+func TestMessage_ServiceFields(t *testing.T) {
+	var m Message
+	require.NoError(t, json.Unmarshal([]byte(`{"message_id":1,"date":1,"chat":{"id":7,"type":"private"},
+		"write_access_allowed":{"from_request":true,"web_app_name":"shop"},
+		"web_app_data":{"data":"payload","button_text":"Open"}}`), &m))
+	require.NotNil(t, m.WriteAccessAllowed)
+	assert.True(t, m.WriteAccessAllowed.FromRequest)
+	assert.Equal(t, "shop", m.WriteAccessAllowed.WebAppName)
+	require.NotNil(t, m.WebAppData)
+	assert.Equal(t, "payload", m.WebAppData.Data)
+}
+
+func TestChatBackground_Types(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want BackgroundType
+	}{
+		{
+			"fill solid",
+			`{"type":"fill","fill":{"type":"solid","color":16711680},"dark_theme_dimming":20}`,
+			BackgroundTypeFill{Type: "fill", Fill: BackgroundFillSolid{Type: "solid", Color: 0xFF0000}, DarkThemeDimming: 20},
+		},
+		{
+			"fill freeform",
+			`{"type":"fill","fill":{"type":"freeform_gradient","colors":[1,2,3]},"dark_theme_dimming":0}`,
+			BackgroundTypeFill{Type: "fill", Fill: BackgroundFillFreeformGradient{Type: "freeform_gradient", Colors: []int{1, 2, 3}}},
+		},
+		{
+			"wallpaper",
+			`{"type":"wallpaper","document":{"file_id":"d","file_unique_id":"u"},"dark_theme_dimming":50,"is_blurred":true}`,
+			BackgroundTypeWallpaper{Type: "wallpaper", Document: Document{FileID: "d", FileUniqueID: "u"}, DarkThemeDimming: 50, IsBlurred: true},
+		},
+		{
+			"pattern",
+			`{"type":"pattern","document":{"file_id":"d","file_unique_id":"u"},"fill":{"type":"gradient","top_color":1,"bottom_color":2,"rotation_angle":45},"intensity":60,"is_moving":true}`,
+			BackgroundTypePattern{
+				Type: "pattern", Document: Document{FileID: "d", FileUniqueID: "u"},
+				Fill:      BackgroundFillGradient{Type: "gradient", TopColor: 1, BottomColor: 2, RotationAngle: 45},
+				Intensity: 60, IsMoving: true,
+			},
+		},
+		{
+			"chat theme",
+			`{"type":"chat_theme","theme_name":"🎄"}`,
+			BackgroundTypeChatTheme{Type: "chat_theme", ThemeName: "🎄"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data := `{"type":` + tc.json + `}`
+			var bg ChatBackground
+			require.NoError(t, json.Unmarshal([]byte(data), &bg))
+			assert.Equal(t, tc.want, bg.Type)
+
+			out, err := json.Marshal(bg)
+			require.NoError(t, err)
+			assert.JSONEq(t, data, string(out))
+		})
+	}
+}
+
+func TestChatBackground_Unknown(t *testing.T) {
+	data := `{"type":{"type":"hologram","fill":{"type":"plasma","hue":3}}}`
+	var bg ChatBackground
+	require.NoError(t, json.Unmarshal([]byte(data), &bg))
+	u, ok := bg.Type.(BackgroundTypeUnknown)
+	require.True(t, ok)
+	assert.Equal(t, "hologram", u.Type)
+
+	out, err := json.Marshal(bg)
+	require.NoError(t, err)
+	assert.JSONEq(t, data, string(out))
+
+	var fill BackgroundTypeFill
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"fill","fill":{"type":"plasma"},"dark_theme_dimming":0}`), &fill))
+	assert.Equal(t, BackgroundFillUnknown{Type: "plasma", Raw: json.RawMessage(`{"type":"plasma"}`)}, fill.Fill)
+}
@@ -0,0 +1,56 @@
+package tg
+
+// UsersShared is a service message: the user shared users with the bot
+// through a KeyboardButtonRequestUsers button.
+type UsersShared struct {
+	RequestID int          `json:"request_id"`
+	Users     []SharedUser `json:"users"`
+}
+
+// SharedUser is a user shared with the bot. Name, username and photo are
+// only set if the button requested them.
+type SharedUser struct {
+	UserID    int64       `json:"user_id"`
+	FirstName string      `json:"first_name,omitempty"`
+	LastName  string      `json:"last_name,omitempty"`
+	Username  string      `json:"username,omitempty"`
+	Photo     []PhotoSize `json:"photo,omitempty"`
+}
+
+// ChatShared is a service message: the user shared a chat with the bot
+// through a KeyboardButtonRequestChat button.
+type ChatShared struct {
+	RequestID int         `json:"request_id"`
+	ChatID    int64       `json:"chat_id"`
+	Title     string      `json:"title,omitempty"`
+	Username  string      `json:"username,omitempty"`
+	Photo     []PhotoSize `json:"photo,omitempty"`
+}
+
+// RequestUsersButton returns a reply keyboard button that asks the user to
+// share users matching req. The request ID comes back in the resulting
+// Message.UsersShared; it must be unique within the message.
+func RequestUsersButton(text string, req KeyboardButtonRequestUsers) KeyboardButton {
+	return KeyboardButton{Text: text, RequestUsers: &req}
+}
+
+// RequestChatButton returns a reply keyboard button that asks the user to
+// share a chat matching req. The request ID comes back in the resulting
+// Message.ChatShared; it must be unique within the message.
+func RequestChatButton(text string, req KeyboardButtonRequestChat) KeyboardButton {
+	return KeyboardButton{Text: text, RequestChat: &req}
+}
+
+// SharedRequestID returns the request ID of a users_shared or chat_shared
+// service message, correlating it with the button that asked for it.
+func (m *Message) SharedRequestID() (int, bool) {
+	switch {
+	case m == nil:
+		return 0, false
+	case m.UsersShared != nil:
+		return m.UsersShared.RequestID, true
+	case m.ChatShared != nil:
+		return m.ChatShared.RequestID, true
+	}
+	return 0, false
+}
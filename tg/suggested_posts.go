@@ -0,0 +1,129 @@
+package tg
+
+import "fmt"
+
+// ================== Channel Direct Messages ==================
+//
+// A channel with direct messages enabled has a linked supergroup
+// (ChatFullInfo.IsDirectMessages, ParentChat) in which every user who
+// writes to the channel gets a topic. Messages there carry
+// DirectMessagesTopic; a bot answers in a topic by setting
+// direct_messages_topic_id on send methods. Users may also suggest posts
+// for the channel, which administrators approve or decline.
+// Added in Bot API 9.2.
+
+// Suggested post price currencies.
+const (
+	SuggestedPostCurrencyStars = "XTR" // amount in Telegram Stars
+	SuggestedPostCurrencyTON   = "TON" // amount in nanotoncoins
+)
+
+// Suggested post price limits, per currency.
+const (
+	MinSuggestedPostStars = 5
+	MaxSuggestedPostStars = 100_000
+	MinSuggestedPostTON   = 10_000_000         // 0.01 TON
+	MaxSuggestedPostTON   = 10_000_000_000_000 // 10000 TON
+)
+
+// Suggested post states, as in SuggestedPostInfo.State.
+const (
+	SuggestedPostStatePending  = "pending"
+	SuggestedPostStateApproved = "approved"
+	SuggestedPostStateDeclined = "declined"
+)
+
+// DirectMessagesTopic describes a topic of a channel direct messages chat.
+type DirectMessagesTopic struct {
+	TopicID int64 `json:"topic_id"`
+	User    *User `json:"user,omitempty"` // the user who created the topic
+}
+
+// SuggestedPostPrice is the price of a suggested post.
+type SuggestedPostPrice struct {
+	Currency string `json:"currency"` // SuggestedPostCurrencyStars or SuggestedPostCurrencyTON
+	Amount   int64  `json:"amount"`
+}
+
+// Validate checks the currency and that Amount is within its limits.
+func (p *SuggestedPostPrice) Validate() error {
+	switch p.Currency {
+	case SuggestedPostCurrencyStars:
+		if p.Amount < MinSuggestedPostStars || p.Amount > MaxSuggestedPostStars {
+			return NewValidationError("price.amount", fmt.Sprintf("must be %d-%d Stars", MinSuggestedPostStars, MaxSuggestedPostStars))
+		}
+	case SuggestedPostCurrencyTON:
+		if p.Amount < MinSuggestedPostTON || p.Amount > MaxSuggestedPostTON {
+			return NewValidationError("price.amount", fmt.Sprintf("must be %d-%d nanotoncoins", MinSuggestedPostTON, MaxSuggestedPostTON))
+		}
+	default:
+		return NewValidationError("price.currency", `must be "XTR" or "TON"`)
+	}
+	return nil
+}
+
+// SuggestedPostParameters are the price and publication time proposed for
+// a suggested post, set on a message sent to a direct messages chat.
+type SuggestedPostParameters struct {
+	Price    *SuggestedPostPrice `json:"price,omitempty"`     // nil = unpaid
+	SendDate int64               `json:"send_date,omitempty"` // Unix time, 5 min to 30 days ahead; 0 = chosen on approval
+}
+
+// Validate checks Price.
+func (p *SuggestedPostParameters) Validate() error {
+	if p == nil || p.Price == nil {
+		return nil
+	}
+	return p.Price.Validate()
+}
+
+// SuggestedPostInfo describes a suggested post in a direct messages chat.
+type SuggestedPostInfo struct {
+	State    string              `json:"state"` // SuggestedPostState*
+	Price    *SuggestedPostPrice `json:"price,omitempty"`
+	SendDate int64               `json:"send_date,omitempty"`
+}
+
+// SuggestedPostApproved is a service message: a suggested post was
+// approved.
+type SuggestedPostApproved struct {
+	SuggestedPostMessage *Message            `json:"suggested_post_message,omitempty"`
+	Price                *SuggestedPostPrice `json:"price,omitempty"`
+	SendDate             int64               `json:"send_date"`
+}
+
+// SuggestedPostApprovalFailed is a service message: approving a suggested
+// post failed because the user could not pay for it.
+type SuggestedPostApprovalFailed struct {
+	SuggestedPostMessage *Message           `json:"suggested_post_message,omitempty"`
+	Price                SuggestedPostPrice `json:"price"`
+}
+
+// SuggestedPostDeclined is a service message: a suggested post was
+// declined.
+type SuggestedPostDeclined struct {
+	SuggestedPostMessage *Message `json:"suggested_post_message,omitempty"`
+	Comment              string   `json:"comment,omitempty"`
+}
+
+// SuggestedPostPaid is a service message: payment for a suggested post
+// was received.
+type SuggestedPostPaid struct {
+	SuggestedPostMessage *Message    `json:"suggested_post_message,omitempty"`
+	Currency             string      `json:"currency"`
+	Amount               int64       `json:"amount,omitempty"`      // TON, in nanotoncoins
+	StarAmount           *StarAmount `json:"star_amount,omitempty"` // Stars
+}
+
+// Suggested post refund reasons, as in SuggestedPostRefunded.Reason.
+const (
+	SuggestedPostRefundPostDeleted     = "post_deleted"
+	SuggestedPostRefundPaymentRefunded = "payment_refunded"
+)
+
+// SuggestedPostRefunded is a service message: payment for a suggested
+// post was refunded.
+type SuggestedPostRefunded struct {
+	SuggestedPostMessage *Message `json:"suggested_post_message,omitempty"`
+	Reason               string   `json:"reason"`
+}
@@ -0,0 +1,44 @@
+package tg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestedPostPrice_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		price SuggestedPostPrice
+		field string // "" = valid
+	}{
+		{"stars min", SuggestedPostPrice{SuggestedPostCurrencyStars, MinSuggestedPostStars}, ""},
+		{"stars max", SuggestedPostPrice{SuggestedPostCurrencyStars, MaxSuggestedPostStars}, ""},
+		{"stars too low", SuggestedPostPrice{SuggestedPostCurrencyStars, MinSuggestedPostStars - 1}, "price.amount"},
+		{"stars too high", SuggestedPostPrice{SuggestedPostCurrencyStars, MaxSuggestedPostStars + 1}, "price.amount"},
+		{"ton min", SuggestedPostPrice{SuggestedPostCurrencyTON, MinSuggestedPostTON}, ""},
+		{"ton too low", SuggestedPostPrice{SuggestedPostCurrencyTON, MinSuggestedPostTON - 1}, "price.amount"},
+		{"ton too high", SuggestedPostPrice{SuggestedPostCurrencyTON, MaxSuggestedPostTON + 1}, "price.amount"},
+		{"unknown currency", SuggestedPostPrice{"USD", 100}, "price.currency"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.price.Validate()
+			if tc.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var verr *ValidationError
+			require.ErrorAs(t, err, &verr)
+			assert.Equal(t, tc.field, verr.Field)
+		})
+	}
+}
+
+func TestSuggestedPostParameters_Validate(t *testing.T) {
+	var nilParams *SuggestedPostParameters
+	assert.NoError(t, nilParams.Validate())
+	assert.NoError(t, (&SuggestedPostParameters{SendDate: 1800000000}).Validate())
+	assert.Error(t, (&SuggestedPostParameters{Price: &SuggestedPostPrice{Currency: SuggestedPostCurrencyStars}}).Validate())
+}
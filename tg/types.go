@@ -1,6 +1,7 @@
 package tg
 
 import (
+	"encoding/json"
 	"errors"
 	"strconv"
 )
@@ -20,51 +21,86 @@ type Editable interface {
 
 // Message represents a Telegram message.
 type Message struct {
-	MessageID             int                   `json:"message_id"`
-	MessageThreadID       int                   `json:"message_thread_id,omitempty"`
-	From                  *User                 `json:"from,omitempty"`
-	SenderChat            *Chat                 `json:"sender_chat,omitempty"`
-	Date                  int64                 `json:"date"`
-	Chat                  *Chat                 `json:"chat"`
-	ForwardFrom           *User                 `json:"forward_from,omitempty"`
-	ForwardFromChat       *Chat                 `json:"forward_from_chat,omitempty"`
-	ForwardDate           int64                 `json:"forward_date,omitempty"`
-	IsTopicMessage        bool                  `json:"is_topic_message,omitempty"`
-	IsAutomaticForward    bool                  `json:"is_automatic_forward,omitempty"`
-	ReplyToMessage        *Message              `json:"reply_to_message,omitempty"`
-	ViaBot                *User                 `json:"via_bot,omitempty"`
-	EditDate              int64                 `json:"edit_date,omitempty"`
-	HasProtectedContent   bool                  `json:"has_protected_content,omitempty"`
-	MediaGroupID          string                `json:"media_group_id,omitempty"`
-	AuthorSignature       string                `json:"author_signature,omitempty"`
-	Text                  string                `json:"text,omitempty"`
-	Entities              []MessageEntity       `json:"entities,omitempty"`
-	Caption               string                `json:"caption,omitempty"`
-	CaptionEntities       []MessageEntity       `json:"caption_entities,omitempty"`
-	Photo                 []PhotoSize           `json:"photo,omitempty"`
-	Document              *Document             `json:"document,omitempty"`
-	Animation             *Animation            `json:"animation,omitempty"`
-	Video                 *Video                `json:"video,omitempty"`
-	Audio                 *Audio                `json:"audio,omitempty"`
-	Voice                 *Voice                `json:"voice,omitempty"`
-	Sticker               *Sticker              `json:"sticker,omitempty"`
-	VideoNote             *VideoNote            `json:"video_note,omitempty"`
-	Contact               *Contact              `json:"contact,omitempty"`
-	Location              *Location             `json:"location,omitempty"`
-	Venue                 *Venue                `json:"venue,omitempty"`
-	Poll                  *Poll                 `json:"poll,omitempty"`
-	NewChatMembers        []User                `json:"new_chat_members,omitempty"`
-	LeftChatMember        *User                 `json:"left_chat_member,omitempty"`
-	NewChatTitle          string                `json:"new_chat_title,omitempty"`
-	NewChatPhoto          []PhotoSize           `json:"new_chat_photo,omitempty"`
-	DeleteChatPhoto       bool                  `json:"delete_chat_photo,omitempty"`
-	GroupChatCreated      bool                  `json:"group_chat_created,omitempty"`
-	SupergroupChatCreated bool                  `json:"supergroup_chat_created,omitempty"`
-	ChannelChatCreated    bool                  `json:"channel_chat_created,omitempty"`
-	ChatOwnerLeft         *ChatOwnerLeft        `json:"chat_owner_left,omitempty"`    // 9.4
-	ChatOwnerChanged      *ChatOwnerChanged     `json:"chat_owner_changed,omitempty"` // 9.4
-	SenderTag             string                `json:"sender_tag,omitempty"`         // 9.5
-	ReplyMarkup           *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	MessageID                     int                            `json:"message_id"`
+	MessageThreadID               int                            `json:"message_thread_id,omitempty"`
+	DirectMessagesTopic           *DirectMessagesTopic           `json:"direct_messages_topic,omitempty"` // 9.2
+	From                          *User                          `json:"from,omitempty"`
+	SenderChat                    *Chat                          `json:"sender_chat,omitempty"`
+	Date                          int64                          `json:"date"`
+	Chat                          *Chat                          `json:"chat"`
+	BusinessConnectionID          string                         `json:"business_connection_id,omitempty"`
+	ForwardOrigin                 *MessageOrigin                 `json:"forward_origin,omitempty"`
+	ForwardFrom                   *User                          `json:"forward_from,omitempty"`      // Deprecated: pre-7.0; use ForwardedFrom.
+	ForwardFromChat               *Chat                          `json:"forward_from_chat,omitempty"` // Deprecated: pre-7.0; use ForwardedFromChat.
+	ForwardDate                   int64                          `json:"forward_date,omitempty"`      // Deprecated: pre-7.0; use ForwardedDate.
+	IsTopicMessage                bool                           `json:"is_topic_message,omitempty"`
+	IsAutomaticForward            bool                           `json:"is_automatic_forward,omitempty"`
+	ReplyToMessage                *Message                       `json:"reply_to_message,omitempty"`
+	ExternalReply                 *ExternalReplyInfo             `json:"external_reply,omitempty"`
+	Quote                         *TextQuote                     `json:"quote,omitempty"`
+	ReplyToStory                  *Story                         `json:"reply_to_story,omitempty"`
+	ViaBot                        *User                          `json:"via_bot,omitempty"`
+	EditDate                      int64                          `json:"edit_date,omitempty"`
+	HasProtectedContent           bool                           `json:"has_protected_content,omitempty"`
+	MediaGroupID                  string                         `json:"media_group_id,omitempty"`
+	AuthorSignature               string                         `json:"author_signature,omitempty"`
+	IsPaidPost                    bool                           `json:"is_paid_post,omitempty"`        // 9.2
+	SuggestedPostInfo             *SuggestedPostInfo             `json:"suggested_post_info,omitempty"` // 9.2
+	Text                          string                         `json:"text,omitempty"`
+	Entities                      []MessageEntity                `json:"entities,omitempty"`
+	Caption                       string                         `json:"caption,omitempty"`
+	CaptionEntities               []MessageEntity                `json:"caption_entities,omitempty"`
+	Photo                         []PhotoSize                    `json:"photo,omitempty"`
+	Document                      *Document                      `json:"document,omitempty"`
+	Animation                     *Animation                     `json:"animation,omitempty"`
+	Video                         *Video                         `json:"video,omitempty"`
+	Audio                         *Audio                         `json:"audio,omitempty"`
+	Voice                         *Voice                         `json:"voice,omitempty"`
+	Sticker                       *Sticker                       `json:"sticker,omitempty"`
+	Story                         *Story                         `json:"story,omitempty"`
+	VideoNote                     *VideoNote                     `json:"video_note,omitempty"`
+	Contact                       *Contact                       `json:"contact,omitempty"`
+	Location                      *Location                      `json:"location,omitempty"`
+	Venue                         *Venue                         `json:"venue,omitempty"`
+	Poll                          *Poll                          `json:"poll,omitempty"`
+	Checklist                     *Checklist                     `json:"checklist,omitempty"`
+	Giveaway                      *Giveaway                      `json:"giveaway,omitempty"`
+	GiveawayWinners               *GiveawayWinners               `json:"giveaway_winners,omitempty"`
+	NewChatMembers                []User                         `json:"new_chat_members,omitempty"`
+	LeftChatMember                *User                          `json:"left_chat_member,omitempty"`
+	NewChatTitle                  string                         `json:"new_chat_title,omitempty"`
+	NewChatPhoto                  []PhotoSize                    `json:"new_chat_photo,omitempty"`
+	DeleteChatPhoto               bool                           `json:"delete_chat_photo,omitempty"`
+	GroupChatCreated              bool                           `json:"group_chat_created,omitempty"`
+	SupergroupChatCreated         bool                           `json:"supergroup_chat_created,omitempty"`
+	ChannelChatCreated            bool                           `json:"channel_chat_created,omitempty"`
+	UsersShared                   *UsersShared                   `json:"users_shared,omitempty"`
+	ChatShared                    *ChatShared                    `json:"chat_shared,omitempty"`
+	MessageAutoDeleteTimerChanged *MessageAutoDeleteTimerChanged `json:"message_auto_delete_timer_changed,omitempty"`
+	WriteAccessAllowed            *WriteAccessAllowed            `json:"write_access_allowed,omitempty"`
+	ProximityAlertTriggered       *ProximityAlertTriggered       `json:"proximity_alert_triggered,omitempty"`
+	BoostAdded                    *ChatBoostAdded                `json:"boost_added,omitempty"`
+	ChatBackgroundSet             *ChatBackground                `json:"chat_background_set,omitempty"`
+	ChecklistTasksDone            *ChecklistTasksDone            `json:"checklist_tasks_done,omitempty"`
+	ChecklistTasksAdded           *ChecklistTasksAdded           `json:"checklist_tasks_added,omitempty"`
+	GiveawayCreated               *GiveawayCreated               `json:"giveaway_created,omitempty"`
+	GiveawayCompleted             *GiveawayCompleted             `json:"giveaway_completed,omitempty"`
+	VideoChatScheduled            *VideoChatScheduled            `json:"video_chat_scheduled,omitempty"`
+	VideoChatStarted              *VideoChatStarted              `json:"video_chat_started,omitempty"`
+	VideoChatEnded                *VideoChatEnded                `json:"video_chat_ended,omitempty"`
+	VideoChatParticipantsInvited  *VideoChatParticipantsInvited  `json:"video_chat_participants_invited,omitempty"`
+	WebAppData                    *WebAppData                    `json:"web_app_data,omitempty"`
+	SuggestedPostApproved         *SuggestedPostApproved         `json:"suggested_post_approved,omitempty"`        // 9.2
+	SuggestedPostApprovalFailed   *SuggestedPostApprovalFailed   `json:"suggested_post_approval_failed,omitempty"` // 9.2
+	SuggestedPostDeclined         *SuggestedPostDeclined         `json:"suggested_post_declined,omitempty"`        // 9.2
+	SuggestedPostPaid             *SuggestedPostPaid             `json:"suggested_post_paid,omitempty"`            // 9.2
+	SuggestedPostRefunded         *SuggestedPostRefunded         `json:"suggested_post_refunded,omitempty"`        // 9.2
+	ChatOwnerLeft                 *ChatOwnerLeft                 `json:"chat_owner_left,omitempty"`                // 9.4
+	ChatOwnerChanged              *ChatOwnerChanged              `json:"chat_owner_changed,omitempty"`             // 9.4
+	SenderTag                     string                         `json:"sender_tag,omitempty"`                     // 9.5
+	ReplyMarkup                   *InlineKeyboardMarkup          `json:"reply_markup,omitempty"`
+
+	raw json.RawMessage // set by UnmarshalUpdateWithRaw
 }
 
 // MessageSig implements Editable.
@@ -97,15 +133,20 @@ type User struct {
 	AllowsUsersToCreateTopics bool   `json:"allows_users_to_create_topics,omitempty"` // 9.4
 }
 
-// Chat represents a Telegram chat.
+// Chat represents a Telegram chat as embedded in messages and updates.
+// getChat returns the complete ChatFullInfo instead.
 type Chat struct {
-	ID                                 int64      `json:"id"`
-	Type                               string     `json:"type"`
-	Title                              string     `json:"title,omitempty"`
-	Username                           string     `json:"username,omitempty"`
-	FirstName                          string     `json:"first_name,omitempty"`
-	LastName                           string     `json:"last_name,omitempty"`
-	IsForum                            bool       `json:"is_forum,omitempty"`
+	ID               int64  `json:"id"`
+	Type             string `json:"type"`
+	Title            string `json:"title,omitempty"`
+	Username         string `json:"username,omitempty"`
+	FirstName        string `json:"first_name,omitempty"`
+	LastName         string `json:"last_name,omitempty"`
+	IsForum          bool   `json:"is_forum,omitempty"`
+	IsDirectMessages bool   `json:"is_direct_messages,omitempty"` // 9.2
+
+	// The fields below predate ChatFullInfo (Bot API 7.3) and are no
+	// longer sent with Chat; read them from ChatFullInfo.
 	Photo                              *ChatPhoto `json:"photo,omitempty"`
 	ActiveUsernames                    []string   `json:"active_usernames,omitempty"`
 	Bio                                string     `json:"bio,omitempty"`
@@ -244,6 +285,7 @@ type Venue struct {
 type Poll struct {
 	ID                    string          `json:"id"`
 	Question              string          `json:"question"`
+	QuestionEntities      []MessageEntity `json:"question_entities,omitempty"`
 	Options               []PollOption    `json:"options"`
 	TotalVoterCount       int             `json:"total_voter_count"`
 	IsClosed              bool            `json:"is_closed"`
@@ -259,8 +301,9 @@ type Poll struct {
 
 // PollOption contains information about one answer option in a poll.
 type PollOption struct {
-	Text       string `json:"text"`
-	VoterCount int    `json:"voter_count"`
+	Text         string          `json:"text"`
+	TextEntities []MessageEntity `json:"text_entities,omitempty"`
+	VoterCount   int             `json:"voter_count"`
 }
 
 // MessageID represents a message identifier (returned by copyMessage).
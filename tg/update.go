@@ -7,21 +7,256 @@ import (
 
 // Update represents an incoming update from Telegram.
 type Update struct {
-	UpdateID           int                 `json:"update_id"`
-	Message            *Message            `json:"message,omitempty"`
-	EditedMessage      *Message            `json:"edited_message,omitempty"`
-	ChannelPost        *Message            `json:"channel_post,omitempty"`
-	EditedChannelPost  *Message            `json:"edited_channel_post,omitempty"`
-	CallbackQuery      *CallbackQuery      `json:"callback_query,omitempty"`
-	InlineQuery        *InlineQuery        `json:"inline_query,omitempty"`
-	ChosenInlineResult *ChosenInlineResult `json:"chosen_inline_result,omitempty"`
-	ShippingQuery      *ShippingQuery      `json:"shipping_query,omitempty"`
-	PreCheckoutQuery   *PreCheckoutQuery   `json:"pre_checkout_query,omitempty"`
-	Poll               *Poll               `json:"poll,omitempty"`
-	PollAnswer         *PollAnswer         `json:"poll_answer,omitempty"`
-	MyChatMember       *ChatMemberUpdated  `json:"my_chat_member,omitempty"`
-	ChatMember         *ChatMemberUpdated  `json:"chat_member,omitempty"`
-	ChatJoinRequest    *ChatJoinRequest    `json:"chat_join_request,omitempty"`
+	UpdateID                int                          `json:"update_id"`
+	Message                 *Message                     `json:"message,omitempty"`
+	EditedMessage           *Message                     `json:"edited_message,omitempty"`
+	ChannelPost             *Message                     `json:"channel_post,omitempty"`
+	EditedChannelPost       *Message                     `json:"edited_channel_post,omitempty"`
+	BusinessConnection      *BusinessConnection          `json:"business_connection,omitempty"`
+	BusinessMessage         *Message                     `json:"business_message,omitempty"`
+	EditedBusinessMessage   *Message                     `json:"edited_business_message,omitempty"`
+	DeletedBusinessMessages *BusinessMessagesDeleted     `json:"deleted_business_messages,omitempty"`
+	MessageReaction         *MessageReactionUpdated      `json:"message_reaction,omitempty"`
+	MessageReactionCount    *MessageReactionCountUpdated `json:"message_reaction_count,omitempty"`
+	InlineQuery             *InlineQuery                 `json:"inline_query,omitempty"`
+	ChosenInlineResult      *ChosenInlineResult          `json:"chosen_inline_result,omitempty"`
+	CallbackQuery           *CallbackQuery               `json:"callback_query,omitempty"`
+	ShippingQuery           *ShippingQuery               `json:"shipping_query,omitempty"`
+	PreCheckoutQuery        *PreCheckoutQuery            `json:"pre_checkout_query,omitempty"`
+	PurchasedPaidMedia      *PaidMediaPurchased          `json:"purchased_paid_media,omitempty"`
+	Poll                    *Poll                        `json:"poll,omitempty"`
+	PollAnswer              *PollAnswer                  `json:"poll_answer,omitempty"`
+	MyChatMember            *ChatMemberUpdated           `json:"my_chat_member,omitempty"`
+	ChatMember              *ChatMemberUpdated           `json:"chat_member,omitempty"`
+	ChatJoinRequest         *ChatJoinRequest             `json:"chat_join_request,omitempty"`
+	ChatBoost               *ChatBoostUpdated            `json:"chat_boost,omitempty"`
+	RemovedChatBoost        *ChatBoostRemoved            `json:"removed_chat_boost,omitempty"`
+
+	// Album is set on updates produced by album aggregation (see
+	// receiver.CollectAlbums); it is never sent by Telegram.
+	Album *Album `json:"-"`
+
+	// raw is set by UnmarshalUpdateWithRaw. It is a pointer so that
+	// Update stays comparable.
+	raw *json.RawMessage
+}
+
+// Update types, as used in allowed_updates and returned by Update.Type.
+const (
+	UpdateTypeMessage                 = "message"
+	UpdateTypeEditedMessage           = "edited_message"
+	UpdateTypeChannelPost             = "channel_post"
+	UpdateTypeEditedChannelPost       = "edited_channel_post"
+	UpdateTypeBusinessConnection      = "business_connection"
+	UpdateTypeBusinessMessage         = "business_message"
+	UpdateTypeEditedBusinessMessage   = "edited_business_message"
+	UpdateTypeDeletedBusinessMessages = "deleted_business_messages"
+	UpdateTypeMessageReaction         = "message_reaction"
+	UpdateTypeMessageReactionCount    = "message_reaction_count"
+	UpdateTypeInlineQuery             = "inline_query"
+	UpdateTypeChosenInlineResult      = "chosen_inline_result"
+	UpdateTypeCallbackQuery           = "callback_query"
+	UpdateTypeShippingQuery           = "shipping_query"
+	UpdateTypePreCheckoutQuery        = "pre_checkout_query"
+	UpdateTypePurchasedPaidMedia      = "purchased_paid_media"
+	UpdateTypePoll                    = "poll"
+	UpdateTypePollAnswer              = "poll_answer"
+	UpdateTypeMyChatMember            = "my_chat_member"
+	UpdateTypeChatMember              = "chat_member"
+	UpdateTypeChatJoinRequest         = "chat_join_request"
+	UpdateTypeChatBoost               = "chat_boost"
+	UpdateTypeRemovedChatBoost        = "removed_chat_boost"
+)
+
+// AllUpdateTypes returns every update type galigo models. Telegram's default
+// (an empty allowed_updates) omits chat_member, message_reaction and
+// message_reaction_count, so pass this list to receive them too.
+func AllUpdateTypes() []string {
+	return []string{
+		UpdateTypeMessage,
+		UpdateTypeEditedMessage,
+		UpdateTypeChannelPost,
+		UpdateTypeEditedChannelPost,
+		UpdateTypeBusinessConnection,
+		UpdateTypeBusinessMessage,
+		UpdateTypeEditedBusinessMessage,
+		UpdateTypeDeletedBusinessMessages,
+		UpdateTypeMessageReaction,
+		UpdateTypeMessageReactionCount,
+		UpdateTypeInlineQuery,
+		UpdateTypeChosenInlineResult,
+		UpdateTypeCallbackQuery,
+		UpdateTypeShippingQuery,
+		UpdateTypePreCheckoutQuery,
+		UpdateTypePurchasedPaidMedia,
+		UpdateTypePoll,
+		UpdateTypePollAnswer,
+		UpdateTypeMyChatMember,
+		UpdateTypeChatMember,
+		UpdateTypeChatJoinRequest,
+		UpdateTypeChatBoost,
+		UpdateTypeRemovedChatBoost,
+	}
+}
+
+// Type returns the update's type (one of the UpdateType constants), or ""
+// if it carries no payload galigo knows about. Use it to route updates:
+//
+//	switch u.Type() {
+//	case tg.UpdateTypePollAnswer:
+//	    tally.Record(u.PollAnswer)
+//	}
+func (u *Update) Type() string {
+	switch {
+	case u.Message != nil:
+		return UpdateTypeMessage
+	case u.EditedMessage != nil:
+		return UpdateTypeEditedMessage
+	case u.ChannelPost != nil:
+		return UpdateTypeChannelPost
+	case u.EditedChannelPost != nil:
+		return UpdateTypeEditedChannelPost
+	case u.BusinessConnection != nil:
+		return UpdateTypeBusinessConnection
+	case u.BusinessMessage != nil:
+		return UpdateTypeBusinessMessage
+	case u.EditedBusinessMessage != nil:
+		return UpdateTypeEditedBusinessMessage
+	case u.DeletedBusinessMessages != nil:
+		return UpdateTypeDeletedBusinessMessages
+	case u.MessageReaction != nil:
+		return UpdateTypeMessageReaction
+	case u.MessageReactionCount != nil:
+		return UpdateTypeMessageReactionCount
+	case u.InlineQuery != nil:
+		return UpdateTypeInlineQuery
+	case u.ChosenInlineResult != nil:
+		return UpdateTypeChosenInlineResult
+	case u.CallbackQuery != nil:
+		return UpdateTypeCallbackQuery
+	case u.ShippingQuery != nil:
+		return UpdateTypeShippingQuery
+	case u.PreCheckoutQuery != nil:
+		return UpdateTypePreCheckoutQuery
+	case u.PurchasedPaidMedia != nil:
+		return UpdateTypePurchasedPaidMedia
+	case u.Poll != nil:
+		return UpdateTypePoll
+	case u.PollAnswer != nil:
+		return UpdateTypePollAnswer
+	case u.MyChatMember != nil:
+		return UpdateTypeMyChatMember
+	case u.ChatMember != nil:
+		return UpdateTypeChatMember
+	case u.ChatJoinRequest != nil:
+		return UpdateTypeChatJoinRequest
+	case u.ChatBoost != nil:
+		return UpdateTypeChatBoost
+	case u.RemovedChatBoost != nil:
+		return UpdateTypeRemovedChatBoost
+	}
+	return ""
+}
+
+// Date returns the Unix time of the event the update reports (the edit
+// time for edited messages), or 0 for update types that carry no date,
+// such as callback and inline queries.
+func (u *Update) Date() int64 {
+	if m := u.message(); m != nil {
+		if m.EditDate != 0 {
+			return m.EditDate
+		}
+		return m.Date
+	}
+	switch {
+	case u.MessageReaction != nil:
+		return u.MessageReaction.Date
+	case u.MessageReactionCount != nil:
+		return u.MessageReactionCount.Date
+	case u.MyChatMember != nil:
+		return u.MyChatMember.Date
+	case u.ChatMember != nil:
+		return u.ChatMember.Date
+	case u.ChatJoinRequest != nil:
+		return u.ChatJoinRequest.Date
+	}
+	return 0
+}
+
+// EffectiveMessage returns the message update is about: its message,
+// channel post or business message (edited or not), or the message the
+// button of a callback query is attached to. It returns nil for other
+// update types.
+func (u *Update) EffectiveMessage() *Message {
+	if m := u.message(); m != nil {
+		return m
+	}
+	if u.CallbackQuery != nil {
+		return u.CallbackQuery.Message
+	}
+	return nil
+}
+
+// EffectiveChat returns the chat update happened in, or nil for update
+// types outside a chat, such as inline queries and poll answers.
+func (u *Update) EffectiveChat() *Chat {
+	if m := u.EffectiveMessage(); m != nil {
+		return m.Chat
+	}
+	if m := u.MemberUpdate(); m != nil {
+		return m.Chat
+	}
+	switch {
+	case u.ChatJoinRequest != nil:
+		return u.ChatJoinRequest.Chat
+	case u.MessageReaction != nil:
+		return u.MessageReaction.Chat
+	case u.MessageReactionCount != nil:
+		return u.MessageReactionCount.Chat
+	case u.ChatBoost != nil:
+		return &u.ChatBoost.Chat
+	case u.RemovedChatBoost != nil:
+		return &u.RemovedChatBoost.Chat
+	case u.DeletedBusinessMessages != nil:
+		return &u.DeletedBusinessMessages.Chat
+	}
+	return nil
+}
+
+// EffectiveUser returns the user who caused update: the sender of a
+// message, the user who pressed a button, asked an inline query, paid,
+// voted, reacted or changed a membership. It returns nil for anonymous
+// senders and update types without a user, such as polls.
+func (u *Update) EffectiveUser() *User {
+	switch {
+	case u.CallbackQuery != nil:
+		return u.CallbackQuery.From
+	case u.InlineQuery != nil:
+		return u.InlineQuery.From
+	case u.ChosenInlineResult != nil:
+		return u.ChosenInlineResult.From
+	case u.ShippingQuery != nil:
+		return u.ShippingQuery.From
+	case u.PreCheckoutQuery != nil:
+		return u.PreCheckoutQuery.From
+	case u.PurchasedPaidMedia != nil:
+		return &u.PurchasedPaidMedia.From
+	case u.PollAnswer != nil:
+		return u.PollAnswer.User
+	case u.MessageReaction != nil:
+		return u.MessageReaction.User
+	case u.ChatJoinRequest != nil:
+		return u.ChatJoinRequest.From
+	case u.BusinessConnection != nil:
+		return &u.BusinessConnection.User
+	}
+	if m := u.MemberUpdate(); m != nil {
+		return m.From
+	}
+	if m := u.message(); m != nil {
+		return m.From
+	}
+	return nil
 }
 
 // CallbackQuery represents an incoming callback query from an inline keyboard.
@@ -159,6 +394,31 @@ func (u *ChatMemberUpdated) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON implements custom marshaling for ChatMemberUpdated, writing
+// OldChatMember and NewChatMember back with their status discriminator.
+func (u ChatMemberUpdated) MarshalJSON() ([]byte, error) {
+	type Alias ChatMemberUpdated
+	var aux struct {
+		Alias
+		OldChatMember json.RawMessage `json:"old_chat_member,omitempty"`
+		NewChatMember json.RawMessage `json:"new_chat_member,omitempty"`
+	}
+	aux.Alias = Alias(u)
+
+	var err error
+	if u.OldChatMember != nil {
+		if aux.OldChatMember, err = MarshalChatMember(u.OldChatMember); err != nil {
+			return nil, fmt.Errorf("old_chat_member: %w", err)
+		}
+	}
+	if u.NewChatMember != nil {
+		if aux.NewChatMember, err = MarshalChatMember(u.NewChatMember); err != nil {
+			return nil, fmt.Errorf("new_chat_member: %w", err)
+		}
+	}
+	return json.Marshal(aux)
+}
+
 // ChatInviteLink represents an invite link for a chat.
 type ChatInviteLink struct {
 	InviteLink              string `json:"invite_link"`
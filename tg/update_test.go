@@ -0,0 +1,105 @@
+package tg_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestUpdate_Effective(t *testing.T) {
+	const user = `{"id":7,"is_bot":false,"first_name":"A"}`
+	const chat = `{"id":-5,"type":"supergroup"}`
+	tests := []struct {
+		name    string
+		raw     string
+		message int   // ID of EffectiveMessage, 0 = nil
+		chatID  int64 // 0 = nil
+		userID  int64 // 0 = nil
+	}{
+		{"message", `{"update_id":1,"message":{"message_id":3,"date":0,"chat":` + chat + `,"from":` + user + `}}`, 3, -5, 7},
+		{"business_message", `{"update_id":1,"edited_business_message":{"message_id":4,"date":0,"chat":` + chat + `,"from":` + user + `}}`, 4, -5, 7},
+		{"callback", `{"update_id":1,"callback_query":{"id":"c","from":` + user + `,"chat_instance":"x","message":{"message_id":9,"date":0,"chat":` + chat + `}}}`, 9, -5, 7},
+		{"inline_query", `{"update_id":1,"inline_query":{"id":"q","from":` + user + `,"query":"","offset":""}}`, 0, 0, 7},
+		{"shipping_query", `{"update_id":1,"shipping_query":{"id":"s","from":` + user + `,"invoice_payload":"p","shipping_address":{}}}`, 0, 0, 7},
+		{"pre_checkout_query", `{"update_id":1,"pre_checkout_query":{"id":"p","from":` + user + `,"currency":"XTR","total_amount":1,"invoice_payload":"p"}}`, 0, 0, 7},
+		{"poll_answer", `{"update_id":1,"poll_answer":{"poll_id":"p","user":` + user + `,"option_ids":[0]}}`, 0, 0, 7},
+		{"chat_member", `{"update_id":1,"chat_member":{"chat":` + chat + `,"from":` + user + `,"date":0,"old_chat_member":{"status":"left","user":` + user + `},"new_chat_member":{"status":"member","user":` + user + `}}}`, 0, -5, 7},
+		{"reaction_count", `{"update_id":1,"message_reaction_count":{"chat":` + chat + `,"message_id":1,"date":0,"reactions":[]}}`, 0, -5, 0},
+		{"chat_boost", `{"update_id":1,"chat_boost":{"chat":` + chat + `,"boost":{"boost_id":"b","add_date":0,"expiration_date":0,"source":{"source":"premium","user":` + user + `}}}}`, 0, -5, 0},
+		{"removed_chat_boost", `{"update_id":1,"removed_chat_boost":{"chat":` + chat + `,"boost_id":"b","remove_date":0,"source":{"source":"premium","user":` + user + `}}}`, 0, -5, 0},
+		{"deleted_business_messages", `{"update_id":1,"deleted_business_messages":{"business_connection_id":"b","chat":` + chat + `,"message_ids":[1]}}`, 0, -5, 0},
+		{"poll", `{"update_id":1,"poll":{"id":"p","question":"Q?","options":[],"type":"regular"}}`, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var u tg.Update
+			require.NoError(t, json.Unmarshal([]byte(tt.raw), &u))
+
+			if msg := u.EffectiveMessage(); tt.message == 0 {
+				assert.Nil(t, msg)
+			} else {
+				require.NotNil(t, msg)
+				assert.Equal(t, tt.message, msg.MessageID)
+			}
+			if c := u.EffectiveChat(); tt.chatID == 0 {
+				assert.Nil(t, c)
+			} else {
+				require.NotNil(t, c)
+				assert.Equal(t, tt.chatID, c.ID)
+			}
+			if usr := u.EffectiveUser(); tt.userID == 0 {
+				assert.Nil(t, usr)
+			} else {
+				require.NotNil(t, usr)
+				assert.Equal(t, tt.userID, usr.ID)
+			}
+		})
+	}
+}
+
+func TestUpdate_Type(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{`{"update_id":1,"message":{"message_id":1,"date":0,"chat":{"id":1,"type":"private"}}}`, tg.UpdateTypeMessage},
+		{`{"update_id":2,"poll":{"id":"p1","question":"Q?","options":[],"type":"quiz"}}`, tg.UpdateTypePoll},
+		{`{"update_id":3,"poll_answer":{"poll_id":"p1","user":{"id":42,"is_bot":false,"first_name":"A"},"option_ids":[1]}}`, tg.UpdateTypePollAnswer},
+		{`{"update_id":4,"callback_query":{"id":"c","from":{"id":1,"is_bot":false,"first_name":"A"},"chat_instance":"x"}}`, tg.UpdateTypeCallbackQuery},
+		{`{"update_id":5}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			var u tg.Update
+			require.NoError(t, json.Unmarshal([]byte(tt.raw), &u))
+			assert.Equal(t, tt.want, u.Type())
+		})
+	}
+}
+
+func TestUpdate_Date(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want int64
+	}{
+		{"message", `{"update_id":1,"message":{"message_id":1,"date":100,"chat":{"id":1,"type":"private"}}}`, 100},
+		{"edited", `{"update_id":2,"edited_message":{"message_id":1,"date":100,"edit_date":160,"chat":{"id":1,"type":"private"}}}`, 160},
+		{"join_request", `{"update_id":3,"chat_join_request":{"chat":{"id":-1,"type":"group"},"from":{"id":1,"is_bot":false,"first_name":"A"},"user_chat_id":1,"date":200}}`, 200},
+		{"callback", `{"update_id":4,"callback_query":{"id":"c","from":{"id":1,"is_bot":false,"first_name":"A"},"chat_instance":"x"}}`, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var u tg.Update
+			require.NoError(t, json.Unmarshal([]byte(tt.raw), &u))
+			assert.Equal(t, tt.want, u.Date())
+		})
+	}
+}
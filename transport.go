@@ -0,0 +1,163 @@
+package galigo
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"sync/atomic"
+	"time"
+)
+
+// ================== Shared Transport ==================
+//
+// By default the sender and the polling receiver each build their own
+// http.Client. A Transport is one tuned connection pool that can be shared
+// by both, and by many bots, via WithTransport; it also counts connection
+// reuse and DNS/connect latency.
+
+// TransportConfig tunes a Transport. Zero fields use the defaults noted.
+type TransportConfig struct {
+	// MaxConnsPerHost limits connections to the Bot API host, including
+	// those in use (default 0 = unlimited).
+	MaxConnsPerHost int
+	// MaxIdleConnsPerHost is the number of idle connections kept per host
+	// (default 100; net/http's default of 2 forces redials under load).
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout closes idle connections after this long (default 90s).
+	IdleConnTimeout time.Duration
+	// ResponseHeaderTimeout bounds the wait for response headers. It must
+	// exceed the polling timeout, as getUpdates holds headers until updates
+	// arrive (default 90s).
+	ResponseHeaderTimeout time.Duration
+	// Proxy selects a proxy per request (nil = none), e.g.
+	// http.ProxyFromEnvironment or http.ProxyURL(u).
+	Proxy func(*http.Request) (*url.URL, error)
+	// DialContext dials connections (nil = a net.Dialer with a 10s timeout
+	// and 30s keep-alive).
+	DialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+	// TLSConfig is used for HTTPS (nil = TLS 1.2 minimum).
+	TLSConfig *tls.Config
+}
+
+// TransportStats are cumulative counters of a Transport.
+type TransportStats struct {
+	Requests    int64         // requests sent
+	NewConns    int64         // requests that opened a connection
+	ReusedConns int64         // requests served by a pooled connection
+	DNSLookups  int64         // DNS lookups performed
+	DNSTime     time.Duration // total time spent in DNS lookups
+	Dials       int64         // TCP connects completed
+	DialTime    time.Duration // total time spent connecting
+}
+
+// ReuseRatio returns the share of requests served by pooled connections.
+func (s TransportStats) ReuseRatio() float64 {
+	total := s.NewConns + s.ReusedConns
+	if total == 0 {
+		return 0
+	}
+	return float64(s.ReusedConns) / float64(total)
+}
+
+// Transport is an http.RoundTripper with a tuned connection pool and
+// metrics. It is safe for concurrent use.
+type Transport struct {
+	base *http.Transport
+
+	requests    atomic.Int64
+	newConns    atomic.Int64
+	reusedConns atomic.Int64
+	dnsLookups  atomic.Int64
+	dnsNanos    atomic.Int64
+	dials       atomic.Int64
+	dialNanos   atomic.Int64
+}
+
+var _ http.RoundTripper = (*Transport)(nil)
+
+// NewTransport returns a Transport configured by cfg.
+func NewTransport(cfg TransportConfig) *Transport {
+	if cfg.MaxIdleConnsPerHost <= 0 {
+		cfg.MaxIdleConnsPerHost = 100
+	}
+	if cfg.IdleConnTimeout <= 0 {
+		cfg.IdleConnTimeout = 90 * time.Second
+	}
+	if cfg.ResponseHeaderTimeout <= 0 {
+		cfg.ResponseHeaderTimeout = 90 * time.Second
+	}
+	if cfg.DialContext == nil {
+		cfg.DialContext = (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+	}
+	if cfg.TLSConfig == nil {
+		cfg.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return &Transport{
+		base: &http.Transport{
+			Proxy:                 cfg.Proxy,
+			DialContext:           cfg.DialContext,
+			MaxIdleConns:          2 * cfg.MaxIdleConnsPerHost,
+			MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+			MaxConnsPerHost:       cfg.MaxConnsPerHost,
+			IdleConnTimeout:       cfg.IdleConnTimeout,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+			ForceAttemptHTTP2:     true,
+			TLSClientConfig:       cfg.TLSConfig,
+		},
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	// Atomics: parallel dials (Happy Eyeballs) call the hooks concurrently.
+	var dnsStart, dialStart atomic.Int64
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				t.reusedConns.Add(1)
+			} else {
+				t.newConns.Add(1)
+			}
+		},
+		DNSStart: func(httptrace.DNSStartInfo) { dnsStart.Store(time.Now().UnixNano()) },
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.dnsLookups.Add(1)
+			t.dnsNanos.Add(time.Now().UnixNano() - dnsStart.Load())
+		},
+		ConnectStart: func(string, string) { dialStart.Store(time.Now().UnixNano()) },
+		ConnectDone: func(_, _ string, err error) {
+			if err == nil {
+				t.dials.Add(1)
+				t.dialNanos.Add(time.Now().UnixNano() - dialStart.Load())
+			}
+		},
+	}
+	ctx := httptrace.WithClientTrace(req.Context(), trace)
+	return t.base.RoundTrip(req.WithContext(ctx))
+}
+
+// CloseIdleConnections closes pooled connections not in use.
+func (t *Transport) CloseIdleConnections() {
+	t.base.CloseIdleConnections()
+}
+
+// Stats returns the counters accumulated so far.
+func (t *Transport) Stats() TransportStats {
+	return TransportStats{
+		Requests:    t.requests.Load(),
+		NewConns:    t.newConns.Load(),
+		ReusedConns: t.reusedConns.Load(),
+		DNSLookups:  t.dnsLookups.Load(),
+		DNSTime:     time.Duration(t.dnsNanos.Load()),
+		Dials:       t.dials.Load(),
+		DialTime:    time.Duration(t.dialNanos.Load()),
+	}
+}
@@ -0,0 +1,63 @@
+package galigo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prilive-com/galigo/tg"
+)
+
+func TestTransport_CountsReuse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	transport := NewTransport(TransportConfig{})
+	client := &http.Client{Transport: transport}
+	for range 3 {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	stats := transport.Stats()
+	assert.Equal(t, int64(3), stats.Requests)
+	assert.Equal(t, int64(1), stats.NewConns)
+	assert.Equal(t, int64(2), stats.ReusedConns)
+	assert.Equal(t, int64(1), stats.Dials)
+	assert.InDelta(t, 2.0/3, stats.ReuseRatio(), 0.001)
+
+	transport.CloseIdleConnections()
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, int64(2), transport.Stats().NewConns, "redial after idle close")
+}
+
+func TestTransport_SharedBySenderAndPoller(t *testing.T) {
+	server := newManagerAPI(t)
+	transport := NewTransport(TransportConfig{MaxConnsPerHost: 4})
+
+	bot, err := New(managerTokenA, WithBaseURL(server.URL), WithPolling(1, 10), WithTransport(transport))
+	require.NoError(t, err)
+	defer bot.Close()
+	require.NoError(t, bot.Start(context.Background()))
+
+	_, err = bot.SendMessage(context.Background(), tg.ChatID(1), "hi")
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return transport.Stats().Requests >= 2 }, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestTransportStats_ReuseRatioEmpty(t *testing.T) {
+	assert.Zero(t, TransportStats{}.ReuseRatio())
+}
@@ -0,0 +1,82 @@
+package galigo
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/prilive-com/galigo/internal/version"
+)
+
+// BotAPIVersion is the Telegram Bot API version this release targets.
+const BotAPIVersion = version.BotAPIVersion
+
+// Version returns the galigo module version compiled into the binary,
+// e.g. "v1.4.0". It is "devel" when galigo itself is the main module.
+func Version() string {
+	return version.Version()
+}
+
+// BuildInfo describes the running binary, for logs, health endpoints and
+// bug reports.
+type BuildInfo struct {
+	Version       string    // galigo module version
+	BotAPIVersion string    // Bot API version galigo targets
+	GoVersion     string    // Toolchain that built the binary, e.g. "go1.25.1"
+	MainModule    string    // Path of the application's main module
+	VCSRevision   string    // Commit of the main module, if stamped by go build
+	VCSTime       time.Time // Commit time, if stamped
+	VCSModified   bool      // Working tree had uncommitted changes
+}
+
+// ReadBuildInfo returns version and build metadata for the running binary.
+// VCS fields are empty unless the binary was built with VCS stamping
+// (the go build default inside a repository).
+func ReadBuildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:       Version(),
+		BotAPIVersion: BotAPIVersion,
+	}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	info.GoVersion = bi.GoVersion
+	info.MainModule = bi.Main.Path
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			info.VCSRevision = s.Value
+		case "vcs.time":
+			info.VCSTime, _ = time.Parse(time.RFC3339, s.Value)
+		case "vcs.modified":
+			info.VCSModified = s.Value == "true"
+		}
+	}
+	return info
+}
+
+// String formats the build info on one line, e.g.
+// "galigo v1.4.0 (Bot API 9.5) go1.25.1 example.com/bot@3f2a9c1d0e4b".
+func (b BuildInfo) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "galigo %s (Bot API %s)", b.Version, b.BotAPIVersion)
+	if b.GoVersion != "" {
+		sb.WriteString(" " + b.GoVersion)
+	}
+	if b.MainModule != "" {
+		sb.WriteString(" " + b.MainModule)
+		if b.VCSRevision != "" {
+			rev := b.VCSRevision
+			if len(rev) > 12 {
+				rev = rev[:12]
+			}
+			sb.WriteString("@" + rev)
+			if b.VCSModified {
+				sb.WriteString("+dirty")
+			}
+		}
+	}
+	return sb.String()
+}
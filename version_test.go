@@ -0,0 +1,42 @@
+package galigo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/prilive-com/galigo/internal/version"
+)
+
+func TestVersion(t *testing.T) {
+	assert.NotEmpty(t, Version())
+	assert.Equal(t, "9.5", BotAPIVersion)
+
+	ua := version.UserAgent()
+	assert.True(t, strings.HasPrefix(ua, "galigo/"+Version()+" "), ua)
+	assert.Contains(t, ua, "Bot API "+BotAPIVersion)
+}
+
+func TestReadBuildInfo(t *testing.T) {
+	info := ReadBuildInfo()
+	assert.Equal(t, Version(), info.Version)
+	assert.Equal(t, BotAPIVersion, info.BotAPIVersion)
+	assert.True(t, strings.HasPrefix(info.GoVersion, "go"), info.GoVersion)
+}
+
+func TestBuildInfo_String(t *testing.T) {
+	info := BuildInfo{
+		Version:       "v1.4.0",
+		BotAPIVersion: "9.5",
+		GoVersion:     "go1.25.1",
+		MainModule:    "example.com/bot",
+		VCSRevision:   "3f2a9c1d0e4b5a6978",
+		VCSTime:       time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		VCSModified:   true,
+	}
+	assert.Equal(t, "galigo v1.4.0 (Bot API 9.5) go1.25.1 example.com/bot@3f2a9c1d0e4b+dirty", info.String())
+
+	assert.Equal(t, "galigo devel (Bot API 9.5)", BuildInfo{Version: "devel", BotAPIVersion: "9.5"}.String())
+}